@@ -0,0 +1,228 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission limits the concurrent invocations of the runners, so
+// that a burst of users does not exhaust the model quota or the memory.
+//
+// A [Controller] is installed on a runner with runner.Config.Admission:
+//
+//	controller := admission.New(admission.Config{
+//		MaxConcurrent: 20,
+//		QueueSize:     100,
+//		Overflow:      admission.ShedLowest,
+//	})
+//	r, err := runner.New(runner.Config{..., Admission: controller})
+//
+// The invocations over the limit wait in a queue ordered by priority, then
+// by arrival. When the queue is full, the invocation is rejected with a
+// [RejectedError], served as 429 Too Many Requests with a Retry-After header
+// by the REST server.
+package admission
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OverflowPolicy defines what happens to an invocation arriving when the
+// queue is full.
+type OverflowPolicy string
+
+const (
+	// Reject rejects the arriving invocation.
+	Reject OverflowPolicy = "reject"
+	// ShedLowest rejects the queued invocation of the lowest priority, the
+	// last arrived among equals, if its priority is lower than the one of the
+	// arriving invocation, which takes its place. Otherwise the arriving
+	// invocation is rejected.
+	ShedLowest OverflowPolicy = "shed_lowest"
+)
+
+// Config configures a [Controller].
+type Config struct {
+	// MaxConcurrent is the maximum number of invocations running at once.
+	// Zero means no limit.
+	MaxConcurrent int
+	// QueueSize is the maximum number of invocations waiting to run. Zero
+	// means the invocations over MaxConcurrent are rejected right away.
+	QueueSize int // optional
+	// Overflow is the policy when the queue is full. Defaults to Reject.
+	Overflow OverflowPolicy // optional
+	// RetryAfter is the delay suggested to the rejected callers. Defaults to
+	// 1 second.
+	RetryAfter time.Duration // optional
+	// Priority returns the priority of the invocations of a user, higher
+	// runs first, e.g. by plan of the user. It is used by the runners when
+	// Run is not given runner.WithPriority. Defaults to 0 for all.
+	Priority func(ctx context.Context, appName, userID string) int // optional
+}
+
+// RejectedError is returned when an invocation is not admitted.
+type RejectedError struct {
+	// RetryAfter is the delay suggested before retrying.
+	RetryAfter time.Duration
+	// Shed is true if the invocation was queued, then shed for an invocation
+	// of higher priority.
+	Shed bool
+}
+
+func (e *RejectedError) Error() string {
+	if e.Shed {
+		return fmt.Sprintf("invocation shed from the queue for a higher priority one, retry after %s", e.RetryAfter)
+	}
+	return fmt.Sprintf("too many invocations, retry after %s", e.RetryAfter)
+}
+
+// Controller admits the invocations up to the limits of its config. It is
+// safe for concurrent use and can be shared by several runners.
+type Controller struct {
+	cfg Config
+
+	mu      sync.Mutex
+	running int
+	queue   []*waiter
+	seq     uint64
+}
+
+type waiter struct {
+	priority int
+	seq      uint64
+	// admitted receives nil when the invocation is admitted, or the error
+	// it is shed with.
+	admitted chan error
+}
+
+// New creates a Controller.
+func New(cfg Config) *Controller {
+	if cfg.Overflow == "" {
+		cfg.Overflow = Reject
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = time.Second
+	}
+	return &Controller{cfg: cfg}
+}
+
+// Priority returns the priority of the invocations of the user, see
+// Config.Priority.
+func (c *Controller) Priority(ctx context.Context, appName, userID string) int {
+	if c.cfg.Priority == nil {
+		return 0
+	}
+	return c.cfg.Priority(ctx, appName, userID)
+}
+
+// Acquire waits until an invocation of the given priority is admitted. It
+// returns a *RejectedError if the invocation is not, or the cause of ctx if
+// ctx is done first. The returned function must be called when the
+// invocation finishes.
+func (c *Controller) Acquire(ctx context.Context, priority int) (release func(), err error) {
+	c.mu.Lock()
+	if c.cfg.MaxConcurrent <= 0 || c.running < c.cfg.MaxConcurrent && len(c.queue) == 0 {
+		c.running++
+		c.mu.Unlock()
+		return c.releaseFunc(), nil
+	}
+	w := &waiter{priority: priority, seq: c.seq, admitted: make(chan error, 1)}
+	c.seq++
+	switch {
+	case len(c.queue) < c.cfg.QueueSize:
+	case c.cfg.Overflow == ShedLowest && len(c.queue) > 0 && c.queue[c.lowest()].priority < priority:
+		shed := c.remove(c.lowest())
+		shed.admitted <- &RejectedError{RetryAfter: c.cfg.RetryAfter, Shed: true}
+	default:
+		c.mu.Unlock()
+		return nil, &RejectedError{RetryAfter: c.cfg.RetryAfter}
+	}
+	c.queue = append(c.queue, w)
+	c.mu.Unlock()
+
+	select {
+	case err := <-w.admitted:
+		if err != nil {
+			return nil, err
+		}
+		return c.releaseFunc(), nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		for i, queued := range c.queue {
+			if queued == w {
+				c.remove(i)
+				c.mu.Unlock()
+				return nil, context.Cause(ctx)
+			}
+		}
+		c.mu.Unlock()
+		// The invocation was admitted or shed concurrently.
+		if err := <-w.admitted; err == nil {
+			c.release()
+		}
+		return nil, context.Cause(ctx)
+	}
+}
+
+// Stats returns the number of running and queued invocations.
+func (c *Controller) Stats() (running, queued int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.running, len(c.queue)
+}
+
+func (c *Controller) releaseFunc() func() {
+	var once sync.Once
+	return func() { once.Do(c.release) }
+}
+
+// release hands the slot of a finished invocation to the next queued one.
+func (c *Controller) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.queue) == 0 {
+		c.running--
+		return
+	}
+	c.remove(c.highest()).admitted <- nil
+}
+
+// highest returns the index of the queued invocation to run next: the
+// highest priority, the first arrived among equals.
+func (c *Controller) highest() int {
+	best := 0
+	for i, w := range c.queue {
+		if b := c.queue[best]; w.priority > b.priority || w.priority == b.priority && w.seq < b.seq {
+			best = i
+		}
+	}
+	return best
+}
+
+// lowest returns the index of the queued invocation to shed first: the
+// lowest priority, the last arrived among equals.
+func (c *Controller) lowest() int {
+	worst := 0
+	for i, w := range c.queue {
+		if b := c.queue[worst]; w.priority < b.priority || w.priority == b.priority && w.seq > b.seq {
+			worst = i
+		}
+	}
+	return worst
+}
+
+func (c *Controller) remove(i int) *waiter {
+	w := c.queue[i]
+	c.queue = append(c.queue[:i], c.queue[i+1:]...)
+	return w
+}
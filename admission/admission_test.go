@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/admission"
+)
+
+// queue starts an Acquire of each priority, in order, and waits for them to
+// be queued. The result of each is sent to results with its priority.
+func queue(t *testing.T, c *admission.Controller, results chan<- result, priorities ...int) {
+	t.Helper()
+	_, queued := c.Stats()
+	for _, p := range priorities {
+		go func() {
+			release, err := c.Acquire(t.Context(), p)
+			results <- result{priority: p, release: release, err: err}
+		}()
+		queued++
+		waitFor(t, func() bool { _, q := c.Stats(); return q >= queued })
+	}
+}
+
+type result struct {
+	priority int
+	release  func()
+	err      error
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestController_Priority(t *testing.T) {
+	c := admission.New(admission.Config{MaxConcurrent: 1, QueueSize: 3})
+	release, err := c.Acquire(t.Context(), 0)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	results := make(chan result, 3)
+	queue(t, c, results, 1, 5, 1)
+
+	var order []int
+	for range 3 {
+		release()
+		r := <-results
+		if r.err != nil {
+			t.Fatalf("Acquire() error = %v", r.err)
+		}
+		order = append(order, r.priority)
+		release = r.release
+	}
+	release()
+	if diff := cmp.Diff([]int{5, 1, 1}, order); diff != "" {
+		t.Errorf("admission order mismatch (-want +got):\n%s", diff)
+	}
+	if running, queued := c.Stats(); running != 0 || queued != 0 {
+		t.Errorf("Stats() = %d, %d, want 0, 0", running, queued)
+	}
+}
+
+func TestController_Overflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		overflow admission.OverflowPolicy
+		priority int
+		// wantShed is the priority of the queued invocation shed, or -1 if
+		// the arriving one is rejected.
+		wantShed int
+	}{
+		{name: "reject", overflow: admission.Reject, priority: 9, wantShed: -1},
+		{name: "shed lowest", overflow: admission.ShedLowest, priority: 9, wantShed: 1},
+		{name: "shed lowest, arriving lower", overflow: admission.ShedLowest, priority: 0, wantShed: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := admission.New(admission.Config{MaxConcurrent: 1, QueueSize: 2, Overflow: tt.overflow, RetryAfter: time.Minute})
+			release, err := c.Acquire(t.Context(), 0)
+			if err != nil {
+				t.Fatalf("Acquire() error = %v", err)
+			}
+			defer release()
+			results := make(chan result, 3)
+			queue(t, c, results, 1, 2)
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+			var rejected *admission.RejectedError
+			if tt.wantShed < 0 {
+				if _, err := c.Acquire(ctx, tt.priority); !errors.As(err, &rejected) || rejected.Shed || rejected.RetryAfter != time.Minute {
+					t.Errorf("Acquire() error = %v, want a RejectedError", err)
+				}
+				return
+			}
+			go c.Acquire(ctx, tt.priority)
+			r := <-results
+			if r.priority != tt.wantShed || !errors.As(r.err, &rejected) || !rejected.Shed {
+				t.Errorf("Acquire(%d) = %v, want shed", r.priority, r.err)
+			}
+			if _, queued := c.Stats(); queued != 2 {
+				t.Errorf("%d queued, want 2", queued)
+			}
+		})
+	}
+}
+
+func TestController_Cancel(t *testing.T) {
+	c := admission.New(admission.Config{MaxConcurrent: 1, QueueSize: 1})
+	release, err := c.Acquire(t.Context(), 0)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.Acquire(ctx, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	release()
+	if running, queued := c.Stats(); running != 0 || queued != 0 {
+		t.Errorf("Stats() = %d, %d, want 0, 0", running, queued)
+	}
+}
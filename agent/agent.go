@@ -18,12 +18,14 @@ import (
 	"context"
 	"fmt"
 	"iter"
+	"runtime/debug"
 
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/artifact"
 	agentinternal "google.golang.org/adk/internal/agent"
+	"google.golang.org/adk/internal/logging"
 	"google.golang.org/adk/internal/plugininternal/plugincontext"
 	"google.golang.org/adk/internal/telemetry"
 	"google.golang.org/adk/memory"
@@ -167,9 +169,19 @@ func (a *agent) Run(ctx InvocationContext) iter.Seq2[*session.Event, error] {
 			})
 		})
 		defer endSpan()
+		// A panic of the consumer, raised while yielding, is not ours to
+		// recover: it must keep unwinding through the iterator.
+		yielding := false
+		consumer := yield
+		yield = func(event *session.Event, err error) bool {
+			yielding = true
+			ok := consumer(event, err)
+			yielding = false
+			return ok
+		}
 		// TODO: verify&update the setup here. Should we branch etc.
 		ctx := &invocationContext{
-			Context:   ctx.WithContext(spanCtx),
+			Context:   logging.With(ctx.WithContext(spanCtx), logging.AgentNameKey, a.Name()),
 			agent:     a,
 			artifacts: ctx.Artifacts(),
 			memory:    ctx.Memory(),
@@ -180,7 +192,17 @@ func (a *agent) Run(ctx InvocationContext) iter.Seq2[*session.Event, error] {
 			userContent:   ctx.UserContent(),
 			runConfig:     ctx.RunConfig(),
 			endInvocation: ctx.Ended(),
+
+			transcriptionCache: ctx.TranscriptionCache(),
 		}
+		defer func() {
+			if yielding {
+				return
+			}
+			if r := recover(); r != nil {
+				yield(a.panicEvent(ctx, r, debug.Stack()), nil)
+			}
+		}()
 		event, err := runBeforeAgentCallbacks(ctx)
 		if event != nil || err != nil {
 			if !yield(event, err) {
@@ -196,6 +218,11 @@ func (a *agent) Run(ctx InvocationContext) iter.Seq2[*session.Event, error] {
 			if event != nil && event.Author == "" {
 				event.Author = getAuthorForEvent(ctx, event)
 			}
+			// The events of the agents run in a branch, e.g. by a parallel
+			// agent, must not be seen by the agents of the other branches.
+			if event != nil && event.Branch == "" {
+				event.Branch = ctx.Branch()
+			}
 			if !yield(event, err) {
 				return
 			}
@@ -212,6 +239,27 @@ func (a *agent) Run(ctx InvocationContext) iter.Seq2[*session.Event, error] {
 	}
 }
 
+// panicEvent ends the invocation after a panic of the agent or its callbacks
+// and returns the error event reporting it.
+func (a *agent) panicEvent(ctx InvocationContext, r any, stack []byte) *session.Event {
+	err := &PanicError{Source: fmt.Sprintf("agent %q", a.Name()), Value: r, Stack: stack}
+	logging.FromContext(ctx).ErrorContext(ctx, "Agent panicked", "error", err, "stack", string(stack))
+	ctx.EndInvocation()
+
+	event := session.NewEventWithContext(ctx, ctx.InvocationID())
+	event.Author = a.Name()
+	event.Branch = ctx.Branch()
+	event.LLMResponse = model.LLMResponse{
+		ErrorCode:    ErrorCodePanic,
+		ErrorMessage: err.Error(),
+		TurnComplete: true,
+	}
+	if agentinternal.PanicStackTraces(ctx) {
+		event.CustomMetadata = map[string]any{PanicStackKey: string(stack)}
+	}
+	return event
+}
+
 func (a *agent) internal() *agent {
 	return a
 }
@@ -242,7 +290,7 @@ func runBeforeAgentCallbacks(ctx InvocationContext) (*session.Event, error) {
 			return nil, fmt.Errorf("failed to run plugin before agent callback: %w", err)
 		}
 		if content != nil {
-			event := session.NewEvent(ctx.InvocationID())
+			event := session.NewEventWithContext(ctx, ctx.InvocationID())
 			event.LLMResponse = model.LLMResponse{
 				Content: content,
 			}
@@ -263,7 +311,7 @@ func runBeforeAgentCallbacks(ctx InvocationContext) (*session.Event, error) {
 			continue
 		}
 
-		event := session.NewEvent(ctx.InvocationID())
+		event := session.NewEventWithContext(ctx, ctx.InvocationID())
 		event.LLMResponse = model.LLMResponse{
 			Content: content,
 		}
@@ -276,7 +324,7 @@ func runBeforeAgentCallbacks(ctx InvocationContext) (*session.Event, error) {
 
 	// check if has delta create event with it
 	if len(callbackCtx.actions.StateDelta) > 0 {
-		event := session.NewEvent(ctx.InvocationID())
+		event := session.NewEventWithContext(ctx, ctx.InvocationID())
 		event.Author = agent.Name()
 		event.Branch = ctx.Branch()
 		event.Actions = *callbackCtx.actions
@@ -304,7 +352,7 @@ func runAfterAgentCallbacks(ctx InvocationContext) (*session.Event, error) {
 			return nil, fmt.Errorf("failed to run plugin after agent callback: %w", err)
 		}
 		if content != nil {
-			event := session.NewEvent(ctx.InvocationID())
+			event := session.NewEventWithContext(ctx, ctx.InvocationID())
 			event.LLMResponse = model.LLMResponse{
 				Content: content,
 			}
@@ -324,7 +372,7 @@ func runAfterAgentCallbacks(ctx InvocationContext) (*session.Event, error) {
 			continue
 		}
 
-		event := session.NewEvent(ctx.InvocationID())
+		event := session.NewEventWithContext(ctx, ctx.InvocationID())
 		event.LLMResponse = model.LLMResponse{
 			Content: newContent,
 		}
@@ -338,7 +386,7 @@ func runAfterAgentCallbacks(ctx InvocationContext) (*session.Event, error) {
 
 	// check if has delta create event with it
 	if len(callbackCtx.actions.StateDelta) > 0 {
-		event := session.NewEvent(ctx.InvocationID())
+		event := session.NewEventWithContext(ctx, ctx.InvocationID())
 		event.Author = agent.Name()
 		event.Branch = ctx.Branch()
 		event.Actions = *callbackCtx.actions
@@ -438,6 +486,8 @@ type invocationContext struct {
 	userContent   *genai.Content
 	runConfig     *RunConfig
 	endInvocation bool
+
+	transcriptionCache *TranscriptionCache
 }
 
 func (c *invocationContext) Agent() Agent {
@@ -472,12 +522,20 @@ func (c *invocationContext) RunConfig() *RunConfig {
 	return c.runConfig
 }
 
+func (c *invocationContext) TranscriptionCache() *TranscriptionCache {
+	return c.transcriptionCache
+}
+
 func (c *invocationContext) EndInvocation() {
 	c.endInvocation = true
 }
 
+func (c *invocationContext) Cancel(reason string) {
+	CancelInvocation(c, reason)
+}
+
 func (c *invocationContext) Ended() bool {
-	return c.endInvocation
+	return c.endInvocation || IsInvocationCancelled(c)
 }
 
 func (c *invocationContext) WithContext(ctx context.Context) InvocationContext {
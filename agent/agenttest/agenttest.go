@@ -0,0 +1,191 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package agenttest provides golden-transcript tests of agents: an agent is
+// run on scripted user turns, typically with a ReplayModel, and the events
+// it produces are compared with a transcript stored in a golden file.
+//
+//	func TestWeatherAgent(t *testing.T) {
+//		transcript := agenttest.Run(t, agenttest.Config{Agent: newWeatherAgent()}, "Weather in Paris?")
+//		agenttest.CompareGolden(t, "testdata/weather.golden.json", transcript)
+//	}
+//
+// The golden files are written, instead of compared, when the tests are run
+// with the -agenttest.update flag.
+//
+// Harness runs an agent turn by turn, with assertions on the events of the
+// turns.
+package agenttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/session"
+)
+
+var update = flag.Bool("agenttest.update", false, "write the golden transcripts of agenttest instead of comparing them")
+
+// Config configures the run of an agent under test.
+type Config struct {
+	// Agent is the root agent of the run.
+	Agent agent.Agent
+	// State is the initial state of the session.
+	State map[string]any // optional
+	// RunConfig is the run config of every turn.
+	RunConfig agent.RunConfig // optional
+}
+
+// Transcript is the normalized event stream of the turns of a run.
+type Transcript struct {
+	Turns []Turn `json:"turns"`
+}
+
+// Turn is a user message and the events it produced.
+type Turn struct {
+	User   string  `json:"user"`
+	Events []Event `json:"events"`
+}
+
+// Event is an event normalized for comparison: its IDs and timestamps are
+// dropped and the function call IDs are numbered in order of appearance.
+type Event struct {
+	Author          string         `json:"author,omitempty"`
+	Content         *genai.Content `json:"content,omitempty"`
+	StateDelta      map[string]any `json:"stateDelta,omitempty"`
+	TransferToAgent string         `json:"transferToAgent,omitempty"`
+	Escalate        bool           `json:"escalate,omitempty"`
+	ErrorCode       string         `json:"errorCode,omitempty"`
+	ErrorMessage    string         `json:"errorMessage,omitempty"`
+	// Error is the error yielded by the run in place of an event.
+	Error string `json:"error,omitempty"`
+}
+
+// Run runs the agent on the user turns, in order, in a new session of an
+// in-memory session service, and returns the transcript of the complete
+// events. The IDs and timestamps are generated deterministically, see
+// session.Providers.
+func Run(t testing.TB, cfg Config, turns ...string) *Transcript {
+	t.Helper()
+	h := newHarness(t, cfg)
+	n := &normalizer{callIDs: map[string]string{}}
+	transcript := &Transcript{}
+	for _, text := range turns {
+		turn := Turn{User: text, Events: []Event{}}
+		for event, err := range h.run(text) {
+			if err != nil {
+				turn.Events = append(turn.Events, Event{Error: err.Error()})
+				continue
+			}
+			normalized, err := n.event(event)
+			if err != nil {
+				t.Fatalf("failed to normalize event %s: %v", event.ID, err)
+			}
+			turn.Events = append(turn.Events, normalized)
+		}
+		transcript.Turns = append(transcript.Turns, turn)
+	}
+	return transcript
+}
+
+// CompareGolden compares the transcript with the golden file at path and
+// reports the differences. With the -agenttest.update flag, it writes the
+// transcript to the file instead.
+func CompareGolden(t testing.TB, path string, got *Transcript) {
+	t.Helper()
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal the transcript: %v", err)
+	}
+	data = append(data, '\n')
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("failed to write the golden transcript: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the golden transcript, run the test with -agenttest.update to write it: %v", err)
+	}
+	if bytes.Equal(want, data) {
+		return
+	}
+	diff := cmp.Diff(strings.Split(string(want), "\n"), strings.Split(string(data), "\n"))
+	t.Errorf("transcript differs from %s (-want +got):\n%s\nRun the test with -agenttest.update to accept the changes.", path, diff)
+}
+
+// normalizer normalizes the events of a run.
+type normalizer struct {
+	// callIDs maps the function call IDs to their normalized IDs.
+	callIDs map[string]string
+}
+
+func (n *normalizer) event(event *session.Event) (Event, error) {
+	normalized := Event{
+		Author:          event.Author,
+		StateDelta:      event.Actions.StateDelta,
+		TransferToAgent: event.Actions.TransferToAgent,
+		Escalate:        event.Actions.Escalate,
+		ErrorCode:       event.ErrorCode,
+		ErrorMessage:    event.ErrorMessage,
+	}
+	if event.Content == nil {
+		return normalized, nil
+	}
+	// A copy, not to modify the event in the session.
+	data, err := json.Marshal(event.Content)
+	if err != nil {
+		return Event{}, err
+	}
+	var content genai.Content
+	if err := json.Unmarshal(data, &content); err != nil {
+		return Event{}, err
+	}
+	for _, part := range content.Parts {
+		if part.FunctionCall != nil {
+			part.FunctionCall.ID = n.callID(part.FunctionCall.ID)
+		}
+		if part.FunctionResponse != nil {
+			part.FunctionResponse.ID = n.callID(part.FunctionResponse.ID)
+		}
+	}
+	normalized.Content = &content
+	return normalized, nil
+}
+
+func (n *normalizer) callID(id string) string {
+	if id == "" {
+		return ""
+	}
+	if normalized, ok := n.callIDs[id]; ok {
+		return normalized
+	}
+	normalized := fmt.Sprintf("call-%d", len(n.callIDs)+1)
+	n.callIDs[id] = normalized
+	return normalized
+}
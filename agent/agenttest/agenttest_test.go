@@ -0,0 +1,94 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agenttest_test
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/agenttest"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func newWeatherAgent(t *testing.T, answer string) agent.Agent {
+	t.Helper()
+	type args struct {
+		City string `json:"city"`
+	}
+	getWeather, err := functiontool.New(functiontool.Config{Name: "get_weather", Description: "returns the weather"}, func(ctx tool.Context, a args) (map[string]any, error) {
+		if err := ctx.State().Set("last_city", a.City); err != nil {
+			return nil, err
+		}
+		return map[string]any{"weather": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name: "weather_agent",
+		Model: agenttest.NewReplayModel(
+			genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": "Paris"}, genai.RoleModel),
+			genai.NewContentFromText(answer, genai.RoleModel),
+			genai.NewContentFromText("You're welcome.", genai.RoleModel),
+		),
+		Tools: []tool.Tool{getWeather},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestCompareGolden(t *testing.T) {
+	golden := filepath.Join("testdata", "weather.golden.json")
+	transcript := agenttest.Run(t, agenttest.Config{Agent: newWeatherAgent(t, "It is sunny in Paris.")}, "Weather in Paris?", "Thanks!")
+	agenttest.CompareGolden(t, golden, transcript)
+
+	if flag.Lookup("agenttest.update").Value.String() == "true" {
+		return
+	}
+	// A change of behavior is reported.
+	changed := agenttest.Run(t, agenttest.Config{Agent: newWeatherAgent(t, "It is raining in Paris.")}, "Weather in Paris?", "Thanks!")
+	rec := &recorder{TB: t}
+	agenttest.CompareGolden(rec, golden, changed)
+	if len(rec.errors) != 1 {
+		t.Errorf("CompareGolden() reported %d errors, want the changed answer reported", len(rec.errors))
+	}
+}
+
+func TestRun_ReplayExhausted(t *testing.T) {
+	transcript := agenttest.Run(t, agenttest.Config{Agent: newWeatherAgent(t, "It is sunny in Paris.")}, "Weather in Paris?", "Thanks!", "Bye!")
+	events := transcript.Turns[2].Events
+	if len(events) == 0 || events[len(events)-1].Error == "" {
+		t.Errorf("last turn events = %+v, want the error of the replay model", events)
+	}
+}
+
+// recorder records the errors reported by CompareGolden.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agenttest
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+// Harness runs an agent in a session of in-memory services, for tests:
+//
+//	h := agenttest.NewHarness(t, weatherAgent) // with a ReplayModel
+//	turn := h.Send("Weather in Paris?")
+//	turn.AssertToolCalled("get_weather", map[string]any{"city": "Paris"})
+//	turn.AssertFinalTextContains("sunny")
+//
+// The IDs and timestamps are generated deterministically, see
+// session.Providers.
+type Harness struct {
+	// Runner runs the agent, in the session of SessionID.
+	Runner          *runner.Runner
+	SessionService  session.Service
+	ArtifactService artifact.Service
+	UserID          string
+	SessionID       string
+	// RunConfig is the run config of the turns sent with Send.
+	RunConfig agent.RunConfig
+
+	t testing.TB
+}
+
+// NewHarness returns a harness running the agent in a new session.
+func NewHarness(t testing.TB, a agent.Agent) *Harness {
+	t.Helper()
+	return newHarness(t, Config{Agent: a})
+}
+
+func newHarness(t testing.TB, cfg Config) *Harness {
+	t.Helper()
+	h := &Harness{
+		SessionService:  session.InMemoryService(),
+		ArtifactService: artifact.InMemoryService(),
+		UserID:          "user",
+		SessionID:       "session",
+		RunConfig:       cfg.RunConfig,
+		t:               t,
+	}
+	var err error
+	h.Runner, err = runner.New(runner.Config{
+		AppName:         "agenttest",
+		Agent:           cfg.Agent,
+		SessionService:  h.SessionService,
+		ArtifactService: h.ArtifactService,
+		Providers: session.Providers{
+			NewID: session.SequentialIDs("id"),
+			Now:   session.SteppingClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Second),
+		},
+	})
+	if err != nil {
+		t.Fatalf("runner.New() error = %v", err)
+	}
+	if _, err := h.SessionService.Create(t.Context(), &session.CreateRequest{AppName: "agenttest", UserID: h.UserID, SessionID: h.SessionID, State: cfg.State}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return h
+}
+
+// run runs a turn and yields its complete events.
+func (h *Harness) run(text string) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		for event, err := range h.Runner.Run(h.t.Context(), h.UserID, h.SessionID, genai.NewContentFromText(text, genai.RoleUser), h.RunConfig) {
+			if err == nil && event.Partial {
+				continue
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// Send runs a turn on the user message and returns its complete events. The
+// test fails if the run yields an error.
+func (h *Harness) Send(text string) *TurnResult {
+	h.t.Helper()
+	result := &TurnResult{t: h.t}
+	for event, err := range h.run(text) {
+		if err != nil {
+			h.t.Fatalf("Run(%q) error = %v", text, err)
+		}
+		result.Events = append(result.Events, event)
+	}
+	return result
+}
+
+// State returns the current state of the session.
+func (h *Harness) State() map[string]any {
+	h.t.Helper()
+	resp, err := h.SessionService.Get(h.t.Context(), &session.GetRequest{AppName: "agenttest", UserID: h.UserID, SessionID: h.SessionID})
+	if err != nil {
+		h.t.Fatalf("Get() error = %v", err)
+	}
+	state := map[string]any{}
+	for key, value := range resp.Session.State().All() {
+		state[key] = value
+	}
+	return state
+}
+
+// TurnResult holds the complete events of a turn sent with Harness.Send.
+type TurnResult struct {
+	Events []*session.Event
+
+	t testing.TB
+}
+
+// FinalText returns the text of the last final response of the turn.
+func (r *TurnResult) FinalText() string {
+	for i := len(r.Events) - 1; i >= 0; i-- {
+		event := r.Events[i]
+		if event.Content == nil || !event.IsFinalResponse() {
+			continue
+		}
+		var text strings.Builder
+		for _, part := range event.Content.Parts {
+			if !part.Thought {
+				text.WriteString(part.Text)
+			}
+		}
+		return text.String()
+	}
+	return ""
+}
+
+// AssertToolCalled reports an error unless a tool with the name was called
+// during the turn with the given args. Only the given args are compared, nil
+// matches any args.
+func (r *TurnResult) AssertToolCalled(name string, args map[string]any) {
+	r.t.Helper()
+	var calls []string
+	for _, event := range r.Events {
+		for _, call := range utils.FunctionCalls(event.Content) {
+			if call.Name != name {
+				continue
+			}
+			if matchArgs(call.Args, args) {
+				return
+			}
+			calls = append(calls, fmt.Sprint(call.Args))
+		}
+	}
+	if len(calls) == 0 {
+		r.t.Errorf("tool %s was not called", name)
+		return
+	}
+	r.t.Errorf("tool %s was called with %v, want args %v", name, calls, args)
+}
+
+func matchArgs(got, want map[string]any) bool {
+	for key, value := range want {
+		if !cmp.Equal(got[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// AssertStateSet reports an error unless the state key was last set to the
+// value during the turn.
+func (r *TurnResult) AssertStateSet(key string, value any) {
+	r.t.Helper()
+	got, ok := any(nil), false
+	for _, event := range r.Events {
+		if v, set := event.Actions.StateDelta[key]; set {
+			got, ok = v, true
+		}
+	}
+	if !ok {
+		r.t.Errorf("state %q was not set", key)
+		return
+	}
+	if !cmp.Equal(got, value) {
+		r.t.Errorf("state %q = %v, want %v", key, got, value)
+	}
+}
+
+// AssertFinalTextContains reports an error unless the text of the final
+// response of the turn contains substr.
+func (r *TurnResult) AssertFinalTextContains(substr string) {
+	r.t.Helper()
+	if got := r.FinalText(); !strings.Contains(got, substr) {
+		r.t.Errorf("final text = %q, want it to contain %q", got, substr)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agenttest_test
+
+import (
+	"testing"
+
+	"google.golang.org/adk/agent/agenttest"
+)
+
+func TestHarness(t *testing.T) {
+	h := agenttest.NewHarness(t, newWeatherAgent(t, "It is sunny in Paris."))
+	turn := h.Send("Weather in Paris?")
+	turn.AssertToolCalled("get_weather", map[string]any{"city": "Paris"})
+	turn.AssertStateSet("last_city", "Paris")
+	turn.AssertFinalTextContains("sunny")
+	if got := h.State()["last_city"]; got != "Paris" {
+		t.Errorf("State() last_city = %v, want Paris", got)
+	}
+
+	// The failed assertions are reported.
+	rec := &recorder{TB: t}
+	turn = agenttest.NewHarness(rec, newWeatherAgent(t, "It is sunny in Paris.")).Send("Weather in Paris?")
+	turn.AssertToolCalled("get_weather", map[string]any{"city": "London"})
+	turn.AssertStateSet("unit", "celsius")
+	turn.AssertFinalTextContains("raining")
+	if len(rec.errors) != 3 {
+		t.Errorf("assertions reported %q, want 3 errors", rec.errors)
+	}
+}
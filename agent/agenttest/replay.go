@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agenttest
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// ReplayModel is a model replaying scripted responses, one per call, in
+// order. It fails the calls once the responses are exhausted.
+type ReplayModel struct {
+	mu        sync.Mutex
+	responses []*genai.Content
+	requests  []*model.LLMRequest
+}
+
+// NewReplayModel returns a model replaying the responses.
+func NewReplayModel(responses ...*genai.Content) *ReplayModel {
+	return &ReplayModel{responses: responses}
+}
+
+// Name implements model.LLM.
+func (m *ReplayModel) Name() string { return "replay" }
+
+// GenerateContent implements model.LLM. It yields the next response,
+// without streaming.
+func (m *ReplayModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.mu.Lock()
+		call := len(m.requests)
+		m.requests = append(m.requests, req)
+		m.mu.Unlock()
+		if call >= len(m.responses) {
+			yield(nil, fmt.Errorf("replay model: no response scripted for call %d", call+1))
+			return
+		}
+		yield(&model.LLMResponse{Content: m.responses[call], TurnComplete: true}, nil)
+	}
+}
+
+// Requests returns the requests received by the model so far.
+func (m *ReplayModel) Requests() []*model.LLMRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*model.LLMRequest(nil), m.requests...)
+}
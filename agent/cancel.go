@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	agentinternal "google.golang.org/adk/internal/agent"
+)
+
+// ErrInvocationCancelled is the cause of the context of an invocation
+// cancelled with [CancelInvocation] or [InvocationContext.Cancel].
+var ErrInvocationCancelled = errors.New("invocation cancelled")
+
+// ErrInvocationTimeout is the cause of the context of an invocation that
+// exceeded [RunConfig.Timeout]. It wraps [ErrInvocationCancelled].
+var ErrInvocationTimeout = fmt.Errorf("%w: timeout exceeded", ErrInvocationCancelled)
+
+// CancelInvocation cancels the invocation that ctx belongs to.
+//
+// It can be called with any context derived from the invocation context,
+// e.g. from callbacks, tools and plugins. In-flight model calls are
+// cancelled, pending tool calls are answered with a cancellation response
+// and the runner appends a final event with the given reason to the session.
+//
+// It returns false if ctx does not belong to an invocation started by the
+// runner.
+func CancelInvocation(ctx context.Context, reason string) bool {
+	return agentinternal.Cancel(ctx, fmt.Errorf("%w: %s", ErrInvocationCancelled, reason))
+}
+
+// IsInvocationCancelled reports whether the invocation ctx belongs to was
+// cancelled with [CancelInvocation] or timed out.
+func IsInvocationCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrInvocationCancelled)
+}
@@ -90,10 +90,17 @@ type InvocationContext interface {
 	// RunConfig stores the runtime configuration used during this invocation.
 	RunConfig() *RunConfig
 
+	// TranscriptionCache accumulates the transcriptions of the audio streamed
+	// during a live invocation. It is shared by the agents of the invocation.
+	TranscriptionCache() *TranscriptionCache
+
 	// EndInvocation ends the current invocation. This stops any planned agent
 	// calls.
 	EndInvocation()
-	// Ended returns whether the invocation has ended.
+	// Cancel cancels the current invocation with the given reason, see
+	// [CancelInvocation].
+	Cancel(reason string)
+	// Ended returns whether the invocation has ended or was cancelled.
 	Ended() bool
 
 	// WithContext returns a new instance of the context with overridden embedded context.
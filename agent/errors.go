@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The errors below are returned by agents and the runner so that callers
+// can branch on the kind of failure with errors.As. Cancellations and
+// timeouts are reported with [ErrInvocationCancelled] and
+// [ErrInvocationTimeout], the timeouts of the stages of the invocations with
+// [StageTimeoutError].
+
+// ModelError is returned when a call to the model of an agent fails.
+type ModelError struct {
+	// Agent is the name of the agent that called the model.
+	Agent string
+	// Model is the name of the model.
+	Model string
+	Err   error
+}
+
+func (e *ModelError) Error() string {
+	return fmt.Sprintf("agent %q: model %q: %v", e.Agent, e.Model, e.Err)
+}
+
+func (e *ModelError) Unwrap() error { return e.Err }
+
+// ToolError is the error passed to the tool error and after tool callbacks
+// when a tool call fails.
+//
+// Failed tool calls don't stop the invocation, the error message is sent to
+// the model as the function response instead.
+type ToolError struct {
+	// Tool is the name of the tool.
+	Tool string
+	// CallID is the ID of the function call.
+	CallID string
+	Err    error
+}
+
+func (e *ToolError) Error() string {
+	return fmt.Sprintf("tool %q (call %q): %v", e.Tool, e.CallID, e.Err)
+}
+
+func (e *ToolError) Unwrap() error { return e.Err }
+
+// ErrAgentNotFound is wrapped by a [TransferError] if the target agent is not
+// in the agent tree.
+var ErrAgentNotFound = errors.New("agent not found")
+
+// TransferError is returned when an agent fails to transfer the
+// conversation to another agent.
+type TransferError struct {
+	// From is the name of the agent transferring the conversation.
+	From string
+	// To is the name of the target agent.
+	To  string
+	Err error
+}
+
+func (e *TransferError) Error() string {
+	return fmt.Sprintf("transfer from agent %q to %q: %v", e.From, e.To, e.Err)
+}
+
+func (e *TransferError) Unwrap() error { return e.Err }
+
+// LimitExceededError is returned when an invocation exceeds a limit set in
+// [RunConfig].
+type LimitExceededError struct {
+	// Limit is the name of the RunConfig field, e.g. "MaxLLMCalls".
+	Limit string
+	// Max is the value of the limit.
+	Max int
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("invocation exceeded %s limit of %d", e.Limit, e.Max)
+}
+
+// StageTimeoutError is returned when a stage of an invocation, e.g. a model
+// call, exceeds its timeout, see runner.Timeouts. The invocation goes on: a
+// timed out tool call is reported to the model like any tool error.
+//
+// It matches context.DeadlineExceeded with errors.Is.
+type StageTimeoutError struct {
+	// Stage is the stage, e.g. "model call".
+	Stage string
+	// Timeout is the timeout of the stage.
+	Timeout time.Duration
+}
+
+func (e *StageTimeoutError) Error() string {
+	return fmt.Sprintf("%s exceeded its timeout of %v", e.Stage, e.Timeout)
+}
+
+func (e *StageTimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+const (
+	// ErrorCodePanic is the ErrorCode of the event ending an invocation
+	// after a recovered panic.
+	ErrorCodePanic = "INTERNAL"
+	// PanicStackKey is the CustomMetadata key holding the stack trace of a
+	// recovered panic, see runner.Config.PanicStackTraces.
+	PanicStackKey = "adk_panic_stack"
+)
+
+// PanicError is the error of a panic recovered in an agent, a callback or a
+// tool. The panic ends the invocation with an error event, or the tool call
+// with an error response, instead of crashing the process.
+type PanicError struct {
+	// Source is what panicked, e.g. `tool "search"`.
+	Source string
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panic. It is only sent in the events
+	// and function responses with runner.Config.PanicStackTraces.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic in %s: %v", e.Source, e.Value)
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package identity propagates the identity of the end user an agent runs on
+// behalf of, e.g. extracted from the credentials of an HTTP request.
+//
+// The identity is carried by the Go context: the invocation and tool
+// contexts of a run embed the context it was started with, so tools, e.g.
+// the transports of MCP toolsets, can get the identity with [FromContext]:
+//
+//	func (t *myTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+//		user, ok := identity.FromContext(ctx)
+//		...
+//	}
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Identity is the identity of an end user.
+type Identity struct {
+	// Subject uniquely identifies the user, e.g. the sub claim of a token.
+	Subject string
+	// Email of the user, if known.
+	Email string
+	// Claims are the claims of the credentials of the user, if any.
+	Claims map[string]any
+	// Token is the credential the identity was extracted from, e.g. to be
+	// forwarded to the services called on behalf of the user. Optional.
+	Token string
+}
+
+type identityCtxKey struct{}
+
+// ToContext returns a copy of ctx carrying the identity.
+func ToContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityCtxKey{}, id)
+}
+
+// FromContext returns the identity carried by ctx, if any.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityCtxKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+// ErrUnauthenticated is returned by an [Extractor] when the credentials of a
+// request are invalid.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// An Extractor extracts the identity of the end user from a request. It
+// returns nil if the request carries no identity, and an error if its
+// credentials are invalid.
+type Extractor func(*http.Request) (*Identity, error)
+
+// Middleware returns an HTTP middleware that adds the identity extracted from
+// the requests to their context. Requests for which extract fails are
+// rejected with 401 Unauthorized.
+func Middleware(extract Extractor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := extract(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if id != nil {
+				r = r.WithContext(ToContext(r.Context(), id))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// FromHeaders returns an Extractor reading the subject and the email of the
+// user from request headers, e.g. X-Forwarded-User and X-Forwarded-Email.
+// Use it only behind a trusted proxy which authenticates the users and sets
+// the headers: the headers are not verified. emailHeader is optional.
+func FromHeaders(subjectHeader, emailHeader string) Extractor {
+	return func(r *http.Request) (*Identity, error) {
+		subject := r.Header.Get(subjectHeader)
+		if subject == "" {
+			return nil, nil
+		}
+		id := &Identity{Subject: subject}
+		if emailHeader != "" {
+			id.Email = r.Header.Get(emailHeader)
+		}
+		return id, nil
+	}
+}
+
+// FromBearerToken returns an Extractor reading the identity from the bearer
+// token of the Authorization header. verify checks the token, e.g. the
+// signature, issuer and audience of a JWT, and returns its claims; the sub
+// and email claims are the subject and the email of the identity.
+func FromBearerToken(verify func(ctx context.Context, token string) (map[string]any, error)) Extractor {
+	return func(r *http.Request) (*Identity, error) {
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return nil, nil
+		}
+		claims, err := verify(r.Context(), token)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+		}
+		id := &Identity{Claims: claims, Token: token}
+		id.Subject, _ = claims["sub"].(string)
+		id.Email, _ = claims["email"].(string)
+		if id.Subject == "" {
+			return nil, fmt.Errorf("%w: the token has no sub claim", ErrUnauthenticated)
+		}
+		return id, nil
+	}
+}
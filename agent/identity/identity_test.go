@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/identity"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestMiddleware(t *testing.T) {
+	verify := func(ctx context.Context, token string) (map[string]any, error) {
+		if token != "valid" {
+			return nil, errors.New("invalid token")
+		}
+		return map[string]any{"sub": "user-1", "email": "user@example.com"}, nil
+	}
+	tests := []struct {
+		name       string
+		extract    identity.Extractor
+		header     http.Header
+		wantStatus int
+		want       *identity.Identity
+	}{
+		{
+			name:       "bearer token",
+			extract:    identity.FromBearerToken(verify),
+			header:     http.Header{"Authorization": {"Bearer valid"}},
+			wantStatus: http.StatusOK,
+			want:       &identity.Identity{Subject: "user-1", Email: "user@example.com", Token: "valid"},
+		},
+		{
+			name:       "invalid bearer token",
+			extract:    identity.FromBearerToken(verify),
+			header:     http.Header{"Authorization": {"Bearer invalid"}},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no credentials",
+			extract:    identity.FromBearerToken(verify),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "headers",
+			extract:    identity.FromHeaders("X-Forwarded-User", "X-Forwarded-Email"),
+			header:     http.Header{"X-Forwarded-User": {"user-2"}, "X-Forwarded-Email": {"other@example.com"}},
+			wantStatus: http.StatusOK,
+			want:       &identity.Identity{Subject: "user-2", Email: "other@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *identity.Identity
+			handler := identity.Middleware(tt.extract)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = identity.FromContext(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header = tt.header
+			if req.Header == nil {
+				req.Header = http.Header{}
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("identity = %+v, want %+v", got, tt.want)
+			}
+			if got != nil && (got.Subject != tt.want.Subject || got.Email != tt.want.Email || got.Token != tt.want.Token) {
+				t.Errorf("identity = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromContext_Tool(t *testing.T) {
+	var got *identity.Identity
+	whoami, err := functiontool.New(functiontool.Config{Name: "whoami"}, func(ctx tool.Context, args struct{}) (map[string]any, error) {
+		got, _ = identity.FromContext(ctx)
+		return map[string]any{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name: "agent",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			{Role: genai.RoleModel, Parts: []*genai.Part{genai.NewPartFromFunctionCall("whoami", nil)}},
+			genai.NewContentFromText("done", genai.RoleModel),
+		}},
+		Tools: []tool.Tool{whoami},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := runner.New(runner.Config{AppName: "app", Agent: a, SessionService: session.InMemoryService(), AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := identity.ToContext(t.Context(), &identity.Identity{Subject: "user-1"})
+	for _, err := range r.Run(ctx, "user-1", "session", genai.NewContentFromText("who am I?", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got == nil || got.Subject != "user-1" {
+		t.Errorf("identity in the tool = %+v, want user-1", got)
+	}
+}
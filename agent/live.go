@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"google.golang.org/genai"
+)
+
+// ErrLiveRequestQueueClosed is returned when sending to a closed
+// [LiveRequestQueue].
+var ErrLiveRequestQueueClosed = errors.New("live request queue closed")
+
+// LiveRequest is a client input of a live invocation. Exactly one of its
+// fields is set.
+type LiveRequest struct {
+	// Content is a turn of the user, sent to the model as a whole. The
+	// model replies when it is received.
+	Content *genai.Content
+	// Blob is a chunk of realtime input, e.g. audio or video, streamed to
+	// the model.
+	Blob *genai.Blob
+	// ActivityStart and ActivityEnd mark the start and the end of the user
+	// activity, e.g. speech, when the automatic activity detection of the
+	// model is disabled.
+	ActivityStart bool
+	ActivityEnd   bool
+}
+
+// LiveRequestQueue carries the client inputs of a live invocation to the
+// agent, see runner.Runner.RunLive. The invocation ends when the queue is
+// closed.
+//
+// It is safe for concurrent use.
+type LiveRequestQueue struct {
+	requests  chan *LiveRequest
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewLiveRequestQueue creates an empty LiveRequestQueue.
+func NewLiveRequestQueue() *LiveRequestQueue {
+	return &LiveRequestQueue{
+		requests: make(chan *LiveRequest, 64),
+		closed:   make(chan struct{}),
+	}
+}
+
+// Send adds a request to the queue. It blocks while the queue is full, and
+// returns ErrLiveRequestQueueClosed if the queue is closed.
+func (q *LiveRequestQueue) Send(req *LiveRequest) error {
+	select {
+	case <-q.closed:
+		return ErrLiveRequestQueueClosed
+	default:
+	}
+	select {
+	case q.requests <- req:
+		return nil
+	case <-q.closed:
+		return ErrLiveRequestQueueClosed
+	}
+}
+
+// SendContent sends a turn of the user.
+func (q *LiveRequestQueue) SendContent(content *genai.Content) error {
+	return q.Send(&LiveRequest{Content: content})
+}
+
+// SendRealtime sends a chunk of realtime input, e.g. audio.
+func (q *LiveRequestQueue) SendRealtime(blob *genai.Blob) error {
+	return q.Send(&LiveRequest{Blob: blob})
+}
+
+// SendActivityStart signals the start of the user activity.
+func (q *LiveRequestQueue) SendActivityStart() error {
+	return q.Send(&LiveRequest{ActivityStart: true})
+}
+
+// SendActivityEnd signals the end of the user activity.
+func (q *LiveRequestQueue) SendActivityEnd() error {
+	return q.Send(&LiveRequest{ActivityEnd: true})
+}
+
+// Close closes the queue. The requests sent before are still received.
+func (q *LiveRequestQueue) Close() {
+	q.closeOnce.Do(func() { close(q.closed) })
+}
+
+// Receive returns the next request. It blocks until a request is sent, and
+// returns io.EOF once the queue is closed and drained, or the error of ctx.
+func (q *LiveRequestQueue) Receive(ctx context.Context) (*LiveRequest, error) {
+	select {
+	case req := <-q.requests:
+		return req, nil
+	case <-q.closed:
+		select {
+		case req := <-q.requests:
+			return req, nil
+		default:
+			return nil, io.EOF
+		}
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+// TranscriptionCache accumulates the transcriptions of the audio of a live
+// invocation, see InvocationContext.TranscriptionCache. The live models
+// stream the transcriptions in chunks, yielded as partial events and
+// consolidated in a single transcription stored in the session when complete.
+//
+// It is safe for concurrent use.
+type TranscriptionCache struct {
+	mu     sync.Mutex
+	input  strings.Builder
+	output strings.Builder
+}
+
+// NewTranscriptionCache creates an empty TranscriptionCache.
+func NewTranscriptionCache() *TranscriptionCache {
+	return &TranscriptionCache{}
+}
+
+// AddInput adds a chunk of the transcription of the audio of the user. If
+// the chunk finishes the transcription, the consolidated transcription is
+// returned and the cache is cleared.
+func (c *TranscriptionCache) AddInput(t *genai.Transcription) *genai.Transcription {
+	return c.add(&c.input, t)
+}
+
+// AddOutput adds a chunk of the transcription of the audio of the model, see
+// AddInput.
+func (c *TranscriptionCache) AddOutput(t *genai.Transcription) *genai.Transcription {
+	return c.add(&c.output, t)
+}
+
+// FlushInput returns the transcription consolidating the chunks of the audio
+// of the user added since the last finished one, and clears the cache. It
+// returns nil if there are none.
+func (c *TranscriptionCache) FlushInput() *genai.Transcription {
+	return c.add(&c.input, &genai.Transcription{Finished: true})
+}
+
+// FlushOutput is like FlushInput for the audio of the model.
+func (c *TranscriptionCache) FlushOutput() *genai.Transcription {
+	return c.add(&c.output, &genai.Transcription{Finished: true})
+}
+
+func (c *TranscriptionCache) add(b *strings.Builder, t *genai.Transcription) *genai.Transcription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b.WriteString(t.Text)
+	if !t.Finished || b.Len() == 0 {
+		return nil
+	}
+	text := b.String()
+	b.Reset()
+	return &genai.Transcription{Text: text, Finished: true}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+func TestLiveRequestQueue(t *testing.T) {
+	q := NewLiveRequestQueue()
+	if err := q.SendContent(genai.NewContentFromText("hello", genai.RoleUser)); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.SendActivityStart(); err != nil {
+		t.Fatal(err)
+	}
+	q.Close()
+	q.Close()
+	if err := q.SendActivityEnd(); !errors.Is(err, ErrLiveRequestQueueClosed) {
+		t.Errorf("Send() after Close() error = %v, want %v", err, ErrLiveRequestQueueClosed)
+	}
+
+	// The requests sent before Close are still received.
+	req, err := q.Receive(t.Context())
+	if err != nil || req.Content == nil {
+		t.Errorf("Receive() = %+v, %v, want the content", req, err)
+	}
+	req, err = q.Receive(t.Context())
+	if err != nil || !req.ActivityStart {
+		t.Errorf("Receive() = %+v, %v, want the activity start", req, err)
+	}
+	if _, err := q.Receive(t.Context()); err != io.EOF {
+		t.Errorf("Receive() on a drained closed queue error = %v, want io.EOF", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := NewLiveRequestQueue().Receive(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Receive() with a cancelled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestTranscriptionCache(t *testing.T) {
+	cache := NewTranscriptionCache()
+	if got := cache.AddInput(&genai.Transcription{Text: "Hello"}); got != nil {
+		t.Errorf("AddInput() of an unfinished chunk = %v, want nil", got)
+	}
+	if got := cache.AddOutput(&genai.Transcription{Text: "Hi"}); got != nil {
+		t.Errorf("AddOutput() of an unfinished chunk = %v, want nil", got)
+	}
+	want := &genai.Transcription{Text: "Hello there", Finished: true}
+	if diff := cmp.Diff(want, cache.AddInput(&genai.Transcription{Text: " there", Finished: true})); diff != "" {
+		t.Errorf("AddInput() of the finishing chunk mismatch (-want +got):\n%s", diff)
+	}
+	if got := cache.FlushInput(); got != nil {
+		t.Errorf("FlushInput() after a finished transcription = %v, want nil", got)
+	}
+	want = &genai.Transcription{Text: "Hi", Finished: true}
+	if diff := cmp.Diff(want, cache.FlushOutput()); diff != "" {
+		t.Errorf("FlushOutput() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -22,12 +22,14 @@ import (
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/history"
 	agentinternal "google.golang.org/adk/internal/agent"
 	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/llminternal"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
+	"google.golang.org/adk/toolselect"
 )
 
 // New is a constructor for LLMAgent.
@@ -77,6 +79,7 @@ func New(cfg Config) (agent.Agent, error) {
 		State: llminternal.State{
 			Model:                    cfg.Model,
 			GenerateContentConfig:    cfg.GenerateContentConfig,
+			GenerationProfile:        cfg.GenerationProfile,
 			Tools:                    cfg.Tools,
 			Toolsets:                 cfg.Toolsets,
 			DisallowTransferToParent: cfg.DisallowTransferToParent,
@@ -85,6 +88,8 @@ func New(cfg Config) (agent.Agent, error) {
 			OutputSchema:             cfg.OutputSchema,
 			// TODO: internal type for includeContents
 			IncludeContents:           string(cfg.IncludeContents),
+			HistoryStrategy:           cfg.HistoryStrategy,
+			ToolSelector:              cfg.ToolSelector,
 			Instruction:               cfg.Instruction,
 			InstructionProvider:       llminternal.InstructionProvider(cfg.InstructionProvider),
 			GlobalInstruction:         cfg.GlobalInstruction,
@@ -165,6 +170,11 @@ type Config struct {
 	// For example: use this config to adjust model temperature, configure
 	// safety settings, etc.
 	GenerateContentConfig *genai.GenerateContentConfig
+	// GenerationProfile is the name of the model.GenerationProfile providing
+	// the generation parameters not set by GenerateContentConfig, e.g.
+	// model.ProfilePrecise. The profile of agent.RunConfig.GenerationProfile
+	// overrides both.
+	GenerationProfile string
 
 	// BeforeModelCallbacks will be called in the order they are provided until
 	// there's a callback that returns a non-nil LLMResponse or error. Then
@@ -248,6 +258,14 @@ type Config struct {
 
 	// Whether to include contents (conversation history) in the model request.
 	IncludeContents IncludeContents
+	// HistoryStrategy selects the conversation history sent to the model,
+	// e.g. to fit its context window, see the history package. The whole
+	// history is sent if nil.
+	HistoryStrategy history.Strategy
+	// ToolSelector selects the tools declared to the model, e.g. the tools
+	// most relevant to the user message for the agents with many tools, see
+	// the toolselect package. All the tools are declared if nil.
+	ToolSelector toolselect.Selector
 
 	// TODO(ngeorgy): consider to switch to jsonschema for input and output schema.
 	// The input schema when agent is used as a tool.
@@ -361,14 +379,15 @@ type agentState = agentinternal.State
 func (a *llmAgent) run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
 	// TODO: branch context?
 	ctx = icontext.NewInvocationContext(ctx, icontext.InvocationContextParams{
-		Artifacts:    ctx.Artifacts(),
-		Memory:       ctx.Memory(),
-		Session:      ctx.Session(),
-		Branch:       ctx.Branch(),
-		Agent:        a,
-		UserContent:  ctx.UserContent(),
-		RunConfig:    ctx.RunConfig(),
-		InvocationID: ctx.InvocationID(),
+		Artifacts:          ctx.Artifacts(),
+		Memory:             ctx.Memory(),
+		Session:            ctx.Session(),
+		Branch:             ctx.Branch(),
+		Agent:              a,
+		UserContent:        ctx.UserContent(),
+		RunConfig:          ctx.RunConfig(),
+		InvocationID:       ctx.InvocationID(),
+		TranscriptionCache: ctx.TranscriptionCache(),
 	})
 
 	f := &llminternal.Flow{
@@ -15,11 +15,13 @@
 package llmagent_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"iter"
 	"net/http"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -34,6 +36,8 @@ import (
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/geminitool"
+	"google.golang.org/adk/toolselect"
 )
 
 const modelName = "gemini-2.5-flash"
@@ -1114,3 +1118,89 @@ type roundTripperFunc func(*http.Request) (*http.Response, error)
 func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return fn(req)
 }
+
+func TestToolSelector(t *testing.T) {
+	newTool := func(name string) tool.Tool {
+		tl, err := functiontool.New(functiontool.Config{Name: name, Description: name}, func(tool.Context, struct{}) (map[string]any, error) {
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tl
+	}
+	var gotQuery string
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{genai.NewContentFromText("done", genai.RoleModel)}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "agent",
+		Model: mockModel,
+		Tools: []tool.Tool{newTool("book_hotel"), newTool("get_weather"), newTool("send_email"), geminitool.GoogleSearch{}},
+		ToolSelector: toolselect.SelectorFunc(func(ctx context.Context, query string, tools []*genai.FunctionDeclaration) ([]*genai.FunctionDeclaration, error) {
+			gotQuery = query
+			return tools[1:2], nil
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := testutil.CollectEvents(testutil.NewTestAgentRunner(t, a).Run(t, "session", "Will it rain?")); err != nil {
+		t.Fatal(err)
+	}
+	if gotQuery != "Will it rain?" {
+		t.Errorf("selector query = %q, want the user message", gotQuery)
+	}
+	req := mockModel.Requests[0]
+	var declared []string
+	var googleSearch bool
+	for _, tl := range req.Config.Tools {
+		for _, decl := range tl.FunctionDeclarations {
+			declared = append(declared, decl.Name)
+		}
+		googleSearch = googleSearch || tl.GoogleSearch != nil
+	}
+	if diff := cmp.Diff([]string{"get_weather"}, declared); diff != "" {
+		t.Errorf("declared functions mismatch (-want +got):\n%s", diff)
+	}
+	if !googleSearch {
+		t.Error("request lost the Google Search tool, want the tools other than the function tools kept")
+	}
+	if _, ok := req.Tools["send_email"]; !ok {
+		t.Error("unselected tool send_email is not callable, want all the tools callable")
+	}
+}
+
+func TestGenerationProfile(t *testing.T) {
+	model.RegisterProfile("test_agent_profile", model.GenerationProfile{
+		Temperature:     genai.Ptr[float32](0.3),
+		TopP:            genai.Ptr[float32](0.8),
+		MaxOutputTokens: 100,
+	})
+	model.RegisterProfile("test_invocation_profile", model.GenerationProfile{
+		TopP:          genai.Ptr[float32](0.5),
+		StopSequences: []string{"STOP"},
+	})
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{genai.NewContentFromText("done", genai.RoleModel)}}
+	a, err := llmagent.New(llmagent.Config{
+		Name:                  "agent",
+		Model:                 mockModel,
+		GenerationProfile:     "test_agent_profile",
+		GenerateContentConfig: &genai.GenerateContentConfig{Temperature: genai.Ptr[float32](0.9)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner := testutil.NewTestAgentRunner(t, a)
+	stream := runner.RunContentWithConfig(t, "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{GenerationProfile: "test_invocation_profile"})
+	if _, err := testutil.CollectEvents(stream); err != nil {
+		t.Fatal(err)
+	}
+	cfg := mockModel.Requests[0].Config
+	// The agent config overrides its profile, the invocation profile
+	// overrides both.
+	if *cfg.Temperature != 0.9 || *cfg.TopP != 0.5 || cfg.MaxOutputTokens != 100 || !slices.Equal(cfg.StopSequences, []string{"STOP"}) {
+		t.Errorf("request config = {Temperature: %v, TopP: %v, MaxOutputTokens: %v, StopSequences: %v}, want {0.9, 0.5, 100, [STOP]}",
+			*cfg.Temperature, *cfg.TopP, cfg.MaxOutputTokens, cfg.StopSequences)
+	}
+}
@@ -15,7 +15,11 @@
 package agent
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"sync"
 )
 
 // Loader allows to load a particular agent by name and get the root agent
@@ -105,3 +109,140 @@ func (m *multiLoader) LoadAgent(name string) (Agent, error) {
 func (m *multiLoader) RootAgent() Agent {
 	return m.root
 }
+
+// AppConstructor creates the root agent of an app.
+type AppConstructor func() (Agent, error)
+
+// appLoader serves multiple apps, created on their first load.
+type appLoader struct {
+	names []string
+	root  string
+	apps  map[string]*app
+}
+
+type app struct {
+	once      sync.Once
+	construct AppConstructor
+	agent     Agent
+	err       error
+}
+
+func (a *app) load() (Agent, error) {
+	a.once.Do(func() {
+		a.agent, a.err = a.construct()
+	})
+	return a.agent, a.err
+}
+
+// NewAppLoader returns a Loader serving multiple apps, named by their keys in
+// apps. The agent of an app is created by its constructor on its first load,
+// except for the root app, whose agent is created immediately. The root app
+// defaults to the first app in name order whose agent can be created.
+//
+// The apps are isolated from each other: an app whose agent cannot be
+// created is still listed, and the error is returned when the app is loaded.
+// Only the failure of the root app fails NewAppLoader.
+//
+// Unlike [NewMultiLoader], the apps are loaded by their app name, which may
+// differ from the name of their agent, e.g. the directory of an agent config.
+func NewAppLoader(root string, apps map[string]AppConstructor) (Loader, error) {
+	if len(apps) == 0 {
+		return nil, fmt.Errorf("no apps to load")
+	}
+	l := &appLoader{
+		names: slices.Sorted(maps.Keys(apps)),
+		root:  root,
+		apps:  make(map[string]*app, len(apps)),
+	}
+	for name, construct := range apps {
+		if construct == nil {
+			return nil, fmt.Errorf("app %s has no constructor", name)
+		}
+		l.apps[name] = &app{construct: construct}
+	}
+	if l.root != "" {
+		if _, err := l.LoadAgent(l.root); err != nil {
+			return nil, fmt.Errorf("failed to load the root app: %w", err)
+		}
+		return l, nil
+	}
+	var errs []error
+	for _, name := range l.names {
+		if _, err := l.LoadAgent(name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		l.root = name
+		return l, nil
+	}
+	return nil, fmt.Errorf("failed to load any app: %w", errors.Join(errs...))
+}
+
+// ListAgents implements Loader. Returns the names of the apps, sorted.
+func (l *appLoader) ListAgents() []string {
+	return slices.Clone(l.names)
+}
+
+// LoadAgent implements Loader. Returns the agent of the app, creating it on
+// the first load. An empty name loads the root app.
+func (l *appLoader) LoadAgent(name string) (Agent, error) {
+	if name == "" {
+		name = l.root
+	}
+	a, ok := l.apps[name]
+	if !ok {
+		return nil, fmt.Errorf("app %s not found. Please specify one of those: %v", name, l.names)
+	}
+	agent, err := a.load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the agent of app %s: %w", name, err)
+	}
+	return agent, nil
+}
+
+// RootAgent implements Loader. Returns the agent of the root app.
+func (l *appLoader) RootAgent() Agent {
+	agent, _ := l.apps[l.root].load()
+	return agent
+}
+
+// SwappableLoader is a Loader delegating to another Loader, which can be
+// replaced while the SwappableLoader is in use, e.g. to reload the agents
+// during development.
+type SwappableLoader struct {
+	mu     sync.RWMutex
+	loader Loader
+}
+
+// NewSwappableLoader returns a SwappableLoader delegating to l.
+func NewSwappableLoader(l Loader) *SwappableLoader {
+	return &SwappableLoader{loader: l}
+}
+
+// Swap replaces the Loader. The agents already loaded are not affected.
+func (s *SwappableLoader) Swap(l Loader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loader = l
+}
+
+func (s *SwappableLoader) current() Loader {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.loader
+}
+
+// ListAgents implements Loader.
+func (s *SwappableLoader) ListAgents() []string {
+	return s.current().ListAgents()
+}
+
+// LoadAgent implements Loader.
+func (s *SwappableLoader) LoadAgent(name string) (Agent, error) {
+	return s.current().LoadAgent(name)
+}
+
+// RootAgent implements Loader.
+func (s *SwappableLoader) RootAgent() Agent {
+	return s.current().RootAgent()
+}
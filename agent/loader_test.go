@@ -15,7 +15,10 @@
 package agent
 
 import (
+	"errors"
 	"iter"
+	"slices"
+	"strings"
 	"testing"
 
 	"google.golang.org/adk/session"
@@ -103,3 +106,98 @@ func TestDuplicateName(t *testing.T) {
 		}
 	}
 }
+
+func TestSwappableLoader(t *testing.T) {
+	v1 := &testAgent{name: "v1"}
+	v2 := &testAgent{name: "v2"}
+	l := NewSwappableLoader(NewSingleLoader(v1))
+	if got := l.RootAgent(); got != v1 {
+		t.Errorf("RootAgent() = %v, want v1", got.Name())
+	}
+	l.Swap(NewSingleLoader(v2))
+	if got, err := l.LoadAgent("v2"); err != nil || got != v2 {
+		t.Errorf("LoadAgent(v2) = %v, %v, want the swapped agent", got, err)
+	}
+	if _, err := l.LoadAgent("v1"); err == nil {
+		t.Error("LoadAgent(v1) after the swap succeeded, want error")
+	}
+	if got := l.ListAgents(); len(got) != 1 || got[0] != "v2" {
+		t.Errorf("ListAgents() = %v, want [v2]", got)
+	}
+}
+
+func TestAppLoader(t *testing.T) {
+	var created []string
+	constructor := func(name string) AppConstructor {
+		return func() (Agent, error) {
+			created = append(created, name)
+			return &testAgent{name: name + "_agent"}, nil
+		}
+	}
+	l, err := NewAppLoader("", map[string]AppConstructor{
+		"weather": constructor("weather"),
+		"billing": constructor("billing"),
+		"broken":  func() (Agent, error) { return nil, errors.New("bad config") },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := l.ListAgents(), []string{"billing", "broken", "weather"}; !slices.Equal(got, want) {
+		t.Errorf("ListAgents() = %v, want %v", got, want)
+	}
+	if got := l.RootAgent().Name(); got != "billing_agent" {
+		t.Errorf("RootAgent() = %q, want the first app billing_agent", got)
+	}
+	if !slices.Equal(created, []string{"billing"}) {
+		t.Errorf("created apps = %v, want only the root app", created)
+	}
+
+	for range 2 {
+		got, err := l.LoadAgent("weather")
+		if err != nil || got.Name() != "weather_agent" {
+			t.Errorf("LoadAgent(weather) = %v, %v, want weather_agent", got, err)
+		}
+	}
+	if !slices.Equal(created, []string{"billing", "weather"}) {
+		t.Errorf("created apps = %v, want each app created once", created)
+	}
+	if _, err := l.LoadAgent("broken"); err == nil {
+		t.Error("LoadAgent(broken) succeeded, want error")
+	}
+	if _, err := l.LoadAgent("unknown"); err == nil {
+		t.Error("LoadAgent(unknown) succeeded, want error")
+	}
+
+	if _, err := NewAppLoader("broken", map[string]AppConstructor{"broken": func() (Agent, error) { return nil, errors.New("bad config") }}); err == nil {
+		t.Error("NewAppLoader() with a broken root app succeeded, want error")
+	}
+	if _, err := NewAppLoader("", map[string]AppConstructor{"broken": func() (Agent, error) { return nil, errors.New("bad config") }}); err == nil {
+		t.Error("NewAppLoader() without a working app succeeded, want error")
+	}
+	if _, err := NewAppLoader("missing", map[string]AppConstructor{"weather": constructor("weather")}); err == nil {
+		t.Error("NewAppLoader() with a missing root app succeeded, want error")
+	}
+	if _, err := NewAppLoader("", nil); err == nil {
+		t.Error("NewAppLoader() without apps succeeded, want error")
+	}
+}
+
+func TestAppLoader_BrokenFirstApp(t *testing.T) {
+	// The default root app is the first app whose agent can be created.
+	l, err := NewAppLoader("", map[string]AppConstructor{
+		"a_broken": func() (Agent, error) { return nil, errors.New("bad config") },
+		"b_valid":  func() (Agent, error) { return &testAgent{name: "valid_agent"}, nil },
+	})
+	if err != nil {
+		t.Fatalf("NewAppLoader() error = %v", err)
+	}
+	if got := l.RootAgent().Name(); got != "valid_agent" {
+		t.Errorf("RootAgent() = %q, want valid_agent", got)
+	}
+	if got, want := l.ListAgents(), []string{"a_broken", "b_valid"}; !slices.Equal(got, want) {
+		t.Errorf("ListAgents() = %v, want %v", got, want)
+	}
+	if _, err := l.LoadAgent("a_broken"); err == nil || !strings.Contains(err.Error(), "bad config") {
+		t.Errorf("LoadAgent(a_broken) error = %v, want the error of its constructor", err)
+	}
+}
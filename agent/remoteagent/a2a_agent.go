@@ -18,16 +18,18 @@ import (
 	"context"
 	"fmt"
 	"iter"
-	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2aclient"
 	"github.com/a2aproject/a2a-go/a2aclient/agentcard"
+	"github.com/a2aproject/a2a-go/a2asrv"
 
 	"google.golang.org/adk/agent"
 	agentinternal "google.golang.org/adk/internal/agent"
 	iremoteagent "google.golang.org/adk/internal/agent/remoteagent"
+	"google.golang.org/adk/internal/logging"
 	"google.golang.org/adk/server/adka2a"
 	"google.golang.org/adk/session"
 )
@@ -108,6 +110,9 @@ type A2AConfig struct {
 
 	// ClientFactory can be used to provide a set of a2aclient.Client configurations.
 	ClientFactory *a2aclient.Factory
+	// Credentials are attached to the requests according to the security schemes declared by the agent card.
+	// Optional.
+	Credentials *A2ACredentials
 	// MessageSendConfig is attached to a2a.MessageSendParams sent on every agent invocation.
 	MessageSendConfig *a2a.MessageSendConfig
 
@@ -125,12 +130,23 @@ func NewA2A(cfg A2AConfig) (agent.Agent, error) {
 		return nil, fmt.Errorf("either AgentCard or AgentCardSource must be provided")
 	}
 
+	interceptors := []a2aclient.CallInterceptor{extensionsInterceptor{}}
+	if cfg.Credentials != nil {
+		interceptors = append(interceptors, newCredentialsInterceptor(*cfg.Credentials))
+	}
+	clientFactory := cfg.ClientFactory
+	if clientFactory != nil {
+		clientFactory = a2aclient.WithAdditionalOptions(clientFactory, a2aclient.WithInterceptors(interceptors...))
+	} else {
+		clientFactory = a2aclient.NewFactory(a2aclient.WithInterceptors(interceptors...))
+	}
+
 	remoteAgent := &a2aAgent{
 		serverConfig: &iremoteagent.A2AServerConfig{
 			AgentCard:          cfg.AgentCard,
 			AgentCardSource:    cfg.AgentCardSource,
 			CardResolveOptions: cfg.CardResolveOptions,
-			ClientFactory:      cfg.ClientFactory,
+			ClientFactory:      clientFactory,
 		},
 	}
 	agent, err := agent.New(agent.Config{
@@ -168,7 +184,7 @@ func (a *a2aAgent) run(ctx agent.InvocationContext, cfg A2AConfig) iter.Seq2[*se
 			yield(toErrorEvent(ctx, fmt.Errorf("client creation failed: %w", err)), nil)
 			return
 		}
-		defer destroy(client)
+		defer destroy(ctx, client)
 
 		msg, err := newMessage(ctx, cfg)
 		if err != nil {
@@ -255,14 +271,47 @@ func (a *a2aAgent) run(ctx agent.InvocationContext, cfg A2AConfig) iter.Seq2[*se
 			return
 		}
 
-		for a2aEvent, a2aErr := range client.SendStreamingMessage(ctx, req) {
+		// The request outlives the cancellation of the invocation until the
+		// ID of the remote task is known, so that cleanupRemoteTask cancels
+		// the remote task instead of leaving it running.
+		streamCtx, stopStream := context.WithCancelCause(context.WithoutCancel(ctx))
+		defer stopStream(nil)
+		var taskKnown atomic.Bool
+		stopAfter := context.AfterFunc(ctx, func() {
+			if taskKnown.Load() {
+				stopStream(context.Cause(ctx))
+				return
+			}
+			time.AfterFunc(pendingCancelTimeout, func() { stopStream(context.Cause(ctx)) })
+		})
+		defer stopAfter()
+
+		for a2aEvent, a2aErr := range client.SendStreamingMessage(streamCtx, req) {
+			if ctx.Err() != nil {
+				// The invocation was cancelled: the events are only read
+				// for the ID of the remote task to cancel.
+				if a2aEvent != nil {
+					lastEvent = a2aEvent
+				}
+				if a2aErr != nil || (lastEvent != nil && lastEvent.TaskInfo().TaskID != "") {
+					return
+				}
+				continue
+			}
 			if !processEvent(a2aEvent, a2aErr) {
 				return
 			}
+			if a2aEvent != nil && a2aEvent.TaskInfo().TaskID != "" {
+				taskKnown.Store(true)
+			}
 		}
 	}
 }
 
+// pendingCancelTimeout is how long the request of a cancelled invocation is
+// kept waiting for the ID of the remote task to cancel.
+const pendingCancelTimeout = 5 * time.Second
+
 func cleanupRemoteTask(ctx context.Context, cfg A2AConfig, card *a2a.AgentCard, client *a2aclient.Client, lastEvent a2a.Event, cause error) {
 	if lastEvent == nil {
 		return
@@ -299,7 +348,7 @@ func cleanupRemoteTask(ctx context.Context, cfg A2AConfig, card *a2a.AgentCard,
 	defer cancelTimeout()
 	_, err := client.CancelTask(cancelCtx, &a2a.TaskIDParams{ID: taskID})
 	if err != nil {
-		log.Printf("failed to cancel task %s: %v", taskID, err)
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to cancel task", "task_id", taskID, "error", err)
 	}
 }
 
@@ -353,8 +402,20 @@ func convertParts(ctx agent.InvocationContext, cfg A2AConfig, event *session.Eve
 	return parts, nil
 }
 
-func destroy(client *a2aclient.Client) {
+func destroy(ctx context.Context, client *a2aclient.Client) {
 	if err := client.Destroy(); err != nil {
-		log.Printf("failed to destroy client: %v", err)
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to destroy client", "error", err)
 	}
 }
+
+// extensionsInterceptor requests the A2A extensions of ADK servers, so that
+// the data of the remote invocations without A2A equivalent, e.g. state
+// deltas, is preserved.
+type extensionsInterceptor struct {
+	a2aclient.PassthroughInterceptor
+}
+
+func (extensionsInterceptor) Before(ctx context.Context, req *a2aclient.Request) (context.Context, error) {
+	req.Meta[a2asrv.ExtensionsMetaKey] = append(req.Meta[a2asrv.ExtensionsMetaKey], adka2a.ActionsExtensionURI)
+	return ctx, nil
+}
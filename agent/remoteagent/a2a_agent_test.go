@@ -41,6 +41,7 @@ import (
 	"google.golang.org/adk/runner"
 	"google.golang.org/adk/server/adka2a"
 	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool/toolconfirmation"
 )
 
 type mockA2AExecutor struct {
@@ -210,6 +211,7 @@ func TestRemoteAgent_ADK2ADK(t *testing.T) {
 		wantResponses []model.LLMResponse
 		wantEscalate  bool
 		wantTransfer  string
+		wantActions   session.EventActions
 		noStreaming   bool
 	}{
 		{
@@ -340,6 +342,30 @@ func TestRemoteAgent_ADK2ADK(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "state delta and tool confirmations",
+			remoteEvents: []*session.Event{
+				{
+					LLMResponse: model.LLMResponse{Content: genai.NewContentFromText("Please confirm", genai.RoleModel)},
+					Actions: session.EventActions{
+						StateDelta: map[string]any{"seat": "1A"},
+						RequestedToolConfirmations: map[string]toolconfirmation.ToolConfirmation{
+							"call-1": {Hint: "Book seat 1A?"},
+						},
+					},
+				},
+			},
+			wantResponses: []model.LLMResponse{
+				{Content: genai.NewContentFromText("Please confirm", genai.RoleModel)},
+				{TurnComplete: true},
+			},
+			wantActions: session.EventActions{
+				StateDelta: map[string]any{"seat": "1A"},
+				RequestedToolConfirmations: map[string]toolconfirmation.ToolConfirmation{
+					"call-1": {Hint: "Book seat 1A?"},
+				},
+			},
+		},
 		{
 			name: "metadata",
 			remoteEvents: []*session.Event{
@@ -415,6 +441,13 @@ func TestRemoteAgent_ADK2ADK(t *testing.T) {
 				if tc.wantTransfer != lastActions.TransferToAgent {
 					t.Fatalf("lastActions.TransferToAgent = %v, want %v", lastActions.TransferToAgent, tc.wantTransfer)
 				}
+				gotActions := session.EventActions{
+					StateDelta:                 lastActions.StateDelta,
+					RequestedToolConfirmations: lastActions.RequestedToolConfirmations,
+				}
+				if diff := cmp.Diff(tc.wantActions, gotActions, cmpopts.EquateEmpty()); diff != "" {
+					t.Fatalf("lastActions wrong result (+got,-want):\ndiff = %s", diff)
+				}
 			})
 		}
 	}
@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteagent
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/idtoken"
+)
+
+// A2ACredentials are the credentials a remote A2A agent is called with. They
+// are attached to the requests according to the security schemes declared by
+// the agent card: the first requirement of the card the credentials satisfy
+// is used.
+type A2ACredentials struct {
+	// APIKey is sent for the API key security schemes, in the header or
+	// cookie they declare.
+	APIKey string
+
+	// TokenSource provides the bearer tokens sent for the HTTP bearer, OAuth2
+	// and OpenID Connect security schemes. Tokens are reused until they
+	// expire. Use e.g. the token source of an oauth2.Config to refresh the
+	// access tokens of a user, or idtoken.NewTokenSource for the ID tokens of
+	// a service account key.
+	TokenSource oauth2.TokenSource
+
+	// GoogleIDToken makes the bearer tokens Google-signed ID tokens of the
+	// application default credentials, e.g. of the service account of the
+	// environment, with the origin of the remote agent URL as audience, as
+	// expected by Cloud Run and IAP. Ignored if TokenSource is set.
+	GoogleIDToken bool
+}
+
+// credentialsInterceptor attaches A2ACredentials to the requests of an
+// a2aclient.Client.
+type credentialsInterceptor struct {
+	a2aclient.PassthroughInterceptor
+	credentials A2ACredentials
+
+	mu sync.Mutex
+	// tokenSources are the token sources of the bearer tokens, by audience.
+	tokenSources map[string]oauth2.TokenSource
+}
+
+var _ a2aclient.CallInterceptor = (*credentialsInterceptor)(nil)
+
+func newCredentialsInterceptor(credentials A2ACredentials) *credentialsInterceptor {
+	return &credentialsInterceptor{credentials: credentials, tokenSources: make(map[string]oauth2.TokenSource)}
+}
+
+func (ci *credentialsInterceptor) Before(ctx context.Context, req *a2aclient.Request) (context.Context, error) {
+	if req.Card == nil || len(req.Card.SecuritySchemes) == 0 {
+		return ctx, nil
+	}
+	for _, requirement := range req.Card.Security {
+		if !ci.satisfies(req.Card, requirement) {
+			continue
+		}
+		for name := range requirement {
+			if err := ci.attach(ctx, req, req.Card.SecuritySchemes[name]); err != nil {
+				return ctx, fmt.Errorf("failed to attach credentials for security scheme %s: %w", name, err)
+			}
+		}
+		return ctx, nil
+	}
+	return ctx, nil
+}
+
+// satisfies reports whether the credentials can be used for all the schemes
+// of a security requirement.
+func (ci *credentialsInterceptor) satisfies(card *a2a.AgentCard, requirement a2a.SecurityRequirements) bool {
+	if len(requirement) == 0 {
+		return false
+	}
+	for name := range requirement {
+		switch scheme := card.SecuritySchemes[name].(type) {
+		case a2a.APIKeySecurityScheme:
+			if ci.credentials.APIKey == "" || scheme.In == a2a.APIKeySecuritySchemeInQuery {
+				return false
+			}
+		case a2a.HTTPAuthSecurityScheme:
+			if !strings.EqualFold(scheme.Scheme, "bearer") || !ci.hasTokens() {
+				return false
+			}
+		case a2a.OAuth2SecurityScheme, a2a.OpenIDConnectSecurityScheme:
+			if !ci.hasTokens() {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (ci *credentialsInterceptor) hasTokens() bool {
+	return ci.credentials.TokenSource != nil || ci.credentials.GoogleIDToken
+}
+
+func (ci *credentialsInterceptor) attach(ctx context.Context, req *a2aclient.Request, scheme a2a.SecurityScheme) error {
+	if apiKey, ok := scheme.(a2a.APIKeySecurityScheme); ok {
+		if apiKey.In == a2a.APIKeySecuritySchemeInCookie {
+			req.Meta["Cookie"] = append(req.Meta["Cookie"], apiKey.Name+"="+ci.credentials.APIKey)
+		} else {
+			req.Meta[apiKey.Name] = []string{ci.credentials.APIKey}
+		}
+		return nil
+	}
+	ts, err := ci.tokenSource(ctx, req.BaseURL)
+	if err != nil {
+		return err
+	}
+	token, err := ts.Token()
+	if err != nil {
+		return fmt.Errorf("failed to get a token: %w", err)
+	}
+	req.Meta["Authorization"] = []string{"Bearer " + token.AccessToken}
+	return nil
+}
+
+// tokenSource returns the token source of the bearer tokens for the agent
+// served on baseURL.
+func (ci *credentialsInterceptor) tokenSource(ctx context.Context, baseURL string) (oauth2.TokenSource, error) {
+	audience := ""
+	if ci.credentials.TokenSource == nil {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid agent URL %q: %w", baseURL, err)
+		}
+		audience = u.Scheme + "://" + u.Host
+	}
+
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	if ts, ok := ci.tokenSources[audience]; ok {
+		return ts, nil
+	}
+	ts := ci.credentials.TokenSource
+	if ts == nil {
+		// The token source outlives the request, it must not use its context.
+		var err error
+		ts, err = idtoken.NewTokenSource(context.WithoutCancel(ctx), audience)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create an ID token source for %s: %w", audience, err)
+		}
+	}
+	ts = oauth2.ReuseTokenSource(nil, ts)
+	ci.tokenSources[audience] = ts
+	return ts, nil
+}
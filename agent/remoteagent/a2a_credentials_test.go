@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteagent
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+)
+
+type countingTokenSource struct {
+	calls int
+	err   error
+}
+
+func (ts *countingTokenSource) Token() (*oauth2.Token, error) {
+	ts.calls++
+	if ts.err != nil {
+		return nil, ts.err
+	}
+	return &oauth2.Token{AccessToken: "access-token", Expiry: time.Now().Add(time.Hour)}, nil
+}
+
+func TestCredentialsInterceptor(t *testing.T) {
+	schemes := a2a.NamedSecuritySchemes{
+		"apiKey":  a2a.APIKeySecurityScheme{In: a2a.APIKeySecuritySchemeInHeader, Name: "X-API-Key"},
+		"cookie":  a2a.APIKeySecurityScheme{In: a2a.APIKeySecuritySchemeInCookie, Name: "session"},
+		"bearer":  a2a.HTTPAuthSecurityScheme{Scheme: "Bearer"},
+		"basic":   a2a.HTTPAuthSecurityScheme{Scheme: "basic"},
+		"oauth2":  a2a.OAuth2SecurityScheme{},
+		"oidc":    a2a.OpenIDConnectSecurityScheme{OpenIDConnectURL: "https://accounts.example.com"},
+		"mtls":    a2a.MutualTLSSecurityScheme{},
+		"missing": nil,
+	}
+	tests := []struct {
+		name        string
+		credentials A2ACredentials
+		security    []a2a.SecurityRequirements
+		want        a2aclient.CallMeta
+	}{
+		{
+			name:        "api key",
+			credentials: A2ACredentials{APIKey: "secret"},
+			security:    []a2a.SecurityRequirements{{"apiKey": {}}},
+			want:        a2aclient.CallMeta{"X-API-Key": {"secret"}},
+		},
+		{
+			name:        "api key in cookie",
+			credentials: A2ACredentials{APIKey: "secret"},
+			security:    []a2a.SecurityRequirements{{"cookie": {}}},
+			want:        a2aclient.CallMeta{"Cookie": {"session=secret"}},
+		},
+		{
+			name:        "bearer token",
+			credentials: A2ACredentials{TokenSource: &countingTokenSource{}},
+			security:    []a2a.SecurityRequirements{{"bearer": {}}},
+			want:        a2aclient.CallMeta{"Authorization": {"Bearer access-token"}},
+		},
+		{
+			name:        "oauth2 token",
+			credentials: A2ACredentials{TokenSource: &countingTokenSource{}},
+			security:    []a2a.SecurityRequirements{{"oauth2": {"agent.invoke"}}},
+			want:        a2aclient.CallMeta{"Authorization": {"Bearer access-token"}},
+		},
+		{
+			name:        "first satisfied requirement",
+			credentials: A2ACredentials{TokenSource: &countingTokenSource{}},
+			security:    []a2a.SecurityRequirements{{"apiKey": {}}, {"mtls": {}}, {"oidc": {}}},
+			want:        a2aclient.CallMeta{"Authorization": {"Bearer access-token"}},
+		},
+		{
+			name:        "all schemes of a requirement",
+			credentials: A2ACredentials{APIKey: "secret", TokenSource: &countingTokenSource{}},
+			security:    []a2a.SecurityRequirements{{"apiKey": {}, "bearer": {}}},
+			want:        a2aclient.CallMeta{"X-API-Key": {"secret"}, "Authorization": {"Bearer access-token"}},
+		},
+		{
+			name:        "unsatisfied requirements",
+			credentials: A2ACredentials{APIKey: "secret"},
+			security:    []a2a.SecurityRequirements{{"apiKey": {}, "bearer": {}}, {"basic": {}}, {"missing": {}}},
+			want:        a2aclient.CallMeta{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			interceptor := newCredentialsInterceptor(tc.credentials)
+			req := &a2aclient.Request{
+				BaseURL: "https://agent.example.com/a2a/invoke",
+				Meta:    a2aclient.CallMeta{},
+				Card:    &a2a.AgentCard{Security: tc.security, SecuritySchemes: schemes},
+			}
+			if _, err := interceptor.Before(t.Context(), req); err != nil {
+				t.Fatalf("Before() error = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, req.Meta); diff != "" {
+				t.Errorf("Before() meta mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCredentialsInterceptor_Tokens(t *testing.T) {
+	card := &a2a.AgentCard{
+		Security:        []a2a.SecurityRequirements{{"bearer": {}}},
+		SecuritySchemes: a2a.NamedSecuritySchemes{"bearer": a2a.HTTPAuthSecurityScheme{Scheme: "bearer"}},
+	}
+
+	t.Run("reused until expiry", func(t *testing.T) {
+		ts := &countingTokenSource{}
+		interceptor := newCredentialsInterceptor(A2ACredentials{TokenSource: ts})
+		for range 3 {
+			req := &a2aclient.Request{BaseURL: "https://agent.example.com", Meta: a2aclient.CallMeta{}, Card: card}
+			if _, err := interceptor.Before(t.Context(), req); err != nil {
+				t.Fatalf("Before() error = %v", err)
+			}
+		}
+		if ts.calls != 1 {
+			t.Errorf("token source called %d times, want 1", ts.calls)
+		}
+	})
+
+	t.Run("token error", func(t *testing.T) {
+		interceptor := newCredentialsInterceptor(A2ACredentials{TokenSource: &countingTokenSource{err: errors.New("refresh failed")}})
+		req := &a2aclient.Request{BaseURL: "https://agent.example.com", Meta: a2aclient.CallMeta{}, Card: card}
+		if _, err := interceptor.Before(t.Context(), req); err == nil {
+			t.Error("Before() error = nil, want the token error")
+		}
+	})
+}
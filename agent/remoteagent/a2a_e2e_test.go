@@ -459,6 +459,85 @@ func TestA2ACleanupPropagation(t *testing.T) {
 	}
 }
 
+func TestA2ACleanupPropagation_CancelBeforeRemoteTask(t *testing.T) {
+	// Remote A2A server holds its first event until the parent task is cancelled, so the cancellation
+	// arrives before the subagent task ID is known to the remote agent.
+	remoteTaskIDChan := make(chan a2a.TaskID, 1)
+	release := make(chan struct{})
+	serverB := startA2AServer(&mockA2AExecutor{
+		executeFn: func(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+			remoteTaskIDChan <- reqCtx.TaskID
+			<-release
+			if err := queue.Write(ctx, a2a.NewSubmittedTask(reqCtx, reqCtx.Message)); err != nil {
+				return err
+			}
+			for ctx.Err() == nil {
+				if err := queue.Write(ctx, a2a.NewArtifactEvent(reqCtx, a2a.TextPart{Text: "foo"})); err != nil {
+					return err
+				}
+				time.Sleep(1 * time.Millisecond)
+			}
+			finalUpdate := a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateCompleted, nil)
+			finalUpdate.Final = true
+			return queue.Write(ctx, finalUpdate)
+		},
+	})
+	defer serverB.Close()
+
+	remoteAgentB := newA2ARemoteAgent(t, "remote-agent-b", serverB)
+	rootA := newRootAgent("agent-b", remoteAgentB)
+	serverA := startA2AServer(newAgentExecutor(rootA, nil, adka2a.OutputArtifactPerEvent))
+	defer serverA.Close()
+
+	client := newA2AClient(t, serverA)
+	taskIDChan := make(chan a2a.TaskID, 1)
+	cancelledChan := make(chan struct{})
+	go func() {
+		msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "work"})
+		for event, err := range client.SendStreamingMessage(t.Context(), &a2a.MessageSendParams{Message: msg}) {
+			if err != nil {
+				return
+			}
+			select {
+			case taskIDChan <- event.TaskInfo().TaskID:
+			default:
+			}
+			if tu, ok := event.(*a2a.TaskStatusUpdateEvent); ok && tu.Status.State == a2a.TaskStateCanceled {
+				close(cancelledChan)
+				return
+			}
+		}
+	}()
+
+	taskID := <-taskIDChan
+	remoteTaskID := <-remoteTaskIDChan
+	cancelErrChan := make(chan error, 1)
+	go func() {
+		_, err := client.CancelTask(t.Context(), &a2a.TaskIDParams{ID: taskID})
+		cancelErrChan <- err
+	}()
+	// Let the remote server respond once the parent task is cancelled.
+	<-cancelledChan
+	close(release)
+	if err := <-cancelErrChan; err != nil {
+		t.Fatalf("client.CancelTask() error = %v", err)
+	}
+
+	// The subagent task is cancelled once the remote agent learns its ID.
+	remoteClient := newA2AClient(t, serverB)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		remoteTask, err := remoteClient.GetTask(t.Context(), &a2a.TaskQueryParams{ID: remoteTaskID})
+		if err == nil && remoteTask.Status.State == a2a.TaskStateCanceled {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("remoteClient.GetTask() = %v, %v, want a canceled task", remoteTask, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 func TestA2ASingleHopFinalResponse(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -13,4 +13,34 @@
 // limitations under the License.
 
 // Package remoteagent allows to use a remote ADK agents.
+//
+// [NewA2A] creates an agent consuming an agent served over A2A, e.g. by
+// another ADK runtime or a Python agent, which can be used as a sub-agent of
+// Go agents. The agent card is resolved from AgentCardSource on the first
+// run, messages are sent with the transport the card prefers, e.g. JSON-RPC,
+// or with the transports of A2AConfig.ClientFactory, e.g. gRPC, and the
+// task and message events of the remote agent are converted to session
+// events, streamed if the remote agent supports streaming:
+//
+//	remote, err := remoteagent.NewA2A(remoteagent.A2AConfig{
+//		Name:            "weather_agent",
+//		Description:     "Answers questions about the weather.",
+//		AgentCardSource: "https://weather.example.com",
+//	})
+//	...
+//	root, err := llmagent.New(llmagent.Config{
+//		Name:      "root",
+//		Model:     model,
+//		SubAgents: []agent.Agent{remote},
+//	})
+//
+// Agents requiring authentication are called with A2AConfig.Credentials,
+// attached according to the security schemes of their cards: an API key,
+// OAuth2 tokens refreshed by a token source, or Google ID tokens, e.g. of a
+// service account calling an agent on Cloud Run.
+//
+// Remote ADK agents are asked for the [adka2a.ActionsExtension]: the state
+// deltas, tool confirmation requests and long-running function call IDs of
+// their invocations are then set on the session events, instead of only
+// their text.
 package remoteagent
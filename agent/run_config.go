@@ -14,6 +14,14 @@
 
 package agent
 
+import (
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/speech"
+)
+
 // StreamingMode defines the streaming mode for agent execution.
 type StreamingMode string
 
@@ -23,13 +31,96 @@ const (
 	// StreamingModeSSE enables server-sent events streaming, one-way, where
 	// LLM response parts are streamed immediately as they are generated.
 	StreamingModeSSE StreamingMode = "sse"
+	// StreamingModeBidi enables bidirectional streaming, where the client
+	// streams its inputs, e.g. audio, to the model and the model streams its
+	// responses back. It is set by runner.Runner.RunLive.
+	StreamingModeBidi StreamingMode = "bidi"
+)
+
+// StreamGranularity defines how often partial events are emitted in the
+// StreamingModeSSE.
+type StreamGranularity string
+
+const (
+	// StreamGranularityChunk emits a partial event for every chunk streamed
+	// by the model.
+	StreamGranularityChunk StreamGranularity = "chunk"
+	// StreamGranularitySentence buffers the streamed text up to the end of a
+	// sentence or line.
+	StreamGranularitySentence StreamGranularity = "sentence"
+	// StreamGranularityParagraph buffers the streamed text up to the end of a
+	// paragraph, i.e. a blank line.
+	StreamGranularityParagraph StreamGranularity = "paragraph"
 )
 
 // RunConfig controls runtime behavior of an agent.
 type RunConfig struct {
 	// StreamingMode defines the streaming mode for an agent.
+	// If empty, LLM agents don't stream the model responses, same as with
+	// StreamingModeNone.
 	StreamingMode StreamingMode
+	// StreamGranularity controls how the text streamed by the model is split
+	// into partial events, and so how often the after model callbacks see
+	// them. The text is buffered on the server, so that clients on slow links
+	// are not flooded with tiny events; the final events are unchanged. If
+	// empty, every chunk is emitted, same as with StreamGranularityChunk.
+	StreamGranularity StreamGranularity
 	// If true, ADK runner will save each part of the user input that is a blob
 	// (e.g., images, files) as an artifact.
 	SaveInputBlobsAsArtifacts bool
+	// If true, ADK runner will save the blobs generated by the model, e.g.
+	// images, as artifacts and replace them in the events with a text
+	// referencing the artifact. The audio streamed by a live model is saved
+	// as one artifact per turn, see model.Output.
+	SaveOutputBlobsAsArtifacts bool
+	// Timeout limits the duration of the whole invocation, including model
+	// calls, tools and sub-agents. When it expires, the invocation is
+	// cancelled with ErrInvocationTimeout. Zero means no limit.
+	Timeout time.Duration
+	// DryRun makes LLM agents build the request to the model, including the
+	// request processors, instructions, tool declarations and before model
+	// callbacks, without calling the model. The request is returned as a
+	// *model.LLMRequest in the CustomMetadata of the event emitted in place of
+	// the model response, under DryRunRequestKey. The runner runs a dry run
+	// on an in-memory copy of the session: the user message, the state
+	// changes and the events are not persisted, and the blobs are not saved
+	// as artifacts.
+	DryRun bool
+	// MaxLLMCalls limits the number of model calls of the invocation. When
+	// the limit is reached, the invocation fails with a *LimitExceededError.
+	// Zero means no limit.
+	MaxLLMCalls int
+	// OutputSchema overrides the output schema of the LLM agents of the
+	// invocation, e.g. to extract structured data with any agent, see
+	// adk.Extract.
+	OutputSchema *genai.Schema
+	// GenerationProfile is the name of the model.GenerationProfile applied
+	// to the requests of the LLM agents of the invocation. It overrides the
+	// parameters set by the profiles and the GenerateContentConfig of the
+	// agents, see model.GenerationProfile for the precedence rules.
+	GenerationProfile string
+
+	// The settings below configure the live connection of the agents run
+	// with runner.Runner.RunLive.
+
+	// ResponseModalities are the modalities of the model responses, e.g.
+	// genai.ModalityAudio. Defaults to the modality of the model.
+	ResponseModalities []genai.Modality
+	// SpeechConfig configures the voice of the audio responses.
+	SpeechConfig *genai.SpeechConfig
+	// InputAudioTranscription and OutputAudioTranscription enable the
+	// transcription of the audio input and output, yielded in the
+	// InputTranscription and OutputTranscription of the events.
+	InputAudioTranscription  *genai.AudioTranscriptionConfig
+	OutputAudioTranscription *genai.AudioTranscriptionConfig
+	// SpeechToText and TextToSpeech let the agents whose model does not
+	// implement model.LiveLLM converse by voice: the audio of the user is
+	// transcribed and the responses of the model are synthesized, see
+	// speech.NewLiveModel. Without them, RunLive requires live models.
+	SpeechToText speech.SpeechToText
+	TextToSpeech speech.TextToSpeech
 }
+
+// DryRunRequestKey is the CustomMetadata key of the LLM request captured in
+// dry-run mode, see RunConfig.DryRun.
+const DryRunRequestKey = "adk_dry_run_request"
@@ -64,6 +64,7 @@ func TestNewParallelAgent(t *testing.T) {
 				for agentID := 1; agentID <= 3; agentID++ {
 					for responseCount := 1; responseCount <= 2; responseCount++ {
 						res = append(res, &session.Event{
+							Branch: fmt.Sprintf("test_agent.loop_agent_%d", agentID),
 							Author: fmt.Sprintf("sub%d", agentID),
 							LLMResponse: model.LLMResponse{
 								Content: &genai.Content{
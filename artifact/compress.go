@@ -0,0 +1,271 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/codec"
+)
+
+// CompressedService returns an artifact service compressing the inline data
+// of the artifacts saved to s which reaches the threshold of c. The
+// compression is recorded in the MIME type of the stored artifacts, with
+// the parameters compressionParam and sizeParam, which the loaded artifacts
+// do not have. The loaded artifacts are decompressed, whatever the
+// compression they were saved with, so c can be changed or disabled without
+// migrating the artifacts.
+//
+// The text artifacts, the inline data without a valid MIME type and the
+// content saved with SaveStream are stored as is. Opening a compressed
+// artifact streams it through the decompressor: seeking it forward
+// decompresses the skipped content, seeking it backward decompresses it
+// again from the start.
+func CompressedService(s Service, c codec.Compression) Service {
+	cs := &compressedService{Service: s, compression: c}
+	if stream, ok := s.(StreamService); ok {
+		return &compressedStreamService{compressedService: cs, stream: stream}
+	}
+	return cs
+}
+
+const (
+	// compressionParam is the MIME type parameter naming the compressor of
+	// a compressed artifact.
+	compressionParam = "adk-compression"
+	// sizeParam is the MIME type parameter holding the size of a compressed
+	// artifact before its compression.
+	sizeParam = "adk-size"
+)
+
+type compressedService struct {
+	Service
+	compression codec.Compression
+}
+
+func (s *compressedService) Save(ctx context.Context, req *SaveRequest) (*SaveResponse, error) {
+	if req.Part == nil || req.Part.InlineData == nil || !s.compression.Enabled() {
+		return s.Service.Save(ctx, req)
+	}
+	blob := *req.Part.InlineData
+	if len(blob.Data) < cmp.Or(s.compression.Threshold, codec.DefaultCompressionThreshold) {
+		return s.Service.Save(ctx, req)
+	}
+	mediaType, params, err := mime.ParseMediaType(blob.MIMEType)
+	if err != nil {
+		return s.Service.Save(ctx, req)
+	}
+	data, err := s.compression.Compressor.Compress(blob.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress artifact: %w", err)
+	}
+	if len(data) >= len(blob.Data) {
+		return s.Service.Save(ctx, req)
+	}
+	params[compressionParam] = s.compression.Compressor.Name()
+	params[sizeParam] = strconv.Itoa(len(blob.Data))
+	blob.MIMEType = mime.FormatMediaType(mediaType, params)
+	blob.Data = data
+	compressed := *req
+	part := *req.Part
+	part.InlineData = &blob
+	compressed.Part = &part
+	return s.Service.Save(ctx, &compressed)
+}
+
+func (s *compressedService) Load(ctx context.Context, req *LoadRequest) (*LoadResponse, error) {
+	resp, err := s.Service.Load(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Part == nil || resp.Part.InlineData == nil {
+		return resp, nil
+	}
+	compression, err := parseCompression(resp.Part.InlineData.MIMEType)
+	if err != nil || compression.compressor == nil {
+		return resp, err
+	}
+	data, err := compression.compressor.Decompress(resp.Part.InlineData.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress artifact: %w", err)
+	}
+	part := *resp.Part
+	part.InlineData = &genai.Blob{
+		Data:        data,
+		DisplayName: resp.Part.InlineData.DisplayName,
+		MIMEType:    compression.mimeType,
+	}
+	return &LoadResponse{Part: &part}, nil
+}
+
+// artifactCompression is the compression recorded in the MIME type of a
+// stored artifact.
+type artifactCompression struct {
+	// compressor is nil if the artifact is not compressed.
+	compressor codec.Compressor
+	// mimeType is the MIME type of the artifact without the parameters of
+	// the compression.
+	mimeType string
+	// size is the size of the artifact before its compression.
+	size int64
+}
+
+func parseCompression(mimeType string) (artifactCompression, error) {
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil || params[compressionParam] == "" {
+		return artifactCompression{mimeType: mimeType}, nil
+	}
+	compressor, err := codec.LookupCompressor(params[compressionParam])
+	if err == nil && compressor == nil {
+		err = fmt.Errorf("%w %q", codec.ErrUnknownCodec, params[compressionParam])
+	}
+	if err != nil {
+		return artifactCompression{}, fmt.Errorf("failed to decompress artifact: %w", err)
+	}
+	size, err := strconv.ParseInt(params[sizeParam], 10, 64)
+	if err != nil || size < 0 {
+		return artifactCompression{}, fmt.Errorf("failed to decompress artifact: invalid %s parameter %q", sizeParam, params[sizeParam])
+	}
+	delete(params, compressionParam)
+	delete(params, sizeParam)
+	return artifactCompression{
+		compressor: compressor,
+		mimeType:   mime.FormatMediaType(mediaType, params),
+		size:       size,
+	}, nil
+}
+
+type compressedStreamService struct {
+	*compressedService
+	stream StreamService
+}
+
+// Open implements [StreamService]. The compressed artifacts are streamed
+// through their decompressor.
+func (s *compressedStreamService) Open(ctx context.Context, req *LoadRequest) (*OpenResponse, error) {
+	resp, err := s.stream.Open(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := parseCompression(resp.MIMEType)
+	if err != nil {
+		resp.Content.Close()
+		return nil, err
+	}
+	if compression.compressor == nil {
+		return resp, nil
+	}
+	return &OpenResponse{
+		Content:  &decompressReader{compressor: compression.compressor, stored: resp.Content, size: compression.size},
+		MIMEType: compression.mimeType,
+		Size:     compression.size,
+		Version:  resp.Version,
+	}, nil
+}
+
+// SaveStream implements [StreamService]. The content is stored as is.
+func (s *compressedStreamService) SaveStream(ctx context.Context, req *SaveStreamRequest) (*SaveResponse, error) {
+	return s.stream.SaveStream(ctx, req)
+}
+
+// decompressReader reads the decompression of a stored artifact. The seeks
+// are applied by the next Read, so that seeking to the end to get the size,
+// as http.ServeContent does, decompresses nothing.
+type decompressReader struct {
+	compressor codec.Compressor
+	stored     io.ReadSeekCloser
+	size       int64
+
+	// r reads the decompression from the position pos, nil until the first
+	// Read.
+	r   io.ReadCloser
+	pos int64
+	// offset is the position of the next Read.
+	offset int64
+}
+
+func (d *decompressReader) Read(p []byte) (int, error) {
+	if d.r == nil || d.offset < d.pos {
+		if err := d.restart(); err != nil {
+			return 0, err
+		}
+	}
+	if d.offset > d.pos {
+		n, err := io.CopyN(io.Discard, d.r, d.offset-d.pos)
+		d.pos += n
+		if err == io.EOF {
+			// Past the end of the artifact.
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to decompress artifact: %w", err)
+		}
+	}
+	n, err := d.r.Read(p)
+	d.pos += int64(n)
+	d.offset = d.pos
+	if err != nil && err != io.EOF {
+		err = fmt.Errorf("failed to decompress artifact: %w", err)
+	}
+	return n, err
+}
+
+// restart decompresses the stored artifact from its start.
+func (d *decompressReader) restart() error {
+	if d.r != nil {
+		d.r.Close()
+		d.r = nil
+		if _, err := d.stored.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to read artifact: %w", err)
+		}
+	}
+	r, err := codec.NewDecompressReader(d.compressor, d.stored)
+	if err != nil {
+		return fmt.Errorf("failed to decompress artifact: %w", err)
+	}
+	d.r, d.pos = r, 0
+	return nil
+}
+
+func (d *decompressReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += d.offset
+	case io.SeekEnd:
+		offset += d.size
+	}
+	if offset < 0 {
+		return 0, errors.New("decompressReader.Seek: negative position")
+	}
+	d.offset = offset
+	return offset, nil
+}
+
+func (d *decompressReader) Close() error {
+	if d.r != nil {
+		d.r.Close()
+	}
+	return d.stored.Close()
+}
+
+var _ StreamService = (*compressedStreamService)(nil)
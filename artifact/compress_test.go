@@ -0,0 +1,133 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/codec"
+	"google.golang.org/adk/internal/artifact/tests"
+)
+
+func TestCompressedService(t *testing.T) {
+	factory := func(t *testing.T) (artifact.Service, error) {
+		return artifact.CompressedService(artifact.InMemoryService(), codec.Compression{Compressor: codec.Zstd, Threshold: 1}), nil
+	}
+	tests.TestArtifactService(t, "Compressed", factory)
+}
+
+func TestCompressedService_Storage(t *testing.T) {
+	ctx := t.Context()
+	inner := artifact.InMemoryService()
+	s := artifact.CompressedService(inner, codec.Compression{Compressor: codec.Gzip})
+	data := []byte(strings.Repeat("a large tool output\n", 500))
+	_, err := s.Save(ctx, &artifact.SaveRequest{
+		AppName: "app", UserID: "user", SessionID: "session", FileName: "output.txt",
+		Part: genai.NewPartFromBytes(data, "text/plain"),
+	})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	req := &artifact.LoadRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: "output.txt"}
+	stored, err := inner.Load(ctx, req)
+	if err != nil {
+		t.Fatalf("Load() of the inner service error = %v", err)
+	}
+	if got := stored.Part.InlineData.Data; len(got) >= len(data) {
+		t.Errorf("stored artifact of %d bytes, want it compressed below %d bytes", len(got), len(data))
+	}
+	// The compression is recorded in the MIME type, not sniffed.
+	if got, want := stored.Part.InlineData.MIMEType, "text/plain; adk-compression=gzip; adk-size=10000"; got != want {
+		t.Errorf("stored MIME type = %q, want %q", got, want)
+	}
+
+	loaded, err := s.Load(ctx, req)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !bytes.Equal(loaded.Part.InlineData.Data, data) || loaded.Part.InlineData.MIMEType != "text/plain" {
+		t.Errorf("Load() = %d bytes of %s, want the %d bytes saved", len(loaded.Part.InlineData.Data), loaded.Part.InlineData.MIMEType, len(data))
+	}
+
+	opened, err := s.(artifact.StreamService).Open(ctx, req)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer opened.Content.Close()
+	got, err := io.ReadAll(opened.Content)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !bytes.Equal(got, data) || opened.Size != int64(len(data)) {
+		t.Errorf("Open() read %d bytes of size %d, want the %d bytes saved", len(got), opened.Size, len(data))
+	}
+	if opened.MIMEType != "text/plain" {
+		t.Errorf("Open() MIME type = %q, want %q", opened.MIMEType, "text/plain")
+	}
+
+	// The decompressed content is seekable, backward and past the end.
+	for _, offset := range []int64{100, 10, int64(len(data))} {
+		if _, err := opened.Content.Seek(offset, io.SeekStart); err != nil {
+			t.Fatalf("Seek(%d) error = %v", offset, err)
+		}
+		got, err := io.ReadAll(io.LimitReader(opened.Content, 20))
+		if err != nil {
+			t.Fatalf("ReadAll() after Seek(%d) error = %v", offset, err)
+		}
+		if want := data[offset:min(offset+20, int64(len(data)))]; !bytes.Equal(got, want) {
+			t.Errorf("read %q after Seek(%d), want %q", got, offset, want)
+		}
+	}
+	if size, err := opened.Content.Seek(0, io.SeekEnd); err != nil || size != int64(len(data)) {
+		t.Errorf("Seek(0, io.SeekEnd) = %d, %v, want %d", size, err, len(data))
+	}
+
+	// The artifacts saved without compression are read as is, whatever
+	// their content.
+	header, err := codec.Compression{Compressor: codec.Gzip, Threshold: 1}.Compress([]byte(strings.Repeat("plain ", 100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inner.Save(ctx, &artifact.SaveRequest{
+		AppName: "app", UserID: "user", SessionID: "session", FileName: "header.bin",
+		Part: genai.NewPartFromBytes(header, "application/octet-stream"),
+	}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if loaded, err := s.Load(ctx, &artifact.LoadRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: "header.bin"}); err != nil || !bytes.Equal(loaded.Part.InlineData.Data, header) {
+		t.Errorf("Load() of an uncompressed artifact = %v, want its content as is", err)
+	}
+	if _, err := inner.Save(ctx, &artifact.SaveRequest{
+		AppName: "app", UserID: "user", SessionID: "session", FileName: "plain.bin",
+		Part: genai.NewPartFromBytes([]byte("plain"), "application/octet-stream"),
+	}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	opened, err = s.(artifact.StreamService).Open(ctx, &artifact.LoadRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: "plain.bin"})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer opened.Content.Close()
+	if got, err := io.ReadAll(opened.Content); err != nil || string(got) != "plain" {
+		t.Errorf("Open() of an uncompressed artifact read %q, %v, want %q", got, err, "plain")
+	}
+}
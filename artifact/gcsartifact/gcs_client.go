@@ -37,6 +37,9 @@ type gcsBucket interface {
 type gcsObject interface {
 	newWriter(ctx context.Context) gcsWriter
 	newReader(ctx context.Context) (io.ReadCloser, error)
+	// newRangeReader reads length bytes from offset, up to the end if
+	// length is negative.
+	newRangeReader(ctx context.Context, offset, length int64) (io.ReadCloser, error)
 	delete(ctx context.Context) error
 	attrs(ctx context.Context) (*storage.ObjectAttrs, error)
 }
@@ -102,6 +105,11 @@ func (w *gcsObjectWrapper) newReader(ctx context.Context) (io.ReadCloser, error)
 	return w.object.NewReader(ctx)
 }
 
+// newRangeReader implements the gcsObject interface for gcsObjectWrapper.
+func (w *gcsObjectWrapper) newRangeReader(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	return w.object.NewRangeReader(ctx, offset, length)
+}
+
 // Delete implements the gcsObject interface for gcsObjectWrapper.
 func (w *gcsObjectWrapper) delete(ctx context.Context) error {
 	return w.object.Delete(ctx)
@@ -49,6 +49,35 @@ func TestGCSArtifactService(t *testing.T) {
 	tests.TestArtifactService(t, "GCS", factory)
 }
 
+func TestGCSArtifactService_OpenReadsRanges(t *testing.T) {
+	ctx := t.Context()
+	client := newFakeClient()
+	s := &gcsService{bucketName: "new", storageClient: client, bucket: client.bucket("new")}
+	if _, err := s.SaveStream(ctx, &artifact.SaveStreamRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: "video.mp4", MIMEType: "video/mp4", Content: strings.NewReader("0123456789")}); err != nil {
+		t.Fatalf("SaveStream() error = %v", err)
+	}
+	resp, err := s.Open(ctx, &artifact.LoadRequest{AppName: "app", UserID: "user", SessionID: "session", FileName: "video.mp4"})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer resp.Content.Close()
+	blob := client.bucket("new").object(buildBlobName("app", "user", "session", "video.mp4", 1)).(*fakeObject)
+	// Seeking to the end, as http.ServeContent does to get the size, reads nothing.
+	if _, err := resp.Content.Seek(0, io.SeekEnd); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resp.Content.Seek(6, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(resp.Content)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(got) != "6789" || blob.rangeReads != 1 {
+		t.Errorf("read %q with %d range reads, want %q with 1", got, blob.rangeReads, "6789")
+	}
+}
+
 // ---------------------------------- Mock Implementations -----------------------------------
 // fakeClient implements the gcsClient interface for testing.
 type fakeClient struct {
@@ -114,6 +143,7 @@ type fakeObject struct {
 	data        []byte
 	deleted     bool
 	contentType string
+	rangeReads  int
 }
 
 // NewWriter returns a fake writer that stores data in memory.
@@ -132,7 +162,7 @@ func (f *fakeObject) attrs(ctx context.Context) (*storage.ObjectAttrs, error) {
 	if f.deleted || f.data == nil {
 		return nil, storage.ErrObjectNotExist
 	}
-	return &storage.ObjectAttrs{Name: f.name, Created: time.Now(), ContentType: f.contentType}, nil
+	return &storage.ObjectAttrs{Name: f.name, Created: time.Now(), ContentType: f.contentType, Size: int64(len(f.data))}, nil
 }
 
 // Delete marks the object as deleted in memory.
@@ -153,6 +183,21 @@ func (f *fakeObject) newReader(ctx context.Context) (io.ReadCloser, error) {
 	return io.NopCloser(bytes.NewReader(f.data)), nil
 }
 
+// newRangeReader returns a reader for a range of the in-memory data.
+func (f *fakeObject) newRangeReader(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.deleted || f.data == nil {
+		return nil, fs.ErrNotExist
+	}
+	f.rangeReads++
+	data := f.data[min(offset, int64(len(f.data))):]
+	if length >= 0 && length < int64(len(data)) {
+		data = data[:length]
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
 // fakeWriter is a helper type to simulate an *storage.Writer
 type fakeWriter struct {
 	obj         *fakeObject
@@ -99,18 +99,9 @@ func (s *gcsService) Save(ctx context.Context, req *artifact.SaveRequest) (_ *ar
 	appName, userID, sessionID, fileName := req.AppName, req.UserID, req.SessionID, req.FileName
 	newArtifact := req.Part
 
-	nextVersion := int64(1)
-
-	// TODO race condition, could use mutex but it's a remote resource so the issue would still occurs
-	// with multiple consumers, and gcs does not have transactions spanning several operations
-	response, err := s.versions(ctx, &artifact.VersionsRequest{
-		AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID, FileName: req.FileName,
-	})
+	nextVersion, err := s.nextVersion(ctx, appName, userID, sessionID, fileName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list artifact versions: %w", err)
-	}
-	if len(response.Versions) > 0 {
-		nextVersion = slices.Max(response.Versions) + 1
+		return nil, err
 	}
 
 	blobName := buildBlobName(appName, userID, sessionID, fileName, nextVersion)
@@ -136,6 +127,51 @@ func (s *gcsService) Save(ctx context.Context, req *artifact.SaveRequest) (_ *ar
 	return &artifact.SaveResponse{Version: nextVersion}, nil
 }
 
+// nextVersion returns the version of the next save of an artifact.
+func (s *gcsService) nextVersion(ctx context.Context, appName, userID, sessionID, fileName string) (int64, error) {
+	// TODO race condition, could use mutex but it's a remote resource so the issue would still occurs
+	// with multiple consumers, and gcs does not have transactions spanning several operations
+	response, err := s.versions(ctx, &artifact.VersionsRequest{
+		AppName: appName, UserID: userID, SessionID: sessionID, FileName: fileName,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list artifact versions: %w", err)
+	}
+	if len(response.Versions) == 0 {
+		return 1, nil
+	}
+	return slices.Max(response.Versions) + 1, nil
+}
+
+// SaveStream implements [artifact.StreamService]. The content is copied to
+// the blob as it is read.
+func (s *gcsService) SaveStream(ctx context.Context, req *artifact.SaveStreamRequest) (_ *artifact.SaveResponse, err error) {
+	err = req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("request validation failed: %w", err)
+	}
+	appName, userID, sessionID, fileName := req.AppName, req.UserID, req.SessionID, req.FileName
+	nextVersion, err := s.nextVersion(ctx, appName, userID, sessionID, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancelling the context of the writer aborts the upload of a partial blob.
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	writer := s.bucket.object(buildBlobName(appName, userID, sessionID, fileName, nextVersion)).newWriter(writeCtx)
+	writer.SetContentType(req.MIMEType)
+	if _, err := io.Copy(writer, req.Content); err != nil {
+		cancel()
+		_ = writer.Close()
+		return nil, fmt.Errorf("failed to write blob to GCS: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close blob writer: %w", err)
+	}
+	return &artifact.SaveResponse{Version: nextVersion}, nil
+}
+
 // Delete implements [artifact.Service]
 func (s *gcsService) Delete(ctx context.Context, req *artifact.DeleteRequest) error {
 	err := req.Validate()
@@ -238,6 +274,100 @@ func (s *gcsService) Load(ctx context.Context, req *artifact.LoadRequest) (_ *ar
 	return &artifact.LoadResponse{Part: part}, nil
 }
 
+// Open implements [artifact.StreamService]. The blob is read with range
+// requests starting at the offset of the returned reader.
+func (s *gcsService) Open(ctx context.Context, req *artifact.LoadRequest) (*artifact.OpenResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("request validation failed: %w", err)
+	}
+	appName, userID, sessionID, fileName := req.AppName, req.UserID, req.SessionID, req.FileName
+	version := req.Version
+
+	if version == 0 {
+		response, err := s.versions(ctx, &artifact.VersionsRequest{
+			AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID, FileName: req.FileName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifact versions: %w", err)
+		}
+		if len(response.Versions) == 0 {
+			return nil, fmt.Errorf("artifact not found: %w", fs.ErrNotExist)
+		}
+		version = slices.Max(response.Versions)
+	}
+
+	blobName := buildBlobName(appName, userID, sessionID, fileName, version)
+	blob := s.bucket.object(blobName)
+	attrs, err := blob.attrs(ctx)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return nil, fmt.Errorf("artifact '%s' not found: %w", blobName, fs.ErrNotExist)
+		}
+		return nil, fmt.Errorf("could not get blob attributes: %w", err)
+	}
+	return &artifact.OpenResponse{
+		Content:  &blobReader{ctx: ctx, blob: blob, size: attrs.Size},
+		MIMEType: attrs.ContentType,
+		Size:     attrs.Size,
+		Version:  version,
+	}, nil
+}
+
+// blobReader reads a blob from its offset, opening a range reader on the
+// first read after a seek.
+type blobReader struct {
+	ctx    context.Context
+	blob   gcsObject
+	size   int64
+	offset int64
+	reader io.ReadCloser
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.reader == nil {
+		reader, err := r.blob.newRangeReader(r.ctx, r.offset, -1)
+		if err != nil {
+			return 0, fmt.Errorf("could not create reader for blob: %w", err)
+		}
+		r.reader = reader
+	}
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *blobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("seek to negative offset %d", offset)
+	}
+	if offset != r.offset {
+		if err := r.Close(); err != nil {
+			return 0, err
+		}
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+func (r *blobReader) Close() error {
+	if r.reader == nil {
+		return nil
+	}
+	err := r.reader.Close()
+	r.reader = nil
+	return err
+}
+
 // fetchFilenamesFromPrefix is a reusable helper function.
 func (s *gcsService) fetchFilenamesFromPrefix(ctx context.Context, prefix string, filenamesSet map[string]bool) error {
 	// Add a guard clause to prevent a panic if a nil map is passed.
@@ -350,3 +480,5 @@ func (s *gcsService) Versions(ctx context.Context, req *artifact.VersionsRequest
 	}
 	return response, nil
 }
+
+var _ artifact.StreamService = (*gcsService)(nil)
@@ -15,8 +15,10 @@
 package artifact
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/fs"
 	"iter"
 	"maps"
@@ -285,4 +287,68 @@ func (s *inMemoryService) Versions(ctx context.Context, req *VersionsRequest) (*
 	return &VersionsResponse{Versions: versions}, nil
 }
 
-var _ Service = (*inMemoryService)(nil)
+// Open implements [artifact.StreamService]. The content is read from the
+// stored part, without copying it.
+func (s *inMemoryService) Open(ctx context.Context, req *LoadRequest) (*OpenResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("request validation failed: %w", err)
+	}
+	appName, userID, sessionID, fileName := req.AppName, req.UserID, req.SessionID, req.FileName
+	version := req.Version
+	if fileHasUserNamespace(fileName) {
+		sessionID = userScopedArtifactKey
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var artifact *genai.Part
+	var ok bool
+	if version > 0 {
+		artifact, ok = s.get(appName, userID, sessionID, fileName, version)
+	} else {
+		version, artifact, ok = s.find(appName, userID, sessionID, fileName)
+	}
+	if !ok {
+		return nil, fmt.Errorf("artifact not found: %w", fs.ErrNotExist)
+	}
+	resp := &OpenResponse{Version: version}
+	var data []byte
+	if artifact.InlineData != nil {
+		data, resp.MIMEType = artifact.InlineData.Data, artifact.InlineData.MIMEType
+	} else {
+		data, resp.MIMEType = []byte(artifact.Text), "text/plain; charset=utf-8"
+	}
+	resp.Content, resp.Size = nopSeekCloser{bytes.NewReader(data)}, int64(len(data))
+	return resp, nil
+}
+
+// SaveStream implements [artifact.StreamService]. The content is read whole,
+// as it is kept in memory.
+func (s *inMemoryService) SaveStream(ctx context.Context, req *SaveStreamRequest) (*SaveResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("request validation failed: %w", err)
+	}
+	data, err := io.ReadAll(req.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact content: %w", err)
+	}
+	return s.Save(ctx, &SaveRequest{
+		AppName:   req.AppName,
+		UserID:    req.UserID,
+		SessionID: req.SessionID,
+		FileName:  req.FileName,
+		Part:      genai.NewPartFromBytes(data, req.MIMEType),
+	})
+}
+
+// nopSeekCloser is a reader of content in memory, which needs no closing.
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
+var _ StreamService = (*inMemoryService)(nil)
@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// StreamService is implemented by the artifact services able to stream the
+// content of the artifacts, so that large blobs are not held in memory by the
+// callers, e.g. the REST API serving downloads and uploads.
+type StreamService interface {
+	Service
+	// Open returns a reader of the content of an artifact, the latest version
+	// unless req.Version is set.
+	Open(ctx context.Context, req *LoadRequest) (*OpenResponse, error)
+	// SaveStream saves the content read from req.Content as a new version of
+	// the artifact.
+	SaveStream(ctx context.Context, req *SaveStreamRequest) (*SaveResponse, error)
+}
+
+// OpenResponse is the return type of [StreamService.Open].
+type OpenResponse struct {
+	// Content reads the content of the artifact. Seeking does not read the
+	// skipped content, so that ranges of large artifacts are served without
+	// reading them whole. It must be closed.
+	Content io.ReadSeekCloser
+	// MIMEType is the MIME type of the content.
+	MIMEType string
+	// Size is the size of the content in bytes.
+	Size int64
+	// Version is the version opened.
+	Version int64
+}
+
+// SaveStreamRequest is the parameter for [StreamService.SaveStream].
+type SaveStreamRequest struct {
+	AppName, UserID, SessionID, FileName string
+	// MIMEType is the MIME type of the content.
+	MIMEType string
+	// Content is read until io.EOF.
+	Content io.Reader
+}
+
+// Validate checks if the struct is valid or if it is missing fields.
+func (req *SaveStreamRequest) Validate() error {
+	fieldsToCheck := []requiredField{
+		{Name: "AppName", Value: req.AppName},
+		{Name: "UserID", Value: req.UserID},
+		{Name: "SessionID", Value: req.SessionID},
+		{Name: "FileName", Value: req.FileName},
+	}
+	missingFields := validateRequiredStrings(fieldsToCheck)
+	if req.Content == nil {
+		missingFields = append(missingFields, "Content")
+	}
+	if len(missingFields) > 0 {
+		return fmt.Errorf("invalid save stream request: missing required fields: %s", strings.Join(missingFields, ", "))
+	}
+	return validateFileName(req.FileName)
+}
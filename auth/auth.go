@@ -0,0 +1,221 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth provides the authentication of tools calling services on
+// behalf of the user.
+//
+// A tool declares what it needs with a [Config] and gets the credential with
+// tool.Context.Credential. If the user has not authorized the tool yet, the
+// tool calls tool.Context.RequestCredential: ADK emits a [FunctionCallName]
+// function call, the client obtains the credential, e.g. lets the user go
+// through the OAuth2 consent screen, and responds with it. ADK then runs the
+// tool again, which gets the credential, exchanged with an [Exchanger] and
+// stored per user in a [Store], so that the user authorizes only once.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// FunctionCallName is the name of the FunctionCall emitted by ADK when a tool
+// requests a credential.
+//
+// The 'args' of this FunctionCall are the [ToolArguments]: the ID of the
+// function call of the tool and the [Config] of the credential. For OAuth2,
+// the exchanged credential of the config holds the URI to send the user to.
+//
+// Client applications must respond with a FunctionResponse with the same ID
+// and name, whose response is the config with the exchanged credential set
+// by the client, e.g. the OAuth2 redirect URI with the authorization code,
+// or an API key entered by the user.
+const FunctionCallName = "adk_request_credential"
+
+// Type is the type of an authentication scheme and of its credentials.
+type Type string
+
+const (
+	// TypeAPIKey is an API key, sent in a header, a query parameter or a
+	// cookie.
+	TypeAPIKey Type = "apiKey"
+	// TypeHTTP is an HTTP authentication scheme, e.g. a bearer token.
+	TypeHTTP Type = "http"
+	// TypeOAuth2 is OAuth2 with the authorization code flow.
+	TypeOAuth2 Type = "oauth2"
+	// TypeOpenIDConnect is OpenID Connect, with the authorization code flow.
+	TypeOpenIDConnect Type = "openIdConnect"
+)
+
+// Scheme describes how a service authenticates its callers, after the
+// security schemes of OpenAPI.
+type Scheme struct {
+	Type Type `json:"type"`
+	// In is where API keys are sent: "header", "query" or "cookie".
+	In string `json:"in,omitempty"`
+	// Name is the name of the header, query parameter or cookie of API keys.
+	Name string `json:"name,omitempty"`
+	// HTTPScheme is the scheme of the Authorization header of HTTP
+	// authentication, e.g. "bearer".
+	HTTPScheme string `json:"scheme,omitempty"`
+	// AuthorizationURL is the authorization endpoint of OAuth2 and OpenID
+	// Connect.
+	AuthorizationURL string `json:"authorizationUrl,omitempty"`
+	// TokenURL is the token endpoint of OAuth2 and OpenID Connect.
+	TokenURL string `json:"tokenUrl,omitempty"`
+	// Scopes are the OAuth2 scopes requested.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// Credential is a credential of a [Scheme].
+type Credential struct {
+	Type Type `json:"authType"`
+	// APIKey is the key of TypeAPIKey credentials.
+	APIKey string `json:"apiKey,omitempty"`
+	// HTTP is the credential of TypeHTTP credentials.
+	HTTP *HTTPCredential `json:"http,omitempty"`
+	// OAuth2 is the credential of TypeOAuth2 and TypeOpenIDConnect
+	// credentials.
+	OAuth2 *OAuth2Credential `json:"oauth2,omitempty"`
+}
+
+// HTTPCredential is the credential of HTTP authentication.
+type HTTPCredential struct {
+	// Scheme is the scheme of the Authorization header, e.g. "bearer".
+	Scheme string `json:"scheme"`
+	Token  string `json:"token,omitempty"`
+}
+
+// OAuth2Credential is the client of an OAuth2 service, the state of an
+// authorization request, or the tokens obtained for the user, depending on
+// the stage of the auth flow.
+type OAuth2Credential struct {
+	ClientID     string `json:"clientId,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+	// AuthURI is the URI the user is sent to, to authorize the client.
+	AuthURI string `json:"authUri,omitempty"`
+	// State is the state parameter of the authorization request.
+	State       string `json:"state,omitempty"`
+	RedirectURI string `json:"redirectUri,omitempty"`
+	// AuthResponseURI is the redirect URI called back by the authorization
+	// server, with the authorization code.
+	AuthResponseURI string `json:"authResponseUri,omitempty"`
+	AuthCode        string `json:"authCode,omitempty"`
+
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	// ExpiresAt is the expiry of the access token, in seconds since the
+	// Unix epoch, or 0 if it does not expire.
+	ExpiresAt int64 `json:"expiresAt,omitempty"`
+}
+
+// expiryDelta is how long before their expiry the access tokens are
+// considered expired, so that they are not sent when about to expire.
+const expiryDelta = 30 * time.Second
+
+// Expired reports whether c is an OAuth2 credential whose access token has
+// expired, or is about to.
+func (c *Credential) Expired() bool {
+	if c == nil || c.OAuth2 == nil || c.OAuth2.ExpiresAt == 0 {
+		return false
+	}
+	return !time.Now().Add(expiryDelta).Before(time.Unix(c.OAuth2.ExpiresAt, 0))
+}
+
+// Config is the authentication required by a tool.
+type Config struct {
+	Scheme Scheme `json:"authScheme"`
+	// RawCredential is the credential configured for the tool: the OAuth2
+	// client, or the credential itself, e.g. an API key. It is never sent
+	// to the client.
+	RawCredential *Credential `json:"rawAuthCredential,omitempty"`
+	// ExchangedCredential is the credential of an auth request: set by ADK
+	// for the client, e.g. with the OAuth2 authorization URI, then by the
+	// client in its response.
+	ExchangedCredential *Credential `json:"exchangedAuthCredential,omitempty"`
+	// CredentialKey identifies the credential in the [Store]. Defaults to a
+	// key derived from the scheme and the OAuth2 client, see [Config.Key].
+	CredentialKey string `json:"credentialKey,omitempty"`
+}
+
+// Key returns the CredentialKey of c, or a key derived from its scheme and
+// OAuth2 client if unset.
+func (c *Config) Key() string {
+	if c.CredentialKey != "" {
+		return c.CredentialKey
+	}
+	scheme, _ := json.Marshal(c.Scheme)
+	h := sha256.New()
+	h.Write(scheme)
+	if raw := c.RawCredential; raw != nil && raw.OAuth2 != nil {
+		h.Write([]byte(raw.OAuth2.ClientID))
+	}
+	return fmt.Sprintf("adk_%s_%s", c.Scheme.Type, hex.EncodeToString(h.Sum(nil)[:8]))
+}
+
+// ToolArguments are the arguments of the [FunctionCallName] function calls.
+type ToolArguments struct {
+	// FunctionCallID is the ID of the function call of the tool which
+	// requested the credential.
+	FunctionCallID string `json:"functionCallId"`
+	AuthConfig     Config `json:"authConfig"`
+}
+
+// NewRequest returns the config sent to the client to request the credential
+// of c. It has no RawCredential, so that the secrets of the tool are not
+// sent. For OAuth2 and OpenID Connect, its ExchangedCredential holds the
+// authorization URI and a new state.
+func NewRequest(c Config) (Config, error) {
+	req := Config{Scheme: c.Scheme, CredentialKey: c.Key()}
+	if c.Scheme.Type != TypeOAuth2 && c.Scheme.Type != TypeOpenIDConnect {
+		return req, nil
+	}
+	if c.RawCredential == nil || c.RawCredential.OAuth2 == nil || c.RawCredential.OAuth2.ClientID == "" {
+		return Config{}, fmt.Errorf("%s auth requires the OAuth2 client in the raw credential", c.Scheme.Type)
+	}
+	state := rand.Text()
+	client := c.RawCredential.OAuth2
+	req.ExchangedCredential = &Credential{
+		Type: c.Scheme.Type,
+		OAuth2: &OAuth2Credential{
+			ClientID:    client.ClientID,
+			AuthURI:     oauth2Config(c, client.RedirectURI).AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce),
+			State:       state,
+			RedirectURI: client.RedirectURI,
+		},
+	}
+	return req, nil
+}
+
+// oauth2Config returns the OAuth2 client of c.
+func oauth2Config(c Config, redirectURI string) *oauth2.Config {
+	cfg := &oauth2.Config{
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  c.Scheme.AuthorizationURL,
+			TokenURL: c.Scheme.TokenURL,
+		},
+		RedirectURL: redirectURI,
+		Scopes:      c.Scheme.Scopes,
+	}
+	if c.RawCredential != nil && c.RawCredential.OAuth2 != nil {
+		cfg.ClientID = c.RawCredential.OAuth2.ClientID
+		cfg.ClientSecret = c.RawCredential.OAuth2.ClientSecret
+	}
+	return cfg
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func oauth2TestConfig() Config {
+	return Config{
+		Scheme: Scheme{
+			Type:             TypeOAuth2,
+			AuthorizationURL: "https://auth.example.com/authorize",
+			TokenURL:         "https://auth.example.com/token",
+			Scopes:           []string{"read", "write"},
+		},
+		RawCredential: &Credential{
+			Type: TypeOAuth2,
+			OAuth2: &OAuth2Credential{
+				ClientID:     "client",
+				ClientSecret: "secret",
+				RedirectURI:  "https://app.example.com/callback",
+			},
+		},
+	}
+}
+
+func TestConfig_Key(t *testing.T) {
+	cfg := oauth2TestConfig()
+	key := cfg.Key()
+	if !strings.HasPrefix(key, "adk_oauth2_") {
+		t.Errorf("Key() = %q, want prefix adk_oauth2_", key)
+	}
+
+	same := oauth2TestConfig()
+	same.RawCredential.OAuth2.ClientSecret = "rotated"
+	if got := same.Key(); got != key {
+		t.Errorf("Key() with another client secret = %q, want %q", got, key)
+	}
+
+	otherClient := oauth2TestConfig()
+	otherClient.RawCredential.OAuth2.ClientID = "other"
+	if got := otherClient.Key(); got == key {
+		t.Errorf("Key() with another client = %q, want a different key", got)
+	}
+
+	otherScopes := oauth2TestConfig()
+	otherScopes.Scheme.Scopes = []string{"read"}
+	if got := otherScopes.Key(); got == key {
+		t.Errorf("Key() with other scopes = %q, want a different key", got)
+	}
+
+	explicit := oauth2TestConfig()
+	explicit.CredentialKey = "my_key"
+	if got := explicit.Key(); got != "my_key" {
+		t.Errorf("Key() = %q, want my_key", got)
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	cfg := oauth2TestConfig()
+	req, err := NewRequest(cfg)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if req.RawCredential != nil {
+		t.Errorf("NewRequest() RawCredential = %+v, want nil", req.RawCredential)
+	}
+	if req.CredentialKey != cfg.Key() {
+		t.Errorf("NewRequest() CredentialKey = %q, want %q", req.CredentialKey, cfg.Key())
+	}
+	got := req.ExchangedCredential.OAuth2
+	if got.State == "" {
+		t.Fatal("NewRequest() has no state")
+	}
+	if got.ClientSecret != "" {
+		t.Errorf("NewRequest() leaks the client secret")
+	}
+	u, err := url.Parse(got.AuthURI)
+	if err != nil {
+		t.Fatalf("invalid AuthURI %q: %v", got.AuthURI, err)
+	}
+	wantQuery := url.Values{
+		"client_id":     {"client"},
+		"redirect_uri":  {"https://app.example.com/callback"},
+		"response_type": {"code"},
+		"scope":         {"read write"},
+		"state":         {got.State},
+		"access_type":   {"offline"},
+		"prompt":        {"consent"},
+	}
+	if diff := cmp.Diff(wantQuery, u.Query()); diff != "" {
+		t.Errorf("AuthURI query mismatch (-want +got):\n%s", diff)
+	}
+
+	again, err := NewRequest(cfg)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if again.ExchangedCredential.OAuth2.State == got.State {
+		t.Errorf("NewRequest() reused the state %q", got.State)
+	}
+}
+
+func TestNewRequest_APIKey(t *testing.T) {
+	cfg := Config{Scheme: Scheme{Type: TypeAPIKey, In: "header", Name: "X-API-Key"}}
+	req, err := NewRequest(cfg)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	want := Config{Scheme: cfg.Scheme, CredentialKey: cfg.Key()}
+	if diff := cmp.Diff(want, req); diff != "" {
+		t.Errorf("NewRequest() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewRequest_NoClient(t *testing.T) {
+	cfg := oauth2TestConfig()
+	cfg.RawCredential = nil
+	if _, err := NewRequest(cfg); err == nil {
+		t.Error("NewRequest() without OAuth2 client succeeded, want error")
+	}
+}
+
+func TestCredential_Expired(t *testing.T) {
+	tests := []struct {
+		name string
+		cred *Credential
+		want bool
+	}{
+		{name: "nil"},
+		{name: "api key", cred: &Credential{Type: TypeAPIKey, APIKey: "key"}},
+		{name: "no expiry", cred: &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{AccessToken: "t"}}},
+		{
+			name: "valid",
+			cred: &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{ExpiresAt: time.Now().Add(time.Hour).Unix()}},
+		},
+		{
+			name: "about to expire",
+			cred: &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{ExpiresAt: time.Now().Add(10 * time.Second).Unix()}},
+			want: true,
+		},
+		{
+			name: "expired",
+			cred: &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{ExpiresAt: time.Now().Add(-time.Hour).Unix()}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cred.Expired(); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Exchanger turns the credentials returned by clients into the credentials
+// used by tools.
+type Exchanger interface {
+	// Exchange returns the credential of cfg from the credential the client
+	// responded with to its auth request, e.g. exchanges an OAuth2
+	// authorization code for tokens.
+	Exchange(ctx context.Context, cfg Config, response *Credential) (*Credential, error)
+	// Refresh returns a new credential for an expired one, e.g. with its
+	// OAuth2 refresh token.
+	Refresh(ctx context.Context, cfg Config, cred *Credential) (*Credential, error)
+}
+
+// ErrNotRefreshable is returned by Exchanger.Refresh for credentials which
+// can't be refreshed, e.g. without refresh token. The user has to authorize
+// the tool again.
+var ErrNotRefreshable = errors.New("credential can't be refreshed")
+
+// OAuth2Exchanger is the [Exchanger] of the authorization code flow of OAuth2
+// and OpenID Connect. The other credentials are used as the client returns
+// them.
+type OAuth2Exchanger struct {
+	// HTTPClient sends the requests to the token endpoints. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+var _ Exchanger = OAuth2Exchanger{}
+
+// Exchange implements Exchanger.
+func (e OAuth2Exchanger) Exchange(ctx context.Context, cfg Config, response *Credential) (*Credential, error) {
+	if response == nil {
+		return nil, fmt.Errorf("no credential in the auth response")
+	}
+	if cfg.Scheme.Type != TypeOAuth2 && cfg.Scheme.Type != TypeOpenIDConnect {
+		return response, nil
+	}
+	if response.OAuth2 == nil {
+		return nil, fmt.Errorf("no OAuth2 credential in the auth response")
+	}
+	if response.OAuth2.AccessToken != "" {
+		// The client exchanged the authorization code itself.
+		return &Credential{Type: cfg.Scheme.Type, OAuth2: tokenCredential(response.OAuth2)}, nil
+	}
+
+	code := response.OAuth2.AuthCode
+	if code == "" && response.OAuth2.AuthResponseURI != "" {
+		u, err := url.Parse(response.OAuth2.AuthResponseURI)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth response URI: %w", err)
+		}
+		code = u.Query().Get("code")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("no authorization code in the auth response")
+	}
+	redirectURI := response.OAuth2.RedirectURI
+	if redirectURI == "" && cfg.RawCredential != nil && cfg.RawCredential.OAuth2 != nil {
+		redirectURI = cfg.RawCredential.OAuth2.RedirectURI
+	}
+	token, err := oauth2Config(cfg, redirectURI).Exchange(e.context(ctx), code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange the authorization code: %w", err)
+	}
+	return &Credential{Type: cfg.Scheme.Type, OAuth2: fromToken(token)}, nil
+}
+
+// Refresh implements Exchanger.
+func (e OAuth2Exchanger) Refresh(ctx context.Context, cfg Config, cred *Credential) (*Credential, error) {
+	if cred == nil || cred.OAuth2 == nil || cred.OAuth2.RefreshToken == "" {
+		return nil, ErrNotRefreshable
+	}
+	expired := &oauth2.Token{RefreshToken: cred.OAuth2.RefreshToken, Expiry: time.Unix(1, 0)}
+	token, err := oauth2Config(cfg, "").TokenSource(e.context(ctx), expired).Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh the access token: %w", err)
+	}
+	refreshed := fromToken(token)
+	if refreshed.RefreshToken == "" {
+		// Refresh tokens are kept unless the server rotates them.
+		refreshed.RefreshToken = cred.OAuth2.RefreshToken
+	}
+	return &Credential{Type: cred.Type, OAuth2: refreshed}, nil
+}
+
+func (e OAuth2Exchanger) context(ctx context.Context) context.Context {
+	if e.HTTPClient == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, e.HTTPClient)
+}
+
+// tokenCredential returns the tokens of c, without the client and the
+// authorization request, which are not stored.
+func tokenCredential(c *OAuth2Credential) *OAuth2Credential {
+	return &OAuth2Credential{
+		AccessToken:  c.AccessToken,
+		RefreshToken: c.RefreshToken,
+		ExpiresAt:    c.ExpiresAt,
+	}
+}
+
+func fromToken(token *oauth2.Token) *OAuth2Credential {
+	c := &OAuth2Credential{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+	}
+	if !token.Expiry.IsZero() {
+		c.ExpiresAt = token.Expiry.Unix()
+	}
+	return c
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTokenServer returns a token endpoint issuing the given token response
+// and recording the forms of the requests.
+func newTokenServer(t *testing.T, response map[string]any, forms *[]map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+		}
+		form := make(map[string]string)
+		for k := range r.PostForm {
+			form[k] = r.PostForm.Get(k)
+		}
+		*forms = append(*forms, form)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOAuth2Exchanger_Exchange(t *testing.T) {
+	var forms []map[string]string
+	srv := newTokenServer(t, map[string]any{
+		"access_token":  "access",
+		"refresh_token": "refresh",
+		"token_type":    "Bearer",
+	}, &forms)
+	cfg := oauth2TestConfig()
+	cfg.Scheme.TokenURL = srv.URL
+
+	tests := []struct {
+		name     string
+		response *OAuth2Credential
+		wantCode string
+	}{
+		{
+			name:     "auth code",
+			response: &OAuth2Credential{AuthCode: "code1"},
+			wantCode: "code1",
+		},
+		{
+			name:     "auth response URI",
+			response: &OAuth2Credential{AuthResponseURI: "https://app.example.com/callback?code=code2&state=s"},
+			wantCode: "code2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forms = nil
+			got, err := OAuth2Exchanger{HTTPClient: srv.Client()}.Exchange(t.Context(), cfg, &Credential{Type: TypeOAuth2, OAuth2: tt.response})
+			if err != nil {
+				t.Fatalf("Exchange() error = %v", err)
+			}
+			want := &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{AccessToken: "access", RefreshToken: "refresh"}}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Exchange() mismatch (-want +got):\n%s", diff)
+			}
+			if len(forms) != 1 {
+				t.Fatalf("token endpoint called %d times, want 1", len(forms))
+			}
+			if forms[0]["code"] != tt.wantCode || forms[0]["grant_type"] != "authorization_code" {
+				t.Errorf("token request = %v, want code %q", forms[0], tt.wantCode)
+			}
+			if forms[0]["redirect_uri"] != "https://app.example.com/callback" {
+				t.Errorf("token request redirect_uri = %q", forms[0]["redirect_uri"])
+			}
+		})
+	}
+}
+
+func TestOAuth2Exchanger_ExchangeAccessToken(t *testing.T) {
+	cfg := oauth2TestConfig()
+	response := &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{
+		ClientID:    "client",
+		AccessToken: "access",
+		ExpiresAt:   42,
+	}}
+	got, err := OAuth2Exchanger{}.Exchange(t.Context(), cfg, response)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	want := &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{AccessToken: "access", ExpiresAt: 42}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Exchange() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOAuth2Exchanger_ExchangeAPIKey(t *testing.T) {
+	cfg := Config{Scheme: Scheme{Type: TypeAPIKey, In: "header", Name: "X-API-Key"}}
+	response := &Credential{Type: TypeAPIKey, APIKey: "key"}
+	got, err := OAuth2Exchanger{}.Exchange(t.Context(), cfg, response)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if diff := cmp.Diff(response, got); diff != "" {
+		t.Errorf("Exchange() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOAuth2Exchanger_ExchangeNoCode(t *testing.T) {
+	cfg := oauth2TestConfig()
+	_, err := OAuth2Exchanger{}.Exchange(t.Context(), cfg, &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{}})
+	if err == nil {
+		t.Error("Exchange() without code succeeded, want error")
+	}
+}
+
+func TestOAuth2Exchanger_Refresh(t *testing.T) {
+	var forms []map[string]string
+	srv := newTokenServer(t, map[string]any{
+		"access_token": "new-access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	}, &forms)
+	cfg := oauth2TestConfig()
+	cfg.Scheme.TokenURL = srv.URL
+
+	cred := &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{AccessToken: "old", RefreshToken: "refresh", ExpiresAt: 1}}
+	got, err := OAuth2Exchanger{HTTPClient: srv.Client()}.Refresh(t.Context(), cfg, cred)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got.OAuth2.AccessToken != "new-access" || got.OAuth2.RefreshToken != "refresh" {
+		t.Errorf("Refresh() = %+v, want the new access token and the old refresh token", got.OAuth2)
+	}
+	if got.Expired() {
+		t.Errorf("Refresh() returned an expired credential: %+v", got.OAuth2)
+	}
+	if len(forms) != 1 || forms[0]["grant_type"] != "refresh_token" || forms[0]["refresh_token"] != "refresh" {
+		t.Errorf("token requests = %v, want one refresh_token grant", forms)
+	}
+}
+
+func TestOAuth2Exchanger_RefreshWithoutRefreshToken(t *testing.T) {
+	cred := &Credential{Type: TypeOAuth2, OAuth2: &OAuth2Credential{AccessToken: "old", ExpiresAt: 1}}
+	_, err := OAuth2Exchanger{}.Refresh(t.Context(), oauth2TestConfig(), cred)
+	if !errors.Is(err, ErrNotRefreshable) {
+		t.Errorf("Refresh() error = %v, want ErrNotRefreshable", err)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secretmanagerstore provides an [auth.Store] keeping the credentials
+// in Google Cloud Secret Manager.
+//
+// Every credential of a user is a secret of the project, whose latest
+// version holds the credential. Saving a credential adds a version to its
+// secret; older versions can be cleaned up with the version destroy TTL of
+// the secrets.
+package secretmanagerstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"google.golang.org/adk/auth"
+)
+
+// secretIDPrefix is the prefix of the IDs of the secrets of the store.
+const secretIDPrefix = "adk-credential-"
+
+type secretManagerStore struct {
+	project string
+	secrets *secretmanager.ProjectsSecretsService
+}
+
+// New creates a Secret Manager store creating the secrets in the given
+// project.
+func New(ctx context.Context, project string, opts ...option.ClientOption) (auth.Store, error) {
+	if project == "" {
+		return nil, fmt.Errorf("project is required")
+	}
+	service, err := secretmanager.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret manager service: %w", err)
+	}
+	return &secretManagerStore{
+		project: project,
+		secrets: secretmanager.NewProjectsSecretsService(service),
+	}, nil
+}
+
+// secretID returns the ID of the secret of key. The app name and user ID may
+// contain characters not allowed in secret IDs, so they are hashed.
+func secretID(key auth.StoreKey) string {
+	h := sha256.New()
+	for _, s := range []string{key.AppName, key.UserID, key.CredentialKey} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return secretIDPrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *secretManagerStore) secretName(key auth.StoreKey) string {
+	return fmt.Sprintf("projects/%s/secrets/%s", s.project, secretID(key))
+}
+
+// Load implements [auth.Store].
+func (s *secretManagerStore) Load(ctx context.Context, key auth.StoreKey) (*auth.Credential, error) {
+	resp, err := s.secrets.Versions.Access(s.secretName(key) + "/versions/latest").Context(ctx).Do()
+	if isStatus(err, http.StatusNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to access the secret of the credential: %w", err)
+	}
+	if resp.Payload == nil {
+		return nil, fmt.Errorf("secret of the credential has no payload")
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the secret of the credential: %w", err)
+	}
+	var cred auth.Credential
+	if err := json.Unmarshal(data, &cred); err != nil {
+		return nil, fmt.Errorf("failed to decode the credential: %w", err)
+	}
+	return &cred, nil
+}
+
+// Save implements [auth.Store].
+func (s *secretManagerStore) Save(ctx context.Context, key auth.StoreKey, cred *auth.Credential) error {
+	data, err := json.Marshal(cred)
+	if err != nil {
+		return fmt.Errorf("failed to encode the credential: %w", err)
+	}
+	req := &secretmanager.AddSecretVersionRequest{
+		Payload: &secretmanager.SecretPayload{Data: base64.StdEncoding.EncodeToString(data)},
+	}
+	name := s.secretName(key)
+	_, err = s.secrets.AddVersion(name, req).Context(ctx).Do()
+	if isStatus(err, http.StatusNotFound) {
+		if err := s.createSecret(ctx, key); err != nil {
+			return err
+		}
+		_, err = s.secrets.AddVersion(name, req).Context(ctx).Do()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add a version to the secret of the credential: %w", err)
+	}
+	return nil
+}
+
+func (s *secretManagerStore) createSecret(ctx context.Context, key auth.StoreKey) error {
+	secret := &secretmanager.Secret{
+		Replication: &secretmanager.Replication{Automatic: &secretmanager.Automatic{}},
+		Annotations: map[string]string{
+			"adk-app-name":       key.AppName,
+			"adk-user-id":        key.UserID,
+			"adk-credential-key": key.CredentialKey,
+		},
+	}
+	_, err := s.secrets.Create("projects/"+s.project, secret).SecretId(secretID(key)).Context(ctx).Do()
+	// The secret may have been created by a concurrent save.
+	if err != nil && !isStatus(err, http.StatusConflict) {
+		return fmt.Errorf("failed to create the secret of the credential: %w", err)
+	}
+	return nil
+}
+
+func isStatus(err error, code int) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
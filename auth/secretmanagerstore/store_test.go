@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretmanagerstore
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"google.golang.org/adk/auth"
+)
+
+// fakeSecretManager serves the secrets of a project from memory.
+type fakeSecretManager struct {
+	mu sync.Mutex
+	// versions are the payloads of the versions of the secrets, by secret
+	// name.
+	versions map[string][]string
+	// annotations of the secrets, by secret name.
+	annotations map[string]map[string]string
+}
+
+func (f *fakeSecretManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/versions/latest:access"):
+		name := strings.TrimSuffix(path, "/versions/latest:access")
+		versions := f.versions[name]
+		if len(versions) == 0 {
+			writeError(w, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, &secretmanager.AccessSecretVersionResponse{
+			Payload: &secretmanager.SecretPayload{Data: versions[len(versions)-1]},
+		})
+	case r.Method == http.MethodPost && strings.HasSuffix(path, ":addVersion"):
+		name := strings.TrimSuffix(path, ":addVersion")
+		if _, ok := f.annotations[name]; !ok {
+			writeError(w, http.StatusNotFound)
+			return
+		}
+		var req secretmanager.AddSecretVersionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest)
+			return
+		}
+		f.versions[name] = append(f.versions[name], req.Payload.Data)
+		writeJSON(w, &secretmanager.SecretVersion{Name: name + "/versions/1"})
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/secrets"):
+		name := path + "/" + r.URL.Query().Get("secretId")
+		if _, ok := f.annotations[name]; ok {
+			writeError(w, http.StatusConflict)
+			return
+		}
+		var secret secretmanager.Secret
+		if err := json.NewDecoder(r.Body).Decode(&secret); err != nil {
+			writeError(w, http.StatusBadRequest)
+			return
+		}
+		f.annotations[name] = secret.Annotations
+		writeJSON(w, &secretmanager.Secret{Name: name})
+	default:
+		writeError(w, http.StatusNotImplemented)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": code, "message": http.StatusText(code)}})
+}
+
+func newTestStore(t *testing.T) (auth.Store, *fakeSecretManager) {
+	t.Helper()
+	fake := &fakeSecretManager{
+		versions:    make(map[string][]string),
+		annotations: make(map[string]map[string]string),
+	}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	store, err := New(t.Context(), "my-project", option.WithEndpoint(srv.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return store, fake
+}
+
+func TestStore(t *testing.T) {
+	store, fake := newTestStore(t)
+	key := auth.StoreKey{AppName: "my app", UserID: "user@example.com", CredentialKey: "adk_oauth2_1234"}
+
+	got, err := store.Load(t.Context(), key)
+	if err != nil || got != nil {
+		t.Fatalf("Load() of a missing credential = %v, %v, want nil, nil", got, err)
+	}
+
+	first := &auth.Credential{Type: auth.TypeOAuth2, OAuth2: &auth.OAuth2Credential{AccessToken: "a1", RefreshToken: "r1"}}
+	if err := store.Save(t.Context(), key, first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	second := &auth.Credential{Type: auth.TypeOAuth2, OAuth2: &auth.OAuth2Credential{AccessToken: "a2", RefreshToken: "r1", ExpiresAt: 42}}
+	if err := store.Save(t.Context(), key, second); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err = store.Load(t.Context(), key)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if diff := cmp.Diff(second, got); diff != "" {
+		t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+	}
+
+	name := "projects/my-project/secrets/" + secretID(key)
+	if n := len(fake.versions[name]); n != 2 {
+		t.Errorf("secret %s has %d versions, want 2", name, n)
+	}
+	wantAnnotations := map[string]string{
+		"adk-app-name":       "my app",
+		"adk-user-id":        "user@example.com",
+		"adk-credential-key": "adk_oauth2_1234",
+	}
+	if diff := cmp.Diff(wantAnnotations, fake.annotations[name]); diff != "" {
+		t.Errorf("secret annotations mismatch (-want +got):\n%s", diff)
+	}
+
+	otherUser := key
+	otherUser.UserID = "other@example.com"
+	if got, err := store.Load(t.Context(), otherUser); err != nil || got != nil {
+		t.Errorf("Load() of another user = %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestSecretID(t *testing.T) {
+	// The app name and user ID must not be confused when concatenated.
+	a := secretID(auth.StoreKey{AppName: "ab", UserID: "c", CredentialKey: "k"})
+	b := secretID(auth.StoreKey{AppName: "a", UserID: "bc", CredentialKey: "k"})
+	if a == b {
+		t.Errorf("secretID() = %q for different keys", a)
+	}
+	if len(a) > 255 || strings.ContainsFunc(a, func(r rune) bool {
+		return !(r == '-' || r == '_' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		t.Errorf("secretID() = %q is not a valid secret ID", a)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package statestore provides an [auth.Store] keeping the credentials in the
+// user state of the sessions.
+//
+// The credentials are stored in plain text in the session service, so it
+// should only be used with a session service whose storage is trusted.
+package statestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/adk/auth"
+	"google.golang.org/adk/internal/converters"
+	"google.golang.org/adk/session"
+)
+
+// KeyPrefix is the prefix of the state keys of the credentials, followed by
+// the credential key, see [auth.Config.Key].
+const KeyPrefix = session.KeyPrefixUser + "adk_credential:"
+
+// New returns an [auth.Store] keeping the credentials in the user state, so
+// that they are shared by all the sessions of the user within the app.
+//
+// The store can only be used by tools: the context given to Load and Save
+// must be a tool.Context.
+func New() auth.Store {
+	return stateStore{}
+}
+
+type stateStore struct{}
+
+// stateContext is implemented by the contexts of tools and callbacks.
+type stateContext interface {
+	State() session.State
+}
+
+func stateFrom(ctx context.Context) (session.State, error) {
+	sctx, ok := ctx.(stateContext)
+	if !ok {
+		return nil, fmt.Errorf("state store: the context has no session state")
+	}
+	return sctx.State(), nil
+}
+
+// Load implements [auth.Store].
+func (stateStore) Load(ctx context.Context, key auth.StoreKey) (*auth.Credential, error) {
+	state, err := stateFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v, err := state.Get(KeyPrefix + key.CredentialKey)
+	if errors.Is(err, session.ErrStateKeyNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("state store: failed to get the credential: %w", err)
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("state store: unexpected credential type %T", v)
+	}
+	cred, err := converters.FromMapStructure[auth.Credential](m)
+	if err != nil {
+		return nil, fmt.Errorf("state store: failed to decode the credential: %w", err)
+	}
+	return cred, nil
+}
+
+// Save implements [auth.Store].
+func (stateStore) Save(ctx context.Context, key auth.StoreKey, cred *auth.Credential) error {
+	state, err := stateFrom(ctx)
+	if err != nil {
+		return err
+	}
+	m, err := converters.ToMapStructure(cred)
+	if err != nil {
+		return fmt.Errorf("state store: failed to encode the credential: %w", err)
+	}
+	if err := state.Set(KeyPrefix+key.CredentialKey, m); err != nil {
+		return fmt.Errorf("state store: failed to set the credential: %w", err)
+	}
+	return nil
+}
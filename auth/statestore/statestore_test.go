@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statestore
+
+import (
+	"context"
+	"iter"
+	"maps"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/auth"
+	"google.golang.org/adk/session"
+)
+
+type mapState map[string]any
+
+func (s mapState) Get(key string) (any, error) {
+	v, ok := s[key]
+	if !ok {
+		return nil, session.ErrStateKeyNotExist
+	}
+	return v, nil
+}
+
+func (s mapState) Set(key string, value any) error {
+	s[key] = value
+	return nil
+}
+
+func (s mapState) All() iter.Seq2[string, any] {
+	return maps.All(s)
+}
+
+// toolContext stands for the tool.Context given to the store.
+type toolContext struct {
+	context.Context
+	state mapState
+}
+
+func (c toolContext) State() session.State {
+	return c.state
+}
+
+func TestStore(t *testing.T) {
+	ctx := toolContext{Context: t.Context(), state: mapState{}}
+	store := New()
+	key := auth.StoreKey{AppName: "app", UserID: "user", CredentialKey: "adk_oauth2_1234"}
+
+	got, err := store.Load(ctx, key)
+	if err != nil || got != nil {
+		t.Fatalf("Load() of a missing credential = %v, %v, want nil, nil", got, err)
+	}
+
+	cred := &auth.Credential{Type: auth.TypeOAuth2, OAuth2: &auth.OAuth2Credential{AccessToken: "access", ExpiresAt: 42}}
+	if err := store.Save(ctx, key, cred); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	want := map[string]any{
+		"user:adk_credential:adk_oauth2_1234": map[string]any{
+			"authType": "oauth2",
+			"oauth2":   map[string]any{"accessToken": "access", "expiresAt": float64(42)},
+		},
+	}
+	if diff := cmp.Diff(want, map[string]any(ctx.state)); diff != "" {
+		t.Errorf("state mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = store.Load(ctx, key)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if diff := cmp.Diff(cred, got); diff != "" {
+		t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStore_NoState(t *testing.T) {
+	key := auth.StoreKey{AppName: "app", UserID: "user", CredentialKey: "k"}
+	if _, err := New().Load(t.Context(), key); err == nil {
+		t.Error("Load() without session state succeeded, want error")
+	}
+	if err := New().Save(t.Context(), key, &auth.Credential{Type: auth.TypeAPIKey}); err == nil {
+		t.Error("Save() without session state succeeded, want error")
+	}
+}
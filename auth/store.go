@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"sync"
+)
+
+// Store stores the credentials of the users obtained by tools.
+type Store interface {
+	// Load returns the credential of key, or nil if there is none.
+	Load(ctx context.Context, key StoreKey) (*Credential, error)
+	// Save stores the credential of key, replacing the previous one.
+	Save(ctx context.Context, key StoreKey, cred *Credential) error
+}
+
+// StoreKey identifies a credential of a user in a [Store].
+type StoreKey struct {
+	AppName, UserID string
+	// CredentialKey is the key of the config of the credential, see
+	// [Config.Key].
+	CredentialKey string
+}
+
+// NewInMemoryStore returns a [Store] keeping the credentials in memory, lost
+// when the process exits.
+func NewInMemoryStore() Store {
+	return &inMemoryStore{credentials: make(map[StoreKey]*Credential)}
+}
+
+type inMemoryStore struct {
+	mu          sync.RWMutex
+	credentials map[StoreKey]*Credential
+}
+
+func (s *inMemoryStore) Load(ctx context.Context, key StoreKey) (*Credential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.credentials[key], nil
+}
+
+func (s *inMemoryStore) Save(ctx context.Context, key StoreKey, cred *Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.credentials[key] = cred
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	store := NewInMemoryStore()
+	key := StoreKey{AppName: "app", UserID: "user", CredentialKey: "cred"}
+
+	got, err := store.Load(t.Context(), key)
+	if err != nil || got != nil {
+		t.Fatalf("Load() of a missing credential = %v, %v, want nil, nil", got, err)
+	}
+
+	cred := &Credential{Type: TypeAPIKey, APIKey: "key"}
+	if err := store.Save(t.Context(), key, cred); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err = store.Load(t.Context(), key)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if diff := cmp.Diff(cred, got); diff != "" {
+		t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+	}
+
+	otherUser := key
+	otherUser.UserID = "other"
+	if got, err := store.Load(t.Context(), otherUser); err != nil || got != nil {
+		t.Errorf("Load() of another user = %v, %v, want nil, nil", got, err)
+	}
+}
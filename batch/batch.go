@@ -0,0 +1,282 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package batch runs an agent over the records of a dataset file.
+//
+// The records are read from a CSV file, with a header row, or a JSON lines
+// file, and each one is run in a new session with bounded concurrency. The
+// result of every record is written to an output file, which is the
+// checkpoint of the job: a job resumed with the same output skips the
+// records already succeeded.
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+// Status is the outcome of a record.
+type Status string
+
+const (
+	StatusSucceeded Status = "SUCCEEDED"
+	StatusFailed    Status = "FAILED"
+)
+
+// userID is the user of the sessions of the records.
+const userID = "batch_user"
+
+// Config configures [Run].
+type Config struct {
+	// Agent is the root agent run over the records.
+	Agent agent.Agent
+	// AppName of the sessions. Defaults to the agent name.
+	AppName string
+	// SessionService stores the sessions of the records. Defaults to an
+	// in-memory service.
+	SessionService session.Service
+	// ArtifactService stores the artifacts of the agent.
+	ArtifactService artifact.Service
+	// PluginConfig configures the plugins of the runner.
+	PluginConfig runner.PluginConfig
+	// RunConfig configures the runs of the records.
+	RunConfig agent.RunConfig
+
+	// InputPath is the dataset, a CSV file if it has the ".csv" extension
+	// and a JSON lines file otherwise.
+	InputPath string
+	// OutputPath is the file the results are written to, as CSV if it has
+	// the ".csv" extension and as JSON lines otherwise.
+	OutputPath string
+	// Resume resumes the job of the existing output: the records it holds
+	// as succeeded are not run again. Otherwise, the output is truncated.
+	Resume bool
+
+	// Content returns the user content of a record. Defaults to
+	// [DefaultContent].
+	Content func(*Record) (*genai.Content, error)
+	// Parallelism is the number of records run concurrently. Defaults to 1.
+	// Wrap the models of the agent with model.WithRateLimit to keep the
+	// concurrent records within their quota.
+	Parallelism int
+	// Progress is called after every completed record.
+	Progress func(Progress)
+}
+
+// Result is the result of a record, a line of the output.
+type Result struct {
+	ID     string `json:"id"`
+	Status Status `json:"status"`
+	// Output is the text of the last final response of the agent.
+	Output    string `json:"output,omitempty"`
+	Error     string `json:"error,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+}
+
+// Summary is the summary of a job.
+type Summary struct {
+	Succeeded int
+	Failed    int
+	// Skipped is the number of records already succeeded in the resumed
+	// output.
+	Skipped int
+}
+
+// Progress is the progress of a job.
+type Progress struct {
+	// Completed is the number of completed records, the skipped ones
+	// included.
+	Completed int
+	// Result is the result of the last completed record.
+	Result *Result
+	// Resumed is set if the record was skipped as already succeeded.
+	Resumed bool
+}
+
+// ProgressPrinter returns a [Config.Progress] function writing a line per
+// completed record to w.
+func ProgressPrinter(w io.Writer) func(Progress) {
+	return func(p Progress) {
+		suffix := ""
+		if p.Resumed {
+			suffix = " (from checkpoint)"
+		} else if p.Result.Error != "" {
+			suffix = ": " + p.Result.Error
+		}
+		fmt.Fprintf(w, "[%d] %s %s%s\n", p.Completed, p.Result.ID, p.Result.Status, suffix)
+	}
+}
+
+// DefaultContent returns the text of the "prompt" field of the record as
+// the user content, or the record encoded as JSON if it has no such field.
+func DefaultContent(rec *Record) (*genai.Content, error) {
+	if prompt, ok := rec.Fields["prompt"].(string); ok {
+		return genai.NewContentFromText(prompt, genai.RoleUser), nil
+	}
+	b, err := json.Marshal(rec.Fields)
+	if err != nil {
+		return nil, err
+	}
+	return genai.NewContentFromText(string(b), genai.RoleUser), nil
+}
+
+// Run runs the agent over the records of the input, each in a new session
+// whose state holds the fields of the record, e.g. for the placeholders of
+// the agent instructions.
+//
+// The failures of the records are reported in the output; Run only fails
+// on an invalid configuration, an input or output error or a cancelled
+// context. The records interrupted by the cancellation are run again on
+// resume.
+func Run(ctx context.Context, cfg Config) (*Summary, error) {
+	if cfg.Agent == nil {
+		return nil, errors.New("agent is required")
+	}
+	if cfg.InputPath == "" || cfg.OutputPath == "" {
+		return nil, errors.New("input and output paths are required")
+	}
+	if cfg.AppName == "" {
+		cfg.AppName = cfg.Agent.Name()
+	}
+	if cfg.SessionService == nil {
+		cfg.SessionService = session.InMemoryService()
+	}
+	if cfg.Content == nil {
+		cfg.Content = DefaultContent
+	}
+	if cfg.Parallelism <= 0 {
+		cfg.Parallelism = 1
+	}
+
+	r, err := runner.New(runner.Config{
+		AppName:         cfg.AppName,
+		Agent:           cfg.Agent,
+		SessionService:  cfg.SessionService,
+		ArtifactService: cfg.ArtifactService,
+		PluginConfig:    cfg.PluginConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	out, err := openOutput(cfg.OutputPath, cfg.Resume)
+	if err != nil {
+		return nil, err
+	}
+	defer out.close()
+
+	var (
+		mu      sync.Mutex
+		summary Summary
+	)
+	done := func(result *Result, resumed bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case resumed:
+			summary.Skipped++
+		case result.Status == StatusSucceeded:
+			summary.Succeeded++
+		default:
+			summary.Failed++
+		}
+		if cfg.Progress != nil {
+			cfg.Progress(Progress{Completed: summary.Skipped + summary.Succeeded + summary.Failed, Result: result, Resumed: resumed})
+		}
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(cfg.Parallelism)
+	var readErr error
+	for rec, err := range readRecords(cfg.InputPath) {
+		if err != nil {
+			readErr = err
+			break
+		}
+		if out.succeeded[rec.ID] {
+			done(&Result{ID: rec.ID, Status: StatusSucceeded}, true)
+			continue
+		}
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			result := runRecord(gctx, cfg, r, rec)
+			if err := gctx.Err(); err != nil {
+				// The record was interrupted, it is run again on resume.
+				return err
+			}
+			if err := out.write(result); err != nil {
+				return err
+			}
+			done(result, false)
+			return nil
+		})
+	}
+	if err := errors.Join(g.Wait(), readErr); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// runRecord runs the agent with the record in a new session.
+func runRecord(ctx context.Context, cfg Config, r *runner.Runner, rec *Record) *Result {
+	result := &Result{ID: rec.ID, Status: StatusFailed}
+	content, err := cfg.Content(rec)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to build the content: %v", err)
+		return result
+	}
+	created, err := cfg.SessionService.Create(ctx, &session.CreateRequest{AppName: cfg.AppName, UserID: userID, State: rec.Fields})
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to create the session: %v", err)
+		return result
+	}
+	result.SessionID = created.Session.ID()
+
+	for event, err := range r.Run(ctx, userID, result.SessionID, content, cfg.RunConfig) {
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		if event.Content == nil || !event.IsFinalResponse() || event.Partial {
+			continue
+		}
+		var text strings.Builder
+		for _, part := range event.Content.Parts {
+			if !part.Thought {
+				text.WriteString(part.Text)
+			}
+		}
+		if text.Len() > 0 {
+			result.Output = text.String()
+		}
+	}
+	result.Status = StatusSucceeded
+	return result
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"errors"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/session"
+)
+
+// newTestAgent returns an agent replying with the user content and the
+// "lang" field of the state, and failing the content "fail". It records
+// the contents it runs.
+func newTestAgent(t *testing.T, ran *[]string) agent.Agent {
+	t.Helper()
+	var mu sync.Mutex
+	a, err := agent.New(agent.Config{
+		Name: "test_agent",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				text := ctx.UserContent().Parts[0].Text
+				mu.Lock()
+				*ran = append(*ran, text)
+				mu.Unlock()
+				if text == "fail" {
+					yield(nil, errors.New("agent failed"))
+					return
+				}
+				lang, _ := ctx.Session().State().Get("lang")
+				ev := session.NewEventWithContext(ctx, ctx.InvocationID())
+				ev.Content = genai.NewContentFromText(text+" in "+lang.(string), genai.RoleModel)
+				yield(ev, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+	return a
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readResults reads the results of a JSON lines output, ignoring the
+// session IDs, sorted by ID.
+func readResults(t *testing.T, path string) []string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	for i, line := range lines {
+		if j := strings.Index(line, `,"session_id"`); j >= 0 {
+			lines[i] = line[:j] + "}"
+		}
+	}
+	slices.Sort(lines)
+	return lines
+}
+
+func TestRun_JSONL(t *testing.T) {
+	var ran []string
+	input := writeFile(t, "in.jsonl", `{"id": "a", "prompt": "hello", "lang": "en"}
+
+{"prompt": "fail", "lang": "en"}
+{"prompt": "bonjour", "lang": "fr"}
+`)
+	output := filepath.Join(t.TempDir(), "out.jsonl")
+	var progress []string
+	summary, err := Run(t.Context(), Config{
+		Agent:       newTestAgent(t, &ran),
+		InputPath:   input,
+		OutputPath:  output,
+		Parallelism: 2,
+		Progress: func(p Progress) {
+			progress = append(progress, p.Result.ID)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if diff := cmp.Diff(&Summary{Succeeded: 2, Failed: 1}, summary); diff != "" {
+		t.Errorf("Run() summary mismatch (-want +got):\n%s", diff)
+	}
+	want := []string{
+		`{"id":"2","status":"FAILED","error":"agent failed"}`,
+		`{"id":"3","status":"SUCCEEDED","output":"bonjour in fr"}`,
+		`{"id":"a","status":"SUCCEEDED","output":"hello in en"}`,
+	}
+	if diff := cmp.Diff(want, readResults(t, output)); diff != "" {
+		t.Errorf("output mismatch (-want +got):\n%s", diff)
+	}
+	if len(progress) != 3 {
+		t.Errorf("Progress called %d times, want 3", len(progress))
+	}
+}
+
+func TestRun_CSV(t *testing.T) {
+	var ran []string
+	input := writeFile(t, "in.csv", "prompt,lang\nhello,en\n\"hi, there\",de\n")
+	output := filepath.Join(t.TempDir(), "out.csv")
+	if _, err := Run(t.Context(), Config{Agent: newTestAgent(t, &ran), InputPath: input, OutputPath: output}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	b, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	for i, line := range lines {
+		// Remove the session IDs.
+		lines[i] = line[:strings.LastIndex(line, ",")]
+	}
+	want := []string{
+		"id,status,output,error",
+		"1,SUCCEEDED,hello in en,",
+		`2,SUCCEEDED,"hi, there in de",`,
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("output mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRun_Resume(t *testing.T) {
+	var ran []string
+	input := writeFile(t, "in.jsonl", `{"prompt": "one", "lang": "en"}
+{"prompt": "two", "lang": "en"}
+{"prompt": "three", "lang": "en"}
+`)
+	// The job was killed while writing the result of the third record,
+	// after the second one failed.
+	output := writeFile(t, "out.jsonl", `{"id":"1","status":"SUCCEEDED","output":"one in en"}
+{"id":"2","status":"FAILED","error":"agent failed"}
+{"id":"3","sta`)
+	summary, err := Run(t.Context(), Config{Agent: newTestAgent(t, &ran), InputPath: input, OutputPath: output, Resume: true})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if diff := cmp.Diff(&Summary{Succeeded: 2, Skipped: 1}, summary); diff != "" {
+		t.Errorf("Run() summary mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"two", "three"}, ran); diff != "" {
+		t.Errorf("records run mismatch (-want +got):\n%s", diff)
+	}
+	want := []string{
+		`{"id":"1","status":"SUCCEEDED","output":"one in en"}`,
+		`{"id":"2","status":"FAILED","error":"agent failed"}`,
+		`{"id":"2","status":"SUCCEEDED","output":"two in en"}`,
+		`{"id":"3","sta`,
+		`{"id":"3","status":"SUCCEEDED","output":"three in en"}`,
+	}
+	if diff := cmp.Diff(want, readResults(t, output)); diff != "" {
+		t.Errorf("output mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRun_InvalidInput(t *testing.T) {
+	var ran []string
+	input := writeFile(t, "in.jsonl", "{\"prompt\": \"one\", \"lang\": \"en\"}\nnot json\n")
+	_, err := Run(t.Context(), Config{Agent: newTestAgent(t, &ran), InputPath: input, OutputPath: filepath.Join(t.TempDir(), "out.jsonl")})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Run() error = %v, want the invalid line", err)
+	}
+}
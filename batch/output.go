@@ -0,0 +1,164 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// csvHeader is the header of the CSV outputs.
+var csvHeader = []string{"id", "status", "output", "error", "session_id"}
+
+// output writes the results to a CSV or JSON lines file. It is the
+// checkpoint of the job: the records it holds as succeeded are not run
+// again on resume.
+type output struct {
+	mu  sync.Mutex
+	f   *os.File
+	csv *csv.Writer
+	// succeeded are the IDs of the records succeeded in a previous run.
+	succeeded map[string]bool
+}
+
+// openOutput opens the output, appending to it on resume, and truncating
+// it otherwise.
+func openOutput(path string, resume bool) (*output, error) {
+	o := &output{succeeded: make(map[string]bool)}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	terminate := false
+	if resume {
+		var err error
+		if terminate, err = o.load(path); err != nil {
+			return nil, fmt.Errorf("failed to read the output: %w", err)
+		}
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the output: %w", err)
+	}
+	o.f = f
+	if terminate {
+		if _, err := f.Write([]byte("\n")); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to write the output: %w", err)
+		}
+	}
+	if isCSV(path) {
+		o.csv = csv.NewWriter(f)
+		if info, err := f.Stat(); err != nil || info.Size() == 0 {
+			if err := o.writeCSV(csvHeader); err != nil {
+				f.Close()
+				return nil, err
+			}
+		}
+	}
+	return o, nil
+}
+
+// load reads the succeeded records of an existing output. It reports
+// whether the last line of the output is truncated, if the job was killed
+// while writing it, to terminate it before appending.
+func (o *output) load(path string) (truncated bool, err error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if isCSV(path) {
+		cr := csv.NewReader(f)
+		cr.FieldsPerRecord = -1
+		for {
+			row, err := cr.Read()
+			if err != nil {
+				// The rows after a malformed one, the truncated last
+				// row, are ignored.
+				break
+			}
+			if len(row) >= 2 && row[1] == string(StatusSucceeded) {
+				o.succeeded[row[0]] = true
+			}
+		}
+	} else {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(nil, 64<<20)
+		for scanner.Scan() {
+			var result Result
+			if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+				continue
+			}
+			if result.Status == StatusSucceeded {
+				o.succeeded[result.ID] = true
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return false, err
+		}
+	}
+
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return false, err
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	return last[0] != '\n', nil
+}
+
+// write writes the result of a record.
+func (o *output) write(result *Result) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.csv != nil {
+		return o.writeCSV([]string{result.ID, string(result.Status), result.Output, result.Error, result.SessionID})
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode the result: %w", err)
+	}
+	if _, err := o.f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to write the output: %w", err)
+	}
+	return nil
+}
+
+func (o *output) writeCSV(row []string) error {
+	if err := o.csv.Write(row); err != nil {
+		return fmt.Errorf("failed to write the output: %w", err)
+	}
+	o.csv.Flush()
+	if err := o.csv.Error(); err != nil {
+		return fmt.Errorf("failed to write the output: %w", err)
+	}
+	return nil
+}
+
+func (o *output) close() error {
+	return o.f.Close()
+}
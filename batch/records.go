@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Record is a record of the input dataset.
+type Record struct {
+	// ID identifies the record in the output: its "id" field, or its
+	// position in the dataset starting at 1.
+	ID string
+	// Fields are the fields of the record: the values of a JSON line, or
+	// the columns of a CSV row by header.
+	Fields map[string]any
+}
+
+// isCSV reports whether the file at path is a CSV file, by its extension.
+func isCSV(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+// readRecords streams the records of a CSV file, with a header row, or of
+// a JSON lines file.
+func readRecords(path string) iter.Seq2[*Record, error] {
+	return func(yield func(*Record, error) bool) {
+		f, err := os.Open(path)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to open the input: %w", err))
+			return
+		}
+		defer f.Close()
+		read := readJSONL
+		if isCSV(path) {
+			read = readCSV
+		}
+		n := 0
+		for fields, err := range read(f) {
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to read the input: %w", err))
+				return
+			}
+			n++
+			rec := &Record{ID: strconv.Itoa(n), Fields: fields}
+			if id, ok := fields["id"]; ok && id != nil && id != "" {
+				rec.ID = fmt.Sprint(id)
+			}
+			if !yield(rec, nil) {
+				return
+			}
+		}
+	}
+}
+
+func readJSONL(r io.Reader) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 64<<20)
+		line := 0
+		for scanner.Scan() {
+			line++
+			b := bytes.TrimSpace(scanner.Bytes())
+			if len(b) == 0 {
+				continue
+			}
+			var fields map[string]any
+			if err := json.Unmarshal(b, &fields); err != nil {
+				yield(nil, fmt.Errorf("line %d: %w", line, err))
+				return
+			}
+			if !yield(fields, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func readCSV(r io.Reader) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		cr := csv.NewReader(r)
+		header, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for {
+			row, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			fields := make(map[string]any, len(header))
+			for i, name := range header {
+				fields[name] = row[i]
+			}
+			if !yield(fields, nil) {
+				return
+			}
+		}
+	}
+}
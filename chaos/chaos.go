@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chaos injects faults, i.e. latency, errors and malformed
+// responses, into the models, tools and session services of an agent, to
+// test in CI how it copes with them, e.g. its retries and fallbacks.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrInjected is the error of the faulted calls if Fault.Err is nil and the
+// fault is neither a latency nor a malformed response.
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Fault describes the faults injected into the calls of a layer.
+type Fault struct {
+	// Latency delays the faulted calls, or until their context is done.
+	Latency time.Duration // optional
+	// Err is the error the faulted calls fail with.
+	Err error // optional
+	// Malformed makes the faulted calls return a malformed response instead
+	// of failing, see Model and ToolPlugin.
+	Malformed bool // optional
+	// Probability is the probability in (0, 1] that a call is faulted.
+	// Zero faults every call.
+	Probability float64 // optional
+	// Seed seeds the random decisions, so that a test faults the same calls
+	// on every run.
+	Seed uint64 // optional
+	// Skip is the number of calls before the first faulted one.
+	Skip int // optional
+	// Times limits the number of faulted calls, e.g. to make the first calls
+	// fail and the retries succeed. Zero means no limit.
+	Times int // optional
+}
+
+// fails reports whether the faulted calls fail with an error.
+func (f *Fault) fails() bool {
+	return f.Err != nil || f.Latency == 0 && !f.Malformed
+}
+
+func (f *Fault) err() error {
+	if f.Err != nil {
+		return f.Err
+	}
+	return ErrInjected
+}
+
+// injector decides which calls are faulted.
+type injector struct {
+	fault Fault
+
+	mu      sync.Mutex
+	rand    *rand.Rand
+	calls   int
+	faulted int
+}
+
+func newInjector(fault Fault) *injector {
+	return &injector{fault: fault, rand: rand.New(rand.NewPCG(fault.Seed, fault.Seed))}
+}
+
+// inject decides whether the call is faulted. If so, it waits for the
+// latency and returns the error the call fails with, if any.
+func (i *injector) inject(ctx context.Context) (bool, error) {
+	if !i.decide() {
+		return false, nil
+	}
+	if i.fault.Latency > 0 {
+		timer := time.NewTimer(i.fault.Latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if i.fault.fails() {
+		return true, i.fault.err()
+	}
+	return true, nil
+}
+
+func (i *injector) decide() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.calls++
+	if i.calls <= i.fault.Skip {
+		return false
+	}
+	if i.fault.Times > 0 && i.faulted >= i.fault.Times {
+		return false
+	}
+	if p := i.fault.Probability; p > 0 && i.rand.Float64() >= p {
+		return false
+	}
+	i.faulted++
+	return true
+}
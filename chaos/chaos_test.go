@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/chaos"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+type textLLM struct {
+	calls int
+}
+
+func (m *textLLM) Name() string { return "text" }
+
+func (m *textLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.calls++
+		yield(&model.LLMResponse{Content: genai.NewContentFromText(`{"city": "Paris"}`, genai.RoleModel)}, nil)
+	}
+}
+
+func generate(llm model.LLM) (*model.LLMResponse, error) {
+	var last *model.LLMResponse
+	for resp, err := range llm.GenerateContent(context.Background(), &model.LLMRequest{}, false) {
+		if err != nil {
+			return nil, err
+		}
+		last = resp
+	}
+	return last, nil
+}
+
+func TestModel(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+	llm := &textLLM{}
+	// The second and third calls fail.
+	faulty := chaos.Model(llm, chaos.Fault{Err: errUnavailable, Skip: 1, Times: 2})
+	var errs []error
+	for range 4 {
+		_, err := generate(faulty)
+		errs = append(errs, err)
+	}
+	if errs[0] != nil || !errors.Is(errs[1], errUnavailable) || !errors.Is(errs[2], errUnavailable) || errs[3] != nil {
+		t.Errorf("GenerateContent() errors = %v, want the second and third calls to fail", errs)
+	}
+	if llm.calls != 2 {
+		t.Errorf("model called %d times, want the faulted calls not to reach it", llm.calls)
+	}
+
+	resp, err := generate(chaos.Model(llm, chaos.Fault{Malformed: true}))
+	if err != nil {
+		t.Fatalf("GenerateContent() error = %v", err)
+	}
+	if got := resp.Content.Parts[0].Text; got != `{"city":` || resp.FinishReason != genai.FinishReasonMaxTokens {
+		t.Errorf("GenerateContent() = %q, %v, want the truncated text", got, resp.FinishReason)
+	}
+
+	start := time.Now()
+	if _, err := generate(chaos.Model(llm, chaos.Fault{Latency: 20 * time.Millisecond})); err != nil {
+		t.Fatalf("GenerateContent() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("GenerateContent() took %v, want the latency", elapsed)
+	}
+
+	// The same seed faults the same calls.
+	faulted := func() []bool {
+		faulty := chaos.Model(llm, chaos.Fault{Probability: 0.5, Seed: 42})
+		var got []bool
+		for range 20 {
+			_, err := generate(faulty)
+			got = append(got, errors.Is(err, chaos.ErrInjected))
+		}
+		return got
+	}
+	first, second := faulted(), faulted()
+	var count int
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("faulted calls = %v and %v, want the same with the same seed", first, second)
+		}
+		if first[i] {
+			count++
+		}
+	}
+	if count == 0 || count == len(first) {
+		t.Errorf("%d of %d calls faulted, want some with a probability of 0.5", count, len(first))
+	}
+}
+
+func TestToolPlugin(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	getWeather, err := functiontool.New(functiontool.Config{Name: "get_weather"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return map[string]any{"weather": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("get_weather", map[string]any{}, genai.RoleModel),
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{Name: "weather_agent", Model: llm, Tools: []tool.Tool{getWeather}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := chaos.ToolPlugin("chaos", chaos.Fault{Malformed: true}, "get_weather")
+	if err != nil {
+		t.Fatalf("ToolPlugin() error = %v", err)
+	}
+	r, err := runner.New(runner.Config{
+		AppName:           "app",
+		Agent:             a,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+		PluginConfig:      runner.PluginConfig{Plugins: []*plugin.Plugin{p}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]any
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("weather?", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		for _, part := range event.Content.Parts {
+			if part.FunctionResponse != nil {
+				result = part.FunctionResponse.Response
+			}
+		}
+	}
+	if got := result["result"]; got != `{"weather` {
+		t.Errorf("tool result = %v, want the truncated result", result)
+	}
+}
+
+func TestSessionService(t *testing.T) {
+	ctx := t.Context()
+	service := chaos.SessionService(session.InMemoryService(), chaos.Fault{Times: 1})
+	if _, err := service.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user"}); !errors.Is(err, chaos.ErrInjected) {
+		t.Errorf("Create() error = %v, want %v", err, chaos.ErrInjected)
+	}
+	if _, err := service.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user"}); err != nil {
+		t.Errorf("Create() error = %v, want the retry to succeed", err)
+	}
+}
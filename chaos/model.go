@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"iter"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// Model returns a model injecting the fault into the calls of llm, see
+// model.WithInterceptor.
+//
+// The malformed responses replace the response of the model with the first
+// half of its text and a FinishReasonMaxTokens, as from a model cut off
+// mid-output, e.g. in the middle of a JSON object. The function calls are
+// dropped.
+func Model(llm model.LLM, fault Fault) model.LLM {
+	injector := newInjector(fault)
+	return model.WithInterceptor(llm, func(ctx context.Context, req *model.LLMRequest, stream bool, next model.GenerateFunc) iter.Seq2[*model.LLMResponse, error] {
+		return func(yield func(*model.LLMResponse, error) bool) {
+			faulted, err := injector.inject(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !faulted || !fault.Malformed {
+				for resp, err := range next(ctx, req, stream) {
+					if !yield(resp, err) {
+						return
+					}
+				}
+				return
+			}
+			var last *model.LLMResponse
+			for resp, err := range next(ctx, req, false) {
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				last = resp
+			}
+			if last != nil {
+				yield(malformedResponse(last), nil)
+			}
+		}
+	})
+}
+
+func malformedResponse(resp *model.LLMResponse) *model.LLMResponse {
+	var text string
+	if resp.Content != nil {
+		for _, part := range resp.Content.Parts {
+			if !part.Thought {
+				text += part.Text
+			}
+		}
+	}
+	malformed := *resp
+	malformed.Candidates = nil
+	runes := []rune(text)
+	malformed.Content = genai.NewContentFromText(string(runes[:len(runes)/2]), genai.RoleModel)
+	malformed.FinishReason = genai.FinishReasonMaxTokens
+	return &malformed
+}
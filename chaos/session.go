@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+
+	"google.golang.org/adk/session"
+)
+
+// SessionService returns a session service injecting the fault into the
+// calls of s. Only the latency and the errors are injected, the sessions are
+// never malformed.
+func SessionService(s session.Service, fault Fault) session.Service {
+	return &sessionService{Service: s, injector: newInjector(fault)}
+}
+
+type sessionService struct {
+	session.Service
+	injector *injector
+}
+
+func (s *sessionService) Create(ctx context.Context, req *session.CreateRequest) (*session.CreateResponse, error) {
+	if _, err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return s.Service.Create(ctx, req)
+}
+
+func (s *sessionService) Get(ctx context.Context, req *session.GetRequest) (*session.GetResponse, error) {
+	if _, err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return s.Service.Get(ctx, req)
+}
+
+func (s *sessionService) List(ctx context.Context, req *session.ListRequest) (*session.ListResponse, error) {
+	if _, err := s.injector.inject(ctx); err != nil {
+		return nil, err
+	}
+	return s.Service.List(ctx, req)
+}
+
+func (s *sessionService) Delete(ctx context.Context, req *session.DeleteRequest) error {
+	if _, err := s.injector.inject(ctx); err != nil {
+		return err
+	}
+	return s.Service.Delete(ctx, req)
+}
+
+func (s *sessionService) AppendEvent(ctx context.Context, sess session.Session, event *session.Event) error {
+	if _, err := s.injector.inject(ctx); err != nil {
+		return err
+	}
+	return s.Service.AppendEvent(ctx, sess, event)
+}
+
+func (s *sessionService) AppendEvents(ctx context.Context, sess session.Session, events []*session.Event) error {
+	if _, err := s.injector.inject(ctx); err != nil {
+		return err
+	}
+	return s.Service.AppendEvents(ctx, sess, events)
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/tool"
+)
+
+// ToolPlugin returns a plugin injecting the fault into the calls of the
+// tools with the given names, or of all the tools if none is given. The
+// plugin must be installed on the runner, see runner.PluginConfig.
+//
+// The malformed responses replace the result of the tool with a "result"
+// holding the first half of its JSON encoding, as from a tool whose output
+// was cut off.
+func ToolPlugin(name string, fault Fault, toolNames ...string) (*plugin.Plugin, error) {
+	injector := newInjector(fault)
+	// The IDs of the function calls whose results are malformed.
+	var malformed sync.Map
+	return plugin.New(plugin.Config{
+		Name: name,
+		BeforeToolCallback: func(ctx tool.Context, t tool.Tool, args map[string]any) (map[string]any, error) {
+			if len(toolNames) > 0 && !slices.Contains(toolNames, t.Name()) {
+				return nil, nil
+			}
+			faulted, err := injector.inject(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if faulted && fault.Malformed {
+				malformed.Store(ctx.FunctionCallID(), true)
+			}
+			return nil, nil
+		},
+		AfterToolCallback: func(ctx tool.Context, t tool.Tool, args, result map[string]any, err error) (map[string]any, error) {
+			if _, ok := malformed.LoadAndDelete(ctx.FunctionCallID()); !ok || err != nil {
+				return nil, nil
+			}
+			data, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil
+			}
+			return map[string]any{"result": string(data[:len(data)/2])}, nil
+		},
+	})
+}
@@ -16,8 +16,11 @@
 package main
 
 import (
+	_ "google.golang.org/adk/cmd/adkgo/internal/create"
 	_ "google.golang.org/adk/cmd/adkgo/internal/deploy/cloudrun"
+	_ "google.golang.org/adk/cmd/adkgo/internal/eval/record"
 	"google.golang.org/adk/cmd/adkgo/internal/root"
+	_ "google.golang.org/adk/cmd/adkgo/internal/sessions"
 )
 
 func main() {
@@ -0,0 +1,192 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package create handles command line parameters and execution logic for
+// scaffolding a new ADK agent module.
+package create
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/adk/cmd/adkgo/internal/root"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+var (
+	backends      = []string{"gemini", "vertexai"}
+	templateTypes = []string{"chat", "workflow", "mcp"}
+)
+
+type createFlags struct {
+	backend   string
+	template  string
+	model     string
+	apiKey    string
+	project   string
+	region    string
+	module    string
+	outputDir string
+}
+
+var flags createFlags
+
+// createCmd represents the create command
+var createCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Creates a new ADK agent module.",
+	Long: `Scaffolds a Go module for a new agent in the directory <name>: main.go running the agent with the ADK launcher,
+	a sample tool, go.mod, a .env template and a Dockerfile.
+	The template selects the kind of agent: chat (an LLM agent), workflow (LLM agents run in sequence)
+	or mcp (an LLM agent using the tools of an MCP server).
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return flags.create(cmd.OutOrStdout(), args[0])
+	},
+}
+
+// init creates flags and adds subcommand to parent
+func init() {
+	root.RootCmd.AddCommand(createCmd)
+
+	createCmd.PersistentFlags().StringVar(&flags.backend, "model_backend", "gemini", "Model backend: "+strings.Join(backends, " or "))
+	createCmd.PersistentFlags().StringVarP(&flags.template, "template", "t", "chat", "Agent template: "+strings.Join(templateTypes, ", "))
+	createCmd.PersistentFlags().StringVarP(&flags.model, "model", "m", "gemini-2.5-flash", "Model used by the agent")
+	createCmd.PersistentFlags().StringVar(&flags.apiKey, "api_key", "", "Gemini API key written to .env for the gemini backend")
+	createCmd.PersistentFlags().StringVarP(&flags.project, "project", "p", "", "Google Cloud project written to .env for the vertexai backend")
+	createCmd.PersistentFlags().StringVarP(&flags.region, "region", "r", "us-central1", "Google Cloud region written to .env for the vertexai backend")
+	createCmd.PersistentFlags().StringVar(&flags.module, "module", "", "Go module path, defaults to the name")
+	createCmd.PersistentFlags().StringVarP(&flags.outputDir, "output_dir", "o", ".", "Directory in which the agent directory is created")
+}
+
+// templateData is the data the templates are executed with.
+type templateData struct {
+	AgentName string
+	Module    string
+	Model     string
+	Backend   string
+	Template  string
+	APIKey    string
+	Project   string
+	Region    string
+	GoVersion string
+}
+
+// files maps the generated files to their templates, except main.go which
+// depends on the template type.
+var files = map[string]string{
+	"tools.go":   "tools.go.tmpl",
+	"go.mod":     "go.mod.tmpl",
+	".env":       "env.tmpl",
+	"Dockerfile": "Dockerfile.tmpl",
+}
+
+func (f *createFlags) create(out io.Writer, name string) error {
+	data, err := f.templateData(name)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(f.outputDir, name)
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("directory %s already exists and is not empty", dir)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	rendered, err := render(data)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create the agent directory: %w", err)
+	}
+	for _, file := range slices.Sorted(maps.Keys(rendered)) {
+		perm := os.FileMode(0o644)
+		if file == ".env" {
+			// .env may hold an API key.
+			perm = 0o600
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), rendered[file], perm); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+
+	fmt.Fprintf(out, "Created the %s agent %q in %s\n\n", data.Template, data.AgentName, dir)
+	fmt.Fprintf(out, "Next steps:\n  cd %s\n  go mod tidy\n  go run . console\n", dir)
+	return nil
+}
+
+var identifierRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func (f *createFlags) templateData(name string) (*templateData, error) {
+	agentName := strings.ReplaceAll(filepath.Base(name), "-", "_")
+	if !identifierRE.MatchString(agentName) {
+		return nil, fmt.Errorf("invalid agent name %q: use letters, digits, '-' and '_', starting with a letter", name)
+	}
+	if !slices.Contains(backends, f.backend) {
+		return nil, fmt.Errorf("unknown model backend %q, want one of %s", f.backend, strings.Join(backends, ", "))
+	}
+	if !slices.Contains(templateTypes, f.template) {
+		return nil, fmt.Errorf("unknown template %q, want one of %s", f.template, strings.Join(templateTypes, ", "))
+	}
+	module := f.module
+	if module == "" {
+		module = filepath.Base(name)
+	}
+	return &templateData{
+		AgentName: agentName,
+		Module:    module,
+		Model:     f.model,
+		Backend:   f.backend,
+		Template:  f.template,
+		APIKey:    f.apiKey,
+		Project:   f.project,
+		Region:    f.region,
+		GoVersion: "1.24.4",
+	}, nil
+}
+
+// render executes the templates, returning the content of the generated
+// files by file name.
+func render(data *templateData) (map[string][]byte, error) {
+	tmpl, err := template.ParseFS(templatesFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	rendered := make(map[string][]byte)
+	all := map[string]string{"main.go": "main_" + data.Template + ".go.tmpl"}
+	maps.Copy(all, files)
+	for file, name := range all {
+		var b strings.Builder
+		if err := tmpl.ExecuteTemplate(&b, name, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", file, err)
+		}
+		rendered[file] = []byte(b.String())
+	}
+	return rendered, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package create
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	for _, backend := range backends {
+		for _, tmpl := range templateTypes {
+			t.Run(backend+"/"+tmpl, func(t *testing.T) {
+				f := createFlags{backend: backend, template: tmpl, model: "gemini-2.5-flash", project: "my-project", region: "us-central1"}
+				data, err := f.templateData("my-agent")
+				if err != nil {
+					t.Fatal(err)
+				}
+				rendered, err := render(data)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, file := range []string{"main.go", "tools.go"} {
+					formatted, err := format.Source(rendered[file])
+					if err != nil {
+						t.Fatalf("%s does not parse: %v\n%s", file, err, rendered[file])
+					}
+					if !bytes.Equal(formatted, rendered[file]) {
+						t.Errorf("%s is not gofmt-ed:\n%s", file, rendered[file])
+					}
+				}
+				if main := string(rendered["main.go"]); !strings.Contains(main, `Name:        "my_agent"`) {
+					t.Errorf("main.go does not name the agent my_agent:\n%s", main)
+				}
+				if got := string(rendered["go.mod"]); !strings.HasPrefix(got, "module my-agent\n") {
+					t.Errorf("go.mod = %q, want module my-agent", got)
+				}
+				env := string(rendered[".env"])
+				if wantVertex := backend == "vertexai"; strings.Contains(env, "GOOGLE_CLOUD_PROJECT=my-project") != wantVertex {
+					t.Errorf(".env of the %s backend = %q", backend, env)
+				}
+			})
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	f := createFlags{backend: "gemini", template: "chat", model: "gemini-2.5-flash", apiKey: "secret", outputDir: dir}
+	var out bytes.Buffer
+	if err := f.create(&out, "weather"); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{"main.go", "tools.go", "go.mod", ".env", "Dockerfile"} {
+		if _, err := os.Stat(filepath.Join(dir, "weather", file)); err != nil {
+			t.Error(err)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(dir, "weather", ".env")); err == nil && info.Mode().Perm() != 0o600 {
+		t.Errorf(".env mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if err := f.create(&out, "weather"); err == nil {
+		t.Error("create() into an existing directory succeeded, want error")
+	}
+	for _, bad := range []createFlags{
+		{backend: "openai", template: "chat", outputDir: dir},
+		{backend: "gemini", template: "graph", outputDir: dir},
+	} {
+		if err := bad.create(&out, "other"); err == nil {
+			t.Errorf("create() with %+v succeeded, want error", bad)
+		}
+	}
+	if err := f.create(&out, "9lives"); err == nil {
+		t.Error("create() with an invalid name succeeded, want error")
+	}
+}
@@ -230,7 +230,7 @@ func (f *deployCloudRunFlags) runGcloudProxy() error {
 
 			p(strings.Repeat("-", targetWidth))
 			p(util.CenterString("", targetWidth))
-			p(util.CenterString("Running ADK Web UI on http://127.0.0.1:"+strconv.Itoa(f.proxy.port)+"/ui/    <-- open this", targetWidth))
+			p(util.CenterString("Running ADK Web UI on http://127.0.0.1:"+strconv.Itoa(f.proxy.port)+"/dev-ui/    <-- open this", targetWidth))
 			p(util.CenterString("ADK REST API on http://127.0.0.1:"+strconv.Itoa(f.proxy.port)+"/api/         ", targetWidth))
 			p(util.CenterString("", targetWidth))
 			p(util.CenterString("Press Ctrl-C to stop", targetWidth))
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package record handles command line parameters and execution logic for
+// recording eval cases from the sessions of a running ADK REST API server.
+package record
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/adk/cmd/adkgo/internal/eval"
+	adkeval "google.golang.org/adk/eval"
+)
+
+type recordFlags struct {
+	serverURL string
+	appName   string
+	userID    string
+	sessionID string
+	evalSet   string
+	evalID    string
+	replace   bool
+}
+
+var flags recordFlags
+
+// recordCmd represents the record command
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Records a session as an eval case.",
+	Long: `Fetches a session from a running ADK REST API server, e.g. a conversation held in the Web UI,
+	and adds it as an eval case to an eval set file, which is created if needed.
+	The tool calls and final responses of the agent in the session become the expected behavior.
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return flags.record(cmd.OutOrStdout())
+	},
+}
+
+// init creates flags and adds subcommand to parent
+func init() {
+	eval.EvalCmd.AddCommand(recordCmd)
+
+	recordCmd.PersistentFlags().StringVar(&flags.serverURL, "server_url", "http://localhost:8080/api", "URL of the ADK REST API, including its path prefix")
+	recordCmd.PersistentFlags().StringVarP(&flags.appName, "app_name", "a", "", "App name of the session")
+	recordCmd.PersistentFlags().StringVarP(&flags.userID, "user_id", "u", "", "User ID of the session")
+	recordCmd.PersistentFlags().StringVarP(&flags.sessionID, "session_id", "s", "", "ID of the session to record")
+	recordCmd.PersistentFlags().StringVarP(&flags.evalSet, "eval_set", "e", "", "Path to the .evalset.json file to add the eval case to")
+	recordCmd.PersistentFlags().StringVar(&flags.evalID, "eval_id", "", "ID of the eval case, defaults to the session ID")
+	recordCmd.PersistentFlags().BoolVar(&flags.replace, "replace", false, "Replace the eval case with the same ID in the eval set")
+}
+
+func (f *recordFlags) record(out io.Writer) error {
+	if f.appName == "" || f.userID == "" || f.sessionID == "" || f.evalSet == "" {
+		return fmt.Errorf("app_name, user_id, session_id and eval_set are required")
+	}
+	evalCase, err := f.fetchEvalCase()
+	if err != nil {
+		return err
+	}
+
+	set, err := adkeval.LoadEvalSet(f.evalSet)
+	if errors.Is(err, fs.ErrNotExist) {
+		id := strings.TrimSuffix(filepath.Base(f.evalSet), ".evalset.json")
+		set = &adkeval.EvalSet{EvalSetID: id, Name: id, EvalCases: []*adkeval.EvalCase{}}
+	} else if err != nil {
+		return err
+	}
+	if err := set.AddCase(evalCase, f.replace); err != nil {
+		return err
+	}
+	if err := adkeval.SaveEvalSet(f.evalSet, set); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Recorded session %s as eval case %q with %d invocation(s) in %s\n", f.sessionID, evalCase.EvalID, len(evalCase.Conversation), f.evalSet)
+	return nil
+}
+
+func (f *recordFlags) fetchEvalCase() (*adkeval.EvalCase, error) {
+	u := fmt.Sprintf("%s/apps/%s/users/%s/sessions/%s/eval_case", strings.TrimSuffix(f.serverURL, "/"),
+		url.PathEscape(f.appName), url.PathEscape(f.userID), url.PathEscape(f.sessionID))
+	if f.evalID != "" {
+		u += "?eval_id=" + url.QueryEscape(f.evalID)
+	}
+	resp, err := http.Get(u)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reach the ADK REST API: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the eval case: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot record session %s: %s: %s", f.sessionID, resp.Status, strings.TrimSpace(string(body)))
+	}
+	var evalCase adkeval.EvalCase
+	if err := json.Unmarshal(body, &evalCase); err != nil {
+		return nil, fmt.Errorf("cannot decode the eval case: %w", err)
+	}
+	return &evalCase, nil
+}
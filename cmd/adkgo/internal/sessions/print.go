@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/session"
+)
+
+// printSession writes the state and the events of the session in a human
+// readable form.
+func printSession(out io.Writer, s session.Session) {
+	fmt.Fprintf(out, "Session %s of user %s in app %s, last updated %s\n", s.ID(), s.UserID(), s.AppName(), s.LastUpdateTime().Local().Format(time.DateTime))
+
+	state := maps.Collect(s.State().All())
+	if len(state) > 0 {
+		fmt.Fprintln(out, "\nState:")
+		for _, k := range slices.Sorted(maps.Keys(state)) {
+			fmt.Fprintf(out, "  %s = %s\n", k, compactJSON(state[k]))
+		}
+	}
+
+	fmt.Fprintf(out, "\nEvents (%d):\n", s.Events().Len())
+	for event := range s.Events().All() {
+		printEvent(out, event)
+	}
+}
+
+func printEvent(out io.Writer, event *session.Event) {
+	prefix := fmt.Sprintf("[%s] %s", event.Timestamp.Local().Format(time.TimeOnly), event.Author)
+	if event.Content != nil {
+		for _, part := range event.Content.Parts {
+			fmt.Fprintf(out, "%s: %s\n", prefix, formatPart(part))
+		}
+	}
+	if event.ErrorCode != "" || event.ErrorMessage != "" {
+		fmt.Fprintf(out, "%s: [error %s] %s\n", prefix, event.ErrorCode, event.ErrorMessage)
+	}
+	for _, k := range slices.Sorted(maps.Keys(event.Actions.StateDelta)) {
+		fmt.Fprintf(out, "%s: [state] %s = %s\n", prefix, k, compactJSON(event.Actions.StateDelta[k]))
+	}
+	for _, name := range slices.Sorted(maps.Keys(event.Actions.ArtifactDelta)) {
+		fmt.Fprintf(out, "%s: [artifact] %s v%d\n", prefix, name, event.Actions.ArtifactDelta[name])
+	}
+	if event.Actions.TransferToAgent != "" {
+		fmt.Fprintf(out, "%s: [transfer] %s\n", prefix, event.Actions.TransferToAgent)
+	}
+	if event.Actions.Escalate {
+		fmt.Fprintf(out, "%s: [escalate]\n", prefix)
+	}
+}
+
+func formatPart(part *genai.Part) string {
+	switch {
+	case part.FunctionCall != nil:
+		return fmt.Sprintf("[tool call] %s(%s)", part.FunctionCall.Name, compactJSON(part.FunctionCall.Args))
+	case part.FunctionResponse != nil:
+		return fmt.Sprintf("[tool response] %s: %s", part.FunctionResponse.Name, compactJSON(part.FunctionResponse.Response))
+	case part.Thought:
+		return "[thought] " + oneLine(part.Text)
+	case part.Text != "":
+		return oneLine(part.Text)
+	case part.InlineData != nil:
+		return fmt.Sprintf("[inline data] %s, %d bytes", part.InlineData.MIMEType, len(part.InlineData.Data))
+	case part.FileData != nil:
+		return fmt.Sprintf("[file] %s", part.FileData.FileURI)
+	case part.ExecutableCode != nil:
+		return "[code] " + oneLine(part.ExecutableCode.Code)
+	case part.CodeExecutionResult != nil:
+		return "[code result] " + oneLine(part.CodeExecutionResult.Output)
+	default:
+		return "[empty part]"
+	}
+}
+
+// oneLine indents the continuation lines of multi-line text.
+func oneLine(text string) string {
+	return strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", "\n    ")
+}
+
+func compactJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// exportedSession is the JSON form of an exported session, with the field
+// names of the ADK REST API.
+type exportedSession struct {
+	ID             string           `json:"id"`
+	AppName        string           `json:"appName"`
+	UserID         string           `json:"userId"`
+	LastUpdateTime int64            `json:"lastUpdateTime"`
+	State          map[string]any   `json:"state"`
+	Events         []*exportedEvent `json:"events"`
+}
+
+type exportedEvent struct {
+	ID                 string                                      `json:"id"`
+	Timestamp          float64                                     `json:"timestamp"`
+	InvocationID       string                                      `json:"invocationId"`
+	Branch             string                                      `json:"branch,omitempty"`
+	Author             string                                      `json:"author"`
+	LongRunningToolIDs []string                                    `json:"longRunningToolIds,omitempty"`
+	Content            *genai.Content                              `json:"content,omitempty"`
+	UsageMetadata      *genai.GenerateContentResponseUsageMetadata `json:"usageMetadata,omitempty"`
+	ErrorCode          string                                      `json:"errorCode,omitempty"`
+	ErrorMessage       string                                      `json:"errorMessage,omitempty"`
+	FinishReason       genai.FinishReason                          `json:"finishReason,omitempty"`
+	Actions            exportedEventActions                        `json:"actions"`
+}
+
+type exportedEventActions struct {
+	StateDelta        map[string]any   `json:"stateDelta,omitempty"`
+	ArtifactDelta     map[string]int64 `json:"artifactDelta,omitempty"`
+	Escalate          bool             `json:"escalate,omitempty"`
+	SkipSummarization bool             `json:"skipSummarization,omitempty"`
+	TransferToAgent   string           `json:"transferToAgent,omitempty"`
+}
+
+func exportSession(s session.Session) exportedSession {
+	exported := exportedSession{
+		ID:             s.ID(),
+		AppName:        s.AppName(),
+		UserID:         s.UserID(),
+		LastUpdateTime: s.LastUpdateTime().Unix(),
+		State:          maps.Collect(s.State().All()),
+		Events:         []*exportedEvent{},
+	}
+	for event := range s.Events().All() {
+		exported.Events = append(exported.Events, &exportedEvent{
+			ID:                 event.ID,
+			Timestamp:          float64(event.Timestamp.UnixMicro()) / 1e6,
+			InvocationID:       event.InvocationID,
+			Branch:             event.Branch,
+			Author:             event.Author,
+			LongRunningToolIDs: event.LongRunningToolIDs,
+			Content:            event.Content,
+			UsageMetadata:      event.UsageMetadata,
+			ErrorCode:          event.ErrorCode,
+			ErrorMessage:       event.ErrorMessage,
+			FinishReason:       event.FinishReason,
+			Actions: exportedEventActions{
+				StateDelta:        event.Actions.StateDelta,
+				ArtifactDelta:     event.Actions.ArtifactDelta,
+				Escalate:          event.Actions.Escalate,
+				SkipSummarization: event.Actions.SkipSummarization,
+				TransferToAgent:   event.Actions.TransferToAgent,
+			},
+		})
+	}
+	return exported
+}
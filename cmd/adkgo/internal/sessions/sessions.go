@@ -0,0 +1,240 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sessions handles command line parameters and execution logic for
+// inspecting and managing the sessions stored by a session backend.
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/adk/cmd/adkgo/internal/root"
+	"google.golang.org/adk/cmd/launcher/settings"
+	"google.golang.org/adk/session"
+)
+
+type sessionsFlags struct {
+	dsn        string
+	envFile    string
+	configFile string
+	appName    string
+	userID     string
+	output     string
+}
+
+var flags sessionsFlags
+
+// sessionsCmd represents the sessions command
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Inspects and manages stored sessions.",
+	Long: `Lists, shows, deletes and exports the sessions of an app stored by a session backend.
+	The backend is selected like in the launcher: by the session DSN of the config file, overridden by
+	the ADK_SESSION_DSN environment variable, optionally set in the .env file, overridden by --session_dsn.
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the sessions of an app, optionally of a single user.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return flags.list(cmd.Context(), cmd.OutOrStdout())
+	},
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show <session_id>",
+	Short: "Prints the state and the event history of a session.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return flags.show(cmd.Context(), cmd.OutOrStdout(), args[0])
+	},
+}
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <session_id>...",
+	Short: "Deletes sessions.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return flags.delete(cmd.Context(), cmd.OutOrStdout(), args)
+	},
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [session_id...]",
+	Short: "Exports sessions as JSON.",
+	Long: `Writes the given sessions, or all the sessions of the app (and user), with their state and events
+	as a JSON array.
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return flags.export(cmd.Context(), cmd.OutOrStdout(), args)
+	},
+}
+
+// init creates flags and adds subcommand to parent
+func init() {
+	root.RootCmd.AddCommand(sessionsCmd)
+	sessionsCmd.AddCommand(listCmd, showCmd, deleteCmd, exportCmd)
+
+	sessionsCmd.PersistentFlags().StringVar(&flags.dsn, "session_dsn", "", "Session backend, e.g. sqlite://sessions.db (env ADK_SESSION_DSN)")
+	sessionsCmd.PersistentFlags().StringVar(&flags.envFile, "env_file", ".env", "File setting environment variables, in the .env format")
+	sessionsCmd.PersistentFlags().StringVar(&flags.configFile, "config", "", "YAML or TOML config file of the launcher")
+	sessionsCmd.PersistentFlags().StringVarP(&flags.appName, "app_name", "a", "", "App name of the sessions")
+	sessionsCmd.PersistentFlags().StringVarP(&flags.userID, "user_id", "u", "", "User ID of the sessions, required to show, delete or export given sessions")
+	exportCmd.Flags().StringVarP(&flags.output, "output", "o", "", "File the sessions are written to, defaults to the standard output")
+}
+
+// service returns the session service of the settings.
+func (f *sessionsFlags) service() (session.Service, error) {
+	if f.appName == "" {
+		return nil, fmt.Errorf("app_name is required")
+	}
+	loader := settings.NewLoader()
+	loader.EnvFile = f.envFile
+	loader.ConfigFile = f.configFile
+	s, err := loader.Load()
+	if err != nil {
+		return nil, err
+	}
+	if f.dsn != "" {
+		s.Session.DSN = f.dsn
+	}
+	if s.Session.DSN == "" || s.Session.DSN == "memory" {
+		return nil, fmt.Errorf("no persistent session backend: set --session_dsn, ADK_SESSION_DSN or the session DSN of the config file")
+	}
+	return s.NewSessionService()
+}
+
+func (f *sessionsFlags) list(ctx context.Context, out io.Writer) error {
+	service, err := f.service()
+	if err != nil {
+		return err
+	}
+	resp, err := service.List(ctx, &session.ListRequest{AppName: f.appName, UserID: f.userID})
+	if err != nil {
+		return fmt.Errorf("failed to list the sessions: %w", err)
+	}
+	sessions := slices.SortedFunc(slices.Values(resp.Sessions), func(a, b session.Session) int {
+		// Most recently updated first.
+		return b.LastUpdateTime().Compare(a.LastUpdateTime())
+	})
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SESSION ID\tUSER ID\tLAST UPDATE")
+	for _, s := range sessions {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.ID(), s.UserID(), s.LastUpdateTime().Local().Format(time.DateTime))
+	}
+	return w.Flush()
+}
+
+func (f *sessionsFlags) get(ctx context.Context, service session.Service, userID, sessionID string) (session.Session, error) {
+	resp, err := service.Get(ctx, &session.GetRequest{AppName: f.appName, UserID: userID, SessionID: sessionID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the session %s: %w", sessionID, err)
+	}
+	return resp.Session, nil
+}
+
+func (f *sessionsFlags) show(ctx context.Context, out io.Writer, sessionID string) error {
+	if f.userID == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	service, err := f.service()
+	if err != nil {
+		return err
+	}
+	s, err := f.get(ctx, service, f.userID, sessionID)
+	if err != nil {
+		return err
+	}
+	printSession(out, s)
+	return nil
+}
+
+func (f *sessionsFlags) delete(ctx context.Context, out io.Writer, sessionIDs []string) error {
+	if f.userID == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	service, err := f.service()
+	if err != nil {
+		return err
+	}
+	for _, id := range sessionIDs {
+		if err := service.Delete(ctx, &session.DeleteRequest{AppName: f.appName, UserID: f.userID, SessionID: id}); err != nil {
+			return fmt.Errorf("failed to delete the session %s: %w", id, err)
+		}
+		fmt.Fprintf(out, "Deleted the session %s\n", id)
+	}
+	return nil
+}
+
+func (f *sessionsFlags) export(ctx context.Context, out io.Writer, sessionIDs []string) error {
+	if len(sessionIDs) > 0 && f.userID == "" {
+		return fmt.Errorf("user_id is required to export given sessions")
+	}
+	service, err := f.service()
+	if err != nil {
+		return err
+	}
+	type key struct{ userID, sessionID string }
+	var keys []key
+	for _, id := range sessionIDs {
+		keys = append(keys, key{f.userID, id})
+	}
+	if len(sessionIDs) == 0 {
+		resp, err := service.List(ctx, &session.ListRequest{AppName: f.appName, UserID: f.userID})
+		if err != nil {
+			return fmt.Errorf("failed to list the sessions: %w", err)
+		}
+		for _, s := range resp.Sessions {
+			keys = append(keys, key{s.UserID(), s.ID()})
+		}
+	}
+	exported := make([]exportedSession, 0, len(keys))
+	for _, k := range keys {
+		// The listed sessions have no events.
+		s, err := f.get(ctx, service, k.userID, k.sessionID)
+		if err != nil {
+			return err
+		}
+		exported = append(exported, exportSession(s))
+	}
+
+	b, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the sessions: %w", err)
+	}
+	b = append(b, '\n')
+	if f.output == "" {
+		_, err = out.Write(b)
+		return err
+	}
+	if err := os.WriteFile(f.output, b, 0o644); err != nil {
+		return fmt.Errorf("failed to write the sessions: %w", err)
+	}
+	fmt.Fprintf(out, "Exported %d sessions to %s\n", len(exported), f.output)
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessions
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/cmd/launcher/settings"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+// newTestFlags returns the flags of a SQLite backend with a session of
+// user1 with events, and an empty session of user2.
+func newTestFlags(t *testing.T) *sessionsFlags {
+	t.Helper()
+	t.Setenv("ADK_SESSION_DSN", "")
+	os.Unsetenv("ADK_SESSION_DSN")
+	f := &sessionsFlags{
+		dsn:     "sqlite://" + filepath.Join(t.TempDir(), "sessions.db"),
+		envFile: filepath.Join(t.TempDir(), ".env"),
+		appName: "app",
+	}
+	if err := os.WriteFile(f.envFile, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	service, err := (&settings.Settings{Session: settings.SessionSettings{DSN: f.dsn}}).NewSessionService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := t.Context()
+	resp, err := service.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user1", SessionID: "s1", State: map[string]any{"unit": "celsius"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []*session.Event{
+		{Author: "user", LLMResponse: model.LLMResponse{Content: genai.NewContentFromText("Weather in Paris?", genai.RoleUser)}},
+		{Author: "weather_agent", LLMResponse: model.LLMResponse{Content: genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": "Paris"}, genai.RoleModel)}},
+		{Author: "weather_agent", LLMResponse: model.LLMResponse{Content: genai.NewContentFromText("It is sunny.", genai.RoleModel)}, Actions: session.EventActions{StateDelta: map[string]any{"last_city": "Paris"}}},
+	}
+	for _, event := range events {
+		e := session.NewEvent("inv1")
+		e.Author, e.LLMResponse = event.Author, event.LLMResponse
+		if event.Actions.StateDelta != nil {
+			e.Actions.StateDelta = event.Actions.StateDelta
+		}
+		if err := service.AppendEvent(ctx, resp.Session, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := service.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user2", SessionID: "s2"}); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestList(t *testing.T) {
+	f := newTestFlags(t)
+	var out strings.Builder
+	if err := f.list(t.Context(), &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"SESSION ID", "s1", "user1", "s2", "user2"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("list output %q does not contain %q", out.String(), want)
+		}
+	}
+
+	f.userID = "user2"
+	out.Reset()
+	if err := f.list(t.Context(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "s1") {
+		t.Errorf("list output %q of user2 contains the session of user1", out.String())
+	}
+}
+
+func TestShow(t *testing.T) {
+	f := newTestFlags(t)
+	f.userID = "user1"
+	var out strings.Builder
+	if err := f.show(t.Context(), &out, "s1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`unit = "celsius"`,
+		"Events (3):",
+		"user: Weather in Paris?",
+		`weather_agent: [tool call] get_weather({"city":"Paris"})`,
+		"weather_agent: It is sunny.",
+		`weather_agent: [state] last_city = "Paris"`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("show output %q does not contain %q", out.String(), want)
+		}
+	}
+
+	f.userID = ""
+	if err := f.show(t.Context(), &out, "s1"); err == nil {
+		t.Error("show() without user_id succeeded, want error")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	f := newTestFlags(t)
+	f.userID = "user1"
+	var out strings.Builder
+	if err := f.delete(t.Context(), &out, []string{"s1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.show(t.Context(), &out, "s1"); err == nil {
+		t.Error("show() of the deleted session succeeded, want error")
+	}
+}
+
+func TestExport(t *testing.T) {
+	f := newTestFlags(t)
+	f.output = filepath.Join(t.TempDir(), "sessions.json")
+	var out strings.Builder
+	if err := f.export(t.Context(), &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(f.output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []exportedSession
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("exported %d sessions, want 2", len(got))
+	}
+	for _, s := range got {
+		if s.ID == "s1" && (len(s.Events) != 3 || s.State["unit"] != "celsius" || s.Events[1].Content.Parts[0].FunctionCall.Name != "get_weather") {
+			t.Errorf("exported session = %+v, want its state and events", s)
+		}
+	}
+
+	if err := f.export(t.Context(), &out, []string{"s1"}); err == nil {
+		t.Error("export() of a session without user_id succeeded, want error")
+	}
+}
+
+func TestService_NoBackend(t *testing.T) {
+	f := newTestFlags(t)
+	f.dsn = "memory"
+	if _, err := f.service(); err == nil {
+		t.Error("service() of in-memory sessions succeeded, want error")
+	}
+}
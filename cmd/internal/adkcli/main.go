@@ -49,12 +49,41 @@ func main() {
 		log.Fatalf("Error registering functions: %v", err)
 	}
 
-	fmt.Printf("🔍 Scanning for 'root_agent.yaml' in: %s\n", cwd)
+	ctx := context.Background()
+
+	loader, err := loadAgents(ctx, cwd)
+	if err != nil {
+		log.Fatalf("Error loading agent: %v", err)
+	}
+
+	config := &launcher.Config{
+		AgentLoader: loader,
+		// The dev mode of the web launcher reloads the configs on change.
+		ReloadAgentLoader: func(ctx context.Context) (agent.Loader, error) {
+			return loadAgents(ctx, cwd)
+		},
+		PluginConfig: runner.PluginConfig{
+			Plugins: []*plugin.Plugin{
+				replayplugin.MustNew(cwd),
+			},
+		},
+	}
+
+	l := full.NewLauncher()
+	if err = l.Execute(ctx, config, os.Args[1:]); err != nil {
+		log.Fatalf("Run failed: %v\n\n%s", err, l.CommandLineSyntax())
+	}
+}
+
+// loadAgents loads the agents of the root_agent.yaml files found in dir and
+// its subdirectories.
+func loadAgents(ctx context.Context, dir string) (agent.Loader, error) {
+	fmt.Printf("🔍 Scanning for 'root_agent.yaml' in: %s\n", dir)
 
 	// 2. Crawl folder structure to find all configs
 	var agentConfigs []string
 
-	err = filepath.WalkDir(cwd, func(path string, d fs.DirEntry, err error) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			// Report error but continue walking other files
 			fmt.Printf("Warning: skipping %q due to error: %v\n", path, err)
@@ -68,57 +97,36 @@ func main() {
 		return nil
 	})
 	if err != nil {
-		log.Fatalf("Error walking the path: %v", err)
+		return nil, fmt.Errorf("error walking the path: %w", err)
 	}
 
 	// 3. Check if we found anything
 	if len(agentConfigs) == 0 {
-		fmt.Printf("❌ No 'root_agent.yaml' files found in %s or subdirectories\n", cwd)
-		os.Exit(1)
+		return nil, fmt.Errorf("no 'root_agent.yaml' files found in %s or subdirectories", dir)
 	}
 
 	fmt.Printf("🚀 Found %d agent config(s)\n", len(agentConfigs))
-	agentsMap := make(map[string]agent.Agent, len(agentConfigs))
-	// 4. Iterate and Load all agents found
+	apps := make(map[string]agent.AppConstructor, len(agentConfigs))
+	// 4. Register an app per agent config, named by its folder. The agents
+	// are loaded on their first use.
 	for _, configPath := range agentConfigs {
-		fmt.Printf("➡️  Loading agent from: %s\n", configPath)
-
-		// This reads the YAML, finds the 'agent_class', and calls the registered factory.
-		myAgent, err := configurable.FromConfig(context.Background(), configPath)
-		if err != nil {
-			log.Printf("⚠️  Error loading agent at %s: %v", configPath, err)
-			continue // Skip this one and try the next
-		}
-		fmt.Printf("✅ Agent loaded successfully: %s\n", myAgent.Name())
-
 		folderName := filepath.Base(filepath.Dir(configPath))
-		fmt.Printf("✅ Agent folder name: %s\n", folderName)
-
-		if _, ok := agentsMap[folderName]; ok {
-			log.Printf("⚠️  Agent %s already exists, skipping", folderName)
+		if _, ok := apps[folderName]; ok {
+			log.Printf("⚠️  Agent %s already exists, skipping %s", folderName, configPath)
 			continue
 		}
-		agentsMap[folderName] = myAgent
-	}
-
-	ctx := context.Background()
-
-	loader, err := conformance.NewConformanceAgentLoader(agentsMap)
-	if err != nil {
-		log.Fatalf("Error loading agent: %v", err)
-	}
-
-	config := &launcher.Config{
-		AgentLoader: loader,
-		PluginConfig: runner.PluginConfig{
-			Plugins: []*plugin.Plugin{
-				replayplugin.MustNew(cwd),
-			},
-		},
+		apps[folderName] = func() (agent.Agent, error) {
+			fmt.Printf("➡️  Loading agent from: %s\n", configPath)
+			// This reads the YAML, finds the 'agent_class', and calls the registered factory.
+			myAgent, err := configurable.FromConfig(ctx, configPath)
+			if err != nil {
+				log.Printf("⚠️  Error loading agent at %s: %v", configPath, err)
+				return nil, err
+			}
+			fmt.Printf("✅ Agent loaded successfully: %s\n", myAgent.Name())
+			return myAgent, nil
+		}
 	}
 
-	l := full.NewLauncher()
-	if err = l.Execute(ctx, config, os.Args[1:]); err != nil {
-		log.Fatalf("Run failed: %v\n\n%s", err, l.CommandLineSyntax())
-	}
+	return agent.NewAppLoader("", apps)
 }
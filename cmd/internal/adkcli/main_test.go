@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadAgents_InvalidApp(t *testing.T) {
+	dir := t.TempDir()
+	configs := map[string]string{
+		// The invalid app comes first in name order, as the default root.
+		"a_invalid": "agent_class: UnknownAgent\nname: invalid\n",
+		"b_valid":   "agent_class: LoopAgent\nname: valid\nmax_iterations: 1\n",
+	}
+	for app, config := range configs {
+		if err := os.Mkdir(filepath.Join(dir, app), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, app, "root_agent.yaml"), []byte(config), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loader, err := loadAgents(t.Context(), dir)
+	if err != nil {
+		t.Fatalf("loadAgents() error = %v", err)
+	}
+	if got, want := loader.ListAgents(), []string{"a_invalid", "b_valid"}; !slices.Equal(got, want) {
+		t.Errorf("ListAgents() = %v, want %v", got, want)
+	}
+	if got := loader.RootAgent().Name(); got != "valid" {
+		t.Errorf("RootAgent() = %q, want valid", got)
+	}
+	if _, err := loader.LoadAgent("a_invalid"); err == nil {
+		t.Error("LoadAgent(a_invalid) succeeded, want error")
+	}
+}
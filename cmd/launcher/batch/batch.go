@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package batch provides a launcher running an agent over the records of
+// a dataset file, see the batch package.
+package batch
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"google.golang.org/adk/batch"
+	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/cmd/launcher/universal"
+	"google.golang.org/adk/internal/cli/util"
+)
+
+// batchConfig contains command-line params for batch launcher
+type batchConfig struct {
+	inputPath   string
+	outputPath  string
+	parallelism int
+	resume      bool
+	agentName   string
+}
+
+// batchLauncher runs an agent over a dataset file
+type batchLauncher struct {
+	flags  *flag.FlagSet // flags are used to parse command-line arguments
+	config *batchConfig  // config contains parsed command-line parameters
+}
+
+// NewLauncher creates new batch launcher
+func NewLauncher() launcher.SubLauncher {
+	config := &batchConfig{}
+
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	fs.StringVar(&config.inputPath, "input", "", "dataset file, CSV with a header row if it has the .csv extension, JSON lines otherwise")
+	fs.StringVar(&config.outputPath, "output", "", "file the results are written to, CSV if it has the .csv extension, JSON lines otherwise")
+	fs.IntVar(&config.parallelism, "parallelism", 1, "number of records run concurrently")
+	fs.BoolVar(&config.resume, "resume", false, "resumes the job of the existing output, skipping the records already succeeded")
+	fs.StringVar(&config.agentName, "agent", "", "name of the agent to run, as known to the agent loader; defaults to the root agent")
+	return &batchLauncher{config: config, flags: fs}
+}
+
+// Run implements launcher.SubLauncher. It runs the agent over the records
+// of the input.
+func (l *batchLauncher) Run(ctx context.Context, config *launcher.Config) error {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+	config.SetupLogger()
+
+	rootAgent := config.AgentLoader.RootAgent()
+	if l.config.agentName != "" {
+		var err error
+		rootAgent, err = config.AgentLoader.LoadAgent(l.config.agentName)
+		if err != nil {
+			return fmt.Errorf("failed to load the agent: %w", err)
+		}
+	}
+
+	summary, err := batch.Run(ctx, batch.Config{
+		Agent:           rootAgent,
+		SessionService:  config.SessionService,
+		ArtifactService: config.ArtifactService,
+		PluginConfig:    config.PluginConfig,
+		InputPath:       l.config.inputPath,
+		OutputPath:      l.config.outputPath,
+		Resume:          l.config.resume,
+		Parallelism:     l.config.parallelism,
+		Progress:        batch.ProgressPrinter(os.Stderr),
+	})
+	if err != nil {
+		return fmt.Errorf("batch job failed: %w", err)
+	}
+	fmt.Printf("%d succeeded, %d failed, %d skipped\n", summary.Succeeded, summary.Failed, summary.Skipped)
+	return nil
+}
+
+// Parse implements launcher.SubLauncher. After parsing batch-specific
+// arguments returns remaining un-parsed arguments
+func (l *batchLauncher) Parse(args []string) ([]string, error) {
+	err := l.flags.Parse(args)
+	if err != nil || !l.flags.Parsed() {
+		return nil, fmt.Errorf("failed to parse flags: %v", err)
+	}
+	if l.config.inputPath == "" || l.config.outputPath == "" {
+		return nil, fmt.Errorf("-input and -output are required")
+	}
+	return l.flags.Args(), nil
+}
+
+// Keyword implements launcher.SubLauncher. Returns the command-line keyword for this launcher.
+func (l *batchLauncher) Keyword() string {
+	return "batch"
+}
+
+// CommandLineSyntax implements launcher.SubLauncher. Returns the command-line syntax for the batch launcher.
+func (l *batchLauncher) CommandLineSyntax() string {
+	return util.FormatFlagUsage(l.flags)
+}
+
+// SimpleDescription implements launcher.SubLauncher. Returns a simple description of the batch launcher.
+func (l *batchLauncher) SimpleDescription() string {
+	return "runs an agent over the records of a dataset file."
+}
+
+// Execute implements launcher.Launcher. It parses arguments and runs the launcher.
+func (l *batchLauncher) Execute(ctx context.Context, config *launcher.Config, args []string) error {
+	remainingArgs, err := l.Parse(args)
+	if err != nil {
+		return fmt.Errorf("cannot parse args: %w", err)
+	}
+	// do not accept additional arguments
+	err = universal.ErrorOnUnparsedArgs(remainingArgs)
+	if err != nil {
+		return fmt.Errorf("cannot parse all the arguments: %w", err)
+	}
+	return l.Run(ctx, config)
+}
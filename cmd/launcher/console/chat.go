@@ -0,0 +1,234 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package console
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+const chatHelp = `Commands:
+  /new        start a new session
+  /state      print the state of the session
+  /artifacts  list the artifacts of the session
+  /help       print this help
+  /exit       exit the chat`
+
+// chat is a chat with an agent in the console. Input lines starting with '/'
+// are commands, the others are sent to the agent.
+type chat struct {
+	runner          *runner.Runner
+	sessionService  session.Service
+	artifactService artifact.Service
+	appName, userID string
+	sessionID       string
+	streamingMode   agent.StreamingMode
+	out             io.Writer
+	// echo prints the input after the prompt, for input not typed in the
+	// console.
+	echo bool
+}
+
+func (c *chat) newSession(ctx context.Context) error {
+	resp, err := c.sessionService.Create(ctx, &session.CreateRequest{
+		AppName: c.appName,
+		UserID:  c.userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the session: %w", err)
+	}
+	c.sessionID = resp.Session.ID()
+	return nil
+}
+
+// loop reads the input line by line until it ends, the context is cancelled
+// or the user exits.
+func (c *chat) loop(ctx context.Context, input io.Reader) error {
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(input)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	fmt.Fprint(c.out, "\nUser -> ")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				if !c.echo {
+					fmt.Fprintln(c.out, "\nEOF detected, exiting...")
+				}
+				return nil
+			}
+			return fmt.Errorf("failed to read the input: %w", err)
+		case line := <-lines:
+			line = strings.TrimSpace(line)
+			if c.echo {
+				if line == "" || strings.HasPrefix(line, "#") {
+					continue
+				}
+				fmt.Fprintln(c.out, line)
+			}
+			if c.handle(ctx, line) {
+				return nil
+			}
+			fmt.Fprint(c.out, "\nUser -> ")
+		}
+	}
+}
+
+// handle handles an input line, and reports whether the user exits.
+func (c *chat) handle(ctx context.Context, line string) bool {
+	switch {
+	case line == "":
+		return false
+	case strings.HasPrefix(line, "/"):
+		return c.command(ctx, line)
+	default:
+		c.send(ctx, line)
+		return false
+	}
+}
+
+func (c *chat) command(ctx context.Context, line string) bool {
+	switch cmd := strings.Fields(line)[0]; cmd {
+	case "/exit", "/quit":
+		return true
+	case "/help":
+		fmt.Fprintln(c.out, chatHelp)
+	case "/new":
+		if err := c.newSession(ctx); err != nil {
+			fmt.Fprintf(c.out, "ERROR: %v\n", err)
+			return false
+		}
+		fmt.Fprintf(c.out, "Started session %s\n", c.sessionID)
+	case "/state":
+		resp, err := c.sessionService.Get(ctx, &session.GetRequest{AppName: c.appName, UserID: c.userID, SessionID: c.sessionID})
+		if err != nil {
+			fmt.Fprintf(c.out, "ERROR: %v\n", err)
+			return false
+		}
+		b, err := json.MarshalIndent(maps.Collect(resp.Session.State().All()), "", "  ")
+		if err != nil {
+			fmt.Fprintf(c.out, "ERROR: %v\n", err)
+			return false
+		}
+		fmt.Fprintln(c.out, string(b))
+	case "/artifacts":
+		if c.artifactService == nil {
+			fmt.Fprintln(c.out, "No artifact service is configured.")
+			return false
+		}
+		resp, err := c.artifactService.List(ctx, &artifact.ListRequest{AppName: c.appName, UserID: c.userID, SessionID: c.sessionID})
+		if err != nil {
+			fmt.Fprintf(c.out, "ERROR: %v\n", err)
+			return false
+		}
+		if len(resp.FileNames) == 0 {
+			fmt.Fprintln(c.out, "No artifacts.")
+		}
+		for _, name := range resp.FileNames {
+			fmt.Fprintln(c.out, name)
+		}
+	default:
+		fmt.Fprintf(c.out, "Unknown command %s.\n%s\n", cmd, chatHelp)
+	}
+	return false
+}
+
+// send sends the user message to the agent and prints the response as it
+// streams, with the tool calls and their responses.
+func (c *chat) send(ctx context.Context, text string) {
+	fmt.Fprint(c.out, "\nAgent -> ")
+	prevText := ""
+	for event, err := range c.runner.Run(ctx, c.userID, c.sessionID, genai.NewContentFromText(text, genai.RoleUser), agent.RunConfig{
+		StreamingMode: c.streamingMode,
+	}) {
+		if err != nil {
+			fmt.Fprintf(c.out, "\nAGENT_ERROR: %v\n", err)
+			continue
+		}
+		if event.LLMResponse.Content == nil {
+			continue
+		}
+
+		text := ""
+		for _, p := range event.LLMResponse.Content.Parts {
+			text += p.Text
+			if !event.Partial {
+				c.printToolPart(p)
+			}
+		}
+
+		if c.streamingMode != agent.StreamingModeSSE {
+			fmt.Fprint(c.out, text)
+			continue
+		}
+
+		// In SSE mode, always print partial responses and capture them.
+		if !event.IsFinalResponse() {
+			fmt.Fprint(c.out, text)
+			prevText += text
+			continue
+		}
+
+		// Only print final response if it doesn't match previously captured text.
+		if text != prevText {
+			fmt.Fprint(c.out, text)
+		}
+
+		prevText = ""
+	}
+}
+
+func (c *chat) printToolPart(p *genai.Part) {
+	switch {
+	case p.FunctionCall != nil:
+		args, _ := json.Marshal(p.FunctionCall.Args)
+		fmt.Fprintf(c.out, "\n[tool call] %s(%s)\n", p.FunctionCall.Name, args)
+	case p.FunctionResponse != nil:
+		resp, _ := json.Marshal(p.FunctionResponse.Response)
+		fmt.Fprintf(c.out, "[tool response] %s: %s\n", p.FunctionResponse.Name, resp)
+	}
+}
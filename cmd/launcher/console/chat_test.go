@@ -0,0 +1,140 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package console
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func newTestChat(t *testing.T, out *strings.Builder) *chat {
+	t.Helper()
+	type args struct {
+		Name string `json:"name"`
+	}
+	saveNote, err := functiontool.New(functiontool.Config{Name: "save_note"}, func(ctx tool.Context, a args) (map[string]any, error) {
+		if err := ctx.State().Set("last_note", a.Name); err != nil {
+			return nil, err
+		}
+		if _, err := ctx.Artifacts().Save(ctx, a.Name, genai.NewPartFromText("note")); err != nil {
+			return nil, err
+		}
+		return map[string]any{"saved": a.Name}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name: "note_agent",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("save_note", map[string]any{"name": "todo.txt"}, genai.RoleModel),
+			genai.NewContentFromText("Saved.", genai.RoleModel),
+		}},
+		Tools: []tool.Tool{saveNote},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionService, artifactService := session.InMemoryService(), artifact.InMemoryService()
+	r, err := runner.New(runner.Config{
+		AppName:         "console_app",
+		Agent:           a,
+		SessionService:  sessionService,
+		ArtifactService: artifactService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &chat{
+		runner:          r,
+		sessionService:  sessionService,
+		artifactService: artifactService,
+		appName:         "console_app",
+		userID:          "console_user",
+		streamingMode:   agent.StreamingModeNone,
+		out:             out,
+		echo:            true,
+	}
+	if err := c.newSession(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestChat(t *testing.T) {
+	var out strings.Builder
+	c := newTestChat(t, &out)
+	firstSession := c.sessionID
+	input := strings.Join([]string{
+		"# Saves a note.",
+		"Save a todo note",
+		"",
+		"/state",
+		"/artifacts",
+		"/new",
+		"/state",
+		"/unknown",
+		"/exit",
+		"This is not sent.",
+	}, "\n")
+	if err := c.loop(t.Context(), strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+	got := out.String()
+	for _, want := range []string{
+		"User -> Save a todo note\n",
+		`[tool call] save_note({"name":"todo.txt"})`,
+		`[tool response] save_note: {"saved":"todo.txt"}`,
+		"Saved.",
+		`"last_note": "todo.txt"`,
+		"todo.txt\n",
+		"Started session ",
+		"{}",
+		"Unknown command /unknown.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "# Saves a note.") || strings.Contains(got, "This is not sent.") {
+		t.Errorf("output contains a comment or the input after /exit:\n%s", got)
+	}
+	if c.sessionID == firstSession {
+		t.Error("/new did not start a new session")
+	}
+}
+
+func TestChat_NoArtifactService(t *testing.T) {
+	var out strings.Builder
+	c := newTestChat(t, &out)
+	c.artifactService = nil
+	if err := c.loop(t.Context(), strings.NewReader("/artifacts\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.Contains(got, "No artifact service is configured.") {
+		t.Errorf("output = %q, want no artifact service", got)
+	}
+}
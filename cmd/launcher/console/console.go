@@ -16,19 +16,15 @@
 package console
 
 import (
-	"bufio"
 	"context"
-	"errors"
 	"flag"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"time"
 
-	"google.golang.org/genai"
-
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/cmd/launcher"
 	"google.golang.org/adk/cmd/launcher/internal/telemetry"
@@ -43,7 +39,10 @@ type consoleConfig struct {
 	streamingMode       agent.StreamingMode
 	streamingModeString string // command-line param to be converted to agent.StreamingMode
 	otelToCloud         bool
+	gcpObservability    bool
 	shutdownTimeout     time.Duration
+	agentName           string
+	inputFile           string
 }
 
 // consoleLauncher allows to interact with an agent in console
@@ -60,7 +59,10 @@ func NewLauncher() launcher.SubLauncher {
 	fs.StringVar(&config.streamingModeString, "streaming_mode", "",
 		fmt.Sprintf("defines streaming mode (%s|%s)", agent.StreamingModeNone, agent.StreamingModeSSE))
 	fs.DurationVar(&config.shutdownTimeout, "shutdown-timeout", 2*time.Second, "Console shutdown timeout (i.e. '10s', '2m' - see time.ParseDuration for details) - for waiting for active requests to finish during shutdown")
+	fs.BoolVar(&config.gcpObservability, "gcp_observability", false, telemetry.GCPObservabilityFlagUsage)
 	fs.BoolVar(&config.otelToCloud, "otel_to_cloud", false, "Enables/disables OpenTelemetry export to GCP: telemetry.googleapis.com. See adk-go/telemetry package for details about supported options, credentials and environment variables.")
+	fs.StringVar(&config.agentName, "agent", "", "name of the agent to chat with, as known to the agent loader; defaults to the root agent")
+	fs.StringVar(&config.inputFile, "input_file", "", "file with a user message per line, run in batch mode instead of reading the console input; blank lines and lines starting with '#' are skipped")
 	return &consoleLauncher{config: config, flags: fs}
 }
 
@@ -68,16 +70,21 @@ func NewLauncher() launcher.SubLauncher {
 func (l *consoleLauncher) Run(ctx context.Context, config *launcher.Config) error {
 	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
 	defer cancel()
+	config.SetupLogger()
 
-	telemetry, err := telemetry.InitAndSetGlobalOtelProviders(ctx, config, l.config.otelToCloud)
+	telemetryProviders, err := telemetry.InitAndSetGlobalOtelProviders(ctx, config, l.config.otelToCloud || l.config.gcpObservability)
 	if err != nil {
 		return fmt.Errorf("telemetry initialization failed: %v", err)
 	}
+	if l.config.gcpObservability {
+		// The console interaction uses stdout.
+		telemetry.SetupCloudLogging(config, telemetryProviders, os.Stderr)
+	}
 	defer func() {
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), l.config.shutdownTimeout)
 		defer cancel()
-		if err := telemetry.Shutdown(shutdownCtx); err != nil {
-			log.Printf("telemetry shutdown failed: %v", err)
+		if err := telemetryProviders.Shutdown(shutdownCtx); err != nil {
+			slog.ErrorContext(shutdownCtx, "Telemetry shutdown failed", "error", err)
 		}
 	}()
 
@@ -89,17 +96,13 @@ func (l *consoleLauncher) Run(ctx context.Context, config *launcher.Config) erro
 		sessionService = session.InMemoryService()
 	}
 
-	resp, err := sessionService.Create(ctx, &session.CreateRequest{
-		AppName: appName,
-		UserID:  userID,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create the session service: %v", err)
-	}
-
 	rootAgent := config.AgentLoader.RootAgent()
-
-	session := resp.Session
+	if l.config.agentName != "" {
+		rootAgent, err = config.AgentLoader.LoadAgent(l.config.agentName)
+		if err != nil {
+			return fmt.Errorf("failed to load the agent: %w", err)
+		}
+	}
 
 	r, err := runner.New(runner.Config{
 		AppName:         appName,
@@ -107,100 +110,53 @@ func (l *consoleLauncher) Run(ctx context.Context, config *launcher.Config) erro
 		SessionService:  sessionService,
 		ArtifactService: config.ArtifactService,
 		PluginConfig:    config.PluginConfig,
+		Quota:           config.Quota,
+		Admission:       config.Admission,
+		SessionLocker:   config.SessionLocker,
+		InstanceID:      config.InstanceID,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create runner: %v", err)
 	}
 
-	inputChan := make(chan string)
-	readErrChan := make(chan error, 1)
-
-	go func() {
-		reader := bufio.NewReader(os.Stdin)
-		for {
-			userInput, err := reader.ReadString('\n')
-			if err != nil {
-				readErrChan <- err
-				return
-			}
-			inputChan <- userInput
+	var input io.Reader = os.Stdin
+	if l.config.inputFile != "" {
+		f, err := os.Open(l.config.inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to open the input file: %w", err)
 		}
-	}()
-	// Print an initial newline to work around PTY/exec buffering issues in some environments.
-	fmt.Println()
-
-	fmt.Print("\nUser -> ")
+		defer f.Close()
+		input = f
+	}
 
 	// Resolve "auto" streaming mode once per session (stdout TTY-ness doesn't change).
-	defaultStreamingMode := l.config.streamingMode
-	if defaultStreamingMode == "" {
+	streamingMode := l.config.streamingMode
+	if streamingMode == "" {
 		// Stdlib-only terminal heuristic: stdout is a character device.
 		// Avoids adding golang.org/x/term dependency (golangci-lint failed to load its export data in CI).
 		if fi, err := os.Stdout.Stat(); err == nil && (fi.Mode()&os.ModeCharDevice) != 0 {
-			defaultStreamingMode = agent.StreamingModeSSE
+			streamingMode = agent.StreamingModeSSE
 		} else {
-			defaultStreamingMode = agent.StreamingModeNone
+			streamingMode = agent.StreamingModeNone
 		}
 	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		case err := <-readErrChan:
-			if errors.Is(err, io.EOF) {
-				fmt.Println("\nEOF detected, exiting...")
-				return nil
-			}
-			log.Fatal(err)
-		case userInput := <-inputChan:
-
-			userMsg := genai.NewContentFromText(userInput, genai.RoleUser)
-			streamingMode := l.config.streamingMode
-			if streamingMode == "" {
-				streamingMode = defaultStreamingMode
-			}
-
-			fmt.Print("\nAgent -> ")
-			prevText := ""
-			for event, err := range r.Run(ctx, userID, session.ID(), userMsg, agent.RunConfig{
-				StreamingMode: streamingMode,
-			}) {
-				if err != nil {
-					fmt.Printf("\nAGENT_ERROR: %v\n", err)
-				} else {
-					if event.LLMResponse.Content == nil {
-						continue
-					}
-
-					text := ""
-					for _, p := range event.LLMResponse.Content.Parts {
-						text += p.Text
-					}
-
-					if streamingMode != agent.StreamingModeSSE {
-						fmt.Print(text)
-						continue
-					}
-
-					// In SSE mode, always print partial responses and capture them.
-					if !event.IsFinalResponse() {
-						fmt.Print(text)
-						prevText += text
-						continue
-					}
-
-					// Only print final response if it doesn't match previously captured text.
-					if text != prevText {
-						fmt.Print(text)
-					}
-
-					prevText = ""
-				}
-			}
-			fmt.Print("\nUser -> ")
-		}
+	c := &chat{
+		runner:          r,
+		sessionService:  sessionService,
+		artifactService: config.ArtifactService,
+		appName:         appName,
+		userID:          userID,
+		streamingMode:   streamingMode,
+		out:             os.Stdout,
+		echo:            l.config.inputFile != "",
+	}
+	if err := c.newSession(ctx); err != nil {
+		return err
 	}
+	// Print an initial newline to work around PTY/exec buffering issues in some environments.
+	fmt.Println()
+	return c.loop(ctx, input)
 }
 
 // Parse implements launcher.SubLauncher. After parsing console-specific
@@ -17,6 +17,7 @@ package full
 
 import (
 	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/cmd/launcher/batch"
 	"google.golang.org/adk/cmd/launcher/console"
 	"google.golang.org/adk/cmd/launcher/universal"
 	"google.golang.org/adk/cmd/launcher/web"
@@ -27,5 +28,5 @@ import (
 
 // NewLauncher returnes the most versatile universal launcher with all options built-in.
 func NewLauncher() launcher.Launcher {
-	return universal.NewLauncher(console.NewLauncher(), web.NewLauncher(webui.NewLauncher(), a2a.NewLauncher(), api.NewLauncher()))
+	return universal.NewLauncher(console.NewLauncher(), batch.NewLauncher(), web.NewLauncher(webui.NewLauncher(), a2a.NewLauncher(), api.NewLauncher()))
 }
@@ -17,14 +17,24 @@ package telemetry
 
 import (
 	"context"
+	"io"
+	"log/slog"
+	"slices"
 
 	"google.golang.org/adk/cmd/launcher"
 	"google.golang.org/adk/telemetry"
 )
 
 // InitAndSetGlobalOtelProviders initializes telemetry and sets the global OTel providers.
-func InitAndSetGlobalOtelProviders(ctx context.Context, config *launcher.Config, otelToCloud bool) (*telemetry.Providers, error) {
-	opts := append(config.TelemetryOptions, telemetry.WithOtelToCloud(otelToCloud))
+// The launcher specific options are applied after config.TelemetryOptions.
+// otelToCloud enables the export to Google Cloud, which may also be enabled
+// by config.TelemetryOptions, e.g. from the settings.
+func InitAndSetGlobalOtelProviders(ctx context.Context, config *launcher.Config, otelToCloud bool, launcherOpts ...telemetry.Option) (*telemetry.Providers, error) {
+	opts := slices.Clone(config.TelemetryOptions)
+	if otelToCloud {
+		opts = append(opts, telemetry.WithOtelToCloud(true))
+	}
+	opts = append(opts, launcherOpts...)
 	telemetryProviders, err := telemetry.New(ctx, opts...)
 	if err != nil {
 		return nil, err
@@ -32,3 +42,17 @@ func InitAndSetGlobalOtelProviders(ctx context.Context, config *launcher.Config,
 	telemetryProviders.SetGlobalOtelProviders()
 	return telemetryProviders, nil
 }
+
+// SetupCloudLogging installs a default slog logger writing to w in the Cloud
+// Logging structured format, correlated with the traces exported to the
+// project of the providers. A logger set in config takes precedence.
+func SetupCloudLogging(config *launcher.Config, providers *telemetry.Providers, w io.Writer) {
+	if config.Logger != nil {
+		return
+	}
+	slog.SetDefault(slog.New(telemetry.NewCloudLoggingHandler(w, providers.GCPProject, nil)))
+}
+
+// GCPObservabilityFlagUsage is the usage of the launcher flag enabling
+// the GCP telemetry setup.
+const GCPObservabilityFlagUsage = "Preconfigures the telemetry for GCP deployments, e.g. Cloud Run or Agent Engine: exports the traces to Cloud Trace with the GCP resource attributes (like otel_to_cloud) and writes the logs in the Cloud Logging structured format, correlated with the traces."
@@ -17,13 +17,22 @@ package launcher
 
 import (
 	"context"
+	"log/slog"
+	"net/http"
+	"time"
 
+	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2asrv"
 
+	"google.golang.org/adk/admission"
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/identity"
 	"google.golang.org/adk/artifact"
 	"google.golang.org/adk/memory"
+	"google.golang.org/adk/quota"
 	"google.golang.org/adk/runner"
+	"google.golang.org/adk/server/adka2a"
+	"google.golang.org/adk/server/adkgrpc"
 	"google.golang.org/adk/session"
 	"google.golang.org/adk/telemetry"
 )
@@ -63,4 +72,89 @@ type Config struct {
 	A2AOptions       []a2asrv.RequestHandlerOption
 	PluginConfig     runner.PluginConfig
 	TelemetryOptions []telemetry.Option
+	// Logger is installed as the default slog logger, used by the launchers
+	// and the runners they create. Optional.
+	Logger *slog.Logger
+	// RedactHeaders returns the HTTP request headers written to the debug
+	// logs of the web launcher. Defaults to RedactSensitiveHeaders.
+	RedactHeaders func(http.Header) http.Header
+	// ReloadAgentLoader builds the agent loader again, e.g. from the YAML
+	// agent configs, when the directory watched by the dev mode of the web
+	// launcher changes. If nil, the dev mode rebuilds and restarts the Go
+	// program instead. Optional.
+	ReloadAgentLoader func(ctx context.Context) (agent.Loader, error)
+	// IdentityExtractor extracts the identity of the end user from the
+	// requests of the web launcher, which agents and tools get with
+	// identity.FromContext. Optional.
+	IdentityExtractor identity.Extractor
+	// A2AAgentCardOverride is called with the agent cards the a2a web
+	// sublauncher builds for its apps with adka2a.BuildAgentCard, e.g. to
+	// add security schemes or the provider. Optional.
+	A2AAgentCardOverride func(appName string, card *a2a.AgentCard)
+	// A2APushSender delivers the push notifications of the A2A tasks to the
+	// webhooks registered by the clients. If set, the a2a web sublauncher
+	// enables push notifications and serves the JWKS of the sender on
+	// adka2a.JWKSPath. Optional.
+	A2APushSender *adka2a.PushSender
+	// A2AAuth configures the authentication of the callers of the a2a web
+	// sublauncher, identified by IdentityExtractor, and the authorization of
+	// their requests. If nil and IdentityExtractor is set, the callers run
+	// the agents in their own sessions. Optional.
+	A2AAuth *adka2a.AuthConfig
+	// Quota limits the invocations of the users and the tokens of the apps
+	// run by the launchers, see runner.Config.Quota. The web launcher serves
+	// the remaining quota of the users. Optional.
+	Quota *quota.Enforcer
+	// Admission limits the concurrent invocations of the runners created by
+	// the launchers, see runner.Config.Admission. The web launcher answers
+	// the rejected runs with 429 Too Many Requests. Optional.
+	Admission *admission.Controller
+	// SessionLocker and InstanceID configure the runners created by the
+	// launchers for multiple replicas sharing the session and artifact
+	// services, see runner.Config.SessionLocker and runner.Config.InstanceID,
+	// e.g. with database.NewSessionLocker. Optional.
+	SessionLocker runner.SessionLocker
+	InstanceID    string
+	// ReadinessChecks gate the status reported by the gRPC health service of
+	// the a2a web sublauncher, in addition to the check of SessionService,
+	// e.g. adkgrpc.ModelCheck. Optional.
+	ReadinessChecks []adkgrpc.ReadinessCheck
+	// ArtifactUploadThreshold is the size in bytes of the largest artifact
+	// uploaded to the REST API of the web launcher in a single request,
+	// larger artifacts are sent with resumable uploads whose chunks are
+	// stored in ArtifactUploadDir. Defaults to
+	// controllers.DefaultArtifactUploadThreshold and os.TempDir. Optional.
+	ArtifactUploadThreshold int64
+	ArtifactUploadDir       string
+	// Retention is the retention policy of the events of the sessions of
+	// the apps, by app name. The web launcher prunes the sessions of these
+	// apps every RetentionInterval, see RunRetention. SessionService must
+	// implement session.EventDeleter. Optional.
+	Retention map[string]session.RetentionPolicy
+	// RetentionArchive stores the events pruned by the retention job before
+	// they are deleted. If nil, the events are deleted. Optional.
+	RetentionArchive session.Archive
+	// RetentionInterval is the interval between two runs of the retention
+	// job. Defaults to 1 hour. Optional.
+	RetentionInterval time.Duration
+}
+
+// RedactSensitiveHeaders returns a copy of the headers with the values of
+// the credential headers, e.g. Authorization or Cookie, redacted.
+func RedactSensitiveHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for name := range redacted {
+		switch http.CanonicalHeaderKey(name) {
+		case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Goog-Api-Key":
+			redacted[name] = []string{"REDACTED"}
+		}
+	}
+	return redacted
+}
+
+// SetupLogger installs config.Logger as the default slog logger, if set.
+func (c *Config) SetupLogger() {
+	if c.Logger != nil {
+		slog.SetDefault(c.Logger)
+	}
 }
@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package launcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"google.golang.org/adk/session"
+)
+
+// defaultRetentionInterval is the interval between two runs of the retention
+// job if Config.RetentionInterval is not set.
+const defaultRetentionInterval = time.Hour
+
+// PruneSessions prunes the sessions of the apps of c.Retention once, with
+// session.PruneApp. It goes on after the failures to prune an app, and
+// returns them joined.
+func (c *Config) PruneSessions(ctx context.Context) error {
+	var errs []error
+	for _, appName := range slices.Sorted(maps.Keys(c.Retention)) {
+		resp, err := session.PruneApp(ctx, c.SessionService, &session.PruneAppRequest{
+			AppName: appName,
+			Policy:  c.Retention[appName],
+			Archive: c.RetentionArchive,
+		})
+		if resp != nil && resp.Pruned > 0 {
+			slog.InfoContext(ctx, "Pruned the sessions", "app", appName, "sessions", resp.Sessions, "events", resp.Pruned)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to prune the sessions of app %q: %w", appName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunRetention prunes the sessions of the apps of c.Retention every
+// c.RetentionInterval, starting immediately, until ctx is done. The failures
+// are logged. It returns immediately if c.Retention is empty.
+func (c *Config) RunRetention(ctx context.Context) {
+	if len(c.Retention) == 0 {
+		return
+	}
+	interval := c.RetentionInterval
+	if interval <= 0 {
+		interval = defaultRetentionInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := c.PruneSessions(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Session retention failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
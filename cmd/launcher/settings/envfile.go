@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadEnvFile sets the environment variables of a .env file, e.g.
+//
+//	# Comments and blank lines are skipped.
+//	export GOOGLE_API_KEY=my-key
+//	GOOGLE_CLOUD_LOCATION="us-central1" # inline comment
+//
+// The variables already set in the environment are not overridden.
+func LoadEnvFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	vars, err := parseEnv(bufio.NewScanner(f))
+	if err != nil {
+		return fmt.Errorf("invalid env file %s: %w", path, err)
+	}
+	for _, v := range vars {
+		if _, ok := os.LookupEnv(v[0]); ok {
+			continue
+		}
+		if err := os.Setenv(v[0], v[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseEnv returns the name and value of the variables, in order.
+func parseEnv(scanner *bufio.Scanner) ([][2]string, error) {
+	var vars [][2]string
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("line %d: want NAME=value", n)
+		}
+		value, err := parseEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		vars = append(vars, [2]string{name, value})
+	}
+	return vars, scanner.Err()
+}
+
+// parseEnvValue unquotes a value: double quoted values support the Go
+// escapes, single quoted ones are literal, and unquoted ones end at an
+// inline comment.
+func parseEnvValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		end := closingQuote(value, '"')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		return strconv.Unquote(value[:end+1])
+	case strings.HasPrefix(value, "'"):
+		end := closingQuote(value, '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		return value[1:end], nil
+	default:
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		return strings.TrimSpace(value), nil
+	}
+}
+
+// closingQuote returns the index of the quote closing the value, which
+// starts with the quote.
+func closingQuote(value string, quote byte) int {
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
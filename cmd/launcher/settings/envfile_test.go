@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := `# Comment
+export ADK_TEST_EXPORTED=exported
+
+ADK_TEST_PLAIN = plain value # inline comment
+ADK_TEST_DOUBLE="line\nbreak # not a comment"
+ADK_TEST_SINGLE='raw\n value'
+ADK_TEST_SET=from file
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ADK_TEST_SET", "from env")
+	for _, name := range []string{"ADK_TEST_EXPORTED", "ADK_TEST_PLAIN", "ADK_TEST_DOUBLE", "ADK_TEST_SINGLE"} {
+		// Unset at the end of the test.
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+
+	if err := LoadEnvFile(path); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"ADK_TEST_EXPORTED": "exported",
+		"ADK_TEST_PLAIN":    "plain value",
+		"ADK_TEST_DOUBLE":   "line\nbreak # not a comment",
+		"ADK_TEST_SINGLE":   `raw\n value`,
+		"ADK_TEST_SET":      "from env",
+	}
+	for name, value := range want {
+		if got := os.Getenv(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+}
+
+func TestLoadEnvFile_Invalid(t *testing.T) {
+	for _, content := range []string{
+		"NO_VALUE\n",
+		"TWO WORDS=value\n",
+		`UNTERMINATED="value` + "\n",
+	} {
+		path := filepath.Join(t.TempDir(), ".env")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := LoadEnvFile(path); err == nil {
+			t.Errorf("LoadEnvFile(%q) succeeded, want error", content)
+		}
+	}
+}
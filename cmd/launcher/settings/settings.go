@@ -0,0 +1,486 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package settings loads the settings of an ADK application, e.g. its model,
+// session backend or telemetry exporters, from a YAML or TOML config file,
+// the environment, optionally populated from a .env file, and command-line
+// flags. Flags take precedence over the environment, which takes precedence
+// over the config file.
+//
+// A config file looks like:
+//
+//	model:
+//	  name: gemini-2.5-flash
+//	  backend: vertexai
+//	  project: my-project
+//	  location: us-central1
+//	session:
+//	  dsn: sqlite://sessions.db
+//	  codec: msgpack
+//	  compression: zstd
+//	artifact:
+//	  bucket: gs://my-artifacts
+//	  compression: gzip
+//	telemetry:
+//	  otel_to_cloud: true
+//
+// The API key and the session DSN may be secret references, e.g.
+// secret://gcp/gemini-api-key, resolved when the settings are loaded, see
+// the secrets package. The gcp and vault providers are configured by the
+// secrets settings.
+package settings
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/glebarez/sqlite"
+	"google.golang.org/genai"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/artifact/gcsartifact"
+	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/codec"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/model/gemini"
+	"google.golang.org/adk/secrets"
+	"google.golang.org/adk/secrets/secretmanager"
+	"google.golang.org/adk/secrets/vault"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/session/database"
+	"google.golang.org/adk/telemetry"
+)
+
+// Model backends.
+const (
+	BackendGemini   = "gemini"
+	BackendVertexAI = "vertexai"
+)
+
+// Settings are the settings of an ADK application.
+type Settings struct {
+	Model     ModelSettings     `yaml:"model" toml:"model"`
+	Session   SessionSettings   `yaml:"session" toml:"session"`
+	Artifact  ArtifactSettings  `yaml:"artifact" toml:"artifact"`
+	Telemetry TelemetrySettings `yaml:"telemetry" toml:"telemetry"`
+	Secrets   SecretsSettings   `yaml:"secrets" toml:"secrets"`
+}
+
+// ModelSettings are the defaults of the models created by [Settings.NewModel].
+type ModelSettings struct {
+	// Name of the model. Defaults to gemini-2.5-flash.
+	Name string `yaml:"name" toml:"name"`
+	// Backend is BackendGemini (default) or BackendVertexAI.
+	Backend string `yaml:"backend" toml:"backend"`
+	// APIKey of the Gemini API.
+	APIKey string `yaml:"api_key" toml:"api_key"`
+	// Project and Location of the Vertex AI backend.
+	Project  string `yaml:"project" toml:"project"`
+	Location string `yaml:"location" toml:"location"`
+}
+
+// SessionSettings select the session service.
+type SessionSettings struct {
+	// DSN of the session backend: "memory" (default) for in-memory sessions,
+	// or "sqlite://<path>" for sessions stored in a SQLite database.
+	DSN string `yaml:"dsn" toml:"dsn"`
+	// Codec encodes the events stored in a SQLite database: "json"
+	// (default), "msgpack" or "protobuf". The events stored with another
+	// codec remain readable.
+	Codec string `yaml:"codec" toml:"codec"`
+	// Compression compresses the large events stored in a SQLite database:
+	// "none" (default), "gzip" or "zstd".
+	Compression string `yaml:"compression" toml:"compression"`
+}
+
+// ArtifactSettings select the artifact service.
+type ArtifactSettings struct {
+	// Bucket is the Google Cloud Storage bucket storing the artifacts, e.g.
+	// gs://my-artifacts. Artifacts are stored in memory if empty.
+	Bucket string `yaml:"bucket" toml:"bucket"`
+	// Compression compresses the large artifacts stored in the bucket:
+	// "none" (default), "gzip" or "zstd".
+	Compression string `yaml:"compression" toml:"compression"`
+}
+
+// TelemetrySettings configure the telemetry exporters.
+type TelemetrySettings struct {
+	// OtelToCloud exports the telemetry to Google Cloud.
+	OtelToCloud bool `yaml:"otel_to_cloud" toml:"otel_to_cloud"`
+	// OTLPEndpoint is the endpoint of an OTLP collector.
+	OTLPEndpoint string `yaml:"otlp_endpoint" toml:"otlp_endpoint"`
+	// CaptureMessageContent records the content of the LLM messages in the
+	// telemetry.
+	CaptureMessageContent bool `yaml:"capture_message_content" toml:"capture_message_content"`
+}
+
+// SecretsSettings configure the providers of the secret references of the
+// settings.
+type SecretsSettings struct {
+	// GCPProject is the project of the Secret Manager secrets referenced by
+	// ID, e.g. secret://gcp/api-key. Defaults to the model project.
+	GCPProject string `yaml:"gcp_project" toml:"gcp_project"`
+	// VaultAddress is the address of the Vault server of the secret://vault/
+	// references. The token is read from VAULT_TOKEN.
+	VaultAddress string `yaml:"vault_address" toml:"vault_address"`
+	// VaultMount is the path of the KV secrets engine of Vault. Defaults to
+	// "secret".
+	VaultMount string `yaml:"vault_mount" toml:"vault_mount"`
+}
+
+// setting is a setting which can be set by an environment variable or a
+// flag.
+type setting struct {
+	// env is the name of the environment variable.
+	env string
+	// flag is the name of the flag, if any.
+	flag  string
+	usage string
+	set   func(s *Settings, value string) error
+}
+
+func stringSetting(field func(s *Settings) *string) func(s *Settings, value string) error {
+	return func(s *Settings, value string) error {
+		*field(s) = value
+		return nil
+	}
+}
+
+func boolSetting(field func(s *Settings) *bool) func(s *Settings, value string) error {
+	return func(s *Settings, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(s) = b
+		return nil
+	}
+}
+
+var settings = []setting{
+	{env: "ADK_MODEL", flag: "model", usage: "Name of the model", set: stringSetting(func(s *Settings) *string { return &s.Model.Name })},
+	{env: "ADK_MODEL_BACKEND", flag: "model_backend", usage: "Model backend: gemini or vertexai", set: stringSetting(func(s *Settings) *string { return &s.Model.Backend })},
+	{env: "GOOGLE_API_KEY", set: stringSetting(func(s *Settings) *string { return &s.Model.APIKey })},
+	{env: "GOOGLE_CLOUD_PROJECT", flag: "project", usage: "Google Cloud project of the vertexai backend", set: stringSetting(func(s *Settings) *string { return &s.Model.Project })},
+	{env: "GOOGLE_CLOUD_LOCATION", flag: "location", usage: "Google Cloud location of the vertexai backend", set: stringSetting(func(s *Settings) *string { return &s.Model.Location })},
+	{env: "ADK_SESSION_DSN", flag: "session_dsn", usage: "Session backend: memory or sqlite://<path>", set: stringSetting(func(s *Settings) *string { return &s.Session.DSN })},
+	{env: "ADK_SESSION_CODEC", flag: "session_codec", usage: "Codec of the events stored in the session database: json, msgpack or protobuf", set: stringSetting(func(s *Settings) *string { return &s.Session.Codec })},
+	{env: "ADK_SESSION_COMPRESSION", flag: "session_compression", usage: "Compression of the large events stored in the session database: none, gzip or zstd", set: stringSetting(func(s *Settings) *string { return &s.Session.Compression })},
+	{env: "ADK_ARTIFACT_BUCKET", flag: "artifact_bucket", usage: "Google Cloud Storage bucket of the artifacts, e.g. gs://my-artifacts", set: stringSetting(func(s *Settings) *string { return &s.Artifact.Bucket })},
+	{env: "ADK_ARTIFACT_COMPRESSION", flag: "artifact_compression", usage: "Compression of the large artifacts stored in the bucket: none, gzip or zstd", set: stringSetting(func(s *Settings) *string { return &s.Artifact.Compression })},
+	{env: "ADK_OTEL_TO_CLOUD", set: boolSetting(func(s *Settings) *bool { return &s.Telemetry.OtelToCloud })},
+	{env: "OTEL_EXPORTER_OTLP_ENDPOINT", flag: "otlp_endpoint", usage: "Endpoint of the OTLP collector receiving the telemetry", set: stringSetting(func(s *Settings) *string { return &s.Telemetry.OTLPEndpoint })},
+	{env: "OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT", set: boolSetting(func(s *Settings) *bool { return &s.Telemetry.CaptureMessageContent })},
+	{env: "ADK_SECRETS_GCP_PROJECT", set: stringSetting(func(s *Settings) *string { return &s.Secrets.GCPProject })},
+	{env: "VAULT_ADDR", set: stringSetting(func(s *Settings) *string { return &s.Secrets.VaultAddress })},
+}
+
+const defaultEnvFile = ".env"
+
+// Loader loads the Settings.
+type Loader struct {
+	// EnvFile is the .env file setting environment variables. Missing the
+	// default .env file is not an error.
+	EnvFile string
+	// ConfigFile is the YAML (.yaml, .yml or .json) or TOML (.toml) config
+	// file. Optional.
+	ConfigFile string
+
+	// flags are the values of the flags set in the command line.
+	flags map[string]string
+}
+
+// NewLoader returns a Loader of the .env file of the working directory.
+func NewLoader() *Loader {
+	return &Loader{EnvFile: defaultEnvFile, flags: make(map[string]string)}
+}
+
+// RegisterFlags adds the flags selecting the env and config files, and the
+// flags of the settings, to fs.
+func (l *Loader) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.EnvFile, "env_file", l.EnvFile, "File setting environment variables, in the .env format")
+	fs.StringVar(&l.ConfigFile, "config", l.ConfigFile, "YAML or TOML config file with the model, session, artifact and telemetry settings")
+	for _, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+		fs.Func(s.flag, fmt.Sprintf("%s (env %s)", s.usage, s.env), func(value string) error {
+			l.flags[s.flag] = value
+			return nil
+		})
+	}
+}
+
+// Load loads the env file, and returns the settings of the config file
+// overridden by the environment and the flags, with their secret references
+// resolved.
+func (l *Loader) Load() (*Settings, error) {
+	if l.EnvFile != "" {
+		err := LoadEnvFile(l.EnvFile)
+		if err != nil && (l.EnvFile != defaultEnvFile || !errors.Is(err, fs.ErrNotExist)) {
+			return nil, fmt.Errorf("failed to load the env file: %w", err)
+		}
+	}
+	s := &Settings{}
+	if l.ConfigFile != "" {
+		if err := s.loadFile(l.ConfigFile); err != nil {
+			return nil, err
+		}
+	}
+	for _, setting := range settings {
+		value, ok := os.LookupEnv(setting.env)
+		if !ok {
+			continue
+		}
+		if err := setting.set(s, value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", setting.env, err)
+		}
+	}
+	for _, setting := range settings {
+		value, ok := l.flags[setting.flag]
+		if !ok {
+			continue
+		}
+		if err := setting.set(s, value); err != nil {
+			return nil, fmt.Errorf("invalid -%s: %w", setting.flag, err)
+		}
+	}
+	if s.Model.Name == "" {
+		s.Model.Name = "gemini-2.5-flash"
+	}
+	if s.Model.Backend == "" {
+		s.Model.Backend = BackendGemini
+	}
+	// The settings are loaded at startup, before the context of the launcher
+	// is available.
+	if err := s.ResolveSecrets(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// secretFields returns the settings which may be secret references.
+func (s *Settings) secretFields() []*string {
+	return []*string{&s.Model.APIKey, &s.Session.DSN}
+}
+
+// ResolveSecrets registers the gcp and vault secret providers referenced by
+// the settings, and replaces the secret references of the settings with the
+// secrets.
+func (s *Settings) ResolveSecrets(ctx context.Context) error {
+	fields := s.secretFields()
+	referenced := make(map[string]bool)
+	for _, field := range fields {
+		if provider, _, ok := secrets.ParseReference(*field); ok {
+			referenced[provider] = true
+		}
+	}
+	if referenced["gcp"] {
+		project := s.Secrets.GCPProject
+		if project == "" {
+			project = s.Model.Project
+		}
+		provider, err := secretmanager.New(ctx, project)
+		if err != nil {
+			return fmt.Errorf("failed to create the gcp secret provider: %w", err)
+		}
+		secrets.Register("gcp", provider)
+	}
+	if referenced["vault"] {
+		provider, err := vault.New(vault.Config{Address: s.Secrets.VaultAddress, Mount: s.Secrets.VaultMount})
+		if err != nil {
+			return fmt.Errorf("failed to create the vault secret provider: %w", err)
+		}
+		secrets.Register("vault", provider)
+	}
+	for _, field := range fields {
+		value, err := secrets.Resolve(ctx, *field)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+	return nil
+}
+
+func (s *Settings) loadFile(path string) error {
+	switch ext := filepath.Ext(path); ext {
+	case ".toml":
+		md, err := toml.DecodeFile(path, s)
+		if err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("invalid config file %s: unknown keys %v", path, undecoded)
+		}
+	case ".yaml", ".yml", ".json":
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to read the config file: %w", err)
+		}
+		defer f.Close()
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err := dec.Decode(s); err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("unsupported config file extension %q, want .yaml, .yml, .json or .toml", ext)
+	}
+	return nil
+}
+
+// Validate reports the first invalid setting.
+func (s *Settings) Validate() error {
+	switch s.Model.Backend {
+	case BackendGemini, "":
+	case BackendVertexAI:
+		if s.Model.Project == "" || s.Model.Location == "" {
+			return fmt.Errorf("the vertexai backend requires the project and location")
+		}
+	default:
+		return fmt.Errorf("unknown model backend %q, want %s or %s", s.Model.Backend, BackendGemini, BackendVertexAI)
+	}
+	if _, _, err := parseDSN(s.Session.DSN); err != nil {
+		return err
+	}
+	if _, err := codec.Lookup(s.Session.Codec); err != nil {
+		return fmt.Errorf("invalid session codec: %w", err)
+	}
+	if _, err := codec.LookupCompressor(s.Session.Compression); err != nil {
+		return fmt.Errorf("invalid session compression: %w", err)
+	}
+	if _, err := codec.LookupCompressor(s.Artifact.Compression); err != nil {
+		return fmt.Errorf("invalid artifact compression: %w", err)
+	}
+	if bucket := strings.TrimPrefix(s.Artifact.Bucket, "gs://"); s.Artifact.Bucket != "" && (bucket == "" || strings.Contains(bucket, "/")) {
+		return fmt.Errorf("invalid artifact bucket %q, want gs://<bucket>", s.Artifact.Bucket)
+	}
+	return nil
+}
+
+// parseDSN returns the scheme and location of a session DSN.
+func parseDSN(dsn string) (scheme, location string, err error) {
+	if dsn == "" || dsn == "memory" {
+		return "memory", "", nil
+	}
+	scheme, location, ok := strings.Cut(dsn, "://")
+	if !ok || scheme != "sqlite" || location == "" {
+		return "", "", fmt.Errorf("invalid session DSN %q, want memory or sqlite://<path>", dsn)
+	}
+	return scheme, location, nil
+}
+
+// GenAIClientConfig returns the client config of the model backend.
+func (s *Settings) GenAIClientConfig() *genai.ClientConfig {
+	if s.Model.Backend == BackendVertexAI {
+		return &genai.ClientConfig{
+			Backend:  genai.BackendVertexAI,
+			Project:  s.Model.Project,
+			Location: s.Model.Location,
+		}
+	}
+	return &genai.ClientConfig{Backend: genai.BackendGeminiAPI, APIKey: s.Model.APIKey}
+}
+
+// NewModel creates the model of the settings.
+func (s *Settings) NewModel(ctx context.Context) (model.LLM, error) {
+	return gemini.NewModel(ctx, s.Model.Name, s.GenAIClientConfig())
+}
+
+// NewSessionService creates the session service of the session DSN.
+func (s *Settings) NewSessionService() (session.Service, error) {
+	scheme, location, err := parseDSN(s.Session.DSN)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == "memory" {
+		return session.InMemoryService(), nil
+	}
+	c, err := codec.Lookup(s.Session.Codec)
+	if err != nil {
+		return nil, err
+	}
+	compressor, err := codec.LookupCompressor(s.Session.Compression)
+	if err != nil {
+		return nil, err
+	}
+	// Missing app and user states are logged as errors by the default logger.
+	service, err := database.NewSessionService(sqlite.Open(location), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)},
+		database.WithCodec(c), database.WithCompression(codec.Compression{Compressor: compressor}))
+	if err != nil {
+		return nil, err
+	}
+	if err := database.AutoMigrate(service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// Apply sets the session and artifact services of the config which are not
+// set yet, and adds the telemetry options.
+func (s *Settings) Apply(ctx context.Context, config *launcher.Config) error {
+	if config.SessionService == nil {
+		service, err := s.NewSessionService()
+		if err != nil {
+			return err
+		}
+		config.SessionService = service
+	}
+	if config.ArtifactService == nil && s.Artifact.Bucket != "" {
+		service, err := gcsartifact.NewService(ctx, strings.TrimPrefix(s.Artifact.Bucket, "gs://"))
+		if err != nil {
+			return fmt.Errorf("failed to create the artifact service: %w", err)
+		}
+		compressor, err := codec.LookupCompressor(s.Artifact.Compression)
+		if err != nil {
+			return err
+		}
+		if compressor != nil {
+			service = artifact.CompressedService(service, codec.Compression{Compressor: compressor})
+		}
+		config.ArtifactService = service
+	}
+
+	if s.Telemetry.OtelToCloud {
+		config.TelemetryOptions = append(config.TelemetryOptions, telemetry.WithOtelToCloud(true))
+		if s.Model.Project != "" {
+			config.TelemetryOptions = append(config.TelemetryOptions, telemetry.WithGcpResourceProject(s.Model.Project))
+		}
+	}
+	if s.Telemetry.CaptureMessageContent {
+		config.TelemetryOptions = append(config.TelemetryOptions, telemetry.WithGenAICaptureMessageContent(true))
+	}
+	if s.Telemetry.OTLPEndpoint != "" {
+		// The telemetry package reads the OTLP endpoint from the environment.
+		if err := os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", s.Telemetry.OTLPEndpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
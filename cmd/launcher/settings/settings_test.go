@@ -0,0 +1,208 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/session"
+)
+
+// clearEnv unsets the environment variables of the settings for the test.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, s := range settings {
+		t.Setenv(s.env, "")
+		os.Unsetenv(s.env)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	clearEnv(t)
+	configFile := writeFile(t, "adk.yaml", `
+model:
+  name: file-model
+  backend: vertexai
+  project: file-project
+  location: file-location
+session:
+  dsn: sqlite://file.db
+telemetry:
+  otel_to_cloud: true
+`)
+	envFile := writeFile(t, ".env", "GOOGLE_CLOUD_PROJECT=env-file-project\nADK_SESSION_DSN=sqlite://env-file.db\n")
+	t.Setenv("GOOGLE_CLOUD_LOCATION", "env-location")
+	t.Setenv("ADK_SESSION_DSN", "memory")
+
+	l := NewLoader()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l.RegisterFlags(fs)
+	if err := fs.Parse([]string{"-config", configFile, "-env_file", envFile, "-model", "flag-model"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := l.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Settings{
+		Model: ModelSettings{
+			Name:     "flag-model",
+			Backend:  BackendVertexAI,
+			Project:  "env-file-project",
+			Location: "env-location",
+		},
+		// The environment takes precedence over the env file.
+		Session:   SessionSettings{DSN: "memory"},
+		Telemetry: TelemetrySettings{OtelToCloud: true},
+	}
+	if *got != want {
+		t.Errorf("Load() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestLoad_TOML(t *testing.T) {
+	clearEnv(t)
+	l := NewLoader()
+	l.EnvFile = ""
+	l.ConfigFile = writeFile(t, "adk.toml", `
+[model]
+name = "gemini-2.5-pro"
+api_key = "key"
+
+[artifact]
+bucket = "gs://artifacts"
+`)
+	got, err := l.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Settings{
+		Model:    ModelSettings{Name: "gemini-2.5-pro", Backend: BackendGemini, APIKey: "key"},
+		Artifact: ArtifactSettings{Bucket: "gs://artifacts"},
+	}
+	if *got != want {
+		t.Errorf("Load() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		configFile string
+		configBody string
+		env        map[string]string
+		envFile    string
+	}{
+		{name: "unknown YAML key", configFile: "adk.yaml", configBody: "model:\n  nmae: typo\n"},
+		{name: "unknown TOML key", configFile: "adk.toml", configBody: "[model]\nnmae = \"typo\"\n"},
+		{name: "unsupported config file", configFile: "adk.ini", configBody: "model=x\n"},
+		{name: "vertexai without project", env: map[string]string{"ADK_MODEL_BACKEND": "vertexai"}},
+		{name: "unknown backend", env: map[string]string{"ADK_MODEL_BACKEND": "openai"}},
+		{name: "unknown session DSN", env: map[string]string{"ADK_SESSION_DSN": "postgres://db"}},
+		{name: "unknown session codec", env: map[string]string{"ADK_SESSION_CODEC": "yaml"}},
+		{name: "unknown session compression", env: map[string]string{"ADK_SESSION_COMPRESSION": "lz4"}},
+		{name: "unknown artifact compression", env: map[string]string{"ADK_ARTIFACT_COMPRESSION": "lz4"}},
+		{name: "invalid bucket", env: map[string]string{"ADK_ARTIFACT_BUCKET": "gs://bucket/path"}},
+		{name: "invalid bool", env: map[string]string{"ADK_OTEL_TO_CLOUD": "maybe"}},
+		{name: "missing env file", envFile: "missing.env"},
+		{name: "missing secret", env: map[string]string{"GOOGLE_API_KEY": "secret://env/ADK_TEST_MISSING_SECRET"}},
+		{name: "vault secret without address", env: map[string]string{"GOOGLE_API_KEY": "secret://vault/adk/gemini#api_key"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			l := NewLoader()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			l.RegisterFlags(fs)
+			var args []string
+			if tt.configFile != "" {
+				args = append(args, "-config", writeFile(t, tt.configFile, tt.configBody))
+			}
+			if tt.envFile != "" {
+				args = append(args, "-env_file", filepath.Join(t.TempDir(), tt.envFile))
+			}
+			if err := fs.Parse(args); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := l.Load(); err == nil {
+				t.Error("Load() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestLoad_Secrets(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("ADK_TEST_GEMINI_KEY", "my-key")
+	t.Setenv("GOOGLE_API_KEY", "secret://env/ADK_TEST_GEMINI_KEY")
+	dbPath := filepath.Join(t.TempDir(), "sessions.db")
+	dsnFile := writeFile(t, "dsn", "sqlite://"+dbPath+"\n")
+	config := writeFile(t, "adk.yaml", "session:\n  dsn: secret://file/"+dsnFile+"\n")
+
+	l := NewLoader()
+	l.ConfigFile = config
+	s, err := l.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if s.Model.APIKey != "my-key" {
+		t.Errorf("Model.APIKey = %q, want %q", s.Model.APIKey, "my-key")
+	}
+	if want := "sqlite://" + dbPath; s.Session.DSN != want {
+		t.Errorf("Session.DSN = %q, want %q", s.Session.DSN, want)
+	}
+}
+
+func TestApply(t *testing.T) {
+	s := &Settings{Session: SessionSettings{DSN: "sqlite://" + filepath.Join(t.TempDir(), "sessions.db")}}
+	config := &launcher.Config{}
+	if err := s.Apply(t.Context(), config); err != nil {
+		t.Fatal(err)
+	}
+	if config.SessionService == nil {
+		t.Fatal("Apply() did not set the session service")
+	}
+	ctx := context.Background()
+	if _, err := config.SessionService.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user"}); err != nil {
+		t.Errorf("Create() on the SQLite session service failed: %v", err)
+	}
+
+	// The services set in the config are kept.
+	inMemory := session.InMemoryService()
+	config = &launcher.Config{SessionService: inMemory}
+	if err := s.Apply(t.Context(), config); err != nil {
+		t.Fatal(err)
+	}
+	if config.SessionService != inMemory {
+		t.Error("Apply() replaced the session service of the config")
+	}
+}
@@ -19,16 +19,22 @@ package universal
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"strings"
 
 	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/cmd/launcher/settings"
+	"google.golang.org/adk/internal/cli/util"
 )
 
 // uniLauncher contains information about sublaunchers
 type uniLauncher struct {
 	chosenLauncher launcher.SubLauncher // the chosen launcher - after parsing command-line args
 	sublaunchers   []launcher.SubLauncher
+	// flags are the settings flags, given before the keyword of the sublauncher
+	flags    *flag.FlagSet
+	settings *settings.Loader
 }
 
 // Execute implements launcher.Launcher. Parses args and runs the chosen launcher. Returns error if there are non-parsed arguments.
@@ -38,8 +44,13 @@ func (l *uniLauncher) Execute(ctx context.Context, config *launcher.Config, args
 
 // NewLauncher returns a new universal launcher. The first element on launcher list will be the default one if there are no arguments specified
 func NewLauncher(sublaunchers ...launcher.SubLauncher) launcher.Launcher {
+	loader := settings.NewLoader()
+	fs := flag.NewFlagSet("settings", flag.ContinueOnError)
+	loader.RegisterFlags(fs)
 	return &uniLauncher{
 		sublaunchers: sublaunchers,
+		flags:        fs,
+		settings:     loader,
 	}
 }
 
@@ -60,8 +71,15 @@ func (l *uniLauncher) ParseAndRun(ctx context.Context, config *launcher.Config,
 	return l.run(ctx, config)
 }
 
-// run executes the chosen sublauncher.
+// run loads the settings into the config and executes the chosen sublauncher.
 func (l *uniLauncher) run(ctx context.Context, config *launcher.Config) error {
+	s, err := l.settings.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load the settings: %w", err)
+	}
+	if err := s.Apply(ctx, config); err != nil {
+		return fmt.Errorf("cannot apply the settings: %w", err)
+	}
 	return l.chosenLauncher.Run(ctx, config)
 }
 
@@ -79,6 +97,12 @@ func (l *uniLauncher) parse(args []string) ([]string, error) {
 		// no sub launchers
 		return args, fmt.Errorf("there are no sub launchers to parse the arguments")
 	}
+	// the settings flags come first
+	settingsArgs, args := splitFlags(l.flags, args)
+	if err := l.flags.Parse(settingsArgs); err != nil {
+		return nil, fmt.Errorf("failed to parse the settings flags: %v", err)
+	}
+
 	// default to the first one in the list
 	l.chosenLauncher = l.sublaunchers[0]
 
@@ -104,6 +128,7 @@ func (l *uniLauncher) CommandLineSyntax() string {
 		return l.simpleDescription() + "\n\nThere are no sublaunchers to format syntax for."
 	}
 	var b strings.Builder
+	fmt.Fprintf(&b, "Settings flags, given before the arguments. Flags take precedence over the environment, which takes precedence over the config file:\n%s\n", util.FormatFlagUsage(l.flags))
 	fmt.Fprintf(&b, "Arguments: Specify one of the following:\n")
 	for _, l := range l.sublaunchers {
 		fmt.Fprintf(&b, "  * %s - %s\n", l.Keyword(), l.SimpleDescription())
@@ -123,6 +148,29 @@ func (l *uniLauncher) simpleDescription() string {
 	If there are no arguments at all or the first one is not recognized by any of the sublaunchers, the first sublauncher is used.`
 }
 
+// splitFlags splits the leading flags defined in fs, all taking a value, from
+// the rest of the arguments.
+func splitFlags(fs *flag.FlagSet, args []string) (flags, rest []string) {
+	i := 0
+	for i < len(args) {
+		if !strings.HasPrefix(args[i], "-") {
+			break
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(args[i], "-"), "-")
+		name, _, hasValue := strings.Cut(name, "=")
+		if name == "" || fs.Lookup(name) == nil {
+			break
+		}
+		if hasValue {
+			i++
+		} else {
+			i += 2
+		}
+	}
+	i = min(i, len(args))
+	return args[:i], args[i:]
+}
+
 // ErrorOnUnparsedArgs returns an error if there are any unparsed arguments left.
 func ErrorOnUnparsedArgs(args []string) error {
 	if len(args) > 0 {
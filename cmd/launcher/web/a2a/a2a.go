@@ -18,17 +18,27 @@ package a2a
 import (
 	"flag"
 	"fmt"
+	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 
 	a2acore "github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2agrpc"
 	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/push"
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
+	"google.golang.org/adk/agent"
 	"google.golang.org/adk/cmd/launcher"
 	"google.golang.org/adk/cmd/launcher/web"
 	"google.golang.org/adk/internal/cli/util"
 	"google.golang.org/adk/runner"
 	"google.golang.org/adk/server/adka2a"
+	"google.golang.org/adk/server/adkgrpc"
+	"google.golang.org/adk/server/adkgrpc/runnerpb"
 )
 
 // apiPath is a suffix used to build an A2A invocation URL
@@ -36,12 +46,22 @@ const apiPath = "/a2a/invoke"
 
 // a2aConfig contains parameters for launching ADK A2A server
 type a2aConfig struct {
-	agentURL string // user-provided url which will be used in the agent card to specify url for invoking A2A
+	agentURL   string // user-provided url which will be used in the agent card to specify url for invoking A2A
+	grpc       bool   // whether the root agent is also served over gRPC
+	runner     bool   // whether the runner gRPC service is served
+	health     bool   // whether the gRPC health service is served
+	reflection bool   // whether the gRPC server reflection is served
 }
 
 type a2aLauncher struct {
 	flags  *flag.FlagSet // flags are used to parse command-line arguments
 	config *a2aConfig
+	// pushStore holds the push notification configs, if push
+	// notifications are enabled.
+	pushStore a2asrv.PushConfigStore
+	// grpcServer serves the gRPC services on the port of the web server,
+	// if any of them is enabled.
+	grpcServer *grpc.Server
 }
 
 // NewLauncher creates new a2a launcher. It extends Web launcher
@@ -51,6 +71,10 @@ func NewLauncher() web.Sublauncher {
 	fs := flag.NewFlagSet("a2a", flag.ContinueOnError)
 
 	fs.StringVar(&config.agentURL, "a2a_agent_url", "http://localhost:8080", "A2A host URL as advertised in the public agent card. It is used by A2A clients as a connection endpoint.")
+	fs.BoolVar(&config.grpc, "a2a_grpc", true, "Also serve the root agent with the A2A gRPC transport on the same port, for the requests with the application/grpc content type.")
+	fs.BoolVar(&config.runner, "a2a_runner_grpc", true, "Also serve the runner gRPC service of every app, see adkgrpc, on the same port, for the requests with the application/grpc content type.")
+	fs.BoolVar(&config.health, "a2a_grpc_health", true, "Serve the standard gRPC health service along the gRPC services, reporting them as serving while the session service and the readiness checks of the launcher config are up.")
+	fs.BoolVar(&config.reflection, "a2a_grpc_reflection", true, "Serve the gRPC server reflection along the gRPC services. Disable it in production to not expose the service definitions.")
 
 	return &a2aLauncher{
 		config: config,
@@ -78,44 +102,143 @@ func (a *a2aLauncher) Parse(args []string) ([]string, error) {
 }
 
 // SetupSubrouters implements the web.Sublauncher interface. It adds A2A paths to the main router.
+// The root agent is served on the apiPath, and every app of the agent loader
+// on its own path, see appPath.
 func (a *a2aLauncher) SetupSubrouters(router *mux.Router, config *launcher.Config) error {
-	publicURL, err := url.JoinPath(a.config.agentURL, apiPath)
+	if config.A2APushSender != nil {
+		// The push configs are registered by task, shared by all the apps.
+		a.pushStore = push.NewInMemoryStore()
+		router.Handle(adka2a.JWKSPath, config.A2APushSender.JWKSHandler())
+	}
+	if a.config.grpc || a.config.runner {
+		a.grpcServer = grpc.NewServer()
+		router.MatcherFunc(isGRPCRequest).Handler(a.grpcServer)
+		if a.config.health {
+			checks := slices.Clone(config.ReadinessChecks)
+			if config.SessionService != nil {
+				checks = append(checks, adkgrpc.SessionServiceCheck(config.SessionService))
+			}
+			adkgrpc.RegisterHealthServer(a.grpcServer, adkgrpc.HealthConfig{Checks: checks})
+		}
+		if a.config.reflection {
+			reflection.Register(a.grpcServer)
+		}
+	}
+	if a.config.runner {
+		runnerpb.RegisterRunnerServer(a.grpcServer, adkgrpc.NewServer(adkgrpc.Config{
+			AgentLoader:     config.AgentLoader,
+			SessionService:  config.SessionService,
+			ArtifactService: config.ArtifactService,
+			MemoryService:   config.MemoryService,
+			PluginConfig:    config.PluginConfig,
+			Quota:           config.Quota,
+			Admission:       config.Admission,
+			SessionLocker:   config.SessionLocker,
+			InstanceID:      config.InstanceID,
+		}))
+	}
+	rootAgent := config.AgentLoader.RootAgent()
+	if err := a.serveAgent(router, config, rootAgent.Name(), rootAgent, apiPath, a2asrv.WellKnownAgentCardPath, a.config.grpc); err != nil {
+		return err
+	}
+	apps := config.AgentLoader.ListAgents()
+	if len(apps) < 2 {
+		return nil
+	}
+	for _, appName := range apps {
+		agent, err := config.AgentLoader.LoadAgent(appName)
+		if err != nil {
+			return fmt.Errorf("failed to load app %s: %w", appName, err)
+		}
+		path := appPath(appName)
+		if path == apiPath {
+			return fmt.Errorf("app %s can't be served over A2A, its path %s is the path of the root agent", appName, path)
+		}
+		if err := a.serveAgent(router, config, appName, agent, path, path+a2asrv.WellKnownAgentCardPath, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appPath returns the path of an app, e.g. /a2a/weather, on which its A2A
+// requests are served. Its agent card is served on the well-known path
+// under it.
+func appPath(appName string) string {
+	return "/a2a/" + url.PathEscape(appName)
+}
+
+// serveAgent serves the agent card of the agent on cardPath, and A2A
+// JSON-RPC requests on invokePath. If withGRPC is set, the agent is also
+// served with the gRPC transport, whose service paths are fixed.
+func (a *a2aLauncher) serveAgent(router *mux.Router, config *launcher.Config, appName string, agent agent.Agent, invokePath, cardPath string, withGRPC bool) error {
+	publicURL, err := url.JoinPath(a.config.agentURL, invokePath)
 	if err != nil {
 		return err
 	}
+	cardConfig := adka2a.AgentCardConfig{
+		URL:                publicURL,
+		PreferredTransport: a2acore.TransportProtocolJSONRPC,
+	}
+	if withGRPC {
+		u, err := url.Parse(a.config.agentURL)
+		if err != nil {
+			return err
+		}
+		// gRPC clients connect to the host, without scheme.
+		cardConfig.AdditionalInterfaces = []a2acore.AgentInterface{
+			{Transport: a2acore.TransportProtocolJSONRPC, URL: publicURL},
+			{Transport: a2acore.TransportProtocolGRPC, URL: u.Host},
+		}
+	}
+	agentCard := adka2a.BuildAgentCard(agent, cardConfig)
+	if config.A2APushSender != nil {
+		agentCard.Capabilities.PushNotifications = true
+	}
+	if config.A2AAgentCardOverride != nil {
+		config.A2AAgentCardOverride(appName, agentCard)
+	}
+	router.Handle(cardPath, a2asrv.NewStaticAgentCardHandler(agentCard))
 
-	rootAgent := config.AgentLoader.RootAgent()
-	agentCard := &a2acore.AgentCard{
-		Name:                              rootAgent.Name(),
-		Description:                       rootAgent.Description(),
-		DefaultInputModes:                 []string{"text/plain"},
-		DefaultOutputModes:                []string{"text/plain"},
-		URL:                               publicURL,
-		PreferredTransport:                a2acore.TransportProtocolJSONRPC,
-		Skills:                            adka2a.BuildAgentSkills(rootAgent),
-		Capabilities:                      a2acore.AgentCapabilities{Streaming: true},
-		SupportsAuthenticatedExtendedCard: false,
-	}
-	router.Handle(a2asrv.WellKnownAgentCardPath, a2asrv.NewStaticAgentCardHandler(agentCard))
-
-	agent := config.AgentLoader.RootAgent()
 	executor := adka2a.NewExecutor(adka2a.ExecutorConfig{
 		RunnerConfig: runner.Config{
-			AppName:         agent.Name(),
+			AppName:         appName,
 			Agent:           agent,
 			SessionService:  config.SessionService,
 			ArtifactService: config.ArtifactService,
 			PluginConfig:    config.PluginConfig,
+			Quota:           config.Quota,
+			Admission:       config.Admission,
+			SessionLocker:   config.SessionLocker,
+			InstanceID:      config.InstanceID,
 		},
 	})
-	reqHandler := a2asrv.NewHandler(executor, config.A2AOptions...)
-	router.Handle(apiPath, a2asrv.NewJSONRPCHandler(reqHandler))
+	options := slices.Clone(config.A2AOptions)
+	if config.A2APushSender != nil {
+		options = append(options, a2asrv.WithPushNotifications(a.pushStore, config.A2APushSender))
+	}
+	if config.A2AAuth != nil {
+		options = append(options, a2asrv.WithCallInterceptor(adka2a.NewAuthInterceptor(*config.A2AAuth)))
+	} else if config.IdentityExtractor != nil {
+		options = append(options, a2asrv.WithCallInterceptor(adka2a.NewAuthInterceptor(adka2a.AuthConfig{})))
+	}
+	reqHandler := a2asrv.NewHandler(executor, options...)
+	if withGRPC {
+		a2agrpc.NewHandler(reqHandler).RegisterWith(a.grpcServer)
+	}
+	router.Handle(invokePath, a2asrv.NewJSONRPCHandler(reqHandler))
 	return nil
 }
 
+// isGRPCRequest reports whether the request is a gRPC call, sent over
+// HTTP/2 with the application/grpc content type.
+func isGRPCRequest(r *http.Request, _ *mux.RouteMatch) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
+
 // SimpleDescription implements web.Sublauncher
 func (a *a2aLauncher) SimpleDescription() string {
-	return fmt.Sprintf("starts A2A server which handles jsonrpc requests on %s path", apiPath)
+	return fmt.Sprintf("starts A2A server which handles jsonrpc requests on %s path, gRPC requests for the root agent and the runner service, and jsonrpc requests of every app on /a2a/{app}", apiPath)
 }
 
 // UserMessage implements web.Sublauncher.
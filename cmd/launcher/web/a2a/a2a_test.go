@@ -15,20 +15,36 @@
 package a2a
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"iter"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	a2acore "github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2aclient"
 	"github.com/a2aproject/a2a-go/a2aclient/agentcard"
+	"github.com/gorilla/mux"
 	"google.golang.org/genai"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/identity"
 	"google.golang.org/adk/cmd/launcher"
 	"google.golang.org/adk/cmd/launcher/web"
+	"google.golang.org/adk/server/adka2a"
+	"google.golang.org/adk/server/adkgrpc/runnerpb"
 	"google.golang.org/adk/session"
 )
 
@@ -84,6 +100,9 @@ func TestWebLauncher_ServesA2A(t *testing.T) {
 	config := &launcher.Config{
 		AgentLoader:    agent.NewSingleLoader(agnt),
 		SessionService: session.InMemoryService(),
+		A2AAgentCardOverride: func(appName string, card *a2acore.AgentCard) {
+			card.Provider = &a2acore.AgentProvider{Org: "Example", URL: "https://example.com"}
+		},
 	}
 
 	go func() {
@@ -104,6 +123,13 @@ func TestWebLauncher_ServesA2A(t *testing.T) {
 		}
 	}
 
+	if card.Provider == nil || card.Provider.Org != "Example" {
+		t.Errorf("card.Provider = %+v, want the provider of the override", card.Provider)
+	}
+	if card.ProtocolVersion != string(a2acore.Version) {
+		t.Errorf("card.ProtocolVersion = %q, want %q", card.ProtocolVersion, a2acore.Version)
+	}
+
 	client, err := a2aclient.NewFromCard(ctx, card)
 	if err != nil {
 		t.Fatalf("a2aclient.NewFromCard() error = %v", err)
@@ -130,3 +156,427 @@ func TestWebLauncher_ServesA2A(t *testing.T) {
 		t.Fatalf("task.Artifacts[0].Parts[0] = %v, want %v", parts[0], a2acore.TextPart{Text: wantMessage})
 	}
 }
+
+func TestWebLauncher_ServesA2AApps(t *testing.T) {
+	ctx := t.Context()
+
+	port := getFreePort(t)
+	baseURL := "http://localhost:" + strconv.Itoa(port)
+
+	l := web.NewLauncher(NewLauncher())
+	if _, err := l.Parse([]string{"--port", strconv.Itoa(port), "a2a", "--a2a_agent_url", baseURL}); err != nil {
+		t.Fatalf("web.NewLauncher() error = %v", err)
+	}
+
+	newAgent := func(name string) (agent.Agent, error) {
+		return agent.New(agent.Config{
+			Name: name,
+			Run: func(ic agent.InvocationContext) iter.Seq2[*session.Event, error] {
+				return func(yield func(*session.Event, error) bool) {
+					event := session.NewEvent(ic.InvocationID())
+					event.Content = genai.NewContentFromText("Hello from "+name, genai.RoleModel)
+					yield(event, nil)
+				}
+			},
+		})
+	}
+	loader, err := agent.NewAppLoader("weather", map[string]agent.AppConstructor{
+		"weather": func() (agent.Agent, error) { return newAgent("weather_agent") },
+		"billing": func() (agent.Agent, error) { return newAgent("billing_agent") },
+	})
+	if err != nil {
+		t.Fatalf("agent.NewAppLoader() error = %v", err)
+	}
+	config := &launcher.Config{
+		AgentLoader:    loader,
+		SessionService: session.InMemoryService(),
+	}
+
+	go func() {
+		if err := l.Run(t.Context(), config); err != nil {
+			t.Errorf("launcher.Run() error = %v", err)
+		}
+	}()
+
+	tests := []struct {
+		cardPath  string
+		wantAgent string
+		wantURL   string
+	}{
+		{cardPath: "/.well-known/agent-card.json", wantAgent: "weather_agent", wantURL: baseURL + "/a2a/invoke"},
+		{cardPath: "/a2a/weather/.well-known/agent-card.json", wantAgent: "weather_agent", wantURL: baseURL + "/a2a/weather"},
+		{cardPath: "/a2a/billing/.well-known/agent-card.json", wantAgent: "billing_agent", wantURL: baseURL + "/a2a/billing"},
+	}
+	for _, tt := range tests {
+		var card *a2acore.AgentCard
+		for retry := range 3 {
+			time.Sleep(10 * time.Millisecond) // give server time to start
+			card, err = agentcard.DefaultResolver.Resolve(ctx, baseURL, agentcard.WithPath(tt.cardPath))
+			if err == nil {
+				break
+			}
+			if retry == 2 {
+				t.Fatalf("cardResolver.Resolve(%s) error = %v", tt.cardPath, err)
+			}
+		}
+		if card.Name != tt.wantAgent {
+			t.Errorf("card of %s = %q, want %q", tt.cardPath, card.Name, tt.wantAgent)
+		}
+		if card.URL != tt.wantURL {
+			t.Errorf("card URL of %s = %q, want %q", tt.cardPath, card.URL, tt.wantURL)
+		}
+
+		client, err := a2aclient.NewFromCard(ctx, card)
+		if err != nil {
+			t.Fatalf("a2aclient.NewFromCard() error = %v", err)
+		}
+		got, err := client.SendMessage(ctx, &a2acore.MessageSendParams{
+			Message: a2acore.NewMessage(a2acore.MessageRoleUser, a2acore.TextPart{Text: "Hi!"}),
+		})
+		if err != nil {
+			t.Fatalf("client.SendMessage() error = %v", err)
+		}
+		task, ok := got.(*a2acore.Task)
+		if !ok || len(task.Artifacts) != 1 || len(task.Artifacts[0].Parts) != 1 {
+			t.Fatalf("client.SendMessage() = %v, want a task with an artifact", got)
+		}
+		want := a2acore.TextPart{Text: "Hello from " + tt.wantAgent}
+		if gotPart, ok := task.Artifacts[0].Parts[0].(a2acore.TextPart); !ok || gotPart.Text != want.Text {
+			t.Errorf("response of %s = %v, want %v", tt.cardPath, task.Artifacts[0].Parts[0], want)
+		}
+	}
+}
+
+func TestA2ALauncher_RejectsAppOnRootPath(t *testing.T) {
+	newAgent := func() (agent.Agent, error) {
+		return agent.New(agent.Config{Name: "test_agent"})
+	}
+	loader, err := agent.NewAppLoader("weather", map[string]agent.AppConstructor{
+		"weather": newAgent,
+		"invoke":  newAgent,
+	})
+	if err != nil {
+		t.Fatalf("agent.NewAppLoader() error = %v", err)
+	}
+
+	l := NewLauncher()
+	if _, err := l.Parse(nil); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	config := &launcher.Config{
+		AgentLoader:    loader,
+		SessionService: session.InMemoryService(),
+	}
+	err = l.SetupSubrouters(mux.NewRouter(), config)
+	if err == nil || !strings.Contains(err.Error(), apiPath) {
+		t.Fatalf("SetupSubrouters() error = %v, want an error for the app on %s", err, apiPath)
+	}
+}
+
+func TestWebLauncher_ServesA2ATransports(t *testing.T) {
+	ctx := t.Context()
+
+	port := getFreePort(t)
+	baseURL := "http://localhost:" + strconv.Itoa(port)
+
+	l := web.NewLauncher(NewLauncher())
+	if _, err := l.Parse([]string{"--port", strconv.Itoa(port), "a2a", "--a2a_agent_url", baseURL}); err != nil {
+		t.Fatalf("web.NewLauncher() error = %v", err)
+	}
+	wantMessage := "Hello, world!"
+	agnt, err := agent.New(agent.Config{
+		Name: "HelloWorldAgent",
+		Run: func(ic agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				event := session.NewEvent(ic.InvocationID())
+				event.Content = genai.NewContentFromText(wantMessage, genai.RoleModel)
+				yield(event, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+	config := &launcher.Config{
+		AgentLoader:    agent.NewSingleLoader(agnt),
+		SessionService: session.InMemoryService(),
+	}
+	go func() {
+		if err := l.Run(t.Context(), config); err != nil {
+			t.Errorf("launcher.Run() error = %v", err)
+		}
+	}()
+
+	var card *a2acore.AgentCard
+	for retry := range 3 {
+		time.Sleep(10 * time.Millisecond) // give server time to start
+		card, err = agentcard.DefaultResolver.Resolve(ctx, baseURL)
+		if err == nil {
+			break
+		}
+		if retry == 2 {
+			t.Fatalf("cardResolver.Resolve() error = %v", err)
+		}
+	}
+
+	for _, transport := range []a2acore.TransportProtocol{a2acore.TransportProtocolJSONRPC, a2acore.TransportProtocolGRPC} {
+		t.Run(string(transport), func(t *testing.T) {
+			client, err := a2aclient.NewFromCard(ctx, card,
+				a2aclient.WithConfig(a2aclient.Config{PreferredTransports: []a2acore.TransportProtocol{transport}}),
+				a2aclient.WithGRPCTransport(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			)
+			if err != nil {
+				t.Fatalf("a2aclient.NewFromCard() error = %v", err)
+			}
+			defer client.Destroy()
+
+			var gotText string
+			msg := &a2acore.MessageSendParams{Message: a2acore.NewMessage(a2acore.MessageRoleUser, a2acore.TextPart{Text: "Hi!"})}
+			for event, err := range client.SendStreamingMessage(ctx, msg) {
+				if err != nil {
+					t.Fatalf("client.SendStreamingMessage() error = %v", err)
+				}
+				if update, ok := event.(*a2acore.TaskArtifactUpdateEvent); ok {
+					for _, part := range update.Artifact.Parts {
+						if text, ok := part.(a2acore.TextPart); ok {
+							gotText += text.Text
+						}
+					}
+				}
+			}
+			if gotText != wantMessage {
+				t.Errorf("streamed text = %q, want %q", gotText, wantMessage)
+			}
+		})
+	}
+
+	t.Run("runner", func(t *testing.T) {
+		conn, err := grpc.NewClient("localhost:"+strconv.Itoa(port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("grpc.NewClient() error = %v", err)
+		}
+		defer conn.Close()
+		client := runnerpb.NewRunnerClient(conn)
+
+		sess, err := client.CreateSession(ctx, &runnerpb.CreateSessionRequest{AppName: "HelloWorldAgent", UserId: "user"})
+		if err != nil {
+			t.Fatalf("CreateSession() error = %v", err)
+		}
+		resp, err := client.Run(ctx, &runnerpb.RunRequest{
+			AppName:    "HelloWorldAgent",
+			UserId:     "user",
+			SessionId:  sess.GetId(),
+			NewMessage: &runnerpb.Content{Role: genai.RoleUser, Parts: []*runnerpb.Part{{Data: &runnerpb.Part_Text{Text: "Hi!"}}}},
+		})
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if len(resp.GetEvents()) != 1 || resp.GetEvents()[0].GetContent().GetParts()[0].GetText() != wantMessage {
+			t.Errorf("Run() = %v, want the message of the agent", resp)
+		}
+	})
+
+	t.Run("health", func(t *testing.T) {
+		conn, err := grpc.NewClient("localhost:"+strconv.Itoa(port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("grpc.NewClient() error = %v", err)
+		}
+		defer conn.Close()
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: runnerpb.Runner_ServiceDesc.ServiceName})
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Check() status = %v, want SERVING", resp.GetStatus())
+		}
+	})
+}
+
+func TestWebLauncher_SendsPushNotifications(t *testing.T) {
+	ctx := t.Context()
+
+	port := getFreePort(t)
+	baseURL := "http://localhost:" + strconv.Itoa(port)
+
+	l := web.NewLauncher(NewLauncher())
+	if _, err := l.Parse([]string{"--port", strconv.Itoa(port), "a2a", "--a2a_agent_url", baseURL}); err != nil {
+		t.Fatalf("web.NewLauncher() error = %v", err)
+	}
+	agnt, err := agent.New(agent.Config{
+		Name: "HelloWorldAgent",
+		Run: func(ic agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				event := session.NewEvent(ic.InvocationID())
+				event.Content = genai.NewContentFromText("Hello, world!", genai.RoleModel)
+				yield(event, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := adka2a.NewPushSender(adka2a.PushSenderConfig{Signer: key, KeyID: "key-1"})
+	if err != nil {
+		t.Fatalf("adka2a.NewPushSender() error = %v", err)
+	}
+	config := &launcher.Config{
+		AgentLoader:    agent.NewSingleLoader(agnt),
+		SessionService: session.InMemoryService(),
+		A2APushSender:  sender,
+	}
+	go func() {
+		if err := l.Run(t.Context(), config); err != nil {
+			t.Errorf("launcher.Run() error = %v", err)
+		}
+	}()
+
+	notifications := make(chan *a2acore.Task, 10)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var task a2acore.Task
+		if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+			t.Errorf("failed to parse notification: %v", err)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			t.Errorf("Authorization = %q, want a bearer token", r.Header.Get("Authorization"))
+		}
+		notifications <- &task
+	}))
+	defer hook.Close()
+
+	var card *a2acore.AgentCard
+	for retry := range 3 {
+		time.Sleep(10 * time.Millisecond) // give server time to start
+		card, err = agentcard.DefaultResolver.Resolve(ctx, baseURL)
+		if err == nil {
+			break
+		}
+		if retry == 2 {
+			t.Fatalf("cardResolver.Resolve() error = %v", err)
+		}
+	}
+	if !card.Capabilities.PushNotifications {
+		t.Error("card.Capabilities.PushNotifications = false, want true")
+	}
+
+	resp, err := http.Get(baseURL + adka2a.JWKSPath)
+	if err != nil {
+		t.Fatalf("failed to get the JWKS: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("JWKS status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	client, err := a2aclient.NewFromCard(ctx, card)
+	if err != nil {
+		t.Fatalf("a2aclient.NewFromCard() error = %v", err)
+	}
+	_, err = client.SendMessage(ctx, &a2acore.MessageSendParams{
+		Message: a2acore.NewMessage(a2acore.MessageRoleUser, a2acore.TextPart{Text: "Hi!"}),
+		Config:  &a2acore.MessageSendConfig{PushConfig: &a2acore.PushConfig{URL: hook.URL}},
+	})
+	if err != nil {
+		t.Fatalf("client.SendMessage() error = %v", err)
+	}
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case task := <-notifications:
+			if task.Status.State == a2acore.TaskStateCompleted {
+				return
+			}
+		case <-timeout:
+			t.Fatal("no notification of the completed task")
+		}
+	}
+}
+
+// headerInterceptor adds a header to the requests of an A2A client.
+type headerInterceptor struct {
+	a2aclient.PassthroughInterceptor
+	name, value string
+}
+
+func (h headerInterceptor) Before(ctx context.Context, req *a2aclient.Request) (context.Context, error) {
+	req.Meta[h.name] = []string{h.value}
+	return ctx, nil
+}
+
+func TestWebLauncher_AuthenticatesA2ACallers(t *testing.T) {
+	ctx := t.Context()
+
+	port := getFreePort(t)
+	baseURL := "http://localhost:" + strconv.Itoa(port)
+
+	l := web.NewLauncher(NewLauncher())
+	if _, err := l.Parse([]string{"--port", strconv.Itoa(port), "a2a", "--a2a_agent_url", baseURL}); err != nil {
+		t.Fatalf("web.NewLauncher() error = %v", err)
+	}
+	agnt, err := agent.New(agent.Config{
+		Name: "HelloAgent",
+		Run: func(ic agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				event := session.NewEvent(ic.InvocationID())
+				event.Content = genai.NewContentFromText("Hello, "+ic.Session().UserID(), genai.RoleModel)
+				yield(event, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+	config := &launcher.Config{
+		AgentLoader:       agent.NewSingleLoader(agnt),
+		SessionService:    session.InMemoryService(),
+		IdentityExtractor: identity.FromHeaders("X-User", ""),
+		A2AAuth:           &adka2a.AuthConfig{RequireIdentity: true},
+	}
+	go func() {
+		if err := l.Run(t.Context(), config); err != nil {
+			t.Errorf("launcher.Run() error = %v", err)
+		}
+	}()
+
+	var card *a2acore.AgentCard
+	for retry := range 3 {
+		time.Sleep(10 * time.Millisecond) // give server time to start
+		card, err = agentcard.DefaultResolver.Resolve(ctx, baseURL)
+		if err == nil {
+			break
+		}
+		if retry == 2 {
+			t.Fatalf("cardResolver.Resolve() error = %v", err)
+		}
+	}
+
+	msg := &a2acore.MessageSendParams{Message: a2acore.NewMessage(a2acore.MessageRoleUser, a2acore.TextPart{Text: "Hi!"})}
+	anonymous, err := a2aclient.NewFromCard(ctx, card)
+	if err != nil {
+		t.Fatalf("a2aclient.NewFromCard() error = %v", err)
+	}
+	if _, err := anonymous.SendMessage(ctx, msg); !errors.Is(err, a2acore.ErrUnauthenticated) {
+		t.Errorf("anonymous client.SendMessage() error = %v, want %v", err, a2acore.ErrUnauthenticated)
+	}
+
+	client, err := a2aclient.NewFromCard(ctx, card, a2aclient.WithInterceptors(headerInterceptor{name: "X-User", value: "alice"}))
+	if err != nil {
+		t.Fatalf("a2aclient.NewFromCard() error = %v", err)
+	}
+	got, err := client.SendMessage(ctx, msg)
+	if err != nil {
+		t.Fatalf("client.SendMessage() error = %v", err)
+	}
+	task, ok := got.(*a2acore.Task)
+	if !ok || len(task.Artifacts) != 1 || len(task.Artifacts[0].Parts) != 1 {
+		t.Fatalf("client.SendMessage() = %v, want a task with an artifact", got)
+	}
+	if part, ok := task.Artifacts[0].Parts[0].(a2acore.TextPart); !ok || part.Text != "Hello, alice" {
+		t.Errorf("response = %v, want the greeting of the caller", task.Artifacts[0].Parts[0])
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/cmd/launcher"
+)
+
+// reloadChildPortEnv is set for the agent processes started by the dev mode.
+// They serve on the given port, without dev mode.
+const reloadChildPortEnv = "ADK_WEB_RELOAD_CHILD_PORT"
+
+// watchDir starts calling onChange in a goroutine whenever the files in dir
+// change, until the context is done. Hidden files and directories are
+// ignored.
+func watchDir(ctx context.Context, dir string, interval time.Duration, onChange func()) {
+	last, err := dirFingerprint(dir)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to watch the reload directory", "dir", dir, "error", err)
+	}
+	go pollDir(ctx, dir, interval, last, onChange)
+}
+
+func pollDir(ctx context.Context, dir string, interval time.Duration, last uint64, onChange func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		fingerprint, err := dirFingerprint(dir)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to watch the reload directory", "dir", dir, "error", err)
+			continue
+		}
+		if fingerprint != last {
+			last = fingerprint
+			onChange()
+		}
+	}
+}
+
+// dirFingerprint returns a hash of the names, sizes and modification times
+// of the files in dir.
+func dirFingerprint(dir string) (uint64, error) {
+	h := fnv.New64a()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\x00", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return h.Sum64(), err
+}
+
+// reloadAgents swaps the agent loader of the config for a new one built by
+// config.ReloadAgentLoader whenever the reload directory changes. The
+// requests already running keep their agents.
+func (w *webLauncher) reloadAgents(ctx context.Context, config *launcher.Config) {
+	loader := agent.NewSwappableLoader(config.AgentLoader)
+	config.AgentLoader = loader
+	watchDir(ctx, w.config.reloadDir, w.config.reloadInterval, func() {
+		l, err := config.ReloadAgentLoader(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to reload the agents, keeping the previous ones", "error", err)
+			return
+		}
+		loader.Swap(l)
+		slog.InfoContext(ctx, "Reloaded the agents", "agents", l.ListAgents())
+	})
+}
+
+// reloadProxy is the server of the dev mode for Go programs. It rebuilds the
+// Go program in the reload directory whenever it changes, runs it on another
+// port, and proxies the requests to the latest program which started. The
+// previous program is interrupted, letting it finish its active requests.
+type reloadProxy struct {
+	dir    string
+	args   []string
+	binDir string
+	builds int
+
+	mu      sync.RWMutex
+	current *agentProcess
+	lastErr error
+}
+
+type agentProcess struct {
+	cmd   *exec.Cmd
+	proxy *httputil.ReverseProxy
+	// exited is closed when the process exits.
+	exited chan struct{}
+}
+
+func (p *reloadProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.RLock()
+	current, lastErr := p.current, p.lastErr
+	p.mu.RUnlock()
+	if current == nil {
+		http.Error(w, fmt.Sprintf("the agent is not running: %v", lastErr), http.StatusServiceUnavailable)
+		return
+	}
+	current.proxy.ServeHTTP(w, r)
+}
+
+// reload builds and starts the program, and replaces the current one once
+// it accepts connections. On failure, the current program keeps serving.
+func (p *reloadProxy) reload(ctx context.Context) {
+	process, err := p.start(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to reload the agent program", "error", err)
+		p.mu.Lock()
+		p.lastErr = err
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Lock()
+	previous := p.current
+	p.current, p.lastErr = process, nil
+	p.mu.Unlock()
+	previous.stop()
+	slog.InfoContext(ctx, "Reloaded the agent program", "pid", process.cmd.Process.Pid)
+}
+
+func (p *reloadProxy) start(ctx context.Context) (*agentProcess, error) {
+	p.builds++
+	bin := filepath.Join(p.binDir, "agent-"+strconv.Itoa(p.builds))
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+	build := exec.CommandContext(ctx, "go", "build", "-o", bin, ".")
+	build.Dir = p.dir
+	if out, err := build.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("go build failed: %w\n%s", err, out)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(bin, p.args...)
+	cmd.Env = append(os.Environ(), reloadChildPortEnv+"="+strconv.Itoa(port))
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start the agent program: %w", err)
+	}
+	process := &agentProcess{cmd: cmd, exited: make(chan struct{})}
+	go func() {
+		_ = cmd.Wait()
+		close(process.exited)
+	}()
+
+	addr := net.JoinHostPort("localhost", strconv.Itoa(port))
+	for deadline := time.Now().Add(time.Minute); ; {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		select {
+		case <-process.exited:
+			return nil, errors.New("the agent program exited before serving")
+		case <-ctx.Done():
+			process.stop()
+			return nil, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			process.stop()
+			return nil, fmt.Errorf("the agent program does not serve on port %d", port)
+		}
+	}
+	process.proxy = httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: addr})
+	// Flush the SSE responses as they are written.
+	process.proxy.FlushInterval = -1
+	return process, nil
+}
+
+// stop interrupts the process, which finishes its active requests before
+// exiting. It is a no-op for a nil process.
+func (a *agentProcess) stop() {
+	if a == nil {
+		return
+	}
+	if err := a.cmd.Process.Signal(os.Interrupt); err != nil {
+		// Interrupts are not supported on Windows.
+		_ = a.cmd.Process.Kill()
+	}
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// runReloadProxy runs the dev mode server for Go programs until the context
+// is done.
+func (w *webLauncher) runReloadProxy(ctx context.Context) error {
+	binDir, err := os.MkdirTemp("", "adk-reload-")
+	if err != nil {
+		return fmt.Errorf("failed to create the build directory: %w", err)
+	}
+	defer os.RemoveAll(binDir)
+
+	p := &reloadProxy{dir: w.config.reloadDir, args: os.Args[1:], binDir: binDir}
+	p.reload(ctx)
+	watchDir(ctx, p.dir, w.config.reloadInterval, func() { p.reload(ctx) })
+
+	srv := http.Server{
+		Addr:        fmt.Sprintf(":%v", fmt.Sprint(w.config.port)),
+		ReadTimeout: w.config.readTimeout,
+		IdleTimeout: w.config.idleTimeout,
+		Handler:     p,
+	}
+	slog.InfoContext(ctx, "Web server starts in dev mode, reloading on changes", "url", fmt.Sprintf("http://localhost:%v", w.config.port), "dir", p.dir)
+
+	errChan := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- err
+		}
+		close(errChan)
+	}()
+
+	var serverErr error
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), w.config.shutdownTimeout)
+		defer cancel()
+		serverErr = srv.Shutdown(shutdownCtx)
+	case err, ok := <-errChan:
+		if ok {
+			serverErr = fmt.Errorf("server failed: %v", err)
+		}
+	}
+	p.mu.Lock()
+	current := p.current
+	p.current = nil
+	p.mu.Unlock()
+	current.stop()
+	if current != nil {
+		<-current.exited
+	}
+	return serverErr
+}
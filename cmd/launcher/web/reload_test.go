@@ -0,0 +1,120 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/session"
+)
+
+func newTestAgent(t *testing.T, name string) agent.Agent {
+	t.Helper()
+	a, err := agent.New(agent.Config{
+		Name: name,
+		Run: func(agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(func(*session.Event, error) bool) {}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestDirFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "root_agent.yaml")
+	if err := os.WriteFile(file, []byte("name: v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	before, err := dirFingerprint(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git", "index"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := dirFingerprint(dir); err != nil || got != before {
+		t.Errorf("dirFingerprint() after a hidden change = %v, %v, want %v", got, err, before)
+	}
+
+	if err := os.WriteFile(file, []byte("name: v2 changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := dirFingerprint(dir); err != nil || got == before {
+		t.Errorf("dirFingerprint() after a change = %v, %v, want another fingerprint", got, err)
+	}
+}
+
+func TestReloadAgents(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "root_agent.yaml")
+	if err := os.WriteFile(file, []byte("name: v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := make(chan struct{}, 1)
+	config := &launcher.Config{
+		AgentLoader: agent.NewSingleLoader(newTestAgent(t, "v1")),
+		ReloadAgentLoader: func(ctx context.Context) (agent.Loader, error) {
+			defer func() { reloaded <- struct{}{} }()
+			return agent.NewSingleLoader(newTestAgent(t, "v2")), nil
+		},
+	}
+	w := &webLauncher{config: &webConfig{reloadDir: dir, reloadInterval: 10 * time.Millisecond}}
+	w.reloadAgents(t.Context(), config)
+	if got := config.AgentLoader.RootAgent().Name(); got != "v1" {
+		t.Errorf("root agent before the change = %q, want v1", got)
+	}
+
+	if err := os.WriteFile(file, []byte("name: v2 changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("agents not reloaded after the change")
+	}
+	// The swap follows the reload.
+	deadline := time.Now().Add(5 * time.Second)
+	for config.AgentLoader.RootAgent().Name() != "v2" {
+		if time.Now().After(deadline) {
+			t.Fatal("root agent not swapped after the reload")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestReloadProxy_NotRunning(t *testing.T) {
+	p := &reloadProxy{}
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/list-apps", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d before the first build", rec.Code, http.StatusServiceUnavailable)
+	}
+}
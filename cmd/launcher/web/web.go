@@ -20,18 +20,27 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 
+	"google.golang.org/adk/agent/identity"
 	"google.golang.org/adk/cmd/launcher"
 	"google.golang.org/adk/cmd/launcher/internal/telemetry"
 	"google.golang.org/adk/cmd/launcher/universal"
 	"google.golang.org/adk/internal/cli/util"
 	"google.golang.org/adk/session"
+	adktelemetry "google.golang.org/adk/telemetry"
 )
 
 // webConfig contains parameters for launching web server
@@ -42,6 +51,13 @@ type webConfig struct {
 	idleTimeout     time.Duration
 	shutdownTimeout time.Duration
 	otelToCloud     bool
+	metrics         bool
+	// gcpObservability enables otelToCloud and the Cloud Logging format.
+	gcpObservability bool
+	// reloadDir enables the dev mode, reloading the agents when the
+	// directory changes.
+	reloadDir      string
+	reloadInterval time.Duration
 }
 
 // webLauncher can launch web server
@@ -152,8 +168,16 @@ func (w *webLauncher) Run(ctx context.Context, config *launcher.Config) error {
 	if config.SessionService == nil {
 		config.SessionService = session.InMemoryService()
 	}
+	config.SetupLogger()
 
-	router := BuildBaseRouter()
+	redactHeaders := config.RedactHeaders
+	if redactHeaders == nil {
+		redactHeaders = launcher.RedactSensitiveHeaders
+	}
+	router := buildRouter(redactHeaders)
+	if config.IdentityExtractor != nil {
+		router.Use(identity.Middleware(config.IdentityExtractor))
+	}
 
 	// check if there are any active sublaunchers
 	if len(w.activeSublaunchers) == 0 {
@@ -164,6 +188,39 @@ func (w *webLauncher) Run(ctx context.Context, config *launcher.Config) error {
 		return fmt.Errorf("no active sublaunchers found - please specify them in the command line. Possible values: %v", availableSublaunchers)
 	}
 
+	if port := os.Getenv(reloadChildPortEnv); port != "" {
+		// The program was started by the dev mode, which interrupts it
+		// once its next build serves.
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", reloadChildPortEnv, err)
+		}
+		w.config.port = p
+		w.config.reloadDir = ""
+		var cancel context.CancelFunc
+		ctx, cancel = signal.NotifyContext(ctx, os.Interrupt)
+		defer cancel()
+	}
+	if w.config.reloadDir != "" {
+		if config.ReloadAgentLoader == nil {
+			ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+			defer cancel()
+			return w.runReloadProxy(ctx)
+		}
+		w.reloadAgents(ctx, config)
+	}
+
+	var telemetryOpts []adktelemetry.Option
+	if w.config.metrics {
+		// The endpoint is registered before the sublaunchers, which may
+		// serve all the remaining paths.
+		reader, err := setupMetricsEndpoint(router)
+		if err != nil {
+			return fmt.Errorf("metrics endpoint setup failed: %v", err)
+		}
+		telemetryOpts = append(telemetryOpts, adktelemetry.WithMetricReaders(reader))
+	}
+
 	// Setup subrouters
 	for _, l := range w.sublaunchers {
 		if _, isActive := w.activeSublaunchers[l.Keyword()]; isActive {
@@ -173,23 +230,37 @@ func (w *webLauncher) Run(ctx context.Context, config *launcher.Config) error {
 		}
 	}
 
-	log.Printf("Starting the web server: %+v", w.config)
-	log.Println()
+	telemetryService, err := telemetry.InitAndSetGlobalOtelProviders(ctx, config, w.config.otelToCloud || w.config.gcpObservability, telemetryOpts...)
+	if err != nil {
+		return fmt.Errorf("telemetry initialization failed: %v", err)
+	}
+	if w.config.gcpObservability {
+		telemetry.SetupCloudLogging(config, telemetryService, os.Stdout)
+	}
+
+	slog.InfoContext(ctx, "Starting the web server", "config", fmt.Sprintf("%+v", *w.config))
 	webUrl := fmt.Sprintf("http://localhost:%v", fmt.Sprint(w.config.port))
-	log.Printf("Web servers starts on %s", webUrl)
+	slog.InfoContext(ctx, "Web server starts", "url", webUrl)
 	for _, l := range w.activeSublaunchers {
-		l.UserMessage(webUrl, log.Println)
+		l.UserMessage(webUrl, func(v ...any) { slog.InfoContext(ctx, strings.TrimSuffix(fmt.Sprintln(v...), "\n")) })
 	}
-	log.Println()
 
+	// HTTP/2 without TLS is accepted for the gRPC clients, e.g. of A2A.
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
 	srv := http.Server{
 		Addr:         fmt.Sprintf(":%v", fmt.Sprint(w.config.port)),
 		WriteTimeout: w.config.writeTimeout,
 		ReadTimeout:  w.config.readTimeout,
 		IdleTimeout:  w.config.idleTimeout,
 		Handler:      router,
+		Protocols:    &protocols,
 	}
 
+	// The sessions are pruned while the server runs.
+	go config.RunRetention(ctx)
+
 	errChan := make(chan error, 1)
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -198,14 +269,9 @@ func (w *webLauncher) Run(ctx context.Context, config *launcher.Config) error {
 		close(errChan)
 	}()
 
-	telemetryService, err := telemetry.InitAndSetGlobalOtelProviders(ctx, config, w.config.otelToCloud)
-	if err != nil {
-		return fmt.Errorf("telemetry initialization failed: %v", err)
-	}
-
 	select {
 	case <-ctx.Done():
-		log.Println("Shutting down the web server...")
+		slog.InfoContext(ctx, "Shutting down the web server")
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), w.config.shutdownTimeout)
 		defer cancel()
 		serverErr := srv.Shutdown(shutdownCtx)
@@ -237,6 +303,11 @@ func NewLauncher(sublaunchers ...Sublauncher) launcher.SubLauncher {
 	fs.DurationVar(&config.shutdownTimeout, "shutdown-timeout", 15*time.Second, "Server shutdown timeout (i.e. '10s', '2m' - see time.ParseDuration for details) - for waiting for active requests to finish during shutdown")
 	fs.BoolVar(&config.otelToCloud, "otel_to_cloud", false, "Enables/disables OpenTelemetry export to GCP: telemetry.googleapis.com. See adk-go/telemetry package for details about supported options, credentials and environment variables.")
 
+	fs.BoolVar(&config.gcpObservability, "gcp_observability", false, telemetry.GCPObservabilityFlagUsage)
+	fs.BoolVar(&config.metrics, "metrics", false, "Exposes the ADK metrics (invocations, LLM latency, tokens, tool errors, ...) in the Prometheus format on the /metrics endpoint.")
+	fs.StringVar(&config.reloadDir, "reload_dir", "", "Enables the dev mode: watches the directory and reloads the agents when it changes. The agent loader is rebuilt by launcher.Config.ReloadAgentLoader if set, otherwise the Go program in the directory is rebuilt and restarted behind the server, without dropping its connections.")
+	fs.DurationVar(&config.reloadInterval, "reload_interval", time.Second, "Interval between the checks of reload_dir for changes in dev mode (i.e. '500ms', '2s' - see time.ParseDuration for details)")
+
 	return &webLauncher{
 		config:       config,
 		flags:        fs,
@@ -244,25 +315,46 @@ func NewLauncher(sublaunchers ...Sublauncher) launcher.SubLauncher {
 	}
 }
 
-// logger is a middleware that logs the HTTP method, request URI, and the time taken to process the request.
-func logger(inner http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		inner.ServeHTTP(w, r)
+// setupMetricsEndpoint serves the metrics collected by the returned reader on
+// the /metrics endpoint.
+func setupMetricsEndpoint(router *mux.Router) (sdkmetric.Reader, error) {
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, err
+	}
+	router.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{})).Methods(http.MethodGet)
+	return exporter, nil
+}
 
-		log.Printf(
-			"%s %s %s",
-			r.Method,
-			r.RequestURI,
-			time.Since(start),
-		)
-	})
+// requestLogger is a middleware that logs the HTTP method, request URI, and
+// the time taken to process the request. The request headers, as returned by
+// redactHeaders, are logged at the debug level.
+func requestLogger(redactHeaders func(http.Header) http.Header) mux.MiddlewareFunc {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			inner.ServeHTTP(w, r)
+
+			ctx := r.Context()
+			logger := slog.Default()
+			attrs := []any{"method", r.Method, "uri", r.RequestURI, "duration", time.Since(start)}
+			if logger.Enabled(ctx, slog.LevelDebug) {
+				attrs = append(attrs, "headers", redactHeaders(r.Header))
+			}
+			logger.InfoContext(ctx, "HTTP request", attrs...)
+		})
+	}
 }
 
 // BuildBaseRouter returns the main router, which can be extended by sub-routers.
 func BuildBaseRouter() *mux.Router {
+	return buildRouter(launcher.RedactSensitiveHeaders)
+}
+
+func buildRouter(redactHeaders func(http.Header) http.Header) *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
-	router.Use(logger)
+	router.Use(requestLogger(redactHeaders))
 	return router
 }
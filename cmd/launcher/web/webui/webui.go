@@ -22,6 +22,8 @@ import (
 	"io/fs"
 	"log"
 	"net/http"
+	"path"
+	"strings"
 
 	"github.com/gorilla/mux"
 
@@ -59,6 +61,14 @@ func (w *webUILauncher) Parse(args []string) ([]string, error) {
 	if err != nil || !w.flags.Parsed() {
 		return nil, fmt.Errorf("failed to parse webui flags: %v", err)
 	}
+	p := w.config.pathPrefix
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	if !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	w.config.pathPrefix = p
 	restArgs := w.flags.Args()
 	return restArgs, nil
 }
@@ -78,7 +88,7 @@ func (w *webUILauncher) SimpleDescription() string {
 // UserMessage implements the web.Sublauncher interface. It prints a message
 // to the user with the URL to access the WebUI.
 func (w *webUILauncher) UserMessage(webURL string, printer func(v ...any)) {
-	printer(fmt.Sprintf("       webui:  you can access API using %s%s", webURL, w.config.pathPrefix))
+	printer(fmt.Sprintf("       webui:  you can access the Web UI using %s%s", webURL, w.config.pathPrefix))
 }
 
 // embed web UI files into the executable
@@ -86,31 +96,78 @@ func (w *webUILauncher) UserMessage(webURL string, printer func(v ...any)) {
 //go:embed distr/*
 var content embed.FS
 
-// AddSubrouter adds a subrouter to serve the ADK Web UI.
+// legacyPathPrefix is the path the Web UI was served on before /dev-ui/,
+// redirected to the path prefix.
+const legacyPathPrefix = "/ui/"
+
+// AddSubrouter adds a subrouter to serve the ADK Web UI. If backendAddress is
+// empty, the Web UI calls the REST API on /api of the address it is served
+// from.
 func (w *webUILauncher) AddSubrouter(router *mux.Router, pathPrefix, backendAddress string) {
 	// Setup serving of ADK Web UI
 	rUI := router.Methods("GET").PathPrefix(pathPrefix).Subrouter()
 
 	//   generate /assets/config/runtime-config.json in the runtime.
 	//   It removes the need to prepare this file during deployment and update the distribution files.
-	runtimeConfigResponse := struct {
-		BackendUrl string `json:"backendUrl"`
-	}{BackendUrl: backendAddress}
 	rUI.Methods("GET").Path("/assets/config/runtime-config.json").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runtimeConfigResponse := struct {
+			BackendUrl string `json:"backendUrl"`
+		}{BackendUrl: backendAddress}
+		if runtimeConfigResponse.BackendUrl == "" {
+			runtimeConfigResponse.BackendUrl = requestOrigin(r) + "/api"
+		}
 		controllers.EncodeJSONResponse(runtimeConfigResponse, http.StatusOK, w)
 	})
 
-	//   redirect the user from / to pathPrefix (/ui/)
+	//   redirect the user from / and the legacy path to pathPrefix (/dev-ui/)
 	router.Methods("GET").Path("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, pathPrefix, http.StatusFound)
 	})
+	if pathPrefix != legacyPathPrefix {
+		router.Methods("GET").PathPrefix(legacyPathPrefix).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, pathPrefix, http.StatusFound)
+		})
+	}
 
 	// serve web ui from the embedded resources
 	ui, err := fs.Sub(content, "distr")
 	if err != nil {
 		log.Fatalf("cannot prepare ADK Web UI files as embedded content: %v", err)
 	}
-	rUI.Methods("GET").Handler(http.StripPrefix(pathPrefix, http.FileServer(http.FS(ui))))
+	rUI.Methods("GET").Handler(http.StripPrefix(pathPrefix, spaHandler(ui)))
+}
+
+// spaHandler serves the files of the Web UI, and its index.html for the
+// other paths, which are routes of the single page app.
+func spaHandler(ui fs.FS) http.Handler {
+	fileServer := http.FileServer(http.FS(ui))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		if name != "" {
+			if _, err := fs.Stat(ui, name); err != nil {
+				r = r.Clone(r.Context())
+				r.URL.Path = "/"
+			}
+		}
+		fileServer.ServeHTTP(w, r)
+	})
+}
+
+// requestOrigin returns the scheme and host the request was sent to, as seen
+// from the browser.
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	host := r.Host
+	if forwarded := r.Header.Get("X-Forwarded-Host"); forwarded != "" {
+		host = forwarded
+	}
+	return scheme + "://" + host
 }
 
 // NewLauncher creates a new Sublauncher for the ADK Web UI.
@@ -118,8 +175,8 @@ func NewLauncher() weblauncher.Sublauncher {
 	config := &webUIConfig{}
 
 	fs := flag.NewFlagSet("webui", flag.ContinueOnError)
-	fs.StringVar(&config.backendAddress, "api_server_address", "http://localhost:8080/api", "ADK REST API server address as seen from the user browser. Please specify the whole URL, i.e. 'http://localhost:8080/api'.")
-	config.pathPrefix = "/ui/"
+	fs.StringVar(&config.backendAddress, "api_server_address", "", "ADK REST API server address as seen from the user browser. Please specify the whole URL, i.e. 'http://localhost:8080/api'. Defaults to /api on the address the Web UI is served from.")
+	fs.StringVar(&config.pathPrefix, "path_prefix", "/dev-ui/", "ADK Web UI path prefix. Default is '/dev-ui/', as expected by the Web UI.")
 
 	return &webUILauncher{
 		config: config,
@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func newTestRouter(t *testing.T, args ...string) *mux.Router {
+	t.Helper()
+	l := NewLauncher().(*webUILauncher)
+	if _, err := l.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	router := mux.NewRouter()
+	if err := l.SetupSubrouters(router, nil); err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
+func get(t *testing.T, router http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestWebUI(t *testing.T) {
+	router := newTestRouter(t)
+	tests := []struct {
+		name         string
+		target       string
+		wantStatus   int
+		wantLocation string
+		wantBody     string
+	}{
+		{name: "root", target: "/", wantStatus: http.StatusFound, wantLocation: "/dev-ui/"},
+		{name: "legacy path", target: "/ui/", wantStatus: http.StatusFound, wantLocation: "/dev-ui/"},
+		{name: "index", target: "/dev-ui/", wantStatus: http.StatusOK, wantBody: "<base href=\"./\">"},
+		{name: "asset", target: "/dev-ui/adk_favicon.svg", wantStatus: http.StatusOK, wantBody: "<svg"},
+		{name: "app route", target: "/dev-ui/apps/weather", wantStatus: http.StatusOK, wantBody: "<base href=\"./\">"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := get(t, router, "http://localhost:8080"+tt.target, nil)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+			if got := rr.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+			if !strings.Contains(rr.Body.String(), tt.wantBody) {
+				t.Errorf("body does not contain %q", tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestWebUI_RuntimeConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		header http.Header
+		want   string
+	}{
+		{name: "same origin", want: "http://localhost:9090/api"},
+		{name: "behind a proxy", header: http.Header{"X-Forwarded-Proto": {"https"}, "X-Forwarded-Host": {"agents.example.com"}}, want: "https://agents.example.com/api"},
+		{name: "API server address", args: []string{"-api_server_address", "http://api:8000/api"}, want: "http://api:8000/api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := get(t, newTestRouter(t, tt.args...), "http://localhost:9090/dev-ui/assets/config/runtime-config.json", tt.header)
+			var got struct {
+				BackendURL string `json:"backendUrl"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.BackendURL != tt.want {
+				t.Errorf("backendUrl = %q, want %q", got.BackendURL, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package codec provides the serialization codecs of the data stored by the
+// session and artifact backends, e.g. the events of the sessions.
+//
+// JSON is the default codec. The binary codecs produce smaller payloads for
+// high-volume deployments. MessagePack encodes the JSON data model of the
+// values, so the custom JSON encodings of the types, e.g. of the session
+// events, are preserved, and the integers are kept exact as with JSON.
+// Protobuf encodes the session events only, as the typed Event messages of
+// the gRPC API, and decodes them as from JSON.
+//
+// The payloads written by [Encode] with a binary codec start with a header
+// naming their codec, which [Decode] reads to pick the codec of each
+// payload. A backend can thus switch codecs without migrating the data
+// already stored: payloads without a header are JSON.
+//
+// [session.Event]: https://pkg.go.dev/google.golang.org/adk/session#Event
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Codec encodes and decodes the stored values.
+type Codec interface {
+	// Name identifies the codec in the settings and the headers of the
+	// payloads, e.g. "msgpack". At most 255 bytes.
+	Name() string
+	// Marshal returns the encoding of v.
+	Marshal(v any) ([]byte, error)
+	// Unmarshal decodes data into v, which must be a pointer.
+	Unmarshal(data []byte, v any) error
+}
+
+// ErrUnknownCodec is returned for a codec name which is not registered.
+var ErrUnknownCodec = errors.New("unknown codec")
+
+var (
+	// JSON is the default codec, encoding the values with encoding/json.
+	JSON Codec = jsonCodec{}
+	// MessagePack encodes the JSON data model of the values in the
+	// MessagePack format.
+	MessagePack Codec = msgpackCodec{}
+	// Protobuf encodes the *session.Event values as runnerpb.Event
+	// messages. The fields of the events the message does not have are
+	// kept as JSON in the message.
+	Protobuf Codec = protobufCodec{}
+)
+
+var (
+	mu     sync.RWMutex
+	codecs = map[string]Codec{
+		JSON.Name():        JSON,
+		MessagePack.Name(): MessagePack,
+		Protobuf.Name():    Protobuf,
+	}
+)
+
+// Register makes the codec available to [Lookup] and [Decode] by its name.
+// It panics if a codec with the same name is already registered.
+func Register(c Codec) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := codecs[c.Name()]; ok {
+		panic(fmt.Sprintf("codec: Register called twice for codec %q", c.Name()))
+	}
+	if len(c.Name()) == 0 || len(c.Name()) > 255 {
+		panic(fmt.Sprintf("codec: invalid codec name %q", c.Name()))
+	}
+	codecs[c.Name()] = c
+}
+
+// Lookup returns the registered codec with the given name, or JSON if the
+// name is empty.
+func Lookup(name string) (Codec, error) {
+	if name == "" {
+		return JSON, nil
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownCodec, name)
+	}
+	return c, nil
+}
+
+// Names returns the names of the registered codecs, sorted.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(codecs))
+	for name := range codecs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// headerMagic starts the header of the payloads of the non-JSON codecs. It
+// is followed by the length of the codec name and the name. A JSON text
+// never starts with a NUL byte.
+const headerMagic = "\x00adk"
+
+// Encode returns the encoding of v with the codec, prefixed with the header
+// naming the codec unless it is JSON. A nil codec is JSON.
+func Encode(c Codec, v any) ([]byte, error) {
+	if c == nil || c.Name() == JSON.Name() {
+		return json.Marshal(v)
+	}
+	payload, err := c.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.Name(), err)
+	}
+	name := c.Name()
+	data := make([]byte, 0, len(headerMagic)+1+len(name)+len(payload))
+	data = append(data, headerMagic...)
+	data = append(data, byte(len(name)))
+	data = append(data, name...)
+	return append(data, payload...), nil
+}
+
+// Decode decodes data written by [Encode] into v, with the codec named by
+// its header. Data without a header is decoded as JSON. Data compressed by
+// [Compression.Compress] is decompressed first.
+func Decode(data []byte, v any) error {
+	data, err := Decompress(data)
+	if err != nil {
+		return err
+	}
+	c, payload, err := Detect(data)
+	if err != nil {
+		return err
+	}
+	if err := c.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("%s: %w", c.Name(), err)
+	}
+	return nil
+}
+
+// Detect returns the codec of data written by [Encode] and the payload
+// following its header.
+func Detect(data []byte) (Codec, []byte, error) {
+	if !bytes.HasPrefix(data, []byte(headerMagic)) {
+		return JSON, data, nil
+	}
+	rest := data[len(headerMagic):]
+	if len(rest) == 0 || len(rest) < 1+int(rest[0]) {
+		return nil, nil, errors.New("codec: truncated header")
+	}
+	name := string(rest[1 : 1+int(rest[0])])
+	c, err := Lookup(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, rest[1+len(name):], nil
+}
+
+// jsonCodec is the JSON codec.
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return "json" }
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// toJSONValue returns the JSON data model of v: nil, bool, json.Number,
+// string, []any and map[string]any.
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// fromJSONValue decodes the JSON data model value into v.
+func fromJSONValue(value, v any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
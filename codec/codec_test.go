@@ -0,0 +1,183 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type record struct {
+	Name    string            `json:"name"`
+	Count   int64             `json:"count"`
+	Ratio   float64           `json:"ratio"`
+	Enabled bool              `json:"enabled"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+	Next    *record           `json:"next"`
+	Data    []byte            `json:"data"`
+}
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	want := record{
+		Name:    strings.Repeat("long name ", 40),
+		Count:   -1 << 40,
+		Ratio:   0.25,
+		Enabled: true,
+		Tags:    []string{"a", "", strings.Repeat("b", 70000)},
+		Labels:  map[string]string{"k": "v", "é": "ü"},
+		Next:    &record{Count: math.MaxInt32 + 1, Ratio: -3e-9},
+		Data:    []byte{0, 1, 2, 255},
+	}
+	for _, c := range []Codec{JSON, MessagePack} {
+		t.Run(c.Name(), func(t *testing.T) {
+			data, err := Encode(c, want)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			gotCodec, _, err := Detect(data)
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if gotCodec.Name() != c.Name() {
+				t.Errorf("Detect() = %s, want %s", gotCodec.Name(), c.Name())
+			}
+			var got record
+			if err := Decode(data, &got); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMessagePack_Integers(t *testing.T) {
+	for _, n := range []int64{0, 1, 127, 128, -1, -32, -33, -128, -129, 255, 256, 1 << 16, -1 << 16, math.MaxInt64, math.MinInt64} {
+		data, err := MessagePack.Marshal(n)
+		if err != nil {
+			t.Fatalf("Marshal(%d) error = %v", n, err)
+		}
+		var got int64
+		if err := MessagePack.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%d) error = %v", n, err)
+		}
+		if got != n {
+			t.Errorf("Unmarshal(Marshal(%d)) = %d", n, got)
+		}
+	}
+}
+
+func TestCodecs_LargeIntegers(t *testing.T) {
+	type integers struct {
+		Max      int64  `json:"max"`
+		Min      int64  `json:"min"`
+		Unsafe   int64  `json:"unsafe"`
+		Unsigned uint64 `json:"unsigned"`
+	}
+	// Integers beyond 2^53 are not exact as float64.
+	want := integers{Max: math.MaxInt64, Min: math.MinInt64, Unsafe: 1<<53 + 1, Unsigned: math.MaxUint64}
+	for _, c := range []Codec{JSON, MessagePack} {
+		t.Run(c.Name(), func(t *testing.T) {
+			data, err := Encode(c, want)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			var got integers
+			if err := Decode(data, &got); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMessagePack_Smaller(t *testing.T) {
+	v := map[string]any{"id": "event", "count": 12, "flags": []bool{true, false}, "nested": map[string]any{"ratio": 0.5}}
+	jsonData, err := Encode(JSON, v)
+	if err != nil {
+		t.Fatalf("Encode(JSON) error = %v", err)
+	}
+	msgpackData, err := Encode(MessagePack, v)
+	if err != nil {
+		t.Fatalf("Encode(MessagePack) error = %v", err)
+	}
+	if len(msgpackData) >= len(jsonData) {
+		t.Errorf("Encode(MessagePack) = %d bytes, want fewer than the %d bytes of JSON", len(msgpackData), len(jsonData))
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	valid, err := Encode(MessagePack, map[string]any{"k": "v"})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "unknown codec", data: []byte(headerMagic + "\x03zip{}"), wantErr: ErrUnknownCodec},
+		{name: "truncated header", data: []byte(headerMagic + "\x07msg")},
+		{name: "truncated payload", data: valid[:len(valid)-1]},
+		{name: "trailing bytes", data: append(valid, 0xc0)},
+		{name: "invalid json", data: []byte("{")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]any
+			err := Decode(tt.data, &got)
+			if err == nil {
+				t.Fatal("Decode() succeeded, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type upperCodec struct{ jsonCodec }
+
+func (upperCodec) Name() string { return "test-upper" }
+
+func TestRegister(t *testing.T) {
+	if _, err := Lookup("test-upper"); !errors.Is(err, ErrUnknownCodec) {
+		t.Fatalf("Lookup() of an unregistered codec error = %v, want %v", err, ErrUnknownCodec)
+	}
+	Register(upperCodec{})
+	c, err := Lookup("test-upper")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	data, err := Encode(c, "x")
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var got string
+	if err := Decode(data, &got); err != nil || got != "x" {
+		t.Errorf("Decode() = %q, %v, want %q", got, err, "x")
+	}
+	if c, err := Lookup(""); err != nil || c != JSON {
+		t.Errorf("Lookup(\"\") = %v, %v, want JSON", c, err)
+	}
+}
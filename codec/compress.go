@@ -0,0 +1,244 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compressor compresses the stored payloads.
+type Compressor interface {
+	// Name identifies the compressor in the settings and the headers of the
+	// compressed payloads, e.g. "zstd". At most 255 bytes.
+	Name() string
+	// Compress returns the compression of data.
+	Compress(data []byte) ([]byte, error)
+	// Decompress returns the data compressed by Compress.
+	Decompress(data []byte) ([]byte, error)
+}
+
+// StreamDecompressor is implemented by the compressors able to decompress
+// a stream, so that large payloads are not held in memory.
+type StreamDecompressor interface {
+	// NewReader returns a reader of the decompression of r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// NewDecompressReader returns a reader of the decompression of r by c,
+// streamed if c implements [StreamDecompressor]. Otherwise r is read whole
+// and decompressed in memory.
+func NewDecompressReader(c Compressor, r io.Reader) (io.ReadCloser, error) {
+	if stream, ok := c.(StreamDecompressor); ok {
+		return stream.NewReader(r)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err = c.Decompress(data)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+var (
+	// Gzip compresses the payloads with gzip.
+	Gzip Compressor = gzipCompressor{}
+	// Zstd compresses the payloads with Zstandard, faster than gzip at a
+	// similar ratio.
+	Zstd Compressor = zstdCompressor{}
+)
+
+var compressors = map[string]Compressor{
+	Gzip.Name(): Gzip,
+	Zstd.Name(): Zstd,
+}
+
+// RegisterCompressor makes the compressor available to [LookupCompressor]
+// and [Decompress] by its name. It panics if a compressor with the same name
+// is already registered.
+func RegisterCompressor(c Compressor) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := compressors[c.Name()]; ok {
+		panic(fmt.Sprintf("codec: RegisterCompressor called twice for compressor %q", c.Name()))
+	}
+	if len(c.Name()) == 0 || len(c.Name()) > 255 {
+		panic(fmt.Sprintf("codec: invalid compressor name %q", c.Name()))
+	}
+	compressors[c.Name()] = c
+}
+
+// LookupCompressor returns the registered compressor with the given name,
+// or nil if the name is empty or "none".
+func LookupCompressor(name string) (Compressor, error) {
+	if name == "" || name == "none" {
+		return nil, nil
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := compressors[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownCodec, name)
+	}
+	return c, nil
+}
+
+// DefaultCompressionThreshold is the Threshold of a Compression leaving it
+// zero: 1 KiB.
+const DefaultCompressionThreshold = 1 << 10
+
+// Compression compresses the payloads of at least Threshold bytes. The zero
+// Compression compresses nothing.
+type Compression struct {
+	// Compressor compresses the payloads, none if nil.
+	Compressor Compressor
+	// Threshold is the minimum size of the payloads compressed, in bytes.
+	// Smaller payloads gain little and are stored as is.
+	// DefaultCompressionThreshold if zero.
+	Threshold int
+}
+
+// Enabled reports whether the compression compresses payloads.
+func (c Compression) Enabled() bool {
+	return c.Compressor != nil
+}
+
+// compressedMagic starts the header of the compressed payloads. It is
+// followed by the length of the compressor name and the name.
+const compressedMagic = "\x00adz"
+
+// Compress returns the payload compressed, prefixed with the header naming
+// its compressor, or the payload unchanged if it is below the threshold or
+// does not shrink.
+func (c Compression) Compress(data []byte) ([]byte, error) {
+	threshold := c.Threshold
+	if threshold == 0 {
+		threshold = DefaultCompressionThreshold
+	}
+	if !c.Enabled() || len(data) < threshold {
+		return data, nil
+	}
+	compressed, err := c.Compressor.Compress(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.Compressor.Name(), err)
+	}
+	name := c.Compressor.Name()
+	if len(compressedMagic)+1+len(name)+len(compressed) >= len(data) {
+		return data, nil
+	}
+	out := make([]byte, 0, len(compressedMagic)+1+len(name)+len(compressed))
+	out = append(out, compressedMagic...)
+	out = append(out, byte(len(name)))
+	out = append(out, name...)
+	return append(out, compressed...), nil
+}
+
+// IsCompressed reports whether data starts with the header of a compressed
+// payload.
+func IsCompressed(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(compressedMagic))
+}
+
+// Decompress returns the payload compressed by [Compression.Compress], with
+// the compressor named by its header. Data without a header is returned
+// unchanged.
+func Decompress(data []byte) ([]byte, error) {
+	if !IsCompressed(data) {
+		return data, nil
+	}
+	rest := data[len(compressedMagic):]
+	if len(rest) == 0 || len(rest) < 1+int(rest[0]) {
+		return nil, errors.New("codec: truncated compression header")
+	}
+	name := string(rest[1 : 1+int(rest[0])])
+	c, err := LookupCompressor(name)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownCodec, name)
+	}
+	out, err := c.Decompress(rest[1+len(name):])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
+
+// gzipCompressor is the gzip compressor.
+type gzipCompressor struct{}
+
+func (gzipCompressor) Name() string { return "gzip" }
+
+func (gzipCompressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCompressor) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func (gzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// zstdEncoder and zstdDecoder are shared: their EncodeAll and DecodeAll
+// methods are safe for concurrent use.
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+)
+
+// zstdCompressor is the Zstandard compressor.
+type zstdCompressor struct{}
+
+func (zstdCompressor) Name() string { return "zstd" }
+
+func (zstdCompressor) Compress(data []byte) ([]byte, error) {
+	return zstdEncoder.EncodeAll(data, nil), nil
+}
+
+func (zstdCompressor) Decompress(data []byte) ([]byte, error) {
+	return zstdDecoder.DecodeAll(data, nil)
+}
+
+func (zstdCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestCompression(t *testing.T) {
+	large := []byte(strings.Repeat("a large tool output ", 200))
+	small := []byte("small")
+	for _, c := range []Compressor{Gzip, Zstd} {
+		t.Run(c.Name(), func(t *testing.T) {
+			compression := Compression{Compressor: c}
+			got, err := compression.Compress(large)
+			if err != nil {
+				t.Fatalf("Compress() error = %v", err)
+			}
+			if !IsCompressed(got) || len(got) >= len(large) {
+				t.Errorf("Compress() = %d bytes, want fewer than %d, compressed", len(got), len(large))
+			}
+			decompressed, err := Decompress(got)
+			if err != nil {
+				t.Fatalf("Decompress() error = %v", err)
+			}
+			if !bytes.Equal(decompressed, large) {
+				t.Errorf("Decompress() = %q, want %q", decompressed, large)
+			}
+
+			// The payloads below the threshold are kept as is.
+			if got, err := compression.Compress(small); err != nil || !bytes.Equal(got, small) {
+				t.Errorf("Compress(small) = %q, %v, want it unchanged", got, err)
+			}
+		})
+	}
+
+	// The payloads which do not shrink are kept as is.
+	random := make([]byte, 2048)
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range random {
+		random[i] = byte(r.Uint32())
+	}
+	if got, err := (Compression{Compressor: Gzip}).Compress(random); err != nil || !bytes.Equal(got, random) {
+		t.Errorf("Compress(random) = %d bytes, %v, want it unchanged", len(got), err)
+	}
+
+	// The zero Compression compresses nothing.
+	if got, err := (Compression{}).Compress(large); err != nil || !bytes.Equal(got, large) {
+		t.Errorf("zero Compression Compress() = %d bytes, %v, want it unchanged", len(got), err)
+	}
+}
+
+// wholeCompressor has no streaming decompression.
+type wholeCompressor struct{ Compressor }
+
+func TestNewDecompressReader(t *testing.T) {
+	large := []byte(strings.Repeat("a large tool output ", 200))
+	for _, c := range []Compressor{Gzip, Zstd, wholeCompressor{Zstd}} {
+		t.Run(c.Name(), func(t *testing.T) {
+			compressed, err := c.Compress(large)
+			if err != nil {
+				t.Fatalf("Compress() error = %v", err)
+			}
+			r, err := NewDecompressReader(c, bytes.NewReader(compressed))
+			if err != nil {
+				t.Fatalf("NewDecompressReader() error = %v", err)
+			}
+			defer r.Close()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if !bytes.Equal(got, large) {
+				t.Errorf("ReadAll() = %d bytes, want the %d bytes compressed", len(got), len(large))
+			}
+		})
+	}
+}
+
+func TestDecode_Compressed(t *testing.T) {
+	want := map[string]any{"output": strings.Repeat("x", 4096)}
+	for _, c := range []Codec{JSON, MessagePack} {
+		data, err := Encode(c, want)
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		if data, err = (Compression{Compressor: Zstd}).Compress(data); err != nil {
+			t.Fatalf("Compress() error = %v", err)
+		}
+		var got map[string]any
+		if err := Decode(data, &got); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if got["output"] != want["output"] {
+			t.Errorf("Decode() with codec %s did not round trip", c.Name())
+		}
+	}
+
+	if _, err := Decompress([]byte(compressedMagic + "\x03lz4...")); !errors.Is(err, ErrUnknownCodec) {
+		t.Errorf("Decompress() of an unknown compressor error = %v, want %v", err, ErrUnknownCodec)
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// msgpackCodec is the MessagePack codec, see https://msgpack.org. It
+// encodes the JSON data model only: the values are first encoded as JSON.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string { return "msgpack" }
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	value, err := toJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return appendMsgpack(nil, value)
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	value, rest, err := readMsgpack(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("%d trailing bytes after the msgpack value", len(rest))
+	}
+	return fromJSONValue(value, v)
+}
+
+// appendMsgpack appends the MessagePack encoding of the JSON data model
+// value to b. The keys of the maps are sorted, so the encoding is
+// deterministic.
+func appendMsgpack(b []byte, value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if v {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return appendMsgpackInt(b, i), nil
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return binary.BigEndian.AppendUint64(append(b, 0xcf), u), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(f)), nil
+	case string:
+		return appendMsgpackString(b, v), nil
+	case []any:
+		b = appendMsgpackLen(b, len(v), 0x90, 16, 0xdc, 0xdd)
+		for _, elem := range v {
+			var err error
+			if b, err = appendMsgpack(b, elem); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]any:
+		b = appendMsgpackLen(b, len(v), 0x80, 16, 0xde, 0xdf)
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			b = appendMsgpackString(b, key)
+			var err error
+			if b, err = appendMsgpack(b, v[key]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+func appendMsgpackInt(b []byte, i int64) []byte {
+	switch {
+	case i >= 0 && i <= 0x7f, i < 0 && i >= -32:
+		return append(b, byte(i))
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		return append(b, 0xd0, byte(i))
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(i))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(i))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(i))
+	}
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+// appendMsgpackLen appends the header of an array or a map of n elements:
+// fix|n if n < fixMax, else the 16 or 32 bits length.
+func appendMsgpackLen(b []byte, n int, fix byte, fixMax int, code16, code32 byte) []byte {
+	switch {
+	case n < fixMax:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, code16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, code32), uint32(n))
+	}
+}
+
+var errMsgpackTruncated = errors.New("truncated msgpack value")
+
+// readMsgpack decodes the MessagePack value at the start of b into its JSON
+// data model and returns the bytes following it. Binary values are decoded
+// as strings and extension values are rejected.
+func readMsgpack(b []byte) (any, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errMsgpackTruncated
+	}
+	code, b := b[0], b[1:]
+	switch {
+	case code <= 0x7f:
+		return int64(code), b, nil
+	case code >= 0xe0:
+		return int64(int8(code)), b, nil
+	case code&0xf0 == 0x80:
+		return readMsgpackMap(b, int(code&0x0f))
+	case code&0xf0 == 0x90:
+		return readMsgpackArray(b, int(code&0x0f))
+	case code&0xe0 == 0xa0:
+		return readMsgpackString(b, int(code&0x1f))
+	}
+	switch code {
+	case 0xc0:
+		return nil, b, nil
+	case 0xc2:
+		return false, b, nil
+	case 0xc3:
+		return true, b, nil
+	case 0xc4, 0xd9:
+		n, b, err := readMsgpackUint(b, 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackString(b, int(n))
+	case 0xc5, 0xda:
+		n, b, err := readMsgpackUint(b, 2)
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackString(b, int(n))
+	case 0xc6, 0xdb:
+		n, b, err := readMsgpackUint(b, 4)
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackString(b, int(n))
+	case 0xca:
+		n, b, err := readMsgpackUint(b, 4)
+		if err != nil {
+			return nil, nil, err
+		}
+		return float64(math.Float32frombits(uint32(n))), b, nil
+	case 0xcb:
+		n, b, err := readMsgpackUint(b, 8)
+		if err != nil {
+			return nil, nil, err
+		}
+		return math.Float64frombits(n), b, nil
+	case 0xcc, 0xcd, 0xce:
+		n, b, err := readMsgpackUint(b, 1<<(code-0xcc))
+		if err != nil {
+			return nil, nil, err
+		}
+		return int64(n), b, nil
+	case 0xcf:
+		n, b, err := readMsgpackUint(b, 8)
+		if err != nil {
+			return nil, nil, err
+		}
+		return n, b, nil
+	case 0xd0:
+		n, b, err := readMsgpackUint(b, 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return int64(int8(n)), b, nil
+	case 0xd1:
+		n, b, err := readMsgpackUint(b, 2)
+		if err != nil {
+			return nil, nil, err
+		}
+		return int64(int16(n)), b, nil
+	case 0xd2:
+		n, b, err := readMsgpackUint(b, 4)
+		if err != nil {
+			return nil, nil, err
+		}
+		return int64(int32(n)), b, nil
+	case 0xd3:
+		n, b, err := readMsgpackUint(b, 8)
+		if err != nil {
+			return nil, nil, err
+		}
+		return int64(n), b, nil
+	case 0xdc, 0xdd:
+		n, b, err := readMsgpackUint(b, 2<<(code-0xdc))
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackArray(b, int(n))
+	case 0xde, 0xdf:
+		n, b, err := readMsgpackUint(b, 2<<(code-0xde))
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackMap(b, int(n))
+	}
+	return nil, nil, fmt.Errorf("unsupported msgpack type 0x%02x", code)
+}
+
+// readMsgpackUint reads the big-endian unsigned integer of size bytes at
+// the start of b.
+func readMsgpackUint(b []byte, size int) (uint64, []byte, error) {
+	if len(b) < size {
+		return 0, nil, errMsgpackTruncated
+	}
+	var n uint64
+	for _, c := range b[:size] {
+		n = n<<8 | uint64(c)
+	}
+	return n, b[size:], nil
+}
+
+func readMsgpackString(b []byte, n int) (any, []byte, error) {
+	if len(b) < n {
+		return nil, nil, errMsgpackTruncated
+	}
+	return string(b[:n]), b[n:], nil
+}
+
+func readMsgpackArray(b []byte, n int) (any, []byte, error) {
+	// Each element takes at least a byte: do not trust larger lengths.
+	if len(b) < n {
+		return nil, nil, errMsgpackTruncated
+	}
+	array := make([]any, n)
+	for i := range array {
+		var err error
+		if array[i], b, err = readMsgpack(b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return array, b, nil
+}
+
+func readMsgpackMap(b []byte, n int) (any, []byte, error) {
+	if len(b) < 2*n {
+		return nil, nil, errMsgpackTruncated
+	}
+	m := make(map[string]any, n)
+	for range n {
+		key, rest, err := readMsgpack(b)
+		if err != nil {
+			return nil, nil, err
+		}
+		s, ok := key.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("unsupported msgpack map key of type %T", key)
+		}
+		if m[s], b, err = readMsgpack(rest); err != nil {
+			return nil, nil, err
+		}
+	}
+	return m, b, nil
+}
@@ -0,0 +1,347 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/genai"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"google.golang.org/adk/server/adkgrpc/runnerpb"
+	"google.golang.org/adk/session"
+)
+
+// protobufCodec encodes the session events as runnerpb.Event messages, the
+// events of the gRPC API. The fields of the event the message does not
+// have, e.g. the grounding metadata or the parts other than text, function
+// calls and responses and inline data, are encoded as JSON in the field
+// restField of the message. An event is decoded as from its JSON encoding:
+// the numbers of the maps of any values, e.g. of the state delta, are
+// float64, and the integers of the typed fields are exact.
+//
+// Only *session.Event values are encoded.
+type protobufCodec struct{}
+
+// restField is the number of the field of runnerpb.Event holding the JSON
+// encoding of the rest of the event. The message does not define it, so it
+// is kept as an unknown field.
+const restField protowire.Number = 1000
+
+// emptyRest is the JSON encoding of an event whose fields are all in the
+// message: it is not written.
+var emptyRest, _ = json.Marshal(&session.Event{})
+
+func (protobufCodec) Name() string { return "protobuf" }
+
+func (protobufCodec) Marshal(v any) ([]byte, error) {
+	e, ok := v.(*session.Event)
+	if !ok {
+		return nil, fmt.Errorf("cannot encode %T, only *session.Event", v)
+	}
+	event, rest, err := toPBEvent(e)
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	restData, err := json.Marshal(rest)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(restData, emptyRest) {
+		return data, nil
+	}
+	data = protowire.AppendTag(data, restField, protowire.BytesType)
+	return protowire.AppendBytes(data, restData), nil
+}
+
+func (protobufCodec) Unmarshal(data []byte, v any) error {
+	e, ok := v.(*session.Event)
+	if !ok {
+		return fmt.Errorf("cannot decode into %T, only *session.Event", v)
+	}
+	var event runnerpb.Event
+	if err := proto.Unmarshal(data, &event); err != nil {
+		return err
+	}
+	rest, err := restOf(&event)
+	if err != nil {
+		return err
+	}
+	*e = session.Event{}
+	if rest != nil {
+		if err := json.Unmarshal(rest, e); err != nil {
+			return fmt.Errorf("rest of the event: %w", err)
+		}
+	}
+	fromPBEvent(&event, e)
+	return nil
+}
+
+// restOf returns the JSON encoding of the rest of the event, nil if there is
+// none.
+func restOf(event *runnerpb.Event) ([]byte, error) {
+	unknown := event.ProtoReflect().GetUnknown()
+	for len(unknown) > 0 {
+		num, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		unknown = unknown[n:]
+		if num == restField && typ == protowire.BytesType {
+			rest, n := protowire.ConsumeBytes(unknown)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			return rest, nil
+		}
+		n = protowire.ConsumeFieldValue(num, typ, unknown)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		unknown = unknown[n:]
+	}
+	return nil, nil
+}
+
+// toPBEvent returns the message of the event and the rest of the event,
+// without the fields of the message.
+func toPBEvent(e *session.Event) (*runnerpb.Event, *session.Event, error) {
+	rest := &session.Event{
+		LLMResponse: e.LLMResponse,
+		Actions: session.EventActions{
+			RequestedToolConfirmations: e.Actions.RequestedToolConfirmations,
+			RequestedAuthConfigs:       e.Actions.RequestedAuthConfigs,
+		},
+	}
+	rest.Partial, rest.TurnComplete, rest.Interrupted = false, false, false
+	rest.ErrorCode, rest.ErrorMessage = "", ""
+
+	event := &runnerpb.Event{
+		Id:                 e.ID,
+		InvocationId:       e.InvocationID,
+		Author:             e.Author,
+		Branch:             e.Branch,
+		Partial:            e.Partial,
+		TurnComplete:       e.TurnComplete,
+		Interrupted:        e.Interrupted,
+		ErrorCode:          e.ErrorCode,
+		ErrorMessage:       e.ErrorMessage,
+		LongRunningToolIds: e.LongRunningToolIDs,
+		Actions: &runnerpb.EventActions{
+			ArtifactDelta:     e.Actions.ArtifactDelta,
+			TransferToAgent:   e.Actions.TransferToAgent,
+			Escalate:          e.Actions.Escalate,
+			SkipSummarization: e.Actions.SkipSummarization,
+		},
+	}
+	if !e.Timestamp.IsZero() {
+		event.Timestamp = timestamppb.New(e.Timestamp)
+	}
+	if content, ok, err := toPBContent(e.Content); err != nil {
+		return nil, nil, fmt.Errorf("content: %w", err)
+	} else if ok {
+		event.Content = content
+		rest.Content = nil
+	}
+	if u := e.UsageMetadata; u != nil && reflect.DeepEqual(u, &genai.GenerateContentResponseUsageMetadata{
+		PromptTokenCount:     u.PromptTokenCount,
+		CandidatesTokenCount: u.CandidatesTokenCount,
+		TotalTokenCount:      u.TotalTokenCount,
+	}) {
+		event.UsageMetadata = &runnerpb.UsageMetadata{
+			PromptTokenCount:     u.PromptTokenCount,
+			CandidatesTokenCount: u.CandidatesTokenCount,
+			TotalTokenCount:      u.TotalTokenCount,
+		}
+		rest.UsageMetadata = nil
+	}
+	var err error
+	if event.CustomMetadata, err = toStruct(e.CustomMetadata); err != nil {
+		return nil, nil, fmt.Errorf("custom metadata: %w", err)
+	}
+	rest.CustomMetadata = nil
+	if event.Actions.StateDelta, err = toStruct(e.Actions.StateDelta); err != nil {
+		return nil, nil, fmt.Errorf("state delta: %w", err)
+	}
+	return event, rest, nil
+}
+
+// fromPBEvent sets the fields of e held by the message.
+func fromPBEvent(event *runnerpb.Event, e *session.Event) {
+	e.ID = event.GetId()
+	e.InvocationID = event.GetInvocationId()
+	e.Author = event.GetAuthor()
+	e.Branch = event.GetBranch()
+	e.Partial = event.GetPartial()
+	e.TurnComplete = event.GetTurnComplete()
+	e.Interrupted = event.GetInterrupted()
+	e.ErrorCode = event.GetErrorCode()
+	e.ErrorMessage = event.GetErrorMessage()
+	e.LongRunningToolIDs = event.GetLongRunningToolIds()
+	if event.Timestamp != nil {
+		e.Timestamp = event.GetTimestamp().AsTime()
+	}
+	if event.Content != nil {
+		e.Content = fromPBContent(event.GetContent())
+	}
+	if u := event.GetUsageMetadata(); u != nil {
+		e.UsageMetadata = &genai.GenerateContentResponseUsageMetadata{
+			PromptTokenCount:     u.GetPromptTokenCount(),
+			CandidatesTokenCount: u.GetCandidatesTokenCount(),
+			TotalTokenCount:      u.GetTotalTokenCount(),
+		}
+	}
+	e.CustomMetadata = fromStruct(event.GetCustomMetadata())
+	actions := event.GetActions()
+	e.Actions.StateDelta = fromStruct(actions.GetStateDelta())
+	if len(actions.GetArtifactDelta()) > 0 {
+		e.Actions.ArtifactDelta = actions.GetArtifactDelta()
+	}
+	e.Actions.TransferToAgent = actions.GetTransferToAgent()
+	e.Actions.Escalate = actions.GetEscalate()
+	e.Actions.SkipSummarization = actions.GetSkipSummarization()
+}
+
+// toPBContent returns the message of the content, and false if it has
+// parts the message cannot hold.
+func toPBContent(c *genai.Content) (*runnerpb.Content, bool, error) {
+	if c == nil {
+		return nil, false, nil
+	}
+	content := &runnerpb.Content{Role: c.Role}
+	for _, p := range c.Parts {
+		part, ok, err := toPBPart(p)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		content.Parts = append(content.Parts, part)
+	}
+	return content, true, nil
+}
+
+// toPBPart returns the message of the part, and false if the part has
+// fields the message does not have.
+func toPBPart(p *genai.Part) (*runnerpb.Part, bool, error) {
+	if p == nil {
+		return nil, false, nil
+	}
+	part := &runnerpb.Part{Thought: p.Thought}
+	switch {
+	case p.Text != "" && reflect.DeepEqual(p, &genai.Part{Text: p.Text, Thought: p.Thought}):
+		part.Data = &runnerpb.Part_Text{Text: p.Text}
+	case p.FunctionCall != nil && reflect.DeepEqual(p, &genai.Part{FunctionCall: &genai.FunctionCall{
+		ID: p.FunctionCall.ID, Name: p.FunctionCall.Name, Args: p.FunctionCall.Args,
+	}, Thought: p.Thought}):
+		args, err := toStruct(p.FunctionCall.Args)
+		if err != nil {
+			return nil, false, fmt.Errorf("function call %q: %w", p.FunctionCall.Name, err)
+		}
+		part.Data = &runnerpb.Part_FunctionCall{FunctionCall: &runnerpb.FunctionCall{
+			Id:   p.FunctionCall.ID,
+			Name: p.FunctionCall.Name,
+			Args: args,
+		}}
+	case p.FunctionResponse != nil && reflect.DeepEqual(p, &genai.Part{FunctionResponse: &genai.FunctionResponse{
+		ID: p.FunctionResponse.ID, Name: p.FunctionResponse.Name, Response: p.FunctionResponse.Response,
+	}, Thought: p.Thought}):
+		response, err := toStruct(p.FunctionResponse.Response)
+		if err != nil {
+			return nil, false, fmt.Errorf("function response %q: %w", p.FunctionResponse.Name, err)
+		}
+		part.Data = &runnerpb.Part_FunctionResponse{FunctionResponse: &runnerpb.FunctionResponse{
+			Id:       p.FunctionResponse.ID,
+			Name:     p.FunctionResponse.Name,
+			Response: response,
+		}}
+	case p.InlineData != nil && reflect.DeepEqual(p, &genai.Part{InlineData: &genai.Blob{
+		MIMEType: p.InlineData.MIMEType, Data: p.InlineData.Data,
+	}, Thought: p.Thought}):
+		part.Data = &runnerpb.Part_InlineData{InlineData: &runnerpb.Blob{
+			MimeType: p.InlineData.MIMEType,
+			Data:     p.InlineData.Data,
+		}}
+	default:
+		return nil, false, nil
+	}
+	return part, true, nil
+}
+
+// fromPBContent returns the content of the message.
+func fromPBContent(c *runnerpb.Content) *genai.Content {
+	content := &genai.Content{Role: c.GetRole()}
+	for _, p := range c.GetParts() {
+		part := &genai.Part{Thought: p.GetThought()}
+		switch data := p.GetData().(type) {
+		case *runnerpb.Part_Text:
+			part.Text = data.Text
+		case *runnerpb.Part_FunctionCall:
+			part.FunctionCall = &genai.FunctionCall{
+				ID:   data.FunctionCall.GetId(),
+				Name: data.FunctionCall.GetName(),
+				Args: fromStruct(data.FunctionCall.GetArgs()),
+			}
+		case *runnerpb.Part_FunctionResponse:
+			part.FunctionResponse = &genai.FunctionResponse{
+				ID:       data.FunctionResponse.GetId(),
+				Name:     data.FunctionResponse.GetName(),
+				Response: fromStruct(data.FunctionResponse.GetResponse()),
+			}
+		case *runnerpb.Part_InlineData:
+			part.InlineData = &genai.Blob{
+				MIMEType: data.InlineData.GetMimeType(),
+				Data:     data.InlineData.GetData(),
+			}
+		}
+		content.Parts = append(content.Parts, part)
+	}
+	return content
+}
+
+// toStruct converts a map to a Struct through its JSON encoding, so the
+// values are decoded as from JSON, e.g. the numbers as float64. An empty
+// map, omitted by the JSON encoding, is nil.
+func toStruct(m map[string]any) (*structpb.Struct, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// fromStruct returns the map of the Struct, nil if it is nil.
+func fromStruct(s *structpb.Struct) map[string]any {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool/toolconfirmation"
+)
+
+func testEvent() *session.Event {
+	return &session.Event{
+		ID:           "event",
+		Timestamp:    time.Date(2026, 1, 2, 3, 4, 5, 6000, time.FixedZone("CET", 3600)),
+		InvocationID: "invocation",
+		Branch:       "root.sub",
+		Author:       "sub",
+		LLMResponse: model.LLMResponse{
+			Content: genai.NewContentFromParts([]*genai.Part{
+				genai.NewPartFromText("Let me check the weather."),
+				genai.NewPartFromFunctionCall("get_weather", map[string]any{"city": "Paris", "days": 3}),
+			}, genai.RoleModel),
+			UsageMetadata: &genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 120, CandidatesTokenCount: 30, TotalTokenCount: 150},
+			TurnComplete:  true,
+		},
+		Actions: session.EventActions{
+			StateDelta:    map[string]any{"city": "Paris", "count": 2},
+			ArtifactDelta: map[string]int64{"report.txt": 1},
+		},
+	}
+}
+
+func TestProtobuf_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		event func(*session.Event)
+	}{
+		{name: "typed fields", event: func(*session.Event) {}},
+		{name: "empty", event: func(e *session.Event) { *e = session.Event{} }},
+		{name: "function response and inline data", event: func(e *session.Event) {
+			e.Content = genai.NewContentFromParts([]*genai.Part{
+				genai.NewPartFromFunctionResponse("get_weather", map[string]any{"temp": -1.5, "sky": nil, "hours": []any{1, 2}}),
+				genai.NewPartFromBytes([]byte{0, 1, 2, 255}, "image/png"),
+				{Text: "thinking", Thought: true},
+			}, genai.RoleUser)
+		}},
+		{name: "parts the message does not have", event: func(e *session.Event) {
+			e.Content.Parts = append(e.Content.Parts,
+				genai.NewPartFromURI("gs://bucket/file.pdf", "application/pdf"),
+				&genai.Part{Text: "signed", ThoughtSignature: []byte("signature")})
+		}},
+		{name: "fields the message does not have", event: func(e *session.Event) {
+			e.GroundingMetadata = &genai.GroundingMetadata{WebSearchQueries: []string{"weather paris"}}
+			e.UsageMetadata.ThoughtsTokenCount = 12
+			e.FinishReason = genai.FinishReasonStop
+			e.CustomMetadata = map[string]any{"trace": "abc"}
+			e.Actions.RequestedToolConfirmations = map[string]toolconfirmation.ToolConfirmation{"call": {Hint: "confirm?"}}
+			e.Actions.TransferToAgent = "other"
+			e.Actions.Escalate = true
+			e.Partial = true
+			e.ErrorCode = "code"
+			e.ErrorMessage = "message"
+			e.LongRunningToolIDs = []string{"call"}
+		}},
+		{name: "empty maps", event: func(e *session.Event) {
+			e.CustomMetadata = map[string]any{}
+			e.Actions.StateDelta = map[string]any{}
+			e.Actions.ArtifactDelta = map[string]int64{}
+			e.Content.Parts[1].FunctionCall.Args = map[string]any{}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := testEvent()
+			tt.event(event)
+
+			// The events are decoded as from their JSON encoding.
+			jsonData, err := Encode(JSON, event)
+			if err != nil {
+				t.Fatalf("Encode(JSON) error = %v", err)
+			}
+			var want session.Event
+			if err := Decode(jsonData, &want); err != nil {
+				t.Fatalf("Decode(JSON) error = %v", err)
+			}
+			data, err := Encode(Protobuf, event)
+			if err != nil {
+				t.Fatalf("Encode(Protobuf) error = %v", err)
+			}
+			var got session.Event
+			if err := Decode(data, &got); err != nil {
+				t.Fatalf("Decode(Protobuf) error = %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("round trip mismatch (-json +protobuf):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProtobuf_LargeIntegers(t *testing.T) {
+	event := testEvent()
+	event.Actions.ArtifactDelta = map[string]int64{"max": math.MaxInt64, "unsafe": 1<<53 + 1}
+	event.UsageMetadata = &genai.GenerateContentResponseUsageMetadata{TotalTokenCount: math.MaxInt32}
+	data, err := Encode(Protobuf, event)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var got session.Event
+	if err := Decode(data, &got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if diff := cmp.Diff(event.Actions.ArtifactDelta, got.Actions.ArtifactDelta); diff != "" {
+		t.Errorf("artifact delta mismatch (-want +got):\n%s", diff)
+	}
+	if got.UsageMetadata.TotalTokenCount != math.MaxInt32 {
+		t.Errorf("total token count = %d, want %d", got.UsageMetadata.TotalTokenCount, math.MaxInt32)
+	}
+}
+
+func TestProtobuf_Smaller(t *testing.T) {
+	withData := testEvent()
+	withData.Content.Parts = append(withData.Content.Parts, genai.NewPartFromBytes(bytes.Repeat([]byte{0xff}, 3000), "image/png"))
+	for name, event := range map[string]*session.Event{"typed fields": testEvent(), "inline data": withData} {
+		jsonData, err := Encode(JSON, event)
+		if err != nil {
+			t.Fatalf("Encode(JSON) error = %v", err)
+		}
+		data, err := Encode(Protobuf, event)
+		if err != nil {
+			t.Fatalf("Encode(Protobuf) error = %v", err)
+		}
+		if len(data) >= len(jsonData) {
+			t.Errorf("%s: Encode(Protobuf) = %d bytes, want fewer than the %d bytes of JSON", name, len(data), len(jsonData))
+		}
+	}
+}
+
+func TestProtobuf_OnlyEvents(t *testing.T) {
+	if _, err := Encode(Protobuf, map[string]any{"k": "v"}); err == nil {
+		t.Error("Encode(map) error = nil, want an error")
+	}
+	data, err := Encode(Protobuf, testEvent())
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	var m map[string]any
+	if err := Decode(data, &m); err == nil {
+		t.Error("Decode(map) error = nil, want an error")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// Progress is the progress of an eval run.
+type Progress struct {
+	// Completed is the number of completed eval cases, out of Total.
+	Completed int
+	Total     int
+	// Case is the result of the last completed eval case.
+	Case *CaseResult
+	// Resumed is set if the result was read from the checkpoint.
+	Resumed bool
+}
+
+// ProgressPrinter returns a [Config.Progress] function writing a line per
+// completed eval case to w.
+func ProgressPrinter(w io.Writer) func(Progress) {
+	return func(p Progress) {
+		suffix := ""
+		if p.Resumed {
+			suffix = " (from checkpoint)"
+		} else if p.Case.Error != "" {
+			suffix = ": " + p.Case.Error
+		}
+		fmt.Fprintf(w, "[%d/%d] %s %s%s\n", p.Completed, p.Total, p.Case.EvalID, p.Case.Status, suffix)
+	}
+}
+
+type progressTracker struct {
+	mu        sync.Mutex
+	report    func(Progress)
+	total     int
+	completed int
+}
+
+func (t *progressTracker) done(result *CaseResult, resumed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.completed++
+	if t.report != nil {
+		t.report(Progress{Completed: t.completed, Total: t.total, Case: result, Resumed: resumed})
+	}
+}
+
+// checkpointEntry is a line of the checkpoint file.
+type checkpointEntry struct {
+	EvalSetID string      `json:"eval_set_id"`
+	Result    *CaseResult `json:"result"`
+}
+
+// checkpoint is a JSON lines file with the results of the completed eval
+// cases. A nil checkpoint records nothing.
+type checkpoint struct {
+	mu        sync.Mutex
+	f         *os.File
+	evalSetID string
+	results   map[string]*CaseResult
+}
+
+func openCheckpoint(path, evalSetID string) (*checkpoint, error) {
+	c := &checkpoint{evalSetID: evalSetID, results: make(map[string]*CaseResult)}
+	if err := c.load(path); err != nil {
+		return nil, fmt.Errorf("failed to read the checkpoint: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the checkpoint: %w", err)
+	}
+	c.f = f
+	return c, nil
+}
+
+func (c *checkpoint) load(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64<<20)
+	for scanner.Scan() {
+		var entry checkpointEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// The last line is truncated if the run was killed while
+			// writing it.
+			continue
+		}
+		if entry.EvalSetID != c.evalSetID {
+			return fmt.Errorf("the checkpoint is for eval set %q, not %q", entry.EvalSetID, c.evalSetID)
+		}
+		if entry.Result != nil && entry.Result.Status != StatusNotEvaluated {
+			c.results[entry.Result.EvalID] = entry.Result
+		}
+	}
+	return scanner.Err()
+}
+
+// result returns the recorded result of the eval case, or nil if it has to
+// be run.
+func (c *checkpoint) result(evalID string) *CaseResult {
+	if c == nil {
+		return nil
+	}
+	return c.results[evalID]
+}
+
+func (c *checkpoint) record(result *CaseResult) error {
+	if c == nil {
+		return nil
+	}
+	b, err := json.Marshal(checkpointEntry{EvalSetID: c.evalSetID, Result: result})
+	if err != nil {
+		return fmt.Errorf("failed to encode the checkpoint: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to write the checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (c *checkpoint) close() {
+	_ = c.f.Close()
+}
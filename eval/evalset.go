@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eval evaluates agents against eval sets.
+//
+// An eval set is a list of eval cases, each a conversation with the
+// expected tool calls and final responses of the agent. The eval sets are
+// stored in .evalset.json files compatible with adk-python. [Run] replays the
+// user messages of every eval case against the agent and scores the actual
+// invocations with the [Evaluator] of each [Metric].
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// EvalSet is a set of eval cases.
+type EvalSet struct {
+	EvalSetID         string      `json:"evalSetId"`
+	Name              string      `json:"name,omitempty"`
+	Description       string      `json:"description,omitempty"`
+	EvalCases         []*EvalCase `json:"evalCases"`
+	CreationTimestamp float64     `json:"creationTimestamp,omitempty"`
+}
+
+// EvalCase is a conversation with the expected behavior of the agent.
+type EvalCase struct {
+	EvalID string `json:"evalId"`
+	// Conversation is the expected invocations of the agent, one per user
+	// message.
+	Conversation []*Invocation `json:"conversation"`
+	// SessionInput is the session the conversation starts in.
+	SessionInput      *SessionInput `json:"sessionInput,omitempty"`
+	CreationTimestamp float64       `json:"creationTimestamp,omitempty"`
+}
+
+// Invocation is a user message and the response of the agent to it.
+type Invocation struct {
+	InvocationID  string            `json:"invocationId,omitempty"`
+	UserContent   *genai.Content    `json:"userContent"`
+	FinalResponse *genai.Content    `json:"finalResponse,omitempty"`
+	Intermediate  *IntermediateData `json:"intermediateData,omitempty"`
+	// CreationTimestamp is in seconds since the Unix epoch.
+	CreationTimestamp float64 `json:"creationTimestamp,omitempty"`
+}
+
+// ToolUses returns the tool calls of the invocation.
+func (inv *Invocation) ToolUses() []*genai.FunctionCall {
+	if inv.Intermediate == nil {
+		return nil
+	}
+	return inv.Intermediate.ToolUses
+}
+
+// IntermediateData is what happened between the user message and the final
+// response.
+type IntermediateData struct {
+	ToolUses []*genai.FunctionCall `json:"toolUses"`
+	// ToolResponses are the responses of the tool calls.
+	ToolResponses []*genai.FunctionResponse `json:"toolResponses,omitempty"`
+	// IntermediateResponses are the texts of the sub-agents before the
+	// final response, as (author, parts) pairs.
+	IntermediateResponses []IntermediateResponse `json:"intermediateResponses"`
+}
+
+// IntermediateResponse is a response of an agent before the final response.
+// It is encoded as an [author, parts] pair, as in adk-python.
+type IntermediateResponse struct {
+	Author string
+	Parts  []*genai.Part
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r IntermediateResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{r.Author, r.Parts})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *IntermediateResponse) UnmarshalJSON(data []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("intermediate response must be an [author, parts] pair, got %d elements", len(pair))
+	}
+	if err := json.Unmarshal(pair[0], &r.Author); err != nil {
+		return err
+	}
+	return json.Unmarshal(pair[1], &r.Parts)
+}
+
+// SessionInput is the initial session of an eval case.
+type SessionInput struct {
+	AppName string         `json:"appName"`
+	UserID  string         `json:"userId"`
+	State   map[string]any `json:"state,omitempty"`
+}
+
+// LoadEvalSet reads an eval set file.
+func LoadEvalSet(path string) (*EvalSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the eval set: %w", err)
+	}
+	set, err := ParseEvalSet(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the eval set %s: %w", path, err)
+	}
+	return set, nil
+}
+
+// ParseEvalSet decodes an eval set. As adk-python, it accepts both the
+// snake_case field names written by adk-python and the camelCase names.
+func ParseEvalSet(data []byte) (*EvalSet, error) {
+	var doc any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	normalized, err := json.Marshal(normalizeKeys(doc))
+	if err != nil {
+		return nil, err
+	}
+	var set EvalSet
+	if err := json.Unmarshal(normalized, &set); err != nil {
+		return nil, err
+	}
+	if set.EvalSetID == "" {
+		return nil, fmt.Errorf("evalSetId is required")
+	}
+	for i, c := range set.EvalCases {
+		if c == nil || c.EvalID == "" {
+			return nil, fmt.Errorf("eval case %d has no evalId", i)
+		}
+		for j, inv := range c.Conversation {
+			if inv == nil || inv.UserContent == nil {
+				return nil, fmt.Errorf("invocation %d of eval case %q has no userContent", j, c.EvalID)
+			}
+		}
+	}
+	return &set, nil
+}
+
+// payloadKeys hold user data whose keys must be kept as is.
+var payloadKeys = map[string]bool{"args": true, "response": true, "state": true}
+
+// normalizeKeys removes the underscores from the keys of the document, so
+// that the snake_case names match the camelCase field names, which
+// encoding/json matches case-insensitively.
+func normalizeKeys(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			key := strings.ReplaceAll(k, "_", "")
+			if payloadKeys[strings.ToLower(key)] {
+				m[key] = val
+			} else {
+				m[key] = normalizeKeys(val)
+			}
+		}
+		return m
+	case []any:
+		for i, val := range v {
+			v[i] = normalizeKeys(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
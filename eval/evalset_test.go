@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+func TestLoadEvalSet(t *testing.T) {
+	set, err := LoadEvalSet("testdata/weather.evalset.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &EvalSet{
+		EvalSetID: "weather",
+		Name:      "weather",
+		EvalCases: []*EvalCase{{
+			EvalID: "paris",
+			Conversation: []*Invocation{{
+				InvocationID:  "e-1",
+				UserContent:   genai.NewContentFromText("What is the weather in Paris?", genai.RoleUser),
+				FinalResponse: genai.NewContentFromText("It is sunny in Paris.", genai.RoleModel),
+				Intermediate: &IntermediateData{
+					ToolUses: []*genai.FunctionCall{{ID: "call-1", Name: "get_weather", Args: map[string]any{"city_name": "Paris"}}},
+					IntermediateResponses: []IntermediateResponse{
+						{Author: "weather_agent", Parts: []*genai.Part{{Text: "Let me check."}}},
+					},
+				},
+				CreationTimestamp: 1747330000,
+			}},
+			SessionInput: &SessionInput{
+				AppName: "weather_app",
+				UserID:  "user",
+				State:   map[string]any{"preferred_unit": "celsius"},
+			},
+			CreationTimestamp: 1747330000,
+		}},
+		CreationTimestamp: 1747330000,
+	}
+	if diff := cmp.Diff(want, set); diff != "" {
+		t.Errorf("LoadEvalSet() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The encoded set uses the camelCase names, also accepted by adk-python.
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := ParseEvalSet(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, roundTrip); diff != "" {
+		t.Errorf("ParseEvalSet(json.Marshal(set)) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseEvalSet_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not json", data: `{`},
+		{name: "no id", data: `{"eval_cases": []}`},
+		{name: "no eval id", data: `{"eval_set_id": "s", "eval_cases": [{"conversation": []}]}`},
+		{name: "no user content", data: `{"eval_set_id": "s", "eval_cases": [{"eval_id": "c", "conversation": [{}]}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseEvalSet([]byte(tt.data)); err == nil {
+				t.Error("ParseEvalSet() succeeded, want error")
+			}
+		})
+	}
+}
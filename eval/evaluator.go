@@ -0,0 +1,245 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// Names of the metrics of adk-python implemented by this package.
+const (
+	ToolTrajectoryAvgScore = "tool_trajectory_avg_score"
+	ResponseMatchScore     = "response_match_score"
+	FinalResponseMatchV2   = "final_response_match_v2"
+)
+
+// Evaluator scores the actual invocations of an eval case against the
+// expected ones. The invocations are paired by index.
+type Evaluator interface {
+	Evaluate(ctx context.Context, actual, expected []*Invocation) (*EvaluationResult, error)
+}
+
+// EvaluationResult is the score of an eval case for a metric.
+type EvaluationResult struct {
+	// OverallScore is the average of the invocation scores.
+	OverallScore float64 `json:"overall_score"`
+	// InvocationScores are the scores of each invocation, from 0 to 1.
+	InvocationScores []float64 `json:"invocation_scores"`
+	// CriterionScores are the average scores of each criterion, for the
+	// evaluators scoring several criteria.
+	CriterionScores map[string]float64 `json:"criterion_scores,omitempty"`
+}
+
+func averageScores(scores []float64) *EvaluationResult {
+	var sum float64
+	for _, score := range scores {
+		sum += score
+	}
+	result := &EvaluationResult{InvocationScores: scores}
+	if len(scores) > 0 {
+		result.OverallScore = sum / float64(len(scores))
+	}
+	return result
+}
+
+// Metric is an evaluator with the minimal score an eval case must get to
+// pass.
+type Metric struct {
+	Name      string
+	Threshold float64
+	Evaluator Evaluator
+}
+
+// DefaultMetrics are the metrics of adk-python when no criteria are
+// configured: an exact tool trajectory match and a response ROUGE-1 score of
+// at least 0.8.
+func DefaultMetrics() []Metric {
+	return []Metric{
+		{Name: ToolTrajectoryAvgScore, Threshold: 1, Evaluator: TrajectoryEvaluator(TrajectoryConfig{})},
+		{Name: ResponseMatchScore, Threshold: 0.8, Evaluator: ResponseEvaluator()},
+	}
+}
+
+// ResponseEvaluator returns an evaluator scoring the final responses with
+// the ROUGE-1 F-measure of their texts against the expected ones.
+func ResponseEvaluator() Evaluator {
+	return evaluatorFunc(func(_ context.Context, actual, expected []*Invocation) ([]float64, error) {
+		scores := make([]float64, len(actual))
+		for i := range actual {
+			scores[i] = rouge1(contentText(expected[i].FinalResponse), contentText(actual[i].FinalResponse))
+		}
+		return scores, nil
+	})
+}
+
+// rouge1 returns the ROUGE-1 F-measure of the candidate against the
+// reference. The texts are lowercased and split on non-alphanumeric
+// characters, without stemming.
+func rouge1(reference, candidate string) float64 {
+	refTokens, candTokens := tokenize(reference), tokenize(candidate)
+	if len(refTokens) == 0 || len(candTokens) == 0 {
+		if len(refTokens) == len(candTokens) {
+			return 1
+		}
+		return 0
+	}
+	counts := make(map[string]int, len(refTokens))
+	for _, token := range refTokens {
+		counts[token]++
+	}
+	overlap := 0
+	for _, token := range candTokens {
+		if counts[token] > 0 {
+			counts[token]--
+			overlap++
+		}
+	}
+	if overlap == 0 {
+		return 0
+	}
+	precision := float64(overlap) / float64(len(candTokens))
+	recall := float64(overlap) / float64(len(refTokens))
+	return 2 * precision * recall / (precision + recall)
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func contentText(content *genai.Content) string {
+	if content == nil {
+		return ""
+	}
+	var texts []string
+	for _, part := range content.Parts {
+		if part.Text != "" && !part.Thought {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// LLMJudgeConfig configures the LLM-as-judge evaluator.
+type LLMJudgeConfig struct {
+	// Judge is the model judging the responses.
+	Judge model.LLM
+	// NumSamples is the number of times each response is judged, the
+	// majority label wins. Defaults to 5.
+	NumSamples int
+}
+
+// LLMJudgeEvaluator returns an evaluator asking the judge model whether the
+// final responses are valid given the user message and the expected
+// response. An invocation scores 1 if the majority of the samples judge its
+// response valid, and 0 otherwise.
+func LLMJudgeEvaluator(cfg LLMJudgeConfig) (Evaluator, error) {
+	if cfg.Judge == nil {
+		return nil, fmt.Errorf("judge model is required")
+	}
+	if cfg.NumSamples <= 0 {
+		cfg.NumSamples = 5
+	}
+	return evaluatorFunc(func(ctx context.Context, actual, expected []*Invocation) ([]float64, error) {
+		scores := make([]float64, len(actual))
+		for i := range actual {
+			valid := 0
+			for range cfg.NumSamples {
+				ok, err := judge(ctx, cfg.Judge, actual[i], expected[i])
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					valid++
+				}
+			}
+			if 2*valid > cfg.NumSamples {
+				scores[i] = 1
+			}
+		}
+		return scores, nil
+	}), nil
+}
+
+const judgePrompt = `You are an expert rater for an AI agent. Given the user prompt, the
+agent response and a reference response, decide whether the agent response
+is valid: it must answer the user prompt with the same information as the
+reference response. Wording, formatting and additional details that do not
+contradict the reference do not matter.
+
+User prompt:
+%s
+
+Agent response:
+%s
+
+Reference response:
+%s
+
+Explain your reasoning, then end your answer with a last line being exactly
+"is_the_agent_response_valid: valid" or "is_the_agent_response_valid: invalid".`
+
+func judge(ctx context.Context, llm model.LLM, actual, expected *Invocation) (bool, error) {
+	prompt := fmt.Sprintf(judgePrompt, contentText(expected.UserContent), contentText(actual.FinalResponse), contentText(expected.FinalResponse))
+	text, err := askJudge(ctx, llm, prompt)
+	if err != nil {
+		return false, err
+	}
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	verdict := strings.ToLower(lines[len(lines)-1])
+	_, label, found := strings.Cut(verdict, "is_the_agent_response_valid:")
+	if !found {
+		return false, fmt.Errorf("judge model returned no verdict: %q", text)
+	}
+	return strings.TrimSpace(label) == "valid", nil
+}
+
+// askJudge sends the prompt to the judge model and returns the text of its
+// answer.
+func askJudge(ctx context.Context, llm model.LLM, prompt string) (string, error) {
+	req := &model.LLMRequest{
+		Model:    llm.Name(),
+		Contents: []*genai.Content{genai.NewContentFromText(prompt, genai.RoleUser)},
+	}
+	var text strings.Builder
+	for resp, err := range llm.GenerateContent(ctx, req, false) {
+		if err != nil {
+			return "", fmt.Errorf("failed to call the judge model: %w", err)
+		}
+		text.WriteString(contentText(resp.Content))
+	}
+	return text.String(), nil
+}
+
+type evaluatorFunc func(ctx context.Context, actual, expected []*Invocation) ([]float64, error)
+
+func (f evaluatorFunc) Evaluate(ctx context.Context, actual, expected []*Invocation) (*EvaluationResult, error) {
+	if len(actual) != len(expected) {
+		return nil, fmt.Errorf("got %d actual invocations for %d expected ones", len(actual), len(expected))
+	}
+	scores, err := f(ctx, actual, expected)
+	if err != nil {
+		return nil, err
+	}
+	return averageScores(scores), nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/testutil"
+)
+
+func invocation(response string, calls ...*genai.FunctionCall) *Invocation {
+	return &Invocation{
+		UserContent:   genai.NewContentFromText("What is the weather in Paris?", genai.RoleUser),
+		FinalResponse: genai.NewContentFromText(response, genai.RoleModel),
+		Intermediate:  &IntermediateData{ToolUses: calls},
+	}
+}
+
+func TestResponseEvaluator(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		want     float64
+	}{
+		{name: "identical", expected: "It is sunny in Paris.", actual: "it is SUNNY in paris", want: 1},
+		{name: "disjoint", expected: "It is sunny.", actual: "No idea", want: 0},
+		// 3 common tokens, precision 3/4, recall 3/5.
+		{name: "partial", expected: "It is sunny in Paris", actual: "It is rainy in", want: 2 * 0.75 * 0.6 / 1.35},
+		{name: "both empty", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResponseEvaluator().Evaluate(t.Context(), []*Invocation{invocation(tt.actual)}, []*Invocation{invocation(tt.expected)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got.OverallScore-tt.want) > 1e-9 {
+				t.Errorf("OverallScore = %v, want %v", got.OverallScore, tt.want)
+			}
+		})
+	}
+}
+
+func TestLLMJudgeEvaluator(t *testing.T) {
+	verdict := func(label string) *genai.Content {
+		return genai.NewContentFromText("Both say it is sunny.\nis_the_agent_response_valid: "+label, genai.RoleModel)
+	}
+	judgeModel := &testutil.MockModel{Responses: []*genai.Content{
+		verdict("valid"), verdict("invalid"), verdict("valid"),
+		verdict("invalid"), verdict("invalid"), verdict("valid"),
+	}}
+	evaluator, err := LLMJudgeEvaluator(LLMJudgeConfig{Judge: judgeModel, NumSamples: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*Invocation{invocation("It is sunny in Paris."), invocation("It is sunny in Paris.")}
+	actual := []*Invocation{invocation("Sunny."), invocation("Rainy.")}
+	got, err := evaluator.Evaluate(t.Context(), actual, expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{1, 0}; !equalScores(got.InvocationScores, want) {
+		t.Errorf("InvocationScores = %v, want %v", got.InvocationScores, want)
+	}
+	if len(judgeModel.Requests) != 6 {
+		t.Errorf("judge model called %d times, want 6", len(judgeModel.Requests))
+	}
+
+	if _, err := LLMJudgeEvaluator(LLMJudgeConfig{}); err == nil {
+		t.Error("LLMJudgeEvaluator() without judge succeeded, want error")
+	}
+}
+
+func equalScores(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
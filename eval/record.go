@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/session"
+)
+
+// CaseFromSession records the conversation of a session as an eval case:
+// the user messages, with the tool calls and final responses of the agent as
+// the expected behavior. Invocations not started by a user message, e.g.
+// resumed with a function response, are skipped.
+//
+// The session input is the state the session started with, i.e. the state
+// keys not written by the events, except the temporary keys.
+func CaseFromSession(evalID string, sess session.Session) (*EvalCase, error) {
+	if evalID == "" {
+		return nil, fmt.Errorf("eval ID is required")
+	}
+	c := &EvalCase{
+		EvalID:            evalID,
+		CreationTimestamp: timestamp(time.Now()),
+		SessionInput: &SessionInput{
+			AppName: sess.AppName(),
+			UserID:  sess.UserID(),
+			State:   make(map[string]any),
+		},
+	}
+	written := make(map[string]bool)
+	byID := make(map[string]*Invocation)
+	for event := range sess.Events().All() {
+		for key := range event.Actions.StateDelta {
+			written[key] = true
+		}
+		inv, ok := byID[event.InvocationID]
+		if !ok {
+			if event.Author != "user" || event.Content == nil {
+				continue
+			}
+			inv = &Invocation{
+				InvocationID:      event.InvocationID,
+				UserContent:       event.Content,
+				Intermediate:      &IntermediateData{},
+				CreationTimestamp: timestamp(event.Timestamp),
+			}
+			byID[event.InvocationID] = inv
+			c.Conversation = append(c.Conversation, inv)
+			continue
+		}
+		addEvent(inv, event)
+	}
+	if len(c.Conversation) == 0 {
+		return nil, fmt.Errorf("session %s has no user message", sess.ID())
+	}
+	for key, value := range sess.State().All() {
+		if !written[key] && !strings.HasPrefix(key, session.KeyPrefixTemp) {
+			c.SessionInput.State[key] = value
+		}
+	}
+	return c, nil
+}
+
+func timestamp(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
+
+// AddCase adds the eval case to the set, replacing the case with the same
+// eval ID if replace is set.
+func (s *EvalSet) AddCase(c *EvalCase, replace bool) error {
+	for i, existing := range s.EvalCases {
+		if existing.EvalID != c.EvalID {
+			continue
+		}
+		if !replace {
+			return fmt.Errorf("eval case %q already exists in eval set %q", c.EvalID, s.EvalSetID)
+		}
+		s.EvalCases[i] = c
+		return nil
+	}
+	s.EvalCases = append(s.EvalCases, c)
+	return nil
+}
+
+// SaveEvalSet writes the eval set to a file. The file uses the camelCase
+// field names, which adk-python reads too.
+func SaveEvalSet(path string, set *EvalSet) error {
+	data, err := json.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the eval set: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write the eval set: %w", err)
+	}
+	return nil
+}
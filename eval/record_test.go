@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+func TestCaseFromSession(t *testing.T) {
+	ctx := t.Context()
+	sessionService := session.InMemoryService()
+	created, err := sessionService.Create(ctx, &session.CreateRequest{
+		AppName: "weather_app",
+		UserID:  "user",
+		State:   map[string]any{"preferred_unit": "celsius", "temp:scratch": 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := runner.New(runner.Config{
+		AppName:        "weather_app",
+		Agent:          newWeatherAgent(t, "Paris", "It is sunny in Paris."),
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := genai.NewContentFromText("What is the weather in Paris?", genai.RoleUser)
+	for _, err := range r.Run(ctx, "user", created.Session.ID(), msg, agent.RunConfig{}, runner.WithStateDelta(map[string]any{"visits": 1})) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := sessionService.Get(ctx, &session.GetRequest{AppName: "weather_app", UserID: "user", SessionID: created.Session.ID()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evalCase, err := CaseFromSession("paris", got.Session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&SessionInput{AppName: "weather_app", UserID: "user", State: map[string]any{"preferred_unit": "celsius"}}, evalCase.SessionInput); diff != "" {
+		t.Errorf("SessionInput mismatch (-want +got):\n%s", diff)
+	}
+	if len(evalCase.Conversation) != 1 {
+		t.Fatalf("got %d invocations, want 1", len(evalCase.Conversation))
+	}
+	inv := evalCase.Conversation[0]
+	if got := contentText(inv.UserContent); got != "What is the weather in Paris?" {
+		t.Errorf("UserContent = %q, want the user message", got)
+	}
+	if got := contentText(inv.FinalResponse); got != "It is sunny in Paris." {
+		t.Errorf("FinalResponse = %q, want the agent answer", got)
+	}
+	if got := inv.ToolUses(); len(got) != 1 || got[0].Name != "get_weather" {
+		t.Errorf("ToolUses = %v, want the get_weather call", got)
+	}
+
+	// The recorded case passes against the same agent.
+	set := &EvalSet{EvalSetID: "weather"}
+	if err := set.AddCase(evalCase, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := set.AddCase(evalCase, false); err == nil {
+		t.Error("AddCase() of a duplicate eval ID succeeded, want error")
+	}
+	if err := set.AddCase(evalCase, true); err != nil || len(set.EvalCases) != 1 {
+		t.Errorf("AddCase(replace) = %v with %d cases, want the case replaced", err, len(set.EvalCases))
+	}
+	path := filepath.Join(t.TempDir(), "weather.evalset.json")
+	if err := SaveEvalSet(path, set); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadEvalSet(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := Run(ctx, Config{Agent: newWeatherAgent(t, "Paris", "It is sunny in Paris.")}, loaded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Passed != 1 {
+		t.Errorf("recorded case result = %+v, want passed", report.Cases[0])
+	}
+}
+
+func TestCaseFromSession_NoUserMessage(t *testing.T) {
+	created, err := session.InMemoryService().Create(t.Context(), &session.CreateRequest{AppName: "app", UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CaseFromSession("empty", created.Session); err == nil {
+		t.Error("CaseFromSession() of an empty session succeeded, want error")
+	}
+}
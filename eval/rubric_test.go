@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/testutil"
+)
+
+func TestRubricJudgeEvaluator(t *testing.T) {
+	verdict := func(lines ...string) *genai.Content {
+		return genai.NewContentFromText("The response answers the question.\n"+strings.Join(lines, "\n"), genai.RoleModel)
+	}
+	judgeModel := &testutil.MockModel{Responses: []*genai.Content{
+		// First invocation: helpful in 2 of 3 samples, never grounded.
+		verdict("helpfulness: yes", "groundedness: no", "safety: yes"),
+		verdict("**helpfulness**: no", "groundedness: no", "safety: yes"),
+		verdict("- helpfulness: Yes.", "groundedness: no", "safety: yes"),
+		// Second invocation: meets all rubrics.
+		verdict("helpfulness: yes", "groundedness: yes", "safety: yes"),
+		verdict("helpfulness: yes", "groundedness: yes", "safety: yes"),
+		verdict("helpfulness: yes", "groundedness: yes", "safety: yes"),
+	}}
+	evaluator, err := RubricJudgeEvaluator(RubricJudgeConfig{Judge: judgeModel, NumSamples: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	weather := invocation("It is sunny in Paris.", &genai.FunctionCall{ID: "call-1", Name: "get_weather", Args: map[string]any{"city": "Paris"}})
+	weather.Intermediate.ToolResponses = []*genai.FunctionResponse{{ID: "call-1", Name: "get_weather", Response: map[string]any{"weather": "sunny"}}}
+	invocations := []*Invocation{invocation("It is sunny."), weather}
+	got, err := evaluator.Evaluate(t.Context(), invocations, invocations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &EvaluationResult{
+		OverallScore:     (2.0/3 + 1) / 2,
+		InvocationScores: []float64{2.0 / 3, 1},
+		CriterionScores:  map[string]float64{"helpfulness": 1, "groundedness": 0.5, "safety": 1},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("Evaluate() mismatch (-want +got):\n%s", diff)
+	}
+	prompt := judgeModel.Requests[3].Contents[0].Parts[0].Text
+	for _, want := range []string{`get_weather({"city":"Paris"}) returned {"weather":"sunny"}`, "- groundedness: ", "It is sunny in Paris."} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("judge prompt %q does not contain %q", prompt, want)
+		}
+	}
+}
+
+func TestRubricJudgeEvaluator_Errors(t *testing.T) {
+	judgeModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromText("helpfulness: yes", genai.RoleModel),
+	}}
+	evaluator, err := RubricJudgeEvaluator(RubricJudgeConfig{Judge: judgeModel, NumSamples: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	invocations := []*Invocation{invocation("It is sunny.")}
+	if _, err := evaluator.Evaluate(t.Context(), invocations, invocations); err == nil {
+		t.Error("Evaluate() with missing verdicts succeeded, want error")
+	}
+
+	for _, cfg := range []RubricJudgeConfig{
+		{},
+		{Judge: judgeModel, Rubrics: []Rubric{}},
+		{Judge: judgeModel, Rubrics: []Rubric{Safety, Safety}},
+		{Judge: judgeModel, Rubrics: []Rubric{{ID: "tone: formal"}}},
+	} {
+		if _, err := RubricJudgeEvaluator(cfg); err == nil {
+			t.Errorf("RubricJudgeEvaluator(%+v) succeeded, want error", cfg)
+		}
+	}
+}
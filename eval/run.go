@@ -0,0 +1,322 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+// Status is the outcome of an eval case or a metric.
+type Status string
+
+const (
+	StatusPassed Status = "PASSED"
+	StatusFailed Status = "FAILED"
+	// StatusNotEvaluated is the status of the eval cases whose run failed.
+	StatusNotEvaluated Status = "NOT_EVALUATED"
+)
+
+// Config configures [Run].
+type Config struct {
+	// Agent is the root agent evaluated.
+	Agent agent.Agent
+	// AppName of the sessions, if the eval case has no session input.
+	// Defaults to the agent name.
+	AppName string
+	// Metrics score the eval cases. Defaults to [DefaultMetrics].
+	Metrics []Metric
+	// PluginConfig configures the plugins of the runner.
+	PluginConfig runner.PluginConfig
+
+	// Parallelism is the number of eval cases run concurrently. Defaults to
+	// 1. Wrap the models of the agent and the judge models with
+	// [model.WithRateLimit] to keep the concurrent cases within their quota.
+	Parallelism int
+	// RateLimitRetries is the number of times an eval case failing on a rate
+	// limit error of a model is run again. Defaults to 3, negative disables
+	// the retries.
+	RateLimitRetries int
+	// RateLimitBackoff is the wait before the first retry of an eval case
+	// failing on a rate limit error, doubled at every retry. Defaults to 10s.
+	RateLimitBackoff time.Duration
+	// Progress is called after every completed eval case.
+	Progress func(Progress)
+	// CheckpointPath is a file recording the result of every completed eval
+	// case. If it exists, the eval cases it records as passed or failed are
+	// not run again, so an interrupted run is resumed where it stopped.
+	CheckpointPath string
+}
+
+// Report is the result of an eval set.
+type Report struct {
+	EvalSetID string        `json:"eval_set_id"`
+	Cases     []*CaseResult `json:"eval_case_results"`
+	// Passed is the number of eval cases that passed.
+	Passed int `json:"passed"`
+	// Failed is the number of eval cases that failed or could not be
+	// evaluated.
+	Failed int `json:"failed"`
+}
+
+// CaseResult is the result of an eval case.
+type CaseResult struct {
+	EvalID  string          `json:"eval_id"`
+	Status  Status          `json:"final_eval_status"`
+	Metrics []*MetricResult `json:"overall_eval_metric_results"`
+	// Invocations are the actual invocations of the agent.
+	Invocations []*Invocation `json:"invocations"`
+	SessionID   string        `json:"session_id"`
+	// Error is why the eval case could not be evaluated.
+	Error string `json:"error,omitempty"`
+}
+
+// MetricResult is the score of an eval case for a metric.
+type MetricResult struct {
+	Name             string    `json:"metric_name"`
+	Threshold        float64   `json:"threshold"`
+	Score            float64   `json:"score"`
+	InvocationScores []float64 `json:"invocation_scores"`
+	// CriterionScores is the breakdown of the score per criterion, for
+	// the metrics scoring several criteria.
+	CriterionScores map[string]float64 `json:"criterion_scores,omitempty"`
+	Status          Status             `json:"eval_status"`
+}
+
+// Run runs every eval case of the set against the agent, each in a new
+// session, and scores the actual invocations with the metrics. An eval case
+// passes if it reaches the threshold of every metric.
+//
+// The failures of the agent or the evaluators are reported in the eval case
+// results; Run only fails on an invalid configuration, a checkpoint error or
+// a cancelled context.
+func Run(ctx context.Context, cfg Config, set *EvalSet) (*Report, error) {
+	if cfg.Agent == nil {
+		return nil, fmt.Errorf("agent is required")
+	}
+	if cfg.AppName == "" {
+		cfg.AppName = cfg.Agent.Name()
+	}
+	if cfg.Metrics == nil {
+		cfg.Metrics = DefaultMetrics()
+	}
+	if cfg.Parallelism <= 0 {
+		cfg.Parallelism = 1
+	}
+	if cfg.RateLimitRetries == 0 {
+		cfg.RateLimitRetries = 3
+	}
+	if cfg.RateLimitBackoff <= 0 {
+		cfg.RateLimitBackoff = 10 * time.Second
+	}
+
+	results := make([]*CaseResult, len(set.EvalCases))
+	var cp *checkpoint
+	if cfg.CheckpointPath != "" {
+		var err error
+		cp, err = openCheckpoint(cfg.CheckpointPath, set.EvalSetID)
+		if err != nil {
+			return nil, err
+		}
+		defer cp.close()
+	}
+	progress := &progressTracker{report: cfg.Progress, total: len(set.EvalCases)}
+	for i, c := range set.EvalCases {
+		if result := cp.result(c.EvalID); result != nil {
+			results[i] = result
+			progress.done(result, true)
+		}
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(cfg.Parallelism)
+	for i, c := range set.EvalCases {
+		if results[i] != nil {
+			continue
+		}
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			result := runCaseWithRetries(gctx, cfg, c)
+			if err := gctx.Err(); err != nil {
+				// The case was interrupted, it is run again on resume.
+				return err
+			}
+			if err := cp.record(result); err != nil {
+				return err
+			}
+			results[i] = result
+			progress.done(result, false)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	report := &Report{EvalSetID: set.EvalSetID, Cases: results}
+	for _, result := range results {
+		if result.Status == StatusPassed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// runCaseWithRetries runs the eval case, retrying it with an exponential
+// backoff while it fails on the rate limits of the models.
+func runCaseWithRetries(ctx context.Context, cfg Config, c *EvalCase) *CaseResult {
+	backoff := cfg.RateLimitBackoff
+	for attempt := 0; ; attempt++ {
+		result, err := runCase(ctx, cfg, c)
+		if err == nil || !model.IsRateLimitError(err) || attempt >= cfg.RateLimitRetries {
+			return result
+		}
+		logging.FromContext(ctx).WarnContext(ctx, "Eval case hit a rate limit, retrying", "eval_id", c.EvalID, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return result
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// runCase runs and scores the eval case. It returns the error the case could
+// not be evaluated for, also reported in the result.
+func runCase(ctx context.Context, cfg Config, c *EvalCase) (*CaseResult, error) {
+	result := &CaseResult{EvalID: c.EvalID, Status: StatusNotEvaluated}
+	actual, sessionID, err := inferInvocations(ctx, cfg, c)
+	result.Invocations = actual
+	result.SessionID = sessionID
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	result.Status = StatusPassed
+	for _, metric := range cfg.Metrics {
+		scored, err := metric.Evaluator.Evaluate(ctx, actual, c.Conversation)
+		if err != nil {
+			result.Status = StatusNotEvaluated
+			result.Error = fmt.Sprintf("metric %s: %v", metric.Name, err)
+			return result, err
+		}
+		metricResult := &MetricResult{
+			Name:             metric.Name,
+			Threshold:        metric.Threshold,
+			Score:            scored.OverallScore,
+			InvocationScores: scored.InvocationScores,
+			CriterionScores:  scored.CriterionScores,
+			Status:           StatusPassed,
+		}
+		if scored.OverallScore < metric.Threshold {
+			metricResult.Status = StatusFailed
+			result.Status = StatusFailed
+		}
+		result.Metrics = append(result.Metrics, metricResult)
+	}
+	return result, nil
+}
+
+// inferInvocations replays the user messages of the eval case against the
+// agent and returns its actual invocations.
+func inferInvocations(ctx context.Context, cfg Config, c *EvalCase) ([]*Invocation, string, error) {
+	appName, userID := cfg.AppName, "eval_user"
+	var state map[string]any
+	if in := c.SessionInput; in != nil {
+		if in.AppName != "" {
+			appName = in.AppName
+		}
+		if in.UserID != "" {
+			userID = in.UserID
+		}
+		state = in.State
+	}
+
+	sessionService := session.InMemoryService()
+	created, err := sessionService.Create(ctx, &session.CreateRequest{AppName: appName, UserID: userID, State: state})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create the session: %w", err)
+	}
+	sessionID := created.Session.ID()
+	r, err := runner.New(runner.Config{
+		AppName:        appName,
+		Agent:          cfg.Agent,
+		SessionService: sessionService,
+		PluginConfig:   cfg.PluginConfig,
+	})
+	if err != nil {
+		return nil, sessionID, err
+	}
+	defer r.Close()
+
+	var invocations []*Invocation
+	for _, expected := range c.Conversation {
+		inv := &Invocation{
+			UserContent:       expected.UserContent,
+			Intermediate:      &IntermediateData{},
+			CreationTimestamp: timestamp(time.Now()),
+		}
+		for event, err := range r.Run(ctx, userID, sessionID, expected.UserContent, agent.RunConfig{}) {
+			if err != nil {
+				return invocations, sessionID, fmt.Errorf("invocation %d failed: %w", len(invocations), err)
+			}
+			addEvent(inv, event)
+		}
+		invocations = append(invocations, inv)
+	}
+	return invocations, sessionID, nil
+}
+
+// addEvent records the tool calls and responses, intermediate responses and
+// final response of an event of the invocation.
+func addEvent(inv *Invocation, event *session.Event) {
+	inv.InvocationID = event.InvocationID
+	if event.Content == nil {
+		return
+	}
+	if event.IsFinalResponse() {
+		inv.FinalResponse = event.Content
+		return
+	}
+	var texts []*genai.Part
+	for _, part := range event.Content.Parts {
+		switch {
+		case part.FunctionCall != nil:
+			inv.Intermediate.ToolUses = append(inv.Intermediate.ToolUses, part.FunctionCall)
+		case part.FunctionResponse != nil:
+			inv.Intermediate.ToolResponses = append(inv.Intermediate.ToolResponses, part.FunctionResponse)
+		case part.Text != "" && !part.Thought:
+			texts = append(texts, part)
+		}
+	}
+	if len(texts) > 0 {
+		inv.Intermediate.IntermediateResponses = append(inv.Intermediate.IntermediateResponses, IntermediateResponse{Author: event.Author, Parts: texts})
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func newWeatherAgent(t *testing.T, city, answer string) agent.Agent {
+	t.Helper()
+	type args struct {
+		CityName string `json:"city_name"`
+	}
+	var gotUnit any
+	getWeather, err := functiontool.New(functiontool.Config{Name: "get_weather"}, func(ctx tool.Context, a args) (map[string]any, error) {
+		gotUnit, _ = ctx.State().Get("preferred_unit")
+		return map[string]any{"weather": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if gotUnit != "celsius" {
+			t.Errorf("preferred_unit = %v, want the state of the session input", gotUnit)
+		}
+	})
+	a, err := llmagent.New(llmagent.Config{
+		Name: "weather_agent",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			{Role: genai.RoleModel, Parts: []*genai.Part{
+				genai.NewPartFromText("Let me check."),
+				genai.NewPartFromFunctionCall("get_weather", map[string]any{"city_name": city}),
+			}},
+			genai.NewContentFromText(answer, genai.RoleModel),
+		}},
+		Tools: []tool.Tool{getWeather},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestRun(t *testing.T) {
+	set, err := LoadEvalSet("testdata/weather.evalset.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		city        string
+		answer      string
+		wantStatus  Status
+		wantMetrics map[string]Status
+	}{
+		{
+			name:        "passed",
+			city:        "Paris",
+			answer:      "It is sunny in Paris.",
+			wantStatus:  StatusPassed,
+			wantMetrics: map[string]Status{ToolTrajectoryAvgScore: StatusPassed, ResponseMatchScore: StatusPassed},
+		},
+		{
+			name:        "wrong tool call",
+			city:        "London",
+			answer:      "It is sunny in Paris.",
+			wantStatus:  StatusFailed,
+			wantMetrics: map[string]Status{ToolTrajectoryAvgScore: StatusFailed, ResponseMatchScore: StatusPassed},
+		},
+		{
+			name:        "wrong response",
+			city:        "Paris",
+			answer:      "I do not know.",
+			wantStatus:  StatusFailed,
+			wantMetrics: map[string]Status{ToolTrajectoryAvgScore: StatusPassed, ResponseMatchScore: StatusFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Run(t.Context(), Config{Agent: newWeatherAgent(t, tt.city, tt.answer)}, set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.EvalSetID != "weather" || len(report.Cases) != 1 {
+				t.Fatalf("Run() = %+v, want a report of the weather eval set", report)
+			}
+			result := report.Cases[0]
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (error: %q)", result.Status, tt.wantStatus, result.Error)
+			}
+			gotMetrics := make(map[string]Status)
+			for _, m := range result.Metrics {
+				gotMetrics[m.Name] = m.Status
+			}
+			for name, want := range tt.wantMetrics {
+				if gotMetrics[name] != want {
+					t.Errorf("metric %s status = %v, want %v", name, gotMetrics[name], want)
+				}
+			}
+
+			inv := result.Invocations[0]
+			if got := inv.Intermediate.IntermediateResponses; len(got) != 1 || got[0].Author != "weather_agent" {
+				t.Errorf("IntermediateResponses = %+v, want the text before the tool call", got)
+			}
+			if inv.InvocationID == "" || result.SessionID == "" {
+				t.Errorf("invocation %+v of session %q has no ID", inv, result.SessionID)
+			}
+		})
+	}
+}
+
+func TestRun_AgentError(t *testing.T) {
+	set, err := LoadEvalSet("testdata/weather.evalset.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The model has no response.
+	a, err := llmagent.New(llmagent.Config{Name: "weather_agent", Model: &testutil.MockModel{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := Run(t.Context(), Config{Agent: a}, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report.Cases[0]; got.Status != StatusNotEvaluated || got.Error == "" {
+		t.Errorf("case result = %+v, want not evaluated with the error", got)
+	}
+	if report.Failed != 1 || report.Passed != 0 {
+		t.Errorf("Passed, Failed = %d, %d, want 0, 1", report.Passed, report.Failed)
+	}
+}
+
+// echoModel answers "echo: <user message>". It is safe for concurrent use,
+// and fails the first rateLimited calls with a rate limit error.
+type echoModel struct {
+	mu          sync.Mutex
+	calls       int
+	rateLimited int
+	inflight    int
+	maxInflight int
+}
+
+func (m *echoModel) Name() string { return "echo" }
+
+func (m *echoModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.mu.Lock()
+		m.calls++
+		limited := m.calls <= m.rateLimited
+		m.inflight++
+		m.maxInflight = max(m.maxInflight, m.inflight)
+		m.mu.Unlock()
+		defer func() {
+			m.mu.Lock()
+			m.inflight--
+			m.mu.Unlock()
+		}()
+
+		if limited {
+			yield(nil, genai.APIError{Code: http.StatusTooManyRequests, Message: "quota exceeded"})
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+		text := contentText(req.Contents[len(req.Contents)-1])
+		yield(&model.LLMResponse{Content: genai.NewContentFromText("echo: "+text, genai.RoleModel)}, nil)
+	}
+}
+
+func echoEvalSet(n int) *EvalSet {
+	set := &EvalSet{EvalSetID: "echo"}
+	for i := range n {
+		q := "question " + string(rune('a'+i))
+		set.EvalCases = append(set.EvalCases, &EvalCase{
+			EvalID: q,
+			Conversation: []*Invocation{{
+				UserContent:   genai.NewContentFromText(q, genai.RoleUser),
+				FinalResponse: genai.NewContentFromText("echo: "+q, genai.RoleModel),
+			}},
+		})
+	}
+	return set
+}
+
+func newEchoAgent(t *testing.T, m model.LLM) agent.Agent {
+	t.Helper()
+	a, err := llmagent.New(llmagent.Config{Name: "echo_agent", Model: m})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestRun_Parallel(t *testing.T) {
+	m := &echoModel{}
+	var progress []Progress
+	report, err := Run(t.Context(), Config{
+		Agent:       newEchoAgent(t, m),
+		Parallelism: 3,
+		Progress:    func(p Progress) { progress = append(progress, p) },
+	}, echoEvalSet(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Passed != 6 {
+		t.Errorf("Passed = %d, want 6", report.Passed)
+	}
+	for i, result := range report.Cases {
+		if want := echoEvalSet(6).EvalCases[i].EvalID; result.EvalID != want {
+			t.Errorf("Cases[%d] = %q, want the order of the eval set %q", i, result.EvalID, want)
+		}
+	}
+	if m.maxInflight < 2 || m.maxInflight > 3 {
+		t.Errorf("max concurrent model calls = %d, want 2 to 3", m.maxInflight)
+	}
+	if len(progress) != 6 || progress[5].Completed != 6 || progress[5].Total != 6 {
+		t.Errorf("progress = %+v, want 6 updates up to 6/6", progress)
+	}
+}
+
+func TestRun_RateLimitRetries(t *testing.T) {
+	m := &echoModel{rateLimited: 2}
+	report, err := Run(t.Context(), Config{
+		Agent:            newEchoAgent(t, m),
+		RateLimitBackoff: time.Millisecond,
+	}, echoEvalSet(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report.Cases[0]; got.Status != StatusPassed {
+		t.Errorf("case result = %+v, want passed after the retries", got)
+	}
+
+	m = &echoModel{rateLimited: 2}
+	report, err = Run(t.Context(), Config{
+		Agent:            newEchoAgent(t, m),
+		RateLimitRetries: -1,
+	}, echoEvalSet(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report.Cases[0]; got.Status != StatusNotEvaluated || !strings.Contains(got.Error, "quota exceeded") {
+		t.Errorf("case result = %+v, want not evaluated on the rate limit", got)
+	}
+}
+
+func TestRun_Checkpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	set := echoEvalSet(3)
+
+	// The first run is interrupted after the first case.
+	ctx, cancel := context.WithCancel(t.Context())
+	_, err := Run(ctx, Config{
+		Agent:          newEchoAgent(t, &echoModel{}),
+		CheckpointPath: path,
+		Progress:       func(Progress) { cancel() },
+	}, set)
+	if err == nil {
+		t.Fatal("interrupted Run() succeeded, want error")
+	}
+
+	m := &echoModel{}
+	var resumed int
+	report, err := Run(t.Context(), Config{
+		Agent:          newEchoAgent(t, m),
+		CheckpointPath: path,
+		Progress: func(p Progress) {
+			if p.Resumed {
+				resumed++
+			}
+		},
+	}, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Passed != 3 || resumed != 1 || m.calls != 2 {
+		t.Errorf("resumed run passed %d cases, %d from the checkpoint, with %d model calls; want 3, 1, 2", report.Passed, resumed, m.calls)
+	}
+
+	// A fully recorded run is not run again.
+	m = &echoModel{}
+	if _, err := Run(t.Context(), Config{Agent: newEchoAgent(t, m), CheckpointPath: path}, set); err != nil {
+		t.Fatal(err)
+	}
+	if m.calls != 0 {
+		t.Errorf("model called %d times for a completed run, want 0", m.calls)
+	}
+
+	// The checkpoint of another eval set is rejected.
+	other := echoEvalSet(1)
+	other.EvalSetID = "other"
+	if _, err := Run(t.Context(), Config{Agent: newEchoAgent(t, m), CheckpointPath: path}, other); err == nil {
+		t.Error("Run() with the checkpoint of another eval set succeeded, want error")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+
+	"google.golang.org/genai"
+)
+
+// MatchType is how the actual tool calls are matched against the expected
+// ones. The names are those of adk-python.
+type MatchType string
+
+const (
+	// MatchExact requires the same tool calls in the same order.
+	MatchExact MatchType = "EXACT"
+	// MatchInOrder requires the expected tool calls in the same order,
+	// allowing other tool calls in between.
+	MatchInOrder MatchType = "IN_ORDER"
+	// MatchAnyOrder requires the expected tool calls in any order, allowing
+	// other tool calls.
+	MatchAnyOrder MatchType = "ANY_ORDER"
+)
+
+// ArgMatcher reports whether the actual value of a tool call argument
+// matches the expected value of the eval case.
+type ArgMatcher func(actual, expected any) (bool, error)
+
+// ExactArg matches equal values. The numbers are compared by value, so an
+// int argument of a Go tool matches the same number decoded from JSON.
+func ExactArg(actual, expected any) (bool, error) {
+	return reflect.DeepEqual(normalizeValue(actual), normalizeValue(expected)), nil
+}
+
+// RegexArg matches the values whose text matches the expected value, a
+// regular expression. Non-string values are matched in their JSON encoding.
+// Use ^ and $ to match the whole value.
+func RegexArg(actual, expected any) (bool, error) {
+	pattern, ok := expected.(string)
+	if !ok {
+		return false, fmt.Errorf("expected value %v is not a regular expression", expected)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid regular expression: %w", err)
+	}
+	text, ok := actual.(string)
+	if !ok {
+		b, err := json.Marshal(actual)
+		if err != nil {
+			return false, err
+		}
+		text = string(b)
+	}
+	return re.MatchString(text), nil
+}
+
+// JSONSubsetArg matches the values containing the expected value: the
+// objects must have the expected keys with matching values, ignoring the
+// other keys, and the arrays must have the same length with matching
+// elements. The other values must be equal.
+func JSONSubsetArg(actual, expected any) (bool, error) {
+	return jsonSubset(normalizeValue(actual), normalizeValue(expected)), nil
+}
+
+func jsonSubset(actual, expected any) bool {
+	switch expected := expected.(type) {
+	case map[string]any:
+		actual, ok := actual.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range expected {
+			av, ok := actual[k]
+			if !ok || !jsonSubset(av, v) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		actual, ok := actual.([]any)
+		if !ok || len(actual) != len(expected) {
+			return false
+		}
+		for i := range expected {
+			if !jsonSubset(actual[i], expected[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(actual, expected)
+	}
+}
+
+// TrajectoryConfig configures the tool trajectory evaluator.
+type TrajectoryConfig struct {
+	// Match is how the tool calls are matched. Defaults to MatchExact.
+	Match MatchType
+	// PartialCredit scores the invocations with the fraction of the
+	// expected tool calls matched, instead of 1 if they all match and 0
+	// otherwise. With MatchExact, it is the fraction of the positions with
+	// matching calls, out of the longest of the two trajectories.
+	PartialCredit bool
+	// ArgMatchers match specific arguments, by tool name and argument name.
+	// They apply to the arguments present in the expected tool call.
+	ArgMatchers map[string]map[string]ArgMatcher
+	// DefaultArgMatcher matches the other arguments of a tool call, as a
+	// whole object. Defaults to ExactArg; use JSONSubsetArg to ignore the
+	// arguments missing from the expected call.
+	DefaultArgMatcher ArgMatcher
+}
+
+// TrajectoryEvaluator returns an evaluator matching the tool calls of the
+// invocations against the expected ones, with the same names and matching
+// arguments. The zero config requires the exact same calls, as the
+// tool_trajectory_avg_score metric of adk-python.
+func TrajectoryEvaluator(cfg TrajectoryConfig) Evaluator {
+	if cfg.Match == "" {
+		cfg.Match = MatchExact
+	}
+	if cfg.DefaultArgMatcher == nil {
+		cfg.DefaultArgMatcher = ExactArg
+	}
+	t := &trajectoryMatcher{cfg: cfg}
+	return evaluatorFunc(func(_ context.Context, actual, expected []*Invocation) ([]float64, error) {
+		scores := make([]float64, len(actual))
+		for i := range actual {
+			score, err := t.score(actual[i].ToolUses(), expected[i].ToolUses())
+			if err != nil {
+				return nil, err
+			}
+			scores[i] = score
+		}
+		return scores, nil
+	})
+}
+
+type trajectoryMatcher struct {
+	cfg TrajectoryConfig
+}
+
+func (t *trajectoryMatcher) score(actual, expected []*genai.FunctionCall) (float64, error) {
+	var matched, total int
+	switch t.cfg.Match {
+	case MatchExact:
+		total = max(len(actual), len(expected))
+		for i := range min(len(actual), len(expected)) {
+			ok, err := t.callMatches(actual[i], expected[i])
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				matched++
+			}
+		}
+	case MatchInOrder:
+		total = len(expected)
+		// Greedily match each expected call with the first matching actual
+		// call after the previous match.
+		next := 0
+		for _, exp := range expected {
+			for j := next; j < len(actual); j++ {
+				ok, err := t.callMatches(actual[j], exp)
+				if err != nil {
+					return 0, err
+				}
+				if ok {
+					matched++
+					next = j + 1
+					break
+				}
+			}
+		}
+	case MatchAnyOrder:
+		total = len(expected)
+		used := make([]bool, len(actual))
+		for _, exp := range expected {
+			for j := range actual {
+				if used[j] {
+					continue
+				}
+				ok, err := t.callMatches(actual[j], exp)
+				if err != nil {
+					return 0, err
+				}
+				if ok {
+					matched++
+					used[j] = true
+					break
+				}
+			}
+		}
+	default:
+		return 0, fmt.Errorf("unknown match type %q", t.cfg.Match)
+	}
+
+	if total == 0 {
+		return 1, nil
+	}
+	if t.cfg.PartialCredit {
+		return float64(matched) / float64(total), nil
+	}
+	if matched == total {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (t *trajectoryMatcher) callMatches(actual, expected *genai.FunctionCall) (bool, error) {
+	if actual.Name != expected.Name {
+		return false, nil
+	}
+	actualArgs, expectedArgs := maps.Clone(actual.Args), maps.Clone(expected.Args)
+	for name, match := range t.cfg.ArgMatchers[expected.Name] {
+		exp, ok := expectedArgs[name]
+		if !ok {
+			continue
+		}
+		ok, err := match(actualArgs[name], exp)
+		if err != nil {
+			return false, fmt.Errorf("tool %s argument %s: %w", expected.Name, name, err)
+		}
+		if !ok {
+			return false, nil
+		}
+		delete(actualArgs, name)
+		delete(expectedArgs, name)
+	}
+	ok, err := t.cfg.DefaultArgMatcher(actualArgs, expectedArgs)
+	if err != nil {
+		return false, fmt.Errorf("tool %s arguments: %w", expected.Name, err)
+	}
+	return ok, nil
+}
+
+// normalizeValue converts the numbers to float64, as they are in the values
+// decoded from JSON.
+func normalizeValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[k] = normalizeValue(val)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, val := range v {
+			s[i] = normalizeValue(val)
+		}
+		return s
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"math"
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func call(name string, args map[string]any) *genai.FunctionCall {
+	return &genai.FunctionCall{Name: name, Args: args}
+}
+
+func TestTrajectoryEvaluator(t *testing.T) {
+	paris := call("get_weather", map[string]any{"city": "Paris", "days": float64(2)})
+	london := call("get_weather", map[string]any{"city": "London"})
+	search := call("search", map[string]any{"query": "weather"})
+
+	tests := []struct {
+		name     string
+		cfg      TrajectoryConfig
+		actual   []*genai.FunctionCall
+		expected []*genai.FunctionCall
+		want     float64
+	}{
+		{
+			name: "exact with Go argument types",
+			// Same call, with the argument types of a Go tool.
+			actual:   []*genai.FunctionCall{call("get_weather", map[string]any{"city": "Paris", "days": 2})},
+			expected: []*genai.FunctionCall{paris},
+			want:     1,
+		},
+		{
+			name:     "exact with other arguments",
+			actual:   []*genai.FunctionCall{london},
+			expected: []*genai.FunctionCall{paris},
+			want:     0,
+		},
+		{
+			name:     "exact with extra call",
+			actual:   []*genai.FunctionCall{paris, search},
+			expected: []*genai.FunctionCall{paris},
+			want:     0,
+		},
+		{
+			name:     "exact with partial credit",
+			cfg:      TrajectoryConfig{PartialCredit: true},
+			actual:   []*genai.FunctionCall{paris, search},
+			expected: []*genai.FunctionCall{paris},
+			want:     0.5,
+		},
+		{
+			name:     "no calls",
+			actual:   nil,
+			expected: nil,
+			want:     1,
+		},
+		{
+			name:     "in order with calls in between",
+			cfg:      TrajectoryConfig{Match: MatchInOrder},
+			actual:   []*genai.FunctionCall{search, paris, search, london},
+			expected: []*genai.FunctionCall{paris, london},
+			want:     1,
+		},
+		{
+			name:     "in order in another order",
+			cfg:      TrajectoryConfig{Match: MatchInOrder},
+			actual:   []*genai.FunctionCall{london, paris},
+			expected: []*genai.FunctionCall{paris, london},
+			want:     0,
+		},
+		{
+			name:     "in order with partial credit",
+			cfg:      TrajectoryConfig{Match: MatchInOrder, PartialCredit: true},
+			actual:   []*genai.FunctionCall{london, paris},
+			expected: []*genai.FunctionCall{paris, london},
+			want:     0.5,
+		},
+		{
+			name:     "any order",
+			cfg:      TrajectoryConfig{Match: MatchAnyOrder},
+			actual:   []*genai.FunctionCall{london, search, paris},
+			expected: []*genai.FunctionCall{paris, london},
+			want:     1,
+		},
+		{
+			name:     "any order with a call made once for two expected",
+			cfg:      TrajectoryConfig{Match: MatchAnyOrder, PartialCredit: true},
+			actual:   []*genai.FunctionCall{paris},
+			expected: []*genai.FunctionCall{paris, paris},
+			want:     0.5,
+		},
+		{
+			name: "regex argument",
+			cfg: TrajectoryConfig{ArgMatchers: map[string]map[string]ArgMatcher{
+				"search": {"query": RegexArg},
+			}},
+			actual:   []*genai.FunctionCall{call("search", map[string]any{"query": "Weather in Paris today"})},
+			expected: []*genai.FunctionCall{call("search", map[string]any{"query": "(?i)weather.*paris"})},
+			want:     1,
+		},
+		{
+			name: "regex argument without match",
+			cfg: TrajectoryConfig{ArgMatchers: map[string]map[string]ArgMatcher{
+				"search": {"query": RegexArg},
+			}},
+			actual:   []*genai.FunctionCall{call("search", map[string]any{"query": "news in Paris"})},
+			expected: []*genai.FunctionCall{call("search", map[string]any{"query": "(?i)weather.*paris"})},
+			want:     0,
+		},
+		{
+			name:     "JSON subset arguments",
+			cfg:      TrajectoryConfig{DefaultArgMatcher: JSONSubsetArg},
+			actual:   []*genai.FunctionCall{call("book", map[string]any{"trip": map[string]any{"to": "Paris", "seats": 2}, "note": "window"})},
+			expected: []*genai.FunctionCall{call("book", map[string]any{"trip": map[string]any{"to": "Paris"}})},
+			want:     1,
+		},
+		{
+			name:     "JSON subset arguments without match",
+			cfg:      TrajectoryConfig{DefaultArgMatcher: JSONSubsetArg},
+			actual:   []*genai.FunctionCall{call("book", map[string]any{"trip": map[string]any{"to": "Rome"}})},
+			expected: []*genai.FunctionCall{call("book", map[string]any{"trip": map[string]any{"to": "Paris"}})},
+			want:     0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TrajectoryEvaluator(tt.cfg).Evaluate(t.Context(), []*Invocation{invocation("", tt.actual...)}, []*Invocation{invocation("", tt.expected...)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got.OverallScore-tt.want) > 1e-9 {
+				t.Errorf("OverallScore = %v, want %v", got.OverallScore, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrajectoryEvaluator_Errors(t *testing.T) {
+	regexSearch := TrajectoryConfig{ArgMatchers: map[string]map[string]ArgMatcher{"search": {"query": RegexArg}}}
+	tests := []struct {
+		name     string
+		cfg      TrajectoryConfig
+		actual   []*Invocation
+		expected []*Invocation
+	}{
+		{
+			name:     "missing invocations",
+			actual:   nil,
+			expected: []*Invocation{invocation("")},
+		},
+		{
+			name:     "invalid regex",
+			cfg:      regexSearch,
+			actual:   []*Invocation{invocation("", call("search", map[string]any{"query": "x"}))},
+			expected: []*Invocation{invocation("", call("search", map[string]any{"query": "("}))},
+		},
+		{
+			name:     "unknown match type",
+			cfg:      TrajectoryConfig{Match: "FUZZY"},
+			actual:   []*Invocation{invocation("")},
+			expected: []*Invocation{invocation("")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := TrajectoryEvaluator(tt.cfg).Evaluate(t.Context(), tt.actual, tt.expected); err == nil {
+				t.Error("Evaluate() succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package adk provides conveniences over the packages of the Agent
+// Development Kit.
+package adk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+const (
+	// extractUserID is the user of the sessions of the extractions.
+	extractUserID = "extract_user"
+	// maxRepairs is the number of times the agent is asked to repair an
+	// invalid response.
+	maxRepairs = 2
+)
+
+// Extract runs the agent of r with the input and returns its response
+// decoded in a T, making "LLM as a function" usage one call:
+//
+//	type Invoice struct {
+//		Number string  `json:"number"`
+//		Total  float64 `json:"total"`
+//	}
+//	invoice, err := adk.Extract[Invoice](ctx, r, text)
+//
+// The agent runs with the output schema of T, see [SchemaFor], which
+// overrides the output schema of its LLM agents. The JSON of the response
+// is extracted from the text around it, e.g. markdown code fences, and
+// validated against the schema. If it is invalid, the agent is asked to
+// repair it, up to two times.
+//
+// Every extraction runs in a new session: r must be created with
+// AutoCreateSession.
+func Extract[T any](ctx context.Context, r *runner.Runner, input string) (T, error) {
+	var value T
+	js, err := jsonSchemaFor[T]()
+	if err != nil {
+		return value, err
+	}
+	schema, err := toGenaiSchema(js)
+	if err != nil {
+		return value, err
+	}
+	resolved, err := js.Resolve(nil)
+	if err != nil {
+		return value, fmt.Errorf("invalid schema of %T: %w", value, err)
+	}
+	cfg := agent.RunConfig{OutputSchema: schema}
+	sessionID := session.NewID(ctx)
+	msg := genai.NewContentFromText(input, genai.RoleUser)
+	for attempt := 0; ; attempt++ {
+		text, err := finalResponse(ctx, r, sessionID, msg, cfg)
+		if err != nil {
+			return value, fmt.Errorf("failed to run the agent: %w", err)
+		}
+		err = decode(repairJSON(text), resolved, &value)
+		if err == nil {
+			return value, nil
+		}
+		if attempt == maxRepairs {
+			return value, fmt.Errorf("invalid response after %d repairs: %w", maxRepairs, err)
+		}
+		msg = genai.NewContentFromText(fmt.Sprintf("Your response is invalid: %v. Reply with the corrected JSON only.", err), genai.RoleUser)
+	}
+}
+
+// finalResponse runs the agent and returns the text of its last final
+// response.
+func finalResponse(ctx context.Context, r *runner.Runner, sessionID string, msg *genai.Content, cfg agent.RunConfig) (string, error) {
+	var text string
+	for event, err := range r.Run(ctx, extractUserID, sessionID, msg, cfg) {
+		if err != nil {
+			return "", err
+		}
+		if event.Partial || event.Content == nil || !event.IsFinalResponse() {
+			continue
+		}
+		var sb strings.Builder
+		for _, part := range event.Content.Parts {
+			if !part.Thought {
+				sb.WriteString(part.Text)
+			}
+		}
+		if sb.Len() > 0 {
+			text = sb.String()
+		}
+	}
+	if text == "" {
+		return "", errors.New("the agent did not respond")
+	}
+	return text, nil
+}
+
+// repairJSON returns the JSON value of the text, without the text around
+// it, e.g. markdown code fences or an introduction.
+func repairJSON(text string) string {
+	text = strings.TrimSpace(text)
+	start := strings.IndexAny(text, "{[")
+	if start < 0 {
+		return text
+	}
+	end := strings.LastIndexAny(text, "}]")
+	if end < start {
+		return text[start:]
+	}
+	return text[start : end+1]
+}
+
+// decode validates the JSON against the schema and decodes it in v.
+func decode(data string, schema *jsonschema.Resolved, v any) error {
+	var instance any
+	if err := json.Unmarshal([]byte(data), &instance); err != nil {
+		return fmt.Errorf("failed to parse the JSON: %w", err)
+	}
+	if err := schema.Validate(instance); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("failed to decode the JSON: %w", err)
+	}
+	return nil
+}
+
+// SchemaFor returns the schema of the JSON encoding of the values of type
+// T, e.g. for the OutputSchema of the LLM agents. The descriptions of the
+// fields are read from their jsonschema struct tags.
+func SchemaFor[T any]() (*genai.Schema, error) {
+	js, err := jsonSchemaFor[T]()
+	if err != nil {
+		return nil, err
+	}
+	return toGenaiSchema(js)
+}
+
+func jsonSchemaFor[T any]() (*jsonschema.Schema, error) {
+	js, err := jsonschema.For[T](nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer the schema of %T: %w", *new(T), err)
+	}
+	return js, nil
+}
+
+// toGenaiSchema converts a JSON schema to a genai.Schema.
+func toGenaiSchema(js *jsonschema.Schema) (*genai.Schema, error) {
+	if js == nil {
+		return nil, nil
+	}
+	if js.Ref != "" {
+		return nil, fmt.Errorf("recursive types are not supported: %s", js.Ref)
+	}
+	s := &genai.Schema{
+		Description:      js.Description,
+		Format:           js.Format,
+		Minimum:          js.Minimum,
+		Maximum:          js.Maximum,
+		Required:         js.Required,
+		PropertyOrdering: js.PropertyOrder,
+	}
+	types := js.Types
+	if js.Type != "" {
+		types = []string{js.Type}
+	}
+	if slices.Contains(types, "null") {
+		s.Nullable = genai.Ptr(true)
+		types = slices.DeleteFunc(slices.Clone(types), func(t string) bool { return t == "null" })
+	}
+	switch len(types) {
+	case 0:
+	case 1:
+		s.Type = genai.Type(strings.ToUpper(types[0]))
+	default:
+		return nil, fmt.Errorf("multiple types are not supported: %v", types)
+	}
+	for _, e := range js.Enum {
+		s.Enum = append(s.Enum, fmt.Sprint(e))
+	}
+	if js.MinItems != nil {
+		s.MinItems = genai.Ptr(int64(*js.MinItems))
+	}
+	if js.MaxItems != nil {
+		s.MaxItems = genai.Ptr(int64(*js.MaxItems))
+	}
+	var err error
+	if s.Items, err = toGenaiSchema(js.Items); err != nil {
+		return nil, err
+	}
+	for name, prop := range js.Properties {
+		p, err := toGenaiSchema(prop)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", name, err)
+		}
+		if s.Properties == nil {
+			s.Properties = make(map[string]*genai.Schema, len(js.Properties))
+		}
+		s.Properties[name] = p
+	}
+	return s, nil
+}
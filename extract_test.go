@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+type invoice struct {
+	Number string     `json:"number"`
+	Total  float64    `json:"total" jsonschema:"the total in euros"`
+	Items  []lineItem `json:"items,omitempty"`
+	Paid   *bool      `json:"paid,omitempty"`
+}
+
+type lineItem struct {
+	Name string `json:"name"`
+}
+
+func newExtractRunner(t *testing.T, responses ...string) (*runner.Runner, *testutil.MockModel) {
+	t.Helper()
+	mock := &testutil.MockModel{}
+	for _, r := range responses {
+		mock.Responses = append(mock.Responses, genai.NewContentFromText(r, genai.RoleModel))
+	}
+	a, err := llmagent.New(llmagent.Config{Name: "extractor", Model: mock})
+	if err != nil {
+		t.Fatalf("llmagent.New() error = %v", err)
+	}
+	r, err := runner.New(runner.Config{
+		AppName:           "testApp",
+		Agent:             a,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+	})
+	if err != nil {
+		t.Fatalf("runner.New() error = %v", err)
+	}
+	return r, mock
+}
+
+func TestExtract(t *testing.T) {
+	r, mock := newExtractRunner(t, "Here is the invoice:\n```json\n{\"number\": \"A1\", \"total\": 12.5, \"items\": [{\"name\": \"tea\"}]}\n```")
+	got, err := Extract[invoice](t.Context(), r, "Invoice A1: tea, 12.50 EUR")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if diff := cmp.Diff(invoice{Number: "A1", Total: 12.5, Items: []lineItem{{Name: "tea"}}}, got); diff != "" {
+		t.Errorf("Extract() mismatch (-want +got):\n%s", diff)
+	}
+	config := mock.Requests[0].Config
+	if config.ResponseMIMEType != "application/json" || config.ResponseSchema == nil || config.ResponseSchema.Type != genai.TypeObject {
+		t.Errorf("request config = %+v, want the JSON output schema of the type", config)
+	}
+}
+
+func TestExtract_Repair(t *testing.T) {
+	r, mock := newExtractRunner(t,
+		`{"number": "A1"}`,
+		`{"number": "A1", "total": 12.5}`,
+	)
+	got, err := Extract[invoice](t.Context(), r, "Invoice A1, 12.50 EUR")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if diff := cmp.Diff(invoice{Number: "A1", Total: 12.5}, got); diff != "" {
+		t.Errorf("Extract() mismatch (-want +got):\n%s", diff)
+	}
+	if len(mock.Requests) != 2 {
+		t.Fatalf("model called %d times, want 2", len(mock.Requests))
+	}
+	contents := mock.Requests[1].Contents
+	if repair := contents[len(contents)-1].Parts[0].Text; !strings.Contains(repair, "total") {
+		t.Errorf("repair message = %q, want the validation error", repair)
+	}
+}
+
+func TestExtract_Invalid(t *testing.T) {
+	r, _ := newExtractRunner(t, "no idea", `{"number": 1}`, `{"number": "A1", "total": "twelve"}`)
+	if _, err := Extract[invoice](t.Context(), r, "Invoice A1"); err == nil {
+		t.Error("Extract() succeeded, want error after the repairs")
+	}
+}
+
+func TestSchemaFor(t *testing.T) {
+	got, err := SchemaFor[invoice]()
+	if err != nil {
+		t.Fatalf("SchemaFor() error = %v", err)
+	}
+	want := &genai.Schema{
+		Type:             genai.TypeObject,
+		Required:         []string{"number", "total"},
+		PropertyOrdering: []string{"number", "total", "items", "paid"},
+		Properties: map[string]*genai.Schema{
+			"number": {Type: genai.TypeString},
+			"total":  {Type: genai.TypeNumber, Description: "the total in euros"},
+			"items": {
+				Type:     genai.TypeArray,
+				Nullable: genai.Ptr(true),
+				Items: &genai.Schema{
+					Type:             genai.TypeObject,
+					Required:         []string{"name"},
+					PropertyOrdering: []string{"name"},
+					Properties:       map[string]*genai.Schema{"name": {Type: genai.TypeString}},
+				},
+			},
+			"paid": {Type: genai.TypeBoolean, Nullable: genai.Ptr(true)},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SchemaFor() mismatch (-want +got):\n%s", diff)
+	}
+}
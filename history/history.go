@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history provides the strategies selecting the conversation history
+// sent to the model by the LLM agents, so long sessions fit the context
+// window of the model, see llmagent.Config.HistoryStrategy.
+//
+// The strategies select whole turns of the conversation. A turn starts with a
+// user content that is not a function response and holds the model contents
+// and the function calls and responses that follow it, so the selected
+// history never separates a function call from its response. The last turn,
+// holding the current request of the user, is always kept.
+package history
+
+import (
+	"context"
+
+	"google.golang.org/genai"
+)
+
+// Strategy selects the contents of the conversation sent to the model. The
+// contents are in chronological order and must not be modified.
+type Strategy interface {
+	Select(ctx context.Context, contents []*genai.Content) ([]*genai.Content, error)
+}
+
+// StrategyFunc is a function implementing Strategy.
+type StrategyFunc func(ctx context.Context, contents []*genai.Content) ([]*genai.Content, error)
+
+// Select implements Strategy.
+func (f StrategyFunc) Select(ctx context.Context, contents []*genai.Content) ([]*genai.Content, error) {
+	return f(ctx, contents)
+}
+
+// Turns splits the contents into the turns of the conversation. Consecutive
+// user contents belong to the same turn.
+func Turns(contents []*genai.Content) [][]*genai.Content {
+	var turns [][]*genai.Content
+	for i, c := range contents {
+		if len(turns) == 0 || startsTurn(c) && !startsTurn(contents[i-1]) {
+			turns = append(turns, nil)
+		}
+		turns[len(turns)-1] = append(turns[len(turns)-1], c)
+	}
+	return turns
+}
+
+// startsTurn reports whether the content is a user content that can start a
+// turn, i.e. not a function response.
+func startsTurn(c *genai.Content) bool {
+	if c == nil || c.Role != genai.RoleUser {
+		return false
+	}
+	for _, p := range c.Parts {
+		if p != nil && p.FunctionResponse != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// flatten returns the contents of the turns.
+func flatten(turns [][]*genai.Content) []*genai.Content {
+	var contents []*genai.Content
+	for _, turn := range turns {
+		contents = append(contents, turn...)
+	}
+	return contents
+}
+
+// LastTurns returns the strategy keeping the last n turns of the
+// conversation, at least the current one.
+func LastTurns(n int) Strategy {
+	n = max(n, 1)
+	return StrategyFunc(func(ctx context.Context, contents []*genai.Content) ([]*genai.Content, error) {
+		turns := Turns(contents)
+		if len(turns) <= n {
+			return contents, nil
+		}
+		return flatten(turns[len(turns)-n:]), nil
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+// conversation returns the contents of a conversation of three turns, the
+// second one calling a function.
+func conversation() []*genai.Content {
+	return []*genai.Content{
+		genai.NewContentFromText("Hi, I'm planning a trip to Paris.", genai.RoleUser),
+		genai.NewContentFromText("Great, how can I help?", genai.RoleModel),
+		genai.NewContentFromText("What's the weather there?", genai.RoleUser),
+		genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": "Paris"}, genai.RoleModel),
+		genai.NewContentFromFunctionResponse("get_weather", map[string]any{"weather": "sunny"}, genai.RoleUser),
+		genai.NewContentFromText("It is sunny.", genai.RoleModel),
+		genai.NewContentFromText("Any museum to visit?", genai.RoleUser),
+		genai.NewContentFromText("For context: [guide] said: The Louvre.", genai.RoleUser),
+	}
+}
+
+func TestTurns(t *testing.T) {
+	contents := conversation()
+	got := Turns(contents)
+	want := [][]*genai.Content{contents[:2], contents[2:6], contents[6:]}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Turns() mismatch (-want +got):\n%s", diff)
+	}
+	if got := Turns(nil); got != nil {
+		t.Errorf("Turns(nil) = %v, want nil", got)
+	}
+}
+
+func TestLastTurns(t *testing.T) {
+	contents := conversation()
+	for _, tt := range []struct {
+		n    int
+		want []*genai.Content
+	}{
+		{n: 0, want: contents[6:]},
+		{n: 1, want: contents[6:]},
+		{n: 2, want: contents[2:]},
+		{n: 5, want: contents},
+	} {
+		got, err := LastTurns(tt.n).Select(t.Context(), contents)
+		if err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("LastTurns(%d).Select() mismatch (-want +got):\n%s", tt.n, diff)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// Embedder computes the embeddings of texts, e.g. with an embedding model.
+type Embedder interface {
+	// Embed returns the embeddings of the texts, in the same order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EmbedderFunc is a function implementing Embedder.
+type EmbedderFunc func(ctx context.Context, texts []string) ([][]float32, error)
+
+// Embed implements Embedder.
+func (f EmbedderFunc) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return f(ctx, texts)
+}
+
+// GenAIEmbedder returns the Embedder computing the embeddings with an
+// embedding model of the client, e.g. "text-embedding-004".
+func GenAIEmbedder(client *genai.Client, model string) Embedder {
+	return EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		contents := make([]*genai.Content, len(texts))
+		for i, text := range texts {
+			contents[i] = genai.NewContentFromText(text, genai.RoleUser)
+		}
+		resp, err := client.Models.EmbedContent(ctx, model, contents, nil)
+		if err != nil {
+			return nil, err
+		}
+		embeddings := make([][]float32, len(resp.Embeddings))
+		for i, e := range resp.Embeddings {
+			embeddings[i] = e.Values
+		}
+		return embeddings, nil
+	})
+}
+
+// RelevanceConfig configures the Relevance strategy.
+type RelevanceConfig struct {
+	// Embedder computes the embeddings of the texts of the turns.
+	Embedder Embedder
+	// TopK is the number of earlier turns kept, the most similar to the
+	// current turn.
+	TopK int
+	// Recent is the number of turns before the current one that are kept
+	// whatever their relevance, to keep the flow of the conversation.
+	Recent int // optional
+}
+
+// Relevance returns the strategy keeping the turns of the conversation most
+// relevant to the current turn: the cosine similarity of the embeddings of
+// their texts ranks the earlier turns, of which the TopK best are kept, in
+// chronological order, with the Recent last turns. The turns without text
+// are ranked last, and the most recent turns are kept if the current turn
+// has no text.
+func Relevance(cfg RelevanceConfig) Strategy {
+	return StrategyFunc(func(ctx context.Context, contents []*genai.Content) ([]*genai.Content, error) {
+		turns := Turns(contents)
+		older := len(turns) - 1 - max(cfg.Recent, 0)
+		if older <= cfg.TopK {
+			return contents, nil
+		}
+		query := turnText(turns[len(turns)-1])
+		scores := make([]float64, older)
+		if query != "" {
+			// Only the turns with text are embedded, the others are ranked last.
+			texts := []string{query}
+			var embedded []int
+			for i, turn := range turns[:older] {
+				scores[i] = math.Inf(-1)
+				if text := turnText(turn); text != "" {
+					texts = append(texts, text)
+					embedded = append(embedded, i)
+				}
+			}
+			embeddings, err := cfg.Embedder.Embed(ctx, texts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to embed the history: %w", err)
+			}
+			if len(embeddings) != len(texts) {
+				return nil, fmt.Errorf("embedder returned %d embeddings for %d texts", len(embeddings), len(texts))
+			}
+			for j, i := range embedded {
+				scores[i] = cosine(embeddings[0], embeddings[j+1])
+			}
+		}
+
+		// The most recent turns win the ties.
+		ranked := make([]int, older)
+		for i := range older {
+			ranked[i] = older - 1 - i
+		}
+		slices.SortStableFunc(ranked, func(a, b int) int { return cmp.Compare(scores[b], scores[a]) })
+		kept := ranked[:max(cfg.TopK, 0)]
+		slices.Sort(kept)
+
+		var selected [][]*genai.Content
+		for _, i := range kept {
+			selected = append(selected, turns[i])
+		}
+		return flatten(append(selected, turns[older:]...)), nil
+	})
+}
+
+// turnText returns the texts of the contents of the turn, without the
+// thoughts.
+func turnText(turn []*genai.Content) string {
+	var texts []string
+	for _, c := range turn {
+		for _, p := range c.Parts {
+			if p != nil && p.Text != "" && !p.Thought {
+				texts = append(texts, p.Text)
+			}
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// cosine returns the cosine similarity of the vectors, 0 if one of them is
+// null.
+func cosine(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range min(len(a), len(b)) {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
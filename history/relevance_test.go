@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+// keywordEmbedder embeds the texts on the axes of the keywords they contain.
+func keywordEmbedder(keywords ...string) EmbedderFunc {
+	return func(ctx context.Context, texts []string) ([][]float32, error) {
+		embeddings := make([][]float32, len(texts))
+		for i, text := range texts {
+			embeddings[i] = make([]float32, len(keywords))
+			for j, keyword := range keywords {
+				if strings.Contains(strings.ToLower(text), keyword) {
+					embeddings[i][j] = 1
+				}
+			}
+		}
+		return embeddings, nil
+	}
+}
+
+func TestRelevance(t *testing.T) {
+	contents := []*genai.Content{
+		genai.NewContentFromText("Book a hotel in Paris.", genai.RoleUser),
+		genai.NewContentFromText("Done.", genai.RoleModel),
+		genai.NewContentFromText("What's the weather in Rome?", genai.RoleUser),
+		genai.NewContentFromText("Rainy.", genai.RoleModel),
+		genai.NewContentFromText("Thanks.", genai.RoleUser),
+		genai.NewContentFromText("You're welcome.", genai.RoleModel),
+		genai.NewContentFromText("Is my hotel near the Louvre?", genai.RoleUser),
+	}
+	embedder := keywordEmbedder("hotel", "weather")
+
+	for _, tt := range []struct {
+		name string
+		cfg  RelevanceConfig
+		want []*genai.Content
+	}{
+		{
+			name: "most relevant turn",
+			cfg:  RelevanceConfig{Embedder: embedder, TopK: 1},
+			want: []*genai.Content{contents[0], contents[1], contents[6]},
+		},
+		{
+			name: "with recent turns",
+			cfg:  RelevanceConfig{Embedder: embedder, TopK: 1, Recent: 1},
+			want: []*genai.Content{contents[0], contents[1], contents[4], contents[5], contents[6]},
+		},
+		{
+			name: "enough turns",
+			cfg:  RelevanceConfig{Embedder: embedder, TopK: 3},
+			want: contents,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Relevance(tt.cfg).Select(t.Context(), contents)
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Select() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	failing := EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		return nil, errors.New("unavailable")
+	})
+	if _, err := Relevance(RelevanceConfig{Embedder: failing, TopK: 1}).Select(t.Context(), contents); err == nil {
+		t.Errorf("Select() with a failing embedder error = nil, want an error")
+	}
+}
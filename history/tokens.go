@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+)
+
+// charsPerToken is the average number of characters of a token, used to
+// estimate the tokens of the texts.
+const charsPerToken = 4
+
+// blobTokens is the estimated number of tokens of an inline blob, as the
+// Gemini models count an image.
+const blobTokens = 258
+
+// TokenCounter counts the tokens of a content.
+type TokenCounter func(c *genai.Content) int
+
+// EstimateTokens estimates the tokens of a content without calling the model:
+// a token every 4 characters of the texts and of the JSON encoding of the
+// function calls and responses, and 258 tokens by inline blob.
+func EstimateTokens(c *genai.Content) int {
+	if c == nil {
+		return 0
+	}
+	chars, tokens := 0, 0
+	for _, p := range c.Parts {
+		if p == nil {
+			continue
+		}
+		chars += utf8.RuneCountInString(p.Text)
+		if p.FunctionCall != nil {
+			chars += jsonLen(p.FunctionCall)
+		}
+		if p.FunctionResponse != nil {
+			chars += jsonLen(p.FunctionResponse)
+		}
+		if p.InlineData != nil {
+			tokens += blobTokens
+		}
+	}
+	return tokens + (chars+charsPerToken-1)/charsPerToken
+}
+
+func jsonLen(v any) int {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// TokenBudget returns the strategy dropping the oldest turns of the
+// conversation until the tokens of the history, counted with count, are
+// within maxTokens. The tokens are estimated with EstimateTokens if count is
+// nil. The current turn is kept even if it exceeds the budget.
+func TokenBudget(maxTokens int, count TokenCounter) Strategy {
+	if count == nil {
+		count = EstimateTokens
+	}
+	return StrategyFunc(func(ctx context.Context, contents []*genai.Content) ([]*genai.Content, error) {
+		turns := Turns(contents)
+		total, first := 0, len(turns)
+		for i := len(turns) - 1; i >= 0; i-- {
+			for _, c := range turns[i] {
+				total += count(c)
+			}
+			if total > maxTokens && i < len(turns)-1 {
+				break
+			}
+			first = i
+		}
+		if first == 0 {
+			return contents, nil
+		}
+		return flatten(turns[first:]), nil
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	content := &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{
+		genai.NewPartFromText("12345678"),
+		genai.NewPartFromText("123"),
+		genai.NewPartFromBytes([]byte("png"), "image/png"),
+		genai.NewPartFromFunctionCall("f", nil),
+	}}
+	// 11 characters of text and 12 of {"name":"f"}.
+	if got, want := EstimateTokens(content), 258+6; got != want {
+		t.Errorf("EstimateTokens() = %d, want %d", got, want)
+	}
+	if got := EstimateTokens(nil); got != 0 {
+		t.Errorf("EstimateTokens(nil) = %d, want 0", got)
+	}
+}
+
+func TestTokenBudget(t *testing.T) {
+	contents := conversation()
+	// Every content counts for 10 tokens.
+	count := func(*genai.Content) int { return 10 }
+	for _, tt := range []struct {
+		name      string
+		maxTokens int
+		want      []*genai.Content
+	}{
+		{name: "whole history", maxTokens: 80, want: contents},
+		{name: "drops the oldest turn", maxTokens: 79, want: contents[2:]},
+		{name: "keeps the last turns", maxTokens: 60, want: contents[2:]},
+		{name: "keeps the current turn", maxTokens: 5, want: contents[6:]},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TokenBudget(tt.maxTokens, count).Select(t.Context(), contents)
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Select() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
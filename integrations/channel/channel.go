@@ -0,0 +1,211 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package channel runs agents on chat surfaces, e.g. Telegram or Discord.
+//
+// An [Adapter] connects a chat surface: it receives the inbound messages,
+// which [Serve] runs with the runner, and sends the replies of the agent
+// back. The same agent can serve several chat surfaces at once, each
+// conversation in its own session.
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/runner"
+)
+
+// failureText is the reply sent when the agent fails.
+const failureText = "Sorry, something went wrong."
+
+// Message is an inbound message of a chat surface.
+type Message struct {
+	// ID identifies the message in the chat.
+	ID string
+	// ChatID identifies the conversation, e.g. a chat, a group or
+	// a channel.
+	ChatID string
+	// ThreadID identifies the thread of the message in the conversation.
+	ThreadID string // optional
+	// UserID identifies the author of the message.
+	UserID string
+	// UserName is the display name of the author.
+	UserName string // optional
+	// Text is the text of the message.
+	Text string
+	// Parts are the other parts of the message, e.g. the attached files.
+	Parts []*genai.Part // optional
+	// Metadata is the data of the adapter about the message, e.g. to reply
+	// to it.
+	Metadata map[string]string // optional
+}
+
+// content returns the user content of the message, its text prefixed with
+// the author to tell the participants of group chats apart.
+func (m *Message) content() *genai.Content {
+	author := m.UserName
+	if author == "" {
+		author = m.UserID
+	}
+	parts := []*genai.Part{genai.NewPartFromText(fmt.Sprintf("%s: %s", author, m.Text))}
+	return genai.NewContentFromParts(append(parts, m.Parts...), genai.RoleUser)
+}
+
+// Handler handles an inbound message.
+type Handler func(ctx context.Context, msg *Message)
+
+// Adapter connects a chat surface.
+type Adapter interface {
+	// Name identifies the chat surface, e.g. "telegram".
+	Name() string
+	// Receive receives the inbound messages and calls handle for each of
+	// them, until ctx is done or the adapter fails.
+	Receive(ctx context.Context, handle Handler) error
+	// Send sends the text in reply to the message.
+	Send(ctx context.Context, to *Message, text string) error
+}
+
+// SessionMapper returns the user and session IDs of the message of the
+// named adapter.
+type SessionMapper func(adapter string, msg *Message) (userID, sessionID string)
+
+// DefaultSessionMapper maps each chat to a user "<adapter>:<chat ID>", and
+// each thread of the chat to a session. The messages outside of threads
+// share the session of the chat.
+func DefaultSessionMapper(adapter string, msg *Message) (userID, sessionID string) {
+	sessionID = msg.ThreadID
+	if sessionID == "" {
+		sessionID = msg.ChatID
+	}
+	return adapter + ":" + msg.ChatID, sessionID
+}
+
+// Config is the configuration of [Serve].
+type Config struct {
+	// Runner runs the agent. It must be created with AutoCreateSession, the
+	// sessions are created on the first message of a conversation.
+	Runner *runner.Runner
+	// Adapters are the chat surfaces served.
+	Adapters []Adapter
+	// RunConfig is the configuration of the runs.
+	RunConfig agent.RunConfig // optional
+	// SessionMapper maps the messages to the sessions. Defaults to
+	// DefaultSessionMapper.
+	SessionMapper SessionMapper // optional
+}
+
+// Serve receives the messages of the adapters and replies with the agent
+// until ctx is done or an adapter fails. Every final response of the agent
+// with text is sent as a reply. Serve returns once the running agents
+// are done.
+func Serve(ctx context.Context, cfg Config) error {
+	if cfg.Runner == nil {
+		return errors.New("runner is required")
+	}
+	if len(cfg.Adapters) == 0 {
+		return errors.New("at least one adapter is required")
+	}
+	if cfg.SessionMapper == nil {
+		cfg.SessionMapper = DefaultSessionMapper
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	g, gctx := errgroup.WithContext(ctx)
+	for _, a := range cfg.Adapters {
+		g.Go(func() error {
+			err := a.Receive(gctx, func(ctx context.Context, msg *Message) {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					reply(ctx, cfg, a, msg)
+				}()
+			})
+			if err != nil {
+				return fmt.Errorf("adapter %q failed: %w", a.Name(), err)
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// reply runs the agent with the message and sends its replies.
+func reply(ctx context.Context, cfg Config, a Adapter, msg *Message) {
+	logger := logging.FromContext(ctx).With("adapter", a.Name(), "chat_id", msg.ChatID)
+	userID, sessionID := cfg.SessionMapper(a.Name(), msg)
+	for event, err := range cfg.Runner.Run(ctx, userID, sessionID, msg.content(), cfg.RunConfig) {
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to run the agent", "session_id", sessionID, "error", err)
+			if err := a.Send(ctx, msg, failureText); err != nil {
+				logger.ErrorContext(ctx, "Failed to send the reply", "error", err)
+			}
+			return
+		}
+		if event.Partial || !event.IsFinalResponse() || event.Content == nil {
+			continue
+		}
+		var text strings.Builder
+		for _, part := range event.Content.Parts {
+			if !part.Thought {
+				text.WriteString(part.Text)
+			}
+		}
+		if text.Len() == 0 {
+			continue
+		}
+		if err := a.Send(ctx, msg, text.String()); err != nil {
+			logger.ErrorContext(ctx, "Failed to send the reply", "error", err)
+			return
+		}
+	}
+}
+
+// Split splits the text in chunks of at most max runes, for the chat
+// surfaces limiting the length of the messages. The chunks end at the last
+// line break or space when there is one.
+func Split(text string, max int) []string {
+	if max <= 0 {
+		return []string{text}
+	}
+	var chunks []string
+	for utf8.RuneCountInString(text) > max {
+		// end is the byte offset of the rune following the first max runes.
+		end, n := 0, 0
+		for i := range text {
+			if n == max {
+				end = i
+				break
+			}
+			n++
+		}
+		cut := end
+		if i := strings.LastIndexAny(text[:end], "\n "); i > 0 {
+			cut = i
+		}
+		chunks = append(chunks, text[:cut])
+		text = strings.TrimLeft(text[cut:], "\n ")
+	}
+	return append(chunks, text)
+}
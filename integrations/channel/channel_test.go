@@ -0,0 +1,153 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package channel
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+// fakeAdapter delivers its messages, then records the replies until all
+// are sent.
+type fakeAdapter struct {
+	name     string
+	messages []*Message
+	want     int
+
+	mu      sync.Mutex
+	replies []string
+	done    chan struct{}
+}
+
+func (a *fakeAdapter) Name() string { return a.name }
+
+func (a *fakeAdapter) Receive(ctx context.Context, handle Handler) error {
+	for _, msg := range a.messages {
+		handle(ctx, msg)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (a *fakeAdapter) Send(ctx context.Context, to *Message, text string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.replies = append(a.replies, to.ID+": "+text)
+	if len(a.replies) == a.want {
+		close(a.done)
+	}
+	return nil
+}
+
+func TestServe(t *testing.T) {
+	// echoAgent replies with the user content and the session of the run.
+	echoAgent, err := agent.New(agent.Config{
+		Name: "echo",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				ev := session.NewEventWithContext(ctx, ctx.InvocationID())
+				text := ctx.UserContent().Parts[0].Text + " in " + ctx.Session().UserID() + "/" + ctx.Session().ID()
+				ev.Content = genai.NewContentFromText(text, genai.RoleModel)
+				yield(ev, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+	r, err := runner.New(runner.Config{
+		AppName:           "testApp",
+		Agent:             echoAgent,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+	})
+	if err != nil {
+		t.Fatalf("runner.New() error = %v", err)
+	}
+
+	telegram := &fakeAdapter{name: "telegram", want: 2, done: make(chan struct{}), messages: []*Message{
+		{ID: "1", ChatID: "c1", UserID: "u1", UserName: "ann", Text: "hi"},
+		{ID: "2", ChatID: "c1", ThreadID: "t1", UserID: "u2", Text: "hello"},
+	}}
+	discord := &fakeAdapter{name: "discord", want: 1, done: make(chan struct{}), messages: []*Message{
+		{ID: "3", ChatID: "c1", UserID: "u1", UserName: "bob", Text: "hey"},
+	}}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	errc := make(chan error)
+	go func() {
+		errc <- Serve(ctx, Config{Runner: r, Adapters: []Adapter{telegram, discord}})
+	}()
+	<-telegram.done
+	<-discord.done
+	cancel()
+	if err := <-errc; err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		adapter *fakeAdapter
+		want    []string
+	}{
+		{telegram, []string{"1: ann: hi in telegram:c1/c1", "2: u2: hello in telegram:c1/t1"}},
+		{discord, []string{"3: bob: hey in discord:c1/c1"}},
+	} {
+		got := tt.adapter.replies
+		if len(got) == 2 && got[0] > got[1] {
+			got[0], got[1] = got[1], got[0]
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s replies mismatch (-want +got):\n%s", tt.adapter.name, diff)
+		}
+	}
+}
+
+func TestServe_Validation(t *testing.T) {
+	if err := Serve(t.Context(), Config{}); err == nil {
+		t.Error("Serve() without a runner succeeded, want error")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		max  int
+		want []string
+	}{
+		{name: "short", text: "hello", max: 10, want: []string{"hello"}},
+		{name: "at spaces", text: "hello big world", max: 10, want: []string{"hello big", "world"}},
+		{name: "at line breaks", text: "one two\nthree", max: 10, want: []string{"one two", "three"}},
+		{name: "no space", text: "abcdefgh", max: 3, want: []string{"abc", "def", "gh"}},
+		{name: "runes", text: strings.Repeat("é", 5), max: 2, want: []string{"éé", "éé", "é"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, Split(tt.text, tt.max)); diff != "" {
+				t.Errorf("Split() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
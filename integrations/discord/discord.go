@@ -0,0 +1,327 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package discord provides a [channel.Adapter] running agents as a Discord
+// bot, receiving the messages from the Discord gateway.
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"google.golang.org/adk/integrations/channel"
+	"google.golang.org/adk/internal/logging"
+)
+
+const (
+	defaultGatewayURL = "wss://gateway.discord.gg/?v=10&encoding=json"
+	defaultAPIURL     = "https://discord.com/api/v10"
+	// reconnectDelay is the delay before reconnecting to the gateway after
+	// an error.
+	reconnectDelay = time.Second
+	// maxMessageLength is the maximum length of the messages, in runes.
+	maxMessageLength = 2000
+	// intents are the gateway intents of the bot: the messages of the
+	// guilds, the direct messages and their content.
+	intents = 1<<9 | 1<<12 | 1<<15
+)
+
+// Opcodes of the gateway.
+const (
+	opDispatch       = 0
+	opHeartbeat      = 1
+	opIdentify       = 2
+	opReconnect      = 7
+	opInvalidSession = 9
+	opHello          = 10
+)
+
+// Config is the configuration of an [Adapter].
+type Config struct {
+	// Token is the token of the bot.
+	Token string
+	// GatewayURL is the URL of the gateway. Defaults to
+	// "wss://gateway.discord.gg/?v=10&encoding=json".
+	GatewayURL string // optional
+	// APIURL is the base URL of the HTTP API. Defaults to
+	// "https://discord.com/api/v10".
+	APIURL string // optional
+	// HTTPClient is the client calling the HTTP API. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client // optional
+}
+
+// Adapter is a [channel.Adapter] for Discord. The bot replies to the direct
+// messages and to the messages mentioning it. The chats are the Discord
+// channels, threads included.
+type Adapter struct {
+	cfg Config
+}
+
+var _ channel.Adapter = (*Adapter)(nil)
+
+// New returns an [Adapter] for the bot of cfg.Token.
+func New(cfg Config) (*Adapter, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("token is required")
+	}
+	if cfg.GatewayURL == "" {
+		cfg.GatewayURL = defaultGatewayURL
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = defaultAPIURL
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &Adapter{cfg: cfg}, nil
+}
+
+// Name returns "discord".
+func (a *Adapter) Name() string {
+	return "discord"
+}
+
+// payload is a message of the gateway.
+type payload struct {
+	Op   int             `json:"op"`
+	Data json.RawMessage `json:"d,omitempty"`
+	Seq  *int64          `json:"s,omitempty"`
+	Type string          `json:"t,omitempty"`
+}
+
+type user struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Bot      bool   `json:"bot"`
+}
+
+type message struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+	GuildID   string `json:"guild_id"`
+	Author    user   `json:"author"`
+	Content   string `json:"content"`
+	Mentions  []user `json:"mentions"`
+}
+
+// Receive connects to the gateway and calls handle for the messages to the
+// bot, until ctx is done. The connection errors are logged and the adapter
+// reconnects.
+func (a *Adapter) Receive(ctx context.Context, handle channel.Handler) error {
+	for {
+		err := a.receive(ctx, handle)
+		if ctx.Err() != nil {
+			return nil
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "Discord gateway connection failed", "error", err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// receive runs a connection to the gateway until it fails.
+func (a *Adapter) receive(ctx context.Context, handle channel.Handler) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, a.cfg.GatewayURL, nil)
+	if err != nil {
+		return err
+	}
+	// The connection is closed when ctx is done or a heartbeat fails, the
+	// replies in progress continue.
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-connCtx.Done()
+		conn.Close()
+	}()
+
+	var hello payload
+	if err := conn.ReadJSON(&hello); err != nil {
+		return err
+	}
+	if hello.Op != opHello {
+		return fmt.Errorf("unexpected opcode %d, want hello", hello.Op)
+	}
+	var helloData struct {
+		HeartbeatInterval int64 `json:"heartbeat_interval"`
+	}
+	if err := json.Unmarshal(hello.Data, &helloData); err != nil {
+		return err
+	}
+
+	var (
+		// writeMu serializes the writes of the heartbeats and of the
+		// receive loop.
+		writeMu sync.Mutex
+		seqMu   sync.Mutex
+		seq     *int64
+	)
+	send := func(op int, data any) error {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteJSON(payload{Op: op, Data: b})
+	}
+	heartbeat := func() error {
+		seqMu.Lock()
+		s := seq
+		seqMu.Unlock()
+		return send(opHeartbeat, s)
+	}
+
+	if err := send(opIdentify, map[string]any{
+		"token":   a.cfg.Token,
+		"intents": intents,
+		"properties": map[string]string{
+			"os":      "linux",
+			"browser": "adk",
+			"device":  "adk",
+		},
+	}); err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(time.Duration(helloData.HeartbeatInterval) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-connCtx.Done():
+				return
+			case <-ticker.C:
+				if err := heartbeat(); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	var botID string
+	for {
+		var p payload
+		if err := conn.ReadJSON(&p); err != nil {
+			return err
+		}
+		switch p.Op {
+		case opHeartbeat:
+			if err := heartbeat(); err != nil {
+				return err
+			}
+		case opReconnect:
+			return errors.New("gateway requested a reconnection")
+		case opInvalidSession:
+			return errors.New("invalid gateway session")
+		case opDispatch:
+			if p.Seq != nil {
+				seqMu.Lock()
+				seq = p.Seq
+				seqMu.Unlock()
+			}
+			switch p.Type {
+			case "READY":
+				var ready struct {
+					User user `json:"user"`
+				}
+				if err := json.Unmarshal(p.Data, &ready); err != nil {
+					return err
+				}
+				botID = ready.User.ID
+			case "MESSAGE_CREATE":
+				var m message
+				if err := json.Unmarshal(p.Data, &m); err != nil {
+					return err
+				}
+				if msg := toMessage(&m, botID); msg != nil {
+					handle(ctx, msg)
+				}
+			}
+		}
+	}
+}
+
+// toMessage returns the channel message of a Discord message, or nil if it
+// is neither a direct message nor a mention of the bot.
+func toMessage(m *message, botID string) *channel.Message {
+	if m.Author.Bot || botID == "" {
+		return nil
+	}
+	mentioned := false
+	for _, u := range m.Mentions {
+		mentioned = mentioned || u.ID == botID
+	}
+	if m.GuildID != "" && !mentioned {
+		return nil
+	}
+	text := m.Content
+	for _, mention := range []string{"<@" + botID + ">", "<@!" + botID + ">"} {
+		text = strings.ReplaceAll(text, mention, "")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	return &channel.Message{
+		ID:       m.ID,
+		ChatID:   m.ChannelID,
+		UserID:   m.Author.ID,
+		UserName: m.Author.Username,
+		Text:     text,
+	}
+}
+
+// Send sends the text in reply to the message, split in several messages
+// if it is longer than the limit of Discord.
+func (a *Adapter) Send(ctx context.Context, to *channel.Message, text string) error {
+	for _, chunk := range channel.Split(text, maxMessageLength) {
+		body, err := json.Marshal(map[string]any{
+			"content":           chunk,
+			"message_reference": map[string]any{"message_id": to.ID, "fail_if_not_exists": false},
+			"allowed_mentions":  map[string]any{"replied_user": false},
+		})
+		if err != nil {
+			return err
+		}
+		url := strings.TrimSuffix(a.cfg.APIURL, "/") + "/channels/" + to.ChatID + "/messages"
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bot "+a.cfg.Token)
+		resp, err := a.cfg.HTTPClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to send the Discord message: %w", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to send the Discord message: unexpected status %s", resp.Status)
+		}
+	}
+	return nil
+}
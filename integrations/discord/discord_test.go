@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+
+	"google.golang.org/adk/integrations/channel"
+)
+
+// fakeGateway serves a gateway connection sending the hello, the ready
+// event and the given dispatch events.
+func fakeGateway(t *testing.T, events []map[string]any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade() error = %v", err)
+			return
+		}
+		defer conn.Close()
+		if err := conn.WriteJSON(map[string]any{"op": opHello, "d": map[string]any{"heartbeat_interval": 60000}}); err != nil {
+			t.Errorf("WriteJSON() error = %v", err)
+			return
+		}
+		var identify struct {
+			Op   int `json:"op"`
+			Data struct {
+				Token   string `json:"token"`
+				Intents int    `json:"intents"`
+			} `json:"d"`
+		}
+		if err := conn.ReadJSON(&identify); err != nil {
+			t.Errorf("ReadJSON() error = %v", err)
+			return
+		}
+		if identify.Op != opIdentify || identify.Data.Token != "token" || identify.Data.Intents != intents {
+			t.Errorf("identify = %+v, want the token and intents", identify)
+		}
+		events = append([]map[string]any{{"user": map[string]any{"id": "bot"}, "type": "READY"}}, events...)
+		for i, event := range events {
+			typ := "MESSAGE_CREATE"
+			if event["type"] == "READY" {
+				typ = "READY"
+			}
+			if err := conn.WriteJSON(map[string]any{"op": opDispatch, "s": i + 1, "t": typ, "d": event}); err != nil {
+				t.Errorf("WriteJSON() error = %v", err)
+				return
+			}
+		}
+		// Wait for the client to close the connection.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAdapter_Receive(t *testing.T) {
+	gateway := fakeGateway(t, []map[string]any{
+		{"id": "1", "channel_id": "c1", "guild_id": "g", "author": map[string]any{"id": "u1", "username": "ann"}, "content": "<@bot> hi", "mentions": []map[string]any{{"id": "bot"}}},
+		{"id": "2", "channel_id": "c1", "guild_id": "g", "author": map[string]any{"id": "u1", "username": "ann"}, "content": "not for the bot"},
+		{"id": "3", "channel_id": "c2", "author": map[string]any{"id": "b", "bot": true}, "content": "beep"},
+		{"id": "4", "channel_id": "c2", "author": map[string]any{"id": "u2", "username": "bob"}, "content": "hello"},
+	})
+	a, err := New(Config{Token: "token", GatewayURL: "ws" + strings.TrimPrefix(gateway.URL, "http")})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	var got []*channel.Message
+	err = a.Receive(ctx, func(ctx context.Context, msg *channel.Message) {
+		got = append(got, msg)
+		if len(got) == 2 {
+			cancel()
+		}
+	})
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+
+	want := []*channel.Message{
+		{ID: "1", ChatID: "c1", UserID: "u1", UserName: "ann", Text: "hi"},
+		{ID: "4", ChatID: "c2", UserID: "u2", UserName: "bob", Text: "hello"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Receive() messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAdapter_Send(t *testing.T) {
+	var got []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channels/c1/messages" || r.Header.Get("Authorization") != "Bot token" {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		got = append(got, body)
+	}))
+	defer server.Close()
+	a, err := New(Config{Token: "token", APIURL: server.URL})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := a.Send(t.Context(), &channel.Message{ID: "1", ChatID: "c1"}, "hello"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	want := []map[string]any{{
+		"content":           "hello",
+		"message_reference": map[string]any{"message_id": "1", "fail_if_not_exists": false},
+		"allowed_mentions":  map[string]any{"replied_user": false},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("sent messages mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := a.Send(t.Context(), &channel.Message{ID: "1", ChatID: "other"}, "hello"); err == nil {
+		t.Error("Send() to an unknown channel succeeded, want error")
+	}
+}
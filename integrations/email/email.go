@@ -0,0 +1,264 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package email provides a [channel.Adapter] running agents on email, e.g.
+// for ticket triage agents.
+//
+// The incoming emails are polled from a [Mailbox], or posted to the
+// adapter as a webhook. The agent is run with the subject and the body of
+// the email, and its attachments as inline data, which are saved as
+// artifacts when the runs are configured with SaveInputBlobsAsArtifacts.
+// The final responses of the agent are sent as replies in the thread of
+// the email, each thread of a sender in its own session.
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/integrations/channel"
+	"google.golang.org/adk/internal/logging"
+)
+
+const (
+	defaultPollInterval = time.Minute
+	// maxEmailSize is the maximum size of the emails posted to the webhook.
+	maxEmailSize = 32 << 20
+)
+
+// Mailbox is a source of incoming emails, e.g. an IMAP inbox.
+type Mailbox interface {
+	// Fetch returns the new emails in the RFC 5322 format. The emails are
+	// returned once.
+	Fetch(ctx context.Context) ([][]byte, error)
+}
+
+// MailboxFunc is an adapter to use ordinary functions as a [Mailbox].
+type MailboxFunc func(ctx context.Context) ([][]byte, error)
+
+// Fetch calls f(ctx).
+func (f MailboxFunc) Fetch(ctx context.Context) ([][]byte, error) {
+	return f(ctx)
+}
+
+// Sender sends emails.
+type Sender interface {
+	// Send sends the email, in the RFC 5322 format, to the recipients.
+	Send(ctx context.Context, from string, to []string, msg []byte) error
+}
+
+// SenderFunc is an adapter to use ordinary functions as a [Sender].
+type SenderFunc func(ctx context.Context, from string, to []string, msg []byte) error
+
+// Send calls f(ctx, from, to, msg).
+func (f SenderFunc) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	return f(ctx, from, to, msg)
+}
+
+// SMTPSender returns a [Sender] sending the emails with the SMTP server at
+// addr, see smtp.SendMail.
+func SMTPSender(addr string, auth smtp.Auth) Sender {
+	return SenderFunc(func(ctx context.Context, from string, to []string, msg []byte) error {
+		return smtp.SendMail(addr, auth, from, to, msg)
+	})
+}
+
+// Config is the configuration of an [Adapter].
+type Config struct {
+	// Address is the email address of the agent, the sender of the
+	// replies.
+	Address string
+	// Sender sends the replies.
+	Sender Sender
+	// Mailbox is polled for the incoming emails. If nil, the emails are
+	// received with the webhook only, see [Adapter.ServeHTTP].
+	Mailbox Mailbox // optional
+	// PollInterval is the interval between the polls of the mailbox.
+	// Defaults to 1m.
+	PollInterval time.Duration // optional
+}
+
+// Adapter is a [channel.Adapter] for email. The chats are the senders, and
+// the threads are the email threads, identified by their first message.
+type Adapter struct {
+	cfg Config
+
+	mu     sync.Mutex
+	handle channel.Handler
+	ctx    context.Context
+}
+
+var _ channel.Adapter = (*Adapter)(nil)
+
+// New returns an [Adapter] for the address of cfg.
+func New(cfg Config) (*Adapter, error) {
+	if _, err := mail.ParseAddress(cfg.Address); err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+	if cfg.Sender == nil {
+		return nil, errors.New("sender is required")
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	return &Adapter{cfg: cfg}, nil
+}
+
+// Name returns "email".
+func (a *Adapter) Name() string {
+	return "email"
+}
+
+// Receive polls the mailbox, if any, and handles the emails posted to the
+// webhook until ctx is done. The errors of the polling are logged.
+func (a *Adapter) Receive(ctx context.Context, handle channel.Handler) error {
+	a.mu.Lock()
+	a.handle, a.ctx = handle, ctx
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.handle, a.ctx = nil, nil
+		a.mu.Unlock()
+	}()
+
+	if a.cfg.Mailbox == nil {
+		<-ctx.Done()
+		return nil
+	}
+	ticker := time.NewTicker(a.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		emails, err := a.cfg.Mailbox.Fetch(ctx)
+		if err != nil && ctx.Err() == nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "Failed to fetch the emails", "error", err)
+		}
+		for _, raw := range emails {
+			if err := a.receive(ctx, handle, raw); err != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "Failed to parse an email", "error", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// receive parses the email and handles it, unless it was sent by the agent
+// or automatically.
+func (a *Adapter) receive(ctx context.Context, handle channel.Handler, raw []byte) error {
+	msg, err := parse(raw)
+	if err != nil {
+		return err
+	}
+	if msg == nil || strings.EqualFold(msg.UserID, a.address()) {
+		return nil
+	}
+	handle(ctx, msg)
+	return nil
+}
+
+// ServeHTTP receives an email posted in the RFC 5322 format, e.g. by the
+// inbound email service of a provider. The handler does not authenticate
+// the requests, it must be served behind an authentication middleware.
+func (a *Adapter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	a.mu.Lock()
+	handle, ctx := a.handle, a.ctx
+	a.mu.Unlock()
+	if handle == nil {
+		http.Error(w, "the adapter is not receiving", http.StatusServiceUnavailable)
+		return
+	}
+	raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxEmailSize))
+	if err != nil {
+		http.Error(w, "failed to read the email", http.StatusBadRequest)
+		return
+	}
+	// The email is handled with the context of Receive, the agent runs
+	// after the response.
+	if err := a.receive(ctx, handle, raw); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Send sends the text in reply to the email, in its thread.
+func (a *Adapter) Send(ctx context.Context, to *channel.Message, text string) error {
+	subject := to.Metadata[metadataSubject]
+	if !strings.HasPrefix(strings.ToLower(subject), "re:") {
+		subject = "Re: " + subject
+	}
+	id, err := messageID(a.address())
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for _, h := range [][2]string{
+		{"From", a.cfg.Address},
+		{"To", (&mail.Address{Name: to.UserName, Address: to.UserID}).String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", id},
+		{"In-Reply-To", to.ID},
+		{"References", to.Metadata[metadataReferences]},
+		{"Auto-Submitted", "auto-replied"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "8bit"},
+	} {
+		if h[1] != "" {
+			fmt.Fprintf(&b, "%s: %s\r\n", h[0], h[1])
+		}
+	}
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n"))
+	if err := a.cfg.Sender.Send(ctx, a.address(), []string{to.UserID}, []byte(b.String())); err != nil {
+		return fmt.Errorf("failed to send the email: %w", err)
+	}
+	return nil
+}
+
+// address returns the bare email address of the agent.
+func (a *Adapter) address() string {
+	addr, _ := mail.ParseAddress(a.cfg.Address)
+	return addr.Address
+}
+
+// messageID returns a new message ID in the domain of the address.
+func messageID(address string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	domain := address[strings.LastIndex(address, "@")+1:]
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
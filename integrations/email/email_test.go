@@ -0,0 +1,239 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package email
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/integrations/channel"
+)
+
+const rawEmail = "From: Ann <Ann@example.com>\r\n" +
+	"To: support@agent.example.com\r\n" +
+	"Subject: =?utf-8?q?Broken_caf=C3=A9?=\r\n" +
+	"Message-Id: <2@example.com>\r\n" +
+	"In-Reply-To: <1@example.com>\r\n" +
+	"References: <1@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=outer\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=inner\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"The machine is =\r\nbroken.\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html\r\n" +
+	"\r\n" +
+	"<p>The machine is broken.</p>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-Disposition: attachment; filename=\"photo.png\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"cG5n\r\n" +
+	"--outer--\r\n"
+
+var wantMessage = &channel.Message{
+	ID:       "<2@example.com>",
+	ChatID:   "ann@example.com",
+	ThreadID: "<1@example.com>",
+	UserID:   "ann@example.com",
+	UserName: "Ann",
+	Text:     "Subject: Broken café\n\nThe machine is broken.",
+	Parts: []*genai.Part{
+		{InlineData: &genai.Blob{DisplayName: "photo.png", MIMEType: "image/png", Data: []byte("png")}},
+	},
+	Metadata: map[string]string{
+		metadataSubject:    "Broken café",
+		metadataReferences: "<1@example.com> <2@example.com>",
+	},
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want *channel.Message
+	}{
+		{name: "multipart", raw: rawEmail, want: wantMessage},
+		{
+			name: "plain text",
+			raw:  "From: bob@example.com\r\nSubject: Hi\r\nMessage-Id: <3@example.com>\r\n\r\nHello\r\n",
+			want: &channel.Message{
+				ID:       "<3@example.com>",
+				ChatID:   "bob@example.com",
+				ThreadID: "<3@example.com>",
+				UserID:   "bob@example.com",
+				Text:     "Subject: Hi\n\nHello",
+				Metadata: map[string]string{metadataSubject: "Hi", metadataReferences: "<3@example.com>"},
+			},
+		},
+		{
+			name: "automatic reply",
+			raw:  "From: bob@example.com\r\nAuto-Submitted: auto-replied\r\nSubject: Out of office\r\n\r\nAway\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parse([]byte(tt.raw))
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAdapter_Receive(t *testing.T) {
+	polls := 0
+	a, err := New(Config{
+		Address: "Support <support@agent.example.com>",
+		Sender:  SenderFunc(func(context.Context, string, []string, []byte) error { return nil }),
+		Mailbox: MailboxFunc(func(ctx context.Context) ([][]byte, error) {
+			polls++
+			if polls > 1 {
+				return nil, nil
+			}
+			return [][]byte{
+				[]byte("From: support@agent.example.com\r\nSubject: Loop\r\n\r\nown email\r\n"),
+				[]byte(rawEmail),
+			}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	var got []*channel.Message
+	err = a.Receive(ctx, func(ctx context.Context, msg *channel.Message) {
+		got = append(got, msg)
+		cancel()
+	})
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if diff := cmp.Diff([]*channel.Message{wantMessage}, got); diff != "" {
+		t.Errorf("Receive() messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAdapter_ServeHTTP(t *testing.T) {
+	a, err := New(Config{
+		Address: "support@agent.example.com",
+		Sender:  SenderFunc(func(context.Context, string, []string, []byte) error { return nil }),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/email", strings.NewReader(rawEmail)))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("ServeHTTP() before Receive status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	received := make(chan *channel.Message, 1)
+	done := make(chan error)
+	go func() {
+		done <- a.Receive(ctx, func(ctx context.Context, msg *channel.Message) { received <- msg })
+	}()
+	for rec.Code == http.StatusServiceUnavailable {
+		rec = httptest.NewRecorder()
+		a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/email", strings.NewReader(rawEmail)))
+	}
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+	if diff := cmp.Diff(wantMessage, <-received); diff != "" {
+		t.Errorf("received message mismatch (-want +got):\n%s", diff)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Receive() error = %v", err)
+	}
+}
+
+func TestAdapter_Send(t *testing.T) {
+	var (
+		gotFrom string
+		gotTo   []string
+		gotMsg  []byte
+	)
+	a, err := New(Config{
+		Address: "Support <support@agent.example.com>",
+		Sender: SenderFunc(func(ctx context.Context, from string, to []string, msg []byte) error {
+			gotFrom, gotTo, gotMsg = from, to, msg
+			return nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := a.Send(t.Context(), wantMessage, "Try turning it off\nand on."); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if gotFrom != "support@agent.example.com" || !cmp.Equal(gotTo, []string{"ann@example.com"}) {
+		t.Errorf("Send() envelope = %q %q, want the agent to the sender", gotFrom, gotTo)
+	}
+	reply, err := mail.ReadMessage(strings.NewReader(string(gotMsg)))
+	if err != nil {
+		t.Fatalf("invalid reply: %v", err)
+	}
+	subject, err := decoder.DecodeHeader(reply.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("invalid subject: %v", err)
+	}
+	got := map[string]string{
+		"Subject":        subject,
+		"To":             reply.Header.Get("To"),
+		"In-Reply-To":    reply.Header.Get("In-Reply-To"),
+		"References":     reply.Header.Get("References"),
+		"Auto-Submitted": reply.Header.Get("Auto-Submitted"),
+	}
+	want := map[string]string{
+		"Subject":        "Re: Broken café",
+		"To":             `"Ann" <ann@example.com>`,
+		"In-Reply-To":    "<2@example.com>",
+		"References":     "<1@example.com> <2@example.com>",
+		"Auto-Submitted": "auto-replied",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("reply headers mismatch (-want +got):\n%s", diff)
+	}
+	if !strings.HasSuffix(reply.Header.Get("Message-Id"), "@agent.example.com>") {
+		t.Errorf("reply Message-Id = %q, want in the domain of the agent", reply.Header.Get("Message-Id"))
+	}
+	if body := string(gotMsg[strings.Index(string(gotMsg), "\r\n\r\n")+4:]); body != "Try turning it off\r\nand on." {
+		t.Errorf("reply body = %q, want the text with CRLF line breaks", body)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/integrations/channel"
+)
+
+// Metadata keys of the channel messages.
+const (
+	metadataSubject    = "subject"
+	metadataReferences = "references"
+)
+
+// decoder decodes the encoded words of the headers.
+var decoder = &mime.WordDecoder{}
+
+// parse parses a raw email into a channel message: its text is the subject
+// and the plain text body, and the attachments are inline data parts.
+// It returns nil for the automatic emails, e.g. the out of office replies,
+// to prevent loops.
+func parse(raw []byte) (*channel.Message, error) {
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the email: %w", err)
+	}
+	if auto := m.Header.Get("Auto-Submitted"); auto != "" && auto != "no" {
+		return nil, nil
+	}
+	from, err := m.Header.AddressList("From")
+	if err != nil || len(from) == 0 {
+		return nil, fmt.Errorf("invalid sender: %v", err)
+	}
+	subject, err := decoder.DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		subject = m.Header.Get("Subject")
+	}
+
+	var body parsedBody
+	if err := body.parsePart(m.Header, m.Body); err != nil {
+		return nil, err
+	}
+
+	text := body.text
+	if text == "" {
+		text = body.html
+	}
+
+	id := m.Header.Get("Message-Id")
+	references := strings.Fields(m.Header.Get("References"))
+	if len(references) == 0 {
+		references = strings.Fields(m.Header.Get("In-Reply-To"))
+	}
+	// The thread of the email is identified by its first message.
+	threadID := id
+	if len(references) > 0 {
+		threadID = references[0]
+	}
+	return &channel.Message{
+		ID:       id,
+		ChatID:   strings.ToLower(from[0].Address),
+		ThreadID: threadID,
+		UserID:   strings.ToLower(from[0].Address),
+		UserName: from[0].Name,
+		Text:     fmt.Sprintf("Subject: %s\n\n%s", subject, strings.TrimSpace(text)),
+		Parts:    body.attachments,
+		Metadata: map[string]string{
+			metadataSubject:    subject,
+			metadataReferences: strings.Join(append(references, id), " "),
+		},
+	}, nil
+}
+
+// header is the header of an email or of a MIME part.
+type header interface {
+	Get(key string) string
+}
+
+// parsedBody is the body of an email.
+type parsedBody struct {
+	text        string
+	html        string
+	attachments []*genai.Part
+}
+
+// parsePart parses a MIME part, recursively for the multipart ones.
+func (b *parsedBody) parsePart(h header, r io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(r, params["boundary"])
+		for {
+			p, err := mr.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read the email: %w", err)
+			}
+			if err := b.parsePart(p.Header, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data, err := io.ReadAll(decode(h.Get("Content-Transfer-Encoding"), r))
+	if err != nil {
+		return fmt.Errorf("failed to decode the email: %w", err)
+	}
+	disposition, dispositionParams, _ := mime.ParseMediaType(h.Get("Content-Disposition"))
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	switch {
+	case disposition != "attachment" && mediaType == "text/plain" && b.text == "":
+		b.text = string(data)
+	case disposition != "attachment" && mediaType == "text/html" && b.html == "":
+		b.html = string(data)
+	default:
+		b.attachments = append(b.attachments, &genai.Part{InlineData: &genai.Blob{
+			DisplayName: filename,
+			MIMEType:    mediaType,
+			Data:        data,
+		}})
+	}
+	return nil
+}
+
+// decode decodes the content transfer encoding of a part.
+func decode(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(encoding) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
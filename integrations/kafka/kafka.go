@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafka provides a [trigger.Source] invoking agents on the messages
+// of Kafka topics.
+//
+// The package does not depend on a Kafka client: the [Reader] and [Writer]
+// interfaces follow the consumer and producer of the common clients, e.g.
+// the Reader and Writer of github.com/segmentio/kafka-go, which are
+// wrapped to convert their messages.
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"google.golang.org/adk/integrations/trigger"
+)
+
+// Headers added to the dead-lettered messages.
+const (
+	HeaderError  = "adk_error"
+	HeaderSource = "adk_source"
+)
+
+// Message is a Kafka message.
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+}
+
+// Header is a header of a Kafka message.
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Reader reads the messages of a consumer group.
+type Reader interface {
+	// FetchMessage returns the next message, without committing its
+	// offset.
+	FetchMessage(ctx context.Context) (Message, error)
+	// CommitMessages commits the offsets of the messages.
+	CommitMessages(ctx context.Context, msgs ...Message) error
+}
+
+// Writer writes messages.
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...Message) error
+}
+
+// Source is a [trigger.Source] reading the messages of a [Reader] one at
+// a time, in order. The offset of a message is committed once it is
+// handled. Kafka cannot redeliver a single message: Receive fails on the
+// first message failing, for the consumer to restart from the last
+// committed offset, unless the trigger has a dead letter.
+type Source struct {
+	name   string
+	reader Reader
+}
+
+var _ trigger.Source = (*Source)(nil)
+
+// NewSource returns a [Source] with the name, e.g. the topic, reading the
+// messages of reader.
+func NewSource(name string, reader Reader) (*Source, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	if reader == nil {
+		return nil, errors.New("reader is required")
+	}
+	return &Source{name: name, reader: reader}, nil
+}
+
+// Name returns the name of the source.
+func (s *Source) Name() string {
+	return s.name
+}
+
+// Receive reads the messages until ctx is done or a message fails.
+func (s *Source) Receive(ctx context.Context, handle trigger.Handler) error {
+	for {
+		m, err := s.reader.FetchMessage(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch the message: %w", err)
+		}
+		msg := &trigger.Message{
+			ID:   fmt.Sprintf("%s/%d/%d", m.Topic, m.Partition, m.Offset),
+			Data: m.Value,
+		}
+		if len(m.Headers) > 0 {
+			msg.Attributes = make(map[string]string, len(m.Headers))
+			for _, h := range m.Headers {
+				msg.Attributes[h.Key] = string(h.Value)
+			}
+		}
+		if err := handle(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("message %s failed: %w", msg.ID, err)
+		}
+		if err := s.reader.CommitMessages(ctx, m); err != nil {
+			return fmt.Errorf("failed to commit the message %s: %w", msg.ID, err)
+		}
+	}
+}
+
+// NewDeadLetter returns a [trigger.DeadLetter] writing the messages to the
+// topic, with the HeaderError and HeaderSource headers.
+func NewDeadLetter(w Writer, topic string) (trigger.DeadLetter, error) {
+	if w == nil {
+		return nil, errors.New("writer is required")
+	}
+	if topic == "" {
+		return nil, errors.New("topic is required")
+	}
+	return trigger.DeadLetterFunc(func(ctx context.Context, source string, msg *trigger.Message, cause error) error {
+		m := Message{Topic: topic, Value: msg.Data}
+		for _, k := range slices.Sorted(maps.Keys(msg.Attributes)) {
+			m.Headers = append(m.Headers, Header{Key: k, Value: []byte(msg.Attributes[k])})
+		}
+		m.Headers = append(m.Headers,
+			Header{Key: HeaderError, Value: []byte(cause.Error())},
+			Header{Key: HeaderSource, Value: []byte(source)},
+		)
+		if err := w.WriteMessages(ctx, m); err != nil {
+			return fmt.Errorf("failed to write to the dead-letter topic: %w", err)
+		}
+		return nil
+	}), nil
+}
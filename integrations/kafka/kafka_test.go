@@ -0,0 +1,128 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/integrations/trigger"
+)
+
+// fakeReader returns its messages, then blocks until ctx is done.
+type fakeReader struct {
+	messages  []Message
+	committed []int64
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (Message, error) {
+	if len(r.messages) == 0 {
+		<-ctx.Done()
+		return Message{}, ctx.Err()
+	}
+	m := r.messages[0]
+	r.messages = r.messages[1:]
+	return m, nil
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...Message) error {
+	for _, m := range msgs {
+		r.committed = append(r.committed, m.Offset)
+	}
+	return nil
+}
+
+func TestSource_Receive(t *testing.T) {
+	reader := &fakeReader{messages: []Message{
+		{Topic: "orders", Partition: 1, Offset: 10, Value: []byte("good"), Headers: []Header{{Key: "kind", Value: []byte("order")}}},
+		{Topic: "orders", Partition: 1, Offset: 11, Value: []byte("bad")},
+		{Topic: "orders", Partition: 1, Offset: 12, Value: []byte("never read")},
+	}}
+	s, err := NewSource("orders", reader)
+	if err != nil {
+		t.Fatalf("NewSource() error = %v", err)
+	}
+
+	var got []*trigger.Message
+	err = s.Receive(t.Context(), func(ctx context.Context, msg *trigger.Message) error {
+		got = append(got, msg)
+		if string(msg.Data) == "bad" {
+			return errors.New("agent failed")
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Receive() succeeded, want the error of the failed message")
+	}
+
+	want := []*trigger.Message{
+		{ID: "orders/1/10", Data: []byte("good"), Attributes: map[string]string{"kind": "order"}},
+		{ID: "orders/1/11", Data: []byte("bad")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Receive() messages mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int64{10}, reader.committed); diff != "" {
+		t.Errorf("committed offsets mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSource_ReceiveCanceled(t *testing.T) {
+	s, err := NewSource("orders", &fakeReader{})
+	if err != nil {
+		t.Fatalf("NewSource() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if err := s.Receive(ctx, func(context.Context, *trigger.Message) error { return nil }); err != nil {
+		t.Errorf("Receive() error = %v, want nil once ctx is done", err)
+	}
+}
+
+type writerFunc func(ctx context.Context, msgs ...Message) error
+
+func (f writerFunc) WriteMessages(ctx context.Context, msgs ...Message) error {
+	return f(ctx, msgs...)
+}
+
+func TestNewDeadLetter(t *testing.T) {
+	var got []Message
+	dl, err := NewDeadLetter(writerFunc(func(ctx context.Context, msgs ...Message) error {
+		got = append(got, msgs...)
+		return nil
+	}), "orders.dead")
+	if err != nil {
+		t.Fatalf("NewDeadLetter() error = %v", err)
+	}
+	msg := &trigger.Message{ID: "orders/1/11", Data: []byte("bad"), Attributes: map[string]string{"kind": "order"}}
+	if err := dl.DeadLetter(t.Context(), "orders", msg, errors.New("agent failed")); err != nil {
+		t.Fatalf("DeadLetter() error = %v", err)
+	}
+	want := []Message{{
+		Topic: "orders.dead",
+		Value: []byte("bad"),
+		Headers: []Header{
+			{Key: "kind", Value: []byte("order")},
+			{Key: HeaderError, Value: []byte("agent failed")},
+			{Key: HeaderSource, Value: []byte("orders")},
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("written messages mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pubsub provides a [trigger.Source] invoking agents on the messages
+// of a Google Cloud Pub/Sub subscription.
+package pubsub
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"google.golang.org/api/option"
+	pubsubapi "google.golang.org/api/pubsub/v1"
+
+	"google.golang.org/adk/integrations/trigger"
+	"google.golang.org/adk/internal/logging"
+)
+
+const (
+	defaultMaxMessages = 10
+	defaultAckDeadline = time.Minute
+	// retryDelay is the delay before pulling again after an error.
+	retryDelay = time.Second
+)
+
+// Attributes added to the dead-lettered messages.
+const (
+	AttributeError  = "adk_error"
+	AttributeSource = "adk_source"
+)
+
+// Config is the configuration of a [Source].
+type Config struct {
+	// Subscription is the name of the subscription, in the format
+	// "projects/{project}/subscriptions/{subscription}".
+	Subscription string
+	// MaxMessages is the maximum number of messages pulled, and handled
+	// concurrently, at once. Defaults to 10.
+	MaxMessages int64 // optional
+	// AckDeadline is the acknowledgement deadline of the messages, extended
+	// while they are handled. Defaults to 1m.
+	AckDeadline time.Duration // optional
+}
+
+// Source is a [trigger.Source] pulling the messages of a subscription.
+// The messages failing are not acknowledged, Pub/Sub redelivers them and
+// forwards them to the dead-letter topic of the subscription, if any.
+type Source struct {
+	cfg Config
+	svc *pubsubapi.Service
+}
+
+var _ trigger.Source = (*Source)(nil)
+
+// NewSource returns a [Source] for the subscription of cfg.
+func NewSource(ctx context.Context, cfg Config, opts ...option.ClientOption) (*Source, error) {
+	if cfg.Subscription == "" {
+		return nil, errors.New("subscription is required")
+	}
+	if cfg.MaxMessages <= 0 {
+		cfg.MaxMessages = defaultMaxMessages
+	}
+	if cfg.AckDeadline <= 0 {
+		cfg.AckDeadline = defaultAckDeadline
+	}
+	svc, err := pubsubapi.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Pub/Sub client: %w", err)
+	}
+	return &Source{cfg: cfg, svc: svc}, nil
+}
+
+// Name returns the name of the subscription.
+func (s *Source) Name() string {
+	return s.cfg.Subscription
+}
+
+// Receive pulls the messages until ctx is done. The errors of the pulls
+// are logged and the pulls retried.
+func (s *Source) Receive(ctx context.Context, handle trigger.Handler) error {
+	subs := s.svc.Projects.Subscriptions
+	for {
+		resp, err := subs.Pull(s.cfg.Subscription, &pubsubapi.PullRequest{MaxMessages: s.cfg.MaxMessages}).Context(ctx).Do()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "Failed to pull the Pub/Sub messages", "subscription", s.cfg.Subscription, "error", err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+		var wg sync.WaitGroup
+		for _, m := range resp.ReceivedMessages {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.handle(ctx, handle, m)
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+// handle handles a message, extending its deadline meanwhile, and
+// acknowledges it on success.
+func (s *Source) handle(ctx context.Context, handle trigger.Handler, m *pubsubapi.ReceivedMessage) {
+	logger := logging.FromContext(ctx).With("subscription", s.cfg.Subscription, "message_id", m.Message.MessageId)
+	subs := s.svc.Projects.Subscriptions
+	modifyDeadline := func(deadline time.Duration) error {
+		_, err := subs.ModifyAckDeadline(s.cfg.Subscription, &pubsubapi.ModifyAckDeadlineRequest{
+			AckIds:             []string{m.AckId},
+			AckDeadlineSeconds: int64(deadline.Seconds()),
+		}).Context(context.WithoutCancel(ctx)).Do()
+		return err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(m.Message.Data)
+	if err != nil {
+		logger.ErrorContext(ctx, "Invalid Pub/Sub message data", "error", err)
+		return
+	}
+
+	handleCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(s.cfg.AckDeadline / 2)
+		defer ticker.Stop()
+		for {
+			if err := modifyDeadline(s.cfg.AckDeadline); err != nil {
+				logger.WarnContext(ctx, "Failed to extend the Pub/Sub message deadline", "error", err)
+			}
+			select {
+			case <-handleCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	err = handle(handleCtx, &trigger.Message{
+		ID:         m.Message.MessageId,
+		Data:       data,
+		Attributes: m.Message.Attributes,
+	})
+	cancel()
+	<-done
+
+	if err != nil {
+		// A zero deadline makes the message available for redelivery.
+		if err := modifyDeadline(0); err != nil {
+			logger.WarnContext(ctx, "Failed to nack the Pub/Sub message", "error", err)
+		}
+		return
+	}
+	if _, err := subs.Acknowledge(s.cfg.Subscription, &pubsubapi.AcknowledgeRequest{
+		AckIds: []string{m.AckId},
+	}).Context(context.WithoutCancel(ctx)).Do(); err != nil {
+		logger.ErrorContext(ctx, "Failed to acknowledge the Pub/Sub message", "error", err)
+	}
+}
+
+// NewDeadLetter returns a [trigger.DeadLetter] publishing the messages to
+// the topic, in the format "projects/{project}/topics/{topic}", with the
+// AttributeError and AttributeSource attributes.
+func NewDeadLetter(ctx context.Context, topic string, opts ...option.ClientOption) (trigger.DeadLetter, error) {
+	if topic == "" {
+		return nil, errors.New("topic is required")
+	}
+	svc, err := pubsubapi.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Pub/Sub client: %w", err)
+	}
+	return trigger.DeadLetterFunc(func(ctx context.Context, source string, msg *trigger.Message, cause error) error {
+		attributes := maps.Clone(msg.Attributes)
+		if attributes == nil {
+			attributes = map[string]string{}
+		}
+		attributes[AttributeError] = cause.Error()
+		attributes[AttributeSource] = source
+		_, err := svc.Projects.Topics.Publish(topic, &pubsubapi.PublishRequest{
+			Messages: []*pubsubapi.PubsubMessage{{
+				Data:       base64.StdEncoding.EncodeToString(msg.Data),
+				Attributes: attributes,
+			}},
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("failed to publish to the dead-letter topic: %w", err)
+		}
+		return nil
+	}), nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+
+	"google.golang.org/adk/integrations/trigger"
+)
+
+// fakePubSub is a fake of the Pub/Sub API delivering its messages on the
+// first pull and recording the requests.
+type fakePubSub struct {
+	mu       sync.Mutex
+	messages []map[string]any
+	requests map[string][]map[string]any
+	// handled is closed once all the messages are acknowledged or nacked.
+	handled chan struct{}
+}
+
+func newFakePubSub(t *testing.T, messages []map[string]any) (*fakePubSub, []option.ClientOption) {
+	f := &fakePubSub{messages: messages, requests: map[string][]map[string]any{}, handled: make(chan struct{})}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, []option.ClientOption{option.WithEndpoint(server.URL), option.WithoutAuthentication()}
+}
+
+func (f *fakePubSub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.requests[r.URL.Path] = append(f.requests[r.URL.Path], req)
+	var resp any = map[string]any{}
+	switch r.URL.Path {
+	case "/v1/projects/p/subscriptions/s:pull":
+		resp = map[string]any{"receivedMessages": f.messages}
+		if f.messages == nil {
+			f.mu.Unlock()
+			<-r.Context().Done()
+			return
+		}
+		f.messages = nil
+	case "/v1/projects/p/topics/dead:publish":
+		resp = map[string]any{"messageIds": []string{"1"}}
+	}
+	acks := len(f.requests["/v1/projects/p/subscriptions/s:acknowledge"])
+	nacks := 0
+	for _, req := range f.requests["/v1/projects/p/subscriptions/s:modifyAckDeadline"] {
+		if _, ok := req["ackDeadlineSeconds"]; !ok {
+			nacks++
+		}
+	}
+	if acks+nacks == 2 && r.URL.Path != "/v1/projects/p/subscriptions/s:pull" {
+		select {
+		case <-f.handled:
+		default:
+			close(f.handled)
+		}
+	}
+	f.mu.Unlock()
+	json.NewEncoder(w).Encode(resp)
+}
+
+func receivedMessage(ackID, id, data string) map[string]any {
+	return map[string]any{
+		"ackId": ackID,
+		"message": map[string]any{
+			"messageId":  id,
+			"data":       base64.StdEncoding.EncodeToString([]byte(data)),
+			"attributes": map[string]string{"kind": data},
+		},
+	}
+}
+
+func TestSource_Receive(t *testing.T) {
+	f, opts := newFakePubSub(t, []map[string]any{
+		receivedMessage("a1", "m1", "good"),
+		receivedMessage("a2", "m2", "bad"),
+	})
+	s, err := NewSource(t.Context(), Config{Subscription: "projects/p/subscriptions/s"}, opts...)
+	if err != nil {
+		t.Fatalf("NewSource() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	var (
+		mu  sync.Mutex
+		got = map[string]*trigger.Message{}
+	)
+	done := make(chan error)
+	go func() {
+		done <- s.Receive(ctx, func(ctx context.Context, msg *trigger.Message) error {
+			mu.Lock()
+			got[msg.ID] = msg
+			mu.Unlock()
+			if string(msg.Data) == "bad" {
+				return errors.New("failed")
+			}
+			return nil
+		})
+	}()
+	<-f.handled
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+
+	want := map[string]*trigger.Message{
+		"m1": {ID: "m1", Data: []byte("good"), Attributes: map[string]string{"kind": "good"}},
+		"m2": {ID: "m2", Data: []byte("bad"), Attributes: map[string]string{"kind": "bad"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Receive() messages mismatch (-want +got):\n%s", diff)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wantAcks := []map[string]any{{"ackIds": []any{"a1"}}}
+	if diff := cmp.Diff(wantAcks, f.requests["/v1/projects/p/subscriptions/s:acknowledge"]); diff != "" {
+		t.Errorf("acknowledged messages mismatch (-want +got):\n%s", diff)
+	}
+	var nacked []any
+	for _, req := range f.requests["/v1/projects/p/subscriptions/s:modifyAckDeadline"] {
+		if _, ok := req["ackDeadlineSeconds"]; !ok {
+			nacked = append(nacked, req["ackIds"].([]any)...)
+		}
+	}
+	if diff := cmp.Diff([]any{"a2"}, nacked); diff != "" {
+		t.Errorf("nacked messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewDeadLetter(t *testing.T) {
+	f, opts := newFakePubSub(t, nil)
+	dl, err := NewDeadLetter(t.Context(), "projects/p/topics/dead", opts...)
+	if err != nil {
+		t.Fatalf("NewDeadLetter() error = %v", err)
+	}
+	msg := &trigger.Message{ID: "m1", Data: []byte("bad"), Attributes: map[string]string{"kind": "order"}}
+	if err := dl.DeadLetter(t.Context(), "projects/p/subscriptions/s", msg, errors.New("agent failed")); err != nil {
+		t.Fatalf("DeadLetter() error = %v", err)
+	}
+	want := []map[string]any{{"messages": []any{map[string]any{
+		"data": base64.StdEncoding.EncodeToString([]byte("bad")),
+		"attributes": map[string]any{
+			"kind":          "order",
+			AttributeError:  "agent failed",
+			AttributeSource: "projects/p/subscriptions/s",
+		},
+	}}}}
+	if diff := cmp.Diff(want, f.requests["/v1/projects/p/topics/dead:publish"]); diff != "" {
+		t.Errorf("published messages mismatch (-want +got):\n%s", diff)
+	}
+	if msg.Attributes[AttributeError] != "" {
+		t.Errorf("DeadLetter() modified the attributes of the message")
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// api calls the methods of the Slack Web API with the bot token.
+type api struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// call calls the method with the params, sent as JSON, or as a form if they
+// are url.Values, and decodes the response in out.
+func (a *api) call(ctx context.Context, method string, params, out any) error {
+	var body io.Reader
+	contentType := "application/json; charset=utf-8"
+	if form, ok := params.(url.Values); ok {
+		body = strings.NewReader(form.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	} else {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+method, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack %s: unexpected status %s", method, resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("slack %s: %w", method, err)
+	}
+	var status struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(b, &status); err != nil {
+		return fmt.Errorf("slack %s: failed to decode the response: %w", method, err)
+	}
+	if !status.OK {
+		return fmt.Errorf("slack %s: %s", method, status.Error)
+	}
+	if out != nil {
+		if err := json.Unmarshal(b, out); err != nil {
+			return fmt.Errorf("slack %s: failed to decode the response: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// postMessage posts the text in the thread, or as a new message if threadTS
+// is empty, and returns the timestamp of the message.
+func (a *api) postMessage(ctx context.Context, channel, threadTS, text string) (string, error) {
+	var resp struct {
+		TS string `json:"ts"`
+	}
+	params := map[string]string{"channel": channel, "text": text}
+	if threadTS != "" {
+		params["thread_ts"] = threadTS
+	}
+	err := a.call(ctx, "chat.postMessage", params, &resp)
+	return resp.TS, err
+}
+
+// updateMessage replaces the text of the message.
+func (a *api) updateMessage(ctx context.Context, channel, ts, text string) error {
+	return a.call(ctx, "chat.update", map[string]string{"channel": channel, "ts": ts, "text": text}, nil)
+}
+
+// uploadFile shares the file in the thread.
+func (a *api) uploadFile(ctx context.Context, channel, threadTS, name string, data []byte) error {
+	var upload struct {
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+	if err := a.call(ctx, "files.getUploadURLExternal", url.Values{
+		"filename": {name},
+		"length":   {strconv.Itoa(len(data))},
+	}, &upload); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, upload.UploadURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload the file: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload the file: unexpected status %s", resp.Status)
+	}
+	files, err := json.Marshal([]map[string]string{{"id": upload.FileID, "title": name}})
+	if err != nil {
+		return err
+	}
+	return a.call(ctx, "files.completeUploadExternal", url.Values{
+		"files":      {string(files)},
+		"channel_id": {channel},
+		"thread_ts":  {threadTS},
+	}, nil)
+}
+
+// download downloads a private file shared with the bot.
+func (a *api) download(ctx context.Context, fileURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download the file: unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/logging"
+)
+
+// envelope is the body of an Events API request.
+type envelope struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Event     event  `json:"event"`
+}
+
+// event is a message or mention event of the Events API.
+type event struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype"`
+	ChannelType string `json:"channel_type"`
+	Channel     string `json:"channel"`
+	User        string `json:"user"`
+	BotID       string `json:"bot_id"`
+	Text        string `json:"text"`
+	TS          string `json:"ts"`
+	ThreadTS    string `json:"thread_ts"`
+	Files       []file `json:"files"`
+}
+
+// file is a file shared in a message.
+type file struct {
+	Name               string `json:"name"`
+	MIMEType           string `json:"mimetype"`
+	URLPrivateDownload string `json:"url_private_download"`
+}
+
+// handleEvent handles an Events API request.
+func (b *Bot) handleEvent(w http.ResponseWriter, r *http.Request, body []byte) {
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+	switch env.Type {
+	case "url_verification":
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, env.Challenge)
+		return
+	case "event_callback":
+	default:
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+
+	// Slack retries the events not acknowledged in time, they are already
+	// handled.
+	if r.Header.Get("X-Slack-Retry-Num") != "" {
+		return
+	}
+	ev := env.Event
+	if ev.BotID != "" || (ev.Subtype != "" && ev.Subtype != "file_share") {
+		return
+	}
+	if ev.Type != "app_mention" && (ev.Type != "message" || ev.ChannelType != "im") {
+		return
+	}
+
+	ctx := r.Context()
+	threadTS := ev.ThreadTS
+	if threadTS == "" {
+		threadTS = ev.TS
+	}
+	sessionID := threadTS
+	if ev.ChannelType == "im" && ev.ThreadTS == "" {
+		// Direct messages outside of threads share one session.
+		sessionID = ev.Channel
+	}
+	content := genai.NewContentFromText(fmt.Sprintf("<@%s>: %s", ev.User, ev.Text), genai.RoleUser)
+	for _, f := range ev.Files {
+		data, err := b.api.download(ctx, f.URLPrivateDownload)
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "Failed to download a Slack file", "name", f.Name, "error", err)
+			continue
+		}
+		content.Parts = append(content.Parts, &genai.Part{InlineData: &genai.Blob{
+			DisplayName: f.Name,
+			MIMEType:    f.MIMEType,
+			Data:        data,
+		}})
+	}
+	b.start(ctx, &conversation{
+		channel:   ev.Channel,
+		threadTS:  threadTS,
+		sessionID: sessionID,
+		content:   content,
+	})
+}
+
+// handleCommand handles a slash command. The reply of the bot starts a new
+// thread, which continues as a conversation with the mentions of the bot.
+func (b *Bot) handleCommand(w http.ResponseWriter, r *http.Request, body []byte) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid command", http.StatusBadRequest)
+		return
+	}
+	command, channel := form.Get("command"), form.Get("channel_id")
+	if command == "" || channel == "" {
+		http.Error(w, "invalid command", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	ts, err := b.api.postMessage(ctx, channel, "", placeholderText)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Failed to reply to a Slack command", "command", command, "error", err)
+		return
+	}
+	text := strings.TrimSpace(command + " " + form.Get("text"))
+	b.start(ctx, &conversation{
+		channel:   channel,
+		threadTS:  ts,
+		replyTS:   ts,
+		sessionID: ts,
+		content:   genai.NewContentFromText(fmt.Sprintf("<@%s>: %s", form.Get("user_id"), text), genai.RoleUser),
+	})
+}
@@ -0,0 +1,314 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slack runs agents as a Slack bot.
+//
+// A [Bot] is an http.Handler serving the Events API and the slash commands
+// of a Slack app. The mentions of the bot and its direct messages are run
+// in a session per thread, and the replies are streamed by editing the
+// message of the bot. Files shared with the bot are sent to the agent as
+// inline data, and the artifacts saved by the agent are uploaded in the
+// thread.
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+const (
+	defaultAPIURL         = "https://slack.com/api/"
+	defaultStreamInterval = time.Second
+	// maxRequestAge is the maximum age of the requests accepted from
+	// Slack, to prevent replays.
+	maxRequestAge = 5 * time.Minute
+	// placeholderText is the text of the reply while the agent runs.
+	placeholderText = "_Thinking…_"
+	// failureText is the text of the reply when the agent fails.
+	failureText = "Sorry, something went wrong."
+)
+
+// Config is the configuration of a [Bot].
+type Config struct {
+	// SigningSecret is the signing secret of the Slack app, used to verify
+	// the requests.
+	SigningSecret string
+	// BotToken is the bot user OAuth token of the Slack app.
+	BotToken string
+	// AppName is the app name of the runner, used to load the artifacts.
+	AppName string
+	// Runner runs the agent. It must be created with AutoCreateSession, the
+	// sessions are created on the first message of a thread.
+	Runner *runner.Runner
+	// ArtifactService is the artifact service of the runner. If set, the
+	// artifacts saved by the agent are uploaded in the thread.
+	ArtifactService artifact.Service // optional
+	// RunConfig is the configuration of the runs. StreamingMode is always
+	// StreamingModeSSE.
+	RunConfig agent.RunConfig // optional
+	// StreamInterval is the minimum interval between the edits of a reply.
+	// Defaults to 1s.
+	StreamInterval time.Duration // optional
+	// APIURL is the base URL of the Slack Web API. Defaults to
+	// "https://slack.com/api/".
+	APIURL string // optional
+	// HTTPClient is the client calling the Slack Web API. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client // optional
+}
+
+// Bot is an http.Handler serving the requests of a Slack app.
+//
+// The user ID of the sessions is the channel ID, and the session ID is the
+// timestamp of the thread, or the channel ID for direct messages.
+type Bot struct {
+	cfg Config
+	api *api
+	wg  sync.WaitGroup
+}
+
+// New returns a [Bot] running the agent of cfg.Runner.
+func New(cfg Config) (*Bot, error) {
+	if cfg.SigningSecret == "" {
+		return nil, errors.New("signing secret is required")
+	}
+	if cfg.BotToken == "" {
+		return nil, errors.New("bot token is required")
+	}
+	if cfg.Runner == nil {
+		return nil, errors.New("runner is required")
+	}
+	if cfg.ArtifactService != nil && cfg.AppName == "" {
+		return nil, errors.New("app name is required to upload the artifacts")
+	}
+	if cfg.StreamInterval <= 0 {
+		cfg.StreamInterval = defaultStreamInterval
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = defaultAPIURL
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	cfg.RunConfig.StreamingMode = agent.StreamingModeSSE
+	return &Bot{
+		cfg: cfg,
+		api: &api{baseURL: cfg.APIURL, token: cfg.BotToken, client: cfg.HTTPClient},
+	}, nil
+}
+
+// ServeHTTP serves the Events API requests, in JSON, and the slash
+// commands, form-encoded. The requests are acknowledged immediately and the
+// agent runs in the background.
+func (b *Bot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read the request", http.StatusBadRequest)
+		return
+	}
+	if err := Verify(b.cfg.SigningSecret, r.Header, body, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		b.handleCommand(w, r, body)
+		return
+	}
+	b.handleEvent(w, r, body)
+}
+
+// Wait waits for the agents running in the background.
+func (b *Bot) Wait() {
+	b.wg.Wait()
+}
+
+// Verify verifies the signature of a request from Slack, sent in the
+// X-Slack-Signature header with the timestamp in X-Slack-Request-Timestamp.
+// The requests older than 5 minutes are rejected.
+func Verify(secret string, header http.Header, body []byte, now time.Time) error {
+	ts := header.Get("X-Slack-Request-Timestamp")
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errors.New("invalid request timestamp")
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return errors.New("request timestamp is too old")
+	}
+	if !hmac.Equal([]byte(header.Get("X-Slack-Signature")), []byte(Sign(secret, ts, body))) {
+		return errors.New("invalid request signature")
+	}
+	return nil
+}
+
+// Sign returns the signature of a request from Slack, "v0=" followed by the
+// hex encoded HMAC-SHA256 of "v0:", the timestamp, ":" and the body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:", timestamp)
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// conversation is a run of the agent replying in a thread.
+type conversation struct {
+	channel  string
+	threadTS string
+	// replyTS is the timestamp of the reply of the bot, empty to post
+	// a new one.
+	replyTS   string
+	sessionID string
+	content   *genai.Content
+}
+
+// start runs the conversation in the background, detached from the request.
+func (b *Bot) start(ctx context.Context, c *conversation) {
+	ctx = context.WithoutCancel(ctx)
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		if err := b.reply(ctx, c); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "Failed to reply in Slack", "channel", c.channel, "thread_ts", c.threadTS, "error", err)
+		}
+	}()
+}
+
+// reply runs the agent and streams its reply in the thread.
+func (b *Bot) reply(ctx context.Context, c *conversation) error {
+	if c.replyTS == "" {
+		ts, err := b.api.postMessage(ctx, c.channel, c.threadTS, placeholderText)
+		if err != nil {
+			return err
+		}
+		c.replyTS = ts
+	}
+
+	var (
+		text       strings.Builder
+		partial    string
+		lastUpdate time.Time
+		artifacts  []string
+		runErr     error
+	)
+	for event, err := range b.cfg.Runner.Run(ctx, c.channel, c.sessionID, c.content, b.cfg.RunConfig) {
+		if err != nil {
+			runErr = err
+			break
+		}
+		if event.Partial {
+			partial += eventText(event)
+			if time.Since(lastUpdate) >= b.cfg.StreamInterval {
+				if err := b.api.updateMessage(ctx, c.channel, c.replyTS, text.String()+partial); err != nil {
+					return err
+				}
+				lastUpdate = time.Now()
+			}
+			continue
+		}
+		partial = ""
+		text.WriteString(eventText(event))
+		artifacts = append(artifacts, slices.Sorted(maps.Keys(event.Actions.ArtifactDelta))...)
+	}
+
+	final := text.String()
+	if runErr != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Failed to run the agent", "session_id", c.sessionID, "error", runErr)
+		final = failureText
+	}
+	if final == "" {
+		final = " "
+	}
+	if err := b.api.updateMessage(ctx, c.channel, c.replyTS, final); err != nil {
+		return err
+	}
+	return b.uploadArtifacts(ctx, c, artifacts)
+}
+
+// uploadArtifacts uploads the latest version of the artifacts in the thread.
+func (b *Bot) uploadArtifacts(ctx context.Context, c *conversation, names []string) error {
+	if b.cfg.ArtifactService == nil {
+		return nil
+	}
+	for _, name := range names {
+		resp, err := b.cfg.ArtifactService.Load(ctx, &artifact.LoadRequest{
+			AppName:   b.cfg.AppName,
+			UserID:    c.channel,
+			SessionID: c.sessionID,
+			FileName:  name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load artifact %q: %w", name, err)
+		}
+		data := artifactData(resp.Part)
+		if data == nil {
+			continue
+		}
+		if err := b.api.uploadFile(ctx, c.channel, c.threadTS, name, data); err != nil {
+			return fmt.Errorf("failed to upload artifact %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// artifactData returns the bytes of an artifact, or nil if it has neither
+// inline data nor text.
+func artifactData(part *genai.Part) []byte {
+	switch {
+	case part == nil:
+		return nil
+	case part.InlineData != nil:
+		return part.InlineData.Data
+	case part.Text != "":
+		return []byte(part.Text)
+	}
+	return nil
+}
+
+// eventText returns the text of the event, without the thoughts.
+func eventText(event *session.Event) string {
+	if event.Content == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, part := range event.Content.Parts {
+		if part.Thought {
+			continue
+		}
+		sb.WriteString(part.Text)
+	}
+	return sb.String()
+}
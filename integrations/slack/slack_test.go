@@ -0,0 +1,316 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slack
+
+import (
+	"encoding/json"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+const testSecret = "secret"
+
+type call struct {
+	Method string
+	Params map[string]string
+}
+
+// fakeSlack is a fake of the Slack Web API recording the calls.
+type fakeSlack struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	calls    []call
+	uploaded []byte
+}
+
+func newFakeSlack(t *testing.T) *fakeSlack {
+	f := &fakeSlack{}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); r.URL.Path != "/upload" && got != "Bearer xoxb-token" {
+			t.Errorf("Authorization = %q, want the bot token", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		switch r.URL.Path {
+		case "/files/report.csv":
+			w.Write([]byte("a,b"))
+			return
+		case "/upload":
+			f.uploaded = body
+			return
+		}
+		params := map[string]string{}
+		if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+			form, _ := url.ParseQuery(string(body))
+			for k := range form {
+				params[k] = form.Get(k)
+			}
+		} else if err := json.Unmarshal(body, &params); err != nil {
+			t.Errorf("invalid body %q: %v", body, err)
+		}
+		method := strings.TrimPrefix(r.URL.Path, "/api/")
+		f.calls = append(f.calls, call{Method: method, Params: params})
+		resp := map[string]any{"ok": true}
+		switch method {
+		case "chat.postMessage":
+			resp["ts"] = "200.2"
+		case "files.getUploadURLExternal":
+			resp["upload_url"] = f.server.URL + "/upload"
+			resp["file_id"] = "F1"
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func newTestBot(t *testing.T, slack *fakeSlack, run func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error]) (*Bot, session.Service) {
+	t.Helper()
+	a, err := agent.New(agent.Config{Name: "test_agent", Run: run})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+	sessionService := session.InMemoryService()
+	artifactService := artifact.InMemoryService()
+	r, err := runner.New(runner.Config{
+		AppName:           "testApp",
+		Agent:             a,
+		SessionService:    sessionService,
+		ArtifactService:   artifactService,
+		AutoCreateSession: true,
+	})
+	if err != nil {
+		t.Fatalf("runner.New() error = %v", err)
+	}
+	bot, err := New(Config{
+		SigningSecret:   testSecret,
+		BotToken:        "xoxb-token",
+		AppName:         "testApp",
+		Runner:          r,
+		ArtifactService: artifactService,
+		StreamInterval:  time.Hour,
+		APIURL:          slack.server.URL + "/api/",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return bot, sessionService
+}
+
+func signedRequest(body, contentType string) *http.Request {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/slack", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", Sign(testSecret, ts, []byte(body)))
+	return req
+}
+
+// replyAgent streams "Hello" and saves an artifact.
+func replyAgent(got *[]*genai.Content) func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+	return func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+		return func(yield func(*session.Event, error) bool) {
+			*got = append(*got, ctx.UserContent())
+			for _, text := range []string{"Hel", "lo"} {
+				ev := session.NewEventWithContext(ctx, ctx.InvocationID())
+				ev.Content = genai.NewContentFromText(text, genai.RoleModel)
+				ev.Partial = true
+				if !yield(ev, nil) {
+					return
+				}
+			}
+			resp, err := ctx.Artifacts().Save(ctx, "report.txt", genai.NewPartFromText("report"))
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			ev := session.NewEventWithContext(ctx, ctx.InvocationID())
+			ev.Content = genai.NewContentFromText("Hello", genai.RoleModel)
+			ev.Actions.ArtifactDelta = map[string]int64{"report.txt": resp.Version}
+			yield(ev, nil)
+		}
+	}
+}
+
+func TestBot_URLVerification(t *testing.T) {
+	bot, _ := newTestBot(t, newFakeSlack(t), nil)
+	rec := httptest.NewRecorder()
+	bot.ServeHTTP(rec, signedRequest(`{"type":"url_verification","challenge":"abc"}`, "application/json"))
+	if rec.Code != http.StatusOK || rec.Body.String() != "abc" {
+		t.Errorf("ServeHTTP() = %d %q, want 200 %q", rec.Code, rec.Body.String(), "abc")
+	}
+}
+
+func TestBot_InvalidSignature(t *testing.T) {
+	bot, _ := newTestBot(t, newFakeSlack(t), nil)
+	req := signedRequest(`{"type":"url_verification","challenge":"abc"}`, "application/json")
+	req.Header.Set("X-Slack-Signature", "v0=invalid")
+	rec := httptest.NewRecorder()
+	bot.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte("body")
+	header := func(ts time.Time, secret string) http.Header {
+		unix := strconv.FormatInt(ts.Unix(), 10)
+		return http.Header{
+			"X-Slack-Request-Timestamp": {unix},
+			"X-Slack-Signature":         {Sign(secret, unix, body)},
+		}
+	}
+	tests := []struct {
+		name    string
+		header  http.Header
+		wantErr bool
+	}{
+		{name: "valid", header: header(now, testSecret)},
+		{name: "wrong secret", header: header(now, "other"), wantErr: true},
+		{name: "too old", header: header(now.Add(-10*time.Minute), testSecret), wantErr: true},
+		{name: "missing timestamp", header: http.Header{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(testSecret, tt.header, body, now); (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBot_AppMention(t *testing.T) {
+	slack := newFakeSlack(t)
+	var got []*genai.Content
+	bot, sessionService := newTestBot(t, slack, replyAgent(&got))
+
+	body, err := json.Marshal(map[string]any{
+		"type": "event_callback",
+		"event": map[string]any{
+			"type":    "app_mention",
+			"channel": "C1",
+			"user":    "U1",
+			"text":    "summarize this",
+			"ts":      "100.1",
+			"files": []map[string]string{
+				{"name": "report.csv", "mimetype": "text/csv", "url_private_download": slack.server.URL + "/files/report.csv"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	bot.ServeHTTP(rec, signedRequest(string(body), "application/json"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	bot.Wait()
+
+	wantContent := []*genai.Content{genai.NewContentFromParts([]*genai.Part{
+		genai.NewPartFromText("<@U1>: summarize this"),
+		{InlineData: &genai.Blob{DisplayName: "report.csv", MIMEType: "text/csv", Data: []byte("a,b")}},
+	}, genai.RoleUser)}
+	if diff := cmp.Diff(wantContent, got); diff != "" {
+		t.Errorf("user content mismatch (-want +got):\n%s", diff)
+	}
+	wantCalls := []call{
+		{Method: "chat.postMessage", Params: map[string]string{"channel": "C1", "thread_ts": "100.1", "text": placeholderText}},
+		{Method: "chat.update", Params: map[string]string{"channel": "C1", "ts": "200.2", "text": "Hel"}},
+		{Method: "chat.update", Params: map[string]string{"channel": "C1", "ts": "200.2", "text": "Hello"}},
+		{Method: "files.getUploadURLExternal", Params: map[string]string{"filename": "report.txt", "length": "6"}},
+		{Method: "files.completeUploadExternal", Params: map[string]string{"files": `[{"id":"F1","title":"report.txt"}]`, "channel_id": "C1", "thread_ts": "100.1"}},
+	}
+	if diff := cmp.Diff(wantCalls, slack.calls); diff != "" {
+		t.Errorf("Slack calls mismatch (-want +got):\n%s", diff)
+	}
+	if string(slack.uploaded) != "report" {
+		t.Errorf("uploaded = %q, want %q", slack.uploaded, "report")
+	}
+	if _, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "testApp", UserID: "C1", SessionID: "100.1"}); err != nil {
+		t.Errorf("session of the thread: Get() error = %v", err)
+	}
+}
+
+func TestBot_IgnoredEvents(t *testing.T) {
+	slack := newFakeSlack(t)
+	var got []*genai.Content
+	bot, _ := newTestBot(t, slack, replyAgent(&got))
+
+	for _, ev := range []map[string]any{
+		{"type": "message", "channel_type": "channel", "channel": "C1", "user": "U1", "text": "hi", "ts": "1.1"},
+		{"type": "message", "channel_type": "im", "channel": "D1", "bot_id": "B1", "text": "hi", "ts": "1.2"},
+		{"type": "message", "channel_type": "im", "channel": "D1", "subtype": "message_changed", "ts": "1.3"},
+	} {
+		body, err := json.Marshal(map[string]any{"type": "event_callback", "event": ev})
+		if err != nil {
+			t.Fatal(err)
+		}
+		bot.ServeHTTP(httptest.NewRecorder(), signedRequest(string(body), "application/json"))
+	}
+	bot.Wait()
+	if len(got) != 0 || len(slack.calls) != 0 {
+		t.Errorf("ignored events ran the agent %d times and called Slack %d times, want none", len(got), len(slack.calls))
+	}
+}
+
+func TestBot_SlashCommand(t *testing.T) {
+	slack := newFakeSlack(t)
+	var got []*genai.Content
+	bot, sessionService := newTestBot(t, slack, replyAgent(&got))
+
+	form := url.Values{"command": {"/ask"}, "text": {"what is new?"}, "channel_id": {"C1"}, "user_id": {"U1"}}
+	rec := httptest.NewRecorder()
+	bot.ServeHTTP(rec, signedRequest(form.Encode(), "application/x-www-form-urlencoded"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	bot.Wait()
+
+	if diff := cmp.Diff([]*genai.Content{genai.NewContentFromText("<@U1>: /ask what is new?", genai.RoleUser)}, got); diff != "" {
+		t.Errorf("user content mismatch (-want +got):\n%s", diff)
+	}
+	wantCalls := []call{
+		{Method: "chat.postMessage", Params: map[string]string{"channel": "C1", "text": placeholderText}},
+		{Method: "chat.update", Params: map[string]string{"channel": "C1", "ts": "200.2", "text": "Hel"}},
+		{Method: "chat.update", Params: map[string]string{"channel": "C1", "ts": "200.2", "text": "Hello"}},
+	}
+	if diff := cmp.Diff(wantCalls, slack.calls[:3]); diff != "" {
+		t.Errorf("Slack calls mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "testApp", UserID: "C1", SessionID: "200.2"}); err != nil {
+		t.Errorf("session of the command: Get() error = %v", err)
+	}
+}
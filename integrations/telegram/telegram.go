@@ -0,0 +1,234 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telegram provides a [channel.Adapter] running agents as
+// a Telegram bot, receiving the messages with the long polling of the Bot
+// API.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/adk/integrations/channel"
+	"google.golang.org/adk/internal/logging"
+)
+
+const (
+	defaultAPIURL      = "https://api.telegram.org"
+	defaultPollTimeout = 30 * time.Second
+	// retryDelay is the delay before polling again after an error.
+	retryDelay = time.Second
+	// maxMessageLength is the maximum length of the messages, in runes.
+	maxMessageLength = 4096
+)
+
+// Config is the configuration of an [Adapter].
+type Config struct {
+	// Token is the token of the bot.
+	Token string
+	// APIURL is the base URL of the Bot API. Defaults to
+	// "https://api.telegram.org".
+	APIURL string // optional
+	// HTTPClient is the client calling the Bot API. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client // optional
+	// PollTimeout is the timeout of the long polling. Defaults to 30s.
+	PollTimeout time.Duration // optional
+}
+
+// Adapter is a [channel.Adapter] for Telegram. The chats are the Telegram
+// chats, and the threads are the topics of the forum supergroups.
+type Adapter struct {
+	cfg Config
+}
+
+var _ channel.Adapter = (*Adapter)(nil)
+
+// New returns an [Adapter] for the bot of cfg.Token.
+func New(cfg Config) (*Adapter, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("token is required")
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = defaultAPIURL
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.PollTimeout <= 0 {
+		cfg.PollTimeout = defaultPollTimeout
+	}
+	return &Adapter{cfg: cfg}, nil
+}
+
+// Name returns "telegram".
+func (a *Adapter) Name() string {
+	return "telegram"
+}
+
+type update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *message `json:"message"`
+}
+
+type message struct {
+	MessageID       int64  `json:"message_id"`
+	MessageThreadID int64  `json:"message_thread_id"`
+	IsTopicMessage  bool   `json:"is_topic_message"`
+	From            *user  `json:"from"`
+	Chat            chat   `json:"chat"`
+	Text            string `json:"text"`
+	Caption         string `json:"caption"`
+}
+
+type user struct {
+	ID        int64  `json:"id"`
+	IsBot     bool   `json:"is_bot"`
+	FirstName string `json:"first_name"`
+	Username  string `json:"username"`
+}
+
+type chat struct {
+	ID int64 `json:"id"`
+}
+
+// Receive polls the updates of the bot until ctx is done, and calls handle
+// for the messages with text sent by users. The errors of the polling are
+// logged and the polling retried.
+func (a *Adapter) Receive(ctx context.Context, handle channel.Handler) error {
+	var offset int64
+	for {
+		var updates []update
+		err := a.call(ctx, "getUpdates", map[string]any{
+			"offset":          offset,
+			"timeout":         int(a.cfg.PollTimeout.Seconds()),
+			"allowed_updates": []string{"message"},
+		}, &updates)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "Failed to get the Telegram updates", "error", err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if msg := toMessage(u.Message); msg != nil {
+				handle(ctx, msg)
+			}
+		}
+	}
+}
+
+// toMessage returns the channel message of a Telegram message, or nil if
+// it is not a message with text sent by a user.
+func toMessage(m *message) *channel.Message {
+	if m == nil || m.From == nil || m.From.IsBot {
+		return nil
+	}
+	text := m.Text
+	if text == "" {
+		text = m.Caption
+	}
+	if text == "" {
+		return nil
+	}
+	msg := &channel.Message{
+		ID:       strconv.FormatInt(m.MessageID, 10),
+		ChatID:   strconv.FormatInt(m.Chat.ID, 10),
+		UserID:   strconv.FormatInt(m.From.ID, 10),
+		UserName: m.From.Username,
+		Text:     text,
+	}
+	if msg.UserName == "" {
+		msg.UserName = m.From.FirstName
+	}
+	if m.IsTopicMessage {
+		msg.ThreadID = strconv.FormatInt(m.MessageThreadID, 10)
+	}
+	return msg
+}
+
+// Send sends the text in reply to the message, split in several messages
+// if it is longer than the limit of Telegram.
+func (a *Adapter) Send(ctx context.Context, to *channel.Message, text string) error {
+	for _, chunk := range channel.Split(text, maxMessageLength) {
+		params := map[string]any{
+			"chat_id":          to.ChatID,
+			"text":             chunk,
+			"reply_parameters": map[string]any{"message_id": to.ID, "allow_sending_without_reply": true},
+		}
+		if to.ThreadID != "" {
+			params["message_thread_id"] = to.ThreadID
+		}
+		if err := a.call(ctx, "sendMessage", params, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// call calls the method of the Bot API and decodes its result in out.
+func (a *Adapter) call(ctx context.Context, method string, params, out any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	endpoint := strings.TrimSuffix(a.cfg.APIURL, "/") + "/bot" + a.cfg.Token + "/" + method
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.cfg.HTTPClient.Do(req)
+	if err != nil {
+		// The URL of the error contains the token.
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	var result struct {
+		OK          bool            `json:"ok"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("telegram %s: failed to decode the response: %w", method, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("telegram %s: %s", method, result.Description)
+	}
+	if out != nil {
+		if err := json.Unmarshal(result.Result, out); err != nil {
+			return fmt.Errorf("telegram %s: failed to decode the result: %w", method, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/integrations/channel"
+)
+
+// fakeBotAPI is a fake of the Bot API returning the updates once and
+// recording the sent messages.
+type fakeBotAPI struct {
+	mu      sync.Mutex
+	updates []map[string]any
+	offsets []float64
+	sent    []map[string]any
+}
+
+func (f *fakeBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result any = true
+	switch r.URL.Path {
+	case "/bottoken/getUpdates":
+		f.offsets = append(f.offsets, params["offset"].(float64))
+		result, f.updates = f.updates, []map[string]any{}
+	case "/bottoken/sendMessage":
+		f.sent = append(f.sent, params)
+	default:
+		json.NewEncoder(w).Encode(map[string]any{"ok": false, "description": "Not Found"})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+func newTestAdapter(t *testing.T, api *fakeBotAPI) *Adapter {
+	t.Helper()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	a, err := New(Config{Token: "token", APIURL: server.URL})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return a
+}
+
+func TestAdapter_Receive(t *testing.T) {
+	api := &fakeBotAPI{updates: []map[string]any{
+		{"update_id": 10, "message": map[string]any{"message_id": 1, "chat": map[string]any{"id": -5}, "from": map[string]any{"id": 7, "username": "ann"}, "text": "hi"}},
+		{"update_id": 11, "message": map[string]any{"message_id": 2, "chat": map[string]any{"id": -5}, "from": map[string]any{"id": 8, "is_bot": true}, "text": "beep"}},
+		{"update_id": 12, "message": map[string]any{"message_id": 3, "chat": map[string]any{"id": -5}, "from": map[string]any{"id": 9, "first_name": "Bob"}, "caption": "look", "is_topic_message": true, "message_thread_id": 4}},
+	}}
+	a := newTestAdapter(t, api)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	var got []*channel.Message
+	err := a.Receive(ctx, func(ctx context.Context, msg *channel.Message) {
+		got = append(got, msg)
+		if len(got) == 2 {
+			cancel()
+		}
+	})
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+
+	want := []*channel.Message{
+		{ID: "1", ChatID: "-5", UserID: "7", UserName: "ann", Text: "hi"},
+		{ID: "3", ChatID: "-5", ThreadID: "4", UserID: "9", UserName: "Bob", Text: "look"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Receive() messages mismatch (-want +got):\n%s", diff)
+	}
+	if api.offsets[0] != 0 {
+		t.Errorf("first getUpdates offset = %v, want 0", api.offsets[0])
+	}
+}
+
+func TestAdapter_Send(t *testing.T) {
+	api := &fakeBotAPI{}
+	a := newTestAdapter(t, api)
+
+	text := strings.Repeat("a", maxMessageLength) + " b"
+	if err := a.Send(t.Context(), &channel.Message{ID: "3", ChatID: "-5", ThreadID: "4"}, text); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	reply := map[string]any{"message_id": "3", "allow_sending_without_reply": true}
+	want := []map[string]any{
+		{"chat_id": "-5", "message_thread_id": "4", "text": strings.Repeat("a", maxMessageLength), "reply_parameters": reply},
+		{"chat_id": "-5", "message_thread_id": "4", "text": "b", "reply_parameters": reply},
+	}
+	if diff := cmp.Diff(want, api.sent); diff != "" {
+		t.Errorf("sent messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAdapter_SendError(t *testing.T) {
+	a := newTestAdapter(t, &fakeBotAPI{})
+	a.cfg.Token = "other"
+	err := a.Send(t.Context(), &channel.Message{ID: "1", ChatID: "1"}, "hi")
+	if err == nil || !strings.Contains(err.Error(), "Not Found") {
+		t.Errorf("Send() error = %v, want Not Found", err)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trigger invokes agents on the messages of event sources, e.g.
+// Pub/Sub subscriptions or Kafka topics, for event-driven pipelines.
+//
+// A [Source] receives the messages, which [Serve] runs with the runner.
+// A message is acknowledged once its invocation completes. The failed
+// invocations are retried, and the messages failing every attempt are
+// passed to the [DeadLetter], or left unacknowledged for the source to
+// redeliver them.
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/runner"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBackoff     = time.Second
+)
+
+// Attributes of the messages used by [DefaultMapper].
+const (
+	AttributeUserID    = "user_id"
+	AttributeSessionID = "session_id"
+)
+
+// Message is a message of an event source.
+type Message struct {
+	// ID identifies the message in the source.
+	ID string
+	// Data is the payload of the message.
+	Data []byte
+	// Attributes are the attributes of the message, e.g. the Pub/Sub
+	// attributes or the Kafka headers.
+	Attributes map[string]string // optional
+}
+
+// Handler handles a message of a source. The source acknowledges the
+// message if the handler returns nil.
+type Handler func(ctx context.Context, msg *Message) error
+
+// Source is a source of messages.
+type Source interface {
+	// Name identifies the source, e.g. the subscription.
+	Name() string
+	// Receive receives the messages and calls handle for each of them,
+	// until ctx is done or the source fails. The messages are acknowledged
+	// when handle returns nil.
+	Receive(ctx context.Context, handle Handler) error
+}
+
+// Invocation is the invocation of the agent for a message.
+type Invocation struct {
+	UserID    string
+	SessionID string
+	Content   *genai.Content
+	// StateDelta is applied to the session state with the user content.
+	StateDelta map[string]any // optional
+}
+
+// Mapper returns the invocation of the agent for a message of the named
+// source.
+type Mapper func(source string, msg *Message) (*Invocation, error)
+
+// DefaultMapper maps the data of the message to the user content, as text,
+// and its attributes to the state. The invocation runs in the session of
+// the AttributeUserID and AttributeSessionID attributes, which default to
+// the name of the source and the ID of the message.
+func DefaultMapper(source string, msg *Message) (*Invocation, error) {
+	inv := &Invocation{
+		UserID:    source,
+		SessionID: msg.ID,
+		Content:   genai.NewContentFromText(string(msg.Data), genai.RoleUser),
+	}
+	if id := msg.Attributes[AttributeUserID]; id != "" {
+		inv.UserID = id
+	}
+	if id := msg.Attributes[AttributeSessionID]; id != "" {
+		inv.SessionID = id
+	}
+	if len(msg.Attributes) > 0 {
+		inv.StateDelta = make(map[string]any, len(msg.Attributes))
+		for k, v := range msg.Attributes {
+			inv.StateDelta[k] = v
+		}
+	}
+	return inv, nil
+}
+
+// DeadLetter handles the messages failing every attempt, e.g. by
+// publishing them to a dead-letter topic. The message is acknowledged if
+// DeadLetter returns nil.
+type DeadLetter interface {
+	DeadLetter(ctx context.Context, source string, msg *Message, cause error) error
+}
+
+// DeadLetterFunc is an adapter to use ordinary functions as a [DeadLetter].
+type DeadLetterFunc func(ctx context.Context, source string, msg *Message, cause error) error
+
+// DeadLetter calls f(ctx, source, msg, cause).
+func (f DeadLetterFunc) DeadLetter(ctx context.Context, source string, msg *Message, cause error) error {
+	return f(ctx, source, msg, cause)
+}
+
+// Config is the configuration of [Serve].
+type Config struct {
+	// Runner runs the agent. It must be created with AutoCreateSession
+	// unless the sessions of the messages exist.
+	Runner *runner.Runner
+	// Sources are the sources of the messages.
+	Sources []Source
+	// RunConfig is the configuration of the runs.
+	RunConfig agent.RunConfig // optional
+	// Mapper maps the messages to the invocations. Defaults to
+	// DefaultMapper.
+	Mapper Mapper // optional
+	// MaxAttempts is the number of attempts to run the invocation of
+	// a message. Defaults to 3.
+	MaxAttempts int // optional
+	// Backoff is the delay before the second attempt, doubled for each
+	// following attempt. Defaults to 1s.
+	Backoff time.Duration // optional
+	// DeadLetter handles the messages failing every attempt. If nil, they
+	// are not acknowledged.
+	DeadLetter DeadLetter // optional
+}
+
+// Serve receives the messages of the sources and invokes the agent for
+// each of them until ctx is done or a source fails.
+func Serve(ctx context.Context, cfg Config) error {
+	if cfg.Runner == nil {
+		return errors.New("runner is required")
+	}
+	if len(cfg.Sources) == 0 {
+		return errors.New("at least one source is required")
+	}
+	if cfg.Mapper == nil {
+		cfg.Mapper = DefaultMapper
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultBackoff
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	for _, s := range cfg.Sources {
+		g.Go(func() error {
+			err := s.Receive(gctx, func(ctx context.Context, msg *Message) error {
+				return handle(ctx, cfg, s.Name(), msg)
+			})
+			if err != nil {
+				return fmt.Errorf("source %q failed: %w", s.Name(), err)
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// handle invokes the agent for the message, with retries, and passes it to
+// the dead letter if every attempt fails.
+func handle(ctx context.Context, cfg Config, source string, msg *Message) error {
+	logger := logging.FromContext(ctx).With("source", source, "message_id", msg.ID)
+	inv, err := cfg.Mapper(source, msg)
+	if err == nil {
+		backoff := cfg.Backoff
+		for attempt := 1; ; attempt++ {
+			if err = invoke(ctx, cfg, inv); err == nil {
+				return nil
+			}
+			if attempt == cfg.MaxAttempts || ctx.Err() != nil {
+				break
+			}
+			logger.WarnContext(ctx, "Invocation failed, retrying", "attempt", attempt, "error", err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+	if ctx.Err() != nil {
+		return err
+	}
+	logger.ErrorContext(ctx, "Message failed", "error", err)
+	if cfg.DeadLetter == nil {
+		return err
+	}
+	if dlErr := cfg.DeadLetter.DeadLetter(ctx, source, msg, err); dlErr != nil {
+		return fmt.Errorf("failed to dead-letter the message: %w", errors.Join(dlErr, err))
+	}
+	return nil
+}
+
+// invoke runs the invocation to completion.
+func invoke(ctx context.Context, cfg Config, inv *Invocation) error {
+	var opts []runner.RunOption
+	if inv.StateDelta != nil {
+		opts = append(opts, runner.WithStateDelta(inv.StateDelta))
+	}
+	for _, err := range cfg.Runner.Run(ctx, inv.UserID, inv.SessionID, inv.Content, cfg.RunConfig, opts...) {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+// fakeSource delivers its messages once and records the results.
+type fakeSource struct {
+	messages []*Message
+	results  []error
+}
+
+func (s *fakeSource) Name() string { return "fake" }
+
+func (s *fakeSource) Receive(ctx context.Context, handle Handler) error {
+	for _, msg := range s.messages {
+		s.results = append(s.results, handle(ctx, msg))
+	}
+	return nil
+}
+
+// newTestRunner returns a runner whose agent fails the messages "fail" and
+// fails "flaky" on the first attempt. It records the state of the runs.
+func newTestRunner(t *testing.T, states *[]map[string]any) *runner.Runner {
+	t.Helper()
+	flaky := 0
+	a, err := agent.New(agent.Config{
+		Name: "test_agent",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				text := ctx.UserContent().Parts[0].Text
+				if text == "fail" || (text == "flaky" && flaky == 0) {
+					flaky++
+					yield(nil, errors.New("agent failed"))
+					return
+				}
+				state := map[string]any{}
+				for k, v := range ctx.Session().State().All() {
+					state[k] = v
+				}
+				*states = append(*states, state)
+				ev := session.NewEventWithContext(ctx, ctx.InvocationID())
+				ev.Content = genai.NewContentFromText("done", genai.RoleModel)
+				yield(ev, nil)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+	r, err := runner.New(runner.Config{
+		AppName:           "testApp",
+		Agent:             a,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+	})
+	if err != nil {
+		t.Fatalf("runner.New() error = %v", err)
+	}
+	return r
+}
+
+func TestServe(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		deadLetter bool
+		wantErr    bool
+		wantStates int
+		wantDead   []string
+	}{
+		{name: "success", data: "hello", wantStates: 1},
+		{name: "retried", data: "flaky", wantStates: 1},
+		{name: "dead letter", data: "fail", deadLetter: true, wantDead: []string{"fake/1: agent failed"}},
+		{name: "not acknowledged", data: "fail", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var states []map[string]any
+			source := &fakeSource{messages: []*Message{{ID: "1", Data: []byte(tt.data), Attributes: map[string]string{"kind": "order"}}}}
+			var dead []string
+			cfg := Config{
+				Runner:  newTestRunner(t, &states),
+				Sources: []Source{source},
+				Backoff: time.Millisecond,
+			}
+			if tt.deadLetter {
+				cfg.DeadLetter = DeadLetterFunc(func(ctx context.Context, source string, msg *Message, cause error) error {
+					dead = append(dead, source+"/"+msg.ID+": "+cause.Error())
+					return nil
+				})
+			}
+			if err := Serve(t.Context(), cfg); err != nil {
+				t.Fatalf("Serve() error = %v", err)
+			}
+			if gotErr := source.results[0] != nil; gotErr != tt.wantErr {
+				t.Errorf("handle() error = %v, wantErr %v", source.results[0], tt.wantErr)
+			}
+			if len(states) != tt.wantStates {
+				t.Errorf("agent completed %d runs, want %d", len(states), tt.wantStates)
+			}
+			if tt.wantStates > 0 && states[0]["kind"] != "order" {
+				t.Errorf("state = %v, want the attributes", states[0])
+			}
+			if diff := cmp.Diff(tt.wantDead, dead); diff != "" {
+				t.Errorf("dead letters mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultMapper(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *Message
+		want *Invocation
+	}{
+		{
+			name: "default session",
+			msg:  &Message{ID: "m1", Data: []byte("hi")},
+			want: &Invocation{UserID: "src", SessionID: "m1", Content: genai.NewContentFromText("hi", genai.RoleUser)},
+		},
+		{
+			name: "session attributes",
+			msg:  &Message{ID: "m1", Data: []byte("hi"), Attributes: map[string]string{AttributeUserID: "u", AttributeSessionID: "s"}},
+			want: &Invocation{
+				UserID:     "u",
+				SessionID:  "s",
+				Content:    genai.NewContentFromText("hi", genai.RoleUser),
+				StateDelta: map[string]any{AttributeUserID: "u", AttributeSessionID: "s"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DefaultMapper("src", tt.msg)
+			if err != nil {
+				t.Fatalf("DefaultMapper() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DefaultMapper() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
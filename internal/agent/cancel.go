@@ -0,0 +1,37 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import "context"
+
+type cancelCtxKey struct{}
+
+// WithCancel returns a copy of ctx which can be cancelled with [Cancel] by
+// any context derived from it.
+func WithCancel(ctx context.Context) (context.Context, context.CancelCauseFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return context.WithValue(ctx, cancelCtxKey{}, cancel), cancel
+}
+
+// Cancel cancels the closest context created with [WithCancel] with the
+// given cause. It returns false if ctx is not derived from such context.
+func Cancel(ctx context.Context, cause error) bool {
+	cancel, ok := ctx.Value(cancelCtxKey{}).(context.CancelCauseFunc)
+	if !ok {
+		return false
+	}
+	cancel(cause)
+	return true
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import "context"
+
+type panicStackTracesKey struct{}
+
+// WithPanicStackTraces returns a copy of ctx whose recovered panics carry
+// their stack trace in the events and function responses.
+func WithPanicStackTraces(ctx context.Context) context.Context {
+	return context.WithValue(ctx, panicStackTracesKey{}, true)
+}
+
+// PanicStackTraces reports whether the recovered panics of ctx carry their
+// stack trace, see [WithPanicStackTraces].
+func PanicStackTraces(ctx context.Context) bool {
+	v, _ := ctx.Value(panicStackTracesKey{}).(bool)
+	return v
+}
@@ -14,7 +14,13 @@
 
 package runconfig
 
-import "context"
+import (
+	"context"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+)
 
 type StreamingMode string
 
@@ -26,6 +32,13 @@ const (
 
 type RunConfig struct {
 	StreamingMode StreamingMode
+	DryRun        bool
+	MaxLLMCalls   int
+
+	// LiveRequestQueue and LiveConnectConfig are set in the bidi streaming
+	// mode.
+	LiveRequestQueue  *agent.LiveRequestQueue
+	LiveConnectConfig *genai.LiveConnectConfig
 }
 
 func ToContext(ctx context.Context, cfg *RunConfig) context.Context {
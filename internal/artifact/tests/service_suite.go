@@ -18,8 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -56,6 +58,19 @@ func TestArtifactService(t *testing.T, name string, factory func(t *testing.T) (
 		}
 		testArtifactService_UserScoped(ctx, t, srv, name)
 	})
+	t.Run(fmt.Sprintf("Test%sArtifactService_Stream", name), func(t *testing.T) {
+		ctx := t.Context()
+		// Create the service using the factory for this sub-test
+		srv, err := factory(t)
+		if err != nil {
+			t.Fatalf("Failed to set up service: %v", err)
+		}
+		streamSrv, ok := srv.(artifact.StreamService)
+		if !ok {
+			t.Skipf("%s does not implement artifact.StreamService", name)
+		}
+		testArtifactService_Stream(ctx, t, streamSrv, name)
+	})
 }
 
 func testArtifactService(ctx context.Context, t *testing.T, srv artifact.Service, testSuffix string) {
@@ -409,3 +424,85 @@ func testArtifactService_Empty(ctx context.Context, t *testing.T, srv artifact.S
 		}
 	})
 }
+
+func testArtifactService_Stream(ctx context.Context, t *testing.T, srv artifact.StreamService, testSuffix string) {
+	appName := "testapp"
+	userID := "testuser"
+	sessionID := "testsession"
+
+	for i, content := range []string{"first video", "0123456789"} {
+		resp, err := srv.SaveStream(ctx, &artifact.SaveStreamRequest{
+			AppName: appName, UserID: userID, SessionID: sessionID, FileName: "video.mp4",
+			MIMEType: "video/mp4", Content: strings.NewReader(content),
+		})
+		if err != nil {
+			t.Fatalf("SaveStream() error = %v", err)
+		}
+		if resp.Version != int64(i+1) {
+			t.Errorf("SaveStream() version = %d, want %d", resp.Version, i+1)
+		}
+	}
+
+	t.Run(fmt.Sprintf("Load_%s", testSuffix), func(t *testing.T) {
+		resp, err := srv.Load(ctx, &artifact.LoadRequest{AppName: appName, UserID: userID, SessionID: sessionID, FileName: "video.mp4"})
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if diff := cmp.Diff(genai.NewPartFromBytes([]byte("0123456789"), "video/mp4"), resp.Part); diff != "" {
+			t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run(fmt.Sprintf("OpenRange_%s", testSuffix), func(t *testing.T) {
+		resp, err := srv.Open(ctx, &artifact.LoadRequest{AppName: appName, UserID: userID, SessionID: sessionID, FileName: "video.mp4"})
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer resp.Content.Close()
+		if resp.Version != 2 || resp.Size != 10 || resp.MIMEType != "video/mp4" {
+			t.Errorf("Open() = version %d, size %d, MIME type %q, want version 2, size 10, video/mp4", resp.Version, resp.Size, resp.MIMEType)
+		}
+		if _, err := resp.Content.Seek(-4, io.SeekEnd); err != nil {
+			t.Fatalf("Seek() error = %v", err)
+		}
+		got, err := io.ReadAll(resp.Content)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if string(got) != "6789" {
+			t.Errorf("content after seeking = %q, want %q", got, "6789")
+		}
+		if _, err := resp.Content.Seek(2, io.SeekStart); err != nil {
+			t.Fatalf("Seek() error = %v", err)
+		}
+		buf := make([]byte, 3)
+		if _, err := io.ReadFull(resp.Content, buf); err != nil {
+			t.Fatalf("ReadFull() error = %v", err)
+		}
+		if string(buf) != "234" {
+			t.Errorf("content after seeking back = %q, want %q", buf, "234")
+		}
+	})
+
+	t.Run(fmt.Sprintf("OpenVersion_%s", testSuffix), func(t *testing.T) {
+		resp, err := srv.Open(ctx, &artifact.LoadRequest{AppName: appName, UserID: userID, SessionID: sessionID, FileName: "video.mp4", Version: 1})
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer resp.Content.Close()
+		got, err := io.ReadAll(resp.Content)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if string(got) != "first video" {
+			t.Errorf("content = %q, want %q", got, "first video")
+		}
+	})
+
+	t.Run(fmt.Sprintf("OpenUnknown_%s", testSuffix), func(t *testing.T) {
+		_, err := srv.Open(ctx, &artifact.LoadRequest{AppName: appName, UserID: userID, SessionID: sessionID, FileName: "unknown.mp4"})
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Open() error = %v, want fs.ErrNotExist", err)
+		}
+	})
+}
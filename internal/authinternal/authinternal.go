@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package authinternal resolves the credentials of tools, see the auth
+// package.
+package authinternal
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/adk/auth"
+	"google.golang.org/adk/internal/logging"
+)
+
+// Config is the credential handling of the invocations of a runner.
+type Config struct {
+	// Store stores the credentials per user. If nil, they are not stored.
+	Store auth.Store
+	// Exchanger defaults to auth.OAuth2Exchanger.
+	Exchanger auth.Exchanger
+}
+
+type configKey struct{}
+
+// ToContext returns a context whose tools resolve credentials according to
+// cfg.
+func ToContext(ctx context.Context, cfg Config) context.Context {
+	return context.WithValue(ctx, configKey{}, cfg)
+}
+
+func configFrom(ctx context.Context) Config {
+	cfg, _ := ctx.Value(configKey{}).(Config)
+	if cfg.Exchanger == nil {
+		cfg.Exchanger = auth.OAuth2Exchanger{}
+	}
+	return cfg
+}
+
+type responsesKey struct{}
+
+// WithResponses returns a context carrying the credentials returned by the
+// client for the auth requests of tools, by the ID of their function call.
+func WithResponses(ctx context.Context, responses map[string]*auth.Credential) context.Context {
+	return context.WithValue(ctx, responsesKey{}, responses)
+}
+
+func response(ctx context.Context, functionCallID string) *auth.Credential {
+	responses, _ := ctx.Value(responsesKey{}).(map[string]*auth.Credential)
+	return responses[functionCallID]
+}
+
+// Resolve returns the credential of cfg for the function call of a tool, or
+// nil if the tool has to request it. It is, in order:
+//   - the raw credential of cfg, if it can be used as is, e.g. an API key;
+//   - the credential stored for key, refreshed if expired;
+//   - the credential returned by the client for the function call, exchanged
+//     and stored.
+func Resolve(ctx context.Context, cfg auth.Config, key auth.StoreKey, functionCallID string) (*auth.Credential, error) {
+	if usable(cfg.RawCredential) {
+		return cfg.RawCredential, nil
+	}
+	handling := configFrom(ctx)
+
+	if handling.Store != nil {
+		stored, err := handling.Store.Load(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil && !stored.Expired() {
+			return stored, nil
+		}
+		if stored != nil {
+			refreshed, err := handling.Exchanger.Refresh(ctx, cfg, stored)
+			switch {
+			case err == nil:
+				if err := handling.Store.Save(ctx, key, refreshed); err != nil {
+					return nil, err
+				}
+				return refreshed, nil
+			case !errors.Is(err, auth.ErrNotRefreshable):
+				// The user authorizes the tool again, e.g. after revoking
+				// its access.
+				logging.FromContext(ctx).WarnContext(ctx, "Failed to refresh credential", "credential_key", key.CredentialKey, "error", err)
+			}
+		}
+	}
+
+	resp := response(ctx, functionCallID)
+	if resp == nil {
+		return nil, nil
+	}
+	exchanged, err := handling.Exchanger.Exchange(ctx, cfg, resp)
+	if err != nil {
+		return nil, err
+	}
+	if handling.Store != nil {
+		if err := handling.Store.Save(ctx, key, exchanged); err != nil {
+			return nil, err
+		}
+	}
+	return exchanged, nil
+}
+
+// usable reports whether cred can be used without exchange.
+func usable(cred *auth.Credential) bool {
+	switch {
+	case cred == nil:
+		return false
+	case cred.APIKey != "":
+		return true
+	case cred.HTTP != nil && cred.HTTP.Token != "":
+		return true
+	case cred.OAuth2 != nil && cred.OAuth2.AccessToken != "":
+		return !cred.Expired()
+	}
+	return false
+}
@@ -28,6 +28,7 @@ import (
 	"google.golang.org/adk/agent/workflowagents/sequentialagent"
 	"google.golang.org/adk/internal/llminternal/googlellm"
 	"google.golang.org/adk/model/gemini"
+	"google.golang.org/adk/secrets"
 	"google.golang.org/adk/tool"
 )
 
@@ -111,6 +112,8 @@ type llmAgentYAMLConfig struct {
 	DisallowTransferToParent bool `yaml:"disallow_transfer_to_parent,omitempty"`
 
 	GenerateContentConfig *genai.GenerateContentConfig `yaml:"generate_content_config,omitempty"`
+
+	GenerationProfile string `yaml:"generation_profile,omitempty"`
 }
 
 func (c *llmAgentYAMLConfig) toLLMAgentConfig(ctx context.Context) (*llmagent.Config, error) {
@@ -156,6 +159,7 @@ func (c *llmAgentYAMLConfig) toLLMAgentConfig(ctx context.Context) (*llmagent.Co
 		Tools:                    tools,
 		Toolsets:                 toolsets,
 		GenerateContentConfig:    c.GenerateContentConfig,
+		GenerationProfile:        c.GenerationProfile,
 		BeforeAgentCallbacks:     beforeCallbacks,
 		AfterAgentCallbacks:      afterCallbacks,
 	}, nil
@@ -248,7 +252,11 @@ func resolveTools(ctx context.Context, parentPath string, toolConfigs []ToolConf
 	for _, tc := range toolConfigs {
 		if tc.Name != "" {
 			ctx = context.WithValue(ctx, parentPathKey, parentPath)
-			a, ts, err := ResolveToolReference(ctx, tc.Name, tc.Args)
+			args, err := resolveSecretArgs(ctx, tc.Args)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to resolve the args of tool %s: %w", tc.Name, err)
+			}
+			a, ts, err := ResolveToolReference(ctx, tc.Name, args)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to resolve tool reference %s: %w", tc.Name, err)
 			}
@@ -263,6 +271,48 @@ func resolveTools(ctx context.Context, parentPath string, toolConfigs []ToolConf
 	return tools, toolsets, nil
 }
 
+// resolveSecretArgs returns a copy of the args of a tool or toolset, e.g. the
+// API key of a toolset, whose secret references are resolved.
+func resolveSecretArgs(ctx context.Context, args map[string]any) (map[string]any, error) {
+	if args == nil {
+		return nil, nil
+	}
+	resolved, err := resolveSecretValue(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.(map[string]any), nil
+}
+
+func resolveSecretValue(ctx context.Context, v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return secrets.Resolve(ctx, v)
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, value := range v {
+			resolved, err := resolveSecretValue(ctx, value)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = resolved
+		}
+		return m, nil
+	case []any:
+		s := make([]any, len(v))
+		for i, value := range v {
+			resolved, err := resolveSecretValue(ctx, value)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = resolved
+		}
+		return s, nil
+	default:
+		return v, nil
+	}
+}
+
 func resolveCallbacks[T any](ctx context.Context, callbacks []codeConfig) ([]T, error) {
 	var cbs []T
 	for _, ref := range callbacks {
@@ -25,6 +25,7 @@ import (
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/auth"
 	"google.golang.org/adk/internal/configurable/conformance/replayplugin"
 	"google.golang.org/adk/memory"
 	"google.golang.org/adk/model"
@@ -397,7 +398,9 @@ func (m *MockInvocationContext) Memory() agent.Memory
 func (m *MockInvocationContext) Branch() string                                          { return "" }
 func (m *MockInvocationContext) UserContent() *genai.Content                             { return nil }
 func (m *MockInvocationContext) RunConfig() *agent.RunConfig                             { return nil } // Use context? No, RunConfig struct.
+func (m *MockInvocationContext) TranscriptionCache() *agent.TranscriptionCache           { return nil }
 func (m *MockInvocationContext) EndInvocation()                                          {}
+func (m *MockInvocationContext) Cancel(string)                                           {}
 func (m *MockInvocationContext) Ended() bool                                             { return false }
 func (m *MockInvocationContext) WithContext(ctx context.Context) agent.InvocationContext { return m }
 func (m *MockInvocationContext) Value(key any) any                                       { return nil }
@@ -445,6 +448,8 @@ func (m *MockToolContext) SearchMemory(ctx context.Context, query string) (*memo
 }
 func (m *MockToolContext) ToolConfirmation() *toolconfirmation.ToolConfirmation { return nil }
 func (m *MockToolContext) RequestConfirmation(hint string, payload any) error   { return nil }
+func (m *MockToolContext) Credential(cfg auth.Config) (*auth.Credential, error) { return nil, nil }
+func (m *MockToolContext) RequestCredential(cfg auth.Config) error              { return nil }
 func (m *MockToolContext) AppName() string                                      { return "mock-app" }
 func (m *MockToolContext) Branch() string                                       { return "" }
 func (m *MockToolContext) SessionID() string                                    { return "mock-session-id" }
@@ -60,7 +60,10 @@ func TestWithContext(t *testing.T) {
 	if got.Value(key) != val {
 		t.Errorf("WithContext() did not update context")
 	}
-	if diff := cmp.Diff(inv, got, cmp.AllowUnexported(InvocationContext{}), cmpopts.IgnoreFields(InvocationContext{}, "Context")); diff != "" {
+	if diff := cmp.Diff(inv, got, cmp.AllowUnexported(InvocationContext{}), cmpopts.IgnoreFields(InvocationContext{}, "Context"), cmpopts.IgnoreUnexported(agent.TranscriptionCache{})); diff != "" {
 		t.Errorf("WithContext() mismatch (-want +got):\n%s", diff)
 	}
+	if got.TranscriptionCache() != inv.TranscriptionCache() {
+		t.Errorf("WithContext() did not share the TranscriptionCache")
+	}
 }
@@ -17,7 +17,6 @@ package context
 import (
 	"context"
 
-	"github.com/google/uuid"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
@@ -36,11 +35,17 @@ type InvocationContextParams struct {
 	RunConfig     *agent.RunConfig
 	EndInvocation bool
 	InvocationID  string
+	// TranscriptionCache is shared with the contexts derived from this one,
+	// created if nil.
+	TranscriptionCache *agent.TranscriptionCache
 }
 
 func NewInvocationContext(ctx context.Context, params InvocationContextParams) agent.InvocationContext {
 	if params.InvocationID == "" {
-		params.InvocationID = "e-" + uuid.NewString()
+		params.InvocationID = "e-" + session.NewID(ctx)
+	}
+	if params.TranscriptionCache == nil {
+		params.TranscriptionCache = agent.NewTranscriptionCache()
 	}
 	return &InvocationContext{
 		Context: ctx,
@@ -86,12 +91,20 @@ func (c *InvocationContext) RunConfig() *agent.RunConfig {
 	return c.params.RunConfig
 }
 
+func (c *InvocationContext) TranscriptionCache() *agent.TranscriptionCache {
+	return c.params.TranscriptionCache
+}
+
 func (c *InvocationContext) EndInvocation() {
 	c.params.EndInvocation = true
 }
 
+func (c *InvocationContext) Cancel(reason string) {
+	agent.CancelInvocation(c, reason)
+}
+
 func (c *InvocationContext) Ended() bool {
-	return c.params.EndInvocation
+	return c.params.EndInvocation || agent.IsInvocationCancelled(c)
 }
 
 func (c *InvocationContext) WithContext(ctx context.Context) agent.InvocationContext {
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package convert provides helpers to build and combine the genai types of
+// the model requests: generate content configs, system instructions, tool
+// declarations, contents and function call and response parts.
+package convert
+
+import (
+	"fmt"
+	"reflect"
+
+	"google.golang.org/genai"
+)
+
+// CloneContent returns a deep copy of the content, which can be modified
+// without changing the content of an event.
+func CloneContent(c *genai.Content) *genai.Content {
+	return Clone(c)
+}
+
+// CloneContents returns a deep copy of the contents.
+func CloneContents(contents []*genai.Content) []*genai.Content {
+	return Clone(contents)
+}
+
+// Clone returns a deep copy of the src.
+// NOTE: this does not work for types with unexported fields.
+func Clone[M any](src M) M {
+	val := reflect.ValueOf(src)
+
+	// Handle nil pointers
+	if val.Kind() == reflect.Pointer && val.IsNil() {
+		var zero M
+		return zero
+	}
+
+	srcIsPointer := val.Kind() == reflect.Pointer
+
+	// Dereference pointer to get the underlying value
+	if srcIsPointer {
+		val = val.Elem()
+	}
+
+	// Create a new instance of the same type
+	newVal := reflect.New(val.Type()).Elem()
+
+	// Recursively copy fields
+	deepCopy(val, newVal)
+
+	// Return as the original type
+	if srcIsPointer {
+		return newVal.Addr().Interface().(M)
+	}
+	return newVal.Interface().(M)
+}
+
+// deepCopy copies src to dst using reflect.
+func deepCopy(src, dst reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		t := src.Type()
+		for i := 0; i < src.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				panic(fmt.Sprintf("deepCopy: unexported field %q in type %q", t.Field(i).Name, t.Name()))
+			}
+			// The fields of dst are addressable, they are copied in place.
+			deepCopy(src.Field(i), dst.Field(i))
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Cap()))
+		for i := 0; i < src.Len(); i++ {
+			// The elements of the new slice are copied in place.
+			deepCopy(src.Index(i), dst.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		// The copies of the keys and values are made in temporaries reused
+		// for all the entries, SetMapIndex copies them into the map.
+		keyCopy := reflect.New(src.Type().Key()).Elem()
+		valCopy := reflect.New(src.Type().Elem()).Elem()
+		for iter := src.MapRange(); iter.Next(); {
+			keyCopy.SetZero()
+			deepCopy(iter.Key(), keyCopy)
+			valCopy.SetZero()
+			deepCopy(iter.Value(), valCopy)
+			dst.SetMapIndex(keyCopy, valCopy)
+		}
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		// Create a new pointer and deep copy the underlying value
+		newPtr := reflect.New(src.Elem().Type())
+		deepCopy(src.Elem(), newPtr.Elem())
+		dst.Set(newPtr)
+	default:
+		// For basic types, direct assignment is sufficient
+		dst.Set(src)
+	}
+}
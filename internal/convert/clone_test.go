@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package llminternal
+package convert
 
 import (
 	"reflect"
@@ -44,7 +44,7 @@ func TestClone(t *testing.T) {
 
 	check := func(t *testing.T, original, cloned *testStruct) {
 		if !reflect.DeepEqual(original, cloned) {
-			t.Errorf("clone() = %+v, want %+v", cloned, original)
+			t.Errorf("Clone() = %+v, want %+v", cloned, original)
 		}
 
 		// Modify cloned and check if original is affected
@@ -54,7 +54,7 @@ func TestClone(t *testing.T) {
 		cloned.N.S = "nested2"
 
 		if reflect.DeepEqual(original, cloned) {
-			t.Errorf("clone() should not be affected by modifications to original")
+			t.Errorf("Clone() should not be affected by modifications to original")
 		}
 		if original.Sl[0] != "a" {
 			t.Errorf("original slice was modified")
@@ -72,20 +72,20 @@ func TestClone(t *testing.T) {
 
 	t.Run("pointer", func(t *testing.T) {
 		original := testData()
-		cloned := clone(original)
+		cloned := Clone(original)
 		check(t, original, cloned)
 	})
 	t.Run("value", func(t *testing.T) {
 		original := testData()
-		cloned := clone(*original)
+		cloned := Clone(*original)
 		check(t, original, &cloned)
 	})
 	t.Run("interface", func(t *testing.T) {
 		original := testData()
-		cloned := clone(any(original))
+		cloned := Clone(any(original))
 		typed, ok := cloned.(*testStruct)
 		if !ok {
-			t.Fatalf("clone failed with interface: %v", cloned)
+			t.Fatalf("Clone failed with interface: %v", cloned)
 		}
 		check(t, original, typed)
 	})
@@ -93,9 +93,9 @@ func TestClone(t *testing.T) {
 
 func TestCloneNil(t *testing.T) {
 	var original *int
-	cloned := clone(original)
+	cloned := Clone(original)
 	if cloned != nil {
-		t.Errorf("clone(nil) = %v, want nil", cloned)
+		t.Errorf("Clone(nil) = %v, want nil", cloned)
 	}
 }
 
@@ -107,8 +107,8 @@ func TestCloneUnexported(t *testing.T) {
 
 	defer func() {
 		if r := recover(); r == nil {
-			t.Errorf("clone() did not panic on unexported field")
+			t.Errorf("Clone() did not panic on unexported field")
 		}
 	}()
-	clone(original)
+	Clone(original)
 }
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"reflect"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// MergeConfigs merges the configs into a new config, the later configs
+// taking precedence: a field set in a config overrides the field of the
+// previous ones, except for the system instructions, which are combined with
+// CombineInstructions, and the tools, which are concatenated with DedupTools.
+// The configs are not modified and nil configs are ignored. It returns nil if
+// all the configs are nil.
+func MergeConfigs(configs ...*genai.GenerateContentConfig) *genai.GenerateContentConfig {
+	var merged *genai.GenerateContentConfig
+	for _, cfg := range configs {
+		if cfg == nil {
+			continue
+		}
+		if merged == nil {
+			merged = Clone(cfg)
+			continue
+		}
+		src, dst := reflect.ValueOf(cfg).Elem(), reflect.ValueOf(merged).Elem()
+		for i := range src.NumField() {
+			switch src.Type().Field(i).Name {
+			case "SystemInstruction":
+				merged.SystemInstruction = CombineInstructions(merged.SystemInstruction, Clone(cfg.SystemInstruction))
+			case "Tools":
+				merged.Tools = DedupTools(append(merged.Tools, Clone(cfg.Tools)...))
+			default:
+				if field := src.Field(i); !field.IsZero() {
+					fieldCopy := reflect.New(field.Type()).Elem()
+					deepCopy(field, fieldCopy)
+					dst.Field(i).Set(fieldCopy)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// CombineInstructions combines the system instructions into one content with
+// the role of the first one, genai.RoleUser by default. Consecutive text
+// parts are joined with a blank line, the other parts are kept as is. It
+// returns nil if all the instructions are nil or empty.
+func CombineInstructions(instructions ...*genai.Content) *genai.Content {
+	var combined *genai.Content
+	for _, inst := range instructions {
+		if inst == nil || len(inst.Parts) == 0 {
+			continue
+		}
+		if combined == nil {
+			role := inst.Role
+			if role == "" {
+				role = genai.RoleUser
+			}
+			combined = &genai.Content{Role: role}
+		}
+		for _, p := range inst.Parts {
+			if p == nil {
+				continue
+			}
+			if last := len(combined.Parts) - 1; last >= 0 && isText(combined.Parts[last]) && isText(p) {
+				// Copy the part not to modify the instruction it comes from.
+				joined := *combined.Parts[last]
+				joined.Text += "\n\n" + p.Text
+				combined.Parts[last] = &joined
+				continue
+			}
+			combined.Parts = append(combined.Parts, p)
+		}
+	}
+	return combined
+}
+
+// AppendInstructions appends the instructions, joined with a blank line, to
+// the system instruction of the config.
+func AppendInstructions(cfg *genai.GenerateContentConfig, instructions ...string) {
+	if len(instructions) == 0 {
+		return
+	}
+	cfg.SystemInstruction = CombineInstructions(cfg.SystemInstruction, genai.NewContentFromText(strings.Join(instructions, "\n\n"), genai.RoleUser))
+}
+
+// isText reports whether the part is a plain text part, which can be joined
+// with other text parts.
+func isText(p *genai.Part) bool {
+	return p.Text != "" && !p.Thought && len(p.ThoughtSignature) == 0
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+func TestMergeConfigs(t *testing.T) {
+	search := &genai.Tool{GoogleSearch: &genai.GoogleSearch{}}
+	base := &genai.GenerateContentConfig{
+		Temperature:       genai.Ptr[float32](0.2),
+		MaxOutputTokens:   100,
+		StopSequences:     []string{"END"},
+		SystemInstruction: genai.NewContentFromText("Be brief.", genai.RoleUser),
+		Tools: []*genai.Tool{
+			{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_weather"}}},
+			search,
+		},
+	}
+	override := &genai.GenerateContentConfig{
+		Temperature:       genai.Ptr[float32](0.7),
+		SystemInstruction: genai.NewContentFromText("Answer in French.", genai.RoleUser),
+		Tools: []*genai.Tool{
+			{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_weather"}, {Name: "get_time"}}},
+			search,
+		},
+	}
+
+	got := MergeConfigs(base, nil, override)
+	want := &genai.GenerateContentConfig{
+		Temperature:       genai.Ptr[float32](0.7),
+		MaxOutputTokens:   100,
+		StopSequences:     []string{"END"},
+		SystemInstruction: genai.NewContentFromText("Be brief.\n\nAnswer in French.", genai.RoleUser),
+		Tools: []*genai.Tool{
+			{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_weather"}}},
+			search,
+			{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_time"}}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MergeConfigs() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The merged config doesn't share the values of the configs.
+	got.StopSequences[0] = "STOP"
+	*got.Temperature = 1
+	got.SystemInstruction.Parts[0].Text = "changed"
+	if base.StopSequences[0] != "END" || *override.Temperature != 0.7 || base.SystemInstruction.Parts[0].Text != "Be brief." {
+		t.Errorf("MergeConfigs() result shares the values of the configs")
+	}
+
+	if got := MergeConfigs(nil, nil); got != nil {
+		t.Errorf("MergeConfigs(nil, nil) = %v, want nil", got)
+	}
+}
+
+func TestCombineInstructions(t *testing.T) {
+	image := &genai.Part{InlineData: &genai.Blob{MIMEType: "image/png", Data: []byte("png")}}
+	first := &genai.Content{Role: "system", Parts: []*genai.Part{{Text: "You are a helpful assistant."}}}
+	second := &genai.Content{Parts: []*genai.Part{{Text: "Be brief."}, image, {Text: "Use the image."}}}
+
+	got := CombineInstructions(nil, first, &genai.Content{}, second)
+	want := &genai.Content{Role: "system", Parts: []*genai.Part{
+		{Text: "You are a helpful assistant.\n\nBe brief."},
+		image,
+		{Text: "Use the image."},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CombineInstructions() mismatch (-want +got):\n%s", diff)
+	}
+	if first.Parts[0].Text != "You are a helpful assistant." {
+		t.Errorf("CombineInstructions() modified its input: %q", first.Parts[0].Text)
+	}
+
+	if got := CombineInstructions(nil, &genai.Content{}); got != nil {
+		t.Errorf("CombineInstructions() of empty instructions = %v, want nil", got)
+	}
+}
+
+func TestAppendInstructions(t *testing.T) {
+	cfg := &genai.GenerateContentConfig{}
+	AppendInstructions(cfg)
+	if cfg.SystemInstruction != nil {
+		t.Errorf("AppendInstructions() without instructions set %v", cfg.SystemInstruction)
+	}
+	AppendInstructions(cfg, "Be brief.", "Answer in French.")
+	AppendInstructions(cfg, "Use metric units.")
+	want := genai.NewContentFromText("Be brief.\n\nAnswer in French.\n\nUse metric units.", genai.RoleUser)
+	if diff := cmp.Diff(want, cfg.SystemInstruction); diff != "" {
+		t.Errorf("AppendInstructions() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import "google.golang.org/genai"
+
+// FunctionCalls returns the function calls of the parts of the content.
+func FunctionCalls(c *genai.Content) []*genai.FunctionCall {
+	if c == nil {
+		return nil
+	}
+	var calls []*genai.FunctionCall
+	for _, p := range c.Parts {
+		if p != nil && p.FunctionCall != nil {
+			calls = append(calls, p.FunctionCall)
+		}
+	}
+	return calls
+}
+
+// FunctionResponses returns the function responses of the parts of the
+// content.
+func FunctionResponses(c *genai.Content) []*genai.FunctionResponse {
+	if c == nil {
+		return nil
+	}
+	var responses []*genai.FunctionResponse
+	for _, p := range c.Parts {
+		if p != nil && p.FunctionResponse != nil {
+			responses = append(responses, p.FunctionResponse)
+		}
+	}
+	return responses
+}
+
+// FunctionResponsePart returns the part answering the function call with
+// the response, with the ID and the name of the call.
+func FunctionResponsePart(call *genai.FunctionCall, response map[string]any) *genai.Part {
+	return &genai.Part{FunctionResponse: &genai.FunctionResponse{
+		ID:       call.ID,
+		Name:     call.Name,
+		Response: response,
+	}}
+}
+
+// FunctionResponseContent returns the user content answering the function
+// call with the response, see FunctionResponsePart.
+func FunctionResponseContent(call *genai.FunctionCall, response map[string]any) *genai.Content {
+	return &genai.Content{
+		Role:  genai.RoleUser,
+		Parts: []*genai.Part{FunctionResponsePart(call, response)},
+	}
+}
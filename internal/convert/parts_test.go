@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+func TestFunctionParts(t *testing.T) {
+	call := &genai.FunctionCall{ID: "call-1", Name: "get_weather", Args: map[string]any{"city": "Paris"}}
+	content := &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{
+		genai.NewPartFromText("Let me check."),
+		{FunctionCall: call},
+		nil,
+	}}
+	if diff := cmp.Diff([]*genai.FunctionCall{call}, FunctionCalls(content)); diff != "" {
+		t.Errorf("FunctionCalls() mismatch (-want +got):\n%s", diff)
+	}
+	if got := FunctionResponses(content); got != nil {
+		t.Errorf("FunctionResponses() = %v, want nil", got)
+	}
+
+	got := FunctionResponseContent(call, map[string]any{"weather": "sunny"})
+	want := &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{{FunctionResponse: &genai.FunctionResponse{
+		ID:       "call-1",
+		Name:     "get_weather",
+		Response: map[string]any{"weather": "sunny"},
+	}}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FunctionResponseContent() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want.Parts[0].FunctionResponse, FunctionResponses(got)[0]); diff != "" {
+		t.Errorf("FunctionResponses() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"reflect"
+	"slices"
+
+	"google.golang.org/genai"
+)
+
+// AddFunctionDeclarations adds the function declarations to the config. All
+// the function declarations are packed into the first tool that has function
+// declarations, created if there is none, as the models expect. The
+// declarations with the name of a declaration of the config are skipped.
+func AddFunctionDeclarations(cfg *genai.GenerateContentConfig, decls ...*genai.FunctionDeclaration) {
+	declared := declaredFunctions(cfg.Tools)
+	var funcTool *genai.Tool
+	for _, t := range cfg.Tools {
+		if t != nil && t.FunctionDeclarations != nil {
+			funcTool = t
+			break
+		}
+	}
+	for _, decl := range decls {
+		if decl == nil || declared[decl.Name] {
+			continue
+		}
+		declared[decl.Name] = true
+		if funcTool == nil {
+			funcTool = &genai.Tool{}
+			cfg.Tools = append(cfg.Tools, funcTool)
+		}
+		funcTool.FunctionDeclarations = append(funcTool.FunctionDeclarations, decl)
+	}
+}
+
+// DedupTools returns the tools without the repeated ones: the tools equal to
+// a previous tool and the function declarations with the name of a
+// previous declaration. The tools left without declarations are dropped. The
+// tools are not modified.
+func DedupTools(tools []*genai.Tool) []*genai.Tool {
+	var deduped []*genai.Tool
+	declared := make(map[string]bool)
+	for _, t := range tools {
+		if t == nil || slices.ContainsFunc(deduped, func(d *genai.Tool) bool { return reflect.DeepEqual(d, t) }) {
+			continue
+		}
+		if len(t.FunctionDeclarations) == 0 {
+			deduped = append(deduped, t)
+			continue
+		}
+		var decls []*genai.FunctionDeclaration
+		for _, decl := range t.FunctionDeclarations {
+			if decl == nil || declared[decl.Name] {
+				continue
+			}
+			declared[decl.Name] = true
+			decls = append(decls, decl)
+		}
+		switch {
+		case len(decls) == len(t.FunctionDeclarations):
+			deduped = append(deduped, t)
+		case len(decls) > 0 || !onlyFunctions(t):
+			copied := *t
+			copied.FunctionDeclarations = decls
+			deduped = append(deduped, &copied)
+		}
+	}
+	return deduped
+}
+
+// declaredFunctions returns the names of the function declarations of the
+// tools.
+func declaredFunctions(tools []*genai.Tool) map[string]bool {
+	declared := make(map[string]bool)
+	for _, t := range tools {
+		if t == nil {
+			continue
+		}
+		for _, decl := range t.FunctionDeclarations {
+			if decl != nil {
+				declared[decl.Name] = true
+			}
+		}
+	}
+	return declared
+}
+
+// onlyFunctions reports whether the tool only declares functions, and is
+// useless without them.
+func onlyFunctions(t *genai.Tool) bool {
+	copied := *t
+	copied.FunctionDeclarations = nil
+	return reflect.ValueOf(copied).IsZero()
+}
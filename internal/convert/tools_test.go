@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+func TestAddFunctionDeclarations(t *testing.T) {
+	search := &genai.Tool{GoogleSearch: &genai.GoogleSearch{}}
+	cfg := &genai.GenerateContentConfig{Tools: []*genai.Tool{search}}
+
+	AddFunctionDeclarations(cfg, &genai.FunctionDeclaration{Name: "get_weather"}, nil)
+	AddFunctionDeclarations(cfg, &genai.FunctionDeclaration{Name: "get_time"}, &genai.FunctionDeclaration{Name: "get_weather"})
+	AddFunctionDeclarations(cfg)
+
+	want := []*genai.Tool{
+		search,
+		{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_weather"}, {Name: "get_time"}}},
+	}
+	if diff := cmp.Diff(want, cfg.Tools); diff != "" {
+		t.Errorf("AddFunctionDeclarations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDedupTools(t *testing.T) {
+	search := &genai.Tool{GoogleSearch: &genai.GoogleSearch{}}
+	weather := &genai.Tool{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_weather"}}}
+	repeated := &genai.Tool{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_weather"}, {Name: "get_time"}}}
+	onlyRepeated := &genai.Tool{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_time"}}}
+
+	got := DedupTools([]*genai.Tool{weather, search, nil, search, repeated, onlyRepeated, weather})
+	want := []*genai.Tool{
+		weather,
+		search,
+		{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_time"}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DedupTools() mismatch (-want +got):\n%s", diff)
+	}
+	if len(repeated.FunctionDeclarations) != 2 {
+		t.Errorf("DedupTools() modified its input: %v", repeated.FunctionDeclarations)
+	}
+}
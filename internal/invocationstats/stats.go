@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package invocationstats collects the model and tool usage of an invocation.
+package invocationstats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// Stats is safe for concurrent use, e.g. by parallel agents.
+type Stats struct {
+	mu sync.Mutex
+
+	LLMCalls         int
+	PromptTokens     int32
+	CandidatesTokens int32
+	TotalTokens      int32
+	LLMDuration      time.Duration
+	ToolCalls        int
+	ToolDuration     time.Duration
+}
+
+// RecordLLMCall records a completed model call.
+func (s *Stats) RecordLLMCall(d time.Duration, usage *genai.GenerateContentResponseUsageMetadata) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.LLMCalls++
+	s.LLMDuration += d
+	if usage != nil {
+		s.PromptTokens += usage.PromptTokenCount
+		s.CandidatesTokens += usage.CandidatesTokenCount
+		s.TotalTokens += usage.TotalTokenCount
+	}
+}
+
+// RecordToolCall records a completed tool call.
+func (s *Stats) RecordToolCall(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ToolCalls++
+	s.ToolDuration += d
+}
+
+// Snapshot returns a copy of the stats.
+func (s *Stats) Snapshot() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Stats{
+		LLMCalls:         s.LLMCalls,
+		PromptTokens:     s.PromptTokens,
+		CandidatesTokens: s.CandidatesTokens,
+		TotalTokens:      s.TotalTokens,
+		LLMDuration:      s.LLMDuration,
+		ToolCalls:        s.ToolCalls,
+		ToolDuration:     s.ToolDuration,
+	}
+}
+
+type ctxKey struct{}
+
+func ToContext(ctx context.Context, s *Stats) context.Context {
+	return context.WithValue(ctx, ctxKey{}, s)
+}
+
+// FromContext returns the stats of the invocation, or nil if they are not
+// collected.
+func FromContext(ctx context.Context) *Stats {
+	s, _ := ctx.Value(ctxKey{}).(*Stats)
+	return s
+}
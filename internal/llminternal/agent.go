@@ -18,8 +18,10 @@ import (
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/history"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/tool"
+	"google.golang.org/adk/toolselect"
 )
 
 // holds LLMAgent internal state
@@ -34,8 +36,11 @@ type State struct {
 	Toolsets []tool.Toolset
 
 	IncludeContents string
+	HistoryStrategy history.Strategy
+	ToolSelector    toolselect.Selector
 
 	GenerateContentConfig *genai.GenerateContentConfig
+	GenerationProfile     string
 
 	Instruction               string
 	InstructionProvider       InstructionProvider
@@ -26,6 +26,7 @@ import (
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/internal/agent/parentmap"
+	"google.golang.org/adk/internal/convert"
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/internal/utils"
 	"google.golang.org/adk/model"
@@ -222,7 +223,6 @@ func appendTools(r *model.LLMRequest, tools ...tool.Tool) error {
 
 		if fnTool, ok := tool.(toolinternal.FunctionTool); ok {
 			if decl := fnTool.Declaration(); decl != nil {
-				// TODO: verify for duplicates.
 				declarations = append(declarations, decl)
 			}
 		}
@@ -233,21 +233,7 @@ func appendTools(r *model.LLMRequest, tools ...tool.Tool) error {
 	if r.Config == nil {
 		r.Config = &genai.GenerateContentConfig{}
 	}
-	// Find an existing genai.Tool with FunctionDeclarations
-	var funcTool *genai.Tool
-	for _, gt := range r.Config.Tools {
-		if gt.FunctionDeclarations != nil {
-			funcTool = gt
-			break
-		}
-	}
-	if funcTool != nil {
-		funcTool.FunctionDeclarations = append(funcTool.FunctionDeclarations, declarations...)
-	} else {
-		r.Config.Tools = append(r.Config.Tools, &genai.Tool{
-			FunctionDeclarations: declarations,
-		})
-	}
+	convert.AddFunctionDeclarations(r.Config, declarations...)
 	return nil
 }
 
@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/auth"
+	"google.golang.org/adk/internal/authinternal"
+	"google.golang.org/adk/internal/converters"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+)
+
+// authPreprocessor calls again the tools which requested a credential with
+// tool.Context.RequestCredential, once the client responded to the
+// adk_request_credential function calls. The credentials of the responses
+// are given to the tools by tool.Context.Credential.
+// See adk-python src/google/adk/auth/auth_preprocessor.py.
+func authPreprocessor(ctx agent.InvocationContext, req *model.LLMRequest, f *Flow) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		if asLLMAgent(ctx.Agent()) == nil || ctx.Session() == nil {
+			return
+		}
+
+		var events []*session.Event
+		for e := range ctx.Session().Events().All() {
+			events = append(events, e)
+		}
+		responseEventIndex := -1
+		for k := len(events) - 1; k >= 0; k-- {
+			if events[k].Author == "user" {
+				responseEventIndex = k
+				break
+			}
+		}
+		if responseEventIndex < 0 {
+			return
+		}
+		responses := make(map[string]*genai.FunctionResponse)
+		for _, resp := range utils.FunctionResponses(events[responseEventIndex].Content) {
+			if resp.Name == auth.FunctionCallName {
+				responses[resp.ID] = resp
+			}
+		}
+		if len(responses) == 0 {
+			return
+		}
+
+		// The credentials returned by the client, by the ID of the function
+		// call of the tool which requested them.
+		credentials := make(map[string]*auth.Credential)
+		for k := responseEventIndex - 1; k >= 0; k-- {
+			for _, call := range utils.FunctionCalls(events[k].Content) {
+				resp, ok := responses[call.ID]
+				if !ok || call.Name != auth.FunctionCallName {
+					continue
+				}
+				cred, toolCallID, err := credentialFromResponse(call, resp)
+				if err != nil {
+					yield(nil, fmt.Errorf("invalid response to auth request %s: %w", call.ID, err))
+					return
+				}
+				credentials[toolCallID] = cred
+			}
+		}
+
+		// The tools already called again by a previous step of the invocation.
+		for _, event := range events[responseEventIndex+1:] {
+			for _, resp := range utils.FunctionResponses(event.Content) {
+				delete(credentials, resp.ID)
+			}
+		}
+		if len(credentials) == 0 {
+			return
+		}
+
+		var parts []*genai.Part
+		for k := responseEventIndex - 1; k >= 0 && len(parts) < len(credentials); k-- {
+			for _, call := range utils.FunctionCalls(events[k].Content) {
+				if _, ok := credentials[call.ID]; ok && call.Name != auth.FunctionCallName {
+					parts = append(parts, &genai.Part{FunctionCall: call})
+				}
+			}
+		}
+		if len(parts) == 0 {
+			return
+		}
+
+		toolsmap := make(map[string]tool.Tool)
+		for _, tool := range f.Tools {
+			toolsmap[tool.Name()] = tool
+		}
+		toolCtx := ctx.WithContext(authinternal.WithResponses(ctx, credentials))
+		ev, err := f.handleFunctionCalls(toolCtx, toolsmap, &model.LLMResponse{
+			Content: &genai.Content{Parts: parts, Role: genai.RoleUser},
+		}, nil)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		// The tools may request the credential again, e.g. if the user
+		// denied the access.
+		requestCredentialEvent, err := generateRequestCredentialEvent(ctx, ev)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if requestCredentialEvent != nil {
+			if !yield(requestCredentialEvent, nil) {
+				return
+			}
+		}
+		yield(ev, nil)
+	}
+}
+
+// credentialFromResponse returns the credential of the response of the
+// client to an adk_request_credential call, and the ID of the function call
+// of the tool which requested it. Only the exchanged credential of the
+// response is used, the rest of the config of the tool is trusted from the
+// request.
+func credentialFromResponse(call *genai.FunctionCall, resp *genai.FunctionResponse) (*auth.Credential, string, error) {
+	args, err := converters.FromMapStructure[auth.ToolArguments](call.Args)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode the request: %w", err)
+	}
+
+	response := resp.Response
+	// ADK web client sends the response encapsulated in a 'response' key.
+	if wrapped, ok := response["response"]; ok && len(response) == 1 {
+		jsonString, ok := wrapped.(string)
+		if !ok {
+			return nil, "", fmt.Errorf("'response' key found but value is not a string")
+		}
+		response = nil
+		if err := json.Unmarshal([]byte(jsonString), &response); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal 'response': %w", err)
+		}
+	}
+	cfg, err := converters.FromMapStructure[auth.Config](response)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode the auth config: %w", err)
+	}
+	cred := cfg.ExchangedCredential
+	if cred == nil {
+		return nil, "", fmt.Errorf("no exchanged credential")
+	}
+	if err := checkState(args.AuthConfig.ExchangedCredential, cred); err != nil {
+		return nil, "", err
+	}
+	return cred, args.FunctionCallID, nil
+}
+
+// checkState checks that the OAuth2 authorization response of the client
+// carries the state of the authorization request, so that authorization
+// codes issued for other requests are rejected.
+func checkState(request, response *auth.Credential) error {
+	if request == nil || request.OAuth2 == nil || request.OAuth2.State == "" {
+		return nil
+	}
+	if response.OAuth2 == nil || response.OAuth2.AuthResponseURI == "" {
+		return nil
+	}
+	u, err := url.Parse(response.OAuth2.AuthResponseURI)
+	if err != nil {
+		return fmt.Errorf("invalid auth response URI: %w", err)
+	}
+	if state := u.Query().Get("state"); state != request.OAuth2.State {
+		return fmt.Errorf("state of the auth response doesn't match the request")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/auth"
+)
+
+func TestCredentialFromResponse(t *testing.T) {
+	request := &genai.FunctionCall{
+		ID:   "request",
+		Name: auth.FunctionCallName,
+		Args: map[string]any{
+			"functionCallId": "tool-call",
+			"authConfig": map[string]any{
+				"authScheme": map[string]any{"type": "oauth2"},
+				"exchangedAuthCredential": map[string]any{
+					"authType": "oauth2",
+					"oauth2":   map[string]any{"state": "s1"},
+				},
+			},
+		},
+	}
+	responseConfig := func(authResponseURI string) map[string]any {
+		return map[string]any{
+			"authScheme": map[string]any{"type": "oauth2"},
+			"exchangedAuthCredential": map[string]any{
+				"authType": "oauth2",
+				"oauth2":   map[string]any{"authResponseUri": authResponseURI},
+			},
+		}
+	}
+	wrapped, err := json.Marshal(responseConfig("https://app/callback?code=c&state=s1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		response map[string]any
+		want     *auth.Credential
+		wantErr  bool
+	}{
+		{
+			name:     "matching state",
+			response: responseConfig("https://app/callback?code=c&state=s1"),
+			want: &auth.Credential{Type: auth.TypeOAuth2, OAuth2: &auth.OAuth2Credential{
+				AuthResponseURI: "https://app/callback?code=c&state=s1",
+			}},
+		},
+		{
+			name:     "wrapped in response key",
+			response: map[string]any{"response": string(wrapped)},
+			want: &auth.Credential{Type: auth.TypeOAuth2, OAuth2: &auth.OAuth2Credential{
+				AuthResponseURI: "https://app/callback?code=c&state=s1",
+			}},
+		},
+		{
+			name:     "other state",
+			response: responseConfig("https://app/callback?code=c&state=s2"),
+			wantErr:  true,
+		},
+		{
+			name:     "no state",
+			response: responseConfig("https://app/callback?code=c"),
+			wantErr:  true,
+		},
+		{
+			name:     "no credential",
+			response: map[string]any{"authScheme": map[string]any{"type": "oauth2"}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &genai.FunctionResponse{ID: "request", Name: auth.FunctionCallName, Response: tt.response}
+			got, toolCallID, err := credentialFromResponse(request, resp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("credentialFromResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if toolCallID != "tool-call" {
+				t.Errorf("credentialFromResponse() tool call ID = %q, want tool-call", toolCallID)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("credentialFromResponse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
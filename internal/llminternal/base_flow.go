@@ -19,19 +19,28 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"log/slog"
 	"maps"
+	"runtime/debug"
 	"slices"
 	"strings"
+	"time"
 
-	"github.com/google/uuid"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/auth"
+	agentinternal "google.golang.org/adk/internal/agent"
 	"google.golang.org/adk/internal/agent/parentmap"
 	"google.golang.org/adk/internal/agent/runconfig"
 	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/convert"
+	"google.golang.org/adk/internal/invocationstats"
 	"google.golang.org/adk/internal/llminternal/googlellm"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/internal/modelcalls"
 	"google.golang.org/adk/internal/plugininternal/plugincontext"
+	"google.golang.org/adk/internal/stagetimeout"
 	"google.golang.org/adk/internal/telemetry"
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/internal/utils"
@@ -95,6 +104,9 @@ var (
 )
 
 func (f *Flow) Run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+	if runConfig := runconfig.FromContext(ctx); runConfig != nil && runConfig.StreamingMode == runconfig.StreamingModeBidi {
+		return f.runLive(ctx)
+	}
 	return func(yield func(*session.Event, error) bool) {
 		for {
 			var lastEvent *session.Event
@@ -109,7 +121,7 @@ func (f *Flow) Run(ctx agent.InvocationContext) iter.Seq2[*session.Event, error]
 				}
 				lastEvent = ev
 			}
-			if lastEvent == nil || lastEvent.IsFinalResponse() {
+			if lastEvent == nil || lastEvent.IsFinalResponse() || agent.IsInvocationCancelled(ctx) {
 				return
 			}
 			if lastEvent.LLMResponse.Partial {
@@ -154,6 +166,11 @@ func (f *Flow) runOneStep(ctx agent.InvocationContext) iter.Seq2[*session.Event,
 		// Calls the LLM.
 		for resp, err := range f.callLLM(ctx, req, stateDelta, artifactDelta) {
 			if err != nil {
+				// The model call was interrupted by the cancellation, the runner
+				// reports it with the final event of the invocation.
+				if agent.IsInvocationCancelled(ctx) {
+					return
+				}
 				yield(nil, err)
 				return
 			}
@@ -164,7 +181,8 @@ func (f *Flow) runOneStep(ctx agent.InvocationContext) iter.Seq2[*session.Event,
 			// Skip the model response event if there is no content and no error code.
 			// This is needed for the code executor to trigger another loop according to
 			// adk-python src/google/adk/flows/llm_flows/base_llm_flow.py BaseLlmFlow._postprocess_async.
-			if resp.Content == nil && resp.ErrorCode == "" && !resp.Interrupted {
+			// Dry-run responses carry the request instead of content.
+			if resp.Content == nil && resp.ErrorCode == "" && !resp.Interrupted && resp.CustomMetadata[agent.DryRunRequestKey] == nil {
 				continue
 			}
 
@@ -206,6 +224,17 @@ func (f *Flow) runOneStep(ctx agent.InvocationContext) iter.Seq2[*session.Event,
 				}
 			}
 
+			requestCredentialEvent, err := generateRequestCredentialEvent(ctx, ev)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if requestCredentialEvent != nil {
+				if !yield(requestCredentialEvent, nil) {
+					return
+				}
+			}
+
 			if !yield(ev, nil) {
 				return
 			}
@@ -231,7 +260,7 @@ func (f *Flow) runOneStep(ctx agent.InvocationContext) iter.Seq2[*session.Event,
 			}
 			nextAgent := f.agentToRun(ctx, ev.Actions.TransferToAgent)
 			if nextAgent == nil {
-				yield(nil, fmt.Errorf("failed to find agent: %s", ev.Actions.TransferToAgent))
+				yield(nil, &agent.TransferError{From: ctx.Agent().Name(), To: ev.Actions.TransferToAgent, Err: agent.ErrAgentNotFound})
 				return
 			}
 			for ev, err := range nextAgent.Run(ctx) {
@@ -261,6 +290,10 @@ func (f *Flow) preprocess(ctx agent.InvocationContext, req *model.LLMRequest) it
 		if f.Tools != nil {
 			if err := toolPreprocess(ctx, req, f.Tools); err != nil {
 				yield(nil, err)
+				return
+			}
+			if err := selectTools(ctx, req, f.Tools); err != nil {
+				yield(nil, err)
 			}
 		}
 	}
@@ -284,8 +317,8 @@ func toolPreprocess(ctx agent.InvocationContext, req *model.LLMRequest, tools []
 	return nil
 }
 
-func newResponseWithEventID(resp *model.LLMResponse) *responseWithEventID {
-	return &responseWithEventID{resp, uuid.New().String()}
+func newResponseWithEventID(ctx context.Context, resp *model.LLMResponse) *responseWithEventID {
+	return &responseWithEventID{resp, session.NewID(ctx)}
 }
 
 func (f *Flow) callLLM(ctx agent.InvocationContext, req *model.LLMRequest, stateDelta map[string]any, artifactDelta map[string]int64) iter.Seq2[*responseWithEventID, error] {
@@ -295,7 +328,7 @@ func (f *Flow) callLLM(ctx agent.InvocationContext, req *model.LLMRequest, state
 			cctx := icontext.NewCallbackContextWithDelta(ctx, stateDelta, artifactDelta)
 			callbackResponse, callbackErr := pluginManager.RunBeforeModelCallback(cctx, req)
 			if callbackResponse != nil || callbackErr != nil {
-				yield(newResponseWithEventID(callbackResponse), callbackErr)
+				yield(newResponseWithEventID(ctx, callbackResponse), callbackErr)
 				return
 			}
 		}
@@ -305,7 +338,7 @@ func (f *Flow) callLLM(ctx agent.InvocationContext, req *model.LLMRequest, state
 			callbackResponse, callbackErr := callback(cctx, req)
 
 			if callbackResponse != nil || callbackErr != nil {
-				yield(newResponseWithEventID(callbackResponse), callbackErr)
+				yield(newResponseWithEventID(ctx, callbackResponse), callbackErr)
 				return
 			}
 		}
@@ -313,10 +346,28 @@ func (f *Flow) callLLM(ctx agent.InvocationContext, req *model.LLMRequest, state
 		// TODO: Set _ADK_AGENT_NAME_LABEL_KEY in req.GenerateConfig.Labels
 		// to help with slicing the billing reports on a per-agent basis.
 
+		runConfig := runconfig.FromContext(ctx)
+		if runConfig.DryRun {
+			yield(newResponseWithEventID(ctx, &model.LLMResponse{
+				CustomMetadata: map[string]any{agent.DryRunRequestKey: req},
+				TurnComplete:   true,
+			}), nil)
+			return
+		}
+
+		if stats := invocationstats.FromContext(ctx); stats != nil && runConfig.MaxLLMCalls > 0 && stats.Snapshot().LLMCalls >= runConfig.MaxLLMCalls {
+			yield(nil, &agent.LimitExceededError{Limit: "MaxLLMCalls", Max: runConfig.MaxLLMCalls})
+			return
+		}
+
 		// TODO: RunLive mode when invocation_context.run_config.support_cfc is true.
-		useStream := runconfig.FromContext(ctx).StreamingMode == runconfig.StreamingModeSSE
+		useStream := runConfig.StreamingMode == runconfig.StreamingModeSSE
 
-		for resp, err := range generateContent(ctx, f.Model, req, useStream) {
+		var granularity agent.StreamGranularity
+		if cfg := ctx.RunConfig(); cfg != nil {
+			granularity = cfg.StreamGranularity
+		}
+		for resp, err := range bufferPartials(ctx, granularity, generateContent(ctx, f.Model, req, useStream)) {
 			if err != nil {
 				cbResp, cbErr := f.runOnModelErrorCallbacks(ctx, req, stateDelta, artifactDelta, err)
 				if cbErr != nil {
@@ -324,7 +375,7 @@ func (f *Flow) callLLM(ctx agent.InvocationContext, req *model.LLMRequest, state
 					return
 				}
 				if cbResp == nil {
-					yield(nil, err)
+					yield(nil, &agent.ModelError{Agent: ctx.Agent().Name(), Model: f.Model.Name(), Err: err})
 					return
 				}
 				resp = &responseWithEventID{
@@ -335,7 +386,7 @@ func (f *Flow) callLLM(ctx agent.InvocationContext, req *model.LLMRequest, state
 			}
 			// Function call ID is optional in genai API and some models do not use the field.
 			// Set it in case after model callbacks use it.
-			utils.PopulateClientFunctionCallID(resp.Content)
+			utils.PopulateClientFunctionCallID(ctx, resp.Content)
 
 			callbackResp, callbackErr := f.runAfterModelCallbacks(ctx, resp.LLMResponse, stateDelta, artifactDelta, err)
 			// TODO: check if we should stop iterator on the first error from stream or continue yielding next results.
@@ -385,15 +436,34 @@ func generateContent(ctx agent.InvocationContext, m model.LLM, req *model.LLMReq
 		backend := googlellm.GetGoogleLLMVariant(m)
 		// Log request before calling the model.
 		telemetry.LogRequest(ctx, req, backend)
+		logger := logging.FromContext(ctx)
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			logger.DebugContext(ctx, "Calling model", "model", m.Name(), "prompt", logging.RedactPrompt(ctx, lastContentText(req.Contents)))
+		}
+
+		calls := modelcalls.FromContext(ctx)
+		var call *modelcalls.Call
+		if calls != nil {
+			call = modelcalls.NewCall(ctx.InvocationID(), ctx.Agent().Name(), m.Name(), req)
+		}
 
 		var lastResponse responseWithEventID
 		var lastErr error
 		spanEnded := false
+		start := time.Now()
 		endSpanAndTrackResult := func() {
 			if spanEnded {
 				// Return to avoid spamming the logs with "span already ended" errors.
 				return
 			}
+			var usage *genai.GenerateContentResponseUsageMetadata
+			if lastResponse.LLMResponse != nil {
+				usage = lastResponse.UsageMetadata
+			}
+			if stats := invocationstats.FromContext(ctx); stats != nil {
+				stats.RecordLLMCall(time.Since(start), usage)
+			}
+			telemetry.RecordLLMCall(ctx, m.Name(), time.Since(start), usage, lastErr)
 			telemetry.TraceGenerateContentResult(span, telemetry.TraceGenerateContentResultParams{
 				Response: lastResponse.LLMResponse,
 				EventID:  lastResponse.eventID,
@@ -401,13 +471,22 @@ func generateContent(ctx agent.InvocationContext, m model.LLM, req *model.LLMReq
 			})
 			span.End()
 			spanEnded = true
+			if call != nil {
+				calls.Add(call)
+			}
 		}
 		// Ensure that the span is ended in case of error or if none final responses are yielded before the yield returns false.
 		defer endSpanAndTrackResult()
-		for resp, err := range m.GenerateContent(ctx, req, useStream) {
-			response := newResponseWithEventID(resp)
+		callCtx, cancel := stagetimeout.With(ctx, stagetimeout.ModelCall, stagetimeout.FromContext(ctx).ModelCall)
+		defer cancel()
+		for resp, err := range m.GenerateContent(ctx.WithContext(callCtx), req, useStream) {
+			err = stagetimeout.Err(callCtx, err)
+			response := newResponseWithEventID(ctx, resp)
 			lastResponse = *response
 			lastErr = err
+			if call != nil && !spanEnded {
+				call.AddResponse(response.eventID, resp, err)
+			}
 			// Complete the span immediately to avoid capturing the upstream yield processing time.
 			if err != nil {
 				endSpanAndTrackResult()
@@ -495,9 +574,9 @@ func (f *Flow) finalizeModelResponseEvent(ctx agent.InvocationContext, resp *res
 	// FunctionCall & FunctionResponse matching algorithm assumes non-empty function call IDs
 	// but function call ID is optional in genai API and some models do not use the field.
 	// Generate function call ids. (see functions.populate_client_function_call_id in python SDK)
-	utils.PopulateClientFunctionCallID(resp.Content)
+	utils.PopulateClientFunctionCallID(ctx, resp.Content)
 
-	ev := session.NewEvent(ctx.InvocationID())
+	ev := session.NewEventWithContext(ctx, ctx.InvocationID())
 	ev.ID = resp.eventID // TODO change NewEvent to accept event id
 	ev.Author = ctx.Agent().Name()
 	ev.Branch = ctx.Branch()
@@ -567,7 +646,7 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 		mergedCtx, mergedToolCallSpan := telemetry.StartTrace(ctx, "execute_tool (merged)")
 		ctx = ctx.WithContext(mergedCtx)
 		defer func() {
-			telemetry.TraceMergedToolCallsResult(mergedToolCallSpan, mergedEvent, err)
+			telemetry.TraceMergedToolCallsResult(ctx, mergedToolCallSpan, mergedEvent, err)
 			mergedToolCallSpan.End()
 		}()
 	}
@@ -579,7 +658,10 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 				Args:     fnCall.Args,
 			})
 			defer span.End()
-			toolCallCtx := ctx.WithContext(sctx)
+			// The timeout of the tool call also limits its callbacks.
+			callCtx, cancel := stagetimeout.With(logging.With(sctx, logging.ToolNameKey, fnCall.Name), stagetimeout.ToolCall, stagetimeout.FromContext(ctx).ToolCall)
+			defer cancel()
+			toolCallCtx := ctx.WithContext(callCtx)
 			var confirmation *toolconfirmation.ToolConfirmation
 			if toolConfirmations != nil {
 				confirmation = toolConfirmations[fnCall.ID]
@@ -587,7 +669,11 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 			toolCtx := toolinternal.NewToolContext(toolCallCtx, fnCall.ID, &session.EventActions{StateDelta: make(map[string]any)}, confirmation)
 
 			curTool, found := toolsDict[fnCall.Name]
-			if !found {
+			if agent.IsInvocationCancelled(ctx) {
+				// Pending calls of a cancelled invocation still get a response
+				// to keep the function calls and responses in the history paired.
+				result = map[string]any{"error": context.Cause(ctx).Error()}
+			} else if !found {
 				err := newToolNotFoundError(fnCall.Name, toolNames)
 				result, err = f.runOnToolErrorCallbacks(toolCtx, &fakeTool{name: fnCall.Name}, fnCall.Args, err)
 				if err != nil {
@@ -604,20 +690,9 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 			}
 
 			// TODO: handle long-running tool.
-			ev := session.NewEvent(ctx.InvocationID())
+			ev := session.NewEventWithContext(ctx, ctx.InvocationID())
 			ev.LLMResponse = model.LLMResponse{
-				Content: &genai.Content{
-					Role: "user",
-					Parts: []*genai.Part{
-						{
-							FunctionResponse: &genai.FunctionResponse{
-								ID:       fnCall.ID,
-								Name:     fnCall.Name,
-								Response: result,
-							},
-						},
-					},
-				},
+				Content: convert.FunctionResponseContent(fnCall, result),
 			}
 			ev.Author = ctx.Agent().Name()
 			ev.Branch = ctx.Branch()
@@ -636,7 +711,7 @@ func (f *Flow) handleFunctionCalls(ctx agent.InvocationContext, toolsDict map[st
 					toolErr = errors.New(errStr)
 				}
 			}
-			telemetry.TraceToolResult(span, telemetry.TraceToolResultParams{
+			telemetry.TraceToolResult(toolCallCtx, span, telemetry.TraceToolResultParams{
 				Description:   traceTool.Description(),
 				ResponseEvent: ev,
 				Error:         toolErr,
@@ -663,7 +738,16 @@ func (f *Flow) runOnToolErrorCallbacks(toolCtx tool.Context, tool tool.Tool, fAr
 	return f.invokeOnToolErrorCallbacks(toolCtx, tool, fArgs, err)
 }
 
-func (f *Flow) callTool(toolCtx tool.Context, tool toolinternal.FunctionTool, fArgs map[string]any) map[string]any {
+func (f *Flow) callTool(toolCtx tool.Context, tool toolinternal.FunctionTool, fArgs map[string]any) (result map[string]any) {
+	// A panic of the tool or its callbacks fails the tool call only.
+	defer func() {
+		if r := recover(); r != nil {
+			err := &agent.PanicError{Source: fmt.Sprintf("tool %q", tool.Name()), Value: r, Stack: debug.Stack()}
+			logging.FromContext(toolCtx).ErrorContext(toolCtx, "Tool call panicked", "function_call_id", toolCtx.FunctionCallID(), "error", err, "stack", string(err.Stack))
+			telemetry.RecordToolError(toolCtx, tool.Name())
+			result = toolErrorResponse(toolCtx, err)
+		}
+	}()
 	var response map[string]any
 	var err error
 	pluginManager := pluginManagerFromContext(toolCtx)
@@ -675,7 +759,17 @@ func (f *Flow) callTool(toolCtx tool.Context, tool toolinternal.FunctionTool, fA
 	}
 
 	if response == nil && err == nil {
+		start := time.Now()
 		response, err = tool.Run(toolCtx, fArgs)
+		err = stagetimeout.Err(toolCtx, err)
+		if stats := invocationstats.FromContext(toolCtx); stats != nil {
+			stats.RecordToolCall(time.Since(start))
+		}
+		if err != nil {
+			logging.FromContext(toolCtx).WarnContext(toolCtx, "Tool call failed", "function_call_id", toolCtx.FunctionCallID(), "error", err)
+			telemetry.RecordToolError(toolCtx, tool.Name())
+			err = &agent.ToolError{Tool: tool.Name(), CallID: toolCtx.FunctionCallID(), Err: err}
+		}
 	}
 
 	var errorResponse map[string]any
@@ -705,7 +799,23 @@ func (f *Flow) callTool(toolCtx tool.Context, tool toolinternal.FunctionTool, fA
 	}
 
 	if err != nil {
-		return map[string]any{"error": err.Error()}
+		// The model gets the error of the tool without the call details.
+		if toolErr, ok := err.(*agent.ToolError); ok {
+			err = toolErr.Err
+		}
+		return toolErrorResponse(toolCtx, err)
+	}
+	return response
+}
+
+// toolErrorResponse returns the function response of a failed tool call.
+// The stack trace of a panic is added under "stack" if enabled, see
+// runner.Config.PanicStackTraces.
+func toolErrorResponse(ctx context.Context, err error) map[string]any {
+	response := map[string]any{"error": err.Error()}
+	var panicErr *agent.PanicError
+	if errors.As(err, &panicErr) && agentinternal.PanicStackTraces(ctx) {
+		response["stack"] = string(panicErr.Stack)
 	}
 	return response
 }
@@ -812,6 +922,12 @@ func mergeEventActions(base, other *session.EventActions) *session.EventActions
 		}
 		maps.Copy(base.RequestedToolConfirmations, other.RequestedToolConfirmations)
 	}
+	if other.RequestedAuthConfigs != nil {
+		if base.RequestedAuthConfigs == nil {
+			base.RequestedAuthConfigs = make(map[string]auth.Config)
+		}
+		maps.Copy(base.RequestedAuthConfigs, other.RequestedAuthConfigs)
+	}
 	return base
 }
 
@@ -847,3 +963,15 @@ type pluginManager interface {
 	RunAfterToolCallback(ctx tool.Context, t tool.Tool, args, result map[string]any, err error) (map[string]any, error)
 	RunOnToolErrorCallback(ctx tool.Context, t tool.Tool, args map[string]any, err error) (map[string]any, error)
 }
+
+// lastContentText returns the text of the last content sent to the model.
+func lastContentText(contents []*genai.Content) string {
+	if len(contents) == 0 || contents[len(contents)-1] == nil {
+		return ""
+	}
+	var text strings.Builder
+	for _, part := range contents[len(contents)-1].Parts {
+		text.WriteString(part.Text)
+	}
+	return text.String()
+}
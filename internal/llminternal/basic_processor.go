@@ -17,11 +17,11 @@ package llminternal
 import (
 	"fmt"
 	"iter"
-	"reflect"
 
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/convert"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
 )
@@ -38,15 +38,27 @@ func basicRequestProcessor(ctx agent.InvocationContext, req *model.LLMRequest, f
 
 		state := llmAgent.internal()
 
-		req.Config = clone(state.GenerateContentConfig)
+		req.Config = convert.Clone(state.GenerateContentConfig)
 		if req.Config == nil {
 			req.Config = &genai.GenerateContentConfig{}
 		}
+		// The profile of the agent provides the defaults of its config, the
+		// profile of the invocation overrides both.
+		if err := applyProfile(req.Config, state.GenerationProfile, false); err != nil {
+			yield(nil, err)
+			return
+		}
+		if cfg := ctx.RunConfig(); cfg != nil {
+			if err := applyProfile(req.Config, cfg.GenerationProfile, true); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
 
 		// Set OutputSchema directly if no tools are present or native combo support exists.
 		// Otherwise, OutputSchemaRequestProcessor will be used to provide a tool-based workaround.
-		if state.OutputSchema != nil && !needOutputSchemaProcessor(state) {
-			req.Config.ResponseSchema = state.OutputSchema
+		if schema := outputSchema(ctx, state); schema != nil && !needOutputSchemaProcessor(state) {
+			req.Config.ResponseSchema = schema
 			req.Config.ResponseMIMEType = "application/json"
 		}
 
@@ -55,85 +67,15 @@ func basicRequestProcessor(ctx agent.InvocationContext, req *model.LLMRequest, f
 	}
 }
 
-// clone returns a deep copy of the src.
-// NOTE: this does not work for types with unexported fields.
-func clone[M any](src M) M {
-	val := reflect.ValueOf(src)
-
-	// Handle nil pointers
-	if val.Kind() == reflect.Pointer && val.IsNil() {
-		var zero M
-		return zero
-	}
-
-	srcIsPointer := val.Kind() == reflect.Pointer
-
-	// Dereference pointer to get the underlying value
-	if srcIsPointer {
-		val = val.Elem()
-	}
-
-	// Create a new instance of the same type
-	newVal := reflect.New(val.Type()).Elem()
-
-	// Recursively copy fields
-	deepCopy(val, newVal)
-
-	// Return as the original type
-	if srcIsPointer {
-		return newVal.Addr().Interface().(M)
+// applyProfile applies the generation profile name, if any, to cfg.
+func applyProfile(cfg *genai.GenerateContentConfig, name string, override bool) error {
+	if name == "" {
+		return nil
 	}
-	return newVal.Interface().(M)
-}
-
-// deepCopy copies src to dst using reflect.
-func deepCopy(src, dst reflect.Value) {
-	switch src.Kind() {
-	case reflect.Struct:
-		t := src.Type()
-		for i := 0; i < src.NumField(); i++ {
-			if !t.Field(i).IsExported() {
-				panic(fmt.Sprintf("deepCopy: unexported field %q in type %q", t.Field(i).Name, t.Name()))
-			}
-			// Create a copy of the field and set it on the destination struct
-			fieldCopy := reflect.New(src.Field(i).Type()).Elem()
-			deepCopy(src.Field(i), fieldCopy)
-			dst.Field(i).Set(fieldCopy)
-		}
-	case reflect.Slice:
-		if src.IsNil() {
-			return
-		}
-		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Cap()))
-		for i := 0; i < src.Len(); i++ {
-			// Create a copy of each element and set it in the new slice
-			elemCopy := reflect.New(src.Index(i).Type()).Elem()
-			deepCopy(src.Index(i), elemCopy)
-			dst.Index(i).Set(elemCopy)
-		}
-	case reflect.Map:
-		if src.IsNil() {
-			return
-		}
-		dst.Set(reflect.MakeMap(src.Type()))
-		for _, key := range src.MapKeys() {
-			// Create copies of the key and value and set them in the new map
-			keyCopy := reflect.New(key.Type()).Elem()
-			deepCopy(key, keyCopy)
-			valCopy := reflect.New(src.MapIndex(key).Type()).Elem()
-			deepCopy(src.MapIndex(key), valCopy)
-			dst.SetMapIndex(keyCopy, valCopy)
-		}
-	case reflect.Pointer:
-		if src.IsNil() {
-			return
-		}
-		// Create a new pointer and deep copy the underlying value
-		newPtr := reflect.New(src.Elem().Type())
-		deepCopy(src.Elem(), newPtr.Elem())
-		dst.Set(newPtr)
-	default:
-		// For basic types, direct assignment is sufficient
-		dst.Set(src)
+	p, ok := model.LookupProfile(name)
+	if !ok {
+		return fmt.Errorf("unknown generation profile %q", name)
 	}
+	p.Apply(cfg, override)
+	return nil
 }
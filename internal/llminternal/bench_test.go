@@ -0,0 +1,146 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/race"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+// The benchmarks of the hot event path are compared across changes with
+// benchstat:
+//
+//	go test -run '^$' -bench . -benchmem -count 10 ./internal/llminternal ./session > new.txt
+//	benchstat old.txt new.txt
+//
+// The allocation budgets below are enforced by TestAllocationBudgets, they
+// are updated when a change trades allocations for a feature.
+const (
+	// buildContentsAllocsBudget is the budget of buildContentsDefault for
+	// the events of historyEvents(100).
+	buildContentsAllocsBudget = 900
+	// streamAggregationAllocsBudget is the budget of the aggregation of the
+	// responses of streamedResponses(100).
+	streamAggregationAllocsBudget = 120
+)
+
+// historyEvents returns a conversation of n events, alternating user
+// messages, function calls, function responses and answers.
+func historyEvents(n int) []*session.Event {
+	events := make([]*session.Event, 0, n)
+	for i := range n {
+		event := session.NewEvent(fmt.Sprintf("invocation-%d", i/4))
+		event.Author = "agent"
+		switch i % 4 {
+		case 0:
+			event.Author = "user"
+			event.Content = genai.NewContentFromText(fmt.Sprintf("What is the weather in city %d?", i), genai.RoleUser)
+		case 1:
+			event.Content = genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": i}, genai.RoleModel)
+			event.Content.Parts[0].FunctionCall.ID = fmt.Sprintf("call-%d", i)
+		case 2:
+			event.Content = genai.NewContentFromFunctionResponse("get_weather", map[string]any{"weather": "sunny"}, genai.RoleUser)
+			event.Content.Parts[0].FunctionResponse.ID = fmt.Sprintf("call-%d", i-1)
+		case 3:
+			event.Content = genai.NewContentFromText("It is sunny.", genai.RoleModel)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// streamedResponses returns the n partial responses of a streamed answer
+// and its final empty response.
+func streamedResponses(n int) []*genai.GenerateContentResponse {
+	resps := make([]*genai.GenerateContentResponse, 0, n+1)
+	for range n {
+		resps = append(resps, &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{
+			Content: genai.NewContentFromText("token ", genai.RoleModel),
+		}}})
+	}
+	return append(resps, &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{
+		Content:      &genai.Content{Role: genai.RoleModel},
+		FinishReason: genai.FinishReasonStop,
+	}}})
+}
+
+func aggregate(b testing.TB, resps []*genai.GenerateContentResponse) {
+	aggregator := NewStreamingResponseAggregator()
+	var last *model.LLMResponse
+	for _, resp := range resps {
+		for llmResp, err := range aggregator.ProcessResponse(b.Context(), resp) {
+			if err != nil {
+				b.Fatal(err)
+			}
+			last = llmResp
+		}
+	}
+	if closed := aggregator.Close(); closed != nil {
+		last = closed
+	}
+	if last == nil {
+		b.Fatal("no aggregated response")
+	}
+}
+
+func BenchmarkBuildContents(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		events := historyEvents(n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := buildContentsDefault("agent", events); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkStreamAggregation(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		resps := streamedResponses(n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				aggregate(b, resps)
+			}
+		})
+	}
+}
+
+func TestAllocationBudgets(t *testing.T) {
+	if race.Enabled || testing.Short() {
+		t.Skip("the allocation budgets are not checked with the race detector or in short mode")
+	}
+	events := historyEvents(100)
+	if allocs := testing.AllocsPerRun(100, func() {
+		if _, err := buildContentsDefault("agent", events); err != nil {
+			t.Fatal(err)
+		}
+	}); allocs > buildContentsAllocsBudget {
+		t.Errorf("buildContentsDefault() allocations = %v, want at most %d", allocs, buildContentsAllocsBudget)
+	}
+	resps := streamedResponses(100)
+	if allocs := testing.AllocsPerRun(100, func() { aggregate(t, resps) }); allocs > streamAggregationAllocsBudget {
+		t.Errorf("stream aggregation allocations = %v, want at most %d", allocs, streamAggregationAllocsBudget)
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/auth"
+	"google.golang.org/adk/internal/convert"
 	"google.golang.org/adk/internal/utils"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
@@ -49,21 +51,33 @@ func ContentsRequestProcessor(ctx agent.InvocationContext, req *model.LLMRequest
 		var events []*session.Event
 		if ctx.Session() != nil {
 			for e := range ctx.Session().Events().All() {
-				events = append(events, e)
+				// Skip the events of the other branches, e.g. of the peers of
+				// the agent under a parallel agent, before looking for the
+				// current turn.
+				if eventBelongsToBranch(ctx.Branch(), e) {
+					events = append(events, e)
+				}
 			}
 		}
-		contents, err := fn(ctx.Agent().Name(), ctx.Branch(), events)
+		contents, err := fn(ctx.Agent().Name(), events)
 		if err != nil {
 			yield(nil, err)
 			return
 		}
+		if strategy := llmAgent.internal().HistoryStrategy; strategy != nil {
+			contents, err = strategy.Select(ctx, contents)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to select the history: %w", err))
+				return
+			}
+		}
 		req.Contents = append(req.Contents, contents...)
 	}
 }
 
 // buildContentsDefault returns the contents for the LLM request by applying
 // filtering, rearrangement, and content processing to the given events.
-func buildContentsDefault(agentName, invocationBranch string, events []*session.Event) ([]*genai.Content, error) {
+func buildContentsDefault(agentName string, events []*session.Event) ([]*genai.Content, error) {
 	// parse the events, leaving the contents and the function calls and responses from the current agent.
 	var filtered []*session.Event
 	for _, ev := range events {
@@ -77,11 +91,6 @@ func buildContentsDefault(agentName, invocationBranch string, events []*session.
 			// But unlike python that distinguishes None vs empty string, two cases are indistinguishable in Go.
 			continue
 		}
-		// Skip events that do not belong to the current branch.
-		// TODO: can we use a richer type for branch (e.g. []string) instead of using string prefix test?
-		if !eventBelongsToBranch(invocationBranch, ev) {
-			continue
-		}
 		if isAuthEvent(ev) {
 			continue
 		}
@@ -104,9 +113,9 @@ func buildContentsDefault(agentName, invocationBranch string, events []*session.
 		return nil, err
 	}
 
-	var contents []*genai.Content
+	contents := make([]*genai.Content, 0, len(filtered))
 	for _, ev := range filtered {
-		content := clone(utils.Content(ev))
+		content := convert.CloneContent(utils.Content(ev))
 		if content == nil {
 			continue
 		}
@@ -125,6 +134,10 @@ func buildContentsDefault(agentName, invocationBranch string, events []*session.
 	return contents, nil
 }
 
+// eventBelongsToBranch reports whether the event is visible in the invocation
+// branch: the events of the branch and of its ancestors, and the events
+// without branch.
+// TODO: can we use a richer type for branch (e.g. []string) instead of using string prefix test?
 func eventBelongsToBranch(invocationBranch string, event *session.Event) bool {
 	if invocationBranch == "" || event.Branch == "" {
 		return true
@@ -441,17 +454,17 @@ func mergeFunctionResponseEvents(functionResponseEvents []*session.Event) (*sess
 //
 //	In multi-agent scenarios, the "current turn" for an agent starts from an
 //	actual user or from another agent.
-func buildContentsCurrentTurnContextOnly(agentName, branch string, events []*session.Event) ([]*genai.Content, error) {
+func buildContentsCurrentTurnContextOnly(agentName string, events []*session.Event) ([]*genai.Content, error) {
 	// Find the latest event that starts the current turn and process from there
 	for i := len(events) - 1; i >= 0; i-- {
 		event := events[i]
 		if event.Author == "user" || isOtherAgentReply(agentName, event) {
-			return buildContentsDefault(agentName, branch, events[i:])
+			return buildContentsDefault(agentName, events[i:])
 		}
 	}
 	// NOTE: in Python, it returns [] if there is no event authored by a user or another agent,
 	// but that may be a bug.
-	return buildContentsDefault(agentName, branch, events)
+	return buildContentsDefault(agentName, events)
 }
 
 func isOtherAgentReply(currentAgentName string, ev *session.Event) bool {
@@ -505,20 +518,16 @@ func stringify(v any) string {
 	return string(s)
 }
 
-// requestEUCFunctionCallName is a special function to handle credential
-// request.
-const requestEUCFunctionCallName = "adk_request_credential"
-
 func isAuthEvent(ev *session.Event) bool {
 	c := utils.Content(ev)
 	if c == nil {
 		return false
 	}
 	for _, p := range c.Parts {
-		if p.FunctionCall != nil && p.FunctionCall.Name == requestEUCFunctionCallName {
+		if p.FunctionCall != nil && p.FunctionCall.Name == auth.FunctionCallName {
 			return true
 		}
-		if p.FunctionResponse != nil && p.FunctionResponse.Name == requestEUCFunctionCallName {
+		if p.FunctionResponse != nil && p.FunctionResponse.Name == auth.FunctionCallName {
 			return true
 		}
 	}
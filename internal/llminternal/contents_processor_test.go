@@ -26,6 +26,7 @@ import (
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/history"
 	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/llminternal"
 	"google.golang.org/adk/internal/utils"
@@ -412,6 +413,69 @@ func TestContentsRequestProcessor(t *testing.T) {
 	}
 }
 
+func TestContentsRequestProcessor_SiblingBranches(t *testing.T) {
+	events := []*session.Event{
+		{
+			Author: "user",
+			LLMResponse: model.LLMResponse{
+				Content: genai.NewContentFromText("hello", "user"),
+			},
+		},
+		{
+			Author: "a",
+			Branch: "parallel.a",
+			LLMResponse: model.LLMResponse{
+				Content: genai.NewContentFromFunctionCall("func1", nil, "model"),
+			},
+		},
+		{
+			// Starts the current turn of a if the branches are ignored.
+			Author: "b",
+			Branch: "parallel.b",
+			LLMResponse: model.LLMResponse{
+				Content: genai.NewContentFromText("b answer", "model"),
+			},
+		},
+		{
+			Author: "a",
+			Branch: "parallel.a",
+			LLMResponse: model.LLMResponse{
+				Content: genai.NewContentFromFunctionResponse("func1", nil, "user"),
+			},
+		},
+	}
+	want := []*genai.Content{
+		genai.NewContentFromText("hello", "user"),
+		genai.NewContentFromFunctionCall("func1", nil, "model"),
+		genai.NewContentFromFunctionResponse("func1", nil, "user"),
+	}
+
+	for _, includeContents := range []llmagent.IncludeContents{llmagent.IncludeContentsDefault, llmagent.IncludeContentsNone} {
+		t.Run(string(includeContents), func(t *testing.T) {
+			testAgent := utils.Must(llmagent.New(llmagent.Config{
+				Name:            "a",
+				Model:           &testModel{},
+				IncludeContents: includeContents,
+			}))
+			ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+				Agent:   testAgent,
+				Branch:  "parallel.a",
+				Session: &fakeSession{events: events},
+			})
+
+			req := &model.LLMRequest{}
+			for _, err := range llminternal.ContentsRequestProcessor(ctx, req, &llminternal.Flow{}) {
+				if err != nil {
+					t.Fatalf("ContentsRequestProcessor() error = %v", err)
+				}
+			}
+			if diff := cmp.Diff(want, req.Contents); diff != "" {
+				t.Errorf("ContentsRequestProcessor() contents mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestConvertForeignEvent(t *testing.T) {
 	t.Parallel()
 	now := time.Now()
@@ -517,6 +581,42 @@ func TestConvertForeignEvent(t *testing.T) {
 	}
 }
 
+func TestContentsRequestProcessor_HistoryStrategy(t *testing.T) {
+	event := func(author, text string, role genai.Role) *session.Event {
+		return &session.Event{Author: author, LLMResponse: model.LLMResponse{Content: genai.NewContentFromText(text, role)}}
+	}
+	testAgent := utils.Must(llmagent.New(llmagent.Config{
+		Name:            "testAgent",
+		Model:           &testModel{},
+		HistoryStrategy: history.LastTurns(2),
+	}))
+	ctx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{
+		Agent: testAgent,
+		Session: &fakeSession{events: []*session.Event{
+			event("user", "first", "user"),
+			event("testAgent", "first answer", "model"),
+			event("user", "second", "user"),
+			event("testAgent", "second answer", "model"),
+			event("user", "third", "user"),
+		}},
+	})
+
+	req := &model.LLMRequest{}
+	for _, err := range llminternal.ContentsRequestProcessor(ctx, req, &llminternal.Flow{}) {
+		if err != nil {
+			t.Fatalf("ContentsRequestProcessor() error = %v", err)
+		}
+	}
+	want := []*genai.Content{
+		genai.NewContentFromText("second", "user"),
+		genai.NewContentFromText("second answer", "model"),
+		genai.NewContentFromText("third", "user"),
+	}
+	if diff := cmp.Diff(want, req.Contents); diff != "" {
+		t.Errorf("LLMRequest contents mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestContentsRequestProcessor_NonLLMAgent(t *testing.T) {
 	testAgent := utils.Must(agent.New(agent.Config{
 		Name: "test_agent",
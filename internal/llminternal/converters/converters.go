@@ -23,30 +23,17 @@ import (
 func Genai2LLMResponse(res *genai.GenerateContentResponse) *model.LLMResponse {
 	usageMetadata := res.UsageMetadata
 	if len(res.Candidates) > 0 && res.Candidates[0] != nil {
-		candidate := res.Candidates[0]
-		if candidate.Content != nil && len(candidate.Content.Parts) > 0 {
-			return &model.LLMResponse{
-				Content:           candidate.Content,
-				GroundingMetadata: candidate.GroundingMetadata,
-				FinishReason:      candidate.FinishReason,
-				CitationMetadata:  candidate.CitationMetadata,
-				AvgLogprobs:       candidate.AvgLogprobs,
-				LogprobsResult:    candidate.LogprobsResult,
-				UsageMetadata:     usageMetadata,
-				ModelVersion:      res.ModelVersion,
+		resp := candidate2LLMResponse(res, res.Candidates[0])
+		// The other candidates, requested with CandidateCount, are kept for
+		// the selection of model.WithCandidates.
+		if len(res.Candidates) > 1 {
+			for _, candidate := range res.Candidates {
+				if candidate != nil {
+					resp.Candidates = append(resp.Candidates, candidate2LLMResponse(res, candidate))
+				}
 			}
 		}
-		return &model.LLMResponse{
-			ErrorCode:         string(candidate.FinishReason),
-			ErrorMessage:      candidate.FinishMessage,
-			GroundingMetadata: candidate.GroundingMetadata,
-			FinishReason:      candidate.FinishReason,
-			CitationMetadata:  candidate.CitationMetadata,
-			AvgLogprobs:       candidate.AvgLogprobs,
-			LogprobsResult:    candidate.LogprobsResult,
-			UsageMetadata:     usageMetadata,
-			ModelVersion:      res.ModelVersion,
-		}
+		return resp
 	}
 	if res.PromptFeedback != nil {
 		return &model.LLMResponse{
@@ -63,3 +50,29 @@ func Genai2LLMResponse(res *genai.GenerateContentResponse) *model.LLMResponse {
 		ModelVersion:  res.ModelVersion,
 	}
 }
+
+func candidate2LLMResponse(res *genai.GenerateContentResponse, candidate *genai.Candidate) *model.LLMResponse {
+	if candidate.Content != nil && len(candidate.Content.Parts) > 0 {
+		return &model.LLMResponse{
+			Content:           candidate.Content,
+			GroundingMetadata: candidate.GroundingMetadata,
+			FinishReason:      candidate.FinishReason,
+			CitationMetadata:  candidate.CitationMetadata,
+			AvgLogprobs:       candidate.AvgLogprobs,
+			LogprobsResult:    candidate.LogprobsResult,
+			UsageMetadata:     res.UsageMetadata,
+			ModelVersion:      res.ModelVersion,
+		}
+	}
+	return &model.LLMResponse{
+		ErrorCode:         string(candidate.FinishReason),
+		ErrorMessage:      candidate.FinishMessage,
+		GroundingMetadata: candidate.GroundingMetadata,
+		FinishReason:      candidate.FinishReason,
+		CitationMetadata:  candidate.CitationMetadata,
+		AvgLogprobs:       candidate.AvgLogprobs,
+		LogprobsResult:    candidate.LogprobsResult,
+		UsageMetadata:     res.UsageMetadata,
+		ModelVersion:      res.ModelVersion,
+	}
+}
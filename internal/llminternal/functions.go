@@ -15,9 +15,15 @@
 package llminternal
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/auth"
+	"google.golang.org/adk/internal/converters"
 	"google.golang.org/adk/internal/utils"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
@@ -61,7 +67,7 @@ func generateRequestConfirmationEvent(
 		}
 
 		requestConfirmationFC := &genai.FunctionCall{
-			ID:   utils.GenerateFunctionCallID(),
+			ID:   utils.GenerateFunctionCallID(invocationContext),
 			Name: toolconfirmation.FunctionCallName,
 			Args: args,
 		}
@@ -76,7 +82,7 @@ func generateRequestConfirmationEvent(
 		return nil
 	}
 
-	ev := session.NewEvent(invocationContext.InvocationID())
+	ev := session.NewEventWithContext(invocationContext, invocationContext.InvocationID())
 	ev.Author = invocationContext.Agent().Name()
 	ev.Branch = invocationContext.Branch()
 	ev.LLMResponse = model.LLMResponse{
@@ -88,3 +94,43 @@ func generateRequestConfirmationEvent(
 	ev.LongRunningToolIDs = longRunningToolIDs
 	return ev
 }
+
+// generateRequestCredentialEvent creates a new Event containing
+// adk_request_credential function calls for the credentials requested by the
+// tools of functionResponseEvent with tool.Context.RequestCredential.
+func generateRequestCredentialEvent(invocationContext agent.InvocationContext, functionResponseEvent *session.Event) (*session.Event, error) {
+	if functionResponseEvent == nil || len(functionResponseEvent.Actions.RequestedAuthConfigs) == 0 {
+		return nil, nil
+	}
+
+	parts := []*genai.Part{}
+	longRunningToolIDs := []string{}
+	for _, funcID := range slices.Sorted(maps.Keys(functionResponseEvent.Actions.RequestedAuthConfigs)) {
+		args, err := converters.ToMapStructure(auth.ToolArguments{
+			FunctionCallID: funcID,
+			AuthConfig:     functionResponseEvent.Actions.RequestedAuthConfigs[funcID],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the auth request of function call %s: %w", funcID, err)
+		}
+		requestCredentialFC := &genai.FunctionCall{
+			ID:   utils.GenerateFunctionCallID(invocationContext),
+			Name: auth.FunctionCallName,
+			Args: args,
+		}
+		parts = append(parts, &genai.Part{FunctionCall: requestCredentialFC})
+		longRunningToolIDs = append(longRunningToolIDs, requestCredentialFC.ID)
+	}
+
+	ev := session.NewEventWithContext(invocationContext, invocationContext.InvocationID())
+	ev.Author = invocationContext.Agent().Name()
+	ev.Branch = invocationContext.Branch()
+	ev.LLMResponse = model.LLMResponse{
+		Content: &genai.Content{
+			Parts: parts,
+			Role:  genai.RoleModel,
+		},
+	}
+	ev.LongRunningToolIDs = longRunningToolIDs
+	return ev, nil
+}
@@ -55,6 +55,10 @@ func (m *mockInvocationContext) Branch() string {
 	return m.branch
 }
 
+func (m *mockInvocationContext) Value(key any) any {
+	return nil
+}
+
 func TestGenerateRequestConfirmationEvent(t *testing.T) {
 	confirmingFunctionCall := &genai.FunctionCall{
 		ID:   "call_1",
@@ -0,0 +1,354 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"sync"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/agent/runconfig"
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/speech"
+	"google.golang.org/adk/tool"
+)
+
+// liveItem is a response of the live model, or a user content taken from the
+// live request queue and sent to the model.
+type liveItem struct {
+	resp        *model.LLMResponse
+	userContent *genai.Content
+	err         error
+}
+
+// runLive runs the agent on a live connection to the model: the requests of
+// the LiveRequestQueue are streamed to the model and its responses are
+// yielded as events until the queue is closed.
+func (f *Flow) runLive(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		if f.Model == nil {
+			yield(nil, fmt.Errorf("agent %q: %w", ctx.Agent().Name(), ErrModelNotConfigured))
+			return
+		}
+		liveModel, ok := f.Model.(model.LiveLLM)
+		if !ok {
+			cfg := ctx.RunConfig()
+			if cfg == nil || cfg.SpeechToText == nil && cfg.TextToSpeech == nil {
+				yield(nil, fmt.Errorf("agent %q: model %q does not support live connections", ctx.Agent().Name(), f.Model.Name()))
+				return
+			}
+			liveModel = speech.NewLiveModel(f.Model, speech.Config{SpeechToText: cfg.SpeechToText, TextToSpeech: cfg.TextToSpeech})
+		}
+		runConfig := runconfig.FromContext(ctx)
+		if runConfig == nil || runConfig.LiveRequestQueue == nil {
+			yield(nil, errors.New("live run requires a LiveRequestQueue"))
+			return
+		}
+		queue := runConfig.LiveRequestQueue
+
+		req := &model.LLMRequest{
+			Model:             f.Model.Name(),
+			LiveConnectConfig: runConfig.LiveConnectConfig,
+		}
+		for ev, err := range f.preprocess(ctx, req) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if ev != nil {
+				if !yield(ev, nil) {
+					return
+				}
+			}
+		}
+		if ctx.Ended() {
+			return
+		}
+		if resp, err := f.runBeforeModelCallbacks(ctx, req); resp != nil || err != nil {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			yield(f.finalizeModelResponseEvent(ctx, newResponseWithEventID(ctx, resp), nil, nil), nil)
+			return
+		}
+
+		tools := make(map[string]tool.Tool)
+		for k, v := range req.Tools {
+			t, ok := v.(tool.Tool)
+			if !ok {
+				yield(nil, fmt.Errorf("unexpected tool type %T for tool %v", v, k))
+				return
+			}
+			tools[k] = t
+		}
+
+		conn, err := liveModel.ConnectLive(ctx, req)
+		if err != nil {
+			yield(nil, &agent.ModelError{Agent: ctx.Agent().Name(), Model: f.Model.Name(), Err: err})
+			return
+		}
+
+		items := make(chan liveItem)
+		done := make(chan struct{})
+		readCtx, cancelRead := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		var stopOnce sync.Once
+		stop := func() {
+			stopOnce.Do(func() {
+				close(done)
+				cancelRead()
+				_ = conn.Close()
+				wg.Wait()
+			})
+		}
+		defer stop()
+		send := func(item liveItem) bool {
+			select {
+			case items <- item:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for resp, err := range conn.Receive() {
+				if !send(liveItem{resp: resp, err: err}) || err != nil {
+					return
+				}
+			}
+			send(liveItem{err: io.EOF})
+		}()
+		go func() {
+			defer wg.Done()
+			// The connection is closed when the client is done, which ends
+			// the responses of the model.
+			defer conn.Close()
+			for {
+				liveReq, err := queue.Receive(readCtx)
+				if err != nil {
+					return
+				}
+				// The turn of the user is yielded before the model can
+				// respond to it.
+				if liveReq.Content != nil && !send(liveItem{userContent: liveReq.Content}) {
+					return
+				}
+				if err := sendLiveRequest(conn, liveReq); err != nil {
+					send(liveItem{err: fmt.Errorf("failed to send to the live model: %w", err)})
+					return
+				}
+			}
+		}()
+
+		for {
+			var item liveItem
+			select {
+			case item = <-items:
+			case <-ctx.Done():
+				return
+			}
+			if errors.Is(item.err, io.EOF) {
+				// The transcriptions interrupted by the end of the
+				// connection are stored too.
+				for _, ev := range liveTranscriptionEvents(ctx, &model.LLMResponse{Interrupted: true}) {
+					if !yield(ev, nil) {
+						return
+					}
+				}
+				return
+			}
+			if item.err != nil {
+				if agent.IsInvocationCancelled(ctx) {
+					return
+				}
+				yield(nil, &agent.ModelError{Agent: ctx.Agent().Name(), Model: f.Model.Name(), Err: item.err})
+				return
+			}
+			if item.userContent != nil {
+				ev := session.NewEventWithContext(ctx, ctx.InvocationID())
+				ev.Author = "user"
+				ev.Branch = ctx.Branch()
+				ev.LLMResponse = model.LLMResponse{Content: item.userContent}
+				if !yield(ev, nil) {
+					return
+				}
+				continue
+			}
+
+			resp := newResponseWithEventID(ctx, item.resp)
+			if err := f.postprocess(ctx, req, resp); err != nil {
+				yield(nil, err)
+				return
+			}
+			utils.PopulateClientFunctionCallID(ctx, resp.Content)
+			stateDelta := make(map[string]any)
+			callbackResp, err := f.runAfterModelCallbacks(ctx, resp.LLMResponse, stateDelta, make(map[string]int64), nil)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if callbackResp != nil {
+				resp.LLMResponse = callbackResp
+			}
+			if isEmptyLiveResponse(resp.LLMResponse) {
+				continue
+			}
+			for _, ev := range liveTranscriptionEvents(ctx, resp.LLMResponse) {
+				if !yield(ev, nil) {
+					return
+				}
+			}
+
+			ev := f.finalizeModelResponseEvent(ctx, resp, tools, stateDelta)
+			if resp.InputTranscription != nil {
+				ev.Author = "user"
+			}
+			if !yield(ev, nil) {
+				return
+			}
+
+			fnEvent, err := f.handleFunctionCalls(ctx, tools, resp.LLMResponse, nil)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if fnEvent == nil {
+				continue
+			}
+			if !yield(fnEvent, nil) {
+				return
+			}
+			if fnEvent.Actions.TransferToAgent == "" {
+				if err := conn.SendContent(fnEvent.Content); err != nil {
+					yield(nil, &agent.ModelError{Agent: ctx.Agent().Name(), Model: f.Model.Name(), Err: err})
+					return
+				}
+				continue
+			}
+
+			// The agent the conversation is transferred to opens its own
+			// connection on the same queue.
+			nextAgent := f.agentToRun(ctx, fnEvent.Actions.TransferToAgent)
+			if nextAgent == nil {
+				yield(nil, &agent.TransferError{From: ctx.Agent().Name(), To: fnEvent.Actions.TransferToAgent, Err: agent.ErrAgentNotFound})
+				return
+			}
+			stop()
+			for ev, err := range nextAgent.Run(ctx) {
+				if !yield(ev, err) || err != nil {
+					return
+				}
+			}
+			return
+		}
+	}
+}
+
+// sendLiveRequest sends a request of the live request queue to the model.
+func sendLiveRequest(conn model.LiveConnection, req *agent.LiveRequest) error {
+	switch {
+	case req.Content != nil:
+		return conn.SendContent(req.Content)
+	case req.Blob != nil:
+		if strings.HasPrefix(req.Blob.MIMEType, "audio/") {
+			return conn.SendRealtime(genai.LiveRealtimeInput{Audio: req.Blob})
+		}
+		return conn.SendRealtime(genai.LiveRealtimeInput{Video: req.Blob})
+	case req.ActivityStart:
+		return conn.SendRealtime(genai.LiveRealtimeInput{ActivityStart: &genai.ActivityStart{}})
+	case req.ActivityEnd:
+		return conn.SendRealtime(genai.LiveRealtimeInput{ActivityEnd: &genai.ActivityEnd{}})
+	}
+	return nil
+}
+
+// liveTranscriptionEvents consolidates the chunks of the transcriptions of
+// the response with the previous chunks, see agent.TranscriptionCache: the
+// chunk finishing a transcription is replaced by the complete transcription.
+// It returns the events of the transcriptions ended by the response without
+// a finished chunk, e.g. the transcription of the user when the model starts
+// responding, or the transcriptions of a completed or interrupted turn.
+func liveTranscriptionEvents(ctx agent.InvocationContext, resp *model.LLMResponse) []*session.Event {
+	cache := ctx.TranscriptionCache()
+	if cache == nil {
+		return nil
+	}
+	var events []*session.Event
+	newEvent := func(author string, resp model.LLMResponse) {
+		ev := session.NewEventWithContext(ctx, ctx.InvocationID())
+		ev.Author = author
+		ev.Branch = ctx.Branch()
+		ev.LLMResponse = resp
+		events = append(events, ev)
+	}
+	ended := resp.TurnComplete || resp.Interrupted
+	if resp.InputTranscription != nil {
+		if t := cache.AddInput(resp.InputTranscription); t != nil {
+			resp.InputTranscription, resp.Partial = t, false
+		}
+	} else if ended || resp.Content != nil || resp.OutputTranscription != nil {
+		if t := cache.FlushInput(); t != nil {
+			newEvent("user", model.LLMResponse{InputTranscription: t})
+		}
+	}
+	if resp.OutputTranscription != nil {
+		if t := cache.AddOutput(resp.OutputTranscription); t != nil {
+			resp.OutputTranscription, resp.Partial = t, false
+		}
+	} else if ended {
+		if t := cache.FlushOutput(); t != nil {
+			newEvent(ctx.Agent().Name(), model.LLMResponse{OutputTranscription: t})
+		}
+	}
+	return events
+}
+
+// isEmptyLiveResponse reports whether the response carries nothing to yield.
+func isEmptyLiveResponse(resp *model.LLMResponse) bool {
+	return resp.Content == nil && resp.InputTranscription == nil && resp.OutputTranscription == nil &&
+		resp.ErrorCode == "" && !resp.TurnComplete && !resp.Interrupted
+}
+
+// runBeforeModelCallbacks runs the before model callbacks of the plugins and
+// the agent, returning the first response or error.
+func (f *Flow) runBeforeModelCallbacks(ctx agent.InvocationContext, req *model.LLMRequest) (*model.LLMResponse, error) {
+	if pluginManager := pluginManagerFromContext(ctx); pluginManager != nil {
+		cctx := icontext.NewCallbackContextWithDelta(ctx, make(map[string]any), make(map[string]int64))
+		if resp, err := pluginManager.RunBeforeModelCallback(cctx, req); resp != nil || err != nil {
+			return resp, err
+		}
+	}
+	for _, callback := range f.BeforeModelCallbacks {
+		cctx := icontext.NewCallbackContextWithDelta(ctx, make(map[string]any), make(map[string]int64))
+		if resp, err := callback(cctx, req); resp != nil || err != nil {
+			return resp, err
+		}
+	}
+	return nil, nil
+}
@@ -48,12 +48,13 @@ func outputSchemaRequestProcessor(ctx agent.InvocationContext, req *model.LLMReq
 
 		state := llmAgent.internal()
 		// Check if we need the processor in the first place.
-		if state.OutputSchema == nil || !needOutputSchemaProcessor(state) {
+		schema := outputSchema(ctx, state)
+		if schema == nil || !needOutputSchemaProcessor(state) {
 			return
 		}
 
 		// Add the set_model_response tool to handle structured output
-		setResponseTool := &setModelResponseTool{schema: state.OutputSchema}
+		setResponseTool := &setModelResponseTool{schema: schema}
 		if err := toolutils.PackTool(req, setResponseTool); err != nil {
 			yield(nil, fmt.Errorf("failed to pack set_model_response tool: %w", err))
 			return
@@ -67,7 +68,7 @@ func outputSchemaRequestProcessor(ctx agent.InvocationContext, req *model.LLMReq
 // createFinalModelResponseEvent creates a final model response event from set_model_response JSON.
 func createFinalModelResponseEvent(invocationContext agent.InvocationContext, response string) *session.Event {
 	// Create a proper model response event
-	finalEvent := session.NewEvent(invocationContext.InvocationID())
+	finalEvent := session.NewEventWithContext(invocationContext, invocationContext.InvocationID())
 	finalEvent.Author = invocationContext.Agent().Name()
 	finalEvent.Branch = invocationContext.Branch()
 	finalEvent.Content = &genai.Content{
@@ -96,6 +97,15 @@ func retrieveStructuredModelResponse(ev *session.Event) (string, error) {
 	return "", nil
 }
 
+// outputSchema returns the output schema of the agent, overridden by the
+// one of the run config.
+func outputSchema(ctx agent.InvocationContext, state *State) *genai.Schema {
+	if cfg := ctx.RunConfig(); cfg != nil && cfg.OutputSchema != nil {
+		return cfg.OutputSchema
+	}
+	return state.OutputSchema
+}
+
 func needOutputSchemaProcessor(state *State) bool {
 	if state == nil || state.Model == nil {
 		return false
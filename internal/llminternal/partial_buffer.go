@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+)
+
+// bufferPartials merges the partial text responses of the model up to the
+// boundaries of the granularity. The buffered text is flushed before any
+// other response, so the order of the content is preserved.
+func bufferPartials(ctx context.Context, granularity agent.StreamGranularity, responses iter.Seq2[*responseWithEventID, error]) iter.Seq2[*responseWithEventID, error] {
+	var boundary func(string) int
+	switch granularity {
+	case agent.StreamGranularitySentence:
+		boundary = sentenceBoundary
+	case agent.StreamGranularityParagraph:
+		boundary = paragraphBoundary
+	default:
+		return responses
+	}
+	return func(yield func(*responseWithEventID, error) bool) {
+		var (
+			buf     strings.Builder
+			last    *model.LLMResponse
+			thought bool
+		)
+		emit := func(text string) bool {
+			resp := *last
+			resp.Content = &genai.Content{Role: last.Content.Role, Parts: []*genai.Part{{Text: text, Thought: thought}}}
+			return yield(newResponseWithEventID(ctx, &resp), nil)
+		}
+		flush := func() bool {
+			if buf.Len() == 0 {
+				return true
+			}
+			text := buf.String()
+			buf.Reset()
+			return emit(text)
+		}
+
+		for resp, err := range responses {
+			text, isThought, ok := partialText(resp, err)
+			if !ok {
+				if !flush() || !yield(resp, err) {
+					return
+				}
+				continue
+			}
+			if buf.Len() > 0 && isThought != thought {
+				if !flush() {
+					return
+				}
+			}
+			last, thought = resp.LLMResponse, isThought
+			buf.WriteString(text)
+			if i := boundary(buf.String()); i > 0 {
+				buffered := buf.String()
+				buf.Reset()
+				buf.WriteString(buffered[i:])
+				if !emit(buffered[:i]) {
+					return
+				}
+			}
+		}
+		flush()
+	}
+}
+
+// partialText returns the text of a partial response made of text only.
+func partialText(resp *responseWithEventID, err error) (text string, thought, ok bool) {
+	if err != nil || resp == nil || resp.LLMResponse == nil || !resp.Partial || resp.Content == nil || len(resp.Content.Parts) == 0 {
+		return "", false, false
+	}
+	thought = resp.Content.Parts[0].Thought
+	var b strings.Builder
+	for _, part := range resp.Content.Parts {
+		if part.Text == "" || part.Thought != thought || part.FunctionCall != nil || part.InlineData != nil {
+			return "", false, false
+		}
+		b.WriteString(part.Text)
+	}
+	return b.String(), thought, true
+}
+
+// sentenceBoundary returns the index after the last complete sentence or
+// line of the text, or 0. A sentence ends with a punctuation mark followed
+// by a space, so that the end of a chunk, e.g. "3." in "3.14", is not taken
+// for one.
+func sentenceBoundary(text string) int {
+	for i := len(text) - 1; i > 0; i-- {
+		if text[i] == '\n' {
+			return i + 1
+		}
+		if unicode.IsSpace(rune(text[i])) && strings.ContainsRune(".!?", rune(text[i-1])) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// paragraphBoundary returns the index after the last complete paragraph of
+// the text, or 0.
+func paragraphBoundary(text string) int {
+	if i := strings.LastIndex(text, "\n\n"); i >= 0 {
+		return i + 2
+	}
+	return 0
+}
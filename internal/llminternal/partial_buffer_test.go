@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+)
+
+func TestBufferPartials(t *testing.T) {
+	chunks := []string{"Hello wor", "ld. How ", "are you?", " Fine", ".\n\nNext ", "para"}
+	final := "Hello world. How are you? Fine.\n\nNext para"
+	tests := []struct {
+		name        string
+		granularity agent.StreamGranularity
+		want        []string
+	}{
+		{
+			name: "chunk",
+			want: append(append([]string{}, chunks...), final),
+		},
+		{
+			name:        "sentence",
+			granularity: agent.StreamGranularitySentence,
+			want:        []string{"Hello world. ", "How are you? ", "Fine.\n\n", "Next para", final},
+		},
+		{
+			name:        "paragraph",
+			granularity: agent.StreamGranularityParagraph,
+			want:        []string{"Hello world. How are you? Fine.\n\n", "Next para", final},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responses := func(yield func(*responseWithEventID, error) bool) {
+				for _, chunk := range chunks {
+					if !yield(&responseWithEventID{LLMResponse: &model.LLMResponse{
+						Content: genai.NewContentFromText(chunk, genai.RoleModel),
+						Partial: true,
+					}}, nil) {
+						return
+					}
+				}
+				yield(&responseWithEventID{LLMResponse: &model.LLMResponse{
+					Content: genai.NewContentFromText(final, genai.RoleModel),
+				}}, nil)
+			}
+
+			var got []string
+			var partial []bool
+			for resp, err := range bufferPartials(t.Context(), tt.granularity, responses) {
+				if err != nil {
+					t.Fatalf("bufferPartials() error = %v", err)
+				}
+				got = append(got, resp.Content.Parts[0].Text)
+				partial = append(partial, resp.Partial)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("bufferPartials() texts mismatch (-want +got):\n%s", diff)
+			}
+			wantPartial := make([]bool, len(tt.want))
+			for i := range len(wantPartial) - 1 {
+				wantPartial[i] = true
+			}
+			if diff := cmp.Diff(wantPartial, partial); diff != "" {
+				t.Errorf("bufferPartials() partial mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
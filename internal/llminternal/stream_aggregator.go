@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"iter"
 	"reflect"
+	"strings"
 
 	"google.golang.org/genai"
 
@@ -30,8 +31,10 @@ import (
 // It aggregates content from partial responses, and generates LlmResponses for
 // individual (partial) model responses, as well as for aggregated content.
 type streamingResponseAggregator struct {
-	text        string
-	thoughtText string
+	// The text is accumulated in builders, so that aggregating n chunks
+	// does not copy the text n times.
+	text        strings.Builder
+	thoughtText strings.Builder
 	response    *model.LLMResponse
 	role        string
 }
@@ -80,9 +83,9 @@ func (s *streamingResponseAggregator) aggregateResponse(llmResponse *model.LLMRe
 	// If part is text append it
 	if part0 != nil && part0.Text != "" {
 		if part0.Thought {
-			s.thoughtText += part0.Text
+			s.thoughtText.WriteString(part0.Text)
 		} else {
-			s.text += part0.Text
+			s.text.WriteString(part0.Text)
 		}
 		llmResponse.Partial = true
 		return nil
@@ -95,7 +98,7 @@ func (s *streamingResponseAggregator) aggregateResponse(llmResponse *model.LLMRe
 	}
 
 	// If there is aggregated text and there is no content or parts return aggregated response
-	if (s.thoughtText != "" || s.text != "") &&
+	if (s.thoughtText.Len() > 0 || s.text.Len() > 0) &&
 		(llmResponse.Content == nil ||
 			len(llmResponse.Content.Parts) == 0 ||
 			// don't yield the merged text event when receiving audio data
@@ -113,13 +116,13 @@ func (s *streamingResponseAggregator) Close() *model.LLMResponse {
 }
 
 func (s *streamingResponseAggregator) createAggregateResponse() *model.LLMResponse {
-	if (s.text != "" || s.thoughtText != "") && s.response != nil {
-		var parts []*genai.Part
-		if s.thoughtText != "" {
-			parts = append(parts, &genai.Part{Text: s.thoughtText, Thought: true})
+	if (s.text.Len() > 0 || s.thoughtText.Len() > 0) && s.response != nil {
+		parts := make([]*genai.Part, 0, 2)
+		if s.thoughtText.Len() > 0 {
+			parts = append(parts, &genai.Part{Text: s.thoughtText.String(), Thought: true})
 		}
-		if s.text != "" {
-			parts = append(parts, &genai.Part{Text: s.text, Thought: false})
+		if s.text.Len() > 0 {
+			parts = append(parts, &genai.Part{Text: s.text.String(), Thought: false})
 		}
 
 		response := &model.LLMResponse{
@@ -140,7 +143,7 @@ func (s *streamingResponseAggregator) createAggregateResponse() *model.LLMRespon
 
 func (s *streamingResponseAggregator) clear() {
 	s.response = nil
-	s.text = ""
-	s.thoughtText = ""
+	s.text.Reset()
+	s.thoughtText.Reset()
 	s.role = ""
 }
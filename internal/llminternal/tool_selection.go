@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package llminternal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// selectTools removes from the request the declarations of the tools of the
+// agent that its tool selector does not select. The other declarations,
+// e.g. of the transfer tool, are kept, and all the tools remain callable.
+func selectTools(ctx agent.InvocationContext, req *model.LLMRequest, tools []tool.Tool) error {
+	llmAgent, ok := ctx.Agent().(Agent)
+	if !ok {
+		return nil
+	}
+	selector := Reveal(llmAgent).ToolSelector
+	if selector == nil || req.Config == nil {
+		return nil
+	}
+
+	candidates := make(map[string]bool)
+	for _, t := range tools {
+		if _, ok := t.(toolinternal.FunctionTool); ok {
+			candidates[t.Name()] = true
+		}
+	}
+	var decls []*genai.FunctionDeclaration
+	for _, t := range req.Config.Tools {
+		if t == nil {
+			continue
+		}
+		for _, decl := range t.FunctionDeclarations {
+			if decl != nil && candidates[decl.Name] {
+				decls = append(decls, decl)
+			}
+		}
+	}
+	if len(decls) == 0 {
+		return nil
+	}
+	selected, err := selector.Select(ctx, userText(ctx.UserContent()), decls)
+	if err != nil {
+		return fmt.Errorf("failed to select the tools: %w", err)
+	}
+	keep := make(map[string]bool, len(selected))
+	for _, decl := range selected {
+		keep[decl.Name] = true
+	}
+
+	// The genai tools are copied, they may be shared with the config of the
+	// agent.
+	filtered := make([]*genai.Tool, 0, len(req.Config.Tools))
+	for _, t := range req.Config.Tools {
+		if t == nil || len(t.FunctionDeclarations) == 0 {
+			filtered = append(filtered, t)
+			continue
+		}
+		var kept []*genai.FunctionDeclaration
+		for _, decl := range t.FunctionDeclarations {
+			if decl == nil || !candidates[decl.Name] || keep[decl.Name] {
+				kept = append(kept, decl)
+			}
+		}
+		if len(kept) == 0 && isFunctionsOnly(t) {
+			continue
+		}
+		copied := *t
+		copied.FunctionDeclarations = kept
+		filtered = append(filtered, &copied)
+	}
+	req.Config.Tools = filtered
+	return nil
+}
+
+// isFunctionsOnly reports whether the tool only holds function declarations.
+func isFunctionsOnly(t *genai.Tool) bool {
+	copied := *t
+	copied.FunctionDeclarations = nil
+	return reflect.ValueOf(copied).IsZero()
+}
+
+// userText returns the text of the user content, without the thoughts.
+func userText(c *genai.Content) string {
+	if c == nil {
+		return ""
+	}
+	var texts []string
+	for _, p := range c.Parts {
+		if p != nil && p.Text != "" && !p.Thought {
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging carries the logger of an invocation in the context, so
+// that every log line is correlated with the invocation, session, agent and
+// tool it was written for.
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Attribute keys attached to the log lines.
+const (
+	AppNameKey      = "app_name"
+	UserIDKey       = "user_id"
+	SessionIDKey    = "session_id"
+	InvocationIDKey = "invocation_id"
+	AgentNameKey    = "agent_name"
+	ToolNameKey     = "tool_name"
+)
+
+type loggerKey struct{}
+
+type redactPromptKey struct{}
+
+// ToContext returns a context carrying the logger.
+func ToContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of the context, or slog.Default.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With returns a context whose logger adds the given attributes to every
+// log line.
+func With(ctx context.Context, args ...any) context.Context {
+	return ToContext(ctx, FromContext(ctx).With(args...))
+}
+
+// WithPromptRedaction returns a context in which the prompts are logged as
+// returned by redact.
+func WithPromptRedaction(ctx context.Context, redact func(text string) string) context.Context {
+	return context.WithValue(ctx, redactPromptKey{}, redact)
+}
+
+// RedactPrompt returns the prompt text to log.
+func RedactPrompt(ctx context.Context, text string) string {
+	if redact, ok := ctx.Value(redactPromptKey{}).(func(string) string); ok && redact != nil {
+		return redact(text)
+	}
+	return text
+}
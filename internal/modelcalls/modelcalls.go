@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package modelcalls retains the requests sent to the models and their raw
+// responses, by the IDs of the events built from the responses, to debug
+// misbehaving prompts.
+package modelcalls
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/model"
+)
+
+// DefaultCapacity is the number of model calls retained by a Store created
+// with a capacity of zero.
+const DefaultCapacity = 100
+
+// Call is a model call. The request is serialized as sent to the model,
+// after the request processors and the before model callbacks; the
+// responses are serialized as returned by the model, before the after model
+// callbacks.
+type Call struct {
+	InvocationID string          `json:"invocation_id"`
+	Agent        string          `json:"agent"`
+	Model        string          `json:"model"`
+	StartTime    time.Time       `json:"start_time"`
+	EndTime      time.Time       `json:"end_time"`
+	Request      json.RawMessage `json:"request"`
+	// Responses has a response per chunk if the call was streamed.
+	Responses []Response `json:"responses"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// Response is a raw response of a model call.
+type Response struct {
+	// EventID is the ID of the event built from the response.
+	EventID  string          `json:"event_id"`
+	Response json.RawMessage `json:"response"`
+}
+
+// NewCall starts recording a call. The request is serialized right away,
+// later changes to it are not recorded.
+func NewCall(invocationID, agent, modelName string, req *model.LLMRequest) *Call {
+	return &Call{
+		InvocationID: invocationID,
+		Agent:        agent,
+		Model:        modelName,
+		StartTime:    time.Now(),
+		Request:      marshal(req),
+	}
+}
+
+// AddResponse records a response of the call, or the error ending it.
+func (c *Call) AddResponse(eventID string, resp *model.LLMResponse, err error) {
+	if err != nil {
+		c.Error = err.Error()
+	}
+	if resp != nil {
+		c.Responses = append(c.Responses, Response{EventID: eventID, Response: marshal(resp)})
+	}
+}
+
+// marshal serializes v, or the error preventing it, e.g. a value in the
+// CustomMetadata that cannot be serialized.
+func marshal(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+	return data
+}
+
+// Store retains the last model calls in a ring buffer. It is safe for
+// concurrent use.
+type Store struct {
+	mu        sync.Mutex
+	calls     []*Call
+	next      int
+	byEventID map[string]*Call
+}
+
+// NewStore returns a Store retaining the last capacity calls, DefaultCapacity
+// if zero.
+func NewStore(capacity int) *Store {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Store{calls: make([]*Call, capacity), byEventID: make(map[string]*Call)}
+}
+
+// Add retains a completed call, evicting the oldest one if the store is
+// full. The call must not be changed afterwards.
+func (s *Store) Add(call *Call) {
+	call.EndTime = time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old := s.calls[s.next]; old != nil {
+		for _, resp := range old.Responses {
+			if s.byEventID[resp.EventID] == old {
+				delete(s.byEventID, resp.EventID)
+			}
+		}
+	}
+	s.calls[s.next] = call
+	s.next = (s.next + 1) % len(s.calls)
+	for _, resp := range call.Responses {
+		s.byEventID[resp.EventID] = call
+	}
+}
+
+// ByEventID returns the call whose response the event was built from.
+func (s *Store) ByEventID(eventID string) (*Call, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	call, ok := s.byEventID[eventID]
+	return call, ok
+}
+
+type ctxKey struct{}
+
+// ToContext makes the model calls of the invocations run with ctx recorded
+// in s.
+func ToContext(ctx context.Context, s *Store) context.Context {
+	return context.WithValue(ctx, ctxKey{}, s)
+}
+
+// FromContext returns the store of the model calls, nil if they are not
+// recorded.
+func FromContext(ctx context.Context) *Store {
+	s, _ := ctx.Value(ctxKey{}).(*Store)
+	return s
+}
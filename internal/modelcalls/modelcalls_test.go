@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modelcalls
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestStore_EvictsOldestCalls(t *testing.T) {
+	s := NewStore(2)
+	for i := range 3 {
+		call := NewCall("inv", "agent", "mock", &model.LLMRequest{})
+		call.AddResponse(fmt.Sprintf("partial-%d", i), &model.LLMResponse{Partial: true}, nil)
+		call.AddResponse(fmt.Sprintf("event-%d", i), &model.LLMResponse{Content: genai.NewContentFromText("hi", genai.RoleModel)}, nil)
+		s.Add(call)
+	}
+	for _, id := range []string{"partial-0", "event-0"} {
+		if _, ok := s.ByEventID(id); ok {
+			t.Errorf("ByEventID(%q) found an evicted call", id)
+		}
+	}
+	for _, id := range []string{"partial-1", "event-1", "partial-2", "event-2"} {
+		if _, ok := s.ByEventID(id); !ok {
+			t.Errorf("ByEventID(%q) = false, want the retained call", id)
+		}
+	}
+}
+
+func TestCall_AddResponse(t *testing.T) {
+	call := NewCall("inv", "agent", "mock", &model.LLMRequest{
+		Contents: []*genai.Content{genai.NewContentFromText("hello", genai.RoleUser)},
+	})
+	call.AddResponse("event", &model.LLMResponse{CustomMetadata: map[string]any{"bad": make(chan int)}}, nil)
+	call.AddResponse("", nil, errors.New("quota exceeded"))
+
+	if got, want := string(call.Request), `{"Model":"","Contents":[{"parts":[{"text":"hello"}],"role":"user"}],"Config":null,"LiveConnectConfig":null}`; got != want {
+		t.Errorf("Request = %s, want %s", got, want)
+	}
+	if len(call.Responses) != 1 || !strings.Contains(string(call.Responses[0].Response), `{"error":"json: `) {
+		t.Errorf("Responses = %+v, want the serialization error", call.Responses)
+	}
+	if call.Error != "quota exceeded" {
+		t.Errorf("Error = %q, want %q", call.Error, "quota exceeded")
+	}
+}
@@ -270,7 +270,29 @@ func (pm *PluginManager) RunOnModelErrorCallback(cctx agent.CallbackContext, llm
 }
 
 // Close calls the CloseFunc on all registered plugins.
+// If the manager was configured with a CloseTimeout, Close returns an error
+// when the plugins don't finish closing within that time.
 func (pm *PluginManager) Close() error {
+	if pm.closeTimeout <= 0 {
+		return pm.closePlugins()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- pm.closePlugins()
+	}()
+
+	timer := time.NewTimer(pm.closeTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("failed to close plugins: timed out after %v", pm.closeTimeout)
+	}
+}
+
+func (pm *PluginManager) closePlugins() error {
 	var errors []error
 	for _, plugin := range pm.plugins {
 		if err := plugin.Close(); err != nil {
@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stagetimeout limits the duration of the stages of an invocation,
+// see runner.Timeouts.
+package stagetimeout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/adk/agent"
+)
+
+// The stages of an invocation, as reported by agent.StageTimeoutError.
+const (
+	ModelCall = "model call"
+	ToolCall  = "tool call"
+	SessionIO = "session I/O"
+	MCPPing   = "MCP ping"
+)
+
+// DefaultMCPPing is the timeout of the pings of the MCP sessions when
+// Config.MCPPing is zero.
+const DefaultMCPPing = 2 * time.Second
+
+// Config is the timeouts of the stages of the invocations of a runner.
+// Zero means no limit, except for MCPPing.
+type Config struct {
+	ModelCall time.Duration
+	ToolCall  time.Duration
+	SessionIO time.Duration
+	MCPPing   time.Duration
+}
+
+type configKey struct{}
+
+// ToContext returns a context whose stages are limited by cfg.
+func ToContext(ctx context.Context, cfg Config) context.Context {
+	return context.WithValue(ctx, configKey{}, cfg)
+}
+
+// FromContext returns the timeouts of ctx, with the defaults set.
+func FromContext(ctx context.Context) Config {
+	cfg, _ := ctx.Value(configKey{}).(Config)
+	if cfg.MCPPing == 0 {
+		cfg.MCPPing = DefaultMCPPing
+	}
+	return cfg
+}
+
+// With returns a context derived from ctx limited to the timeout of the
+// stage, cancelled with an *agent.StageTimeoutError cause. It returns ctx
+// unchanged if the timeout is not positive.
+func With(ctx context.Context, stage string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeoutCause(ctx, timeout, &agent.StageTimeoutError{Stage: stage, Timeout: timeout})
+}
+
+// Err returns the *agent.StageTimeoutError which cancelled ctx, returned by
+// With, or err if the stage did not time out, e.g. if err is nil or the
+// invocation was cancelled.
+func Err(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	var timeoutErr *agent.StageTimeoutError
+	if cause := context.Cause(ctx); errors.As(cause, &timeoutErr) {
+		return timeoutErr
+	}
+	return err
+}
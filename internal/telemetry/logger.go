@@ -82,7 +82,7 @@ func LogResponse(ctx context.Context, resp *model.LLMResponse, backend genai.Bac
 	kvs := []log.KeyValue{
 		// ADK internal data model only supports single candidate, even though the implementations can return multiple candidates. Hardcoding index to 0.
 		log.Int("index", 0),
-		{Key: "content", Value: contentToLogValue(content)},
+		{Key: "content", Value: contentToLogValue(fromContext(ctx).redactLoggedContent(content))},
 	}
 
 	if finishReason != "" {
@@ -106,7 +106,7 @@ func logSystemMessage(ctx context.Context, req *model.LLMRequest, genAISystem *l
 	record := log.Record{}
 	record.SetEventName("gen_ai.system.message")
 	record.SetBody(log.MapValue(
-		log.KeyValue{Key: "content", Value: extractSystemMessage(req)},
+		log.KeyValue{Key: "content", Value: extractSystemMessage(ctx, req)},
 	))
 	if genAISystem != nil {
 		record.AddAttributes(*genAISystem)
@@ -122,7 +122,7 @@ func logUserMessage(ctx context.Context, content *genai.Content, genAISystem *lo
 	record := log.Record{}
 	record.SetEventName("gen_ai.user.message")
 	record.SetBody(log.MapValue(
-		log.KeyValue{Key: "content", Value: toLogValue(contentToJSONLikeValue(content))},
+		log.KeyValue{Key: "content", Value: toLogValue(contentToJSONLikeValue(fromContext(ctx).redactLoggedContent(content)))},
 	))
 	if genAISystem != nil {
 		record.AddAttributes(*genAISystem)
@@ -146,7 +146,7 @@ func variantToGenAISystem(variant genai.Backend) *log.KeyValue {
 
 // extractSystemMessage extracts the system message from the request config and concatenates it into a single string.
 // If the content is elided, it returns the elided content string.
-func extractSystemMessage(req *model.LLMRequest) log.Value {
+func extractSystemMessage(ctx context.Context, req *model.LLMRequest) log.Value {
 	if !getGenAICaptureMessageContent() {
 		return log.StringValue(elidedContent)
 	}
@@ -154,7 +154,7 @@ func extractSystemMessage(req *model.LLMRequest) log.Value {
 		return log.Value{}
 	}
 	var text []string
-	for _, p := range req.Config.SystemInstruction.Parts {
+	for _, p := range fromContext(ctx).redactLoggedContent(req.Config.SystemInstruction).Parts {
 		if p.Text != "" {
 			text = append(text, p.Text)
 		}
@@ -576,6 +576,32 @@ func TestSpanIDPropagation(t *testing.T) {
 	}
 }
 
+func TestLogRequestAndResponse_RedactContent(t *testing.T) {
+	exporter := setup(t, true)
+	ctx := ToContext(t.Context(), Config{RedactContent: func(c *genai.Content) *genai.Content {
+		return &genai.Content{Role: c.Role, Parts: []*genai.Part{{Text: "[REDACTED]"}}}
+	}})
+
+	req := &model.LLMRequest{
+		Config: &genai.GenerateContentConfig{
+			SystemInstruction: genai.NewContentFromText("The user is jane@example.com.", "system"),
+		},
+		Contents: []*genai.Content{genai.NewContentFromText("I am jane@example.com", genai.RoleUser)},
+	}
+	LogRequest(ctx, req, genai.BackendVertexAI)
+	LogResponse(ctx, &model.LLMResponse{Content: genai.NewContentFromText("Hi jane@example.com", genai.RoleModel)}, genai.BackendVertexAI)
+
+	if len(exporter.records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(exporter.records))
+	}
+	for _, record := range exporter.records {
+		body := record.Body().String()
+		if strings.Contains(body, "jane@example.com") || !strings.Contains(body, "[REDACTED]") {
+			t.Errorf("record %s body = %s, want the content redacted", record.EventName(), body)
+		}
+	}
+}
+
 func setup(t *testing.T, elided bool) *inMemoryExporter {
 	exporter := &inMemoryExporter{}
 	provider := sdklog.NewLoggerProvider(
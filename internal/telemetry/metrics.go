@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.36.0"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/version"
+)
+
+var (
+	appNameKey   = attribute.Key("adk.app_name")
+	agentNameKey = attribute.Key("adk.agent_name")
+	modelKey     = attribute.Key("adk.model")
+	toolNameKey  = attribute.Key("adk.tool_name")
+	operationKey = attribute.Key("adk.operation")
+	errorKey     = attribute.Key("adk.error")
+	reconnectKey = attribute.Key("adk.reconnect")
+	tokenTypeKey = attribute.Key("adk.token_type")
+)
+
+// instruments records the ADK metrics. They are created from the global
+// meter provider, which forwards them to the provider registered later with
+// otel.SetMeterProvider.
+var instruments = newInstruments(otel.GetMeterProvider())
+
+type metricInstruments struct {
+	invocations       metric.Int64Counter
+	llmDuration       metric.Float64Histogram
+	tokens            metric.Int64Counter
+	toolErrors        metric.Int64Counter
+	mcpSessions       metric.Int64Counter
+	sessionOpDuration metric.Float64Histogram
+}
+
+func newInstruments(mp metric.MeterProvider) *metricInstruments {
+	meter := mp.Meter(systemName,
+		metric.WithInstrumentationVersion(version.Version),
+		metric.WithSchemaURL(semconv.SchemaURL),
+	)
+	// Creating an instrument only fails for invalid names or options, the
+	// returned instrument is usable anyway.
+	m := &metricInstruments{}
+	m.invocations, _ = meter.Int64Counter("adk.invocations",
+		metric.WithDescription("Number of agent invocations."),
+		metric.WithUnit("{invocation}"))
+	m.llmDuration, _ = meter.Float64Histogram("adk.llm.duration",
+		metric.WithDescription("Duration of the LLM calls."),
+		metric.WithUnit("s"))
+	m.tokens, _ = meter.Int64Counter("adk.llm.tokens",
+		metric.WithDescription("Number of tokens used by the LLM calls."),
+		metric.WithUnit("{token}"))
+	m.toolErrors, _ = meter.Int64Counter("adk.tool.errors",
+		metric.WithDescription("Number of failed tool calls."),
+		metric.WithUnit("{error}"))
+	m.mcpSessions, _ = meter.Int64Counter("adk.mcp.sessions",
+		metric.WithDescription("Number of MCP sessions established."),
+		metric.WithUnit("{session}"))
+	m.sessionOpDuration, _ = meter.Float64Histogram("adk.session.operation.duration",
+		metric.WithDescription("Duration of the session service operations."),
+		metric.WithUnit("s"))
+	return m
+}
+
+// RecordInvocation counts an invocation of the agent.
+func RecordInvocation(ctx context.Context, appName, agentName string) {
+	instruments.invocations.Add(ctx, 1, metric.WithAttributes(appNameKey.String(appName), agentNameKey.String(agentName)))
+}
+
+// RecordLLMCall records the latency and the token usage of an LLM call.
+func RecordLLMCall(ctx context.Context, modelName string, duration time.Duration, usage *genai.GenerateContentResponseUsageMetadata, err error) {
+	instruments.llmDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(modelKey.String(modelName), errorKey.Bool(err != nil)))
+	if usage == nil {
+		return
+	}
+	for tokenType, count := range map[string]int32{
+		"input":  usage.PromptTokenCount,
+		"output": usage.CandidatesTokenCount,
+	} {
+		if count > 0 {
+			instruments.tokens.Add(ctx, int64(count), metric.WithAttributes(modelKey.String(modelName), tokenTypeKey.String(tokenType)))
+		}
+	}
+}
+
+// RecordToolError counts a failed tool call.
+func RecordToolError(ctx context.Context, toolName string) {
+	instruments.toolErrors.Add(ctx, 1, metric.WithAttributes(toolNameKey.String(toolName)))
+}
+
+// RecordMCPSession counts an established MCP session.
+func RecordMCPSession(ctx context.Context, reconnect bool) {
+	instruments.mcpSessions.Add(ctx, 1, metric.WithAttributes(reconnectKey.Bool(reconnect)))
+}
+
+// RecordSessionOperation records the latency of a session service operation,
+// e.g. "get" or "append_event".
+func RecordSessionOperation(ctx context.Context, operation string, duration time.Duration, err error) {
+	instruments.sessionOpDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(operationKey.String(operation), errorKey.Bool(err != nil)))
+}
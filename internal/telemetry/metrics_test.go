@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/genai"
+)
+
+func TestMetrics(t *testing.T) {
+	ctx := t.Context()
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { _ = mp.Shutdown(ctx) })
+	original := instruments
+	instruments = newInstruments(mp)
+	t.Cleanup(func() { instruments = original })
+
+	RecordInvocation(ctx, "app", "root")
+	RecordInvocation(ctx, "app", "root")
+	RecordLLMCall(ctx, "gemini", time.Second, &genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 10, CandidatesTokenCount: 5}, nil)
+	RecordLLMCall(ctx, "gemini", time.Second, nil, errors.New("failed"))
+	RecordToolError(ctx, "weather")
+	RecordMCPSession(ctx, false)
+	RecordSessionOperation(ctx, "get", time.Millisecond, nil)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+	sums := make(map[string]int64)
+	counts := make(map[string]uint64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					sums[m.Name] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					counts[m.Name] += dp.Count
+				}
+			}
+		}
+	}
+
+	wantSums := map[string]int64{
+		"adk.invocations":  2,
+		"adk.llm.tokens":   15,
+		"adk.tool.errors":  1,
+		"adk.mcp.sessions": 1,
+	}
+	for name, want := range wantSums {
+		if got := sums[name]; got != want {
+			t.Errorf("%s = %d, want %d", name, got, want)
+		}
+	}
+	wantCounts := map[string]uint64{
+		"adk.llm.duration":               2,
+		"adk.session.operation.duration": 1,
+	}
+	for name, want := range wantCounts {
+		if got := counts[name]; got != want {
+			t.Errorf("%s recorded %d times, want %d", name, got, want)
+		}
+	}
+}
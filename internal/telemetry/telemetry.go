@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	semconv "go.opentelemetry.io/otel/semconv/v1.36.0"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genai"
 
 	"google.golang.org/adk/internal/version"
 	"google.golang.org/adk/model"
@@ -46,14 +47,90 @@ var (
 	gcpVertexAgentEventID          = attribute.Key("gcp.vertex.agent.event_id")
 	gcpVertexAgentToolResponseName = attribute.Key("gcp.vertex.agent.tool_response")
 	gcpVertexAgentInvocationID     = attribute.Key("gcp.vertex.agent.invocation_id")
+	gcpVertexAgentAppName          = attribute.Key("gcp.vertex.agent.app_name")
+	gcpVertexAgentUserID           = attribute.Key("gcp.vertex.agent.user_id")
 )
 
 // tracer is the tracer instance for ADK go.
-var tracer trace.Tracer = otel.GetTracerProvider().Tracer(
-	systemName,
-	trace.WithInstrumentationVersion(version.Version),
-	trace.WithSchemaURL(semconv.SchemaURL),
-)
+var tracer trace.Tracer = newTracer(otel.GetTracerProvider())
+
+func newTracer(tp trace.TracerProvider) trace.Tracer {
+	return tp.Tracer(
+		systemName,
+		trace.WithInstrumentationVersion(version.Version),
+		trace.WithSchemaURL(semconv.SchemaURL),
+	)
+}
+
+// RedactFunc returns the tool arguments or response to record in the spans.
+type RedactFunc func(toolName string, data map[string]any) map[string]any
+
+// RedactContentFunc returns the content of the model requests and responses
+// to record in the logs.
+type RedactContentFunc func(*genai.Content) *genai.Content
+
+// Config overrides the tracing of an invocation.
+type Config struct {
+	// TracerProvider replaces the global tracer provider.
+	TracerProvider trace.TracerProvider
+	// Redact is applied to the tool arguments and responses.
+	Redact RedactFunc
+	// RedactContent is applied to the logged contents of the model requests
+	// and responses.
+	RedactContent RedactContentFunc
+}
+
+type invocationTracing struct {
+	tracer        trace.Tracer
+	redact        RedactFunc
+	redactContent RedactContentFunc
+}
+
+type ctxKey struct{}
+
+// ToContext returns a context whose spans are created according to cfg.
+func ToContext(ctx context.Context, cfg Config) context.Context {
+	t := &invocationTracing{tracer: tracer, redact: cfg.Redact, redactContent: cfg.RedactContent}
+	if cfg.TracerProvider != nil {
+		t.tracer = newTracer(cfg.TracerProvider)
+	}
+	return context.WithValue(ctx, ctxKey{}, t)
+}
+
+func fromContext(ctx context.Context) *invocationTracing {
+	if t, ok := ctx.Value(ctxKey{}).(*invocationTracing); ok {
+		return t
+	}
+	return &invocationTracing{tracer: tracer}
+}
+
+func (t *invocationTracing) redactData(toolName string, data map[string]any) map[string]any {
+	if t.redact == nil || data == nil {
+		return data
+	}
+	return t.redact(toolName, data)
+}
+
+func (t *invocationTracing) redactLoggedContent(c *genai.Content) *genai.Content {
+	if t.redactContent == nil || c == nil {
+		return c
+	}
+	return t.redactContent(c)
+}
+
+// StartInvocationSpan starts the root span of an invocation.
+func StartInvocationSpan(ctx context.Context, appName, userID, sessionID string) (context.Context, trace.Span) {
+	return fromContext(ctx).tracer.Start(ctx, "invocation", trace.WithAttributes(
+		gcpVertexAgentAppName.String(appName),
+		gcpVertexAgentUserID.String(userID),
+		semconv.GenAIConversationID(sessionID),
+	))
+}
+
+// TraceInvocationID records the invocation ID on the invocation span.
+func TraceInvocationID(span trace.Span, invocationID string) {
+	span.SetAttributes(gcpVertexAgentInvocationID.String(invocationID))
+}
 
 type agent interface {
 	Name() string
@@ -64,7 +141,7 @@ type agent interface {
 // It returns a new context with the span and the span itself.
 func StartInvokeAgentSpan(ctx context.Context, agent agent, sessionID, invocationID string) (context.Context, trace.Span) {
 	agentName := agent.Name()
-	spanCtx, span := tracer.Start(ctx, fmt.Sprintf("invoke_agent %s", agentName), trace.WithAttributes(
+	spanCtx, span := fromContext(ctx).tracer.Start(ctx, fmt.Sprintf("invoke_agent %s", agentName), trace.WithAttributes(
 		gcpVertexAgentInvocationID.String(invocationID), // used by adk-web
 		semconv.GenAIOperationNameInvokeAgent,
 		semconv.GenAIAgentDescription(agent.Description()),
@@ -96,7 +173,7 @@ type StartGenerateContentSpanParams struct {
 // StartGenerateContentSpan starts a new semconv generate_content span.
 func StartGenerateContentSpan(ctx context.Context, params StartGenerateContentSpanParams) (context.Context, trace.Span) {
 	modelName := params.ModelName
-	spanCtx, span := tracer.Start(ctx, fmt.Sprintf("generate_content %s", modelName), trace.WithAttributes(
+	spanCtx, span := fromContext(ctx).tracer.Start(ctx, fmt.Sprintf("generate_content %s", modelName), trace.WithAttributes(
 		// Used by adk-web, can be removed once it reads the invocation id from invoke_agent span.
 		gcpVertexAgentInvocationID.String(params.InvocationID),
 		semconv.GenAIOperationNameGenerateContent,
@@ -140,10 +217,11 @@ type StartExecuteToolSpanParams struct {
 // StartExecuteToolSpan starts a new semconv execute_tool span.
 func StartExecuteToolSpan(ctx context.Context, params StartExecuteToolSpanParams) (context.Context, trace.Span) {
 	toolName := params.ToolName
-	spanCtx, span := tracer.Start(ctx, fmt.Sprintf("execute_tool %s", toolName), trace.WithAttributes(
+	t := fromContext(ctx)
+	spanCtx, span := t.tracer.Start(ctx, fmt.Sprintf("execute_tool %s", toolName), trace.WithAttributes(
 		semconv.GenAIOperationNameExecuteTool,
 		semconv.GenAIToolName(toolName),
-		gcpVertexAgentToolCallArgsName.String(safeSerialize(params.Args))))
+		gcpVertexAgentToolCallArgsName.String(safeSerialize(t.redactData(toolName, params.Args)))))
 	return spanCtx, span
 }
 
@@ -155,7 +233,7 @@ type TraceToolResultParams struct {
 }
 
 // TraceToolResult records the tool execution events.
-func TraceToolResult(span trace.Span, params TraceToolResultParams) {
+func TraceToolResult(ctx context.Context, span trace.Span, params TraceToolResultParams) {
 	recordErrorAndStatus(span, params.Error)
 
 	attributes := []attribute.KeyValue{
@@ -178,7 +256,7 @@ func TraceToolResult(span trace.Span, params TraceToolResultParams) {
 						toolCallID = functionResponse.ID
 					}
 					if functionResponse.Response != nil {
-						toolResponse = safeSerialize(functionResponse.Response)
+						toolResponse = safeSerialize(fromContext(ctx).redactData(functionResponse.Name, functionResponse.Response))
 					}
 				}
 			}
@@ -230,18 +308,18 @@ func WrapYield[T any](span trace.Span, yield func(T, error) bool, finalizeSpan f
 
 // StartTrace starts a new span with the given name.
 func StartTrace(ctx context.Context, traceName string) (context.Context, trace.Span) {
-	return tracer.Start(ctx, traceName)
+	return fromContext(ctx).tracer.Start(ctx, traceName)
 }
 
 // TraceMergedToolCallsResult records the result of the merged tool calls, including status and tool execution events.
-func TraceMergedToolCallsResult(span trace.Span, fnResponseEvent *session.Event, err error) {
+func TraceMergedToolCallsResult(ctx context.Context, span trace.Span, fnResponseEvent *session.Event, err error) {
 	recordErrorAndStatus(span, err)
 	attributes := []attribute.KeyValue{
 		semconv.GenAIOperationNameKey.String(executeToolName),
 		semconv.GenAIToolNameKey.String(mergeToolName),
 		semconv.GenAIToolDescriptionKey.String(mergeToolName),
 		gcpVertexAgentToolCallArgsName.String("N/A"),
-		gcpVertexAgentToolResponseName.String(safeSerialize(fnResponseEvent)),
+		gcpVertexAgentToolResponseName.String(safeSerialize(redactEvent(fromContext(ctx), fnResponseEvent))),
 	}
 	if fnResponseEvent != nil {
 		attributes = append(attributes, gcpVertexAgentEventID.String(fnResponseEvent.ID))
@@ -249,6 +327,28 @@ func TraceMergedToolCallsResult(span trace.Span, fnResponseEvent *session.Event,
 	span.SetAttributes(attributes...)
 }
 
+// redactEvent returns a copy of the event with redacted function responses.
+func redactEvent(t *invocationTracing, event *session.Event) *session.Event {
+	if t.redact == nil || event == nil || event.Content == nil {
+		return event
+	}
+	redacted := *event
+	content := *event.Content
+	content.Parts = make([]*genai.Part, len(event.Content.Parts))
+	for i, part := range event.Content.Parts {
+		if part.FunctionResponse != nil {
+			p := *part
+			fr := *part.FunctionResponse
+			fr.Response = t.redactData(fr.Name, fr.Response)
+			p.FunctionResponse = &fr
+			part = &p
+		}
+		content.Parts[i] = part
+	}
+	redacted.Content = &content
+	return &redacted
+}
+
 func safeSerialize(obj any) string {
 	dump, err := json.Marshal(obj)
 	if err != nil {
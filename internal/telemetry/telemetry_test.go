@@ -338,7 +338,7 @@ func TestExecuteTool(t *testing.T) {
 			ctx := t.Context()
 
 			_, span := StartExecuteToolSpan(ctx, tc.startParams)
-			TraceToolResult(span, tc.resultParams)
+			TraceToolResult(ctx, span, tc.resultParams)
 			span.End()
 
 			spans := exporter.GetSpans()
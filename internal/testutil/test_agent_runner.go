@@ -147,7 +147,8 @@ type MockModel struct {
 	StreamResponsesCount int
 }
 
-var errNoModelData = errors.New("no data")
+// ErrNoModelData is returned by MockModel when it runs out of responses.
+var ErrNoModelData = errors.New("no data")
 
 func (m *MockModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
 	if stream {
@@ -163,7 +164,7 @@ func (m *MockModel) GenerateContent(ctx context.Context, req *model.LLMRequest,
 func (m *MockModel) Generate(ctx context.Context, req *model.LLMRequest) (*model.LLMResponse, error) {
 	m.Requests = append(m.Requests, req)
 	if len(m.Responses) == 0 {
-		return nil, errNoModelData
+		return nil, ErrNoModelData
 	}
 
 	resp := &model.LLMResponse{
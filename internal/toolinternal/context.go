@@ -18,11 +18,12 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/uuid"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/auth"
+	"google.golang.org/adk/internal/authinternal"
 	contextinternal "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/memory"
 	"google.golang.org/adk/session"
@@ -52,7 +53,7 @@ func (ia *internalArtifacts) Save(ctx context.Context, name string, data *genai.
 
 func NewToolContext(ctx agent.InvocationContext, functionCallID string, actions *session.EventActions, confirmation *toolconfirmation.ToolConfirmation) tool.Context {
 	if functionCallID == "" {
-		functionCallID = uuid.NewString()
+		functionCallID = session.NewID(ctx)
 	}
 	if actions == nil {
 		actions = &session.EventActions{StateDelta: make(map[string]any)}
@@ -134,3 +135,26 @@ func (c *toolContext) RequestConfirmation(hint string, payload any) error {
 	c.eventActions.SkipSummarization = true
 	return nil
 }
+
+func (c *toolContext) Credential(cfg auth.Config) (*auth.Credential, error) {
+	key := auth.StoreKey{AppName: c.AppName(), UserID: c.UserID(), CredentialKey: cfg.Key()}
+	return authinternal.Resolve(c, cfg, key, c.functionCallID)
+}
+
+func (c *toolContext) RequestCredential(cfg auth.Config) error {
+	if c.functionCallID == "" {
+		return fmt.Errorf("error function call id not set when requesting credential for tool")
+	}
+	req, err := auth.NewRequest(cfg)
+	if err != nil {
+		return err
+	}
+	if c.eventActions.RequestedAuthConfigs == nil {
+		c.eventActions.RequestedAuthConfigs = make(map[string]auth.Config)
+	}
+	c.eventActions.RequestedAuthConfigs[c.functionCallID] = req
+	// Like RequestConfirmation, stops the agent loop until the client
+	// responds.
+	c.eventActions.SkipSummarization = true
+	return nil
+}
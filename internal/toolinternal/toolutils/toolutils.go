@@ -20,6 +20,7 @@ import (
 
 	"google.golang.org/genai"
 
+	"google.golang.org/adk/internal/convert"
 	"google.golang.org/adk/model"
 )
 
@@ -47,23 +48,6 @@ func PackTool(req *model.LLMRequest, tool Tool) error {
 	if req.Config == nil {
 		req.Config = &genai.GenerateContentConfig{}
 	}
-	if decl := tool.Declaration(); decl == nil {
-		return nil
-	}
-	// Find an existing genai.Tool with FunctionDeclarations
-	var funcTool *genai.Tool
-	for _, tool := range req.Config.Tools {
-		if tool != nil && tool.FunctionDeclarations != nil {
-			funcTool = tool
-			break
-		}
-	}
-	if funcTool == nil {
-		req.Config.Tools = append(req.Config.Tools, &genai.Tool{
-			FunctionDeclarations: []*genai.FunctionDeclaration{tool.Declaration()},
-		})
-	} else {
-		funcTool.FunctionDeclarations = append(funcTool.FunctionDeclarations, tool.Declaration())
-	}
+	convert.AddFunctionDeclarations(req.Config, tool.Declaration())
 	return nil
 }
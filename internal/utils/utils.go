@@ -15,12 +15,13 @@
 package utils
 
 import (
+	"context"
 	"strings"
 
-	"github.com/google/uuid"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/convert"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
 )
@@ -33,17 +34,18 @@ const afFunctionCallIDPrefix = "adk-"
 // Since the ID field is optional, some models don't fill the field, but
 // the LLMAgent depends on the IDs to map FunctionCall and FunctionResponse events
 // in the event stream.
-func PopulateClientFunctionCallID(c *genai.Content) {
+func PopulateClientFunctionCallID(ctx context.Context, c *genai.Content) {
 	for _, fn := range FunctionCalls(c) {
 		if fn.ID == "" {
-			fn.ID = GenerateFunctionCallID()
+			fn.ID = GenerateFunctionCallID(ctx)
 		}
 	}
 }
 
-// GenerateFunctionCallID generates a new function call ID.
-func GenerateFunctionCallID() string {
-	return afFunctionCallIDPrefix + uuid.NewString()
+// GenerateFunctionCallID generates a new function call ID with the ID
+// provider of ctx, see session.WithProviders.
+func GenerateFunctionCallID(ctx context.Context) string {
+	return afFunctionCallIDPrefix + session.NewID(ctx)
 }
 
 // RemoveClientFunctionCallID removes the function call ID field that was set
@@ -73,31 +75,14 @@ func Content(ev *session.Event) *genai.Content {
 
 // Belows are useful utilities that help working with genai.Content
 // included in types.Event.
-// TODO: Use generics.
 // FunctionCalls extracts all FunctionCall parts from the content.
-func FunctionCalls(c *genai.Content) (ret []*genai.FunctionCall) {
-	if c == nil {
-		return nil
-	}
-	for _, p := range c.Parts {
-		if p.FunctionCall != nil {
-			ret = append(ret, p.FunctionCall)
-		}
-	}
-	return ret
+func FunctionCalls(c *genai.Content) []*genai.FunctionCall {
+	return convert.FunctionCalls(c)
 }
 
 // FunctionResponses extracts all FunctionResponse parts from the content.
-func FunctionResponses(c *genai.Content) (ret []*genai.FunctionResponse) {
-	if c == nil {
-		return nil
-	}
-	for _, p := range c.Parts {
-		if p.FunctionResponse != nil {
-			ret = append(ret, p.FunctionResponse)
-		}
-	}
-	return ret
+func FunctionResponses(c *genai.Content) []*genai.FunctionResponse {
+	return convert.FunctionResponses(c)
 }
 
 // TextParts extracts all Text parts from the content.
@@ -136,20 +121,8 @@ func AppendInstructions(r *model.LLMRequest, instructions ...string) {
 	if len(instructions) == 0 {
 		return
 	}
-
-	inst := strings.Join(instructions, "\n\n")
-
 	if r.Config == nil {
 		r.Config = &genai.GenerateContentConfig{}
 	}
-
-	if r.Config.SystemInstruction == nil {
-		r.Config.SystemInstruction = genai.NewContentFromText(inst, genai.RoleUser)
-		return
-	}
-	if len(r.Config.SystemInstruction.Parts) > 0 && r.Config.SystemInstruction.Parts[len(r.Config.SystemInstruction.Parts)-1].Text != "" {
-		r.Config.SystemInstruction.Parts[len(r.Config.SystemInstruction.Parts)-1].Text += "\n\n" + inst
-		return
-	}
-	r.Config.SystemInstruction.Parts = append(r.Config.SystemInstruction.Parts, genai.NewPartFromText(inst))
+	convert.AppendInstructions(r.Config, instructions...)
 }
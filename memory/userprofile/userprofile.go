@@ -0,0 +1,379 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package userprofile provides a memory service that extracts structured
+// facts about the user (preferences, identifiers, etc.) from conversations
+// and keeps them in user-scoped session state.
+//
+// Facts are extracted with an LLM pass every time a session is added to
+// memory. The extracted facts are merged with the facts already known about
+// the user, de-duplicated and stored under a "user:" prefixed state key, so
+// they are visible from every session of the same user within the app.
+package userprofile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+// DefaultStateKey is the state key under which the facts are stored if
+// Config.StateKey is not set.
+const DefaultStateKey = session.KeyPrefixUser + "profile_facts"
+
+const defaultInstruction = `You extract durable facts about the user from a conversation.
+Only extract facts the user stated about themselves, such as preferences,
+names, identifiers, locations or other personal details that are useful in
+future conversations. Do not extract facts about the assistant, transient
+requests or anything the user did not state explicitly.
+
+Use short snake_case keys (for example "favorite_color", "email") and one of
+the categories "preference", "identifier" or "other".
+Return an empty list if there are no facts.`
+
+const author = "user_profile"
+
+// Fact is a single structured fact about the user.
+type Fact struct {
+	// Category groups facts, e.g. "preference" or "identifier".
+	Category string `json:"category"`
+	// Key identifies the fact within its category, e.g. "favorite_color".
+	Key string `json:"key"`
+	// Value of the fact, e.g. "blue".
+	Value string `json:"value"`
+	// UpdatedAt is the time the fact was last extracted.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Config is the configuration for the user profile memory service.
+type Config struct {
+	// Model is used to extract the facts from the session events.
+	Model model.LLM
+	// SessionService is used to persist the extracted facts in the
+	// user-scoped state of the session.
+	SessionService session.Service
+	// Memory is an optional memory service to which sessions are forwarded.
+	// If set, its search results are returned together with matching facts.
+	Memory memory.Service
+	// Instruction overrides the default extraction instruction.
+	Instruction string
+	// StateKey overrides the state key under which facts are stored.
+	// It must start with session.KeyPrefixUser.
+	StateKey string
+}
+
+// New creates a memory service that extracts user facts from sessions.
+func New(cfg Config) (memory.Service, error) {
+	if cfg.Model == nil {
+		return nil, errors.New("model is required")
+	}
+	if cfg.SessionService == nil {
+		return nil, errors.New("session service is required")
+	}
+	if cfg.Instruction == "" {
+		cfg.Instruction = defaultInstruction
+	}
+	if cfg.StateKey == "" {
+		cfg.StateKey = DefaultStateKey
+	}
+	if !strings.HasPrefix(cfg.StateKey, session.KeyPrefixUser) {
+		return nil, fmt.Errorf("state key %q must start with %q", cfg.StateKey, session.KeyPrefixUser)
+	}
+	return &service{cfg: cfg}, nil
+}
+
+type service struct {
+	cfg Config
+}
+
+// AddSessionToMemory extracts user facts from the session, merges them with
+// the facts already known and stores the result in the user-scoped state.
+// The session is forwarded to the wrapped memory service only after the facts
+// have been stored, so a failed extraction leaves both stores unchanged.
+func (s *service) AddSessionToMemory(ctx context.Context, curSession session.Session) error {
+	if err := s.updateFacts(ctx, curSession); err != nil {
+		return err
+	}
+	if s.cfg.Memory != nil {
+		return s.cfg.Memory.AddSessionToMemory(ctx, curSession)
+	}
+	return nil
+}
+
+func (s *service) updateFacts(ctx context.Context, curSession session.Session) error {
+	transcript := buildTranscript(curSession.Events())
+	if transcript == "" {
+		return nil
+	}
+
+	extracted, err := s.extract(ctx, transcript)
+	if err != nil {
+		return fmt.Errorf("failed to extract user facts: %w", err)
+	}
+	if len(extracted) == 0 {
+		return nil
+	}
+
+	known, err := Facts(curSession.State(), s.cfg.StateKey)
+	if err != nil {
+		return err
+	}
+	merged, changed := Merge(known, extracted, session.Now(ctx))
+	if !changed {
+		return nil
+	}
+
+	stored, err := toStateValue(merged)
+	if err != nil {
+		return err
+	}
+	event := session.NewEventWithContext(ctx, "")
+	event.Author = author
+	event.Actions.StateDelta[s.cfg.StateKey] = stored
+	if err := s.cfg.SessionService.AppendEvent(ctx, curSession, event); err != nil {
+		return fmt.Errorf("failed to store user facts: %w", err)
+	}
+	return nil
+}
+
+// SearchMemory returns the user facts whose category, key or value match
+// any word of the query, followed by the results of the wrapped memory
+// service if configured.
+func (s *service) SearchMemory(ctx context.Context, req *memory.SearchMemoryRequest) (*memory.SearchMemoryResponse, error) {
+	res := &memory.SearchMemoryResponse{}
+
+	facts, err := s.userFacts(ctx, req.AppName, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	queryWords := strings.Fields(strings.ToLower(req.Query))
+	for _, f := range facts {
+		if !matches(f, queryWords) {
+			continue
+		}
+		res.Memories = append(res.Memories, memory.Entry{
+			ID:        f.Category + "/" + f.Key,
+			Content:   genai.NewContentFromText(f.String(), genai.RoleUser),
+			Author:    author,
+			Timestamp: f.UpdatedAt,
+		})
+	}
+
+	if s.cfg.Memory != nil {
+		resp, err := s.cfg.Memory.SearchMemory(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		res.Memories = append(res.Memories, resp.Memories...)
+	}
+	return res, nil
+}
+
+// userFacts reads the facts from the user state.
+//
+// When called from a tool or callback, ctx carries the state of the current
+// session, which includes the user state, and no lookup is needed. Otherwise
+// the state is read from any session of the user returned by
+// session.Service.List. This relies on List returning sessions with the user
+// state merged in, which is the case for the in-memory and database session
+// services.
+func (s *service) userFacts(ctx context.Context, appName, userID string) ([]Fact, error) {
+	if rctx, ok := ctx.(agent.ReadonlyContext); ok && rctx.AppName() == appName && rctx.UserID() == userID {
+		return Facts(rctx.ReadonlyState(), s.cfg.StateKey)
+	}
+	if ictx, ok := ctx.(agent.InvocationContext); ok {
+		if sess := ictx.Session(); sess != nil && sess.AppName() == appName && sess.UserID() == userID {
+			return Facts(sess.State(), s.cfg.StateKey)
+		}
+	}
+
+	resp, err := s.cfg.SessionService.List(ctx, &session.ListRequest{AppName: appName, UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	if len(resp.Sessions) == 0 {
+		return nil, nil
+	}
+	return Facts(resp.Sessions[0].State(), s.cfg.StateKey)
+}
+
+func (s *service) extract(ctx context.Context, transcript string) ([]Fact, error) {
+	req := &model.LLMRequest{
+		Model:    s.cfg.Model.Name(),
+		Contents: []*genai.Content{genai.NewContentFromText(transcript, genai.RoleUser)},
+		Config: &genai.GenerateContentConfig{
+			SystemInstruction: genai.NewContentFromText(s.cfg.Instruction, genai.RoleUser),
+			ResponseMIMEType:  "application/json",
+			ResponseSchema:    factsSchema,
+		},
+	}
+
+	var text strings.Builder
+	for resp, err := range s.cfg.Model.GenerateContent(ctx, req, false) {
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil || resp.Content == nil {
+			continue
+		}
+		for _, part := range resp.Content.Parts {
+			text.WriteString(part.Text)
+		}
+	}
+
+	if strings.TrimSpace(text.String()) == "" {
+		return nil, nil
+	}
+	var facts []Fact
+	if err := json.Unmarshal([]byte(text.String()), &facts); err != nil {
+		return nil, fmt.Errorf("failed to parse model response: %w", err)
+	}
+	return facts, nil
+}
+
+var factsSchema = &genai.Schema{
+	Type: genai.TypeArray,
+	Items: &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"category": {Type: genai.TypeString},
+			"key":      {Type: genai.TypeString},
+			"value":    {Type: genai.TypeString},
+		},
+		Required: []string{"category", "key", "value"},
+	},
+}
+
+// Facts returns the facts stored in the state under the given key.
+// It returns an empty list if the key does not exist.
+func Facts(state session.ReadonlyState, key string) ([]Fact, error) {
+	val, err := state.Get(key)
+	if errors.Is(err, session.ErrStateKeyNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The value may have been round-tripped through a storage backend,
+	// so it is normalized via JSON instead of type-asserted.
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read facts from state key %q: %w", key, err)
+	}
+	var facts []Fact
+	if err := json.Unmarshal(data, &facts); err != nil {
+		return nil, fmt.Errorf("failed to read facts from state key %q: %w", key, err)
+	}
+	return facts, nil
+}
+
+// Merge merges the extracted facts into the known ones. Facts are identified
+// by their normalized category and key; an extracted fact replaces a known
+// one with a different value. Duplicates are dropped.
+// It returns the merged facts and whether anything has changed.
+func Merge(known, extracted []Fact, now time.Time) ([]Fact, bool) {
+	merged := slices.Clone(known)
+	index := make(map[string]int, len(merged))
+	for i, f := range merged {
+		index[f.id()] = i
+	}
+
+	changed := false
+	for _, f := range extracted {
+		f.Category = normalize(f.Category)
+		f.Key = normalize(f.Key)
+		f.Value = strings.TrimSpace(f.Value)
+		if f.Key == "" || f.Value == "" {
+			continue
+		}
+		f.UpdatedAt = now
+
+		i, ok := index[f.id()]
+		if !ok {
+			index[f.id()] = len(merged)
+			merged = append(merged, f)
+			changed = true
+			continue
+		}
+		if strings.EqualFold(merged[i].Value, f.Value) {
+			continue
+		}
+		merged[i] = f
+		changed = true
+	}
+	return merged, changed
+}
+
+// String returns a human readable representation of the fact.
+func (f Fact) String() string {
+	return fmt.Sprintf("%s %s: %s", f.Category, strings.ReplaceAll(f.Key, "_", " "), f.Value)
+}
+
+func (f Fact) id() string {
+	return normalize(f.Category) + "/" + normalize(f.Key)
+}
+
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Join(strings.Fields(s), "_")
+}
+
+func matches(f Fact, queryWords []string) bool {
+	text := strings.ToLower(f.String())
+	for _, w := range queryWords {
+		if strings.Contains(text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func buildTranscript(events session.Events) string {
+	var sb strings.Builder
+	for event := range events.All() {
+		if event.Content == nil {
+			continue
+		}
+		for _, part := range event.Content.Parts {
+			if part.Text == "" || part.Thought {
+				continue
+			}
+			fmt.Fprintf(&sb, "%s: %s\n", event.Author, part.Text)
+		}
+	}
+	return sb.String()
+}
+
+func toStateValue(facts []Fact) ([]any, error) {
+	data, err := json.Marshal(facts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode facts: %w", err)
+	}
+	var res []any
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("failed to encode facts: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,329 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userprofile_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/genai"
+	"gorm.io/gorm"
+
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/memory/userprofile"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/session/database"
+)
+
+func TestAddSessionToMemory(t *testing.T) {
+	ctx := t.Context()
+	sessionService := session.InMemoryService()
+	llm := &testutil.MockModel{
+		Responses: []*genai.Content{
+			genai.NewContentFromText(`[{"category":"preference","key":"favorite color","value":"blue"},{"category":"identifier","key":"email","value":"a@example.com"}]`, genai.RoleModel),
+			genai.NewContentFromText(`[{"category":"Preference","key":"favorite_color","value":"green"},{"category":"identifier","key":"email","value":"a@example.com"}]`, genai.RoleModel),
+		},
+	}
+	svc, err := userprofile.New(userprofile.Config{
+		Model:          llm,
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addSession := func(sessionID, text string) session.Session {
+		t.Helper()
+		resp, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user", SessionID: sessionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		event := session.NewEvent("inv")
+		event.Author = "user"
+		event.LLMResponse = model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleUser)}
+		if err := sessionService.AppendEvent(ctx, resp.Session, event); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.AddSessionToMemory(ctx, resp.Session); err != nil {
+			t.Fatalf("AddSessionToMemory() error = %v", err)
+		}
+		return resp.Session
+	}
+
+	addSession("s1", "My favorite color is blue, email me at a@example.com")
+	addSession("s2", "Actually I prefer green now")
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "app", UserID: "user", SessionID: "s1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := userprofile.Facts(resp.Session.State(), userprofile.DefaultStateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []userprofile.Fact{
+		{Category: "preference", Key: "favorite_color", Value: "green"},
+		{Category: "identifier", Key: "email", Value: "a@example.com"},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(userprofile.Fact{}, "UpdatedAt")); diff != "" {
+		t.Errorf("Facts() mismatch (-want +got):\n%s", diff)
+	}
+
+	search, err := svc.SearchMemory(ctx, &memory.SearchMemoryRequest{AppName: "app", UserID: "user", Query: "color"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(search.Memories) != 1 || search.Memories[0].ID != "preference/favorite_color" {
+		t.Errorf("SearchMemory() = %+v, want a single favorite_color entry", search.Memories)
+	}
+}
+
+func TestAddSessionToMemory_ModelResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  string
+		wantErr   bool
+		wantFacts bool
+	}{
+		{name: "malformed", response: `{"category": "preference"`, wantErr: true},
+		{name: "empty", response: ""},
+		{name: "empty list", response: "[]"},
+		{name: "facts", response: `[{"category":"preference","key":"color","value":"blue"}]`, wantFacts: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			sessionService := session.InMemoryService()
+			wrapped := memory.InMemoryService()
+			svc, err := userprofile.New(userprofile.Config{
+				Model:          &testutil.MockModel{Responses: []*genai.Content{genai.NewContentFromText(tt.response, genai.RoleModel)}},
+				SessionService: sessionService,
+				Memory:         wrapped,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			sess := createSession(t, sessionService, "user", "s1", "my favorite color is blue")
+
+			err = svc.AddSessionToMemory(ctx, sess)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddSessionToMemory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			facts, err := userprofile.Facts(getSession(t, sessionService, "user", "s1").State(), userprofile.DefaultStateKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotFacts := len(facts) > 0; gotFacts != tt.wantFacts {
+				t.Errorf("got facts %v, want facts: %v", facts, tt.wantFacts)
+			}
+
+			// The wrapped memory must not be updated if extraction failed.
+			resp, err := wrapped.SearchMemory(ctx, &memory.SearchMemoryRequest{AppName: "app", UserID: "user", Query: "color"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotForwarded := len(resp.Memories) > 0; gotForwarded == tt.wantErr {
+				t.Errorf("session forwarded to the wrapped memory = %v, want %v", gotForwarded, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearchMemory_WrappedMemory(t *testing.T) {
+	ctx := t.Context()
+	sessionService := session.InMemoryService()
+	svc, err := userprofile.New(userprofile.Config{
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromText(`[{"category":"preference","key":"favorite_color","value":"blue"}]`, genai.RoleModel),
+		}},
+		SessionService: sessionService,
+		Memory:         memory.InMemoryService(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AddSessionToMemory(ctx, createSession(t, sessionService, "user", "s1", "my favorite color is blue")); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := svc.SearchMemory(ctx, &memory.SearchMemoryRequest{AppName: "app", UserID: "user", Query: "color"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotAuthors []string
+	for _, m := range resp.Memories {
+		gotAuthors = append(gotAuthors, m.Author)
+	}
+	if diff := cmp.Diff([]string{"user_profile", "user"}, gotAuthors); diff != "" {
+		t.Errorf("SearchMemory() authors mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAddSessionToMemory_UserIsolation(t *testing.T) {
+	ctx := t.Context()
+	sessionService := session.InMemoryService()
+	svc, err := userprofile.New(userprofile.Config{
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromText(`[{"category":"identifier","key":"name","value":"Alice"}]`, genai.RoleModel),
+		}},
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AddSessionToMemory(ctx, createSession(t, sessionService, "alice", "s2", "my name is Alice")); err != nil {
+		t.Fatal(err)
+	}
+	other := createSession(t, sessionService, "bob", "s3", "hi")
+
+	facts, err := userprofile.Facts(getSession(t, sessionService, "bob", other.ID()).State(), userprofile.DefaultStateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(facts) != 0 {
+		t.Errorf("facts of another user are visible: %v", facts)
+	}
+	resp, err := svc.SearchMemory(ctx, &memory.SearchMemoryRequest{AppName: "app", UserID: "bob", Query: "name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Memories) != 0 {
+		t.Errorf("SearchMemory() for another user = %v, want no memories", resp.Memories)
+	}
+}
+
+func TestAddSessionToMemory_DatabaseSessionService(t *testing.T) {
+	ctx := t.Context()
+	sessionService, err := database.NewSessionService(sqlite.Open("file:userprofile?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.AutoMigrate(sessionService); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := userprofile.New(userprofile.Config{
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromText(`[{"category":"preference","key":"favorite_color","value":"blue"}]`, genai.RoleModel),
+		}},
+		SessionService: sessionService,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.AddSessionToMemory(ctx, createSession(t, sessionService, "user", "s1", "my favorite color is blue")); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := svc.SearchMemory(ctx, &memory.SearchMemoryRequest{AppName: "app", UserID: "user", Query: "color"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Memories) != 1 || resp.Memories[0].ID != "preference/favorite_color" {
+		t.Errorf("SearchMemory() = %+v, want a single favorite_color entry", resp.Memories)
+	}
+}
+
+func createSession(t *testing.T, service session.Service, userID, sessionID, text string) session.Session {
+	t.Helper()
+	resp, err := service.Create(t.Context(), &session.CreateRequest{AppName: "app", UserID: userID, SessionID: sessionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := session.NewEvent("inv")
+	event.Author = "user"
+	event.LLMResponse = model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleUser)}
+	if err := service.AppendEvent(t.Context(), resp.Session, event); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Session
+}
+
+func getSession(t *testing.T, service session.Service, userID, sessionID string) session.Session {
+	t.Helper()
+	resp, err := service.Get(t.Context(), &session.GetRequest{AppName: "app", UserID: userID, SessionID: sessionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Session
+}
+
+func TestMerge(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	known := []userprofile.Fact{
+		{Category: "preference", Key: "language", Value: "Go"},
+	}
+
+	tests := []struct {
+		name        string
+		extracted   []userprofile.Fact
+		want        []userprofile.Fact
+		wantChanged bool
+	}{
+		{
+			name:        "duplicate",
+			extracted:   []userprofile.Fact{{Category: " Preference", Key: "Language", Value: "go"}},
+			want:        known,
+			wantChanged: false,
+		},
+		{
+			name:      "updated",
+			extracted: []userprofile.Fact{{Category: "preference", Key: "language", Value: "Rust"}},
+			want: []userprofile.Fact{
+				{Category: "preference", Key: "language", Value: "Rust", UpdatedAt: now},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "new and empty",
+			extracted: []userprofile.Fact{
+				{Category: "identifier", Key: "user name", Value: "gopher"},
+				{Category: "identifier", Key: "phone", Value: " "},
+			},
+			want: []userprofile.Fact{
+				{Category: "preference", Key: "language", Value: "Go"},
+				{Category: "identifier", Key: "user_name", Value: "gopher", UpdatedAt: now},
+			},
+			wantChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := userprofile.Merge(known, tt.extracted, now)
+			if changed != tt.wantChanged {
+				t.Errorf("Merge() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Merge() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidStateKey(t *testing.T) {
+	_, err := userprofile.New(userprofile.Config{
+		Model:          &testutil.MockModel{},
+		SessionService: session.InMemoryService(),
+		StateKey:       "profile",
+	})
+	if err == nil {
+		t.Error("New() error = nil, want error for non user-scoped key")
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/model/gemini"
+	"google.golang.org/adk/secrets"
 )
 
 const (
@@ -53,6 +54,9 @@ type Config struct {
 	ProxyURL      string
 	CustomHeaders http.Header
 	HTTPClient    *http.Client // For testing only.
+	// Transport tunes the connections to the proxy, see
+	// gemini.WithTransport. Optional.
+	Transport *model.TransportConfig
 }
 
 // Option is a function that configures the Apigee LLM.
@@ -65,13 +69,23 @@ func WithProxyURL(proxyURL string) Option {
 	}
 }
 
-// WithCustomHeaders sets the custom headers for the Apigee LLM.
+// WithCustomHeaders sets the custom headers for the Apigee LLM. Their values
+// may be secret references, e.g. secret://env/APIGEE_API_KEY, resolved with
+// [secrets.Resolve].
 func WithCustomHeaders(headers http.Header) Option {
 	return func(c *Config) {
 		c.CustomHeaders = headers
 	}
 }
 
+// WithTransport tunes the connections to the proxy, shared by the models
+// configured with an equal config, see gemini.WithTransport.
+func WithTransport(transport model.TransportConfig) Option {
+	return func(c *Config) {
+		c.Transport = &transport
+	}
+}
+
 // WithHTTPClient sets the HTTP client for the Apigee LLM. This is for testing only.
 func WithHTTPClient(client *http.Client) Option {
 	return func(c *Config) {
@@ -102,7 +116,11 @@ func NewModel(ctx context.Context, modelName string, opts ...Option) (*apigeeMod
 		return nil, fmt.Errorf("%s environment variable not set", apigeeProxyURLEnvVar)
 	}
 
-	httpOptions := generateHTTPOptions(proxyURL, mi.apiVersion, cfg.CustomHeaders)
+	headers, err := resolveHeaders(ctx, cfg.CustomHeaders)
+	if err != nil {
+		return nil, err
+	}
+	httpOptions := generateHTTPOptions(proxyURL, mi.apiVersion, headers)
 
 	backendType := backendType(mi.isVertexAI)
 
@@ -111,7 +129,11 @@ func NewModel(ctx context.Context, modelName string, opts ...Option) (*apigeeMod
 		return nil, err
 	}
 
-	delegate, err := gemini.NewModel(ctx, mi.modelID, clientConfig)
+	var geminiOpts []gemini.Option
+	if cfg.Transport != nil {
+		geminiOpts = append(geminiOpts, gemini.WithTransport(*cfg.Transport))
+	}
+	delegate, err := gemini.NewModel(ctx, mi.modelID, clientConfig, geminiOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +203,24 @@ func resolveProxyURL(proxyURL string) string {
 	return os.Getenv(apigeeProxyURLEnvVar)
 }
 
+// resolveHeaders returns the headers with their secret references resolved.
+func resolveHeaders(ctx context.Context, headers http.Header) (http.Header, error) {
+	if headers == nil {
+		return nil, nil
+	}
+	resolved := make(http.Header, len(headers))
+	for k, values := range headers {
+		for _, v := range values {
+			v, err := secrets.Resolve(ctx, v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid header %s: %w", k, err)
+			}
+			resolved[k] = append(resolved[k], v)
+		}
+	}
+	return resolved, nil
+}
+
 func generateHTTPOptions(proxyURL, apiVersion string, customHeaders http.Header) *genai.HTTPOptions {
 	httpOptions := &genai.HTTPOptions{
 		BaseURL: proxyURL,
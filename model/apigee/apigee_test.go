@@ -213,6 +213,26 @@ func TestNewModelWithCustomHeaders(t *testing.T) {
 	}
 }
 
+func TestResolveHeaders(t *testing.T) {
+	t.Setenv("ADK_TEST_APIGEE_KEY", "secret-key")
+	headers := http.Header{}
+	headers.Set("X-Api-Key", "secret://env/ADK_TEST_APIGEE_KEY")
+	headers.Set("X-Custom-Header", "custom-value")
+	got, err := resolveHeaders(context.Background(), headers)
+	if err != nil {
+		t.Fatalf("resolveHeaders() returned an unexpected error: %v", err)
+	}
+	want := http.Header{"X-Api-Key": {"secret-key"}, "X-Custom-Header": {"custom-value"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("resolveHeaders() mismatch (-want +got):\n%s", diff)
+	}
+
+	headers.Set("X-Api-Key", "secret://env/ADK_TEST_MISSING_SECRET")
+	if _, err := resolveHeaders(context.Background(), headers); err == nil {
+		t.Errorf("resolveHeaders() with a missing secret did not return an error")
+	}
+}
+
 func TestNewModelWithoutProxyURL(t *testing.T) {
 	ctx := context.Background()
 	t.Setenv("GOOGLE_API_KEY", "test-key")
@@ -0,0 +1,304 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/genai"
+)
+
+// CandidatesConfig configures the multi-candidate generation of
+// WithCandidates.
+type CandidatesConfig struct {
+	// Count is the number of candidates to generate, at least 2.
+	Count int
+	// Policy selects the candidate returned. Defaults to FirstCandidate.
+	Policy SelectionPolicy // optional
+	// Retain keeps all the candidates in the Candidates of the returned
+	// response, and so in the event, for inspection.
+	Retain bool // optional
+}
+
+// SelectionPolicy selects one of the candidates generated for a request.
+type SelectionPolicy interface {
+	// Select returns the index of the selected candidate.
+	Select(ctx context.Context, req *LLMRequest, candidates []*LLMResponse) (int, error)
+}
+
+// SelectionPolicyFunc is an adapter to use a function as a SelectionPolicy.
+type SelectionPolicyFunc func(ctx context.Context, req *LLMRequest, candidates []*LLMResponse) (int, error)
+
+// Select calls f.
+func (f SelectionPolicyFunc) Select(ctx context.Context, req *LLMRequest, candidates []*LLMResponse) (int, error) {
+	return f(ctx, req, candidates)
+}
+
+// WithCandidates returns a model generating cfg.Count candidates for each
+// request and returning the one selected by cfg.Policy. The candidates are
+// requested with the CandidateCount of the request config; when the model
+// returns fewer, the missing ones are generated by concurrent calls. The
+// model is always called without streaming.
+//
+// Candidates without content, e.g. blocked by safety filters, are not
+// offered to the policy unless all the candidates are.
+func WithCandidates(llm LLM, cfg CandidatesConfig) LLM {
+	if cfg.Policy == nil {
+		cfg.Policy = FirstCandidate
+	}
+	return &candidatesLLM{LLM: llm, cfg: cfg}
+}
+
+type candidatesLLM struct {
+	LLM
+	cfg CandidatesConfig
+}
+
+func (m *candidatesLLM) GenerateContent(ctx context.Context, req *LLMRequest, stream bool) iter.Seq2[*LLMResponse, error] {
+	return func(yield func(*LLMResponse, error) bool) {
+		candidates, err := m.generate(ctx, req)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		offered := candidates
+		if valid := withContent(candidates); len(valid) > 0 {
+			offered = valid
+		}
+		i, err := m.cfg.Policy.Select(ctx, req, offered)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to select a candidate: %w", err))
+			return
+		}
+		if i < 0 || i >= len(offered) {
+			yield(nil, fmt.Errorf("selection policy returned candidate %d of %d", i, len(offered)))
+			return
+		}
+		resp := *offered[i]
+		resp.Candidates = nil
+		if m.cfg.Retain {
+			resp.Candidates = candidates
+		}
+		yield(&resp, nil)
+	}
+}
+
+// generate returns the candidates of the request, in the order generated.
+func (m *candidatesLLM) generate(ctx context.Context, req *LLMRequest) ([]*LLMResponse, error) {
+	count := max(m.cfg.Count, 1)
+	first, err := m.call(ctx, req, count)
+	if err != nil {
+		return nil, err
+	}
+	candidates := first.Candidates
+	if len(candidates) == 0 {
+		candidates = []*LLMResponse{first}
+	}
+	if len(candidates) >= count {
+		return candidates[:count], nil
+	}
+
+	missing := make([]*LLMResponse, count-len(candidates))
+	g, ctx := errgroup.WithContext(ctx)
+	for i := range missing {
+		g.Go(func() error {
+			resp, err := m.call(ctx, req, 0)
+			missing[i] = resp
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	for _, resp := range missing {
+		if len(resp.Candidates) > 0 {
+			resp = resp.Candidates[0]
+		}
+		candidates = append(candidates, resp)
+	}
+	return candidates, nil
+}
+
+// call calls the model with the candidate count, if not zero, and returns
+// its final response.
+func (m *candidatesLLM) call(ctx context.Context, req *LLMRequest, count int) (*LLMResponse, error) {
+	r := *req
+	config := genai.GenerateContentConfig{}
+	if req.Config != nil {
+		config = *req.Config
+	}
+	config.CandidateCount = int32(count)
+	r.Config = &config
+
+	var last *LLMResponse
+	for resp, err := range m.LLM.GenerateContent(ctx, &r, false) {
+		if err != nil {
+			return nil, err
+		}
+		last = resp
+	}
+	if last == nil {
+		return nil, fmt.Errorf("model %q returned no response", m.Name())
+	}
+	return last, nil
+}
+
+func withContent(candidates []*LLMResponse) []*LLMResponse {
+	var valid []*LLMResponse
+	for _, c := range candidates {
+		if c.Content != nil && len(c.Content.Parts) > 0 && c.ErrorCode == "" {
+			valid = append(valid, c)
+		}
+	}
+	return valid
+}
+
+// FirstCandidate selects the first candidate.
+var FirstCandidate SelectionPolicy = SelectionPolicyFunc(func(context.Context, *LLMRequest, []*LLMResponse) (int, error) {
+	return 0, nil
+})
+
+// MajorityVote returns a self-consistency policy selecting the most frequent
+// answer among the candidates. The answers are compared by their text, with
+// case and whitespace normalized, and by the names and arguments of their
+// function calls. Ties go to the earliest candidate.
+func MajorityVote() SelectionPolicy {
+	return SelectionPolicyFunc(func(_ context.Context, _ *LLMRequest, candidates []*LLMResponse) (int, error) {
+		votes := make(map[string]int)
+		first := make(map[string]int)
+		best, bestVotes := 0, 0
+		for i, c := range candidates {
+			key := answerKey(c)
+			if _, ok := first[key]; !ok {
+				first[key] = i
+			}
+			votes[key]++
+			if n := votes[key]; n > bestVotes || n == bestVotes && first[key] < best {
+				best, bestVotes = first[key], n
+			}
+		}
+		return best, nil
+	})
+}
+
+func answerKey(resp *LLMResponse) string {
+	if resp.Content == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, part := range resp.Content.Parts {
+		switch {
+		case part.Thought:
+		case part.FunctionCall != nil:
+			args, _ := json.Marshal(part.FunctionCall.Args)
+			fmt.Fprintf(&b, "call:%s(%s)\n", part.FunctionCall.Name, args)
+		case part.Text != "":
+			b.WriteString(strings.ToLower(strings.Join(strings.Fields(part.Text), " ")))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// JudgeRanking returns a policy asking the judge model to pick the best
+// candidate for the request. The judge sees the last user message of the
+// request and the numbered candidates, and answers with a number.
+func JudgeRanking(judge LLM) SelectionPolicy {
+	return SelectionPolicyFunc(func(ctx context.Context, req *LLMRequest, candidates []*LLMResponse) (int, error) {
+		if len(candidates) == 1 {
+			return 0, nil
+		}
+		prompt := judgePrompt(req, candidates)
+		var answer strings.Builder
+		for resp, err := range judge.GenerateContent(ctx, &LLMRequest{
+			Model:    judge.Name(),
+			Contents: []*genai.Content{genai.NewContentFromText(prompt, genai.RoleUser)},
+			Config:   &genai.GenerateContentConfig{},
+		}, false) {
+			if err != nil {
+				return 0, fmt.Errorf("judge model failed: %w", err)
+			}
+			if resp.Content != nil && !resp.Partial {
+				for _, part := range resp.Content.Parts {
+					if !part.Thought {
+						answer.WriteString(part.Text)
+					}
+				}
+			}
+		}
+		m := judgeAnswer.FindString(answer.String())
+		if m == "" {
+			return 0, fmt.Errorf("judge answered %q, want a candidate number", answer.String())
+		}
+		n, err := strconv.Atoi(m)
+		if err != nil || n < 1 || n > len(candidates) {
+			return 0, fmt.Errorf("judge answered %q, want a candidate number from 1 to %d", m, len(candidates))
+		}
+		return n - 1, nil
+	})
+}
+
+var judgeAnswer = regexp.MustCompile(`\d+`)
+
+func judgePrompt(req *LLMRequest, candidates []*LLMResponse) string {
+	var b strings.Builder
+	b.WriteString("Several candidate responses were generated for the request below. Pick the best one: the most correct, complete and helpful.\n\n")
+	for i := len(req.Contents) - 1; i >= 0; i-- {
+		if c := req.Contents[i]; c != nil && c.Role == genai.RoleUser && contentText(c) != "" {
+			fmt.Fprintf(&b, "Request:\n%s\n\n", contentText(c))
+			break
+		}
+	}
+	for i, c := range candidates {
+		fmt.Fprintf(&b, "Candidate %d:\n%s\n\n", i+1, candidateText(c))
+	}
+	fmt.Fprintf(&b, "Answer with the number of the best candidate only, from 1 to %d.", len(candidates))
+	return b.String()
+}
+
+func candidateText(resp *LLMResponse) string {
+	if resp.Content == nil {
+		return ""
+	}
+	var lines []string
+	for _, part := range resp.Content.Parts {
+		switch {
+		case part.Thought:
+		case part.FunctionCall != nil:
+			args, _ := json.Marshal(part.FunctionCall.Args)
+			lines = append(lines, fmt.Sprintf("Call %s(%s)", part.FunctionCall.Name, args))
+		case part.Text != "":
+			lines = append(lines, part.Text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func contentText(c *genai.Content) string {
+	var texts []string
+	for _, part := range c.Parts {
+		if part.Text != "" && !part.Thought {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// candidatesLLM returns up to perCall of its answers per call, in order.
+type candidatesLLM struct {
+	mu      sync.Mutex
+	answers []string
+	perCall int
+	counts  []int32
+}
+
+func (m *candidatesLLM) Name() string { return "candidates" }
+
+func (m *candidatesLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.mu.Lock()
+		m.counts = append(m.counts, req.Config.CandidateCount)
+		n := min(max(int(req.Config.CandidateCount), 1), m.perCall, len(m.answers))
+		answers := m.answers[:n]
+		m.answers = m.answers[n:]
+		m.mu.Unlock()
+
+		var candidates []*model.LLMResponse
+		for _, a := range answers {
+			candidates = append(candidates, &model.LLMResponse{Content: genai.NewContentFromText(a, genai.RoleModel)})
+		}
+		resp := *candidates[0]
+		if len(candidates) > 1 {
+			resp.Candidates = candidates
+		}
+		yield(&resp, nil)
+	}
+}
+
+func responseTexts(resps []*model.LLMResponse) []string {
+	var texts []string
+	for _, r := range resps {
+		texts = append(texts, r.Content.Parts[0].Text)
+	}
+	return texts
+}
+
+func TestWithCandidates(t *testing.T) {
+	tests := []struct {
+		name       string
+		answers    []string
+		perCall    int
+		policy     model.SelectionPolicy
+		retain     bool
+		want       string
+		wantCounts []int32
+	}{
+		{
+			name:       "first",
+			answers:    []string{"a", "b", "c"},
+			perCall:    3,
+			want:       "a",
+			wantCounts: []int32{3},
+		},
+		{
+			name:       "majority vote",
+			answers:    []string{"Paris", "Lyon", " paris "},
+			perCall:    3,
+			policy:     model.MajorityVote(),
+			retain:     true,
+			want:       "Paris",
+			wantCounts: []int32{3},
+		},
+		{
+			name:       "model without candidate count",
+			answers:    []string{"a", "b", "b"},
+			perCall:    1,
+			policy:     model.MajorityVote(),
+			retain:     true,
+			want:       "b",
+			wantCounts: []int32{3, 0, 0},
+		},
+		{
+			name:    "custom policy",
+			answers: []string{"a", "bbb", "cc"},
+			perCall: 3,
+			policy: model.SelectionPolicyFunc(func(_ context.Context, _ *model.LLMRequest, candidates []*model.LLMResponse) (int, error) {
+				longest := 0
+				for i, c := range candidates {
+					if len(c.Content.Parts[0].Text) > len(candidates[longest].Content.Parts[0].Text) {
+						longest = i
+					}
+				}
+				return longest, nil
+			}),
+			want:       "bbb",
+			wantCounts: []int32{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llm := &candidatesLLM{answers: tt.answers, perCall: tt.perCall}
+			m := model.WithCandidates(llm, model.CandidatesConfig{Count: 3, Policy: tt.policy, Retain: tt.retain})
+			var got *model.LLMResponse
+			for resp, err := range m.GenerateContent(t.Context(), &model.LLMRequest{}, true) {
+				if err != nil {
+					t.Fatalf("GenerateContent() error = %v", err)
+				}
+				got = resp
+			}
+			if text := got.Content.Parts[0].Text; text != tt.want {
+				t.Errorf("GenerateContent() = %q, want %q", text, tt.want)
+			}
+			if diff := cmp.Diff(tt.wantCounts, llm.counts); diff != "" {
+				t.Errorf("candidate counts mismatch (-want +got):\n%s", diff)
+			}
+			var wantCandidates []string
+			if tt.retain {
+				wantCandidates = tt.answers
+			}
+			if diff := cmp.Diff(wantCandidates, responseTexts(got.Candidates)); diff != "" {
+				t.Errorf("Candidates mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type judgeLLM struct {
+	answer string
+	prompt string
+}
+
+func (m *judgeLLM) Name() string { return "judge" }
+
+func (m *judgeLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.prompt = req.Contents[0].Parts[0].Text
+		yield(&model.LLMResponse{Content: genai.NewContentFromText(m.answer, genai.RoleModel)}, nil)
+	}
+}
+
+func TestJudgeRanking(t *testing.T) {
+	req := &model.LLMRequest{Contents: []*genai.Content{genai.NewContentFromText("What is the capital of France?", genai.RoleUser)}}
+	candidates := []*model.LLMResponse{
+		{Content: genai.NewContentFromText("Lyon", genai.RoleModel)},
+		{Content: genai.NewContentFromText("Paris", genai.RoleModel)},
+	}
+
+	judge := &judgeLLM{answer: "Candidate 2."}
+	got, err := model.JudgeRanking(judge).Select(t.Context(), req, candidates)
+	if err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+	if got != 1 {
+		t.Errorf("Select() = %d, want 1", got)
+	}
+	for _, want := range []string{"What is the capital of France?", "Candidate 1:\nLyon", "Candidate 2:\nParis"} {
+		if !strings.Contains(judge.prompt, want) {
+			t.Errorf("judge prompt = %q, want it to contain %q", judge.prompt, want)
+		}
+	}
+
+	for _, answer := range []string{"none", "3"} {
+		if _, err := model.JudgeRanking(&judgeLLM{answer: answer}).Select(t.Context(), req, candidates); err == nil {
+			t.Errorf("Select() with judge answer %q succeeded, want error", answer)
+		}
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"runtime"
 	"strings"
 
+	"cloud.google.com/go/auth/credentials"
+	"cloud.google.com/go/auth/httptransport"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/internal/llminternal"
@@ -30,6 +32,7 @@ import (
 	"google.golang.org/adk/internal/llminternal/googlellm"
 	"google.golang.org/adk/internal/version"
 	"google.golang.org/adk/model"
+	"google.golang.org/adk/secrets"
 )
 
 // TODO: test coverage
@@ -39,22 +42,58 @@ type geminiModel struct {
 	versionHeaderValue string
 }
 
+// Option configures the model created by NewModel.
+type Option func(*options)
+
+type options struct {
+	transport *model.TransportConfig
+}
+
+// WithTransport makes the model call the API through the transport of cfg,
+// shared by the models configured with an equal config, see
+// model.SharedTransport. It is ignored if the client config has an HTTP
+// client, used as is.
+func WithTransport(cfg model.TransportConfig) Option {
+	return func(o *options) {
+		o.transport = &cfg
+	}
+}
+
 // NewModel returns [model.LLM], backed by the Gemini API.
 //
 // It uses the provided context and configuration to initialize the underlying
 // [genai.Client]. The modelName specifies which Gemini model to target
 // (e.g., "gemini-2.5-flash").
 //
+// The API key of the config may be a secret reference, e.g.
+// secret://env/GEMINI_API_KEY, resolved with [secrets.Resolve].
+//
 // An error is returned if the [genai.Client] fails to initialize.
-func NewModel(ctx context.Context, modelName string, cfg *genai.ClientConfig) (model.LLM, error) {
+func NewModel(ctx context.Context, modelName string, cfg *genai.ClientConfig, opts ...Option) (model.LLM, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if cfg == nil && o.transport != nil {
+		cfg = &genai.ClientConfig{}
+	}
 	// Create a copy of the config to avoid mutating the caller's config
 	// or the underlying http.Client.
+	sharedTransport := false
 	if cfg != nil {
 		cfgCopy := *cfg
 		if cfg.HTTPClient != nil {
 			clientCopy := *cfg.HTTPClient
 			cfgCopy.HTTPClient = &clientCopy
+		} else if o.transport != nil {
+			cfgCopy.HTTPClient = &http.Client{Transport: model.SharedTransport(*o.transport)}
+			sharedTransport = true
 		}
+		apiKey, err := secrets.Resolve(ctx, cfg.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		cfgCopy.APIKey = apiKey
 		cfg = &cfgCopy
 	}
 
@@ -62,6 +101,11 @@ func NewModel(ctx context.Context, modelName string, cfg *genai.ClientConfig) (m
 	if err != nil {
 		return nil, err
 	}
+	if sharedTransport {
+		if err := authorize(ctx, client.ClientConfig()); err != nil {
+			return nil, err
+		}
+	}
 
 	if client.ClientConfig().HTTPClient != nil {
 		client.ClientConfig().HTTPClient.Transport = &mergeHeadersInterceptor{
@@ -80,6 +124,38 @@ func NewModel(ctx context.Context, modelName string, cfg *genai.ClientConfig) (m
 	}, nil
 }
 
+// authorize adds the credentials of the Vertex AI backend to the HTTP client
+// of cc, as genai.NewClient does only for the clients it creates.
+func authorize(ctx context.Context, cc genai.ClientConfig) error {
+	if cc.Backend != genai.BackendVertexAI || cc.APIKey != "" {
+		return nil
+	}
+	creds := cc.Credentials
+	if creds == nil {
+		var err error
+		creds, err = credentials.DetectDefault(&credentials.DetectOptions{
+			Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find default credentials: %w", err)
+		}
+	}
+	quotaProjectID, err := creds.QuotaProjectID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get quota project ID: %w", err)
+	}
+	authClient, err := httptransport.NewClient(&httptransport.Options{
+		Credentials:      creds,
+		Headers:          http.Header{"X-Goog-User-Project": []string{quotaProjectID}},
+		BaseRoundTripper: cc.HTTPClient.Transport,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	cc.HTTPClient.Transport = authClient.Transport
+	return nil
+}
+
 func (m *geminiModel) Name() string {
 	return m.name
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"iter"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -229,6 +230,76 @@ func TestModel_NoSideEffects(t *testing.T) {
 	}
 }
 
+func TestModel_WithTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "hi"}]}}]}`)
+	}))
+	defer server.Close()
+	transport := model.TransportConfig{MaxIdleConnsPerHost: 7}
+	newModel := func(t *testing.T) *geminiModel {
+		t.Helper()
+		llm, err := NewModel(t.Context(), "gemini-2.0-flash", &genai.ClientConfig{
+			Backend:     genai.BackendGeminiAPI,
+			APIKey:      "fake-api-key",
+			HTTPOptions: genai.HTTPOptions{BaseURL: server.URL + "/"},
+		}, WithTransport(transport))
+		if err != nil {
+			t.Fatalf("NewModel() error = %v", err)
+		}
+		return llm.(*geminiModel)
+	}
+	baseTransport := func(m *geminiModel) http.RoundTripper {
+		return m.client.ClientConfig().HTTPClient.Transport.(*mergeHeadersInterceptor).base
+	}
+
+	first, second := newModel(t), newModel(t)
+	if got := baseTransport(first); got != model.SharedTransport(transport) {
+		t.Errorf("transport of the model = %v, want the shared transport of the config", got)
+	}
+	if baseTransport(first) != baseTransport(second) {
+		t.Errorf("models with an equal transport config have different transports")
+	}
+	for resp, err := range second.GenerateContent(t.Context(), &model.LLMRequest{Contents: genai.Text("hello")}, false) {
+		if err != nil {
+			t.Fatalf("GenerateContent() error = %v", err)
+		}
+		if got := resp.Content.Parts[0].Text; got != "hi" {
+			t.Errorf("GenerateContent() text = %q, want %q", got, "hi")
+		}
+	}
+
+	// The HTTP client of the config is used as is.
+	httpClient := &http.Client{Transport: &http.Transport{}}
+	llm, err := NewModel(t.Context(), "gemini-2.0-flash", &genai.ClientConfig{Backend: genai.BackendGeminiAPI, APIKey: "fake-api-key", HTTPClient: httpClient}, WithTransport(transport))
+	if err != nil {
+		t.Fatalf("NewModel() error = %v", err)
+	}
+	if got := baseTransport(llm.(*geminiModel)); got != httpClient.Transport {
+		t.Errorf("transport of the model = %v, want the transport of the HTTP client of the config", got)
+	}
+}
+
+func TestModel_SecretAPIKey(t *testing.T) {
+	t.Setenv("ADK_TEST_GEMINI_KEY", "resolved-api-key")
+	cfg := &genai.ClientConfig{Backend: genai.BackendGeminiAPI, APIKey: "secret://env/ADK_TEST_GEMINI_KEY"}
+	llm, err := NewModel(t.Context(), "gemini-2.0-flash", cfg)
+	if err != nil {
+		t.Fatalf("NewModel() error = %v", err)
+	}
+	if got := llm.(*geminiModel).client.ClientConfig().APIKey; got != "resolved-api-key" {
+		t.Errorf("API key of the client = %q, want %q", got, "resolved-api-key")
+	}
+	if cfg.APIKey != "secret://env/ADK_TEST_GEMINI_KEY" {
+		t.Errorf("NewModel modified the API key of the passed config to %q", cfg.APIKey)
+	}
+
+	cfg.APIKey = "secret://env/ADK_TEST_MISSING_SECRET"
+	if _, err := NewModel(t.Context(), "gemini-2.0-flash", cfg); err == nil {
+		t.Errorf("NewModel() with a missing secret succeeded, want error")
+	}
+}
+
 func TestModel_RespectsRequestModel(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gemini
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// ConnectLive opens a live connection to the model with the Gemini Live API.
+func (m *geminiModel) ConnectLive(ctx context.Context, req *model.LLMRequest) (model.LiveConnection, error) {
+	session, err := m.client.Live.Connect(ctx, m.modelName(req), liveConnectConfig(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the live model: %w", err)
+	}
+	conn := &liveConnection{session: session}
+	if history := liveHistory(req.Contents); len(history) > 0 {
+		turnComplete := history[len(history)-1].Role == genai.RoleUser
+		if err := session.SendClientContent(genai.LiveClientContentInput{Turns: history, TurnComplete: &turnComplete}); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to send the conversation history: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// liveConnectConfig returns the LiveConnectConfig of the request, completed
+// with the system instruction, tools and generation parameters of its Config.
+func liveConnectConfig(req *model.LLMRequest) *genai.LiveConnectConfig {
+	var cfg genai.LiveConnectConfig
+	if req.LiveConnectConfig != nil {
+		cfg = *req.LiveConnectConfig
+	}
+	if c := req.Config; c != nil {
+		cfg.SystemInstruction = c.SystemInstruction
+		cfg.Tools = c.Tools
+		cfg.Temperature = c.Temperature
+		cfg.TopP = c.TopP
+		cfg.TopK = c.TopK
+		cfg.MaxOutputTokens = c.MaxOutputTokens
+		cfg.Seed = c.Seed
+		cfg.ThinkingConfig = c.ThinkingConfig
+		cfg.MediaResolution = c.MediaResolution
+		if cfg.SpeechConfig == nil {
+			cfg.SpeechConfig = c.SpeechConfig
+		}
+	}
+	return &cfg
+}
+
+// liveHistory returns the text of the conversation history; the other parts,
+// e.g. function calls, are not accepted as client content.
+func liveHistory(contents []*genai.Content) []*genai.Content {
+	var history []*genai.Content
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+		var parts []*genai.Part
+		for _, part := range content.Parts {
+			if part.Text != "" && !part.Thought {
+				parts = append(parts, genai.NewPartFromText(part.Text))
+			}
+		}
+		if len(parts) > 0 {
+			history = append(history, &genai.Content{Role: content.Role, Parts: parts})
+		}
+	}
+	return history
+}
+
+type liveConnection struct {
+	session *genai.Session
+	closed  atomic.Bool
+}
+
+func (c *liveConnection) SendContent(content *genai.Content) error {
+	var responses []*genai.FunctionResponse
+	for _, part := range content.Parts {
+		if part.FunctionResponse != nil {
+			responses = append(responses, part.FunctionResponse)
+		}
+	}
+	if len(responses) > 0 {
+		return c.session.SendToolResponse(genai.LiveToolResponseInput{FunctionResponses: responses})
+	}
+	turnComplete := true
+	return c.session.SendClientContent(genai.LiveClientContentInput{Turns: []*genai.Content{content}, TurnComplete: &turnComplete})
+}
+
+func (c *liveConnection) SendRealtime(input genai.LiveRealtimeInput) error {
+	return c.session.SendRealtimeInput(input)
+}
+
+func (c *liveConnection) Receive() iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		var text strings.Builder
+		for {
+			msg, err := c.session.Receive()
+			if err != nil {
+				var closeErr *websocket.CloseError
+				if c.closed.Load() || (errors.As(err, &closeErr) && closeErr.Code == websocket.CloseNormalClosure) {
+					return
+				}
+				yield(nil, fmt.Errorf("failed to receive from the live model: %w", err))
+				return
+			}
+			for _, resp := range liveResponses(msg, &text) {
+				if !yield(resp, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *liveConnection) Close() error {
+	if c.closed.Swap(true) {
+		return nil
+	}
+	return c.session.Close()
+}
+
+// liveResponses converts a message of the live model to responses. The text
+// streamed in partial responses is accumulated in text and repeated by a
+// final response when the turn completes, is interrupted or calls tools.
+func liveResponses(msg *genai.LiveServerMessage, text *strings.Builder) []*model.LLMResponse {
+	var responses []*model.LLMResponse
+	flushText := func() {
+		if text.Len() == 0 {
+			return
+		}
+		responses = append(responses, &model.LLMResponse{Content: genai.NewContentFromText(text.String(), genai.RoleModel)})
+		text.Reset()
+	}
+	if content := msg.ServerContent; content != nil {
+		if turn := content.ModelTurn; turn != nil && len(turn.Parts) > 0 {
+			for _, part := range turn.Parts {
+				if !part.Thought {
+					text.WriteString(part.Text)
+				}
+			}
+			responses = append(responses, &model.LLMResponse{
+				Content:           turn,
+				GroundingMetadata: content.GroundingMetadata,
+				Partial:           true,
+			})
+		}
+		if t := content.InputTranscription; t != nil {
+			responses = append(responses, &model.LLMResponse{InputTranscription: t, Partial: !t.Finished})
+		}
+		if t := content.OutputTranscription; t != nil {
+			responses = append(responses, &model.LLMResponse{OutputTranscription: t, Partial: !t.Finished})
+		}
+		if content.Interrupted {
+			flushText()
+			responses = append(responses, &model.LLMResponse{Interrupted: true})
+		}
+		if content.TurnComplete {
+			flushText()
+			responses = append(responses, &model.LLMResponse{TurnComplete: true})
+		}
+	}
+	if call := msg.ToolCall; call != nil && len(call.FunctionCalls) > 0 {
+		flushText()
+		parts := make([]*genai.Part, len(call.FunctionCalls))
+		for i, fc := range call.FunctionCalls {
+			parts[i] = &genai.Part{FunctionCall: fc}
+		}
+		responses = append(responses, &model.LLMResponse{Content: &genai.Content{Role: genai.RoleModel, Parts: parts}})
+	}
+	if usage := msg.UsageMetadata; usage != nil && len(responses) > 0 {
+		last := responses[len(responses)-1]
+		last.UsageMetadata = &genai.GenerateContentResponseUsageMetadata{
+			PromptTokenCount:     usage.PromptTokenCount,
+			CandidatesTokenCount: usage.ResponseTokenCount,
+			TotalTokenCount:      usage.TotalTokenCount,
+		}
+	}
+	return responses
+}
+
+var _ model.LiveLLM = &geminiModel{}
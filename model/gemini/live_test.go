@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gemini
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestLiveResponses(t *testing.T) {
+	call := &genai.FunctionCall{ID: "call-1", Name: "get_weather"}
+	messages := []*genai.LiveServerMessage{
+		{ServerContent: &genai.LiveServerContent{ModelTurn: genai.NewContentFromText("It is ", genai.RoleModel)}},
+		{ServerContent: &genai.LiveServerContent{
+			ModelTurn:           genai.NewContentFromText("sunny.", genai.RoleModel),
+			OutputTranscription: &genai.Transcription{Text: "It is sunny."},
+		}},
+		{ServerContent: &genai.LiveServerContent{TurnComplete: true}},
+		{ServerContent: &genai.LiveServerContent{InputTranscription: &genai.Transcription{Text: "And tomorrow?", Finished: true}}},
+		{ServerContent: &genai.LiveServerContent{ModelTurn: genai.NewContentFromText("Let me", genai.RoleModel)}},
+		{ToolCall: &genai.LiveServerToolCall{FunctionCalls: []*genai.FunctionCall{call}}},
+		{ServerContent: &genai.LiveServerContent{Interrupted: true}},
+	}
+	want := []*model.LLMResponse{
+		{Content: genai.NewContentFromText("It is ", genai.RoleModel), Partial: true},
+		{Content: genai.NewContentFromText("sunny.", genai.RoleModel), Partial: true},
+		{OutputTranscription: &genai.Transcription{Text: "It is sunny."}, Partial: true},
+		{Content: genai.NewContentFromText("It is sunny.", genai.RoleModel)},
+		{TurnComplete: true},
+		{InputTranscription: &genai.Transcription{Text: "And tomorrow?", Finished: true}},
+		{Content: genai.NewContentFromText("Let me", genai.RoleModel), Partial: true},
+		{Content: genai.NewContentFromText("Let me", genai.RoleModel)},
+		{Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: call}}}},
+		{Interrupted: true},
+	}
+
+	var text strings.Builder
+	var got []*model.LLMResponse
+	for _, msg := range messages {
+		got = append(got, liveResponses(msg, &text)...)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("liveResponses() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLiveConnectConfig(t *testing.T) {
+	temperature := float32(0.5)
+	instruction := genai.NewContentFromText("Be brief.", genai.RoleUser)
+	req := &model.LLMRequest{
+		Config: &genai.GenerateContentConfig{SystemInstruction: instruction, Temperature: &temperature},
+		LiveConnectConfig: &genai.LiveConnectConfig{
+			ResponseModalities: []genai.Modality{genai.ModalityAudio},
+		},
+	}
+	want := &genai.LiveConnectConfig{
+		ResponseModalities: []genai.Modality{genai.ModalityAudio},
+		SystemInstruction:  instruction,
+		Temperature:        &temperature,
+	}
+	if diff := cmp.Diff(want, liveConnectConfig(req)); diff != "" {
+		t.Errorf("liveConnectConfig() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"iter"
+)
+
+// GenerateFunc generates the responses of a request, like
+// LLM.GenerateContent.
+type GenerateFunc func(ctx context.Context, req *LLMRequest, stream bool) iter.Seq2[*LLMResponse, error]
+
+// Interceptor intercepts a call of a model. It calls next to call the model,
+// possibly with a modified request, and yields the responses, possibly
+// modified. It can also yield responses without calling next, e.g. from a
+// cache or to inject faults in tests.
+type Interceptor func(ctx context.Context, req *LLMRequest, stream bool, next GenerateFunc) iter.Seq2[*LLMResponse, error]
+
+// WithInterceptor returns a model calling llm through the interceptor on
+// every GenerateContent call. Unlike the model callbacks of LLM agents, it
+// also intercepts the calls made outside agents, e.g. for MCP sampling or
+// LLM judges.
+//
+// Interceptors are nested by wrapping the model several times, the last
+// one added sees the calls first.
+func WithInterceptor(llm LLM, fn Interceptor) LLM {
+	return &interceptedLLM{LLM: llm, fn: fn}
+}
+
+type interceptedLLM struct {
+	LLM
+	fn Interceptor
+}
+
+func (m *interceptedLLM) GenerateContent(ctx context.Context, req *LLMRequest, stream bool) iter.Seq2[*LLMResponse, error] {
+	return m.fn(ctx, req, stream, m.LLM.GenerateContent)
+}
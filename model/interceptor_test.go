@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"google.golang.org/adk/model"
+)
+
+func TestWithInterceptor(t *testing.T) {
+	llm := &countingLLM{}
+	var log []string
+	logging := model.WithInterceptor(llm, func(ctx context.Context, req *model.LLMRequest, stream bool, next model.GenerateFunc) iter.Seq2[*model.LLMResponse, error] {
+		return func(yield func(*model.LLMResponse, error) bool) {
+			log = append(log, "before "+req.Model)
+			for resp, err := range next(ctx, req, stream) {
+				if !yield(resp, err) {
+					return
+				}
+			}
+			log = append(log, "after")
+		}
+	})
+	mutating := model.WithInterceptor(logging, func(ctx context.Context, req *model.LLMRequest, stream bool, next model.GenerateFunc) iter.Seq2[*model.LLMResponse, error] {
+		req.Model = "mutated"
+		return next(ctx, req, stream)
+	})
+	if got := mutating.Name(); got != "counting" {
+		t.Errorf("Name() = %q, want the name of the wrapped model", got)
+	}
+	for resp, err := range mutating.GenerateContent(t.Context(), &model.LLMRequest{Model: "original"}, false) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Content.Parts[0].Text != "ok" {
+			t.Errorf("GenerateContent() = %+v, want the response of the model", resp)
+		}
+	}
+	if len(log) != 2 || log[0] != "before mutated" || log[1] != "after" {
+		t.Errorf("log = %q, want the mutated request logged before and after the call", log)
+	}
+	if llm.calls != 1 {
+		t.Errorf("model called %d times, want 1", llm.calls)
+	}
+
+	errInjected := errors.New("injected")
+	failing := model.WithInterceptor(llm, func(ctx context.Context, req *model.LLMRequest, stream bool, next model.GenerateFunc) iter.Seq2[*model.LLMResponse, error] {
+		return func(yield func(*model.LLMResponse, error) bool) {
+			yield(nil, errInjected)
+		}
+	})
+	for _, err := range failing.GenerateContent(t.Context(), &model.LLMRequest{}, false) {
+		if !errors.Is(err, errInjected) {
+			t.Errorf("GenerateContent() error = %v, want %v", err, errInjected)
+		}
+	}
+	if llm.calls != 1 {
+		t.Errorf("model called %d times, want the failing interceptor not to call it", llm.calls)
+	}
+}
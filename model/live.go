@@ -0,0 +1,49 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"iter"
+
+	"google.golang.org/genai"
+)
+
+// LiveLLM is implemented by the models supporting bidirectional streaming,
+// used by the agents run with runner.Runner.RunLive.
+type LiveLLM interface {
+	LLM
+	// ConnectLive opens a live connection to the model, configured by the
+	// Config and LiveConnectConfig of the request. The Contents of the
+	// request are sent as the conversation history.
+	ConnectLive(ctx context.Context, req *LLMRequest) (LiveConnection, error)
+}
+
+// LiveConnection is a live connection to a model, see [LiveLLM].
+type LiveConnection interface {
+	// SendContent sends a turn of the user, or the responses to the function
+	// calls of the model.
+	SendContent(content *genai.Content) error
+	// SendRealtime sends realtime input, e.g. a chunk of audio, or the start
+	// or end of the user activity.
+	SendRealtime(input genai.LiveRealtimeInput) error
+	// Receive yields the responses of the model until the connection is
+	// closed. Text and audio are streamed as partial responses; the text of
+	// a turn is repeated by a final, non-partial response before the
+	// response with TurnComplete.
+	Receive() iter.Seq2[*LLMResponse, error]
+	// Close closes the connection.
+	Close() error
+}
@@ -33,6 +33,10 @@ type LLMRequest struct {
 	Model    string
 	Contents []*genai.Content
 	Config   *genai.GenerateContentConfig
+	// LiveConnectConfig holds the settings of a live connection, see
+	// [LiveLLM]. The system instruction, tools and generation parameters
+	// are taken from Config.
+	LiveConnectConfig *genai.LiveConnectConfig
 
 	Tools map[string]any `json:"-"`
 }
@@ -57,9 +61,17 @@ type LLMResponse struct {
 	TurnComplete bool
 	// Flag indicating that LLM was interrupted when generating the content.
 	// Usually it is due to user interruption during a bidi streaming.
-	Interrupted  bool
-	ErrorCode    string
-	ErrorMessage string
-	FinishReason genai.FinishReason
-	AvgLogprobs  float64
+	Interrupted bool
+	// InputTranscription and OutputTranscription are the transcriptions of
+	// the audio input and output of a live connection.
+	InputTranscription  *genai.Transcription
+	OutputTranscription *genai.Transcription
+	ErrorCode           string
+	ErrorMessage        string
+	FinishReason        genai.FinishReason
+	AvgLogprobs         float64
+	// Candidates are all the candidates generated when more than one was
+	// requested, the response included. WithCandidates retains them only
+	// with CandidatesConfig.Retain.
+	Candidates []*LLMResponse
 }
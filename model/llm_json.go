@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+
+	"google.golang.org/genai"
+)
+
+// llmResponseJSON is the canonical JSON encoding of an LLMResponse. The
+// fields use the camelCase names of the genai types.
+type llmResponseJSON struct {
+	Content             *genai.Content                              `json:"content,omitempty"`
+	CitationMetadata    *genai.CitationMetadata                     `json:"citationMetadata,omitempty"`
+	GroundingMetadata   *genai.GroundingMetadata                    `json:"groundingMetadata,omitempty"`
+	UsageMetadata       *genai.GenerateContentResponseUsageMetadata `json:"usageMetadata,omitempty"`
+	CustomMetadata      map[string]any                              `json:"customMetadata,omitempty"`
+	LogprobsResult      *genai.LogprobsResult                       `json:"logprobsResult,omitempty"`
+	ModelVersion        string                                      `json:"modelVersion,omitempty"`
+	Partial             bool                                        `json:"partial,omitempty"`
+	TurnComplete        bool                                        `json:"turnComplete,omitempty"`
+	Interrupted         bool                                        `json:"interrupted,omitempty"`
+	InputTranscription  *genai.Transcription                        `json:"inputTranscription,omitempty"`
+	OutputTranscription *genai.Transcription                        `json:"outputTranscription,omitempty"`
+	ErrorCode           string                                      `json:"errorCode,omitempty"`
+	ErrorMessage        string                                      `json:"errorMessage,omitempty"`
+	FinishReason        genai.FinishReason                          `json:"finishReason,omitempty"`
+	AvgLogprobs         float64                                     `json:"avgLogprobs,omitempty"`
+	Candidates          []*LLMResponse                              `json:"candidates,omitempty"`
+}
+
+// MarshalJSON encodes the response with camelCase field names, omitting the
+// unset fields.
+func (r LLMResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(llmResponseJSON(r))
+}
+
+// UnmarshalJSON decodes the response. Unknown fields are ignored, and the
+// field names are matched case-insensitively, so the Go field names written
+// before the canonical encoding are accepted too.
+func (r *LLMResponse) UnmarshalJSON(data []byte) error {
+	var v llmResponseJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*r = LLMResponse(v)
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// OutputKind is the modality of an output part of a model response.
+type OutputKind string
+
+const (
+	OutputText  OutputKind = "text"
+	OutputAudio OutputKind = "audio"
+	OutputImage OutputKind = "image"
+	// OutputFile is any other inline data or file reference, e.g. a PDF.
+	OutputFile OutputKind = "file"
+)
+
+// Output is a typed output part of a model response, e.g. a chunk of the
+// audio streamed by a live model or an image generated by the model.
+type Output struct {
+	Kind OutputKind
+	Part *genai.Part
+	// MIMEType is the media type of the audio, image and file outputs,
+	// without its parameters, e.g. "audio/pcm".
+	MIMEType string
+	// SampleRate is the sample rate of the audio outputs in Hz, taken from
+	// the rate parameter of the MIME type, e.g. "audio/pcm;rate=24000".
+	// Zero if unknown.
+	SampleRate int
+}
+
+// Outputs returns the typed outputs of the response content. Thoughts,
+// function calls and function responses are not outputs.
+func (r *LLMResponse) Outputs() []Output {
+	if r == nil || r.Content == nil {
+		return nil
+	}
+	var outputs []Output
+	for _, part := range r.Content.Parts {
+		if out, ok := OutputOf(part); ok {
+			outputs = append(outputs, out)
+		}
+	}
+	return outputs
+}
+
+// OutputOf returns the typed output of the part. It reports false if the part
+// is not an output, see [LLMResponse.Outputs].
+func OutputOf(part *genai.Part) (Output, bool) {
+	switch {
+	case part == nil || part.Thought:
+		return Output{}, false
+	case part.InlineData != nil:
+		return blobOutput(part, part.InlineData.MIMEType), true
+	case part.FileData != nil:
+		return blobOutput(part, part.FileData.MIMEType), true
+	case part.Text != "":
+		return Output{Kind: OutputText, Part: part}, true
+	}
+	return Output{}, false
+}
+
+func blobOutput(part *genai.Part, mimeType string) Output {
+	out := Output{Kind: OutputFile, Part: part, MIMEType: mimeType}
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return out
+	}
+	out.MIMEType = mediaType
+	switch {
+	case strings.HasPrefix(mediaType, "audio/"):
+		out.Kind = OutputAudio
+		out.SampleRate, _ = strconv.Atoi(params["rate"])
+	case strings.HasPrefix(mediaType, "image/"):
+		out.Kind = OutputImage
+	}
+	return out
+}
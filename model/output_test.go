@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestLLMResponse_Outputs(t *testing.T) {
+	text := genai.NewPartFromText("hello")
+	thought := &genai.Part{Text: "hmm", Thought: true}
+	call := genai.NewPartFromFunctionCall("f", nil)
+	audio := genai.NewPartFromBytes([]byte{1, 2}, "audio/pcm;rate=24000")
+	image := genai.NewPartFromBytes([]byte{3}, "image/png")
+	pdf := genai.NewPartFromURI("gs://bucket/doc.pdf", "application/pdf")
+
+	resp := &model.LLMResponse{
+		Content: genai.NewContentFromParts([]*genai.Part{text, thought, call, audio, image, pdf}, genai.RoleModel),
+	}
+	want := []model.Output{
+		{Kind: model.OutputText, Part: text},
+		{Kind: model.OutputAudio, Part: audio, MIMEType: "audio/pcm", SampleRate: 24000},
+		{Kind: model.OutputImage, Part: image, MIMEType: "image/png"},
+		{Kind: model.OutputFile, Part: pdf, MIMEType: "application/pdf"},
+	}
+	if diff := cmp.Diff(want, resp.Outputs()); diff != "" {
+		t.Errorf("Outputs() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := (&model.LLMResponse{}).Outputs(); got != nil {
+		t.Errorf("Outputs() of an empty response = %v, want nil", got)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+	"sync"
+
+	"google.golang.org/genai"
+)
+
+// GenerationProfile is a preset of generation parameters, selected by name
+// for an agent with llmagent.Config.GenerationProfile or for an invocation
+// with agent.RunConfig.GenerationProfile. The parameters left empty are not
+// set by the profile.
+//
+// The parameters of a request are set, from the lowest to the highest
+// precedence, by:
+//   - the profile of the agent,
+//   - the GenerateContentConfig of the agent,
+//   - the profile of the invocation,
+//   - the before model callbacks.
+type GenerationProfile struct {
+	Temperature      *float32
+	TopP             *float32
+	TopK             *float32
+	MaxOutputTokens  int32
+	StopSequences    []string
+	PresencePenalty  *float32
+	FrequencyPenalty *float32
+	Seed             *int32
+}
+
+// Names of the profiles registered by default. They can be replaced with
+// RegisterProfile.
+const (
+	// ProfilePrecise makes the responses as deterministic as the model
+	// allows, e.g. for extraction or classification.
+	ProfilePrecise = "precise"
+	// ProfileBalanced suits most conversations.
+	ProfileBalanced = "balanced"
+	// ProfileCreative favors varied responses, e.g. for brainstorming.
+	ProfileCreative = "creative"
+)
+
+var (
+	profilesMu sync.RWMutex
+	profiles   = map[string]GenerationProfile{
+		ProfilePrecise:  {Temperature: genai.Ptr[float32](0)},
+		ProfileBalanced: {Temperature: genai.Ptr[float32](0.7), TopP: genai.Ptr[float32](0.95)},
+		ProfileCreative: {Temperature: genai.Ptr[float32](1.2), TopP: genai.Ptr[float32](0.98)},
+	}
+)
+
+// RegisterProfile registers p under name, replacing the previous profile of
+// name, if any.
+func RegisterProfile(name string, p GenerationProfile) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	profiles[name] = p
+}
+
+// LookupProfile returns the profile registered under name.
+func LookupProfile(name string) (GenerationProfile, bool) {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	p, ok := profiles[name]
+	return p, ok
+}
+
+// Apply sets the parameters of the profile in cfg. If override is false,
+// only the parameters not set in cfg are set, the profile acting as
+// defaults.
+func (p GenerationProfile) Apply(cfg *genai.GenerateContentConfig, override bool) {
+	setPtr(&cfg.Temperature, p.Temperature, override)
+	setPtr(&cfg.TopP, p.TopP, override)
+	setPtr(&cfg.TopK, p.TopK, override)
+	setPtr(&cfg.PresencePenalty, p.PresencePenalty, override)
+	setPtr(&cfg.FrequencyPenalty, p.FrequencyPenalty, override)
+	setPtr(&cfg.Seed, p.Seed, override)
+	if p.MaxOutputTokens != 0 && (override || cfg.MaxOutputTokens == 0) {
+		cfg.MaxOutputTokens = p.MaxOutputTokens
+	}
+	if p.StopSequences != nil && (override || cfg.StopSequences == nil) {
+		cfg.StopSequences = slices.Clone(p.StopSequences)
+	}
+}
+
+func setPtr[T any](dst **T, v *T, override bool) {
+	if v != nil && (override || *dst == nil) {
+		*dst = genai.Ptr(*v)
+	}
+}
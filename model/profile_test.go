@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestGenerationProfile_Apply(t *testing.T) {
+	profile := model.GenerationProfile{
+		Temperature:     genai.Ptr[float32](0.2),
+		TopP:            genai.Ptr[float32](0.9),
+		MaxOutputTokens: 256,
+		StopSequences:   []string{"END"},
+	}
+	newConfig := func() *genai.GenerateContentConfig {
+		return &genai.GenerateContentConfig{
+			Temperature:     genai.Ptr[float32](1),
+			MaxOutputTokens: 1024,
+			Seed:            genai.Ptr[int32](7),
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		override bool
+		want     *genai.GenerateContentConfig
+	}{
+		{
+			name: "defaults",
+			want: &genai.GenerateContentConfig{
+				Temperature:     genai.Ptr[float32](1),
+				TopP:            genai.Ptr[float32](0.9),
+				MaxOutputTokens: 1024,
+				StopSequences:   []string{"END"},
+				Seed:            genai.Ptr[int32](7),
+			},
+		},
+		{
+			name:     "override",
+			override: true,
+			want: &genai.GenerateContentConfig{
+				Temperature:     genai.Ptr[float32](0.2),
+				TopP:            genai.Ptr[float32](0.9),
+				MaxOutputTokens: 256,
+				StopSequences:   []string{"END"},
+				Seed:            genai.Ptr[int32](7),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig()
+			profile.Apply(cfg, tt.override)
+			if diff := cmp.Diff(tt.want, cfg); diff != "" {
+				t.Errorf("Apply() mismatch (-want +got):\n%s", diff)
+			}
+			*cfg.TopP = 0
+			cfg.StopSequences[0] = "changed"
+			if *profile.TopP != 0.9 || profile.StopSequences[0] != "END" {
+				t.Error("changing the config changed the profile, want the values copied")
+			}
+		})
+	}
+}
+
+func TestRegisterProfile(t *testing.T) {
+	if _, ok := model.LookupProfile(model.ProfilePrecise); !ok {
+		t.Errorf("LookupProfile(%q) = false, want the default profile", model.ProfilePrecise)
+	}
+	model.RegisterProfile("test_terse", model.GenerationProfile{MaxOutputTokens: 64})
+	p, ok := model.LookupProfile("test_terse")
+	if !ok || p.MaxOutputTokens != 64 {
+		t.Errorf("LookupProfile(%q) = %+v, %v, want the registered profile", "test_terse", p, ok)
+	}
+	if _, ok := model.LookupProfile("unknown"); ok {
+		t.Errorf("LookupProfile(%q) = true, want false", "unknown")
+	}
+}
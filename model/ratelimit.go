@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genai"
+)
+
+// WithRateLimit returns a model calling llm at most at the rate of the
+// limiter. The calls wait for the limiter, so concurrent invocations, e.g.
+// of parallel eval cases, share the quota of the model.
+func WithRateLimit(llm LLM, limiter *rate.Limiter) LLM {
+	return &rateLimitedLLM{LLM: llm, limiter: limiter}
+}
+
+type rateLimitedLLM struct {
+	LLM
+	limiter *rate.Limiter
+}
+
+func (m *rateLimitedLLM) GenerateContent(ctx context.Context, req *LLMRequest, stream bool) iter.Seq2[*LLMResponse, error] {
+	return func(yield func(*LLMResponse, error) bool) {
+		if err := m.limiter.Wait(ctx); err != nil {
+			yield(nil, err)
+			return
+		}
+		for resp, err := range m.LLM.GenerateContent(ctx, req, stream) {
+			if !yield(resp, err) {
+				return
+			}
+		}
+	}
+}
+
+// IsRateLimitError reports whether the error is a rate limit or quota error
+// of the model API, which may succeed if retried later.
+func IsRateLimitError(err error) bool {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusTooManyRequests
+	}
+	var apiErrPtr *genai.APIError
+	if errors.As(err, &apiErrPtr) {
+		return apiErrPtr.Code == http.StatusTooManyRequests
+	}
+	return false
+}
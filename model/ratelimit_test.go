@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+type countingLLM struct {
+	calls int
+}
+
+func (m *countingLLM) Name() string { return "counting" }
+
+func (m *countingLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.calls++
+		yield(&model.LLMResponse{Content: genai.NewContentFromText("ok", genai.RoleModel)}, nil)
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	llm := &countingLLM{}
+	// One call every 50ms, without burst.
+	limited := model.WithRateLimit(llm, rate.NewLimiter(rate.Every(50*time.Millisecond), 1))
+	if got := limited.Name(); got != "counting" {
+		t.Errorf("Name() = %q, want the name of the wrapped model", got)
+	}
+	start := time.Now()
+	for range 3 {
+		for _, err := range limited.GenerateContent(t.Context(), &model.LLMRequest{}, false) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 calls took %v, want at least 100ms", elapsed)
+	}
+	if llm.calls != 3 {
+		t.Errorf("wrapped model called %d times, want 3", llm.calls)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	for _, err := range limited.GenerateContent(ctx, &model.LLMRequest{}, false) {
+		if err == nil {
+			t.Error("GenerateContent() with a cancelled context succeeded, want error")
+		}
+	}
+	if llm.calls != 3 {
+		t.Errorf("wrapped model called %d times after cancellation, want 3", llm.calls)
+	}
+}
+
+func TestIsRateLimitError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "rate limit", err: genai.APIError{Code: http.StatusTooManyRequests}, want: true},
+		{name: "wrapped rate limit", err: fmt.Errorf("model failed: %w", &genai.APIError{Code: http.StatusTooManyRequests}), want: true},
+		{name: "other API error", err: genai.APIError{Code: http.StatusBadRequest}, want: false},
+		{name: "other error", err: errors.New("boom"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := model.IsRateLimitError(tt.err); got != tt.want {
+				t.Errorf("IsRateLimitError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"cmp"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TransportConfig tunes the HTTP connections of the model clients, e.g. with
+// gemini.WithTransport. The zero value keeps more idle connections per host
+// than http.DefaultTransport, so that the concurrent calls to a provider reuse
+// their connections instead of opening new ones.
+type TransportConfig struct {
+	// MaxIdleConns limits the idle connections across all hosts. Defaults
+	// to 256.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits the idle connections kept per host.
+	// Defaults to 64.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the connections per host, including the ones in
+	// use; the calls over the limit wait for a connection. Zero means no
+	// limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes the connections idle for longer. Defaults to
+	// 90s.
+	IdleConnTimeout time.Duration
+	// DialTimeout limits the time to open a connection. Defaults to 30s.
+	DialTimeout time.Duration
+	// KeepAlive is the interval of the TCP keep-alive probes of the
+	// connections. Defaults to 30s; negative disables them.
+	KeepAlive time.Duration
+	// TLSHandshakeTimeout defaults to 10s.
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout limits the wait for the response headers of a
+	// call, not the streaming of its body. Zero means no limit.
+	ResponseHeaderTimeout time.Duration
+	// DisableHTTP2 restricts the connections to HTTP/1.1. By default, HTTP/2
+	// is negotiated, multiplexing the concurrent calls over fewer
+	// connections.
+	DisableHTTP2 bool
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = map[TransportConfig]*http.Transport{}
+)
+
+// SharedTransport returns the transport of cfg, shared by all the clients
+// configured with an equal config, so that they share a connection pool.
+func SharedTransport(cfg TransportConfig) *http.Transport {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+	if t, ok := transports[cfg]; ok {
+		return t
+	}
+	t := NewTransport(cfg)
+	transports[cfg] = t
+	return t
+}
+
+// NewTransport returns a new transport tuned by cfg. Unlike SharedTransport,
+// its connections are not shared with other clients.
+func NewTransport(cfg TransportConfig) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   cmp.Or(cfg.DialTimeout, 30*time.Second),
+		KeepAlive: cmp.Or(cfg.KeepAlive, 30*time.Second),
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = dialer.DialContext
+	t.MaxIdleConns = cmp.Or(cfg.MaxIdleConns, 256)
+	t.MaxIdleConnsPerHost = cmp.Or(cfg.MaxIdleConnsPerHost, 64)
+	t.MaxConnsPerHost = cfg.MaxConnsPerHost
+	t.IdleConnTimeout = cmp.Or(cfg.IdleConnTimeout, 90*time.Second)
+	t.TLSHandshakeTimeout = cmp.Or(cfg.TLSHandshakeTimeout, 10*time.Second)
+	t.ResponseHeaderTimeout = cfg.ResponseHeaderTimeout
+	t.ForceAttemptHTTP2 = !cfg.DisableHTTP2
+	if cfg.DisableHTTP2 {
+		t.Protocols = new(http.Protocols)
+		t.Protocols.SetHTTP1(true)
+	}
+	return t
+}
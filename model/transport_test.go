@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/adk/model"
+)
+
+func TestSharedTransport(t *testing.T) {
+	cfg := model.TransportConfig{MaxIdleConnsPerHost: 16, IdleConnTimeout: time.Minute}
+	transport := model.SharedTransport(cfg)
+	if got := model.SharedTransport(cfg); got != transport {
+		t.Errorf("SharedTransport() of an equal config returned another transport")
+	}
+	if got := model.SharedTransport(model.TransportConfig{MaxIdleConnsPerHost: 32}); got == transport {
+		t.Errorf("SharedTransport() of another config returned the same transport")
+	}
+	if transport.MaxIdleConnsPerHost != 16 || transport.IdleConnTimeout != time.Minute {
+		t.Errorf("SharedTransport() = %d idle connections per host for %v, want 16 for 1m", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	transport := model.NewTransport(model.TransportConfig{})
+	if transport.MaxIdleConns != 256 || transport.MaxIdleConnsPerHost != 64 || transport.IdleConnTimeout != 90*time.Second || transport.TLSHandshakeTimeout != 10*time.Second {
+		t.Errorf("NewTransport() of the zero config = %+v, want the defaults", transport)
+	}
+	if !transport.ForceAttemptHTTP2 {
+		t.Errorf("NewTransport() of the zero config does not attempt HTTP/2")
+	}
+	if transport.Proxy == nil {
+		t.Errorf("NewTransport() does not use the proxy of the environment")
+	}
+
+	transport = model.NewTransport(model.TransportConfig{DisableHTTP2: true, MaxConnsPerHost: 4})
+	if transport.ForceAttemptHTTP2 || transport.Protocols.HTTP2() || !transport.Protocols.HTTP1() {
+		t.Errorf("NewTransport() with DisableHTTP2 has protocols %v, want HTTP/1 only", transport.Protocols)
+	}
+	if transport.MaxConnsPerHost != 4 {
+		t.Errorf("NewTransport() MaxConnsPerHost = %d, want 4", transport.MaxConnsPerHost)
+	}
+	if model.NewTransport(model.TransportConfig{}) == model.NewTransport(model.TransportConfig{}) {
+		t.Errorf("NewTransport() returned the same transport twice")
+	}
+}
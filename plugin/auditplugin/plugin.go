@@ -0,0 +1,182 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditplugin provides a plugin recording every LLM request, LLM
+// response and tool call of the invocations to an audit [Sink], for the
+// retention of the complete LLM I/O separately from the sessions.
+package auditplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/tool"
+)
+
+// Kind is the kind of an audit [Record].
+type Kind string
+
+const (
+	KindLLMRequest  Kind = "llm_request"
+	KindLLMResponse Kind = "llm_response"
+	KindToolCall    Kind = "tool_call"
+)
+
+// Record is an audited LLM request, LLM response or tool call.
+type Record struct {
+	Time         time.Time `json:"time"`
+	Kind         Kind      `json:"kind"`
+	AppName      string    `json:"app_name"`
+	UserID       string    `json:"user_id"`
+	SessionID    string    `json:"session_id"`
+	InvocationID string    `json:"invocation_id"`
+	Agent        string    `json:"agent"`
+
+	// LLMRequest is set for KindLLMRequest records.
+	LLMRequest *model.LLMRequest `json:"llm_request,omitempty"`
+	// LLMResponse is set for KindLLMResponse records, unless the model
+	// failed.
+	LLMResponse *model.LLMResponse `json:"llm_response,omitempty"`
+	// ToolCall is set for KindToolCall records.
+	ToolCall *ToolCall `json:"tool_call,omitempty"`
+	// Error is the error of the model or the tool, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ToolCall is an audited tool call.
+type ToolCall struct {
+	Name     string         `json:"name"`
+	ID       string         `json:"id"`
+	Args     map[string]any `json:"args"`
+	Response map[string]any `json:"response,omitempty"`
+}
+
+// RedactFunc applies the redaction policy to a record before it is written
+// to the sink. The record references the live request, response and tool
+// arguments of the invocation: RedactFunc must return a modified copy rather
+// than modify them. Returning nil drops the record.
+type RedactFunc func(*Record) *Record
+
+// Config configures the audit plugin.
+type Config struct {
+	// Name of the plugin. Defaults to "audit_plugin".
+	Name string
+	// Sink receives the records. If it implements io.Closer, it is closed
+	// when the runner is closed.
+	Sink Sink
+	// Redact applies the redaction policy. By default the records are
+	// written as is.
+	Redact RedactFunc
+	// FailOnSinkError fails the model or tool call when a record cannot be
+	// written. By default the error is logged and the invocation continues.
+	FailOnSinkError bool
+}
+
+// New creates an instance of the audit plugin. It records the requests as
+// sent to the model, i.e. after the before-model callbacks of the plugins
+// installed before it.
+func New(cfg Config) (*plugin.Plugin, error) {
+	if cfg.Sink == nil {
+		return nil, errors.New("audit sink is required")
+	}
+	if cfg.Name == "" {
+		cfg.Name = "audit_plugin"
+	}
+	p := &auditPlugin{cfg: cfg}
+	return plugin.New(plugin.Config{
+		Name:                 cfg.Name,
+		BeforeModelCallback:  p.beforeModel,
+		AfterModelCallback:   p.afterModel,
+		OnModelErrorCallback: p.onModelError,
+		AfterToolCallback:    p.afterTool,
+		CloseFunc: func() error {
+			if closer, ok := cfg.Sink.(io.Closer); ok {
+				return closer.Close()
+			}
+			return nil
+		},
+	})
+}
+
+type auditPlugin struct {
+	cfg Config
+}
+
+func (p *auditPlugin) beforeModel(ctx agent.CallbackContext, req *model.LLMRequest) (*model.LLMResponse, error) {
+	record := newRecord(ctx, KindLLMRequest)
+	record.LLMRequest = req
+	return nil, p.write(ctx, record)
+}
+
+func (p *auditPlugin) afterModel(ctx agent.CallbackContext, resp *model.LLMResponse, respErr error) (*model.LLMResponse, error) {
+	// Errors are recorded by onModelError with the request.
+	if respErr != nil || resp == nil || resp.Partial {
+		return nil, nil
+	}
+	record := newRecord(ctx, KindLLMResponse)
+	record.LLMResponse = resp
+	return nil, p.write(ctx, record)
+}
+
+func (p *auditPlugin) onModelError(ctx agent.CallbackContext, req *model.LLMRequest, respErr error) (*model.LLMResponse, error) {
+	record := newRecord(ctx, KindLLMResponse)
+	record.Error = respErr.Error()
+	return nil, p.write(ctx, record)
+}
+
+func (p *auditPlugin) afterTool(ctx tool.Context, t tool.Tool, args, result map[string]any, err error) (map[string]any, error) {
+	record := newRecord(ctx, KindToolCall)
+	record.ToolCall = &ToolCall{Name: t.Name(), ID: ctx.FunctionCallID(), Args: args, Response: result}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	return nil, p.write(ctx, record)
+}
+
+func (p *auditPlugin) write(ctx context.Context, record *Record) error {
+	if p.cfg.Redact != nil {
+		if record = p.cfg.Redact(record); record == nil {
+			return nil
+		}
+	}
+	err := p.cfg.Sink.Write(ctx, record)
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("failed to write the audit record: %w", err)
+	if p.cfg.FailOnSinkError {
+		return err
+	}
+	logging.FromContext(ctx).ErrorContext(ctx, "Failed to write the audit record", "kind", record.Kind, "error", err)
+	return nil
+}
+
+func newRecord(ctx agent.CallbackContext, kind Kind) *Record {
+	return &Record{
+		Time:         time.Now(),
+		Kind:         kind,
+		AppName:      ctx.AppName(),
+		UserID:       ctx.UserID(),
+		SessionID:    ctx.SessionID(),
+		InvocationID: ctx.InvocationID(),
+		Agent:        ctx.AgentName(),
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditplugin_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/plugin/auditplugin"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+type recordingSink struct {
+	records []*auditplugin.Record
+}
+
+func (s *recordingSink) Write(_ context.Context, record *auditplugin.Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+type fakeInserter struct {
+	rows []any
+}
+
+func (i *fakeInserter) Put(_ context.Context, src any) error {
+	i.rows = append(i.rows, src)
+	return nil
+}
+
+func runAgent(t *testing.T, p *plugin.Plugin) {
+	t.Helper()
+	type args struct {
+		Secret string `json:"secret"`
+	}
+	lookup, err := functiontool.New(functiontool.Config{Name: "lookup"}, func(_ tool.Context, a args) (map[string]any, error) {
+		return map[string]any{"result": "found " + a.Secret}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name: "auditee",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("lookup", map[string]any{"secret": "s3cr3t"}, genai.RoleModel),
+			genai.NewContentFromText("done", genai.RoleModel),
+		}},
+		Tools: []tool.Tool{lookup},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := testutil.NewTestAgentRunnerWithPluginManager(t, a, runner.PluginConfig{Plugins: []*plugin.Plugin{p}})
+	if _, err := testutil.CollectEvents(r.Run(t, "session", "hello")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuditPlugin(t *testing.T) {
+	sink := &recordingSink{}
+	p, err := auditplugin.New(auditplugin.Config{Sink: sink})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runAgent(t, p)
+
+	var kinds []auditplugin.Kind
+	for _, record := range sink.records {
+		kinds = append(kinds, record.Kind)
+		if record.AppName != "test_app" || record.SessionID != "session" || record.Agent != "auditee" || record.InvocationID == "" {
+			t.Errorf("record %+v is missing the invocation details", record)
+		}
+	}
+	wantKinds := []auditplugin.Kind{
+		auditplugin.KindLLMRequest, auditplugin.KindLLMResponse, auditplugin.KindToolCall,
+		auditplugin.KindLLMRequest, auditplugin.KindLLMResponse,
+	}
+	if diff := cmp.Diff(wantKinds, kinds); diff != "" {
+		t.Fatalf("record kinds mismatch (-want +got):\n%s", diff)
+	}
+	if got := sink.records[0].LLMRequest.Contents[0].Parts[0].Text; got != "hello" {
+		t.Errorf("audited request text = %q, want %q", got, "hello")
+	}
+	wantCall := &auditplugin.ToolCall{
+		Name:     "lookup",
+		ID:       sink.records[2].ToolCall.ID,
+		Args:     map[string]any{"secret": "s3cr3t"},
+		Response: map[string]any{"result": "found s3cr3t"},
+	}
+	if diff := cmp.Diff(wantCall, sink.records[2].ToolCall); diff != "" {
+		t.Errorf("audited tool call mismatch (-want +got):\n%s", diff)
+	}
+	if got := sink.records[4].LLMResponse.Content.Parts[0].Text; got != "done" {
+		t.Errorf("audited response text = %q, want %q", got, "done")
+	}
+}
+
+func TestAuditPlugin_Redact(t *testing.T) {
+	sink := &recordingSink{}
+	p, err := auditplugin.New(auditplugin.Config{
+		Sink: sink,
+		Redact: func(record *auditplugin.Record) *auditplugin.Record {
+			if record.Kind == auditplugin.KindLLMRequest {
+				return nil
+			}
+			if record.ToolCall != nil {
+				redacted := *record
+				redacted.ToolCall = &auditplugin.ToolCall{Name: record.ToolCall.Name, ID: record.ToolCall.ID}
+				return &redacted
+			}
+			return record
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runAgent(t, p)
+
+	for _, record := range sink.records {
+		if record.Kind == auditplugin.KindLLMRequest {
+			t.Errorf("dropped record %+v was written", record)
+		}
+		if record.ToolCall != nil && (record.ToolCall.Args != nil || record.ToolCall.Response != nil) {
+			t.Errorf("tool call %+v was not redacted", record.ToolCall)
+		}
+	}
+	if len(sink.records) != 3 {
+		t.Errorf("got %d records, want 3", len(sink.records))
+	}
+}
+
+func TestAuditPlugin_SinkError(t *testing.T) {
+	failing := auditplugin.SinkFunc(func(context.Context, *auditplugin.Record) error {
+		return errors.New("sink unavailable")
+	})
+	p, err := auditplugin.New(auditplugin.Config{Sink: failing, FailOnSinkError: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "auditee",
+		Model: &testutil.MockModel{Responses: []*genai.Content{genai.NewContentFromText("done", genai.RoleModel)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := testutil.NewTestAgentRunnerWithPluginManager(t, a, runner.PluginConfig{Plugins: []*plugin.Plugin{p}})
+	if _, err := testutil.CollectEvents(r.Run(t, "session", "hello")); err == nil {
+		t.Error("Run() succeeded, want the sink error")
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := auditplugin.NewFileSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := auditplugin.New(auditplugin.Config{Sink: sink})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runAgent(t, p)
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var kinds []auditplugin.Kind
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record auditplugin.Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		kinds = append(kinds, record.Kind)
+	}
+	if len(kinds) != 5 {
+		t.Errorf("got %d audit lines (%v), want 5", len(kinds), kinds)
+	}
+}
+
+func TestBigQuerySink(t *testing.T) {
+	inserter := &fakeInserter{}
+	sink := auditplugin.NewBigQuerySink(inserter)
+	record := &auditplugin.Record{
+		Kind:         auditplugin.KindToolCall,
+		InvocationID: "inv-1",
+		ToolCall:     &auditplugin.ToolCall{Name: "lookup", ID: "call-1", Args: map[string]any{"q": "x"}},
+	}
+	if err := sink.Write(t.Context(), record); err != nil {
+		t.Fatal(err)
+	}
+	want := []any{&auditplugin.BigQueryRow{
+		Kind:         "tool_call",
+		InvocationID: "inv-1",
+		ToolCall:     `{"name":"lookup","id":"call-1","args":{"q":"x"}}`,
+	}}
+	if diff := cmp.Diff(want, inserter.rows); diff != "" {
+		t.Errorf("inserted rows mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sink receives the audit records. Write is called concurrently by the
+// invocations and must not retain the record after returning.
+type Sink interface {
+	Write(ctx context.Context, record *Record) error
+}
+
+// SinkFunc adapts a function to a [Sink].
+type SinkFunc func(ctx context.Context, record *Record) error
+
+// Write calls f(ctx, record).
+func (f SinkFunc) Write(ctx context.Context, record *Record) error {
+	return f(ctx, record)
+}
+
+// NewWriterSink returns a sink writing the records to w as JSON lines.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *writerSink) Write(_ context.Context, record *Record) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(b, '\n'))
+	return err
+}
+
+// FileSink is a sink appending the records to a file as JSON lines.
+type FileSink struct {
+	writerSink
+	f *os.File
+}
+
+// NewFileSink opens the file, creating it if needed, and returns a sink
+// appending the records to it. The file is closed with the sink.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the audit file: %w", err)
+	}
+	return &FileSink{writerSink: writerSink{w: f}, f: f}, nil
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
+
+// RowInserter inserts rows in a BigQuery table. It is implemented by
+// *bigquery.Inserter of cloud.google.com/go/bigquery.
+type RowInserter interface {
+	Put(ctx context.Context, src any) error
+}
+
+// BigQueryRow is the row written by the BigQuery sink. The LLM request, LLM
+// response and tool call are JSON encoded, to be stored in JSON or STRING
+// columns.
+type BigQueryRow struct {
+	Time         time.Time `bigquery:"time"`
+	Kind         string    `bigquery:"kind"`
+	AppName      string    `bigquery:"app_name"`
+	UserID       string    `bigquery:"user_id"`
+	SessionID    string    `bigquery:"session_id"`
+	InvocationID string    `bigquery:"invocation_id"`
+	Agent        string    `bigquery:"agent"`
+	LLMRequest   string    `bigquery:"llm_request"`
+	LLMResponse  string    `bigquery:"llm_response"`
+	ToolCall     string    `bigquery:"tool_call"`
+	Error        string    `bigquery:"error"`
+}
+
+// NewBigQuerySink returns a sink inserting a [BigQueryRow] per record with
+// the inserter, e.g.
+//
+//	inserter := client.Dataset("audit").Table("llm_io").Inserter()
+//	sink := auditplugin.NewBigQuerySink(inserter)
+func NewBigQuerySink(inserter RowInserter) Sink {
+	return SinkFunc(func(ctx context.Context, record *Record) error {
+		row := &BigQueryRow{
+			Time:         record.Time,
+			Kind:         string(record.Kind),
+			AppName:      record.AppName,
+			UserID:       record.UserID,
+			SessionID:    record.SessionID,
+			InvocationID: record.InvocationID,
+			Agent:        record.Agent,
+			Error:        record.Error,
+		}
+		var err error
+		if row.LLMRequest, err = marshalColumn(record.LLMRequest); err != nil {
+			return err
+		}
+		if row.LLMResponse, err = marshalColumn(record.LLMResponse); err != nil {
+			return err
+		}
+		if row.ToolCall, err = marshalColumn(record.ToolCall); err != nil {
+			return err
+		}
+		return inserter.Put(ctx, row)
+	})
+}
+
+func marshalColumn[T any](v *T) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -12,7 +12,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package plugin provides.
+// Package plugin provides plugins: sets of callbacks installed once on the
+// runner and applied globally to every agent, model and tool call.
+//
+// Plugins are meant for cross-cutting concerns such as logging, policy
+// enforcement or caching. Plugin callbacks run before the corresponding
+// agent-level callbacks; if a plugin callback returns a non-nil result, the
+// remaining plugins and the agent-level callbacks are skipped.
 package plugin
 
 import (
@@ -23,15 +29,25 @@ import (
 	"google.golang.org/adk/session"
 )
 
+// Config is the configuration for creating a new [Plugin].
+// All callbacks are optional.
 type Config struct {
+	// Name must be unique among the plugins of a runner.
 	Name string
 
+	// OnUserMessageCallback is called with the user message before it is
+	// appended to the session. A non-nil result replaces the message.
 	OnUserMessageCallback OnUserMessageCallback
 
+	// OnEventCallback is called for every event yielded by the agents before
+	// it is appended to the session. A non-nil result replaces the event.
 	OnEventCallback OnEventCallback
 
+	// BeforeRunCallback is called before the root agent runs. A non-nil
+	// result ends the invocation early.
 	BeforeRunCallback BeforeRunCallback
-	AfterRunCallback  AfterRunCallback
+	// AfterRunCallback is called once the invocation has finished.
+	AfterRunCallback AfterRunCallback
 
 	BeforeAgentCallback agent.BeforeAgentCallback
 	AfterAgentCallback  agent.AfterAgentCallback
@@ -44,9 +60,11 @@ type Config struct {
 	AfterToolCallback   llmagent.AfterToolCallback
 	OnToolErrorCallback llmagent.OnToolErrorCallback
 
+	// CloseFunc is called when the runner is closed.
 	CloseFunc func() error
 }
 
+// New creates a new [Plugin].
 func New(cfg Config) (*Plugin, error) {
 	p := &Plugin{
 		name:                  cfg.Name,
@@ -75,6 +93,7 @@ func New(cfg Config) (*Plugin, error) {
 	return p, nil
 }
 
+// Plugin is a named set of callbacks. Use [New] to create one.
 type Plugin struct {
 	name string
 
@@ -158,10 +177,14 @@ func (p *Plugin) OnToolErrorCallback() llmagent.OnToolErrorCallback {
 	return p.onToolErrorCallback
 }
 
+// OnUserMessageCallback is called with the user message of an invocation.
 type OnUserMessageCallback func(agent.InvocationContext, *genai.Content) (*genai.Content, error)
 
+// BeforeRunCallback is called before the root agent of an invocation runs.
 type BeforeRunCallback func(agent.InvocationContext) (*genai.Content, error)
 
+// AfterRunCallback is called after an invocation has finished.
 type AfterRunCallback func(agent.InvocationContext)
 
+// OnEventCallback is called for every event produced during an invocation.
 type OnEventCallback func(agent.InvocationContext, *session.Event) (*session.Event, error)
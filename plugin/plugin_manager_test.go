@@ -432,7 +432,7 @@ func TestCallTool(t *testing.T) {
 			stream := testRunner.Run(t, "session", "user input")
 
 			parts, err := testutil.CollectParts(stream)
-			if err != nil && err.Error() != "no data" {
+			if err != nil && !errors.Is(err, testutil.ErrNoModelData) {
 				t.Fatalf("agent returned (%v, %v), want result", parts, err)
 			}
 			var got map[string]any
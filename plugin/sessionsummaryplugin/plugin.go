@@ -0,0 +1,244 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sessionsummaryplugin provides a plugin giving the sessions a short
+// title and a rolling one-paragraph summary, generated by a model and stored
+// in the session state. They are returned with the sessions listed by
+// session.Service.List, e.g. for UIs to render meaningful conversation
+// lists, see [Title] and [Summary].
+package sessionsummaryplugin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/session"
+)
+
+// Session state keys written by the plugin.
+const (
+	// TitleKey holds the title of the session, generated after the first
+	// response of the agents.
+	TitleKey = "adk_session_title"
+	// SummaryKey holds the summary of the session, updated every
+	// Config.SummaryEvery turns.
+	SummaryKey = "adk_session_summary"
+	// SummaryTurnsKey holds the number of user turns covered by the summary.
+	SummaryTurnsKey = "adk_session_summary_turns"
+)
+
+// Config configures the session summary plugin.
+type Config struct {
+	// Name of the plugin. Defaults to "session_summary_plugin".
+	Name string
+	// Model generates the titles and summaries, e.g. a small and fast model.
+	Model model.LLM
+	// SummaryEvery is the number of user turns between the updates of the
+	// summary. Defaults to 5; negative disables the summaries.
+	SummaryEvery int // optional
+}
+
+// New creates an instance of the session summary plugin.
+//
+// The title and the summary are generated when the agents respond to the
+// user, and stored with the state delta of the response. The response is
+// delayed by the generation; a failed generation is logged and retried with
+// the next response.
+func New(cfg Config) (*plugin.Plugin, error) {
+	if cfg.Model == nil {
+		return nil, errors.New("model is required")
+	}
+	if cfg.Name == "" {
+		cfg.Name = "session_summary_plugin"
+	}
+	if cfg.SummaryEvery == 0 {
+		cfg.SummaryEvery = 5
+	}
+	p := &summaryPlugin{cfg: cfg}
+	return plugin.New(plugin.Config{
+		Name:            cfg.Name,
+		OnEventCallback: p.onEvent,
+	})
+}
+
+// Title returns the title of the session, empty if it has none yet.
+func Title(s session.Session) string {
+	return stateString(s.State(), TitleKey)
+}
+
+// Summary returns the summary of the session, empty if it has none yet.
+func Summary(s session.Session) string {
+	return stateString(s.State(), SummaryKey)
+}
+
+func stateString(state session.State, key string) string {
+	v, err := state.Get(key)
+	if err != nil {
+		return ""
+	}
+	s, _ := v.(string)
+	return s
+}
+
+type summaryPlugin struct {
+	cfg Config
+}
+
+const titleInstruction = `Write a short title, at most six words, for the conversation below, e.g. to list it among other conversations. Reply with the title only, without quotes or final punctuation.`
+
+const summaryInstruction = `Summarize the conversation below in a single paragraph of at most five sentences: what the user wants, what was done and what is pending. If a previous summary is given, update it with the new messages. Reply with the summary only.`
+
+func (p *summaryPlugin) onEvent(ctx agent.InvocationContext, event *session.Event) (*session.Event, error) {
+	if event.Author == "user" || event.Partial || event.ErrorCode != "" || event.Content == nil || !event.IsFinalResponse() {
+		return nil, nil
+	}
+	sess := ctx.Session()
+	state := sess.State()
+	events := append(collect(sess.Events()), event)
+	turns := userTurns(events)
+	logger := logging.FromContext(ctx)
+
+	delta := make(map[string]any)
+	if Title(sess) == "" {
+		title, err := p.generate(ctx, titleInstruction, transcript(events, 0))
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to generate the session title", "error", err)
+		} else {
+			delta[TitleKey] = strings.Trim(title, "\"'. ")
+		}
+	}
+
+	summarized := 0
+	if v, err := state.Get(SummaryTurnsKey); err == nil {
+		summarized = toInt(v)
+	}
+	if p.cfg.SummaryEvery > 0 && turns-summarized >= p.cfg.SummaryEvery {
+		input := transcript(events, summarized)
+		if previous := Summary(sess); previous != "" {
+			input = "Previous summary:\n" + previous + "\n\nNew messages:\n" + input
+		}
+		summary, err := p.generate(ctx, summaryInstruction, input)
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to generate the session summary", "error", err)
+		} else {
+			delta[SummaryKey] = summary
+			delta[SummaryTurnsKey] = turns
+		}
+	}
+
+	if len(delta) == 0 {
+		return nil, nil
+	}
+	if event.Actions.StateDelta == nil {
+		event.Actions.StateDelta = make(map[string]any)
+	}
+	for k, v := range delta {
+		event.Actions.StateDelta[k] = v
+	}
+	return event, nil
+}
+
+// generate returns the text generated by the model for the instruction and
+// the input.
+func (p *summaryPlugin) generate(ctx agent.InvocationContext, instruction, input string) (string, error) {
+	req := &model.LLMRequest{
+		Model:    p.cfg.Model.Name(),
+		Contents: []*genai.Content{genai.NewContentFromText(input, genai.RoleUser)},
+		Config: &genai.GenerateContentConfig{
+			SystemInstruction: genai.NewContentFromText(instruction, genai.RoleUser),
+		},
+	}
+	var text strings.Builder
+	for resp, err := range p.cfg.Model.GenerateContent(ctx, req, false) {
+		if err != nil {
+			return "", err
+		}
+		if resp.Partial || resp.Content == nil {
+			continue
+		}
+		for _, part := range resp.Content.Parts {
+			if !part.Thought {
+				text.WriteString(part.Text)
+			}
+		}
+	}
+	if strings.TrimSpace(text.String()) == "" {
+		return "", fmt.Errorf("model %q returned no text", p.cfg.Model.Name())
+	}
+	return strings.TrimSpace(text.String()), nil
+}
+
+func collect(events session.Events) []*session.Event {
+	all := make([]*session.Event, 0, events.Len()+1)
+	for event := range events.All() {
+		all = append(all, event)
+	}
+	return all
+}
+
+func userTurns(events []*session.Event) int {
+	turns := 0
+	for _, event := range events {
+		if event.Author == "user" {
+			turns++
+		}
+	}
+	return turns
+}
+
+// transcript returns the text of the messages of the conversation, from the
+// user turn after the first skip ones.
+func transcript(events []*session.Event, skip int) string {
+	var b strings.Builder
+	turns := 0
+	for _, event := range events {
+		if event.Author == "user" {
+			turns++
+		}
+		if turns <= skip || event.Partial || event.Content == nil {
+			continue
+		}
+		var text []string
+		for _, part := range event.Content.Parts {
+			if part.Text != "" && !part.Thought {
+				text = append(text, part.Text)
+			}
+		}
+		if len(text) > 0 {
+			fmt.Fprintf(&b, "%s: %s\n", event.Author, strings.Join(text, " "))
+		}
+	}
+	return b.String()
+}
+
+// toInt converts a number read from the state, e.g. a float64 once the state
+// went through JSON.
+func toInt(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	}
+	return 0
+}
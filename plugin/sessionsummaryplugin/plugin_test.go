@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessionsummaryplugin_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/plugin/sessionsummaryplugin"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+// summarizer answers the title and summary requests, and records their
+// inputs.
+type summarizer struct {
+	err    error
+	inputs []string
+}
+
+func (m *summarizer) Name() string { return "summarizer" }
+
+func (m *summarizer) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		if m.err != nil {
+			yield(nil, m.err)
+			return
+		}
+		input := req.Contents[0].Parts[0].Text
+		m.inputs = append(m.inputs, input)
+		text := "\"Weather in Paris.\""
+		if strings.HasPrefix(req.Config.SystemInstruction.Parts[0].Text, "Summarize") {
+			text = "The user asked about the weather."
+		}
+		yield(&model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel)}, nil)
+	}
+}
+
+func newRunner(t *testing.T, sessionService session.Service, summaryModel model.LLM) *runner.Runner {
+	t.Helper()
+	p, err := sessionsummaryplugin.New(sessionsummaryplugin.Config{Model: summaryModel, SummaryEvery: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name: "weather_agent",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromText("It is sunny.", genai.RoleModel),
+			genai.NewContentFromText("It is 25 degrees.", genai.RoleModel),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := runner.New(runner.Config{
+		AppName:           "app",
+		Agent:             a,
+		SessionService:    sessionService,
+		AutoCreateSession: true,
+		PluginConfig:      runner.PluginConfig{Plugins: []*plugin.Plugin{p}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func run(t *testing.T, r *runner.Runner, text string) {
+	t.Helper()
+	for _, err := range r.Run(t.Context(), "user", "session", genai.NewContentFromText(text, genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+}
+
+func listSession(t *testing.T, sessionService session.Service) session.Session {
+	t.Helper()
+	resp, err := sessionService.List(t.Context(), &session.ListRequest{AppName: "app", UserID: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Sessions) != 1 {
+		t.Fatalf("List() returned %d sessions, want 1", len(resp.Sessions))
+	}
+	return resp.Sessions[0]
+}
+
+func TestPlugin(t *testing.T) {
+	sessionService := session.InMemoryService()
+	summaryModel := &summarizer{}
+	r := newRunner(t, sessionService, summaryModel)
+
+	run(t, r, "What is the weather in Paris?")
+	sess := listSession(t, sessionService)
+	if got, want := sessionsummaryplugin.Title(sess), "Weather in Paris"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	if got := sessionsummaryplugin.Summary(sess); got != "" {
+		t.Errorf("Summary() after the first turn = %q, want none", got)
+	}
+
+	run(t, r, "And the temperature?")
+	sess = listSession(t, sessionService)
+	if got, want := sessionsummaryplugin.Summary(sess), "The user asked about the weather."; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+	if len(summaryModel.inputs) != 2 {
+		t.Fatalf("summarizer was called %d times, want once for the title and once for the summary", len(summaryModel.inputs))
+	}
+	wantInput := "user: What is the weather in Paris?\nweather_agent: It is sunny.\nuser: And the temperature?\nweather_agent: It is 25 degrees.\n"
+	if got := summaryModel.inputs[1]; got != wantInput {
+		t.Errorf("summary input = %q, want %q", got, wantInput)
+	}
+}
+
+func TestPlugin_ModelError(t *testing.T) {
+	sessionService := session.InMemoryService()
+	r := newRunner(t, sessionService, &summarizer{err: errors.New("unavailable")})
+
+	run(t, r, "What is the weather in Paris?")
+	sess := listSession(t, sessionService)
+	if got := sessionsummaryplugin.Title(sess); got != "" {
+		t.Errorf("Title() = %q, want none", got)
+	}
+	resp, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "app", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Session.Events().Len(); got != 2 {
+		t.Errorf("session has %d events, want the user message and the response", got)
+	}
+}
+
+func TestNew_RequiresModel(t *testing.T) {
+	if _, err := sessionsummaryplugin.New(sessionsummaryplugin.Config{}); err == nil {
+		t.Error("New() without a model succeeded, want an error")
+	}
+}
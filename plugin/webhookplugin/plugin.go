@@ -0,0 +1,333 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhookplugin provides a plugin posting signed JSON payloads to
+// webhooks on the lifecycle of the invocations, e.g. to open tickets or
+// raise alerts when an agent fails or waits for a confirmation.
+package webhookplugin
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+)
+
+// Kind is the kind of a webhook [Payload].
+type Kind string
+
+const (
+	KindInvocationStarted  Kind = "invocation.started"
+	KindInvocationFinished Kind = "invocation.finished"
+	// KindInvocationError is sent for every error of an invocation: the
+	// model and tool errors, and the events with an error code.
+	KindInvocationError Kind = "invocation.error"
+	// KindToolConfirmationRequested is sent for the events requesting the
+	// confirmation of a tool call, see tool.Context.RequestConfirmation.
+	KindToolConfirmationRequested Kind = "tool_confirmation.requested"
+	// KindEscalation is sent for the events escalating to the parent
+	// agent, see session.EventActions.Escalate.
+	KindEscalation Kind = "escalation"
+)
+
+// Headers of the webhook requests.
+const (
+	// SignatureHeader is the signature of the payload, "sha256=" followed by
+	// the hex encoded HMAC-SHA256 of the timestamp, a dot and the body, see
+	// [Sign].
+	SignatureHeader = "X-ADK-Signature"
+	// TimestampHeader is the Unix time of the request in seconds, signed
+	// with the body to prevent replays.
+	TimestampHeader = "X-ADK-Timestamp"
+)
+
+// Payload is the JSON body posted to the webhooks.
+type Payload struct {
+	// ID identifies the payload, e.g. to deduplicate the deliveries.
+	ID           string    `json:"id"`
+	Kind         Kind      `json:"kind"`
+	Time         time.Time `json:"time"`
+	AppName      string    `json:"app_name"`
+	UserID       string    `json:"user_id"`
+	SessionID    string    `json:"session_id"`
+	InvocationID string    `json:"invocation_id"`
+	Agent        string    `json:"agent,omitempty"`
+	// Event is the event the payload is sent for, if any.
+	Event *session.Event `json:"event,omitempty"`
+	// Error is the error of KindInvocationError payloads.
+	Error string `json:"error,omitempty"`
+}
+
+// Webhook is an endpoint receiving the payloads.
+type Webhook struct {
+	URL string
+	// Secret signs the payloads, see SignatureHeader. The payloads are not
+	// signed if empty.
+	Secret string // optional
+	// Kinds are the kinds of the payloads sent to the webhook. Defaults to
+	// all kinds.
+	Kinds []Kind // optional
+	// Header is added to the requests, e.g. for authorization.
+	Header http.Header // optional
+}
+
+// Config configures the webhook plugin.
+type Config struct {
+	// Name of the plugin. Defaults to "webhook_plugin".
+	Name     string
+	Webhooks []Webhook
+	// HTTPClient sends the requests. Defaults to a client with a timeout
+	// of 10 seconds.
+	HTTPClient *http.Client // optional
+	// MaxAttempts is the number of attempts to deliver a payload, retried
+	// when the request fails or the webhook responds with a 5xx or 429
+	// status. Defaults to 3.
+	MaxAttempts int // optional
+	// Backoff is the delay before the first retry, doubled at every
+	// attempt. Defaults to 1 second.
+	Backoff time.Duration // optional
+}
+
+// New creates an instance of the webhook plugin. The payloads are delivered
+// in the background, without delaying the invocations; the failed
+// deliveries are logged. Closing the runner waits for the pending
+// deliveries.
+func New(cfg Config) (*plugin.Plugin, error) {
+	if len(cfg.Webhooks) == 0 {
+		return nil, errors.New("at least one webhook is required")
+	}
+	for _, w := range cfg.Webhooks {
+		if w.URL == "" {
+			return nil, errors.New("webhook URL is required")
+		}
+	}
+	if cfg.Name == "" {
+		cfg.Name = "webhook_plugin"
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.Backoff == 0 {
+		cfg.Backoff = time.Second
+	}
+	p := &webhookPlugin{cfg: cfg}
+	return plugin.New(plugin.Config{
+		Name:                 cfg.Name,
+		BeforeRunCallback:    p.beforeRun,
+		AfterRunCallback:     p.afterRun,
+		OnEventCallback:      p.onEvent,
+		OnModelErrorCallback: p.onModelError,
+		OnToolErrorCallback:  p.onToolError,
+		CloseFunc:            p.close,
+	})
+}
+
+type webhookPlugin struct {
+	cfg Config
+
+	mu      sync.Mutex
+	closed  bool
+	pending sync.WaitGroup
+}
+
+func (p *webhookPlugin) beforeRun(ctx agent.InvocationContext) (*genai.Content, error) {
+	p.send(ctx, newPayload(ctx, KindInvocationStarted))
+	return nil, nil
+}
+
+func (p *webhookPlugin) afterRun(ctx agent.InvocationContext) {
+	p.send(ctx, newPayload(ctx, KindInvocationFinished))
+}
+
+func (p *webhookPlugin) onEvent(ctx agent.InvocationContext, event *session.Event) (*session.Event, error) {
+	var kinds []Kind
+	if event.ErrorCode != "" {
+		kinds = append(kinds, KindInvocationError)
+	}
+	if len(event.Actions.RequestedToolConfirmations) > 0 {
+		kinds = append(kinds, KindToolConfirmationRequested)
+	}
+	if event.Actions.Escalate {
+		kinds = append(kinds, KindEscalation)
+	}
+	for _, kind := range kinds {
+		payload := newPayload(ctx, kind)
+		payload.Agent = event.Author
+		payload.Event = event
+		if kind == KindInvocationError {
+			payload.Error = fmt.Sprintf("%s: %s", event.ErrorCode, event.ErrorMessage)
+		}
+		p.send(ctx, payload)
+	}
+	return nil, nil
+}
+
+func (p *webhookPlugin) onModelError(ctx agent.CallbackContext, req *model.LLMRequest, err error) (*model.LLMResponse, error) {
+	p.sendError(ctx, ctx.AgentName(), fmt.Errorf("model error: %w", err))
+	return nil, nil
+}
+
+func (p *webhookPlugin) onToolError(ctx tool.Context, t tool.Tool, args map[string]any, err error) (map[string]any, error) {
+	p.sendError(ctx, ctx.AgentName(), fmt.Errorf("tool %q error: %w", t.Name(), err))
+	return nil, nil
+}
+
+func (p *webhookPlugin) sendError(ctx agent.CallbackContext, agentName string, err error) {
+	payload := &Payload{
+		ID:           session.NewID(ctx),
+		Kind:         KindInvocationError,
+		Time:         session.Now(ctx),
+		AppName:      ctx.AppName(),
+		UserID:       ctx.UserID(),
+		SessionID:    ctx.SessionID(),
+		InvocationID: ctx.InvocationID(),
+		Agent:        agentName,
+		Error:        err.Error(),
+	}
+	p.send(ctx, payload)
+}
+
+func newPayload(ctx agent.InvocationContext, kind Kind) *Payload {
+	s := ctx.Session()
+	return &Payload{
+		ID:           session.NewID(ctx),
+		Kind:         kind,
+		Time:         session.Now(ctx),
+		AppName:      s.AppName(),
+		UserID:       s.UserID(),
+		SessionID:    s.ID(),
+		InvocationID: ctx.InvocationID(),
+		Agent:        ctx.Agent().Name(),
+	}
+}
+
+// send delivers the payload to the webhooks accepting its kind in the
+// background.
+func (p *webhookPlugin) send(ctx context.Context, payload *Payload) {
+	logger := logging.FromContext(ctx)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to encode the webhook payload", "kind", payload.Kind, "error", err)
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	for _, w := range p.cfg.Webhooks {
+		if len(w.Kinds) > 0 && !slices.Contains(w.Kinds, payload.Kind) {
+			continue
+		}
+		p.pending.Add(1)
+		go func() {
+			defer p.pending.Done()
+			if err := p.deliver(w, body); err != nil {
+				logger.ErrorContext(ctx, "Failed to deliver the webhook payload", "kind", payload.Kind, "url", w.URL, "error", err)
+			}
+		}()
+	}
+}
+
+// deliver posts the body to the webhook, retrying the transient failures.
+func (p *webhookPlugin) deliver(w Webhook, body []byte) error {
+	backoff := p.cfg.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		retry, err = p.post(w, body)
+		if err == nil || !retry || attempt == p.cfg.MaxAttempts {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post posts the body to the webhook once. It reports whether a failure is
+// worth retrying.
+func (p *webhookPlugin) post(w Webhook, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for k, v := range w.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(w.Secret, timestamp, body))
+	}
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook responded with status %s", resp.Status)
+}
+
+// close waits for the pending deliveries, including their retries.
+func (p *webhookPlugin) close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	p.pending.Wait()
+	return nil
+}
+
+// Sign returns the signature of the body sent with the timestamp, see
+// SignatureHeader.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the request headers carry a valid signature of the
+// body, for the receivers of the webhooks. It does not check how old the
+// timestamp is.
+func Verify(secret string, header http.Header, body []byte) bool {
+	signature := header.Get(SignatureHeader)
+	want := Sign(secret, header.Get(TimestampHeader), body)
+	return hmac.Equal([]byte(signature), []byte(want))
+}
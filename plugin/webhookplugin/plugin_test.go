@@ -0,0 +1,196 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookplugin_test
+
+import (
+	"encoding/json"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/plugin/webhookplugin"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool/toolconfirmation"
+)
+
+// receiver records the payloads posted to it. It fails the first
+// failures requests with a 503 status.
+type receiver struct {
+	t        *testing.T
+	secret   string
+	failures int
+
+	mu       sync.Mutex
+	requests int
+	payloads []*webhookplugin.Payload
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rc.t.Errorf("failed to read the request: %v", err)
+	}
+	if rc.secret != "" && !webhookplugin.Verify(rc.secret, r.Header, body) {
+		rc.t.Errorf("request signature %q is invalid", r.Header.Get(webhookplugin.SignatureHeader))
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests++
+	if rc.requests <= rc.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var payload webhookplugin.Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		rc.t.Errorf("failed to decode the payload: %v", err)
+	}
+	rc.payloads = append(rc.payloads, &payload)
+}
+
+func (rc *receiver) kinds() map[webhookplugin.Kind]int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	kinds := make(map[webhookplugin.Kind]int)
+	for _, p := range rc.payloads {
+		kinds[p.Kind]++
+	}
+	return kinds
+}
+
+// runAgent runs an agent yielding an event requesting a tool confirmation,
+// an event with an error and an escalation.
+func runAgent(t *testing.T, p *plugin.Plugin) {
+	t.Helper()
+	a, err := agent.New(agent.Config{
+		Name: "worker",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				confirm := session.NewEvent(ctx.InvocationID())
+				confirm.Actions.RequestedToolConfirmations = map[string]toolconfirmation.ToolConfirmation{"call-1": {Hint: "delete?"}}
+				failed := session.NewEvent(ctx.InvocationID())
+				failed.ErrorCode, failed.ErrorMessage = "SAFETY", "blocked"
+				escalate := session.NewEvent(ctx.InvocationID())
+				escalate.Actions.Escalate = true
+				for _, ev := range []*session.Event{confirm, failed, escalate} {
+					ev.Content = genai.NewContentFromText("working", genai.RoleModel)
+					if !yield(ev, nil) {
+						return
+					}
+				}
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := testutil.NewTestAgentRunnerWithPluginManager(t, a, runner.PluginConfig{Plugins: []*plugin.Plugin{p}})
+	if _, err := testutil.CollectEvents(r.Run(t, "session", "hello")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestWebhookPlugin(t *testing.T) {
+	all := &receiver{t: t, secret: "s3cr3t", failures: 1}
+	alerts := &receiver{t: t}
+	allServer, alertsServer := httptest.NewServer(all), httptest.NewServer(alerts)
+	defer allServer.Close()
+	defer alertsServer.Close()
+
+	p, err := webhookplugin.New(webhookplugin.Config{
+		Webhooks: []webhookplugin.Webhook{
+			{URL: allServer.URL, Secret: all.secret},
+			{URL: alertsServer.URL, Kinds: []webhookplugin.Kind{webhookplugin.KindInvocationError, webhookplugin.KindEscalation}},
+		},
+		Backoff: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	runAgent(t, p)
+
+	want := map[webhookplugin.Kind]int{
+		webhookplugin.KindInvocationStarted:         1,
+		webhookplugin.KindToolConfirmationRequested: 1,
+		webhookplugin.KindInvocationError:           1,
+		webhookplugin.KindEscalation:                1,
+		webhookplugin.KindInvocationFinished:        1,
+	}
+	if diff := cmp.Diff(want, all.kinds()); diff != "" {
+		t.Errorf("payloads of the webhook mismatch (-want +got):\n%s", diff)
+	}
+	want = map[webhookplugin.Kind]int{
+		webhookplugin.KindInvocationError: 1,
+		webhookplugin.KindEscalation:      1,
+	}
+	if diff := cmp.Diff(want, alerts.kinds()); diff != "" {
+		t.Errorf("payloads of the alerts webhook mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, payload := range alerts.payloads {
+		if payload.Agent != "worker" || payload.SessionID != "session" || payload.Event == nil || payload.ID == "" {
+			t.Errorf("payload = %+v, want the agent, session and event", payload)
+		}
+		if payload.Kind == webhookplugin.KindInvocationError && payload.Error != "SAFETY: blocked" {
+			t.Errorf("error payload Error = %q, want %q", payload.Error, "SAFETY: blocked")
+		}
+	}
+}
+
+func TestWebhookPlugin_GivesUp(t *testing.T) {
+	failing := &receiver{t: t, failures: 100}
+	srv := httptest.NewServer(failing)
+	defer srv.Close()
+
+	p, err := webhookplugin.New(webhookplugin.Config{
+		Webhooks:    []webhookplugin.Webhook{{URL: srv.URL, Kinds: []webhookplugin.Kind{webhookplugin.KindInvocationStarted}}},
+		MaxAttempts: 2,
+		Backoff:     time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	runAgent(t, p)
+
+	failing.mu.Lock()
+	defer failing.mu.Unlock()
+	if failing.requests != 2 {
+		t.Errorf("webhook received %d requests, want 2 attempts", failing.requests)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	for _, cfg := range []webhookplugin.Config{
+		{},
+		{Webhooks: []webhookplugin.Webhook{{}}},
+	} {
+		if _, err := webhookplugin.New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded, want error", cfg)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quota limits the usage of the apps: the invocations of each user
+// per day and the model tokens of each app per month.
+//
+// An [Enforcer] is installed on a runner with runner.Config.Quota:
+//
+//	enforcer := quota.New(quota.Config{
+//		Limits: quota.Limits{InvocationsPerUserPerDay: 100, TokensPerAppPerMonth: 10_000_000},
+//	})
+//	r, err := runner.New(runner.Config{..., Quota: enforcer})
+//
+// The invocations over the quota are not run: the runner yields a single
+// event carrying the [ExceededError], see [ExceededFromEvent].
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/adk/session"
+)
+
+// Metric is a usage metric limited by a quota.
+type Metric string
+
+const (
+	// Invocations is the number of invocations, counted per user per day.
+	Invocations Metric = "invocations"
+	// Tokens is the total number of tokens reported by the models, counted
+	// per app per month.
+	Tokens Metric = "tokens"
+)
+
+// Limits are the quotas of an app. Zero means no limit.
+type Limits struct {
+	// InvocationsPerUserPerDay limits the invocations of each user.
+	InvocationsPerUserPerDay int64
+	// TokensPerAppPerMonth limits the tokens of all the invocations of the
+	// app. The invocations are rejected once the limit is reached, the
+	// invocation crossing it still completes.
+	TokensPerAppPerMonth int64
+}
+
+// Config configures an [Enforcer].
+type Config struct {
+	// Limits apply to every app, unless overridden by AppLimits.
+	Limits Limits
+	// AppLimits are the limits of given apps.
+	AppLimits map[string]Limits
+	// Store stores the usage. Defaults to a store within the process.
+	Store Store
+	// Location is where the days and months start. Defaults to UTC.
+	Location *time.Location
+}
+
+// Enforcer checks and records the usage of the apps against their limits. It
+// is safe for concurrent use.
+type Enforcer struct {
+	limits    Limits
+	appLimits map[string]Limits
+	store     Store
+	location  *time.Location
+	now       func() time.Time
+}
+
+// New creates an Enforcer.
+func New(cfg Config) *Enforcer {
+	e := &Enforcer{
+		limits:    cfg.Limits,
+		appLimits: cfg.AppLimits,
+		store:     cfg.Store,
+		location:  cfg.Location,
+		now:       time.Now,
+	}
+	if e.store == nil {
+		e.store = NewInMemoryStore()
+	}
+	if e.location == nil {
+		e.location = time.UTC
+	}
+	return e
+}
+
+// ExceededKey is the CustomMetadata key of the [ExceededError] of the event
+// of a rejected invocation.
+const ExceededKey = "adk_quota_exceeded"
+
+// ErrorCode is the error code of the event of a rejected invocation.
+const ErrorCode = "RESOURCE_EXHAUSTED"
+
+// ExceededError is returned when an invocation exceeds a quota.
+type ExceededError struct {
+	AppName string `json:"app_name"`
+	// UserID is empty for the quotas of the app.
+	UserID string `json:"user_id,omitempty"`
+	Metric Metric `json:"metric"`
+	Limit  int64  `json:"limit"`
+	Used   int64  `json:"used"`
+	// ResetTime is when the usage is reset.
+	ResetTime time.Time `json:"reset_time"`
+}
+
+func (e *ExceededError) Error() string {
+	if e.UserID != "" {
+		return fmt.Sprintf("quota of %d %s of user %q in app %q exceeded, resets at %s", e.Limit, e.Metric, e.UserID, e.AppName, e.ResetTime.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("quota of %d %s of app %q exceeded, resets at %s", e.Limit, e.Metric, e.AppName, e.ResetTime.Format(time.RFC3339))
+}
+
+// ExceededFromEvent returns the exceeded quota carried by the event, also
+// after the event was round-tripped through a session service.
+func ExceededFromEvent(event *session.Event) (*ExceededError, bool) {
+	val, ok := event.CustomMetadata[ExceededKey]
+	if !ok {
+		return nil, false
+	}
+	if exceeded, ok := val.(*ExceededError); ok {
+		return exceeded, true
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, false
+	}
+	var exceeded ExceededError
+	if err := json.Unmarshal(data, &exceeded); err != nil {
+		return nil, false
+	}
+	return &exceeded, true
+}
+
+// Status is the usage of a quota.
+type Status struct {
+	Metric Metric `json:"metric"`
+	// UserID is empty for the quotas of the app.
+	UserID    string    `json:"user_id,omitempty"`
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	ResetTime time.Time `json:"reset_time"`
+}
+
+func (e *Enforcer) limitsOf(appName string) Limits {
+	if l, ok := e.appLimits[appName]; ok {
+		return l
+	}
+	return e.limits
+}
+
+// period returns the key of the current day or month of the counter, and
+// when it ends.
+func (e *Enforcer) period(metric Metric) (string, time.Time) {
+	now := e.now().In(e.location)
+	y, m, d := now.Date()
+	if metric == Invocations {
+		return now.Format(time.DateOnly), time.Date(y, m, d+1, 0, 0, 0, 0, e.location)
+	}
+	return now.Format("2006-01"), time.Date(y, m+1, 1, 0, 0, 0, 0, e.location)
+}
+
+func (e *Enforcer) key(appName, userID string, metric Metric) (Key, time.Time) {
+	period, reset := e.period(metric)
+	return Key{AppName: appName, UserID: userID, Metric: metric, Period: period}, reset
+}
+
+// Begin counts a new invocation of the user. It returns an *ExceededError,
+// without counting the invocation, if the user or the app is over quota.
+func (e *Enforcer) Begin(ctx context.Context, appName, userID string) error {
+	limits := e.limitsOf(appName)
+	if limits.TokensPerAppPerMonth > 0 {
+		key, reset := e.key(appName, "", Tokens)
+		used, err := e.store.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get the token usage: %w", err)
+		}
+		if used >= limits.TokensPerAppPerMonth {
+			return &ExceededError{AppName: appName, Metric: Tokens, Limit: limits.TokensPerAppPerMonth, Used: used, ResetTime: reset}
+		}
+	}
+	key, reset := e.key(appName, userID, Invocations)
+	used, ok, err := e.store.Increment(ctx, key, 1, limits.InvocationsPerUserPerDay)
+	if err != nil {
+		return fmt.Errorf("failed to count the invocation: %w", err)
+	}
+	if !ok {
+		return &ExceededError{AppName: appName, UserID: userID, Metric: Invocations, Limit: limits.InvocationsPerUserPerDay, Used: used, ResetTime: reset}
+	}
+	return nil
+}
+
+// RecordTokens adds the tokens used by an invocation to the usage of the app.
+func (e *Enforcer) RecordTokens(ctx context.Context, appName string, tokens int64) error {
+	if tokens <= 0 {
+		return nil
+	}
+	key, _ := e.key(appName, "", Tokens)
+	if _, _, err := e.store.Increment(ctx, key, tokens, 0); err != nil {
+		return fmt.Errorf("failed to record the token usage: %w", err)
+	}
+	return nil
+}
+
+// Usage returns the status of the quotas of the user in the app. The quotas
+// without limit are omitted.
+func (e *Enforcer) Usage(ctx context.Context, appName, userID string) ([]Status, error) {
+	limits := e.limitsOf(appName)
+	var statuses []Status
+	for _, q := range []struct {
+		userID string
+		metric Metric
+		limit  int64
+	}{
+		{userID, Invocations, limits.InvocationsPerUserPerDay},
+		{"", Tokens, limits.TokensPerAppPerMonth},
+	} {
+		if q.limit <= 0 {
+			continue
+		}
+		key, reset := e.key(appName, q.userID, q.metric)
+		used, err := e.store.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the %s usage: %w", q.metric, err)
+		}
+		statuses = append(statuses, Status{
+			Metric:    q.metric,
+			UserID:    q.userID,
+			Limit:     q.limit,
+			Used:      used,
+			Remaining: max(q.limit-used, 0),
+			ResetTime: reset,
+		})
+	}
+	return statuses, nil
+}
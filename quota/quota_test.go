@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestEnforcer(cfg Config, now *time.Time) *Enforcer {
+	e := New(cfg)
+	e.now = func() time.Time { return *now }
+	return e
+}
+
+func TestEnforcer_Invocations(t *testing.T) {
+	ctx := t.Context()
+	now := time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC)
+	e := newTestEnforcer(Config{Limits: Limits{InvocationsPerUserPerDay: 2}}, &now)
+
+	for range 2 {
+		if err := e.Begin(ctx, "app", "alice"); err != nil {
+			t.Fatalf("Begin() error = %v", err)
+		}
+	}
+	err := e.Begin(ctx, "app", "alice")
+	var exceeded *ExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("Begin() error = %v, want *ExceededError", err)
+	}
+	want := &ExceededError{
+		AppName:   "app",
+		UserID:    "alice",
+		Metric:    Invocations,
+		Limit:     2,
+		Used:      2,
+		ResetTime: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if diff := cmp.Diff(want, exceeded); diff != "" {
+		t.Errorf("Begin() error mismatch (-want +got):\n%s", diff)
+	}
+
+	// Other users and apps have their own quotas.
+	if err := e.Begin(ctx, "app", "bob"); err != nil {
+		t.Errorf("Begin() of another user error = %v", err)
+	}
+	if err := e.Begin(ctx, "other", "alice"); err != nil {
+		t.Errorf("Begin() in another app error = %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	if err := e.Begin(ctx, "app", "alice"); err != nil {
+		t.Errorf("Begin() on the next day error = %v", err)
+	}
+}
+
+func TestEnforcer_Tokens(t *testing.T) {
+	ctx := t.Context()
+	now := time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC)
+	e := newTestEnforcer(Config{
+		Limits:    Limits{TokensPerAppPerMonth: 100},
+		AppLimits: map[string]Limits{"unlimited": {}},
+	}, &now)
+
+	if err := e.Begin(ctx, "app", "alice"); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if err := e.RecordTokens(ctx, "app", 150); err != nil {
+		t.Fatalf("RecordTokens() error = %v", err)
+	}
+	var exceeded *ExceededError
+	if err := e.Begin(ctx, "app", "bob"); !errors.As(err, &exceeded) || exceeded.Metric != Tokens {
+		t.Errorf("Begin() error = %v, want a token quota error", err)
+	}
+	if want := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC); !exceeded.ResetTime.Equal(want) {
+		t.Errorf("ResetTime = %v, want %v", exceeded.ResetTime, want)
+	}
+
+	if err := e.RecordTokens(ctx, "unlimited", 150); err != nil {
+		t.Fatalf("RecordTokens() error = %v", err)
+	}
+	if err := e.Begin(ctx, "unlimited", "alice"); err != nil {
+		t.Errorf("Begin() of an app without limits error = %v", err)
+	}
+}
+
+func TestEnforcer_Usage(t *testing.T) {
+	ctx := t.Context()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	e := newTestEnforcer(Config{Limits: Limits{InvocationsPerUserPerDay: 5, TokensPerAppPerMonth: 1000}}, &now)
+	if err := e.Begin(ctx, "app", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.RecordTokens(ctx, "app", 1200); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := e.Usage(ctx, "app", "alice")
+	if err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+	want := []Status{
+		{Metric: Invocations, UserID: "alice", Limit: 5, Used: 1, Remaining: 4, ResetTime: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{Metric: Tokens, Limit: 1000, Used: 1200, Remaining: 0, ResetTime: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Usage() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"sync"
+)
+
+// Key identifies a usage counter.
+type Key struct {
+	AppName string
+	// UserID is empty for the counters of the app.
+	UserID string
+	Metric Metric
+	// Period is the day, e.g. "2026-01-31", or the month, e.g. "2026-01",
+	// of the counter.
+	Period string
+}
+
+// Store stores the usage counters.
+//
+// The default implementation stores them within the process. Runners in
+// multiple processes sharing the same quotas need an implementation backed
+// by a shared database.
+type Store interface {
+	// Get returns the value of the counter, zero if it was never incremented.
+	Get(ctx context.Context, key Key) (int64, error)
+	// Increment atomically adds n to the counter, unless the result would
+	// exceed limit. A zero limit means no limit. It returns the value of the
+	// counter after the increment, or its unchanged value and false if the
+	// limit would be exceeded.
+	Increment(ctx context.Context, key Key, n, limit int64) (value int64, ok bool, err error)
+}
+
+// NewInMemoryStore returns a [Store] that keeps the counters within the
+// process. The counters of the past periods are not removed.
+func NewInMemoryStore() Store {
+	return &inMemoryStore{counters: make(map[Key]int64)}
+}
+
+type inMemoryStore struct {
+	mu       sync.Mutex
+	counters map[Key]int64
+}
+
+func (s *inMemoryStore) Get(ctx context.Context, key Key) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counters[key], nil
+}
+
+func (s *inMemoryStore) Increment(ctx context.Context, key Key, n, limit int64) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value := s.counters[key]
+	if limit > 0 && value+n > limit {
+		return value, false, nil
+	}
+	s.counters[key] = value + n
+	return value + n, true, nil
+}
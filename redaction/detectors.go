@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction
+
+import (
+	"context"
+	"regexp"
+)
+
+// Info types of the built-in detectors, named after the info types of
+// Cloud DLP.
+const (
+	InfoTypeEmailAddress     = "EMAIL_ADDRESS"
+	InfoTypePhoneNumber      = "PHONE_NUMBER"
+	InfoTypeCreditCardNumber = "CREDIT_CARD_NUMBER"
+	InfoTypeIPAddress        = "IP_ADDRESS"
+)
+
+// Finding is a piece of sensitive data found in a text.
+type Finding struct {
+	// InfoType is the kind of data, e.g. InfoTypeEmailAddress.
+	InfoType string
+	// Start and End are the byte offsets of the data in the text.
+	Start, End int
+}
+
+// Detector finds sensitive data in texts, e.g. with regular expressions or
+// with an inspection service such as Cloud DLP.
+type Detector interface {
+	Detect(ctx context.Context, text string) ([]Finding, error)
+}
+
+// DetectorFunc is a [Detector] implemented by a function.
+type DetectorFunc func(ctx context.Context, text string) ([]Finding, error)
+
+// Detect implements Detector.
+func (f DetectorFunc) Detect(ctx context.Context, text string) ([]Finding, error) {
+	return f(ctx, text)
+}
+
+// Regexp returns a [Detector] of the matches of re, reported as infoType.
+func Regexp(infoType string, re *regexp.Regexp) Detector {
+	return regexpDetector(infoType, re, nil)
+}
+
+func regexpDetector(infoType string, re *regexp.Regexp, valid func(match string) bool) Detector {
+	return DetectorFunc(func(ctx context.Context, text string) ([]Finding, error) {
+		var findings []Finding
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			if valid != nil && !valid(text[loc[0]:loc[1]]) {
+				continue
+			}
+			findings = append(findings, Finding{InfoType: infoType, Start: loc[0], End: loc[1]})
+		}
+		return findings, nil
+	})
+}
+
+var (
+	emailRegexp      = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	phoneRegexp      = regexp.MustCompile(`(?:\+\d{1,3}[ .\-]?)?(?:\(\d{3}\)|\b\d{3})[ .\-]?\d{3}[ .\-]?\d{4}\b`)
+	creditCardRegexp = regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`)
+	ipv4Regexp       = regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`)
+)
+
+// EmailAddress returns a [Detector] of email addresses.
+func EmailAddress() Detector {
+	return Regexp(InfoTypeEmailAddress, emailRegexp)
+}
+
+// PhoneNumber returns a [Detector] of phone numbers in the North American
+// format, optionally with a country code, e.g. +1 (555) 123-4567.
+func PhoneNumber() Detector {
+	return Regexp(InfoTypePhoneNumber, phoneRegexp)
+}
+
+// CreditCardNumber returns a [Detector] of payment card numbers, checked
+// with the Luhn algorithm.
+func CreditCardNumber() Detector {
+	return regexpDetector(InfoTypeCreditCardNumber, creditCardRegexp, luhn)
+}
+
+// IPAddress returns a [Detector] of IPv4 addresses.
+func IPAddress() Detector {
+	return Regexp(InfoTypeIPAddress, ipv4Regexp)
+}
+
+// DefaultDetectors returns the built-in detectors.
+func DefaultDetectors() []Detector {
+	return []Detector{EmailAddress(), CreditCardNumber(), PhoneNumber(), IPAddress()}
+}
+
+// luhn reports whether the digits of s have a valid Luhn checksum.
+func luhn(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n > 0 && sum%10 == 0
+}
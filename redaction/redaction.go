@@ -0,0 +1,345 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redaction removes personal data, e.g. email addresses or phone
+// numbers, from the events of the sessions before they are persisted,
+// exported to telemetry or added to memory.
+//
+// A [Redactor] finds the personal data of texts with [Detector]s, and
+// replaces it according to a [Policy]. The values of given fields, e.g. the
+// "email" argument of a tool or a state key, are redacted as a whole.
+//
+// It is installed on a runner with runner.Config.Redactor:
+//
+//	redactor, err := redaction.New(redaction.Config{
+//		FieldPolicies: map[string]redaction.Policy{"user:address": redaction.Drop},
+//	})
+//	...
+//	r, err := runner.New(runner.Config{..., Redactor: redactor})
+//
+// The events yielded by the runner to its caller are not redacted.
+package redaction
+
+import (
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/session"
+)
+
+// Policy is how sensitive data is redacted.
+type Policy string
+
+const (
+	// Mask replaces the data with its info type, e.g. "[EMAIL_ADDRESS]", or
+	// the values of fields with "[REDACTED]".
+	Mask Policy = "mask"
+	// Hash replaces the data with its info type and a keyed hash of the
+	// data, e.g. "[EMAIL_ADDRESS:5f1c2a9e0b7d4c31]", so that the occurrences
+	// of the same data can be correlated.
+	Hash Policy = "hash"
+	// Drop removes the data, or the fields.
+	Drop Policy = "drop"
+)
+
+// Config configures a [Redactor].
+type Config struct {
+	// Detectors find the sensitive data of texts. Defaults to
+	// DefaultDetectors(). Set an empty slice to only redact fields.
+	Detectors []Detector
+	// Policy is applied to the findings of the detectors. Defaults to Mask.
+	Policy Policy
+	// InfoTypePolicies override Policy for the findings of given info types.
+	InfoTypePolicies map[string]Policy
+	// FieldPolicies redact the whole values of the fields of the given names,
+	// at any depth, of the function call arguments, function responses,
+	// state deltas and custom metadata of the events.
+	FieldPolicies map[string]Policy
+	// HashKey is the key of the hashes of the Hash policy. Defaults to a
+	// random key, so the hashes only correlate data within the process.
+	HashKey []byte
+}
+
+// Redactor redacts texts and events. It is safe for concurrent use.
+type Redactor struct {
+	detectors        []Detector
+	policy           Policy
+	infoTypePolicies map[string]Policy
+	fieldPolicies    map[string]Policy
+	hashKey          []byte
+}
+
+// New creates a Redactor.
+func New(cfg Config) (*Redactor, error) {
+	r := &Redactor{
+		detectors:        cfg.Detectors,
+		policy:           cmp.Or(cfg.Policy, Mask),
+		infoTypePolicies: maps.Clone(cfg.InfoTypePolicies),
+		fieldPolicies:    maps.Clone(cfg.FieldPolicies),
+		hashKey:          slices.Clone(cfg.HashKey),
+	}
+	if r.detectors == nil {
+		r.detectors = DefaultDetectors()
+	}
+	if err := validate(r.policy); err != nil {
+		return nil, err
+	}
+	for _, policies := range []map[string]Policy{r.infoTypePolicies, r.fieldPolicies} {
+		for name, p := range policies {
+			if err := validate(p); err != nil {
+				return nil, fmt.Errorf("policy of %s: %w", name, err)
+			}
+		}
+	}
+	if len(r.hashKey) == 0 {
+		r.hashKey = []byte(rand.Text())
+	}
+	return r, nil
+}
+
+func validate(p Policy) error {
+	switch p {
+	case Mask, Hash, Drop:
+		return nil
+	}
+	return fmt.Errorf("unknown redaction policy %q, want %s, %s or %s", p, Mask, Hash, Drop)
+}
+
+// Text returns text with the findings of the detectors redacted.
+func (r *Redactor) Text(ctx context.Context, text string) (string, error) {
+	if text == "" {
+		return text, nil
+	}
+	var findings []Finding
+	for _, d := range r.detectors {
+		f, err := d.Detect(ctx, text)
+		if err != nil {
+			return "", fmt.Errorf("failed to detect sensitive data: %w", err)
+		}
+		findings = append(findings, f...)
+	}
+	if len(findings) == 0 {
+		return text, nil
+	}
+	// The longest of the overlapping findings wins.
+	slices.SortFunc(findings, func(a, b Finding) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(b.End, a.End))
+	})
+	var b strings.Builder
+	pos := 0
+	for _, f := range findings {
+		if f.Start < pos || f.Start >= f.End || f.End > len(text) {
+			continue
+		}
+		b.WriteString(text[pos:f.Start])
+		b.WriteString(r.replacement(f.InfoType, text[f.Start:f.End], r.policyOf(f.InfoType)))
+		pos = f.End
+	}
+	b.WriteString(text[pos:])
+	return b.String(), nil
+}
+
+func (r *Redactor) policyOf(infoType string) Policy {
+	if p, ok := r.infoTypePolicies[infoType]; ok {
+		return p
+	}
+	return r.policy
+}
+
+// replacement returns the replacement of data of the given label.
+func (r *Redactor) replacement(label, data string, p Policy) string {
+	switch p {
+	case Drop:
+		return ""
+	case Hash:
+		return fmt.Sprintf("[%s:%s]", label, r.hash(data))
+	default:
+		return "[" + label + "]"
+	}
+}
+
+func (r *Redactor) hash(data string) string {
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// Map returns a copy of m with the fields of the field policies and the
+// findings of the detectors in its strings redacted.
+func (r *Redactor) Map(ctx context.Context, m map[string]any) (map[string]any, error) {
+	if m == nil {
+		return nil, nil
+	}
+	redacted := make(map[string]any, len(m))
+	for k, v := range m {
+		if p, ok := r.fieldPolicies[k]; ok {
+			if p == Drop {
+				continue
+			}
+			data, err := json.Marshal(v)
+			if err != nil {
+				data = []byte(fmt.Sprint(v))
+			}
+			redacted[k] = r.replacement("REDACTED", string(data), p)
+			continue
+		}
+		v, err := r.value(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		redacted[k] = v
+	}
+	return redacted, nil
+}
+
+func (r *Redactor) value(ctx context.Context, v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return r.Text(ctx, v)
+	case map[string]any:
+		return r.Map(ctx, v)
+	case []any:
+		redacted := make([]any, len(v))
+		for i, e := range v {
+			e, err := r.value(ctx, e)
+			if err != nil {
+				return nil, err
+			}
+			redacted[i] = e
+		}
+		return redacted, nil
+	default:
+		return v, nil
+	}
+}
+
+// Content returns a copy of c with its texts, function call arguments and
+// function responses redacted.
+func (r *Redactor) Content(ctx context.Context, c *genai.Content) (*genai.Content, error) {
+	if c == nil {
+		return nil, nil
+	}
+	redacted := *c
+	redacted.Parts = make([]*genai.Part, 0, len(c.Parts))
+	for _, part := range c.Parts {
+		if part == nil {
+			redacted.Parts = append(redacted.Parts, part)
+			continue
+		}
+		p := *part
+		var err error
+		if p.Text != "" {
+			if p.Text, err = r.Text(ctx, p.Text); err != nil {
+				return nil, err
+			}
+		}
+		if part.FunctionCall != nil {
+			fc := *part.FunctionCall
+			if fc.Args, err = r.Map(ctx, fc.Args); err != nil {
+				return nil, err
+			}
+			p.FunctionCall = &fc
+		}
+		if part.FunctionResponse != nil {
+			fr := *part.FunctionResponse
+			if fr.Response, err = r.Map(ctx, fr.Response); err != nil {
+				return nil, err
+			}
+			p.FunctionResponse = &fr
+		}
+		redacted.Parts = append(redacted.Parts, &p)
+	}
+	return &redacted, nil
+}
+
+// Event returns a copy of ev with its content, transcriptions, state delta
+// and custom metadata redacted.
+func (r *Redactor) Event(ctx context.Context, ev *session.Event) (*session.Event, error) {
+	if ev == nil {
+		return nil, nil
+	}
+	redacted := *ev
+	var err error
+	if redacted.Content, err = r.Content(ctx, ev.Content); err != nil {
+		return nil, err
+	}
+	for _, t := range []**genai.Transcription{&redacted.InputTranscription, &redacted.OutputTranscription} {
+		if *t == nil {
+			continue
+		}
+		transcription := **t
+		if transcription.Text, err = r.Text(ctx, transcription.Text); err != nil {
+			return nil, err
+		}
+		*t = &transcription
+	}
+	if redacted.CustomMetadata, err = r.Map(ctx, ev.CustomMetadata); err != nil {
+		return nil, err
+	}
+	if redacted.Actions.StateDelta, err = r.Map(ctx, ev.Actions.StateDelta); err != nil {
+		return nil, err
+	}
+	return &redacted, nil
+}
+
+// failedRedaction replaces the data which could not be redacted, e.g. when
+// a detector failed, so that it is never exported as is.
+const failedRedaction = "[REDACTION FAILED]"
+
+// ToolData returns the redaction of the tool arguments and responses
+// recorded in the spans, see runner.Config.RedactToolData.
+func (r *Redactor) ToolData() func(toolName string, data map[string]any) map[string]any {
+	return func(toolName string, data map[string]any) map[string]any {
+		redacted, err := r.Map(context.Background(), data)
+		if err != nil {
+			return map[string]any{"error": failedRedaction}
+		}
+		return redacted
+	}
+}
+
+// Prompt returns the redaction of the prompts written to the debug logs, see
+// runner.Config.RedactPrompt.
+func (r *Redactor) Prompt() func(text string) string {
+	return func(text string) string {
+		redacted, err := r.Text(context.Background(), text)
+		if err != nil {
+			return failedRedaction
+		}
+		return redacted
+	}
+}
+
+// ContentFunc returns the redaction of the contents of the model requests
+// and responses exported to telemetry.
+func (r *Redactor) ContentFunc() func(*genai.Content) *genai.Content {
+	return func(c *genai.Content) *genai.Content {
+		redacted, err := r.Content(context.Background(), c)
+		if err != nil {
+			return &genai.Content{Role: c.Role, Parts: []*genai.Part{{Text: failedRedaction}}}
+		}
+		return redacted
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+func mustNew(t *testing.T, cfg Config) *Redactor {
+	t.Helper()
+	r, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return r
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		text string
+		want string
+	}{
+		{
+			name: "no findings",
+			text: "hello world",
+			want: "hello world",
+		},
+		{
+			name: "default detectors",
+			text: "mail jane.doe@example.co.uk or call +1 (555) 123-4567 from 10.0.0.1, card 4111 1111 1111 1111",
+			want: "mail [EMAIL_ADDRESS] or call [PHONE_NUMBER] from [IP_ADDRESS], card [CREDIT_CARD_NUMBER]",
+		},
+		{
+			name: "invalid card number",
+			text: "order 4111 1111 1111 1112",
+			want: "order 4111 1111 1111 1112",
+		},
+		{
+			name: "drop",
+			cfg:  Config{Policy: Drop},
+			text: "mail jane@example.com now",
+			want: "mail  now",
+		},
+		{
+			name: "info type policy",
+			cfg:  Config{Policy: Drop, InfoTypePolicies: map[string]Policy{InfoTypeIPAddress: Mask}},
+			text: "jane@example.com from 10.0.0.1",
+			want: " from [IP_ADDRESS]",
+		},
+		{
+			name: "custom detector",
+			cfg:  Config{Detectors: []Detector{Regexp("EMPLOYEE_ID", regexp.MustCompile(`E-\d{6}`))}},
+			text: "employee E-123456, jane@example.com",
+			want: "employee [EMPLOYEE_ID], jane@example.com",
+		},
+		{
+			name: "overlapping findings",
+			cfg: Config{Detectors: []Detector{
+				Regexp("SHORT", regexp.MustCompile(`abc`)),
+				Regexp("LONG", regexp.MustCompile(`abcdef`)),
+			}},
+			text: "xabcdefx abc",
+			want: "x[LONG]x [SHORT]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mustNew(t, tt.cfg).Text(t.Context(), tt.text)
+			if err != nil {
+				t.Fatalf("Text() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestText_Hash(t *testing.T) {
+	r := mustNew(t, Config{Policy: Hash, HashKey: []byte("key")})
+	a, err := r.Text(t.Context(), "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := r.Text(t.Context(), "from jane@example.com")
+	c, _ := r.Text(t.Context(), "john@example.com")
+	if !regexp.MustCompile(`^\[EMAIL_ADDRESS:[0-9a-f]{16}\]$`).MatchString(a) {
+		t.Errorf("Text() = %q, want a hashed email address", a)
+	}
+	if b != "from "+a {
+		t.Errorf("Text() = %q, want the same hash %q for the same address", b, a)
+	}
+	if c == a {
+		t.Errorf("Text() = %q for different addresses", c)
+	}
+}
+
+func TestText_DetectorError(t *testing.T) {
+	failing := DetectorFunc(func(ctx context.Context, text string) ([]Finding, error) {
+		return nil, errors.New("unavailable")
+	})
+	r := mustNew(t, Config{Detectors: []Detector{failing}})
+	if _, err := r.Text(t.Context(), "text"); err == nil {
+		t.Errorf("Text() succeeded, want error")
+	}
+	if got := r.Prompt()("text"); got != failedRedaction {
+		t.Errorf("Prompt() = %q, want %q", got, failedRedaction)
+	}
+}
+
+func TestNew_InvalidPolicy(t *testing.T) {
+	for _, cfg := range []Config{
+		{Policy: "encrypt"},
+		{InfoTypePolicies: map[string]Policy{InfoTypeEmailAddress: "encrypt"}},
+		{FieldPolicies: map[string]Policy{"email": ""}},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	r := mustNew(t, Config{FieldPolicies: map[string]Policy{"ssn": Drop, "address": Mask}})
+	got, err := r.Map(t.Context(), map[string]any{
+		"ssn":     "123-45-6789",
+		"count":   3,
+		"contact": map[string]any{"address": map[string]any{"city": "Paris"}, "note": "jane@example.com"},
+		"emails":  []any{"john@example.com", 42},
+	})
+	if err != nil {
+		t.Fatalf("Map() error = %v", err)
+	}
+	want := map[string]any{
+		"count":   3,
+		"contact": map[string]any{"address": "[REDACTED]", "note": "[EMAIL_ADDRESS]"},
+		"emails":  []any{"[EMAIL_ADDRESS]", 42},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Map() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	r := mustNew(t, Config{FieldPolicies: map[string]Policy{"user:email": Drop}})
+	event := &session.Event{
+		ID:     "event",
+		Author: "agent",
+		LLMResponse: model.LLMResponse{
+			Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{
+				{Text: "hello jane@example.com"},
+				{FunctionCall: &genai.FunctionCall{ID: "call", Name: "send", Args: map[string]any{"to": "jane@example.com"}}},
+				{FunctionResponse: &genai.FunctionResponse{ID: "call", Name: "send", Response: map[string]any{"sent_to": "jane@example.com"}}},
+			}},
+			InputTranscription: &genai.Transcription{Text: "call 555-123-4567", Finished: true},
+			CustomMetadata:     map[string]any{"ip": "10.0.0.1"},
+		},
+		Actions: session.EventActions{StateDelta: map[string]any{"user:email": "jane@example.com", "step": 2}},
+	}
+	original := *event.Content.Parts[0]
+
+	got, err := r.Event(t.Context(), event)
+	if err != nil {
+		t.Fatalf("Event() error = %v", err)
+	}
+	want := &session.Event{
+		ID:     "event",
+		Author: "agent",
+		LLMResponse: model.LLMResponse{
+			Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{
+				{Text: "hello [EMAIL_ADDRESS]"},
+				{FunctionCall: &genai.FunctionCall{ID: "call", Name: "send", Args: map[string]any{"to": "[EMAIL_ADDRESS]"}}},
+				{FunctionResponse: &genai.FunctionResponse{ID: "call", Name: "send", Response: map[string]any{"sent_to": "[EMAIL_ADDRESS]"}}},
+			}},
+			InputTranscription: &genai.Transcription{Text: "call [PHONE_NUMBER]", Finished: true},
+			CustomMetadata:     map[string]any{"ip": "[IP_ADDRESS]"},
+		},
+		Actions: session.EventActions{StateDelta: map[string]any{"step": 2}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Event() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&original, event.Content.Parts[0]); diff != "" {
+		t.Errorf("Event() modified the event (-want +got):\n%s", diff)
+	}
+	if event.Actions.StateDelta["user:email"] != "jane@example.com" {
+		t.Errorf("Event() modified the state delta of the event")
+	}
+}
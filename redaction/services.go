@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction
+
+import (
+	"context"
+	"iter"
+	"slices"
+
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/session"
+)
+
+// SessionService returns a session service appending the events redacted by
+// r to s.
+//
+// The redacted events are also appended to the session of the running
+// invocation, so the agents see the redacted history from the next step on.
+func SessionService(s session.Service, r *Redactor) session.Service {
+	return &sessionService{Service: s, redactor: r}
+}
+
+type sessionService struct {
+	session.Service
+	redactor *Redactor
+}
+
+func (s *sessionService) AppendEvent(ctx context.Context, sess session.Session, event *session.Event) error {
+	redacted, err := s.redactor.Event(ctx, event)
+	if err != nil {
+		return err
+	}
+	return s.Service.AppendEvent(ctx, sess, redacted)
+}
+
+func (s *sessionService) AppendEvents(ctx context.Context, sess session.Session, events []*session.Event) error {
+	redacted := make([]*session.Event, len(events))
+	for i, event := range events {
+		var err error
+		if redacted[i], err = s.redactor.Event(ctx, event); err != nil {
+			return err
+		}
+	}
+	return s.Service.AppendEvents(ctx, sess, redacted)
+}
+
+// MemoryService returns a memory service adding the sessions to s with their
+// events redacted by r.
+func MemoryService(s memory.Service, r *Redactor) memory.Service {
+	return &memoryService{Service: s, redactor: r}
+}
+
+type memoryService struct {
+	memory.Service
+	redactor *Redactor
+}
+
+func (s *memoryService) AddSessionToMemory(ctx context.Context, sess session.Session) error {
+	events := make(redactedEvents, 0, sess.Events().Len())
+	for event := range sess.Events().All() {
+		redacted, err := s.redactor.Event(ctx, event)
+		if err != nil {
+			return err
+		}
+		events = append(events, redacted)
+	}
+	return s.Service.AddSessionToMemory(ctx, &redactedSession{Session: sess, events: events})
+}
+
+type redactedSession struct {
+	session.Session
+	events redactedEvents
+}
+
+func (s *redactedSession) Events() session.Events {
+	return s.events
+}
+
+type redactedEvents []*session.Event
+
+func (e redactedEvents) All() iter.Seq[*session.Event] {
+	return slices.Values(e)
+}
+
+func (e redactedEvents) Len() int {
+	return len(e)
+}
+
+func (e redactedEvents) At(i int) *session.Event {
+	return e[i]
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+func newEvent(text string) *session.Event {
+	event := session.NewEvent("invocation")
+	event.Author = "user"
+	event.LLMResponse = model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleUser)}
+	return event
+}
+
+func TestSessionService(t *testing.T) {
+	ctx := t.Context()
+	service := SessionService(session.InMemoryService(), mustNew(t, Config{}))
+	created, err := service.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := newEvent("I am jane@example.com")
+	if err := service.AppendEvent(ctx, created.Session, event); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+	if got := event.Content.Parts[0].Text; got != "I am jane@example.com" {
+		t.Errorf("AppendEvent() modified the event text to %q", got)
+	}
+
+	resp, err := service.Get(ctx, &session.GetRequest{AppName: "app", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := resp.Session.Events().At(0)
+	if got, want := stored.Content.Parts[0].Text, "I am [EMAIL_ADDRESS]"; got != want {
+		t.Errorf("stored event text = %q, want %q", got, want)
+	}
+	if stored.ID != event.ID {
+		t.Errorf("stored event ID = %q, want %q", stored.ID, event.ID)
+	}
+}
+
+func TestMemoryService(t *testing.T) {
+	ctx := t.Context()
+	sessions := session.InMemoryService()
+	created, err := sessions.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sessions.AppendEvent(ctx, created.Session, newEvent("my phone is 555-123-4567")); err != nil {
+		t.Fatal(err)
+	}
+
+	inner := memory.InMemoryService()
+	service := MemoryService(inner, mustNew(t, Config{}))
+	if err := service.AddSessionToMemory(ctx, created.Session); err != nil {
+		t.Fatalf("AddSessionToMemory() error = %v", err)
+	}
+	resp, err := inner.SearchMemory(ctx, &memory.SearchMemoryRequest{AppName: "app", UserID: "user", Query: "phone"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Memories) != 1 {
+		t.Fatalf("SearchMemory() returned %d memories, want 1", len(resp.Memories))
+	}
+	if got := resp.Memories[0].Content.Parts[0].Text; strings.Contains(got, "555") || !strings.Contains(got, "[PHONE_NUMBER]") {
+		t.Errorf("memory text = %q, want the phone number redacted", got)
+	}
+}
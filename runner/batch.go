@@ -0,0 +1,230 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/session"
+)
+
+// EventBatchConfig configures the batching of the events appended to the
+// session service during an invocation. The events are buffered and
+// appended with session.Service.AppendEvents, which writes them in a single
+// transaction on the SQL backends, instead of one write per event.
+//
+// The agents still see the buffered events and their state changes in the
+// session of the invocation. Buffered events are lost if the process exits
+// before they are flushed.
+type EventBatchConfig struct {
+	// FlushInterval is how long an event may be buffered. The batch is
+	// flushed by a timer, so the events are persisted even while no other
+	// event arrives, e.g. during a long tool call. Defaults to 100ms.
+	FlushInterval time.Duration
+	// MaxEvents is the number of buffered events that triggers a flush.
+	// Defaults to 32.
+	MaxEvents int
+}
+
+const (
+	defaultBatchFlushInterval = 100 * time.Millisecond
+	defaultBatchMaxEvents     = 32
+)
+
+// eventBatcher buffers the events of an invocation and appends them to the
+// session service in batches. The batch is flushed when it is full, when
+// its oldest event is older than the flush interval, on a final response and
+// at the end of the invocation. The error of a flush by the timer is
+// returned by the next add or flush.
+type eventBatcher struct {
+	service  session.Service
+	stored   session.Session
+	interval time.Duration
+	max      int
+
+	mu      sync.Mutex
+	pending []*session.Event
+	timer   *time.Timer
+	err     error
+	// state holds the state deltas of the buffered events and the state
+	// written since the oldest of them, in order.
+	state map[string]any
+}
+
+func newEventBatcher(cfg *EventBatchConfig, service session.Service, stored session.Session) *eventBatcher {
+	b := &eventBatcher{
+		service:  service,
+		stored:   stored,
+		interval: cfg.FlushInterval,
+		max:      cfg.MaxEvents,
+	}
+	if b.interval <= 0 {
+		b.interval = defaultBatchFlushInterval
+	}
+	if b.max <= 0 {
+		b.max = defaultBatchMaxEvents
+	}
+	return b
+}
+
+// add buffers the event, flushing the batch if needed.
+func (b *eventBatcher) add(ctx context.Context, event *session.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.err; err != nil {
+		b.err = nil
+		return err
+	}
+	if len(b.pending) == 0 {
+		b.state = make(map[string]any)
+		var timer *time.Timer
+		timer = time.AfterFunc(b.interval, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			// The batch of the timer may have been flushed already.
+			if b.timer == timer {
+				b.err = b.flushLocked(ctx)
+			}
+		})
+		b.timer = timer
+	}
+	b.pending = append(b.pending, event)
+	maps.Copy(b.state, event.Actions.StateDelta)
+	if len(b.pending) >= b.max || event.IsFinalResponse() {
+		return b.flushLocked(ctx)
+	}
+	return nil
+}
+
+// flush appends the buffered events to the session service.
+func (b *eventBatcher) flush(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.flushLocked(ctx)
+}
+
+func (b *eventBatcher) flushLocked(ctx context.Context) error {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if err := b.err; err != nil {
+		b.err = nil
+		return err
+	}
+	if len(b.pending) == 0 {
+		return nil
+	}
+	pending, state := b.pending, b.state
+	b.pending, b.state = nil, nil
+	if err := b.service.AppendEvents(ctx, b.stored, pending); err != nil {
+		return err
+	}
+	// The state deltas of the events are applied in a batch, the state
+	// written in between is restored over them.
+	for key, value := range state {
+		if err := b.stored.State().Set(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// session returns the session of the invocation, overlaying the buffered
+// events on the stored session.
+func (b *eventBatcher) session() session.Session {
+	return &batchedSession{Session: b.stored, batcher: b}
+}
+
+type batchedSession struct {
+	session.Session
+	batcher *eventBatcher
+}
+
+func (s *batchedSession) Events() session.Events {
+	b := s.batcher
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stored := s.Session.Events()
+	events := make(batchedEvents, 0, stored.Len()+len(b.pending))
+	events = slices.AppendSeq(events, stored.All())
+	return append(events, b.pending...)
+}
+
+func (s *batchedSession) State() session.State {
+	return &batchedState{State: s.Session.State(), batcher: s.batcher}
+}
+
+type batchedEvents []*session.Event
+
+func (e batchedEvents) All() iter.Seq[*session.Event] {
+	return slices.Values(e)
+}
+
+func (e batchedEvents) Len() int {
+	return len(e)
+}
+
+func (e batchedEvents) At(i int) *session.Event {
+	return e[i]
+}
+
+// batchedState reads the state of the buffered events over the state of the
+// stored session.
+type batchedState struct {
+	session.State
+	batcher *eventBatcher
+}
+
+func (s *batchedState) Get(key string) (any, error) {
+	b := s.batcher
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if value, ok := b.state[key]; ok {
+		return value, nil
+	}
+	return s.State.Get(key)
+}
+
+func (s *batchedState) All() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		b := s.batcher
+		b.mu.Lock()
+		state := maps.Collect(s.State.All())
+		maps.Copy(state, b.state)
+		b.mu.Unlock()
+		for key, value := range state {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+func (s *batchedState) Set(key string, value any) error {
+	b := s.batcher
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.pending) > 0 {
+		// Restored when the buffered events are flushed.
+		b.state[key] = value
+	}
+	return s.State.Set(key, value)
+}
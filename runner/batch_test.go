@@ -0,0 +1,168 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+type countingSessionService struct {
+	session.Service
+	appendEvent, appendEvents int
+}
+
+func (s *countingSessionService) AppendEvent(ctx context.Context, sess session.Session, event *session.Event) error {
+	s.appendEvent++
+	return s.Service.AppendEvent(ctx, sess, event)
+}
+
+func (s *countingSessionService) AppendEvents(ctx context.Context, sess session.Session, events []*session.Event) error {
+	s.appendEvents++
+	return s.Service.AppendEvents(ctx, sess, events)
+}
+
+func TestRunner_EventBatch(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	countTool, err := functiontool.New(functiontool.Config{Name: "count", Description: "counts the calls"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		n, _ := ctx.State().Get("count")
+		count, _ := n.(int)
+		if err := ctx.State().Set("count", count+1); err != nil {
+			return nil, err
+		}
+		return map[string]any{"count": count + 1}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := func(id string) *genai.Content {
+		return &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: id, Name: "count"}}}}
+	}
+	llm := &fakeLLM{responses: []*genai.Content{
+		call("call-1"),
+		call("call-2"),
+		genai.NewContentFromText("counted twice", genai.RoleModel),
+	}}
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, Tools: []tool.Tool{countTool}}))
+	sessionService := &countingSessionService{Service: session.InMemoryService()}
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             root,
+		SessionService:    sessionService,
+		AutoCreateSession: true,
+		EventBatch:        &EventBatchConfig{FlushInterval: time.Hour},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events int
+	for _, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("count", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		events++
+	}
+	if events != 5 {
+		t.Errorf("Run() yielded %d events, want 5", events)
+	}
+
+	// The agent sees the buffered events and their state changes.
+	lastRequest := llm.requests[len(llm.requests)-1]
+	if got := len(lastRequest.Contents); got != 5 {
+		t.Errorf("last request has %d contents, want the message, the calls and their responses", got)
+	}
+	resp := lastRequest.Contents[len(lastRequest.Contents)-1].Parts[0].FunctionResponse
+	if resp == nil || resp.Response["count"] != float64(2) {
+		t.Errorf("last function response = %+v, want the count of the second call to be 2", resp)
+	}
+
+	// The user message is appended on its own, the events of the agent in a
+	// single batch flushed on the final response.
+	if sessionService.appendEvent != 1 || sessionService.appendEvents != 1 {
+		t.Errorf("AppendEvent() called %d times and AppendEvents() %d times, want 1 and 1", sessionService.appendEvent, sessionService.appendEvents)
+	}
+	got, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := got.Session.Events().Len(); n != 6 {
+		t.Errorf("session has %d events, want 6", n)
+	}
+	if count, _ := got.Session.State().Get("count"); count != 2 {
+		t.Errorf("session state count = %v, want 2", count)
+	}
+}
+
+func TestRunner_EventBatch_FlushInterval(t *testing.T) {
+	ctx := t.Context()
+	sessionService := &countingSessionService{Service: session.InMemoryService()}
+	type args struct{}
+	// The tool runs until the buffered function call is persisted, with no
+	// other event arriving meanwhile.
+	waitTool, err := functiontool.New(functiontool.Config{Name: "wait", Description: "waits for the call to be persisted"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+			resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+			if err != nil {
+				return nil, err
+			}
+			for event := range resp.Session.Events().All() {
+				if calls := event.Content.Parts; len(calls) > 0 && calls[0].FunctionCall != nil {
+					return map[string]any{"persisted": true}, nil
+				}
+			}
+		}
+		return map[string]any{"persisted": false}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &fakeLLM{responses: []*genai.Content{
+		{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call", Name: "wait"}}}},
+		genai.NewContentFromText("done", genai.RoleModel),
+	}}
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, Tools: []tool.Tool{waitTool}}))
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             root,
+		SessionService:    sessionService,
+		AutoCreateSession: true,
+		EventBatch:        &EventBatchConfig{FlushInterval: 10 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("wait", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	lastRequest := llm.requests[len(llm.requests)-1]
+	resp := lastRequest.Contents[len(lastRequest.Contents)-1].Parts[0].FunctionResponse
+	if resp == nil || resp.Response["persisted"] != true {
+		t.Errorf("function response = %+v, want the call persisted within the flush interval", resp)
+	}
+}
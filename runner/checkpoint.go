@@ -0,0 +1,247 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"slices"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/convert"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/session"
+)
+
+// CheckpointKey is the CustomMetadata key of the [Checkpoint] in the
+// checkpoint events of an invocation, see Config.Checkpoints.
+const CheckpointKey = "adk_checkpoint"
+
+// ErrNoInterruptedInvocation is returned by [Runner.ResumeInterrupted] if the
+// last invocation of the session with checkpoints has ended.
+var ErrNoInterruptedInvocation = errors.New("no interrupted invocation")
+
+// CheckpointStatus is the status of an invocation recorded in a [Checkpoint].
+type CheckpointStatus string
+
+const (
+	// CheckpointRunning marks an invocation in progress. An invocation whose
+	// last checkpoint is running was interrupted if no runner is running it,
+	// e.g. after a restart.
+	CheckpointRunning CheckpointStatus = "running"
+	// CheckpointDone marks an invocation that has ended, also when it failed
+	// or was cancelled.
+	CheckpointDone CheckpointStatus = "done"
+)
+
+// Checkpoint records the progress of an invocation in the session. It is
+// carried by events without content, appended when the invocation starts,
+// at every step and when it ends.
+type Checkpoint struct {
+	Status CheckpointStatus `json:"status"`
+	// Agent is the agent that started the invocation.
+	Agent string `json:"agent"`
+	// Steps is the number of completed steps, i.e. the rounds of function
+	// calls that got their responses.
+	Steps int `json:"steps"`
+	// PendingCalls are the function calls waiting for their responses.
+	PendingCalls []PendingCall `json:"pending_calls,omitempty"`
+}
+
+// PendingCall is a function call waiting for its response.
+type PendingCall struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CheckpointFromEvent returns the checkpoint carried by the event, also
+// after the event was round-tripped through a session service.
+func CheckpointFromEvent(event *session.Event) (*Checkpoint, bool) {
+	val, ok := event.CustomMetadata[CheckpointKey]
+	if !ok {
+		return nil, false
+	}
+	if checkpoint, ok := val.(*Checkpoint); ok {
+		return checkpoint, true
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, false
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, false
+	}
+	return &checkpoint, true
+}
+
+// InterruptedInvocation is an invocation that did not end, see
+// [FindInterruptedInvocation].
+type InterruptedInvocation struct {
+	InvocationID string
+	// Agent is the agent that started the invocation.
+	Agent string
+	// Steps is the number of completed steps.
+	Steps int
+	// PendingCalls are the function calls without responses, from the last
+	// checkpoint and the events appended after it.
+	PendingCalls []PendingCall
+	// Answered reports whether the invocation got its final response before
+	// it was interrupted, so there is nothing left to run.
+	Answered bool
+}
+
+// FindInterruptedInvocation returns the last invocation of the session if its
+// last checkpoint is running. Sessions of an application can be scanned with
+// it on startup, to resume the invocations lost by the previous process with
+// [Runner.ResumeInterrupted].
+//
+// The caller must make sure that no runner is still running the invocation,
+// e.g. with a [SessionLocker] shared by the replicas.
+func FindInterruptedInvocation(sess session.Session) (*InterruptedInvocation, bool) {
+	events := sess.Events()
+	for i := events.Len() - 1; i >= 0; i-- {
+		checkpoint, ok := CheckpointFromEvent(events.At(i))
+		if !ok {
+			continue
+		}
+		if checkpoint.Status != CheckpointRunning {
+			return nil, false
+		}
+		interrupted := &InterruptedInvocation{
+			InvocationID: events.At(i).InvocationID,
+			Agent:        checkpoint.Agent,
+			Steps:        checkpoint.Steps,
+			PendingCalls: slices.Clone(checkpoint.PendingCalls),
+		}
+		var last *session.Event
+		for j := i + 1; j < events.Len(); j++ {
+			event := events.At(j)
+			if event.InvocationID != interrupted.InvocationID {
+				continue
+			}
+			interrupted.PendingCalls = trackPendingCalls(interrupted.PendingCalls, event)
+			last = event
+		}
+		interrupted.Answered = len(interrupted.PendingCalls) == 0 && last != nil &&
+			last.Author != "user" && last.Content != nil && last.IsFinalResponse()
+		return interrupted, true
+	}
+	return nil, false
+}
+
+// ResumeInterrupted resumes the last invocation of the session if it was
+// interrupted, e.g. by a restart of the process running it, see
+// [FindInterruptedInvocation]. It returns [ErrNoInterruptedInvocation] if the
+// invocation has ended.
+//
+// The invocation keeps its ID and continues with the agent that produced the
+// last event, from the events appended before the interruption. Function
+// calls left without responses are answered with an error, as they may or
+// may not have been executed; the model decides whether to call them again.
+// If the invocation got its final response, it is only marked as done.
+func (r *Runner) ResumeInterrupted(ctx context.Context, userID, sessionID string, cfg agent.RunConfig, opts ...RunOption) iter.Seq2[*session.Event, error] {
+	opts = append(opts, func(o *runOptions) {
+		o.resumeInterrupted = true
+	})
+	return r.Run(ctx, userID, sessionID, nil, cfg, opts...)
+}
+
+// interruptedMessage returns the message resuming the interrupted invocation
+// of the session, nil if the agent continues from the session events.
+func interruptedMessage(sess session.Session) (*genai.Content, *InterruptedInvocation, error) {
+	interrupted, ok := FindInterruptedInvocation(sess)
+	if !ok {
+		return nil, nil, ErrNoInterruptedInvocation
+	}
+	if len(interrupted.PendingCalls) == 0 {
+		return nil, interrupted, nil
+	}
+	msg := &genai.Content{Role: genai.RoleUser}
+	for _, call := range interrupted.PendingCalls {
+		msg.Parts = append(msg.Parts, convert.FunctionResponsePart(&genai.FunctionCall{ID: call.ID, Name: call.Name}, map[string]any{
+			"error": "the call was interrupted by a restart, it may or may not have been executed",
+		}))
+	}
+	return msg, interrupted, nil
+}
+
+// trackPendingCalls updates the pending calls with the function calls and
+// responses of the event.
+func trackPendingCalls(pending []PendingCall, event *session.Event) []PendingCall {
+	for _, resp := range utils.FunctionResponses(event.Content) {
+		pending = slices.DeleteFunc(pending, func(call PendingCall) bool { return call.ID == resp.ID })
+	}
+	for _, call := range utils.FunctionCalls(event.Content) {
+		pending = append(pending, PendingCall{ID: call.ID, Name: call.Name})
+	}
+	return pending
+}
+
+// checkpointer appends the checkpoints of an invocation.
+type checkpointer struct {
+	checkpoint Checkpoint
+	// author is the author of the checkpoint events, the agent that produced
+	// the last event, so that they do not change the agent the next
+	// invocation is routed to.
+	author string
+}
+
+func newCheckpointer(agentName string, interrupted *InterruptedInvocation) *checkpointer {
+	c := &checkpointer{
+		checkpoint: Checkpoint{Status: CheckpointRunning, Agent: agentName},
+		author:     agentName,
+	}
+	if interrupted != nil {
+		c.checkpoint.Agent = interrupted.Agent
+		c.checkpoint.Steps = interrupted.Steps
+		// The pending calls are answered by the resuming message.
+		if len(interrupted.PendingCalls) > 0 {
+			c.checkpoint.Steps++
+		}
+	}
+	return c
+}
+
+// trackEvent updates the checkpoint with a complete event of the
+// invocation. It returns whether the event completes a step.
+func (c *checkpointer) trackEvent(event *session.Event) bool {
+	if event.Author != "" && event.Author != "user" {
+		c.author = event.Author
+	}
+	calls, responses := utils.FunctionCalls(event.Content), utils.FunctionResponses(event.Content)
+	if len(calls) == 0 && len(responses) == 0 {
+		return false
+	}
+	c.checkpoint.PendingCalls = trackPendingCalls(c.checkpoint.PendingCalls, event)
+	if len(responses) > 0 && len(c.checkpoint.PendingCalls) == 0 {
+		c.checkpoint.Steps++
+	}
+	return true
+}
+
+func (c *checkpointer) newEvent(ctx agent.InvocationContext, status CheckpointStatus) *session.Event {
+	checkpoint := c.checkpoint
+	checkpoint.Status = status
+	checkpoint.PendingCalls = slices.Clone(checkpoint.PendingCalls)
+	event := session.NewEventWithContext(ctx, ctx.InvocationID())
+	event.Author = c.author
+	event.CustomMetadata = map[string]any{CheckpointKey: &checkpoint}
+	return event
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestRunner_ResumeInterrupted(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	var executed int
+	sendTool, err := functiontool.New(functiontool.Config{Name: "send", Description: "sends the message"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		executed++
+		return map[string]any{"status": "sent"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &fakeLLM{responses: []*genai.Content{
+		{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "send"}}}},
+		genai.NewContentFromText("could not confirm the message was sent", genai.RoleModel),
+	}}
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, Tools: []tool.Tool{sendTool}}))
+	sessionService := session.InMemoryService()
+	newRunner := func() *Runner {
+		r, err := New(Config{AppName: "testApp", Agent: root, SessionService: sessionService, AutoCreateSession: true, Checkpoints: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	getSession := func() session.Session {
+		resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Session
+	}
+
+	// The process stops after the model called the tool, before the tool
+	// ran.
+	var invocationID string
+	for event, err := range newRunner().Run(ctx, "user", "session", genai.NewContentFromText("send it", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		invocationID = event.InvocationID
+		break
+	}
+
+	interrupted, ok := FindInterruptedInvocation(getSession())
+	if !ok {
+		t.Fatal("FindInterruptedInvocation() found no interrupted invocation")
+	}
+	want := &InterruptedInvocation{InvocationID: invocationID, Agent: "root", PendingCalls: []PendingCall{{ID: "call-1", Name: "send"}}}
+	if diff := cmp.Diff(want, interrupted); diff != "" {
+		t.Errorf("FindInterruptedInvocation() mismatch (-want +got):\n%s", diff)
+	}
+
+	var events []*session.Event
+	for event, err := range newRunner().ResumeInterrupted(ctx, "user", "session", agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("ResumeInterrupted() error = %v", err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 1 || events[0].InvocationID != invocationID || events[0].Content.Parts[0].Text != "could not confirm the message was sent" {
+		t.Errorf("ResumeInterrupted() events = %+v, want the answer in invocation %q", events, invocationID)
+	}
+	if executed != 0 {
+		t.Errorf("tool executed %d times, want the interrupted call not to be replayed", executed)
+	}
+	lastRequest := llm.requests[len(llm.requests)-1]
+	resp := lastRequest.Contents[len(lastRequest.Contents)-1].Parts[0].FunctionResponse
+	if resp == nil || resp.ID != "call-1" || resp.Response["error"] == nil {
+		t.Errorf("last request content = %+v, want an error response to the interrupted call", lastRequest.Contents[len(lastRequest.Contents)-1])
+	}
+
+	sess := getSession()
+	last, ok := CheckpointFromEvent(sess.Events().At(sess.Events().Len() - 1))
+	if !ok || last.Status != CheckpointDone || last.Steps != 1 {
+		t.Errorf("last checkpoint = %+v, want done after one step", last)
+	}
+	if _, ok := FindInterruptedInvocation(sess); ok {
+		t.Error("FindInterruptedInvocation() found an invocation after it was resumed")
+	}
+	for _, err := range newRunner().ResumeInterrupted(ctx, "user", "session", agent.RunConfig{}) {
+		if !errors.Is(err, ErrNoInterruptedInvocation) {
+			t.Errorf("ResumeInterrupted() error = %v, want %v", err, ErrNoInterruptedInvocation)
+		}
+	}
+}
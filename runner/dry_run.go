@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/session"
+)
+
+// dryRunSession returns an in-memory copy of the session and the service
+// holding it, which a dry run changes in place of the stored session.
+func dryRunSession(ctx context.Context, stored session.Session) (session.Service, session.Session, error) {
+	service := session.InMemoryService()
+	state := make(map[string]any)
+	for key, value := range stored.State().All() {
+		if !strings.HasPrefix(key, session.KeyPrefixTemp) {
+			state[key] = value
+		}
+	}
+	created, err := service.Create(ctx, &session.CreateRequest{
+		AppName:   stored.AppName(),
+		UserID:    stored.UserID(),
+		SessionID: stored.ID(),
+		State:     state,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to copy the session for the dry run: %w", err)
+	}
+	for event := range stored.Events().All() {
+		// The state is copied with the values the events led to.
+		copied := *event
+		copied.Actions.StateDelta = nil
+		if err := service.AppendEvent(ctx, created.Session, &copied); err != nil {
+			return nil, nil, fmt.Errorf("failed to copy the session for the dry run: %w", err)
+		}
+	}
+	return service, created.Session, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/session"
+)
+
+// EventSink receives a copy of every event yielded by [Runner.Run],
+// including partial events.
+//
+// HandleEvent is called synchronously before the event is yielded to the
+// caller of Run, so it must not block. It may be called concurrently by
+// invocations on different sessions.
+type EventSink interface {
+	HandleEvent(ctx context.Context, event *session.Event)
+}
+
+// NewChannelSink returns an [EventSink] that sends the events to ch.
+// Events are dropped if ch is not ready to receive.
+func NewChannelSink(ch chan<- *session.Event) EventSink {
+	return channelSink(ch)
+}
+
+type channelSink chan<- *session.Event
+
+func (s channelSink) HandleEvent(ctx context.Context, event *session.Event) {
+	select {
+	case s <- event:
+	default:
+	}
+}
+
+// NewWriterSink returns an [EventSink] that writes the events to w as
+// JSON lines, in the canonical encoding of session.Event.
+func NewWriterSink(w io.Writer) EventSink {
+	return &writerSink{enc: json.NewEncoder(w)}
+}
+
+type writerSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (s *writerSink) HandleEvent(ctx context.Context, event *session.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.enc.Encode(event); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Failed to write event", "event_id", event.ID, "error", err)
+	}
+}
+
+// teeEvents forwards the events of seq to the sinks.
+func teeEvents(ctx context.Context, seq iter.Seq2[*session.Event, error], sinks []EventSink) iter.Seq2[*session.Event, error] {
+	if len(sinks) == 0 {
+		return seq
+	}
+	return func(yield func(*session.Event, error) bool) {
+		for event, err := range seq {
+			if event != nil {
+				for _, sink := range sinks {
+					sink.HandleEvent(ctx, event)
+				}
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// Replay re-emits the events of the given invocation stored in the session,
+// waiting between events as long as originally elapsed between their
+// timestamps divided by speed. A speed of zero or less re-emits the events
+// without waiting.
+//
+// Replay is meant for debugging UIs and does not run any agent.
+func Replay(ctx context.Context, sess session.Session, invocationID string, speed float64) iter.Seq2[*session.Event, error] {
+	return func(yield func(*session.Event, error) bool) {
+		var last time.Time
+		for event := range sess.Events().All() {
+			if event.InvocationID != invocationID {
+				continue
+			}
+			if !last.IsZero() && speed > 0 {
+				if delay := time.Duration(float64(event.Timestamp.Sub(last)) / speed); delay > 0 {
+					timer := time.NewTimer(delay)
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						yield(nil, ctx.Err())
+						return
+					case <-timer.C:
+					}
+				}
+			}
+			last = event.Timestamp
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
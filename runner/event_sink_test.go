@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+func TestRunner_EventSinks(t *testing.T) {
+	ctx := t.Context()
+	rootAgent := must(llmagent.New(llmagent.Config{Name: "root", Model: &fakeLLM{}}))
+
+	ch := make(chan *session.Event, 10)
+	// A full channel must not block the run.
+	fullCh := make(chan *session.Event)
+	var buf bytes.Buffer
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             rootAgent,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+		EventSinks:        []EventSink{NewChannelSink(ch), NewChannelSink(fullCh), NewWriterSink(&buf)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*session.Event
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		t.Fatal("Run() yielded no events")
+	}
+
+	close(ch)
+	var got []*session.Event
+	for event := range ch {
+		got = append(got, event)
+	}
+	if len(got) != len(events) {
+		t.Fatalf("channel sink got %d events, want %d", len(got), len(events))
+	}
+	for i := range events {
+		if got[i] != events[i] {
+			t.Errorf("channel sink event %d = %v, want %v", i, got[i], events[i])
+		}
+	}
+
+	var lines int
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event session.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("writer sink line %d is not an event: %v", lines, err)
+		}
+		if event.ID != events[lines].ID {
+			t.Errorf("writer sink event %d ID = %q, want %q", lines, event.ID, events[lines].ID)
+		}
+		lines++
+	}
+	if lines != len(events) {
+		t.Errorf("writer sink wrote %d events, want %d", lines, len(events))
+	}
+}
+
+func TestReplay(t *testing.T) {
+	ctx := t.Context()
+	sessionService := session.InMemoryService()
+	resp, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for i, invocationID := range []string{"inv-1", "inv-2", "inv-1"} {
+		event := session.NewEvent(invocationID)
+		event.Author = "root"
+		event.Timestamp = start.Add(time.Duration(i) * 20 * time.Millisecond)
+		event.LLMResponse = model.LLMResponse{Content: genai.NewContentFromText(invocationID, genai.RoleModel)}
+		if err := sessionService.AppendEvent(ctx, resp.Session, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	replayStart := time.Now()
+	var got []string
+	for event, err := range Replay(ctx, resp.Session, "inv-1", 1) {
+		if err != nil {
+			t.Fatalf("Replay() error = %v", err)
+		}
+		got = append(got, event.InvocationID)
+	}
+	if len(got) != 2 || got[0] != "inv-1" || got[1] != "inv-1" {
+		t.Errorf("Replay() events = %v, want 2 events of inv-1", got)
+	}
+	if elapsed := time.Since(replayStart); elapsed < 40*time.Millisecond {
+		t.Errorf("Replay() took %v, want at least the original 40ms", elapsed)
+	}
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	var gotErr error
+	for _, err := range Replay(cancelCtx, resp.Session, "inv-1", 1) {
+		gotErr = err
+	}
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("Replay() with cancelled context error = %v, want %v", gotErr, context.Canceled)
+	}
+}
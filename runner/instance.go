@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"maps"
+
+	"google.golang.org/adk/session"
+)
+
+// InstanceIDKey is the CustomMetadata key of the ID of the instance that
+// appended the event, see Config.InstanceID.
+const InstanceIDKey = "adk_instance_id"
+
+// instanceTaggingService tags the appended events with the ID of the
+// instance of the runner.
+type instanceTaggingService struct {
+	session.Service
+	instanceID string
+}
+
+func (s instanceTaggingService) AppendEvent(ctx context.Context, sess session.Session, event *session.Event) error {
+	s.tag(event)
+	return s.Service.AppendEvent(ctx, sess, event)
+}
+
+func (s instanceTaggingService) AppendEvents(ctx context.Context, sess session.Session, events []*session.Event) error {
+	for _, event := range events {
+		s.tag(event)
+	}
+	return s.Service.AppendEvents(ctx, sess, events)
+}
+
+func (s instanceTaggingService) tag(event *session.Event) {
+	if event == nil || event.Partial {
+		return
+	}
+	// The metadata may be shared with the model response.
+	metadata := maps.Clone(event.CustomMetadata)
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+	metadata[InstanceIDKey] = s.instanceID
+	event.CustomMetadata = metadata
+}
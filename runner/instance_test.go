@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/session"
+)
+
+func TestRunner_InstanceID(t *testing.T) {
+	sessionService := session.InMemoryService()
+	rootAgent := must(llmagent.New(llmagent.Config{Name: "root", Model: &fakeLLM{}}))
+	r, err := New(Config{AppName: "testApp", Agent: rootAgent, SessionService: sessionService, AutoCreateSession: true, InstanceID: "replica-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range r.Run(t.Context(), "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	job := r.RunAsync(t.Context(), "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{})
+	info, err := job.Await(t.Context())
+	if err != nil {
+		t.Fatalf("Await() error = %v", err)
+	}
+	if info.InstanceID != "replica-1" {
+		t.Errorf("job InstanceID = %q, want replica-1", info.InstanceID)
+	}
+	stored, err := GetJobInfo(t.Context(), sessionService, "testApp", "user", "session", job.ID())
+	if err != nil {
+		t.Fatalf("GetJobInfo() error = %v", err)
+	}
+	if stored.InstanceID != "replica-1" {
+		t.Errorf("stored job InstanceID = %q, want replica-1", stored.InstanceID)
+	}
+
+	resp, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Session.Events().Len() == 0 {
+		t.Fatal("session has no events")
+	}
+	for event := range resp.Session.Events().All() {
+		if got := event.CustomMetadata[InstanceIDKey]; got != "replica-1" {
+			t.Errorf("event %q of %s has instance ID %v, want replica-1", event.ID, event.Author, got)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/invocationstats"
+	"google.golang.org/adk/session"
+)
+
+// InvocationInfo describes an invocation running in this process.
+type InvocationInfo struct {
+	InvocationID string
+	AppName      string
+	UserID       string
+	SessionID    string
+	// AgentPath is the branch of the agent that emitted the last event,
+	// e.g. root.planner, or the name of the agent the invocation started
+	// with.
+	AgentPath string
+	// Step describes what the invocation is doing, as of its last event.
+	Step      string
+	StartTime time.Time
+	LLMCalls  int
+	ToolCalls int
+}
+
+// RunningInvocations returns the invocations of all the runners of this
+// process that are running, by start time. It is meant for operators
+// diagnosing stuck agents, see [CancelRunningInvocation].
+func RunningInvocations() []InvocationInfo {
+	return registry.list()
+}
+
+// CancelRunningInvocation cancels a running invocation of this process
+// with the given reason, as [agent.CancelInvocation]. It returns false if
+// there is no such invocation.
+func CancelRunningInvocation(invocationID, reason string) bool {
+	return registry.cancel(invocationID, reason)
+}
+
+// registry holds the running invocations of all the runners.
+var registry = &invocationRegistry{invocations: make(map[string]*runningInvocation)}
+
+type invocationRegistry struct {
+	mu          sync.Mutex
+	invocations map[string]*runningInvocation
+}
+
+type runningInvocation struct {
+	info   InvocationInfo
+	stats  *invocationstats.Stats
+	cancel func(error)
+}
+
+// register adds the invocation of ctx, and returns the functions updating
+// it with the events of the invocation and removing it when it is done.
+func (r *invocationRegistry) register(ctx agent.InvocationContext, cancel func(error)) (update func(*session.Event), unregister func()) {
+	id := ctx.InvocationID()
+	s := ctx.Session()
+	inv := &runningInvocation{
+		info: InvocationInfo{
+			InvocationID: id,
+			AppName:      s.AppName(),
+			UserID:       s.UserID(),
+			SessionID:    s.ID(),
+			AgentPath:    ctx.Agent().Name(),
+			Step:         "started",
+			StartTime:    session.Now(ctx),
+		},
+		stats:  invocationstats.FromContext(ctx),
+		cancel: cancel,
+	}
+	r.mu.Lock()
+	r.invocations[id] = inv
+	r.mu.Unlock()
+
+	update = func(event *session.Event) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if event.Branch != "" {
+			inv.info.AgentPath = event.Branch
+		} else if event.Author != "" && event.Author != "user" {
+			inv.info.AgentPath = event.Author
+		}
+		inv.info.Step = step(event)
+	}
+	unregister = func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.invocations, id)
+	}
+	return update, unregister
+}
+
+func (r *invocationRegistry) list() []InvocationInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	infos := make([]InvocationInfo, 0, len(r.invocations))
+	for _, inv := range r.invocations {
+		info := inv.info
+		if inv.stats != nil {
+			stats := inv.stats.Snapshot()
+			info.LLMCalls, info.ToolCalls = stats.LLMCalls, stats.ToolCalls
+		}
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b InvocationInfo) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	return infos
+}
+
+func (r *invocationRegistry) cancel(invocationID, reason string) bool {
+	r.mu.Lock()
+	inv, ok := r.invocations[invocationID]
+	r.mu.Unlock()
+	if !ok {
+		return false
+	}
+	inv.cancel(fmt.Errorf("%w: %s", agent.ErrInvocationCancelled, reason))
+	return true
+}
+
+// step describes what an invocation does after the event.
+func step(event *session.Event) string {
+	var calls []string
+	responses := false
+	if event.Content != nil {
+		for _, part := range event.Content.Parts {
+			if part.FunctionCall != nil {
+				calls = append(calls, part.FunctionCall.Name)
+			}
+			if part.FunctionResponse != nil {
+				responses = true
+			}
+		}
+	}
+	switch {
+	case len(calls) > 0:
+		return "calling tools: " + strings.Join(calls, ", ")
+	case responses:
+		return "calling the model with the tool responses"
+	case event.Partial:
+		return "streaming the model response"
+	case event.Actions.TransferToAgent != "":
+		return "transferring to " + event.Actions.TransferToAgent
+	default:
+		return "running " + event.Author
+	}
+}
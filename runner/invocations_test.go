@@ -0,0 +1,120 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+func findInvocation(sessionID string) (InvocationInfo, bool) {
+	for _, info := range RunningInvocations() {
+		if info.SessionID == sessionID {
+			return info, true
+		}
+	}
+	return InvocationInfo{}, false
+}
+
+func TestRunningInvocations(t *testing.T) {
+	llm := &stallingLLM{started: make(chan struct{})}
+	rootAgent := must(llmagent.New(llmagent.Config{Name: "root", Model: llm}))
+	r, err := New(Config{AppName: "testApp", Agent: rootAgent, SessionService: session.InMemoryService(), AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	job := r.RunAsync(t.Context(), "user", "stuck-session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{StreamingMode: agent.StreamingModeSSE})
+	<-llm.started
+
+	info, ok := findInvocation("stuck-session")
+	if !ok {
+		t.Fatalf("RunningInvocations() = %+v, want the invocation of stuck-session", RunningInvocations())
+	}
+	if info.AppName != "testApp" || info.UserID != "user" || info.AgentPath != "root" || info.InvocationID == "" {
+		t.Errorf("InvocationInfo = %+v, want the invocation of the root agent", info)
+	}
+	if info.Step != "streaming the model response" {
+		t.Errorf("Step = %q, want streaming the model response", info.Step)
+	}
+	if info.StartTime.Before(start) || info.StartTime.After(time.Now()) {
+		t.Errorf("StartTime = %v, want the start of the run", info.StartTime)
+	}
+
+	if CancelRunningInvocation("unknown", "stuck") {
+		t.Error("CancelRunningInvocation() of an unknown invocation = true, want false")
+	}
+	if !CancelRunningInvocation(info.InvocationID, "stuck") {
+		t.Fatal("CancelRunningInvocation() = false, want true")
+	}
+	got, err := job.Await(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != JobCancelled || !strings.Contains(got.Error, "stuck") {
+		t.Errorf("job = %+v, want cancelled with the reason", got)
+	}
+	if _, ok := findInvocation("stuck-session"); ok {
+		t.Error("the cancelled invocation is still listed as running")
+	}
+}
+
+func TestStep(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *session.Event
+		want  string
+	}{
+		{
+			name:  "function calls",
+			event: &session.Event{LLMResponse: modelResponse(genai.NewPartFromFunctionCall("get_weather", nil), genai.NewPartFromFunctionCall("get_time", nil))},
+			want:  "calling tools: get_weather, get_time",
+		},
+		{
+			name:  "function responses",
+			event: &session.Event{LLMResponse: modelResponse(genai.NewPartFromFunctionResponse("get_weather", nil))},
+			want:  "calling the model with the tool responses",
+		},
+		{
+			name:  "text",
+			event: &session.Event{Author: "root", LLMResponse: modelResponse(genai.NewPartFromText("hi"))},
+			want:  "running root",
+		},
+		{
+			name:  "transfer",
+			event: &session.Event{Actions: session.EventActions{TransferToAgent: "helper"}},
+			want:  "transferring to helper",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := step(tt.event); got != tt.want {
+				t.Errorf("step() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func modelResponse(parts ...*genai.Part) model.LLMResponse {
+	return model.LLMResponse{Content: &genai.Content{Role: genai.RoleModel, Parts: parts}}
+}
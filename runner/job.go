@@ -0,0 +1,247 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/session"
+)
+
+// JobStatus is the status of an invocation started with [Runner.RunAsync].
+type JobStatus string
+
+const (
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// JobInfo describes an invocation started with [Runner.RunAsync].
+//
+// It is persisted in the session state under [JobStateKey], so it can be
+// read with [GetJobInfo] by other processes sharing the session service.
+type JobInfo struct {
+	ID     string    `json:"id"`
+	Status JobStatus `json:"status"`
+	// Error is the error message of a failed or cancelled job.
+	Error string `json:"error,omitempty"`
+	// InstanceID is the Config.InstanceID of the runner running the job.
+	InstanceID string `json:"instance_id,omitempty"`
+}
+
+// JobStateKey returns the session state key of the job with the given ID.
+func JobStateKey(jobID string) string {
+	return "adk_job:" + jobID
+}
+
+// ErrJobNotFound is returned by [GetJobInfo] if the session has no job with
+// the given ID.
+var ErrJobNotFound = errors.New("job not found")
+
+// GetJobInfo reads the status of a job from the session state.
+func GetJobInfo(ctx context.Context, service session.Service, appName, userID, sessionID, jobID string) (*JobInfo, error) {
+	resp, err := service.Get(ctx, &session.GetRequest{AppName: appName, UserID: userID, SessionID: sessionID})
+	if err != nil {
+		return nil, err
+	}
+	val, err := resp.Session.State().Get(JobStateKey(jobID))
+	if errors.Is(err, session.ErrStateKeyNotExist) {
+		return nil, fmt.Errorf("%w: %q", ErrJobNotFound, jobID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The value may have been round-tripped through a storage backend.
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job %q: %w", jobID, err)
+	}
+	var info JobInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to read job %q: %w", jobID, err)
+	}
+	return &info, nil
+}
+
+// Job is a handle of an invocation running in the background.
+type Job struct {
+	id string
+
+	mu   sync.Mutex
+	info JobInfo
+
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// ID returns the ID of the job.
+func (j *Job) ID() string {
+	return j.id
+}
+
+// Info returns the current status of the job.
+func (j *Job) Info() JobInfo {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.info
+}
+
+// Cancel cancels the invocation with the given reason. It has no effect if
+// the job is done.
+func (j *Job) Cancel(reason string) {
+	j.cancel(fmt.Errorf("%w: %s", agent.ErrInvocationCancelled, reason))
+}
+
+// Done returns a channel closed when the job is done.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Await waits for the job to be done and returns its final status.
+func (j *Job) Await(ctx context.Context) (JobInfo, error) {
+	select {
+	case <-ctx.Done():
+		return JobInfo{}, ctx.Err()
+	case <-j.done:
+		return j.Info(), nil
+	}
+}
+
+func (j *Job) setInfo(info JobInfo) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.info = info
+}
+
+// RunAsync starts the invocation in the background and returns its handle.
+//
+// The invocation is not cancelled when ctx is done, only with [Job.Cancel].
+// The status of the job is kept in the session state, the events of the
+// invocation are appended to the session as with [Runner.Run].
+func (r *Runner) RunAsync(ctx context.Context, userID, sessionID string, msg *genai.Content, cfg agent.RunConfig, opts ...RunOption) *Job {
+	jobCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	id := "job-" + session.NewID(r.withProviders(ctx))
+	job := &Job{
+		id:     id,
+		info:   JobInfo{ID: id, Status: JobRunning, InstanceID: r.instanceID},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	// The running status is stored with the user message.
+	opts = append(opts, func(o *runOptions) {
+		delta := maps.Clone(o.stateDelta)
+		if delta == nil {
+			delta = make(map[string]any)
+		}
+		delta[JobStateKey(id)] = jobStateValue(job.Info())
+		o.stateDelta = delta
+	})
+
+	go func() {
+		defer close(job.done)
+		defer cancel(nil)
+
+		// The final status is stored whatever the outcome of the
+		// invocation, e.g. if it fails before it starts or panics.
+		info := JobInfo{ID: job.ID(), Status: JobFailed, Error: "the invocation did not complete", InstanceID: r.instanceID}
+		defer func() {
+			p := recover()
+			if p != nil {
+				info.Status = JobFailed
+				info.Error = fmt.Sprintf("panic: %v", p)
+			}
+			if err := r.storeJobInfo(jobCtx, userID, sessionID, info); err != nil {
+				logging.FromContext(jobCtx).ErrorContext(jobCtx, "Failed to store the job status", "job_id", job.ID(), "error", err)
+			}
+			job.setInfo(info)
+			if p != nil {
+				panic(p)
+			}
+		}()
+
+		var runErr error
+		// cancellation is the final event of an invocation cancelled from
+		// within, e.g. with agent.CancelInvocation.
+		var cancellation *session.Event
+		for event, err := range r.Run(jobCtx, userID, sessionID, msg, cfg, opts...) {
+			if err != nil {
+				runErr = errors.Join(runErr, err)
+				continue
+			}
+			if event.ErrorCode == ErrorCodeCancelled || event.ErrorCode == ErrorCodeTimeout {
+				cancellation = event
+			}
+		}
+
+		info.Status, info.Error = JobSucceeded, ""
+		switch {
+		case agent.IsInvocationCancelled(jobCtx):
+			info.Status = JobCancelled
+			info.Error = context.Cause(jobCtx).Error()
+		case cancellation != nil:
+			info.Status = JobCancelled
+			info.Error = cancellation.ErrorMessage
+		case runErr != nil:
+			info.Status = JobFailed
+			info.Error = runErr.Error()
+		}
+	}()
+	return job
+}
+
+// storeJobInfo stores the status of the job in the session state. The
+// session is locked, so that the status is not written in the middle of
+// another invocation.
+func (r *Runner) storeJobInfo(ctx context.Context, userID, sessionID string, info JobInfo) error {
+	ctx = context.WithoutCancel(ctx)
+	unlock, err := r.sessionLocker.Lock(ctx, SessionKey{AppName: r.appName, UserID: userID, SessionID: sessionID})
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	resp, err := r.sessionService.Get(ctx, &session.GetRequest{AppName: r.appName, UserID: userID, SessionID: sessionID})
+	if err != nil {
+		return err
+	}
+	// The event only changes the state. It is authored by the user, not an
+	// agent, so the next invocation still continues with the last agent.
+	event := session.NewEventWithContext(ctx, "")
+	event.Author = "user"
+	event.Actions.StateDelta[JobStateKey(info.ID)] = jobStateValue(info)
+	return r.sessionService.AppendEvent(ctx, resp.Session, event)
+}
+
+func jobStateValue(info JobInfo) map[string]any {
+	val := map[string]any{"id": info.ID, "status": string(info.Status)}
+	if info.Error != "" {
+		val["error"] = info.Error
+	}
+	if info.InstanceID != "" {
+		val["instance_id"] = info.InstanceID
+	}
+	return val
+}
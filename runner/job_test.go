@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+func TestRunner_RunAsync(t *testing.T) {
+	errModel := errors.New("model failure")
+	tests := []struct {
+		name       string
+		model      model.LLM
+		cancel     bool
+		cfg        agent.RunConfig
+		wantStatus JobStatus
+		wantError  string
+	}{
+		{name: "succeeded", model: &fakeLLM{}, wantStatus: JobSucceeded},
+		{name: "failed", model: &failingLLM{err: errModel}, wantStatus: JobFailed, wantError: errModel.Error()},
+		{name: "cancelled", model: &stallingLLM{started: make(chan struct{})}, cancel: true, wantStatus: JobCancelled, wantError: "client gone"},
+		{name: "failed before the invocation starts", model: &fakeLLM{}, cfg: agent.RunConfig{MaxLLMCalls: -1}, wantStatus: JobFailed, wantError: "MaxLLMCalls must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The job outlives the context it was started with.
+			ctx, cancel := context.WithCancel(t.Context())
+			sessionService := session.InMemoryService()
+			rootAgent := must(llmagent.New(llmagent.Config{Name: "root", Model: tt.model}))
+			r, err := New(Config{AppName: "testApp", Agent: rootAgent, SessionService: sessionService, AutoCreateSession: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := tt.cfg
+			cfg.StreamingMode = agent.StreamingModeSSE
+			job := r.RunAsync(ctx, "user", "session", genai.NewContentFromText("hi", genai.RoleUser), cfg)
+			cancel()
+			if !strings.HasPrefix(job.ID(), "job-") {
+				t.Errorf("job ID = %q, want job- prefix", job.ID())
+			}
+			if tt.cancel {
+				<-tt.model.(*stallingLLM).started
+				job.Cancel("client gone")
+			}
+
+			got, err := job.Await(t.Context())
+			if err != nil {
+				t.Fatalf("Await() error = %v", err)
+			}
+			if got.Status != tt.wantStatus || !strings.Contains(got.Error, tt.wantError) {
+				t.Errorf("Await() = %+v, want status %q and error containing %q", got, tt.wantStatus, tt.wantError)
+			}
+
+			stored, err := GetJobInfo(t.Context(), sessionService, "testApp", "user", "session", job.ID())
+			if err != nil {
+				t.Fatalf("GetJobInfo() error = %v", err)
+			}
+			if diff := cmp.Diff(&got, stored); diff != "" {
+				t.Errorf("GetJobInfo() mismatch (-want +got):\n%s", diff)
+			}
+
+			// The status is not stored on behalf of an agent.
+			resp, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			events := resp.Session.Events()
+			if last := events.At(events.Len() - 1); last.Author != "user" || last.Content != nil {
+				t.Errorf("last event = %+v, want a state-only event authored by the user", last)
+			}
+		})
+	}
+
+	t.Run("unknown job", func(t *testing.T) {
+		sessionService := session.InMemoryService()
+		if _, err := sessionService.Create(t.Context(), &session.CreateRequest{AppName: "testApp", UserID: "user", SessionID: "session"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := GetJobInfo(t.Context(), sessionService, "testApp", "user", "session", "job-1"); !errors.Is(err, ErrJobNotFound) {
+			t.Errorf("GetJobInfo() error = %v, want %v", err, ErrJobNotFound)
+		}
+	})
+}
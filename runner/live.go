@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"iter"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/session"
+)
+
+// RunLive runs the agent on a live, bidirectional connection to its model.
+// The inputs of the user, e.g. text turns or chunks of audio, are sent to the
+// queue and streamed to the model, which responds as they arrive. The model
+// of the agent must implement model.LiveLLM.
+//
+// The events are yielded as in Run: the turns of the user and the complete
+// model responses are appended to the session, the partial responses, e.g.
+// audio chunks, and the turn complete signals are only yielded. The chunks of
+// the transcriptions of the audio are yielded as partial events, and the
+// complete transcriptions are appended to the session, see
+// agent.TranscriptionCache. The run ends when the queue is closed or ctx is
+// cancelled.
+func (r *Runner) RunLive(ctx context.Context, userID, sessionID string, queue *agent.LiveRequestQueue, cfg agent.RunConfig, opts ...RunOption) iter.Seq2[*session.Event, error] {
+	opts = append(opts, func(o *runOptions) {
+		o.live = queue
+	})
+	cfg.StreamingMode = agent.StreamingModeBidi
+	return r.Run(ctx, userID, sessionID, nil, cfg, opts...)
+}
+
+// liveConnectConfig returns the settings of the live connection of a RunLive
+// invocation, nil otherwise.
+func liveConnectConfig(cfg *agent.RunConfig) *genai.LiveConnectConfig {
+	if cfg.StreamingMode != agent.StreamingModeBidi {
+		return nil
+	}
+	return &genai.LiveConnectConfig{
+		ResponseModalities:       cfg.ResponseModalities,
+		SpeechConfig:             cfg.SpeechConfig,
+		InputAudioTranscription:  cfg.InputAudioTranscription,
+		OutputAudioTranscription: cfg.OutputAudioTranscription,
+	}
+}
+
+// isLiveSignalEvent reports whether the event only signals the state of a
+// live connection, e.g. the completion of a turn, and is not worth storing.
+func isLiveSignalEvent(event *session.Event) bool {
+	return event.Content == nil && event.InputTranscription == nil && event.OutputTranscription == nil &&
+		event.ErrorCode == "" && len(event.Actions.StateDelta) == 0 && len(event.Actions.ArtifactDelta) == 0 &&
+		event.Actions.TransferToAgent == "" && !event.Actions.Escalate && event.CustomMetadata == nil
+}
@@ -0,0 +1,343 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/speech"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// fakeLiveLLM answers a user turn with a call to get_weather, and the tool
+// response with a streamed text.
+type fakeLiveLLM struct {
+	fakeLLM
+	conn *fakeLiveConnection
+}
+
+func (m *fakeLiveLLM) ConnectLive(ctx context.Context, req *model.LLMRequest) (model.LiveConnection, error) {
+	m.conn = &fakeLiveConnection{req: req, out: make(chan *model.LLMResponse, 16), closed: make(chan struct{})}
+	return m.conn, nil
+}
+
+type fakeLiveConnection struct {
+	req       *model.LLMRequest
+	out       chan *model.LLMResponse
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	mu       sync.Mutex
+	sent     []*genai.Content
+	realtime []genai.LiveRealtimeInput
+}
+
+func (c *fakeLiveConnection) SendContent(content *genai.Content) error {
+	c.mu.Lock()
+	c.sent = append(c.sent, content)
+	c.mu.Unlock()
+	if len(utils.FunctionResponses(content)) > 0 {
+		c.out <- &model.LLMResponse{Content: genai.NewContentFromText("It is ", genai.RoleModel), Partial: true}
+		c.out <- &model.LLMResponse{Content: genai.NewContentFromText("sunny.", genai.RoleModel), Partial: true}
+		c.out <- &model.LLMResponse{Content: genai.NewContentFromText("It is sunny.", genai.RoleModel)}
+		c.out <- &model.LLMResponse{TurnComplete: true}
+		return nil
+	}
+	c.out <- &model.LLMResponse{Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{
+		{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "get_weather", Args: map[string]any{}}},
+	}}}
+	return nil
+}
+
+func (c *fakeLiveConnection) SendRealtime(input genai.LiveRealtimeInput) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.realtime = append(c.realtime, input)
+	return nil
+}
+
+func (c *fakeLiveConnection) Receive() iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		for {
+			select {
+			case resp := <-c.out:
+				if !yield(resp, nil) {
+					return
+				}
+			case <-c.closed:
+				return
+			}
+		}
+	}
+}
+
+func (c *fakeLiveConnection) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+func TestRunner_RunLive(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	weatherTool, err := functiontool.New(functiontool.Config{Name: "get_weather", Description: "returns the weather"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return map[string]any{"weather": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &fakeLiveLLM{}
+	a := must(llmagent.New(llmagent.Config{Name: "voice_agent", Model: llm, Tools: []tool.Tool{weatherTool}}))
+	sessionService := session.InMemoryService()
+	r, err := New(Config{AppName: "testApp", Agent: a, SessionService: sessionService, AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queue := agent.NewLiveRequestQueue()
+	audio := &genai.Blob{MIMEType: "audio/pcm", Data: []byte{1, 2}}
+	if err := queue.SendRealtime(audio); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.SendContent(genai.NewContentFromText("What is the weather?", genai.RoleUser)); err != nil {
+		t.Fatal(err)
+	}
+	cfg := agent.RunConfig{ResponseModalities: []genai.Modality{genai.ModalityText}}
+	var partials, turnCompletes int
+	for event, err := range r.RunLive(ctx, "user", "session", queue, cfg) {
+		if err != nil {
+			t.Fatalf("RunLive() error = %v", err)
+		}
+		if event.Partial {
+			partials++
+		}
+		if event.TurnComplete {
+			turnCompletes++
+			queue.Close()
+		}
+	}
+	if partials != 2 || turnCompletes != 1 {
+		t.Errorf("RunLive() yielded %d partial and %d turn complete events, want 2 and 1", partials, turnCompletes)
+	}
+
+	conn := llm.conn
+	if got := conn.req.LiveConnectConfig; got == nil || !slices.Equal(got.ResponseModalities, cfg.ResponseModalities) {
+		t.Errorf("LiveConnectConfig = %+v, want the response modalities of the run config", got)
+	}
+	if len(conn.realtime) != 1 || conn.realtime[0].Audio != audio {
+		t.Errorf("realtime inputs = %+v, want the audio blob", conn.realtime)
+	}
+	if len(conn.sent) != 2 || len(utils.FunctionResponses(conn.sent[1])) != 1 {
+		t.Errorf("sent contents = %+v, want the user turn and the tool response", conn.sent)
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for event := range resp.Session.Events().All() {
+		got = append(got, event.Author+": "+eventSummary(event))
+	}
+	want := []string{
+		"user: What is the weather?",
+		"voice_agent: call get_weather",
+		"voice_agent: response get_weather",
+		"voice_agent: It is sunny.",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("session events = %q, want %q", got, want)
+	}
+}
+
+func eventSummary(event *session.Event) string {
+	if calls := utils.FunctionCalls(event.Content); len(calls) > 0 {
+		return "call " + calls[0].Name
+	}
+	if responses := utils.FunctionResponses(event.Content); len(responses) > 0 {
+		return "response " + responses[0].Name
+	}
+	return event.Content.Parts[0].Text
+}
+
+func TestRunner_RunLive_Errors(t *testing.T) {
+	sessionService := session.InMemoryService()
+	notLive := must(llmagent.New(llmagent.Config{Name: "text_agent", Model: &fakeLLM{}}))
+	r, err := New(Config{AppName: "testApp", Agent: notLive, SessionService: sessionService, AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queue := agent.NewLiveRequestQueue()
+	queue.Close()
+	var gotErr error
+	for _, err := range r.RunLive(t.Context(), "user", "session", queue, agent.RunConfig{}) {
+		gotErr = err
+	}
+	if gotErr == nil {
+		t.Error("RunLive() with a model without live support succeeded, want error")
+	}
+
+	gotErr = nil
+	for _, err := range r.Run(t.Context(), "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{StreamingMode: agent.StreamingModeBidi}) {
+		gotErr = err
+	}
+	if gotErr == nil {
+		t.Error("Run() in the bidi streaming mode succeeded, want error")
+	}
+}
+
+func TestRunner_RunLive_Speech(t *testing.T) {
+	llm := &fakeLLM{responses: []*genai.Content{genai.NewContentFromText("It is sunny.", genai.RoleModel)}}
+	a := must(llmagent.New(llmagent.Config{Name: "text_agent", Model: llm}))
+	r, err := New(Config{AppName: "testApp", Agent: a, SessionService: session.InMemoryService(), AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queue := agent.NewLiveRequestQueue()
+	if err := queue.SendRealtime(&genai.Blob{MIMEType: "audio/pcm", Data: []byte("weather?")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.SendActivityEnd(); err != nil {
+		t.Fatal(err)
+	}
+	cfg := agent.RunConfig{
+		OutputAudioTranscription: &genai.AudioTranscriptionConfig{},
+		SpeechToText: speech.TranscribeFunc(func(ctx context.Context, audio *genai.Blob) (string, error) {
+			return "What is the " + strings.TrimSuffix(string(audio.Data), "?") + "?", nil
+		}),
+		TextToSpeech: speech.SynthesizeFunc(func(ctx context.Context, text string, cfg *genai.SpeechConfig) (*genai.Blob, error) {
+			return &genai.Blob{MIMEType: "audio/pcm;rate=24000", Data: []byte(text)}, nil
+		}),
+	}
+	var audio, transcription string
+	for event, err := range r.RunLive(t.Context(), "user", "session", queue, cfg) {
+		if err != nil {
+			t.Fatalf("RunLive() error = %v", err)
+		}
+		for _, out := range event.Outputs() {
+			if out.Kind == model.OutputAudio {
+				audio += string(out.Part.InlineData.Data)
+			}
+		}
+		if event.OutputTranscription != nil {
+			transcription += event.OutputTranscription.Text
+		}
+		if event.TurnComplete {
+			queue.Close()
+		}
+	}
+	if audio != "It is sunny." || transcription != "It is sunny." {
+		t.Errorf("RunLive() yielded the audio %q and the transcription %q, want the synthesized response", audio, transcription)
+	}
+	if len(llm.requests) != 1 {
+		t.Fatalf("got %d model requests, want 1", len(llm.requests))
+	}
+	contents := llm.requests[0].Contents
+	if got := contents[len(contents)-1].Parts[0].Text; got != "What is the weather?" {
+		t.Errorf("last content of the model request = %q, want the transcribed audio", got)
+	}
+}
+
+// transcribingLiveLLM answers the end of the user activity with the chunks
+// of the transcriptions of the audio of the user and of its response.
+type transcribingLiveLLM struct {
+	fakeLLM
+}
+
+func (m *transcribingLiveLLM) ConnectLive(ctx context.Context, req *model.LLMRequest) (model.LiveConnection, error) {
+	return &transcribingLiveConnection{&fakeLiveConnection{req: req, out: make(chan *model.LLMResponse, 16), closed: make(chan struct{})}}, nil
+}
+
+type transcribingLiveConnection struct {
+	*fakeLiveConnection
+}
+
+func (c *transcribingLiveConnection) SendRealtime(input genai.LiveRealtimeInput) error {
+	if input.ActivityEnd == nil {
+		return nil
+	}
+	c.out <- &model.LLMResponse{InputTranscription: &genai.Transcription{Text: "What is"}, Partial: true}
+	c.out <- &model.LLMResponse{InputTranscription: &genai.Transcription{Text: " the weather?"}, Partial: true}
+	c.out <- &model.LLMResponse{OutputTranscription: &genai.Transcription{Text: "It is"}, Partial: true}
+	c.out <- &model.LLMResponse{OutputTranscription: &genai.Transcription{Text: " sunny.", Finished: true}}
+	c.out <- &model.LLMResponse{OutputTranscription: &genai.Transcription{Text: "Bye"}, Partial: true}
+	c.out <- &model.LLMResponse{TurnComplete: true}
+	return nil
+}
+
+func TestRunner_RunLive_Transcriptions(t *testing.T) {
+	a := must(llmagent.New(llmagent.Config{Name: "voice_agent", Model: &transcribingLiveLLM{}}))
+	sessionService := session.InMemoryService()
+	r, err := New(Config{AppName: "testApp", Agent: a, SessionService: sessionService, AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queue := agent.NewLiveRequestQueue()
+	if err := queue.SendActivityEnd(); err != nil {
+		t.Fatal(err)
+	}
+	var partials int
+	for event, err := range r.RunLive(t.Context(), "user", "session", queue, agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("RunLive() error = %v", err)
+		}
+		if event.Partial {
+			partials++
+		}
+		if event.TurnComplete {
+			queue.Close()
+		}
+	}
+	if partials != 4 {
+		t.Errorf("RunLive() yielded %d partial events, want the 4 unfinished chunks", partials)
+	}
+
+	resp, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for event := range resp.Session.Events().All() {
+		switch {
+		case event.InputTranscription != nil:
+			got = append(got, event.Author+" said: "+event.InputTranscription.Text)
+		case event.OutputTranscription != nil:
+			got = append(got, event.Author+" said: "+event.OutputTranscription.Text)
+		}
+	}
+	want := []string{
+		"user said: What is the weather?",
+		"voice_agent said: It is sunny.",
+		"voice_agent said: Bye",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("session transcriptions = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/adk/internal/telemetry"
+	"google.golang.org/adk/session"
+)
+
+// instrumentedSessionService records the latency of the session service
+// operations.
+type instrumentedSessionService struct {
+	session.Service
+}
+
+func (s instrumentedSessionService) Create(ctx context.Context, req *session.CreateRequest) (_ *session.CreateResponse, err error) {
+	defer recordSessionOperation(ctx, "create", time.Now(), &err)
+	return s.Service.Create(ctx, req)
+}
+
+func (s instrumentedSessionService) Get(ctx context.Context, req *session.GetRequest) (_ *session.GetResponse, err error) {
+	defer recordSessionOperation(ctx, "get", time.Now(), &err)
+	return s.Service.Get(ctx, req)
+}
+
+func (s instrumentedSessionService) List(ctx context.Context, req *session.ListRequest) (_ *session.ListResponse, err error) {
+	defer recordSessionOperation(ctx, "list", time.Now(), &err)
+	return s.Service.List(ctx, req)
+}
+
+func (s instrumentedSessionService) Delete(ctx context.Context, req *session.DeleteRequest) (err error) {
+	defer recordSessionOperation(ctx, "delete", time.Now(), &err)
+	return s.Service.Delete(ctx, req)
+}
+
+func (s instrumentedSessionService) AppendEvent(ctx context.Context, sess session.Session, event *session.Event) (err error) {
+	defer recordSessionOperation(ctx, "append_event", time.Now(), &err)
+	return s.Service.AppendEvent(ctx, sess, event)
+}
+
+func (s instrumentedSessionService) AppendEvents(ctx context.Context, sess session.Session, events []*session.Event) (err error) {
+	defer recordSessionOperation(ctx, "append_events", time.Now(), &err)
+	return s.Service.AppendEvents(ctx, sess, events)
+}
+
+func recordSessionOperation(ctx context.Context, operation string, start time.Time, err *error) {
+	telemetry.RecordSessionOperation(ctx, operation, time.Since(start), *err)
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+// outputSaver saves the blobs generated by the model as artifacts, see
+// agent.RunConfig.SaveOutputBlobsAsArtifacts.
+type outputSaver struct {
+	// The audio chunks streamed by a live model in partial events since the
+	// last turn, saved together when the turn completes.
+	audio         []byte
+	audioMIMEType string
+}
+
+// save saves the output blobs of the event as artifacts, replaces them with a
+// text referencing the artifacts and records them in the artifact delta of
+// the event. The audio chunks of the partial events are buffered and saved
+// with the event completing or interrupting the turn.
+func (s *outputSaver) save(ctx context.Context, artifacts agent.Artifacts, event *session.Event) error {
+	if event.Partial {
+		for _, out := range event.Outputs() {
+			if out.Kind == model.OutputAudio && out.Part.InlineData != nil {
+				if s.audio == nil {
+					s.audioMIMEType = out.Part.InlineData.MIMEType
+				}
+				s.audio = append(s.audio, out.Part.InlineData.Data...)
+			}
+		}
+		return nil
+	}
+
+	if event.Content != nil {
+		var parts []*genai.Part
+		for i, part := range event.Content.Parts {
+			out, ok := model.OutputOf(part)
+			if !ok || part.InlineData == nil {
+				continue
+			}
+			if parts == nil {
+				parts = append([]*genai.Part(nil), event.Content.Parts...)
+			}
+			name := fmt.Sprintf("output_%s_%d", event.ID, i)
+			if err := saveOutput(ctx, artifacts, event, name, part); err != nil {
+				return err
+			}
+			parts[i] = genai.NewPartFromText(fmt.Sprintf("Generated %s: %s. It has been saved to the artifacts", out.Kind, name))
+		}
+		if parts != nil {
+			event.Content = &genai.Content{Role: event.Content.Role, Parts: parts}
+		}
+	}
+
+	if (event.TurnComplete || event.Interrupted) && len(s.audio) > 0 {
+		name := fmt.Sprintf("output_%s_audio", event.ID)
+		part := &genai.Part{InlineData: &genai.Blob{MIMEType: s.audioMIMEType, Data: s.audio}}
+		s.audio, s.audioMIMEType = nil, ""
+		if err := saveOutput(ctx, artifacts, event, name, part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func saveOutput(ctx context.Context, artifacts agent.Artifacts, event *session.Event, name string, part *genai.Part) error {
+	resp, err := artifacts.Save(ctx, name, part)
+	if err != nil {
+		return fmt.Errorf("failed to save artifact %s: %w", name, err)
+	}
+	if event.Actions.ArtifactDelta == nil {
+		event.Actions.ArtifactDelta = make(map[string]int64)
+	}
+	event.Actions.ArtifactDelta[name] = resp.Version
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+func TestRunner_SaveOutputBlobsAsArtifacts(t *testing.T) {
+	image := []byte("png")
+	responses := []model.LLMResponse{
+		{Content: genai.NewContentFromParts([]*genai.Part{
+			genai.NewPartFromText("here is an image"),
+			genai.NewPartFromBytes(image, "image/png"),
+		}, genai.RoleModel)},
+		{Content: genai.NewContentFromBytes([]byte{1, 2}, "audio/pcm;rate=24000", genai.RoleModel), Partial: true},
+		{Content: genai.NewContentFromBytes([]byte{3}, "audio/pcm;rate=24000", genai.RoleModel), Partial: true},
+		{TurnComplete: true, CustomMetadata: map[string]any{"turn": "complete"}},
+	}
+	testAgent := must(agent.New(agent.Config{
+		Name: "test_agent",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				for _, resp := range responses {
+					ev := session.NewEventWithContext(ctx, ctx.InvocationID())
+					ev.LLMResponse = resp
+					if !yield(ev, nil) {
+						return
+					}
+				}
+			}
+		},
+	}))
+	artifactService := artifact.InMemoryService()
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             testAgent,
+		SessionService:    session.InMemoryService(),
+		ArtifactService:   artifactService,
+		AutoCreateSession: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var events []*session.Event
+	for ev, err := range r.Run(t.Context(), "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{SaveOutputBlobsAsArtifacts: true}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		events = append(events, ev)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Run() yielded %d events, want 4", len(events))
+	}
+
+	imageName := fmt.Sprintf("output_%s_1", events[0].ID)
+	wantContent := genai.NewContentFromParts([]*genai.Part{
+		genai.NewPartFromText("here is an image"),
+		genai.NewPartFromText("Generated image: " + imageName + ". It has been saved to the artifacts"),
+	}, genai.RoleModel)
+	if diff := cmp.Diff(wantContent, events[0].Content); diff != "" {
+		t.Errorf("image event content mismatch (-want +got):\n%s", diff)
+	}
+	audioName := fmt.Sprintf("output_%s_audio", events[3].ID)
+	for i, want := range map[int]map[string]int64{0: {imageName: 1}, 3: {audioName: 1}} {
+		if diff := cmp.Diff(want, events[i].Actions.ArtifactDelta); diff != "" {
+			t.Errorf("event %d ArtifactDelta mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	for name, want := range map[string]*genai.Blob{
+		imageName: {MIMEType: "image/png", Data: image},
+		audioName: {MIMEType: "audio/pcm;rate=24000", Data: []byte{1, 2, 3}},
+	} {
+		resp, err := artifactService.Load(t.Context(), &artifact.LoadRequest{AppName: "testApp", UserID: "user", SessionID: "session", FileName: name})
+		if err != nil {
+			t.Fatalf("Load(%q) error = %v", name, err)
+		}
+		if diff := cmp.Diff(want, resp.Part.InlineData); diff != "" {
+			t.Errorf("artifact %q mismatch (-want +got):\n%s", name, diff)
+		}
+	}
+}
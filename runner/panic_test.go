@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestRunner_ToolPanic(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	echoTool, err := functiontool.New(functiontool.Config{Name: "echo", Description: "echoes"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return map[string]any{"ok": true}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &fakeLLM{responses: []*genai.Content{
+		{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "echo"}}}},
+		genai.NewContentFromText("recovered", genai.RoleModel),
+	}}
+	root := must(llmagent.New(llmagent.Config{
+		Name:  "root",
+		Model: llm,
+		Tools: []tool.Tool{echoTool},
+		AfterToolCallbacks: []llmagent.AfterToolCallback{
+			func(tool.Context, tool.Tool, map[string]any, map[string]any, error) (map[string]any, error) {
+				panic("broken callback")
+			},
+		},
+	}))
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             root,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+		PanicStackTraces:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	// The invocation goes on, the model gets the panic as the response.
+	if len(llm.requests) != 2 {
+		t.Fatalf("model was called %d times, want 2", len(llm.requests))
+	}
+	contents := llm.requests[1].Contents
+	resp := contents[len(contents)-1].Parts[0].FunctionResponse
+	if resp == nil || fmt.Sprint(resp.Response["error"]) != `panic in tool "echo": broken callback` {
+		t.Fatalf("function response = %+v, want the panic", resp)
+	}
+	if !strings.Contains(fmt.Sprint(resp.Response["stack"]), "goroutine") {
+		t.Errorf("function response stack = %q, want a stack trace", resp.Response["stack"])
+	}
+}
+
+func TestRunner_AgentPanic(t *testing.T) {
+	for _, stackTraces := range []bool{false, true} {
+		t.Run(fmt.Sprintf("PanicStackTraces=%v", stackTraces), func(t *testing.T) {
+			ctx := t.Context()
+			root := must(llmagent.New(llmagent.Config{
+				Name:  "root",
+				Model: &fakeLLM{},
+				BeforeModelCallbacks: []llmagent.BeforeModelCallback{
+					func(agent.CallbackContext, *model.LLMRequest) (*model.LLMResponse, error) {
+						panic("broken callback")
+					},
+				},
+			}))
+			sessionService := session.InMemoryService()
+			r, err := New(Config{
+				AppName:           "testApp",
+				Agent:             root,
+				SessionService:    sessionService,
+				AutoCreateSession: true,
+				PanicStackTraces:  stackTraces,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var events []*session.Event
+			for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{}) {
+				if err != nil {
+					t.Fatalf("Run() error = %v", err)
+				}
+				events = append(events, event)
+			}
+			if len(events) != 1 {
+				t.Fatalf("Run() yielded %d events, want 1", len(events))
+			}
+			event := events[0]
+			if event.Author != "root" || event.ErrorCode != agent.ErrorCodePanic || event.ErrorMessage != `panic in agent "root": broken callback` {
+				t.Errorf("event = {Author: %q, ErrorCode: %q, ErrorMessage: %q}, want the panic of root", event.Author, event.ErrorCode, event.ErrorMessage)
+			}
+			_, hasStack := event.CustomMetadata[agent.PanicStackKey]
+			if hasStack != stackTraces {
+				t.Errorf("event has a stack trace = %v, want %v", hasStack, stackTraces)
+			}
+
+			resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := resp.Session.Events().Len(); got != 2 {
+				t.Errorf("session has %d events, want the user content and the panic", got)
+			}
+		})
+	}
+}
+
+func TestRunner_ConsumerPanicIsNotRecovered(t *testing.T) {
+	ctx := t.Context()
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: &fakeLLM{}}))
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             root,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if r := recover(); r != "consumer" {
+			t.Errorf("recover() = %v, want the panic of the consumer", r)
+		}
+	}()
+	for range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{}) {
+		panic("consumer")
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestRunner_Providers(t *testing.T) {
+	type args struct{}
+	echo, err := functiontool.New(functiontool.Config{Name: "echo", Description: "echoes"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return map[string]any{"ok": true}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func() []*session.Event {
+		llm := &fakeLLM{responses: []*genai.Content{
+			{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{Name: "echo"}}}},
+		}}
+		root := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, Tools: []tool.Tool{echo}}))
+		r, err := New(Config{
+			AppName:           "testApp",
+			Agent:             root,
+			SessionService:    session.InMemoryService(),
+			AutoCreateSession: true,
+			Providers: session.Providers{
+				NewID: session.SequentialIDs("id"),
+				Now:   session.SteppingClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Second),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var events []*session.Event
+		for event, err := range r.Run(t.Context(), "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			events = append(events, event)
+		}
+		return events
+	}
+
+	first, second := run(), run()
+	if len(first) != 3 {
+		t.Fatalf("Run() yielded %d events, want 3", len(first))
+	}
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("Run() output differs between runs (-first +second):\n%s", diff)
+	}
+	if got := first[0].InvocationID; got != "e-id-1" {
+		t.Errorf("InvocationID = %q, want e-id-1", got)
+	}
+	if got := first[0].Content.Parts[0].FunctionCall.ID; !strings.HasPrefix(got, "adk-id-") {
+		t.Errorf("function call ID = %q, want a generated adk-id-N", got)
+	}
+}
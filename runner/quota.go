@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/invocationstats"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/quota"
+	"google.golang.org/adk/session"
+)
+
+// beginQuota counts the invocation against Config.Quota. It returns the event
+// replacing the invocation if it is over quota.
+func (r *Runner) beginQuota(ctx agent.InvocationContext, userID string) (*session.Event, error) {
+	err := r.quota.Begin(ctx, r.appName, userID)
+	var exceeded *quota.ExceededError
+	if !errors.As(err, &exceeded) {
+		return nil, err
+	}
+	event := session.NewEventWithContext(ctx, ctx.InvocationID())
+	event.Author = ctx.Agent().Name()
+	event.LLMResponse = model.LLMResponse{
+		ErrorCode:      quota.ErrorCode,
+		ErrorMessage:   exceeded.Error(),
+		CustomMetadata: map[string]any{quota.ExceededKey: exceeded},
+		TurnComplete:   true,
+	}
+	return event, nil
+}
+
+// recordTokens adds the tokens used by the invocation to the quota usage of
+// the app.
+func (r *Runner) recordTokens(ctx context.Context, stats *invocationstats.Stats) {
+	tokens := int64(stats.Snapshot().TotalTokens)
+	if err := r.quota.RecordTokens(context.WithoutCancel(ctx), r.appName, tokens); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "Failed to record the token usage", "error", err)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/quota"
+	"google.golang.org/adk/session"
+)
+
+func TestRunner_Quota(t *testing.T) {
+	ctx := t.Context()
+	llm := &fakeLLM{usage: &genai.GenerateContentResponseUsageMetadata{TotalTokenCount: 12}}
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: llm}))
+	enforcer := quota.New(quota.Config{Limits: quota.Limits{InvocationsPerUserPerDay: 1}})
+	sessionService := session.InMemoryService()
+	r, err := New(Config{AppName: "testApp", Agent: root, SessionService: sessionService, AutoCreateSession: true, Quota: enforcer})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func() []*session.Event {
+		var events []*session.Event
+		for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			events = append(events, event)
+		}
+		return events
+	}
+
+	if events := run(); len(events) != 1 || events[0].ErrorCode != "" {
+		t.Fatalf("first Run() = %+v, want the model response", events)
+	}
+	events := run()
+	if len(events) != 1 {
+		t.Fatalf("second Run() yielded %d events, want 1", len(events))
+	}
+	exceeded, ok := quota.ExceededFromEvent(events[0])
+	if !ok {
+		t.Fatalf("second Run() = %+v, want a quota exceeded event", events[0])
+	}
+	if exceeded.Metric != quota.Invocations || exceeded.UserID != "user" {
+		t.Errorf("exceeded = %+v, want the invocations of user", exceeded)
+	}
+	if events[0].ErrorCode != quota.ErrorCode {
+		t.Errorf("ErrorCode = %q, want %q", events[0].ErrorCode, quota.ErrorCode)
+	}
+	if len(llm.requests) != 1 {
+		t.Errorf("model called %d times, want 1", len(llm.requests))
+	}
+
+	usage, err := enforcer.Usage(ctx, "testApp", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 1 || usage[0].Used != 1 || usage[0].Remaining != 0 {
+		t.Errorf("Usage() = %+v, want 1 invocation used", usage)
+	}
+}
+
+func TestRunner_QuotaTokens(t *testing.T) {
+	ctx := t.Context()
+	llm := &fakeLLM{usage: &genai.GenerateContentResponseUsageMetadata{TotalTokenCount: 12}}
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: llm}))
+	enforcer := quota.New(quota.Config{Limits: quota.Limits{TokensPerAppPerMonth: 10}})
+	r, err := New(Config{AppName: "testApp", Agent: root, SessionService: session.InMemoryService(), AutoCreateSession: true, Quota: enforcer})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var last *session.Event
+	for range 2 {
+		for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			last = event
+		}
+	}
+	exceeded, ok := quota.ExceededFromEvent(last)
+	if !ok {
+		t.Fatalf("second Run() = %+v, want a quota exceeded event", last)
+	}
+	if exceeded.Metric != quota.Tokens || exceeded.Used != 12 {
+		t.Errorf("exceeded = %+v, want 12 tokens used", exceeded)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/convert"
+	"google.golang.org/adk/internal/utils"
+	"google.golang.org/adk/session"
+)
+
+var (
+	// ErrFunctionCallNotFound is returned by [Runner.ResumeWithFunctionResponse]
+	// if the session has no function call with the given ID.
+	ErrFunctionCallNotFound = errors.New("function call not found")
+	// ErrFunctionCallAnswered is returned by [Runner.ResumeWithFunctionResponse]
+	// if the function call with the given ID already has a response.
+	ErrFunctionCallAnswered = errors.New("function call already has a response")
+)
+
+// ResumeWithFunctionResponse resumes the invocation paused on the function
+// call with the given ID, typically a long-running tool or a tool
+// confirmation, by delivering its response.
+//
+// The function call is looked up in the session events. The invocation
+// continues with the agent that made the call and keeps the ID of the paused
+// invocation, so the LLM loop picks up where it stopped.
+func (r *Runner) ResumeWithFunctionResponse(ctx context.Context, userID, sessionID, functionCallID string, response map[string]any, cfg agent.RunConfig, opts ...RunOption) iter.Seq2[*session.Event, error] {
+	opts = append(opts, func(o *runOptions) {
+		o.resume = &resumeOptions{functionCallID: functionCallID, response: response}
+	})
+	return r.Run(ctx, userID, sessionID, nil, cfg, opts...)
+}
+
+type resumeOptions struct {
+	functionCallID string
+	response       map[string]any
+}
+
+// resumeMessage builds the user message answering the pending function call
+// and returns it together with the ID of the invocation that made the call.
+func resumeMessage(sess session.Session, opts *resumeOptions) (*genai.Content, string, error) {
+	// A long-running tool responds with an interim result when called, the
+	// call stays pending until the user delivers the final response.
+	var answered, answeredByUser bool
+	events := sess.Events()
+	for i := events.Len() - 1; i >= 0; i-- {
+		event := events.At(i)
+		for _, resp := range utils.FunctionResponses(event.Content) {
+			if resp.ID == opts.functionCallID {
+				answered = true
+				answeredByUser = answeredByUser || event.Author == "user"
+			}
+		}
+		for _, call := range utils.FunctionCalls(event.Content) {
+			if call.ID != opts.functionCallID {
+				continue
+			}
+			if answeredByUser || answered && !slices.Contains(event.LongRunningToolIDs, call.ID) {
+				return nil, "", fmt.Errorf("%w: %q", ErrFunctionCallAnswered, opts.functionCallID)
+			}
+			return convert.FunctionResponseContent(call, opts.response), event.InvocationID, nil
+		}
+	}
+	return nil, "", fmt.Errorf("%w: %q", ErrFunctionCallNotFound, opts.functionCallID)
+}
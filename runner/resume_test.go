@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestRunner_ResumeWithFunctionResponse(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	approvalTool, err := functiontool.New(functiontool.Config{Name: "approve", Description: "asks for approval", IsLongRunning: true}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return map[string]any{"status": "pending"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	llm := &fakeLLM{responses: []*genai.Content{
+		{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call-0", Name: "transfer_to_agent", Args: map[string]any{"agent_name": "approver"}}}}},
+		{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "approve"}}}},
+		genai.NewContentFromText("waiting for approval", genai.RoleModel),
+	}}
+	sub := must(llmagent.New(llmagent.Config{Name: "approver", Model: llm, Tools: []tool.Tool{approvalTool}}))
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, SubAgents: []agent.Agent{sub}}))
+
+	sessionService := session.InMemoryService()
+	r, err := New(Config{AppName: "testApp", Agent: root, SessionService: sessionService, AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var invocationID string
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("do it", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		invocationID = event.InvocationID
+	}
+
+	var events []*session.Event
+	for event, err := range r.ResumeWithFunctionResponse(ctx, "user", "session", "call-1", map[string]any{"approved": true}, agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("ResumeWithFunctionResponse() error = %v", err)
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		t.Fatal("ResumeWithFunctionResponse() yielded no events")
+	}
+	for _, event := range events {
+		if event.InvocationID != invocationID || event.Author != "approver" {
+			t.Errorf("event invocation = %q, author = %q, want %q, %q", event.InvocationID, event.Author, invocationID, "approver")
+		}
+	}
+
+	lastRequest := llm.requests[len(llm.requests)-1]
+	lastContent := lastRequest.Contents[len(lastRequest.Contents)-1]
+	want := &genai.FunctionResponse{ID: "call-1", Name: "approve", Response: map[string]any{"approved": true}}
+	if diff := cmp.Diff(want, lastContent.Parts[0].FunctionResponse); diff != "" {
+		t.Errorf("model request function response mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name    string
+		callID  string
+		wantErr error
+	}{
+		{name: "unknown call", callID: "call-2", wantErr: ErrFunctionCallNotFound},
+		{name: "not long-running call", callID: "call-0", wantErr: ErrFunctionCallAnswered},
+		{name: "answered call", callID: "call-1", wantErr: ErrFunctionCallAnswered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotErr error
+			for _, err := range r.ResumeWithFunctionResponse(ctx, "user", "session", tt.callID, nil, agent.RunConfig{}) {
+				gotErr = errors.Join(gotErr, err)
+			}
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("ResumeWithFunctionResponse() error = %v, want %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
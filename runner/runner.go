@@ -17,26 +17,39 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"iter"
-	"log"
+	"log/slog"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/genai"
 
+	"google.golang.org/adk/admission"
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/auth"
+	agentinternal "google.golang.org/adk/internal/agent"
 	"google.golang.org/adk/internal/agent/parentmap"
 	"google.golang.org/adk/internal/agent/runconfig"
 	artifactinternal "google.golang.org/adk/internal/artifact"
+	"google.golang.org/adk/internal/authinternal"
 	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/invocationstats"
 	"google.golang.org/adk/internal/llminternal"
+	"google.golang.org/adk/internal/logging"
 	imemory "google.golang.org/adk/internal/memory"
 	"google.golang.org/adk/internal/plugininternal"
+	"google.golang.org/adk/internal/stagetimeout"
+	"google.golang.org/adk/internal/telemetry"
 	"google.golang.org/adk/internal/utils"
 	"google.golang.org/adk/memory"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/quota"
+	"google.golang.org/adk/redaction"
 	"google.golang.org/adk/session"
 )
 
@@ -53,10 +66,127 @@ type Config struct {
 	MemoryService memory.Service
 	// optional
 	PluginConfig PluginConfig
+	// AutoCreateSession makes the runner create the session on the first
+	// Run call if it does not exist yet in the SessionService.
+	// optional
+	AutoCreateSession bool
+	// SessionLocker serializes concurrent Run calls on the same session.
+	// Defaults to a lock within the process.
+	// optional
+	SessionLocker SessionLocker
+	// InstanceID identifies the process of the runner, e.g. a replica of a
+	// launcher behind a load balancer. It is set on the events appended to
+	// the session, under InstanceIDKey of their CustomMetadata, and on the
+	// JobInfo of the jobs. Runners in multiple processes sharing the same
+	// session and artifact services also need a distributed SessionLocker.
+	// optional
+	InstanceID string
+	// FailFastOnBusySession makes Run fail with a *SessionBusyError instead
+	// of waiting when another invocation is running on the same session.
+	// optional
+	FailFastOnBusySession bool
+	// EventSinks receive a copy of every event yielded by Run.
+	// optional
+	EventSinks []EventSink
+	// EmitInvocationSummary makes Run yield a final event per invocation
+	// with an InvocationSummary in its CustomMetadata.
+	// optional
+	EmitInvocationSummary bool
+	// Checkpoints makes Run append a checkpoint event to the session when an
+	// invocation starts, after every function call and response and when it
+	// ends, see Checkpoint. An invocation interrupted by a restart can then be
+	// resumed with ResumeInterrupted instead of losing the turn.
+	// optional
+	Checkpoints bool
+	// StateMerge resolves writes to the same state key by parallel branches
+	// of an invocation. If nil, the last write wins.
+	// optional
+	StateMerge *StateMergeConfig
+	// TracerProvider creates the spans of the invocations, agent runs, model
+	// calls and tool calls. Defaults to the global tracer provider, see the
+	// telemetry package.
+	// optional
+	TracerProvider trace.TracerProvider
+	// RedactToolData returns the tool arguments and responses recorded in
+	// the spans. By default they are recorded as is.
+	// optional
+	RedactToolData func(toolName string, data map[string]any) map[string]any
+	// Logger writes the logs of the invocations, with the invocation ID,
+	// session ID, agent name and tool name attached to every line.
+	// Defaults to slog.Default().
+	// optional
+	Logger *slog.Logger
+	// RedactPrompt returns the prompt text written to the debug logs. By
+	// default it is logged as is.
+	// optional
+	RedactPrompt func(text string) string
+	// CredentialStore stores the credentials obtained by the tools with
+	// tool.Context.RequestCredential, per user. Defaults to a store within
+	// the process.
+	// optional
+	CredentialStore auth.Store
+	// CredentialExchanger exchanges and refreshes the credentials of the
+	// tools. Defaults to auth.OAuth2Exchanger.
+	// optional
+	CredentialExchanger auth.Exchanger
+	// Redactor redacts the personal data of the events appended to the
+	// session service and added to the memory service, and of the telemetry:
+	// the logged model contents, and the tool data and prompts unless
+	// RedactToolData and RedactPrompt are set.
+	// optional
+	Redactor *redaction.Redactor
+	// Quota limits the invocations of the users and the tokens of the app.
+	// The invocations over quota are not run, Run yields a single event with
+	// the quota.ExceededError instead.
+	// optional
+	Quota *quota.Enforcer
+	// Admission limits the concurrent invocations, queueing them by
+	// priority, see WithPriority. The invocations not admitted fail with an
+	// *admission.RejectedError.
+	// optional
+	Admission *admission.Controller
+	// Providers generate the IDs of the invocations, events and sessions,
+	// and the timestamps of the events. Tests and replays set deterministic
+	// providers to get stable output. Defaults to random UUIDs and the
+	// current time.
+	// optional
+	Providers session.Providers
+	// InputTransformers rewrite in order the user content of Run before the
+	// plugins and the agents see it and it is appended to the session, e.g.
+	// to normalize its text or strip the metadata of its images.
+	// optional
+	InputTransformers []ContentTransformer
+	// OutputTransformers rewrite in order the content of the final
+	// responses of the agents, see session.Event.IsFinalResponse, before
+	// they are appended to the session and yielded, e.g. to apply a template
+	// or to convert them to the format of a channel.
+	// optional
+	OutputTransformers []ContentTransformer
+	// EventBatch makes Run buffer the events of the invocations and append
+	// them to the SessionService in batches, see EventBatchConfig. By
+	// default, every event is appended as soon as it is yielded.
+	// optional
+	EventBatch *EventBatchConfig
+	// Timeouts limits the duration of the model calls, tool calls, session
+	// operations and MCP pings of the invocations, see Timeouts.
+	// optional
+	Timeouts Timeouts
+	// PanicStackTraces attaches the stack traces of the recovered panics of
+	// agents, callbacks and tools to the events and function responses
+	// reporting them, see agent.PanicError. It is meant for debugging: the
+	// stack traces are visible to the model and to the clients.
+	// optional
+	PanicStackTraces bool
 }
 
+// PluginConfig configures the plugins installed on a [Runner].
 type PluginConfig struct {
-	Plugins      []*plugin.Plugin
+	// Plugins are invoked for every invocation handled by the runner, in the
+	// given order. Plugin callbacks run before the callbacks of the agents,
+	// models and tools.
+	Plugins []*plugin.Plugin
+	// CloseTimeout limits how long [Runner.Close] waits for the plugins to
+	// close. Zero means no limit.
 	CloseTimeout time.Duration
 }
 
@@ -64,6 +194,14 @@ type RunOption func(*runOptions)
 
 type runOptions struct {
 	stateDelta map[string]any
+	// resume is set by Runner.ResumeWithFunctionResponse.
+	resume *resumeOptions
+	// resumeInterrupted is set by Runner.ResumeInterrupted.
+	resumeInterrupted bool
+	// live is set by Runner.RunLive.
+	live *agent.LiveRequestQueue
+	// priority is set by WithPriority.
+	priority *int
 }
 
 // WithStateDelta sets a state delta for the run invocation.
@@ -73,6 +211,15 @@ func WithStateDelta(delta map[string]any) RunOption {
 	}
 }
 
+// WithPriority sets the priority of the invocation in the queue of
+// Config.Admission, higher runs first. It overrides the priority of the
+// user given by the admission controller.
+func WithPriority(priority int) RunOption {
+	return func(o *runOptions) {
+		o.priority = &priority
+	}
+}
+
 // New creates a new [Runner].
 func New(cfg Config) (*Runner, error) {
 	if cfg.Agent == nil {
@@ -96,14 +243,76 @@ func New(cfg Config) (*Runner, error) {
 		return nil, fmt.Errorf("failed to create plugin manager: %w", err)
 	}
 
+	sessionLocker := cfg.SessionLocker
+	if sessionLocker == nil {
+		sessionLocker = NewInMemorySessionLocker()
+	}
+
+	credentialStore := cfg.CredentialStore
+	if credentialStore == nil {
+		credentialStore = auth.NewInMemoryStore()
+	}
+
+	sessionService, memoryService := cfg.SessionService, cfg.MemoryService
+	if cfg.InstanceID != "" {
+		sessionService = instanceTaggingService{Service: sessionService, instanceID: cfg.InstanceID}
+	}
+	redactToolData, redactPrompt := cfg.RedactToolData, cfg.RedactPrompt
+	var redactContent func(*genai.Content) *genai.Content
+	if cfg.Redactor != nil {
+		sessionService = redaction.SessionService(sessionService, cfg.Redactor)
+		if memoryService != nil {
+			memoryService = redaction.MemoryService(memoryService, cfg.Redactor)
+		}
+		if redactToolData == nil {
+			redactToolData = cfg.Redactor.ToolData()
+		}
+		if redactPrompt == nil {
+			redactPrompt = cfg.Redactor.Prompt()
+		}
+		redactContent = cfg.Redactor.ContentFunc()
+	}
+
+	if cfg.Timeouts.SessionIO > 0 {
+		sessionService = timeoutSessionService{Service: sessionService, timeout: cfg.Timeouts.SessionIO}
+	}
+
 	return &Runner{
 		appName:         cfg.AppName,
 		rootAgent:       cfg.Agent,
-		sessionService:  cfg.SessionService,
+		sessionService:  instrumentedSessionService{sessionService},
+		instanceID:      cfg.InstanceID,
 		artifactService: cfg.ArtifactService,
-		memoryService:   cfg.MemoryService,
+		memoryService:   memoryService,
 		parents:         parents,
 		pluginManager:   pluginManager,
+
+		autoCreateSession:     cfg.AutoCreateSession,
+		sessionLocker:         sessionLocker,
+		failFastOnBusySession: cfg.FailFastOnBusySession,
+		eventSinks:            cfg.EventSinks,
+		emitInvocationSummary: cfg.EmitInvocationSummary,
+		checkpoints:           cfg.Checkpoints,
+		stateMerge:            cfg.StateMerge,
+		eventBatch:            cfg.EventBatch,
+		logger:                cfg.Logger,
+		redactPrompt:          redactPrompt,
+		quota:                 cfg.Quota,
+		admission:             cfg.Admission,
+		providers:             cfg.Providers,
+		inputTransformers:     cfg.InputTransformers,
+		outputTransformers:    cfg.OutputTransformers,
+		timeouts:              cfg.Timeouts.config(),
+		panicStackTraces:      cfg.PanicStackTraces,
+		tracing: telemetry.Config{
+			TracerProvider: cfg.TracerProvider,
+			Redact:         redactToolData,
+			RedactContent:  redactContent,
+		},
+		auth: authinternal.Config{
+			Store:     credentialStore,
+			Exchanger: cfg.CredentialExchanger,
+		},
 	}, nil
 }
 
@@ -116,45 +325,152 @@ type Runner struct {
 	sessionService  session.Service
 	artifactService artifact.Service
 	memoryService   memory.Service
+	instanceID      string
 
 	parents       parentmap.Map
 	pluginManager *plugininternal.PluginManager
+
+	autoCreateSession     bool
+	sessionLocker         SessionLocker
+	failFastOnBusySession bool
+	eventSinks            []EventSink
+	emitInvocationSummary bool
+	checkpoints           bool
+	stateMerge            *StateMergeConfig
+	eventBatch            *EventBatchConfig
+	tracing               telemetry.Config
+	logger                *slog.Logger
+	redactPrompt          func(text string) string
+	auth                  authinternal.Config
+	quota                 *quota.Enforcer
+	admission             *admission.Controller
+	providers             session.Providers
+	inputTransformers     []ContentTransformer
+	outputTransformers    []ContentTransformer
+	timeouts              stagetimeout.Config
+	panicStackTraces      bool
 }
 
 // Run runs the agent for the given user input, yielding events from agents.
 // For each user message it finds the proper agent within an agent tree to
 // continue the conversation within the session.
+//
+// Events are also sent to Config.EventSinks.
+//
+// Run is safe for concurrent use. Invocations on the same session are
+// serialized: a Run call waits for the running invocation to finish, or fails
+// with a *SessionBusyError if Config.FailFastOnBusySession is set.
 func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.Content, cfg agent.RunConfig, opts ...RunOption) iter.Seq2[*session.Event, error] {
-	// TODO(hakim): we need to validate whether cfg is compatible with the Agent.
-	//   see adk-python/src/google/adk/runners.py Runner._new_invocation_context.
-	// TODO: setup tracer.
+	return teeEvents(ctx, r.run(ctx, userID, sessionID, msg, cfg, opts...), r.eventSinks)
+}
+
+func (r *Runner) run(ctx context.Context, userID, sessionID string, msg *genai.Content, cfg agent.RunConfig, opts ...RunOption) iter.Seq2[*session.Event, error] {
 	return func(yield func(*session.Event, error) bool) {
 		options := runOptions{}
 		for _, opt := range opts {
 			opt(&options)
 		}
+		ctx = r.withProviders(ctx)
 
-		resp, err := r.sessionService.Get(ctx, &session.GetRequest{
-			AppName:   r.appName,
-			UserID:    userID,
-			SessionID: sessionID,
-		})
+		if r.admission != nil {
+			release, err := r.acquire(ctx, userID, options.priority)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			defer release()
+		}
+
+		unlock, err := r.lockSession(ctx, userID, sessionID)
 		if err != nil {
 			yield(nil, err)
 			return
 		}
+		defer unlock()
 
-		storedSession := resp.Session
+		storedSession, err := r.getOrCreateSession(ctx, userID, sessionID)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		// A dry run works on an in-memory copy of the session, so that the
+		// user message, the state changes and the events are not persisted.
+		sessionService := r.sessionService
+		if cfg.DryRun {
+			sessionService, storedSession, err = dryRunSession(ctx, storedSession)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
 
-		agentToRun, err := r.findAgentToRun(storedSession, msg)
+		ctx = r.loggingContext(ctx, userID, sessionID)
+		ctx = telemetry.ToContext(ctx, r.tracing)
+		ctx = authinternal.ToContext(ctx, r.auth)
+		ctx = stagetimeout.ToContext(ctx, r.timeouts)
+		if r.panicStackTraces {
+			ctx = agentinternal.WithPanicStackTraces(ctx)
+		}
+		var span trace.Span
+		ctx, span = telemetry.StartInvocationSpan(ctx, r.appName, userID, sessionID)
+		defer span.End()
+
+		var invocationID string
+		if options.resume != nil {
+			msg, invocationID, err = resumeMessage(storedSession, options.resume)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+		var interrupted *InterruptedInvocation
+		if options.resumeInterrupted {
+			msg, interrupted, err = interruptedMessage(storedSession)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			invocationID = interrupted.InvocationID
+		}
+
+		agentToRun, err := r.findAgentToRun(ctx, storedSession, msg)
 		if err != nil {
 			yield(nil, err)
 			return
 		}
 
+		if err := r.validateRunConfig(&cfg, agentToRun, options.live != nil); err != nil {
+			yield(nil, err)
+			return
+		}
+		telemetry.RecordInvocation(ctx, r.appName, agentToRun.Name())
+
+		// The invocation can be cancelled by agents, tools, callbacks and
+		// plugins with agent.CancelInvocation.
+		var cancel context.CancelCauseFunc
+		ctx, cancel = agentinternal.WithCancel(ctx)
+		defer cancel(nil)
+		if cfg.Timeout > 0 {
+			var cancelTimeout context.CancelFunc
+			ctx, cancelTimeout = context.WithTimeoutCause(ctx, cfg.Timeout, agent.ErrInvocationTimeout)
+			defer cancelTimeout()
+		}
+
+		stats := &invocationstats.Stats{}
+		ctx = invocationstats.ToContext(ctx, stats)
+		var summary *summaryTracker
+		if r.emitInvocationSummary {
+			summary = newSummaryTracker(stats)
+		}
+
 		ctx = parentmap.ToContext(ctx, r.parents)
 		ctx = runconfig.ToContext(ctx, &runconfig.RunConfig{
 			StreamingMode: runconfig.StreamingMode(cfg.StreamingMode),
+			DryRun:        cfg.DryRun,
+			MaxLLMCalls:   cfg.MaxLLMCalls,
+
+			LiveRequestQueue:  options.live,
+			LiveConnectConfig: liveConnectConfig(&cfg),
 		})
 		ctx = plugininternal.ToContext(ctx, r.pluginManager)
 
@@ -178,15 +494,45 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 			}
 		}
 
+		// With batching, the agents see the buffered events in the session.
+		invocationSession := storedSession
+		var batcher *eventBatcher
+		if r.eventBatch != nil {
+			batcher = newEventBatcher(r.eventBatch, sessionService, storedSession)
+			invocationSession = batcher.session()
+		}
+
 		ctx := icontext.NewInvocationContext(ctx, icontext.InvocationContextParams{
-			Artifacts:   artifacts,
-			Memory:      memoryImpl,
-			Session:     storedSession,
-			Agent:       agentToRun,
-			UserContent: msg,
-			RunConfig:   &cfg,
+			Artifacts:    artifacts,
+			Memory:       memoryImpl,
+			Session:      invocationSession,
+			Agent:        agentToRun,
+			UserContent:  msg,
+			RunConfig:    &cfg,
+			InvocationID: invocationID,
 		})
-		ctx, err = r.appendMessageToSession(ctx, storedSession, msg, cfg.SaveInputBlobsAsArtifacts, r.pluginManager, options.stateDelta)
+		telemetry.TraceInvocationID(span, ctx.InvocationID())
+		updateRegistry, unregister := registry.register(ctx, cancel)
+		defer unregister()
+		ctx = ctx.WithContext(logging.With(ctx, logging.InvocationIDKey, ctx.InvocationID()))
+		logger := logging.FromContext(ctx)
+		logger.DebugContext(ctx, "Invocation started", logging.AgentNameKey, agentToRun.Name())
+		defer logger.DebugContext(ctx, "Invocation finished")
+		if r.quota != nil {
+			// A resumed invocation was counted when it started.
+			if options.resume == nil && interrupted == nil {
+				if event, err := r.beginQuota(ctx, userID); event != nil || err != nil {
+					if err == nil {
+						err = sessionService.AppendEvent(ctx, storedSession, event)
+					}
+					yield(event, err)
+					return
+				}
+			}
+			defer r.recordTokens(ctx, stats)
+		}
+		// The blobs are not saved in a dry run, which has no side effects.
+		ctx, err = r.appendMessageToSession(ctx, sessionService, storedSession, msg, cfg.SaveInputBlobsAsArtifacts && !cfg.DryRun, r.pluginManager, options.stateDelta)
 		if err != nil {
 			yield(nil, err)
 			return
@@ -200,12 +546,12 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 
 			earlyExitResult, err := pluginManager.RunBeforeRunCallback(ctx)
 			if earlyExitResult != nil || err != nil {
-				earlyExitEvent := session.NewEvent(ctx.InvocationID())
+				earlyExitEvent := session.NewEventWithContext(ctx, ctx.InvocationID())
 				earlyExitEvent.Author = "user"
 				earlyExitEvent.LLMResponse = model.LLMResponse{
 					Content: msg,
 				}
-				if err := r.sessionService.AppendEvent(ctx, storedSession, earlyExitEvent); err != nil {
+				if err := sessionService.AppendEvent(ctx, storedSession, earlyExitEvent); err != nil {
 					yield(nil, fmt.Errorf("failed to add event to session: %w", err))
 					return
 				}
@@ -214,8 +560,56 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 			}
 		}
 
+		// Events produced before the cancellation are still persisted.
+		persistCtx := context.WithoutCancel(ctx)
+		appendEvent := func(event *session.Event) error {
+			if batcher != nil {
+				return batcher.add(persistCtx, event)
+			}
+			return sessionService.AppendEvent(persistCtx, storedSession, event)
+		}
+		if batcher != nil {
+			// The batch is flushed below when the invocation completes, this
+			// only persists the buffered events when it stops early.
+			defer func() {
+				if err := batcher.flush(persistCtx); err != nil {
+					logger.WarnContext(ctx, "Failed to flush the buffered events", "error", err)
+				}
+			}()
+		}
+		var checkpoints *checkpointer
+		if r.checkpoints || interrupted != nil {
+			checkpoints = newCheckpointer(agentToRun.Name(), interrupted)
+			if interrupted != nil && interrupted.Answered {
+				// Nothing left to run.
+				if err := sessionService.AppendEvent(persistCtx, storedSession, checkpoints.newEvent(ctx, CheckpointDone)); err != nil {
+					yield(nil, fmt.Errorf("failed to add event to session: %w", err))
+				}
+				return
+			}
+			if err := sessionService.AppendEvent(persistCtx, storedSession, checkpoints.newEvent(ctx, CheckpointRunning)); err != nil {
+				yield(nil, fmt.Errorf("failed to add event to session: %w", err))
+				return
+			}
+		}
+		// Partial events since the last complete event, kept to persist the
+		// partial results if the invocation is cancelled mid-stream.
+		var partials []*session.Event
+		var stateMerger *stateMerger
+		if r.stateMerge != nil {
+			stateMerger = newStateMerger(r.stateMerge)
+		}
+		var outputs *outputSaver
+		if cfg.SaveOutputBlobsAsArtifacts && !cfg.DryRun {
+			outputs = &outputSaver{}
+		}
 		for event, err := range agentToRun.Run(ctx) {
 			if err != nil {
+				// Errors after the cancellation are caused by it, they are
+				// reported with the cancellation event below.
+				if agent.IsInvocationCancelled(ctx) {
+					continue
+				}
 				if !yield(event, err) {
 					return
 				}
@@ -235,25 +629,317 @@ func (r *Runner) Run(ctx context.Context, userID, sessionID string, msg *genai.C
 				}
 			}
 
+			if len(r.outputTransformers) > 0 && !event.Partial && event.Content != nil && event.IsFinalResponse() {
+				content, err := transform(icontext.NewReadonlyContext(ctx), r.outputTransformers, event.Content)
+				if err != nil {
+					if !yield(nil, fmt.Errorf("failed to transform the final response: %w", err)) {
+						return
+					}
+					continue
+				}
+				event.Content = content
+			}
+
+			if outputs != nil {
+				if err := outputs.save(persistCtx, ctx.Artifacts(), event); err != nil {
+					if !yield(nil, err) {
+						return
+					}
+					continue
+				}
+			}
+
+			if summary != nil {
+				summary.trackEvent(event)
+			}
+			updateRegistry(event)
+
+			if event.LLMResponse.Partial {
+				partials = append(partials, event)
+			} else {
+				partials = nil
+			}
+
 			// only commit non-partial event to a session service
-			if !event.LLMResponse.Partial {
-				if err := r.sessionService.AppendEvent(ctx, storedSession, event); err != nil {
+			if !event.LLMResponse.Partial && !(options.live != nil && isLiveSignalEvent(event)) {
+				if stateMerger != nil {
+					if err := stateMerger.merge(event); err != nil {
+						if !yield(nil, err) {
+							return
+						}
+					}
+				}
+				if err := appendEvent(event); err != nil {
 					yield(nil, fmt.Errorf("failed to add event to session: %w", err))
 					return
 				}
+				if checkpoints != nil && checkpoints.trackEvent(event) {
+					if err := appendEvent(checkpoints.newEvent(ctx, CheckpointRunning)); err != nil {
+						yield(nil, fmt.Errorf("failed to add event to session: %w", err))
+						return
+					}
+				}
 			}
 
 			if !yield(event, nil) {
 				return
 			}
 		}
+
+		var finalEvents []*session.Event
+		if agent.IsInvocationCancelled(ctx) {
+			finalEvents = append(finalEvents, newPartialResultsEvent(ctx, partials), newCancellationEvent(ctx))
+		}
+		// The checkpoint is not yielded, it only marks the invocation as done
+		// in the session.
+		var done *session.Event
+		if checkpoints != nil {
+			done = checkpoints.newEvent(ctx, CheckpointDone)
+			finalEvents = append(finalEvents, done)
+		}
+		if summary != nil {
+			finalEvents = append(finalEvents, summary.newEvent(ctx))
+		}
+		for _, event := range finalEvents {
+			if event == nil {
+				continue
+			}
+			if err := appendEvent(event); err != nil {
+				yield(nil, fmt.Errorf("failed to add event to session: %w", err))
+				return
+			}
+			if event == done {
+				continue
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+		if batcher != nil {
+			if err := batcher.flush(persistCtx); err != nil {
+				yield(nil, fmt.Errorf("failed to add event to session: %w", err))
+			}
+		}
+	}
+}
+
+// withProviders returns ctx carrying Config.Providers, if set.
+func (r *Runner) withProviders(ctx context.Context) context.Context {
+	if r.providers.NewID == nil && r.providers.Now == nil {
+		return ctx
+	}
+	return session.WithProviders(ctx, r.providers)
+}
+
+// newPartialResultsEvent merges the text of the partial events streamed
+// before the invocation was cancelled into a single complete event.
+// It returns nil if there is no partial text.
+func newPartialResultsEvent(ctx agent.InvocationContext, partials []*session.Event) *session.Event {
+	var text strings.Builder
+	for _, partial := range partials {
+		if partial.Content == nil {
+			continue
+		}
+		for _, part := range partial.Content.Parts {
+			if !part.Thought {
+				text.WriteString(part.Text)
+			}
+		}
+	}
+	if text.Len() == 0 {
+		return nil
+	}
+
+	last := partials[len(partials)-1]
+	event := session.NewEventWithContext(ctx, ctx.InvocationID())
+	event.Author = last.Author
+	event.Branch = last.Branch
+	event.LLMResponse = model.LLMResponse{
+		Content:     genai.NewContentFromText(text.String(), genai.RoleModel),
+		Interrupted: true,
+	}
+	return event
+}
+
+// newCancellationEvent creates the final event of an invocation cancelled
+// with agent.CancelInvocation. The event carries the cancellation reason.
+func newCancellationEvent(ctx agent.InvocationContext) *session.Event {
+	event := session.NewEventWithContext(ctx, ctx.InvocationID())
+	event.Author = ctx.Agent().Name()
+	cause := context.Cause(ctx)
+	errorCode := ErrorCodeCancelled
+	if errors.Is(cause, agent.ErrInvocationTimeout) {
+		errorCode = ErrorCodeTimeout
+	}
+	event.LLMResponse = model.LLMResponse{
+		ErrorCode:    errorCode,
+		ErrorMessage: cause.Error(),
+		TurnComplete: true,
+	}
+	return event
+}
+
+// Error codes of the final event of a cancelled invocation.
+const (
+	// ErrorCodeCancelled is used when the invocation was cancelled with
+	// agent.CancelInvocation.
+	ErrorCodeCancelled = "CANCELLED"
+	// ErrorCodeTimeout is used when the invocation exceeded
+	// agent.RunConfig.Timeout.
+	ErrorCodeTimeout = "DEADLINE_EXCEEDED"
+)
+
+// RunConfigError is returned by [Runner.Run] and [Runner.RunLive] when the
+// agent.RunConfig is invalid or not supported by the runner or the agent
+// handling the invocation. It is returned before the invocation starts and
+// lists all the problems found.
+type RunConfigError struct {
+	// Agent is the name of the agent that was to handle the invocation.
+	Agent string
+	// Problems are the problems found, at least one.
+	Problems []error
+}
+
+func (e *RunConfigError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		problems[i] = p.Error()
+	}
+	return fmt.Sprintf("invalid run config for agent %q: %s", e.Agent, strings.Join(problems, "; "))
+}
+
+func (e *RunConfigError) Unwrap() []error {
+	return e.Problems
+}
+
+// validateRunConfig checks whether cfg is compatible with the runner setup
+// and the agent that is going to handle the invocation. live tells whether
+// the invocation was started by RunLive.
+func (r *Runner) validateRunConfig(cfg *agent.RunConfig, agentToRun agent.Agent, live bool) error {
+	var problems []error
+	switch cfg.StreamingMode {
+	case "", agent.StreamingModeNone, agent.StreamingModeSSE:
+	case agent.StreamingModeBidi:
+		if !live {
+			problems = append(problems, errors.New("the bidi streaming mode is only supported by RunLive"))
+		}
+	default:
+		problems = append(problems, fmt.Errorf("unsupported streaming mode %q", cfg.StreamingMode))
+	}
+	if cfg.MaxLLMCalls < 0 {
+		problems = append(problems, fmt.Errorf("MaxLLMCalls must not be negative, got %d", cfg.MaxLLMCalls))
+	}
+	if cfg.Timeout < 0 {
+		problems = append(problems, fmt.Errorf("Timeout must not be negative, got %v", cfg.Timeout))
+	}
+	for _, modality := range cfg.ResponseModalities {
+		switch modality {
+		case genai.ModalityText, genai.ModalityAudio, genai.ModalityImage:
+		default:
+			problems = append(problems, fmt.Errorf("unsupported response modality %q", modality))
+		}
+	}
+	if len(cfg.ResponseModalities) > 1 && live {
+		problems = append(problems, fmt.Errorf("live connections support a single response modality, got %v", cfg.ResponseModalities))
+	}
+	if _, ok := model.LookupProfile(cfg.GenerationProfile); cfg.GenerationProfile != "" && !ok {
+		problems = append(problems, fmt.Errorf("unknown generation profile %q", cfg.GenerationProfile))
+	}
+	if cfg.SaveInputBlobsAsArtifacts && r.artifactService == nil {
+		problems = append(problems, errors.New("SaveInputBlobsAsArtifacts requires the runner to be configured with an ArtifactService"))
+	}
+	if cfg.SaveOutputBlobsAsArtifacts && r.artifactService == nil {
+		problems = append(problems, errors.New("SaveOutputBlobsAsArtifacts requires the runner to be configured with an ArtifactService"))
+	}
+
+	if llmAgent, ok := agentToRun.(llminternal.Agent); ok {
+		state := llminternal.Reveal(llmAgent)
+		m := state.Model
+		_, liveModel := m.(model.LiveLLM)
+		switch {
+		case m == nil:
+			problems = append(problems, llminternal.ErrModelNotConfigured)
+		case live && !liveModel && cfg.SpeechToText == nil && cfg.TextToSpeech == nil:
+			problems = append(problems, fmt.Errorf("model %q does not support live connections, see RunConfig.SpeechToText and RunConfig.TextToSpeech", m.Name()))
+		}
+		if _, ok := model.LookupProfile(state.GenerationProfile); state.GenerationProfile != "" && !ok {
+			problems = append(problems, fmt.Errorf("unknown generation profile %q of the agent", state.GenerationProfile))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &RunConfigError{Agent: agentToRun.Name(), Problems: problems}
+	}
+	return nil
+}
+
+// acquire waits for Config.Admission to admit the invocation.
+func (r *Runner) acquire(ctx context.Context, userID string, priority *int) (func(), error) {
+	if priority != nil {
+		return r.admission.Acquire(ctx, *priority)
+	}
+	return r.admission.Acquire(ctx, r.admission.Priority(ctx, r.appName, userID))
+}
+
+func (r *Runner) lockSession(ctx context.Context, userID, sessionID string) (func(), error) {
+	key := SessionKey{AppName: r.appName, UserID: userID, SessionID: sessionID}
+	if r.failFastOnBusySession {
+		return r.sessionLocker.TryLock(ctx, key)
+	}
+	unlock, err := r.sessionLocker.Lock(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock session %q: %w", sessionID, err)
+	}
+	return unlock, nil
+}
+
+// getOrCreateSession loads the session from the session service. If the
+// session does not exist and the runner is configured to auto create
+// sessions, a new session with the given ID is created.
+func (r *Runner) getOrCreateSession(ctx context.Context, userID, sessionID string) (session.Session, error) {
+	resp, err := r.sessionService.Get(ctx, &session.GetRequest{
+		AppName:   r.appName,
+		UserID:    userID,
+		SessionID: sessionID,
+	})
+	if err == nil {
+		// A session of another user would expose its user-scoped state.
+		if err := session.CheckScope(resp.Session, r.appName, userID, sessionID); err != nil {
+			return nil, err
+		}
+		return resp.Session, nil
 	}
+	if !r.autoCreateSession || !errors.Is(err, session.ErrSessionNotFound) {
+		return nil, err
+	}
+
+	createResp, err := r.sessionService.Create(ctx, &session.CreateRequest{
+		AppName:   r.appName,
+		UserID:    userID,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	if err := session.CheckScope(createResp.Session, r.appName, userID, sessionID); err != nil {
+		return nil, err
+	}
+	return createResp.Session, nil
 }
 
-func (r *Runner) appendMessageToSession(ctx agent.InvocationContext, storedSession session.Session, msg *genai.Content, saveInputBlobsAsArtifacts bool, pluginManager *plugininternal.PluginManager, stateDelta map[string]any) (agent.InvocationContext, error) {
+// Close releases the resources held by the runner, closing all its plugins.
+func (r *Runner) Close() error {
+	return r.pluginManager.Close()
+}
+
+func (r *Runner) appendMessageToSession(ctx agent.InvocationContext, sessionService session.Service, storedSession session.Session, msg *genai.Content, saveInputBlobsAsArtifacts bool, pluginManager *plugininternal.PluginManager, stateDelta map[string]any) (agent.InvocationContext, error) {
 	if msg == nil {
 		return ctx, nil
 	}
+	msg, err := transform(icontext.NewReadonlyContext(ctx), r.inputTransformers, msg)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to transform the user message: %w", err)
+	}
 	if pluginManager != nil {
 		modifiedMsg, err := pluginManager.RunOnUserMessageCallback(ctx, msg)
 		if err != nil {
@@ -261,18 +947,21 @@ func (r *Runner) appendMessageToSession(ctx agent.InvocationContext, storedSessi
 		}
 		if modifiedMsg != nil {
 			msg = modifiedMsg
-			// update ctx user message
-			ctx = icontext.NewInvocationContext(ctx, icontext.InvocationContextParams{
-				Artifacts:    ctx.Artifacts(),
-				Memory:       ctx.Memory(),
-				Session:      ctx.Session(),
-				Agent:        ctx.Agent(),
-				UserContent:  msg,
-				RunConfig:    ctx.RunConfig(),
-				InvocationID: ctx.InvocationID(),
-			})
 		}
 	}
+	if msg != ctx.UserContent() {
+		// update ctx user message
+		ctx = icontext.NewInvocationContext(ctx, icontext.InvocationContextParams{
+			Artifacts:          ctx.Artifacts(),
+			Memory:             ctx.Memory(),
+			Session:            ctx.Session(),
+			Agent:              ctx.Agent(),
+			UserContent:        msg,
+			RunConfig:          ctx.RunConfig(),
+			InvocationID:       ctx.InvocationID(),
+			TranscriptionCache: ctx.TranscriptionCache(),
+		})
+	}
 
 	artifactsService := ctx.Artifacts()
 	if artifactsService != nil && saveInputBlobsAsArtifacts {
@@ -291,7 +980,7 @@ func (r *Runner) appendMessageToSession(ctx agent.InvocationContext, storedSessi
 		}
 	}
 
-	event := session.NewEvent(ctx.InvocationID())
+	event := session.NewEventWithContext(ctx, ctx.InvocationID())
 
 	event.Author = "user"
 	event.LLMResponse = model.LLMResponse{
@@ -301,21 +990,38 @@ func (r *Runner) appendMessageToSession(ctx agent.InvocationContext, storedSessi
 		event.Actions.StateDelta = stateDelta
 	}
 
-	if err := r.sessionService.AppendEvent(ctx, storedSession, event); err != nil {
+	if err := sessionService.AppendEvent(ctx, storedSession, event); err != nil {
 		return ctx, fmt.Errorf("failed to append event to sessionService: %w", err)
 	}
 	return ctx, nil
 }
 
+// loggingContext returns a context carrying the logger of the invocation.
+func (r *Runner) loggingContext(ctx context.Context, userID, sessionID string) context.Context {
+	logger := r.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	ctx = logging.ToContext(ctx, logger.With(
+		logging.AppNameKey, r.appName,
+		logging.UserIDKey, userID,
+		logging.SessionIDKey, sessionID,
+	))
+	if r.redactPrompt != nil {
+		ctx = logging.WithPromptRedaction(ctx, r.redactPrompt)
+	}
+	return ctx
+}
+
 // findAgentToRun returns the agent that should handle the next request based on
 // session history.
-func (r *Runner) findAgentToRun(session session.Session, msg *genai.Content) (agent.Agent, error) {
+func (r *Runner) findAgentToRun(ctx context.Context, session session.Session, msg *genai.Content) (agent.Agent, error) {
 	if event := handleUserFunctionCallResponse(session.Events(), msg); event != nil {
 		subAgent := findAgent(r.rootAgent, event.Author)
 		if subAgent != nil {
 			return subAgent, nil
 		}
-		log.Printf("Function call from an unknown agent: %s, event id: %s", event.Author, event.ID)
+		logging.FromContext(ctx).WarnContext(ctx, "Function call from an unknown agent", logging.AgentNameKey, event.Author, "event_id", event.ID)
 	}
 
 	events := session.Events()
@@ -329,7 +1035,7 @@ func (r *Runner) findAgentToRun(session session.Session, msg *genai.Content) (ag
 		subAgent := findAgent(r.rootAgent, event.Author)
 		// Agent not found, continue looking for the other event.
 		if subAgent == nil {
-			log.Printf("Event from an unknown agent: %s, event id: %s", event.Author, event.ID)
+			logging.FromContext(ctx).WarnContext(ctx, "Event from an unknown agent", logging.AgentNameKey, event.Author, "event_id", event.ID)
 			continue
 		}
 
@@ -17,18 +17,31 @@ package runner
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
+	"log/slog"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
 	"google.golang.org/adk/artifact"
 	"google.golang.org/adk/model"
+	"google.golang.org/adk/plugin"
+	"google.golang.org/adk/redaction"
 	"google.golang.org/adk/session"
+	"google.golang.org/adk/speech"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
 )
 
 func TestRunner_findAgentToRun(t *testing.T) {
@@ -110,7 +123,7 @@ func TestRunner_findAgentToRun(t *testing.T) {
 			r := &Runner{
 				rootAgent: tt.rootAgent,
 			}
-			gotAgent, err := r.findAgentToRun(tt.session, tt.userMessage)
+			gotAgent, err := r.findAgentToRun(t.Context(), tt.session, tt.userMessage)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Runner.findAgentToRun() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -337,6 +350,837 @@ func TestRunner_SaveInputBlobsAsArtifacts(t *testing.T) {
 	}
 }
 
+func TestRunner_AutoCreateSession(t *testing.T) {
+	ctx := t.Context()
+	testAgent := must(agent.New(agent.Config{
+		Name: "test_agent",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				event := session.NewEvent(ctx.InvocationID())
+				event.LLMResponse = model.LLMResponse{Content: genai.NewContentFromText("hi", genai.RoleModel)}
+				yield(event, nil)
+			}
+		},
+	}))
+
+	for _, autoCreate := range []bool{false, true} {
+		t.Run(fmt.Sprintf("autoCreate=%v", autoCreate), func(t *testing.T) {
+			sessionService := session.InMemoryService()
+			r, err := New(Config{
+				AppName:           "testApp",
+				Agent:             testAgent,
+				SessionService:    sessionService,
+				AutoCreateSession: autoCreate,
+			})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			var gotErr error
+			for _, err := range r.Run(ctx, "user", "new_session", genai.NewContentFromText("hello", genai.RoleUser), agent.RunConfig{}) {
+				if err != nil {
+					gotErr = err
+				}
+			}
+			if (gotErr != nil) == autoCreate {
+				t.Fatalf("Run() error = %v, want error: %v", gotErr, !autoCreate)
+			}
+			if !autoCreate {
+				return
+			}
+
+			resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "new_session"})
+			if err != nil {
+				t.Fatalf("sessionService.Get() error = %v", err)
+			}
+			if got := resp.Session.Events().Len(); got != 2 {
+				t.Errorf("got %d events in the session, want 2", got)
+			}
+		})
+	}
+}
+
+func TestRunner_Redactor(t *testing.T) {
+	ctx := t.Context()
+	testAgent := must(agent.New(agent.Config{
+		Name: "test_agent",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				event := session.NewEvent(ctx.InvocationID())
+				event.LLMResponse = model.LLMResponse{Content: genai.NewContentFromText("write to jane@example.com", genai.RoleModel)}
+				yield(event, nil)
+			}
+		},
+	}))
+	redactor, err := redaction.New(redaction.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionService := session.InMemoryService()
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             testAgent,
+		SessionService:    sessionService,
+		AutoCreateSession: true,
+		Redactor:          redactor,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var got []string
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("I am john@example.com", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		got = append(got, event.Content.Parts[0].Text)
+	}
+	if want := []string{"write to jane@example.com"}; !slices.Equal(got, want) {
+		t.Errorf("Run() yielded %q, want the events not redacted %q", got, want)
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatalf("sessionService.Get() error = %v", err)
+	}
+	var stored []string
+	for event := range resp.Session.Events().All() {
+		stored = append(stored, event.Content.Parts[0].Text)
+	}
+	if want := []string{"I am [EMAIL_ADDRESS]", "write to [EMAIL_ADDRESS]"}; !slices.Equal(stored, want) {
+		t.Errorf("stored events = %q, want %q", stored, want)
+	}
+}
+
+func TestRunner_AutoCreateSession_BackendError(t *testing.T) {
+	sessionService := &failingGetService{Service: session.InMemoryService()}
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             must(agent.New(agent.Config{Name: "test_agent"})),
+		SessionService:    sessionService,
+		AutoCreateSession: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for _, err := range r.Run(t.Context(), "user", "session", genai.NewContentFromText("hello", genai.RoleUser), agent.RunConfig{}) {
+		if !errors.Is(err, errBackendUnavailable) {
+			t.Errorf("Run() error = %v, want %v", err, errBackendUnavailable)
+		}
+	}
+	if sessionService.created {
+		t.Error("session was created after a backend error")
+	}
+}
+
+var errBackendUnavailable = errors.New("backend unavailable")
+
+type failingGetService struct {
+	session.Service
+	created bool
+}
+
+func (s *failingGetService) Get(context.Context, *session.GetRequest) (*session.GetResponse, error) {
+	return nil, errBackendUnavailable
+}
+
+func (s *failingGetService) Create(ctx context.Context, req *session.CreateRequest) (*session.CreateResponse, error) {
+	s.created = true
+	return s.Service.Create(ctx, req)
+}
+
+// leakingGetService returns the session of another user.
+type leakingGetService struct {
+	session.Service
+	otherUserID string
+}
+
+func (s *leakingGetService) Get(ctx context.Context, req *session.GetRequest) (*session.GetResponse, error) {
+	other := *req
+	other.UserID = s.otherUserID
+	return s.Service.Get(ctx, &other)
+}
+
+func TestRunner_SessionOfAnotherUser(t *testing.T) {
+	ctx := t.Context()
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "testApp", UserID: "alice", SessionID: "session", State: map[string]any{"user:secret": "alice's"}}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := New(Config{
+		AppName:        "testApp",
+		Agent:          must(agent.New(agent.Config{Name: "test_agent"})),
+		SessionService: &leakingGetService{Service: sessionService, otherUserID: "alice"},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for event, err := range r.Run(ctx, "bob", "session", genai.NewContentFromText("hello", genai.RoleUser), agent.RunConfig{}) {
+		if event != nil {
+			t.Errorf("Run() yielded event %+v, want none", event)
+		}
+		if !errors.Is(err, session.ErrScopeViolation) {
+			t.Errorf("Run() error = %v, want %v", err, session.ErrScopeViolation)
+		}
+	}
+}
+
+func TestRunner_ValidateRunConfig(t *testing.T) {
+	r := &Runner{}
+	customAgent := must(agent.New(agent.Config{Name: "custom"}))
+	llmAgentWithoutModel := must(llmagent.New(llmagent.Config{Name: "no_model"}))
+	llmAgent := must(llmagent.New(llmagent.Config{Name: "llm", Model: &fakeLLM{}}))
+	liveAgent := must(llmagent.New(llmagent.Config{Name: "live", Model: &fakeLiveLLM{}}))
+	profileAgent := must(llmagent.New(llmagent.Config{Name: "profile", Model: &fakeLLM{}, GenerationProfile: "unknown"}))
+	tests := []struct {
+		name         string
+		cfg          agent.RunConfig
+		agent        agent.Agent
+		live         bool
+		wantProblems int
+	}{
+		{name: "default", cfg: agent.RunConfig{}},
+		{name: "sse", cfg: agent.RunConfig{StreamingMode: agent.StreamingModeSSE}, agent: llmAgent},
+		{name: "unknown streaming mode", cfg: agent.RunConfig{StreamingMode: "unknown"}, wantProblems: 1},
+		{name: "bidi without RunLive", cfg: agent.RunConfig{StreamingMode: agent.StreamingModeBidi}, wantProblems: 1},
+		{name: "live", cfg: agent.RunConfig{StreamingMode: agent.StreamingModeBidi, ResponseModalities: []genai.Modality{genai.ModalityAudio}}, agent: liveAgent, live: true},
+		{name: "live without live model", cfg: agent.RunConfig{StreamingMode: agent.StreamingModeBidi}, agent: llmAgent, live: true, wantProblems: 1},
+		{name: "live with speech", cfg: agent.RunConfig{StreamingMode: agent.StreamingModeBidi, TextToSpeech: speech.SynthesizeFunc(func(context.Context, string, *genai.SpeechConfig) (*genai.Blob, error) { return nil, nil })}, agent: llmAgent, live: true},
+		{name: "negative max llm calls", cfg: agent.RunConfig{MaxLLMCalls: -1}, wantProblems: 1},
+		{name: "negative timeout", cfg: agent.RunConfig{Timeout: -time.Second}, wantProblems: 1},
+		{name: "unknown response modality", cfg: agent.RunConfig{ResponseModalities: []genai.Modality{"SMELL"}}, wantProblems: 1},
+		{name: "several live response modalities", cfg: agent.RunConfig{StreamingMode: agent.StreamingModeBidi, ResponseModalities: []genai.Modality{genai.ModalityText, genai.ModalityAudio}}, agent: liveAgent, live: true, wantProblems: 1},
+		{name: "blobs without artifact service", cfg: agent.RunConfig{SaveInputBlobsAsArtifacts: true}, wantProblems: 1},
+		{name: "output blobs without artifact service", cfg: agent.RunConfig{SaveOutputBlobsAsArtifacts: true}, wantProblems: 1},
+		{name: "llm agent without model", agent: llmAgentWithoutModel, wantProblems: 1},
+		{name: "generation profile", cfg: agent.RunConfig{GenerationProfile: "precise"}, agent: llmAgent},
+		{name: "unknown generation profile", cfg: agent.RunConfig{GenerationProfile: "unknown"}, wantProblems: 1},
+		{name: "unknown generation profile of the agent", agent: profileAgent, wantProblems: 1},
+		{name: "several problems", cfg: agent.RunConfig{StreamingMode: "unknown", MaxLLMCalls: -1, SaveInputBlobsAsArtifacts: true}, agent: llmAgentWithoutModel, wantProblems: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.agent == nil {
+				tt.agent = customAgent
+			}
+			err := r.validateRunConfig(&tt.cfg, tt.agent, tt.live)
+			if tt.wantProblems == 0 {
+				if err != nil {
+					t.Errorf("validateRunConfig() error = %v, want nil", err)
+				}
+				return
+			}
+			var cfgErr *RunConfigError
+			if !errors.As(err, &cfgErr) {
+				t.Fatalf("validateRunConfig() error = %v, want a *RunConfigError", err)
+			}
+			if len(cfgErr.Problems) != tt.wantProblems || cfgErr.Agent != tt.agent.Name() {
+				t.Errorf("validateRunConfig() error = %v, want %d problems of agent %q", err, tt.wantProblems, tt.agent.Name())
+			}
+		})
+	}
+}
+
+func TestRunner_Run_InvalidRunConfig(t *testing.T) {
+	ctx := t.Context()
+	llm := &fakeLLM{}
+	sessionService := session.InMemoryService()
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             must(llmagent.New(llmagent.Config{Name: "root", Model: llm})),
+		SessionService:    sessionService,
+		AutoCreateSession: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var runErr error
+	for _, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{MaxLLMCalls: -1}) {
+		runErr = err
+	}
+	var cfgErr *RunConfigError
+	if !errors.As(runErr, &cfgErr) {
+		t.Fatalf("Run() error = %v, want a *RunConfigError", runErr)
+	}
+	if len(llm.requests) != 0 {
+		t.Errorf("model was called %d times, want 0", len(llm.requests))
+	}
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Session.Events().Len(); got != 0 {
+		t.Errorf("session has %d events, want 0", got)
+	}
+}
+
+func TestRunner_Close(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	tests := []struct {
+		name      string
+		closeFunc func() error
+		timeout   time.Duration
+		wantErr   bool
+	}{
+		{name: "closes plugins", closeFunc: func() error { return nil }},
+		{name: "plugin error", closeFunc: func() error { return errors.New("close failed") }, wantErr: true},
+		{name: "timeout", closeFunc: func() error { <-block; return nil }, timeout: 10 * time.Millisecond, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			closed := false
+			p, err := plugin.New(plugin.Config{
+				Name: "test_plugin",
+				CloseFunc: func() error {
+					closed = true
+					return tt.closeFunc()
+				},
+			})
+			if err != nil {
+				t.Fatalf("plugin.New() error = %v", err)
+			}
+			r, err := New(Config{
+				AppName:        "testApp",
+				Agent:          must(agent.New(agent.Config{Name: "test_agent"})),
+				SessionService: session.InMemoryService(),
+				PluginConfig:   PluginConfig{Plugins: []*plugin.Plugin{p}, CloseTimeout: tt.timeout},
+			})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if err := r.Close(); (err != nil) != tt.wantErr {
+				t.Errorf("Close() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !closed {
+				t.Error("plugin was not closed")
+			}
+		})
+	}
+}
+
+func TestRunner_CancelInvocation(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	stopTool, err := functiontool.New(functiontool.Config{Name: "stop", Description: "stops"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		if !agent.CancelInvocation(ctx, "stopped by tool") {
+			t.Error("CancelInvocation() = false, want true")
+		}
+		return map[string]any{"ok": true}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCalled := false
+	otherTool, err := functiontool.New(functiontool.Config{Name: "other", Description: "other"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		otherCalled = true
+		return map[string]any{"ok": true}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	llm := &fakeLLM{responses: []*genai.Content{{
+		Role: genai.RoleModel,
+		Parts: []*genai.Part{
+			{FunctionCall: &genai.FunctionCall{ID: "1", Name: "stop"}},
+			{FunctionCall: &genai.FunctionCall{ID: "2", Name: "other"}},
+		},
+	}}}
+	rootAgent := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, Tools: []tool.Tool{stopTool, otherTool}}))
+
+	sessionService := session.InMemoryService()
+	r, err := New(Config{AppName: "testApp", Agent: rootAgent, SessionService: sessionService, AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*session.Event
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		events = append(events, event)
+	}
+
+	if otherCalled {
+		t.Error("pending tool was called after the invocation was cancelled")
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+	responses := events[1].Content.Parts
+	if len(responses) != 2 || responses[1].FunctionResponse == nil || !strings.Contains(fmt.Sprint(responses[1].FunctionResponse.Response["error"]), "stopped by tool") {
+		t.Errorf("pending tool call response = %+v, want a cancellation error", responses)
+	}
+	last := events[2]
+	if last.ErrorCode != ErrorCodeCancelled || !strings.Contains(last.ErrorMessage, "stopped by tool") {
+		t.Errorf("last event = %+v, want cancellation event", last.LLMResponse)
+	}
+	if len(llm.requests) != 1 {
+		t.Errorf("model was called %d times, want 1", len(llm.requests))
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// user message + 3 events
+	if got := resp.Session.Events().Len(); got != 4 {
+		t.Errorf("got %d events in session, want 4", got)
+	}
+}
+
+func TestRunner_Timeout(t *testing.T) {
+	ctx := t.Context()
+	rootAgent := must(llmagent.New(llmagent.Config{Name: "root", Model: &stallingLLM{}}))
+
+	sessionService := session.InMemoryService()
+	r, err := New(Config{AppName: "testApp", Agent: rootAgent, SessionService: sessionService, AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*session.Event
+	runConfig := agent.RunConfig{StreamingMode: agent.StreamingModeSSE, Timeout: 50 * time.Millisecond}
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), runConfig) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+	partial := events[2]
+	if partial.Partial || !partial.Interrupted || partial.Author != "root" || partial.Content.Parts[0].Text != "Hello, world" {
+		t.Errorf("partial results event = %+v, want merged partial text", partial.LLMResponse)
+	}
+	last := events[3]
+	if last.ErrorCode != ErrorCodeTimeout || !strings.Contains(last.ErrorMessage, agent.ErrInvocationTimeout.Error()) {
+		t.Errorf("last event = %+v, want timeout event", last.LLMResponse)
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// user message + partial results + timeout event
+	if got := resp.Session.Events().Len(); got != 3 {
+		t.Errorf("got %d events in session, want 3", got)
+	}
+}
+
+func TestRunner_DryRun(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	weatherTool, err := functiontool.New(functiontool.Config{Name: "weather", Description: "returns the weather"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return map[string]any{"weather": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &fakeLLM{}
+	rootAgent := must(llmagent.New(llmagent.Config{
+		Name:        "root",
+		Model:       llm,
+		Instruction: "Help {user_name}.",
+		Tools:       []tool.Tool{weatherTool},
+	}))
+
+	r, err := New(Config{AppName: "testApp", Agent: rootAgent, SessionService: session.InMemoryService(), AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*session.Event
+	msg := genai.NewContentFromText("how is the weather?", genai.RoleUser)
+	for event, err := range r.Run(ctx, "user", "session", msg, agent.RunConfig{DryRun: true}, WithStateDelta(map[string]any{"user_name": "Alice"})) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		events = append(events, event)
+	}
+
+	if len(llm.requests) != 0 {
+		t.Errorf("model was called %d times in dry-run mode, want 0", len(llm.requests))
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	req, ok := events[0].CustomMetadata[agent.DryRunRequestKey].(*model.LLMRequest)
+	if !ok {
+		t.Fatalf("event custom metadata = %v, want the LLM request", events[0].CustomMetadata)
+	}
+	if got := req.Config.SystemInstruction.Parts[0].Text; !strings.Contains(got, "Help Alice.") {
+		t.Errorf("system instruction = %q, want it to contain the templated instruction", got)
+	}
+	if _, ok := req.Tools["weather"]; !ok {
+		t.Errorf("request tools = %v, want weather tool", req.Tools)
+	}
+	if got := req.Contents[len(req.Contents)-1]; got.Parts[0].Text != msg.Parts[0].Text {
+		t.Errorf("last request content = %v, want user message", got)
+	}
+}
+
+func TestRunner_DryRun_SessionUnchanged(t *testing.T) {
+	ctx := t.Context()
+	sessionService := session.InMemoryService()
+	llm := &fakeLLM{}
+	rootAgent := must(llmagent.New(llmagent.Config{Name: "root", Model: llm}))
+	r, err := New(Config{
+		AppName:        "testApp",
+		Agent:          rootAgent,
+		SessionService: sessionService,
+		Checkpoints:    true,
+		EventBatch:     &EventBatchConfig{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "testApp", UserID: "user", SessionID: "session", State: map[string]any{"user_name": "Bob"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := session.NewEvent("previous")
+	event.Author = "user"
+	event.LLMResponse = model.LLMResponse{Content: genai.NewContentFromText("hello", genai.RoleUser)}
+	if err := sessionService.AppendEvent(ctx, created.Session, event); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*session.Event
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{DryRun: true}, WithStateDelta(map[string]any{"user_name": "Alice"})) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 1 || events[0].CustomMetadata[agent.DryRunRequestKey] == nil {
+		t.Fatalf("Run() events = %v, want the dry-run request", events)
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for event := range resp.Session.Events().All() {
+		ids = append(ids, event.ID)
+	}
+	if !slices.Equal(ids, []string{event.ID}) {
+		t.Errorf("stored events after the dry run = %v, want only %q", ids, event.ID)
+	}
+	if got, _ := resp.Session.State().Get("user_name"); got != "Bob" {
+		t.Errorf("stored state user_name = %v, want Bob", got)
+	}
+}
+
+func TestRunner_TypedErrors(t *testing.T) {
+	ctx := t.Context()
+	errModel := errors.New("model failure")
+	errTool := errors.New("tool failure")
+	type args struct{}
+	failingTool, err := functiontool.New(functiontool.Config{Name: "fail", Description: "fails"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return nil, errTool
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotToolErr *agent.ToolError
+	onToolError := func(ctx tool.Context, tool tool.Tool, args map[string]any, err error) (map[string]any, error) {
+		errors.As(err, &gotToolErr)
+		return nil, nil
+	}
+	callTool := &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "fail"}}}}
+	transfer := &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{Name: "transfer_to_agent", Args: map[string]any{"agent_name": "missing"}}}}}
+
+	tests := []struct {
+		name      string
+		model     model.LLM
+		runConfig agent.RunConfig
+		check     func(t *testing.T, err error)
+	}{
+		{
+			name:  "model error",
+			model: &failingLLM{err: errModel},
+			check: func(t *testing.T, err error) {
+				var modelErr *agent.ModelError
+				if !errors.As(err, &modelErr) || modelErr.Agent != "root" || modelErr.Model != "failing" || !errors.Is(err, errModel) {
+					t.Errorf("Run() error = %v, want *agent.ModelError wrapping %v", err, errModel)
+				}
+			},
+		},
+		{
+			name:      "limit exceeded",
+			model:     &fakeLLM{responses: []*genai.Content{callTool}},
+			runConfig: agent.RunConfig{MaxLLMCalls: 1},
+			check: func(t *testing.T, err error) {
+				var limitErr *agent.LimitExceededError
+				if !errors.As(err, &limitErr) || limitErr.Limit != "MaxLLMCalls" || limitErr.Max != 1 {
+					t.Errorf("Run() error = %v, want *agent.LimitExceededError", err)
+				}
+				if gotToolErr == nil || gotToolErr.Tool != "fail" || gotToolErr.CallID != "call-1" || !errors.Is(gotToolErr, errTool) {
+					t.Errorf("tool error callback got %v, want *agent.ToolError wrapping %v", gotToolErr, errTool)
+				}
+			},
+		},
+		{
+			name:  "transfer error",
+			model: &fakeLLM{responses: []*genai.Content{transfer}},
+			check: func(t *testing.T, err error) {
+				var transferErr *agent.TransferError
+				if !errors.As(err, &transferErr) || transferErr.From != "root" || transferErr.To != "missing" || !errors.Is(err, agent.ErrAgentNotFound) {
+					t.Errorf("Run() error = %v, want *agent.TransferError wrapping %v", err, agent.ErrAgentNotFound)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootAgent := must(llmagent.New(llmagent.Config{
+				Name:                 "root",
+				Model:                tt.model,
+				Tools:                []tool.Tool{failingTool},
+				OnToolErrorCallbacks: []llmagent.OnToolErrorCallback{onToolError},
+				// A sub-agent enables the transfer tool.
+				SubAgents: []agent.Agent{must(llmagent.New(llmagent.Config{Name: "helper", Model: tt.model}))},
+			}))
+			r, err := New(Config{AppName: "testApp", Agent: rootAgent, SessionService: session.InMemoryService(), AutoCreateSession: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotErr error
+			for _, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("hi", genai.RoleUser), tt.runConfig) {
+				if err != nil {
+					gotErr = err
+				}
+			}
+			tt.check(t, gotErr)
+		})
+	}
+}
+
+func TestRunner_Tracing(t *testing.T) {
+	ctx := t.Context()
+	type args struct {
+		Password string `json:"password"`
+	}
+	loginTool, err := functiontool.New(functiontool.Config{Name: "login", Description: "logs in"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return map[string]any{"token": "secret-token"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &fakeLLM{
+		responses: []*genai.Content{{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "login", Args: map[string]any{"password": "hunter2"}}}}}},
+		usage:     &genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 10, CandidatesTokenCount: 5},
+	}
+	rootAgent := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, Tools: []tool.Tool{loginTool}}))
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	redact := func(toolName string, data map[string]any) map[string]any {
+		redacted := make(map[string]any, len(data))
+		for k := range data {
+			redacted[k] = "REDACTED"
+		}
+		return redacted
+	}
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             rootAgent,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+		TracerProvider:    tp,
+		RedactToolData:    redact,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invocationID string
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("log in", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		invocationID = event.InvocationID
+	}
+
+	spans := exporter.GetSpans()
+	byName := make(map[string]tracetest.SpanStub)
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+	invocation, ok := byName["invocation"]
+	if !ok {
+		t.Fatalf("got spans %v, want an invocation span", spans)
+	}
+	for _, tt := range []struct{ child, parent string }{
+		{"invoke_agent root", "invocation"},
+		{"generate_content fake", "invoke_agent root"},
+		{"execute_tool login", "invoke_agent root"},
+	} {
+		child, ok := byName[tt.child]
+		if !ok {
+			t.Errorf("missing span %q", tt.child)
+			continue
+		}
+		if child.Parent.SpanID() != byName[tt.parent].SpanContext.SpanID() {
+			t.Errorf("span %q is not a child of %q", tt.child, tt.parent)
+		}
+		if child.SpanContext.TraceID() != invocation.SpanContext.TraceID() {
+			t.Errorf("span %q is not in the invocation trace", tt.child)
+		}
+	}
+	if !hasAttribute(invocation.Attributes, "gcp.vertex.agent.invocation_id", invocationID) {
+		t.Errorf("invocation span attributes = %v, want invocation ID %q", invocation.Attributes, invocationID)
+	}
+	if !hasAttribute(byName["generate_content fake"].Attributes, "gen_ai.usage.input_tokens", "10") {
+		t.Errorf("generate_content span attributes = %v, want input token count", byName["generate_content fake"].Attributes)
+	}
+	for _, attr := range byName["execute_tool login"].Attributes {
+		if value := attr.Value.Emit(); strings.Contains(value, "hunter2") || strings.Contains(value, "secret-token") {
+			t.Errorf("execute_tool span attribute %s = %s, want redacted", attr.Key, value)
+		}
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, key, value string) bool {
+	for _, attr := range attrs {
+		if string(attr.Key) == key && attr.Value.Emit() == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRunner_Logging(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	failingTool, err := functiontool.New(functiontool.Config{Name: "fail", Description: "fails"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return nil, errors.New("tool failure")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &fakeLLM{responses: []*genai.Content{{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "fail"}}}}}}
+	rootAgent := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, Tools: []tool.Tool{failingTool}}))
+
+	var buf bytes.Buffer
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             rootAgent,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+		Logger:            slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		RedactPrompt:      func(string) string { return "REDACTED" },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invocationID string
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("my secret", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		invocationID = event.InvocationID
+	}
+
+	if strings.Contains(buf.String(), "my secret") {
+		t.Errorf("logs contain the prompt, want it redacted:\n%s", buf.String())
+	}
+	lines := make(map[string]map[string]any)
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var line map[string]any
+		if err := dec.Decode(&line); err != nil {
+			t.Fatal(err)
+		}
+		lines[line["msg"].(string)] = line
+	}
+	for msg, want := range map[string]map[string]any{
+		"Invocation started": {"invocation_id": invocationID, "session_id": "session", "app_name": "testApp"},
+		"Calling model":      {"invocation_id": invocationID, "agent_name": "root", "prompt": "REDACTED"},
+		"Tool call failed":   {"invocation_id": invocationID, "agent_name": "root", "tool_name": "fail", "function_call_id": "call-1"},
+	} {
+		line, ok := lines[msg]
+		if !ok {
+			t.Errorf("missing log line %q", msg)
+			continue
+		}
+		for key, value := range want {
+			if line[key] != value {
+				t.Errorf("log line %q: %s = %v, want %v", msg, key, line[key], value)
+			}
+		}
+	}
+}
+
+type failingLLM struct {
+	err error
+}
+
+func (m *failingLLM) Name() string { return "failing" }
+
+func (m *failingLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		yield(nil, m.err)
+	}
+}
+
+// stallingLLM streams two partial responses and then blocks until the
+// request context is done.
+type stallingLLM struct {
+	// started is closed, if set, when the model starts blocking.
+	started chan struct{}
+}
+
+func (m *stallingLLM) Name() string { return "stalling" }
+
+func (m *stallingLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		for _, text := range []string{"Hello, ", "world"} {
+			if !yield(&model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel), Partial: true}, nil) {
+				return
+			}
+		}
+		if m.started != nil {
+			close(m.started)
+		}
+		<-ctx.Done()
+		yield(nil, ctx.Err())
+	}
+}
+
+type fakeLLM struct {
+	responses []*genai.Content
+	requests  []*model.LLMRequest
+	// usage is reported with every response if set.
+	usage *genai.GenerateContentResponseUsageMetadata
+}
+
+func (m *fakeLLM) Name() string { return "fake" }
+
+func (m *fakeLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.requests = append(m.requests, req)
+		if len(m.responses) == 0 {
+			yield(&model.LLMResponse{Content: genai.NewContentFromText("done", genai.RoleModel), UsageMetadata: m.usage}, nil)
+			return
+		}
+		resp := m.responses[0]
+		m.responses = m.responses[1:]
+		yield(&model.LLMResponse{Content: resp, UsageMetadata: m.usage}, nil)
+	}
+}
+
 // creates agentTree for tests and returns references to the agents
 func agentTree(t *testing.T) agentTreeStruct {
 	t.Helper()
@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/adk/session"
+)
+
+// SessionKey identifies a session across apps and users.
+type SessionKey = session.Key
+
+// SessionLocker serializes the invocations on the same session.
+//
+// The default implementation locks sessions within the process. Runners in
+// multiple processes sharing the same session service need an implementation
+// backed by a distributed lock.
+type SessionLocker = session.Locker
+
+// SessionBusyError is returned by [Runner.Run] when
+// Config.FailFastOnBusySession is set and another invocation is running on
+// the same session.
+type SessionBusyError = session.BusyError
+
+// NewInMemorySessionLocker returns a [SessionLocker] that serializes the
+// invocations within the process.
+func NewInMemorySessionLocker() SessionLocker {
+	return &inMemorySessionLocker{locks: make(map[SessionKey]*sessionLock)}
+}
+
+type inMemorySessionLocker struct {
+	mu    sync.Mutex
+	locks map[SessionKey]*sessionLock
+}
+
+type sessionLock struct {
+	ch chan struct{}
+	// refs is the number of holders and waiters, the lock is removed from the
+	// map once it drops to zero.
+	refs int
+}
+
+func (l *inMemorySessionLocker) Lock(ctx context.Context, key SessionKey) (func(), error) {
+	lock := l.acquire(key)
+	select {
+	case lock.ch <- struct{}{}:
+		return l.unlockFunc(key, lock), nil
+	case <-ctx.Done():
+		l.release(key, lock)
+		return nil, ctx.Err()
+	}
+}
+
+func (l *inMemorySessionLocker) TryLock(ctx context.Context, key SessionKey) (func(), error) {
+	lock := l.acquire(key)
+	select {
+	case lock.ch <- struct{}{}:
+		return l.unlockFunc(key, lock), nil
+	default:
+		l.release(key, lock)
+		return nil, &SessionBusyError{Key: key}
+	}
+}
+
+func (l *inMemorySessionLocker) acquire(key SessionKey) *sessionLock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &sessionLock{ch: make(chan struct{}, 1)}
+		l.locks[key] = lock
+	}
+	lock.refs++
+	return lock
+}
+
+func (l *inMemorySessionLocker) release(key SessionKey, lock *sessionLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, key)
+	}
+}
+
+func (l *inMemorySessionLocker) unlockFunc(key SessionKey, lock *sessionLock) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-lock.ch
+			l.release(key, lock)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+func TestInMemorySessionLocker(t *testing.T) {
+	ctx := t.Context()
+	locker := NewInMemorySessionLocker()
+	key := SessionKey{AppName: "app", UserID: "user", SessionID: "s1"}
+
+	unlock, err := locker.Lock(ctx, key)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+
+	var busyErr *SessionBusyError
+	if _, err := locker.TryLock(ctx, key); !errors.As(err, &busyErr) || busyErr.Key != key {
+		t.Errorf("TryLock() error = %v, want *SessionBusyError for %v", err, key)
+	}
+
+	other, err := locker.TryLock(ctx, SessionKey{AppName: "app", UserID: "user", SessionID: "s2"})
+	if err != nil {
+		t.Errorf("TryLock() on another session error = %v", err)
+	} else {
+		other()
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := locker.Lock(timeoutCtx, key); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Lock() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		unlock, err := locker.Lock(ctx, key)
+		if err != nil {
+			t.Errorf("Lock() error = %v", err)
+		}
+		acquired <- unlock
+	}()
+	unlock()
+	// Unlocking twice is a no-op.
+	unlock()
+	(<-acquired)()
+
+	if n := len(locker.(*inMemorySessionLocker).locks); n != 0 {
+		t.Errorf("got %d locks left, want 0", n)
+	}
+}
+
+func TestRunner_ConcurrentSessions(t *testing.T) {
+	ctx := t.Context()
+	tests := []struct {
+		name     string
+		failFast bool
+	}{
+		{name: "queue", failFast: false},
+		{name: "fail fast", failFast: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := make(chan string, 2)
+			release := make(chan struct{})
+			rootAgent := must(agent.New(agent.Config{
+				Name: "root",
+				Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+					return func(yield func(*session.Event, error) bool) {
+						started <- ctx.Session().ID()
+						<-release
+						event := session.NewEvent(ctx.InvocationID())
+						event.LLMResponse = model.LLMResponse{Content: genai.NewContentFromText("done", genai.RoleModel)}
+						yield(event, nil)
+					}
+				},
+			}))
+
+			r, err := New(Config{
+				AppName:               "testApp",
+				Agent:                 rootAgent,
+				SessionService:        session.InMemoryService(),
+				AutoCreateSession:     true,
+				FailFastOnBusySession: tt.failFast,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			run := func(sessionID string) error {
+				for _, err := range r.Run(ctx, "user", sessionID, genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 3)
+			for _, sessionID := range []string{"s1", "s2"} {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs <- run(sessionID)
+				}()
+			}
+			// Both sessions run concurrently.
+			<-started
+			<-started
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- run("s1")
+			}()
+			if tt.failFast {
+				var busyErr *SessionBusyError
+				if err := <-errs; !errors.As(err, &busyErr) {
+					t.Errorf("Run() error = %v, want *SessionBusyError", err)
+				}
+				close(release)
+			} else {
+				select {
+				case id := <-started:
+					t.Errorf("second invocation on session %q started while the first one was running", id)
+				case <-time.After(20 * time.Millisecond):
+				}
+				close(release)
+				if id := <-started; id != "s1" {
+					t.Errorf("queued invocation ran on session %q, want s1", id)
+				}
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Errorf("Run() error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/session"
+)
+
+// StateMergeFunc resolves a conflicting write to a state key. It is called
+// with the value written by another parallel branch of the invocation and
+// the incoming value, and returns the value to commit.
+type StateMergeFunc func(key string, current, incoming any) (any, error)
+
+// LastWriteWins is a [StateMergeFunc] committing the incoming value.
+func LastWriteWins(key string, current, incoming any) (any, error) {
+	return incoming, nil
+}
+
+// ErrorOnConflict is a [StateMergeFunc] rejecting the incoming value with
+// [ErrStateConflict].
+func ErrorOnConflict(key string, current, incoming any) (any, error) {
+	return nil, ErrStateConflict
+}
+
+// ErrStateConflict is returned by [ErrorOnConflict].
+var ErrStateConflict = errors.New("conflicting state write")
+
+// StateMergeConfig configures how the runner commits state deltas when
+// parallel branches of an invocation, e.g. the sub-agents of a parallel
+// agent, write the same state key.
+//
+// Writes by the same branch, or by a branch and its ancestors, are applied in
+// order and never conflict.
+type StateMergeConfig struct {
+	// Default resolves conflicts of keys not matching any prefix of
+	// ByPrefix. Defaults to LastWriteWins.
+	Default StateMergeFunc
+	// ByPrefix resolves conflicts of keys with the given prefix. The longest
+	// matching prefix is used.
+	ByPrefix map[string]StateMergeFunc
+}
+
+// StateConflictError is yielded by [Runner.Run] when a conflicting write is
+// rejected by the StateMergeFunc. The conflicting key is dropped from the
+// state delta of the event, the rest of the event is committed.
+type StateConflictError struct {
+	Key string
+	// Branches that wrote the key.
+	Branches [2]string
+	Err      error
+}
+
+func (e *StateConflictError) Error() string {
+	if errors.Is(e.Err, ErrStateConflict) {
+		return fmt.Sprintf("state key %q written by parallel branches %q and %q", e.Key, e.Branches[0], e.Branches[1])
+	}
+	return fmt.Sprintf("failed to merge state key %q written by parallel branches %q and %q: %v", e.Key, e.Branches[0], e.Branches[1], e.Err)
+}
+
+func (e *StateConflictError) Unwrap() error { return e.Err }
+
+func (c *StateMergeConfig) mergeFunc(key string) StateMergeFunc {
+	merge, longest := c.Default, -1
+	for prefix, fn := range c.ByPrefix {
+		if strings.HasPrefix(key, prefix) && len(prefix) > longest {
+			merge, longest = fn, len(prefix)
+		}
+	}
+	if merge == nil {
+		return LastWriteWins
+	}
+	return merge
+}
+
+// stateMerger tracks the state writes of an invocation.
+type stateMerger struct {
+	cfg    *StateMergeConfig
+	writes map[string]stateWrite
+}
+
+type stateWrite struct {
+	branch string
+	value  any
+}
+
+func newStateMerger(cfg *StateMergeConfig) *stateMerger {
+	return &stateMerger{cfg: cfg, writes: make(map[string]stateWrite)}
+}
+
+// merge resolves the conflicts of the event state delta in place.
+func (m *stateMerger) merge(event *session.Event) error {
+	var errs []error
+	for key, incoming := range event.Actions.StateDelta {
+		prev, ok := m.writes[key]
+		if ok && !relatedBranches(prev.branch, event.Branch) {
+			merged, err := m.cfg.mergeFunc(key)(key, prev.value, incoming)
+			if err != nil {
+				delete(event.Actions.StateDelta, key)
+				errs = append(errs, &StateConflictError{Key: key, Branches: [2]string{prev.branch, event.Branch}, Err: err})
+				continue
+			}
+			event.Actions.StateDelta[key] = merged
+			incoming = merged
+		}
+		m.writes[key] = stateWrite{branch: event.Branch, value: incoming}
+	}
+	return errors.Join(errs...)
+}
+
+// relatedBranches reports whether one branch is the other or its ancestor.
+func relatedBranches(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return a == "" || a == b || strings.HasPrefix(b, a+".")
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/workflowagents/parallelagent"
+	"google.golang.org/adk/agent/workflowagents/sequentialagent"
+	"google.golang.org/adk/session"
+)
+
+func TestRunner_StateMerge(t *testing.T) {
+	ctx := t.Context()
+	writer := func(name string, delta func() map[string]any) agent.Agent {
+		return must(agent.New(agent.Config{
+			Name: name,
+			Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+				return func(yield func(*session.Event, error) bool) {
+					event := session.NewEvent(ctx.InvocationID())
+					event.Author = name
+					event.Branch = ctx.Branch()
+					event.Actions.StateDelta = delta()
+					yield(event, nil)
+				}
+			},
+		}))
+	}
+	branchWriter := func(name string) agent.Agent {
+		return writer(name, func() map[string]any {
+			return map[string]any{"shared": name, "list:items": []any{name}, name: true}
+		})
+	}
+	parallel := must(parallelagent.New(parallelagent.Config{AgentConfig: agent.Config{
+		Name:      "parallel",
+		SubAgents: []agent.Agent{branchWriter("a"), branchWriter("b")},
+	}}))
+	// Runs after the parallel branches, so it doesn't conflict with them.
+	final := writer("final", func() map[string]any {
+		return map[string]any{"after": "final"}
+	})
+	root := must(sequentialagent.New(sequentialagent.Config{AgentConfig: agent.Config{
+		Name:      "root",
+		SubAgents: []agent.Agent{parallel, final},
+	}}))
+
+	appendLists := func(key string, current, incoming any) (any, error) {
+		return append(slices.Clone(current.([]any)), incoming.([]any)...), nil
+	}
+	sessionService := session.InMemoryService()
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             root,
+		SessionService:    sessionService,
+		AutoCreateSession: true,
+		StateMerge: &StateMergeConfig{
+			Default:  ErrorOnConflict,
+			ByPrefix: map[string]StateMergeFunc{"list:": appendLists},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var conflicts []*StateConflictError
+	var first string
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{}) {
+		var conflictErr *StateConflictError
+		if errors.As(err, &conflictErr) {
+			conflicts = append(conflicts, conflictErr)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if first == "" && event.Author != "final" {
+			first = event.Author
+		}
+	}
+
+	if len(conflicts) != 1 || conflicts[0].Key != "shared" || !errors.Is(conflicts[0], ErrStateConflict) {
+		t.Fatalf("got conflicts %v, want one conflict on key shared", conflicts)
+	}
+	second := "b"
+	if first == "b" {
+		second = "a"
+	}
+
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "testApp", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := resp.Session.State()
+	for key, want := range map[string]any{"shared": first, "a": true, "b": true, "after": "final"} {
+		if got, err := state.Get(key); err != nil || got != want {
+			t.Errorf("state[%q] = %v, %v, want %v", key, got, err, want)
+		}
+	}
+	items, err := state.Get("list:items")
+	if err != nil || !slices.Equal(items.([]any), []any{first, second}) {
+		t.Errorf("state[list:items] = %v, %v, want merged list [%s %s]", items, err, first, second)
+	}
+}
+
+func TestRelatedBranches(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"", "root.par.a", true},
+		{"root.par.a", "root.par.a", true},
+		{"root.par", "root.par.a", true},
+		{"root.par.a", "root.par.b", false},
+		{"root.par.a", "root.par.ab", false},
+	}
+	for _, tt := range tests {
+		if got := relatedBranches(tt.a, tt.b); got != tt.want {
+			t.Errorf("relatedBranches(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
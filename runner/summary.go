@@ -0,0 +1,111 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/invocationstats"
+	"google.golang.org/adk/session"
+)
+
+// InvocationSummaryKey is the CustomMetadata key of the [InvocationSummary]
+// in the last event of an invocation, see Config.EmitInvocationSummary.
+const InvocationSummaryKey = "adk_invocation_summary"
+
+// InvocationSummary reports the cost and latency of an invocation.
+type InvocationSummary struct {
+	// LLMCalls is the number of model calls.
+	LLMCalls int `json:"llm_calls"`
+	// Token counts summed over all model calls, as reported by the model.
+	PromptTokens     int32 `json:"prompt_tokens"`
+	CandidatesTokens int32 `json:"candidates_tokens"`
+	TotalTokens      int32 `json:"total_tokens"`
+	// ToolCalls is the number of tool calls.
+	ToolCalls int `json:"tool_calls"`
+	// Agents that produced events, in order of their first event.
+	Agents []string `json:"agents,omitempty"`
+	// Transfers is the number of agent transfers.
+	Transfers int `json:"transfers"`
+	// Duration is the wall time of the invocation.
+	Duration time.Duration `json:"duration"`
+	// LLMDuration is the time spent in model calls.
+	LLMDuration time.Duration `json:"llm_duration"`
+	// ToolDuration is the time spent in tool calls.
+	ToolDuration time.Duration `json:"tool_duration"`
+}
+
+// InvocationSummaryFromEvent returns the invocation summary carried by the
+// event, also after the event was round-tripped through a session service.
+func InvocationSummaryFromEvent(event *session.Event) (*InvocationSummary, bool) {
+	val, ok := event.CustomMetadata[InvocationSummaryKey]
+	if !ok {
+		return nil, false
+	}
+	if summary, ok := val.(*InvocationSummary); ok {
+		return summary, true
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, false
+	}
+	var summary InvocationSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, false
+	}
+	return &summary, true
+}
+
+// summaryTracker builds the summary from the stats collected by the flow and
+// the events of the invocation.
+type summaryTracker struct {
+	start   time.Time
+	stats   *invocationstats.Stats
+	summary InvocationSummary
+}
+
+func newSummaryTracker(stats *invocationstats.Stats) *summaryTracker {
+	return &summaryTracker{start: time.Now(), stats: stats}
+}
+
+func (t *summaryTracker) trackEvent(event *session.Event) {
+	if event.Author != "" && event.Author != "user" && !slices.Contains(t.summary.Agents, event.Author) {
+		t.summary.Agents = append(t.summary.Agents, event.Author)
+	}
+	if event.Actions.TransferToAgent != "" {
+		t.summary.Transfers++
+	}
+}
+
+func (t *summaryTracker) newEvent(ctx agent.InvocationContext) *session.Event {
+	stats := t.stats.Snapshot()
+	summary := t.summary
+	summary.LLMCalls = stats.LLMCalls
+	summary.PromptTokens = stats.PromptTokens
+	summary.CandidatesTokens = stats.CandidatesTokens
+	summary.TotalTokens = stats.TotalTokens
+	summary.ToolCalls = stats.ToolCalls
+	summary.Duration = time.Since(t.start)
+	summary.LLMDuration = stats.LLMDuration
+	summary.ToolDuration = stats.ToolDuration
+
+	event := session.NewEventWithContext(ctx, ctx.InvocationID())
+	event.Author = ctx.Agent().Name()
+	event.CustomMetadata = map[string]any{InvocationSummaryKey: &summary}
+	return event
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestRunner_EmitInvocationSummary(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	slowTool, err := functiontool.New(functiontool.Config{Name: "slow", Description: "takes a while"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		time.Sleep(10 * time.Millisecond)
+		return map[string]any{"ok": true}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	llm := &fakeLLM{
+		responses: []*genai.Content{
+			{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{Name: "transfer_to_agent", Args: map[string]any{"agent_name": "worker"}}}}},
+			{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{Name: "slow"}}}},
+		},
+		usage: &genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 10, CandidatesTokenCount: 2, TotalTokenCount: 12},
+	}
+	worker := must(llmagent.New(llmagent.Config{Name: "worker", Model: llm, Tools: []tool.Tool{slowTool}}))
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, SubAgents: []agent.Agent{worker}}))
+
+	r, err := New(Config{AppName: "testApp", Agent: root, SessionService: session.InMemoryService(), AutoCreateSession: true, EmitInvocationSummary: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var last *session.Event
+	for event, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		last = event
+	}
+
+	got, ok := InvocationSummaryFromEvent(last)
+	if !ok {
+		t.Fatalf("last event = %+v, want invocation summary", last)
+	}
+	// root transfers, worker calls the tool and answers. The transfer is a
+	// tool call too.
+	want := &InvocationSummary{
+		LLMCalls:         3,
+		PromptTokens:     30,
+		CandidatesTokens: 6,
+		TotalTokens:      36,
+		ToolCalls:        2,
+		Agents:           []string{"root", "worker"},
+		Transfers:        1,
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(InvocationSummary{}, "Duration", "LLMDuration", "ToolDuration")); diff != "" {
+		t.Errorf("summary mismatch (-want +got):\n%s", diff)
+	}
+	if got.ToolDuration < 10*time.Millisecond || got.Duration < got.ToolDuration+got.LLMDuration {
+		t.Errorf("durations = %v total, %v llm, %v tool, want the tool and total to cover the tool sleep", got.Duration, got.LLMDuration, got.ToolDuration)
+	}
+
+	// The summary survives a JSON round trip through a session service.
+	data, err := json.Marshal(last.CustomMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored session.Event
+	if err := json.Unmarshal(data, &stored.CustomMetadata); err != nil {
+		t.Fatal(err)
+	}
+	roundTripped, ok := InvocationSummaryFromEvent(&stored)
+	if !ok {
+		t.Fatal("InvocationSummaryFromEvent() = false after JSON round trip")
+	}
+	if diff := cmp.Diff(got, roundTripped); diff != "" {
+		t.Errorf("round-tripped summary mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/adk/internal/stagetimeout"
+	"google.golang.org/adk/session"
+)
+
+// Timeouts limits the duration of the stages of the invocations. The
+// contexts of the stages derive from the context of the invocation, so
+// agent.RunConfig.Timeout still bounds them all.
+//
+// A stage exceeding its timeout fails with an *agent.StageTimeoutError
+// without cancelling the invocation: the model and the tools get their
+// context cancelled and must return. Zero means no limit, except for
+// MCPPing.
+type Timeouts struct {
+	// ModelCall limits each call to a model, including the whole response
+	// stream.
+	ModelCall time.Duration
+	// ToolCall limits each tool call, including its callbacks. The error is
+	// sent to the model as the function response.
+	ToolCall time.Duration
+	// SessionIO limits each operation on the SessionService, e.g. appending
+	// an event.
+	SessionIO time.Duration
+	// MCPPing limits the ping checking an MCP session before reconnecting
+	// it. Defaults to 2s.
+	MCPPing time.Duration
+}
+
+func (t Timeouts) config() stagetimeout.Config {
+	return stagetimeout.Config{
+		ModelCall: t.ModelCall,
+		ToolCall:  t.ToolCall,
+		SessionIO: t.SessionIO,
+		MCPPing:   t.MCPPing,
+	}
+}
+
+// timeoutSessionService limits the duration of the session service
+// operations.
+type timeoutSessionService struct {
+	session.Service
+	timeout time.Duration
+}
+
+func (s timeoutSessionService) Create(ctx context.Context, req *session.CreateRequest) (*session.CreateResponse, error) {
+	ctx, cancel := stagetimeout.With(ctx, stagetimeout.SessionIO, s.timeout)
+	defer cancel()
+	resp, err := s.Service.Create(ctx, req)
+	return resp, stagetimeout.Err(ctx, err)
+}
+
+func (s timeoutSessionService) Get(ctx context.Context, req *session.GetRequest) (*session.GetResponse, error) {
+	ctx, cancel := stagetimeout.With(ctx, stagetimeout.SessionIO, s.timeout)
+	defer cancel()
+	resp, err := s.Service.Get(ctx, req)
+	return resp, stagetimeout.Err(ctx, err)
+}
+
+func (s timeoutSessionService) List(ctx context.Context, req *session.ListRequest) (*session.ListResponse, error) {
+	ctx, cancel := stagetimeout.With(ctx, stagetimeout.SessionIO, s.timeout)
+	defer cancel()
+	resp, err := s.Service.List(ctx, req)
+	return resp, stagetimeout.Err(ctx, err)
+}
+
+func (s timeoutSessionService) Delete(ctx context.Context, req *session.DeleteRequest) error {
+	ctx, cancel := stagetimeout.With(ctx, stagetimeout.SessionIO, s.timeout)
+	defer cancel()
+	return stagetimeout.Err(ctx, s.Service.Delete(ctx, req))
+}
+
+func (s timeoutSessionService) AppendEvent(ctx context.Context, sess session.Session, event *session.Event) error {
+	ctx, cancel := stagetimeout.With(ctx, stagetimeout.SessionIO, s.timeout)
+	defer cancel()
+	return stagetimeout.Err(ctx, s.Service.AppendEvent(ctx, sess, event))
+}
+
+func (s timeoutSessionService) AppendEvents(ctx context.Context, sess session.Session, events []*session.Event) error {
+	ctx, cancel := stagetimeout.With(ctx, stagetimeout.SessionIO, s.timeout)
+	defer cancel()
+	return stagetimeout.Err(ctx, s.Service.AppendEvents(ctx, sess, events))
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+func TestRunner_ModelCallTimeout(t *testing.T) {
+	ctx := t.Context()
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: &stallingLLM{}}))
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             root,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+		Timeouts:          Timeouts{ModelCall: 20 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var runErr error
+	for _, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			runErr = err
+		}
+	}
+	var timeoutErr *agent.StageTimeoutError
+	if !errors.As(runErr, &timeoutErr) || timeoutErr.Stage != "model call" || !errors.Is(runErr, context.DeadlineExceeded) {
+		t.Errorf("Run() error = %v, want a model call *agent.StageTimeoutError", runErr)
+	}
+	var modelErr *agent.ModelError
+	if !errors.As(runErr, &modelErr) {
+		t.Errorf("Run() error = %v, want an *agent.ModelError", runErr)
+	}
+}
+
+func TestRunner_ToolCallTimeout(t *testing.T) {
+	ctx := t.Context()
+	type args struct{}
+	slowTool, err := functiontool.New(functiontool.Config{Name: "slow", Description: "waits for its context"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	llm := &fakeLLM{responses: []*genai.Content{
+		{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "slow"}}}},
+		genai.NewContentFromText("gave up", genai.RoleModel),
+	}}
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: llm, Tools: []tool.Tool{slowTool}}))
+	r, err := New(Config{
+		AppName:           "testApp",
+		Agent:             root,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+		Timeouts:          Timeouts{ToolCall: 20 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	// The invocation goes on, the model gets the timeout as the response.
+	if len(llm.requests) != 2 {
+		t.Fatalf("model was called %d times, want 2", len(llm.requests))
+	}
+	contents := llm.requests[1].Contents
+	resp := contents[len(contents)-1].Parts[0].FunctionResponse
+	if resp == nil || !strings.Contains(fmt.Sprint(resp.Response["error"]), "tool call exceeded its timeout") {
+		t.Errorf("function response = %+v, want the tool call timeout", resp)
+	}
+}
+
+// blockingSessionService blocks the Get calls until their context is done.
+type blockingSessionService struct {
+	session.Service
+}
+
+func (s blockingSessionService) Get(ctx context.Context, req *session.GetRequest) (*session.GetResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestRunner_SessionIOTimeout(t *testing.T) {
+	ctx := t.Context()
+	root := must(llmagent.New(llmagent.Config{Name: "root", Model: &fakeLLM{}}))
+	r, err := New(Config{
+		AppName:        "testApp",
+		Agent:          root,
+		SessionService: blockingSessionService{Service: session.InMemoryService()},
+		Timeouts:       Timeouts{SessionIO: 20 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var runErr error
+	for _, err := range r.Run(ctx, "user", "session", genai.NewContentFromText("go", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			runErr = err
+		}
+	}
+	var timeoutErr *agent.StageTimeoutError
+	if !errors.As(runErr, &timeoutErr) || timeoutErr.Stage != "session I/O" {
+		t.Errorf("Run() error = %v, want a session I/O *agent.StageTimeoutError", runErr)
+	}
+}
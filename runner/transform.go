@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"fmt"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+)
+
+// ContentTransformer rewrites the contents passing through the runner, see
+// Config.InputTransformers and Config.OutputTransformers.
+//
+// Transform must not modify content in place, it returns a new content, or
+// nil to leave the content unchanged.
+type ContentTransformer interface {
+	Transform(ctx agent.ReadonlyContext, content *genai.Content) (*genai.Content, error)
+}
+
+// ContentTransformerFunc is an adapter to use a function as a
+// [ContentTransformer].
+type ContentTransformerFunc func(ctx agent.ReadonlyContext, content *genai.Content) (*genai.Content, error)
+
+// Transform calls f(ctx, content).
+func (f ContentTransformerFunc) Transform(ctx agent.ReadonlyContext, content *genai.Content) (*genai.Content, error) {
+	return f(ctx, content)
+}
+
+// transform applies the transformers to the content in order.
+func transform(ctx agent.ReadonlyContext, transformers []ContentTransformer, content *genai.Content) (*genai.Content, error) {
+	for i, t := range transformers {
+		transformed, err := t.Transform(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("content transformer %d failed: %w", i, err)
+		}
+		if transformed != nil {
+			content = transformed
+		}
+	}
+	return content, nil
+}
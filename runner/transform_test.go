@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/session"
+)
+
+func TestRunner_ContentTransformers(t *testing.T) {
+	llm := &fakeLLM{responses: []*genai.Content{genai.NewContentFromText("it is sunny", genai.RoleModel)}}
+	a := must(llmagent.New(llmagent.Config{Name: "agent", Model: llm}))
+	sessionService := session.InMemoryService()
+	trim := ContentTransformerFunc(func(ctx agent.ReadonlyContext, content *genai.Content) (*genai.Content, error) {
+		return genai.NewContentFromText(strings.TrimSpace(content.Parts[0].Text), genai.Role(content.Role)), nil
+	})
+	unchanged := ContentTransformerFunc(func(ctx agent.ReadonlyContext, content *genai.Content) (*genai.Content, error) {
+		return nil, nil
+	})
+	template := ContentTransformerFunc(func(ctx agent.ReadonlyContext, content *genai.Content) (*genai.Content, error) {
+		return genai.NewContentFromText(ctx.UserID()+": "+content.Parts[0].Text+".", genai.Role(content.Role)), nil
+	})
+	r, err := New(Config{
+		AppName:            "testApp",
+		Agent:              a,
+		SessionService:     sessionService,
+		AutoCreateSession:  true,
+		InputTransformers:  []ContentTransformer{trim, unchanged},
+		OutputTransformers: []ContentTransformer{unchanged, template},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var got []*genai.Content
+	for ev, err := range r.Run(t.Context(), "bob", "session", genai.NewContentFromText("  weather?\n", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		got = append(got, ev.Content)
+	}
+	want := []*genai.Content{genai.NewContentFromText("bob: it is sunny.", genai.RoleModel)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() contents mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*genai.Content{genai.NewContentFromText("weather?", genai.RoleUser)}, llm.requests[0].Contents); diff != "" {
+		t.Errorf("model request contents mismatch (-want +got):\n%s", diff)
+	}
+
+	resp, err := sessionService.Get(t.Context(), &session.GetRequest{AppName: "testApp", UserID: "bob", SessionID: "session"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	var stored []*genai.Content
+	for ev := range resp.Session.Events().All() {
+		stored = append(stored, ev.Content)
+	}
+	want = []*genai.Content{
+		genai.NewContentFromText("weather?", genai.RoleUser),
+		genai.NewContentFromText("bob: it is sunny.", genai.RoleModel),
+	}
+	if diff := cmp.Diff(want, stored); diff != "" {
+		t.Errorf("session contents mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunner_ContentTransformers_Error(t *testing.T) {
+	a := must(llmagent.New(llmagent.Config{Name: "agent", Model: &fakeLLM{}}))
+	errTransform := errors.New("unsupported content")
+	failing := ContentTransformerFunc(func(ctx agent.ReadonlyContext, content *genai.Content) (*genai.Content, error) {
+		return nil, errTransform
+	})
+	for _, cfg := range []Config{
+		{InputTransformers: []ContentTransformer{failing}},
+		{OutputTransformers: []ContentTransformer{failing}},
+	} {
+		cfg.AppName, cfg.Agent, cfg.SessionService, cfg.AutoCreateSession = "testApp", a, session.InMemoryService(), true
+		r, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		var gotErr error
+		for _, err := range r.Run(t.Context(), "user", "session", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+			if err != nil {
+				gotErr = err
+			}
+		}
+		if !errors.Is(gotErr, errTransform) {
+			t.Errorf("Run() error = %v, want %v", gotErr, errTransform)
+		}
+	}
+}
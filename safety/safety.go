@@ -0,0 +1,251 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package safety provides model callbacks blocking the content which
+// violates safety policies: blocklisted terms, prompt injections in tool
+// results, or the findings of external scanners such as Model Armor.
+//
+// The callbacks are installed on an LLM agent:
+//
+//	cfg := safety.Config{
+//		Input:       []safety.Scanner{safety.Blocklist("password")},
+//		ToolResults: []safety.Scanner{safety.PromptInjection()},
+//		Output:      []safety.Scanner{safety.Blocklist("password")},
+//	}
+//	agent, err := llmagent.New(llmagent.Config{
+//		...
+//		BeforeModelCallbacks: []llmagent.BeforeModelCallback{safety.BeforeModel(cfg)},
+//		AfterModelCallbacks:  []llmagent.AfterModelCallback{safety.AfterModel(cfg)},
+//	})
+//
+// A blocked model request or response is replaced by a response with the
+// Config.Message text, whose event carries the [Violation], see
+// [ViolationFromEvent].
+package safety
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+// Stage is the stage of the model call a text is scanned at.
+type Stage string
+
+const (
+	// StageInput is the user messages sent to the model.
+	StageInput Stage = "input"
+	// StageToolResult is the tool results sent to the model.
+	StageToolResult Stage = "tool_result"
+	// StageOutput is the model responses.
+	StageOutput Stage = "output"
+)
+
+// ViolationKey is the CustomMetadata key of the [Violation] of the events of
+// blocked model calls.
+const ViolationKey = "adk_policy_violation"
+
+// ErrorCode is the error code of the responses replacing blocked model calls.
+const ErrorCode = "POLICY_VIOLATION"
+
+// Violation is a violation of a safety policy.
+type Violation struct {
+	// Policy is the name of the violated policy, e.g. "blocklist".
+	Policy string `json:"policy"`
+	// Stage is set by the callbacks.
+	Stage Stage `json:"stage"`
+	// Reason describes the violation.
+	Reason string `json:"reason"`
+	// Matches are the offending parts of the text, if known.
+	Matches []string `json:"matches,omitempty"`
+	// Tool is the name of the tool whose result violated the policy, for
+	// StageToolResult.
+	Tool string `json:"tool,omitempty"`
+}
+
+// ViolationFromEvent returns the violation carried by the event, also after
+// the event was round-tripped through a session service.
+func ViolationFromEvent(event *session.Event) (*Violation, bool) {
+	val, ok := event.CustomMetadata[ViolationKey]
+	if !ok {
+		return nil, false
+	}
+	if v, ok := val.(*Violation); ok {
+		return v, true
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, false
+	}
+	var v Violation
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, false
+	}
+	return &v, true
+}
+
+// Scanner checks texts against a safety policy. External scanners, e.g.
+// Model Armor, implement it by calling their service.
+type Scanner interface {
+	// Scan returns the violation of text, or nil if text complies with the
+	// policy.
+	Scan(ctx context.Context, stage Stage, text string) (*Violation, error)
+}
+
+// ScannerFunc is a [Scanner] implemented by a function.
+type ScannerFunc func(ctx context.Context, stage Stage, text string) (*Violation, error)
+
+// Scan implements Scanner.
+func (f ScannerFunc) Scan(ctx context.Context, stage Stage, text string) (*Violation, error) {
+	return f(ctx, stage, text)
+}
+
+// defaultMessage is the default text of the responses of blocked model calls.
+const defaultMessage = "I can't help with that request."
+
+// Config configures the callbacks.
+type Config struct {
+	// Input scan the user messages of the model requests.
+	Input []Scanner
+	// ToolResults scan the tool results of the model requests.
+	ToolResults []Scanner
+	// Output scan the model responses.
+	Output []Scanner
+	// Message is the text of the responses of blocked model calls. Defaults
+	// to a generic refusal.
+	Message string
+}
+
+// BeforeModel returns a callback scanning the user messages and the tool
+// results sent to the model since its last response. The model is not
+// called if they violate a policy.
+func BeforeModel(cfg Config) llmagent.BeforeModelCallback {
+	return func(ctx agent.CallbackContext, req *model.LLMRequest) (*model.LLMResponse, error) {
+		// Only the contents after the last model response are new: the
+		// history was scanned by the previous calls.
+		for i := len(req.Contents) - 1; i >= 0; i-- {
+			c := req.Contents[i]
+			if c == nil || c.Role == genai.RoleModel {
+				break
+			}
+			for _, part := range c.Parts {
+				v, err := scanPart(ctx, cfg, part)
+				if err != nil || v != nil {
+					return blockedResponse(cfg, v), err
+				}
+			}
+		}
+		return nil, nil
+	}
+}
+
+func scanPart(ctx context.Context, cfg Config, part *genai.Part) (*Violation, error) {
+	switch {
+	case part == nil:
+		return nil, nil
+	case part.Text != "":
+		return scan(ctx, cfg.Input, StageInput, part.Text)
+	case part.FunctionResponse != nil && len(cfg.ToolResults) > 0:
+		var text strings.Builder
+		appendStrings(&text, part.FunctionResponse.Response)
+		v, err := scan(ctx, cfg.ToolResults, StageToolResult, text.String())
+		if v != nil {
+			v.Tool = part.FunctionResponse.Name
+		}
+		return v, err
+	}
+	return nil, nil
+}
+
+// appendStrings appends the strings of a tool result to b, one per line.
+func appendStrings(b *strings.Builder, v any) {
+	switch v := v.(type) {
+	case string:
+		b.WriteString(v)
+		b.WriteByte('\n')
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			appendStrings(b, v[k])
+		}
+	case []any:
+		for _, e := range v {
+			appendStrings(b, e)
+		}
+	}
+}
+
+// AfterModel returns a callback scanning the text of the model responses.
+// The responses which violate a policy are replaced.
+func AfterModel(cfg Config) llmagent.AfterModelCallback {
+	return func(ctx agent.CallbackContext, resp *model.LLMResponse, respErr error) (*model.LLMResponse, error) {
+		if respErr != nil || resp == nil || resp.Content == nil {
+			return nil, nil
+		}
+		var text strings.Builder
+		for _, part := range resp.Content.Parts {
+			if part != nil && part.Text != "" && !part.Thought {
+				text.WriteString(part.Text)
+			}
+		}
+		if text.Len() == 0 {
+			return nil, nil
+		}
+		v, err := scan(ctx, cfg.Output, StageOutput, text.String())
+		if err != nil || v == nil {
+			return nil, err
+		}
+		blocked := blockedResponse(cfg, v)
+		blocked.Partial = resp.Partial
+		blocked.TurnComplete = resp.TurnComplete
+		return blocked, nil
+	}
+}
+
+// scan returns the violation of the first scanner reporting one.
+func scan(ctx context.Context, scanners []Scanner, stage Stage, text string) (*Violation, error) {
+	for _, s := range scanners {
+		v, err := s.Scan(ctx, stage, text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan the %s: %w", stage, err)
+		}
+		if v != nil {
+			v.Stage = stage
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func blockedResponse(cfg Config, v *Violation) *model.LLMResponse {
+	if v == nil {
+		return nil
+	}
+	return &model.LLMResponse{
+		Content:        genai.NewContentFromText(cmp.Or(cfg.Message, defaultMessage), genai.RoleModel),
+		CustomMetadata: map[string]any{ViolationKey: v},
+		ErrorCode:      ErrorCode,
+		ErrorMessage:   fmt.Sprintf("%s policy violation: %s", v.Policy, v.Reason),
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package safety
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Blocklist returns a scanner reporting the texts containing any of the
+// terms. Terms are matched as whole words, ignoring case.
+func Blocklist(terms ...string) Scanner {
+	if len(terms) == 0 {
+		return ScannerFunc(func(context.Context, Stage, string) (*Violation, error) { return nil, nil })
+	}
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = regexp.QuoteMeta(t)
+	}
+	re := regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	return ScannerFunc(func(ctx context.Context, stage Stage, text string) (*Violation, error) {
+		matches := uniqueMatches(re.FindAllString(text, -1))
+		if len(matches) == 0 {
+			return nil, nil
+		}
+		return &Violation{Policy: "blocklist", Reason: "blocklisted terms", Matches: matches}, nil
+	})
+}
+
+// promptInjectionPatterns are the phrases typical of instructions injected in
+// the data read by the tools, e.g. web pages or documents.
+var promptInjectionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override)\s+(?:all\s+|any\s+)?(?:the\s+|your\s+)?(?:previous|prior|above|earlier|preceding|system)\s+(?:instructions|prompts?|rules|directions)`),
+	regexp.MustCompile(`(?i)\b(?:reveal|print|show|repeat|output)\s+(?:me\s+)?(?:your|the)\s+(?:system\s+prompt|(?:initial\s+|hidden\s+)?instructions)`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(?:a|an|in)\b`),
+	regexp.MustCompile(`(?i)\bnew\s+(?:system\s+)?instructions\s*:`),
+	regexp.MustCompile(`(?i)\bdo\s+not\s+(?:tell|inform|let)\s+the\s+user\b`),
+	regexp.MustCompile(`(?im)<\|?(?:im_start|im_end|system)\|?>|\[/?INST\]|^[ \t]*#{2,}[ \t]*(?:system|instruction)s?\b`),
+}
+
+// PromptInjection returns a scanner reporting the texts with the phrases
+// typical of prompt injections, e.g. "ignore all previous instructions". It
+// is meant for the tool results, which may contain instructions planted in
+// the data read by the tools.
+//
+// The heuristics only catch the common injections, so they complement, not
+// replace, a dedicated scanner.
+func PromptInjection() Scanner {
+	return ScannerFunc(func(ctx context.Context, stage Stage, text string) (*Violation, error) {
+		var matches []string
+		for _, re := range promptInjectionPatterns {
+			for _, m := range re.FindAllString(text, -1) {
+				matches = append(matches, strings.TrimSpace(m))
+			}
+		}
+		matches = uniqueMatches(matches)
+		if len(matches) == 0 {
+			return nil, nil
+		}
+		return &Violation{Policy: "prompt_injection", Reason: "suspected prompt injection", Matches: matches}, nil
+	})
+}
+
+func uniqueMatches(matches []string) []string {
+	var unique []string
+	for _, m := range matches {
+		if !slices.ContainsFunc(unique, func(u string) bool { return strings.EqualFold(u, m) }) {
+			unique = append(unique, m)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secretmanager provides a [secrets.Provider] of the secrets of
+// Google Cloud Secret Manager, registered as "gcp" by the launchers.
+package secretmanager
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"google.golang.org/adk/secrets"
+)
+
+type provider struct {
+	project  string
+	versions *secretmanager.ProjectsSecretsVersionsService
+}
+
+// New creates a provider of the secrets of Secret Manager. The names of the
+// secrets are either:
+//   - the resource names of secret versions, e.g.
+//     "projects/my-project/secrets/api-key/versions/3";
+//   - the resource names of secrets, whose latest version is used;
+//   - the IDs of secrets of the given project, optionally followed by
+//     "/versions/<version>".
+//
+// The project is optional if all the names are resource names.
+func New(ctx context.Context, project string, opts ...option.ClientOption) (secrets.Provider, error) {
+	service, err := secretmanager.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret manager service: %w", err)
+	}
+	return &provider{
+		project:  project,
+		versions: secretmanager.NewProjectsSecretsVersionsService(service),
+	}, nil
+}
+
+// versionName returns the resource name of the secret version of name.
+func (p *provider) versionName(name string) (string, error) {
+	if !strings.HasPrefix(name, "projects/") {
+		if p.project == "" {
+			return "", fmt.Errorf("secret %s is not a resource name and no project is set", name)
+		}
+		name = fmt.Sprintf("projects/%s/secrets/%s", p.project, name)
+	}
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	return name, nil
+}
+
+// Secret implements [secrets.Provider].
+func (p *provider) Secret(ctx context.Context, name string) (string, error) {
+	version, err := p.versionName(name)
+	if err != nil {
+		return "", err
+	}
+	resp, err := p.versions.Access(version).Context(ctx).Do()
+	if isStatus(err, http.StatusNotFound) {
+		return "", fmt.Errorf("secret version %s: %w", version, secrets.ErrNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to access the secret version %s: %w", version, err)
+	}
+	if resp.Payload == nil {
+		return "", fmt.Errorf("secret version %s has no payload", version)
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the secret version %s: %w", version, err)
+	}
+	return string(data), nil
+}
+
+func isStatus(err error, code int) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretmanager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"google.golang.org/adk/secrets"
+)
+
+func TestSecret(t *testing.T) {
+	// The payloads of the secret versions, by resource name.
+	versions := map[string]string{
+		"projects/my-project/secrets/api-key/versions/latest": "latest-key",
+		"projects/my-project/secrets/api-key/versions/1":      "first-key",
+		"projects/other/secrets/token/versions/latest":        "other-token",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/"), ":access")
+		payload, ok := versions[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": http.StatusNotFound, "message": "not found"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&secretmanager.AccessSecretVersionResponse{
+			Name:    name,
+			Payload: &secretmanager.SecretPayload{Data: base64.StdEncoding.EncodeToString([]byte(payload))},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	p, err := New(t.Context(), "my-project", option.WithEndpoint(srv.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name, want string
+	}{
+		{name: "api-key", want: "latest-key"},
+		{name: "api-key/versions/1", want: "first-key"},
+		{name: "projects/my-project/secrets/api-key/versions/1", want: "first-key"},
+		{name: "projects/other/secrets/token", want: "other-token"},
+	}
+	for _, tt := range tests {
+		got, err := p.Secret(t.Context(), tt.name)
+		if err != nil {
+			t.Errorf("Secret(%q) error = %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Secret(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := p.Secret(t.Context(), "missing"); !errors.Is(err, secrets.ErrNotFound) {
+		t.Errorf("Secret() of a missing secret error = %v, want %v", err, secrets.ErrNotFound)
+	}
+}
+
+func TestSecret_NoProject(t *testing.T) {
+	p, err := New(t.Context(), "", option.WithEndpoint("http://localhost"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := p.Secret(t.Context(), "api-key"); err == nil {
+		t.Errorf("Secret() of a secret ID without project succeeded, want error")
+	}
+}
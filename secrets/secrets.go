@@ -0,0 +1,144 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets resolves the secret references of configurations, so that
+// secrets such as API keys are not written in config files or flags.
+//
+// A reference is "secret://<provider>/<name>", e.g.
+//
+//	secret://env/GOOGLE_API_KEY
+//	secret://file/secrets/api-key
+//	secret://file//run/secrets/api-key
+//	secret://gcp/projects/my-project/secrets/api-key/versions/latest
+//	secret://vault/adk/gemini#api_key
+//
+// The env and file providers are always registered. The others, e.g. the
+// secretmanager and vault subpackages, are registered with [Register],
+// typically by launchers at startup. Launchers, model constructors and
+// toolsets resolve the references of their configuration with [Resolve].
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Prefix is the prefix of secret references.
+const Prefix = "secret://"
+
+// ErrNotFound is returned, wrapped, by providers when a secret does not
+// exist.
+var ErrNotFound = errors.New("secret not found")
+
+// Provider returns the values of secrets.
+type Provider interface {
+	// Secret returns the value of the secret name. It returns an error
+	// wrapping ErrNotFound if there is no such secret.
+	Secret(ctx context.Context, name string) (string, error)
+}
+
+// ProviderFunc is a [Provider] implemented by a function.
+type ProviderFunc func(ctx context.Context, name string) (string, error)
+
+// Secret implements Provider.
+func (f ProviderFunc) Secret(ctx context.Context, name string) (string, error) {
+	return f(ctx, name)
+}
+
+var (
+	mu        sync.RWMutex
+	providers = map[string]Provider{
+		"env":  Env(),
+		"file": File(),
+	}
+)
+
+// Register makes p the provider of the references "secret://<name>/...",
+// replacing the previous provider of name, if any.
+func Register(name string, p Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	providers[name] = p
+}
+
+// ParseReference returns the provider and the name of the secret of a
+// reference. It reports false if value is not a reference.
+func ParseReference(value string) (provider, name string, ok bool) {
+	rest, ok := strings.CutPrefix(value, Prefix)
+	if !ok {
+		return "", "", false
+	}
+	provider, name, _ = strings.Cut(rest, "/")
+	return provider, name, true
+}
+
+// IsReference reports whether value is a secret reference.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Resolve returns the value of the secret referenced by value, or value as
+// is if it is not a secret reference.
+func Resolve(ctx context.Context, value string) (string, error) {
+	provider, name, ok := ParseReference(value)
+	if !ok {
+		return value, nil
+	}
+	if provider == "" || name == "" {
+		return "", fmt.Errorf("invalid secret reference %q, want %s<provider>/<name>", value, Prefix)
+	}
+	mu.RLock()
+	p, ok := providers[provider]
+	mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("failed to resolve secret %s: unknown provider %q", value, provider)
+	}
+	secret, err := p.Secret(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve secret %s: %w", value, err)
+	}
+	return secret, nil
+}
+
+// Env returns a [Provider] of the environment variables.
+func Env() Provider {
+	return ProviderFunc(func(ctx context.Context, name string) (string, error) {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set: %w", name, ErrNotFound)
+		}
+		return value, nil
+	})
+}
+
+// File returns a [Provider] reading the secrets from files, e.g. mounted by
+// Kubernetes or Docker secrets. Names are the paths of the files, relative to
+// the working directory unless they start with a slash. The trailing
+// newlines of the files are removed.
+func File() Provider {
+	return ProviderFunc(func(ctx context.Context, name string) (string, error) {
+		data, err := os.ReadFile(name)
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("file %s does not exist: %w", name, ErrNotFound)
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	})
+}
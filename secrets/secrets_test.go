@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	t.Setenv("ADK_TEST_SECRET", "from-env")
+	path := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	Register("test", ProviderFunc(func(ctx context.Context, name string) (string, error) {
+		return "test:" + name, nil
+	}))
+
+	tests := []struct {
+		value, want string
+	}{
+		{value: "plain-value", want: "plain-value"},
+		{value: "", want: ""},
+		{value: "secret://env/ADK_TEST_SECRET", want: "from-env"},
+		{value: "secret://file/" + path, want: "from-file"},
+		{value: "secret://test/a/b#c", want: "test:a/b#c"},
+	}
+	for _, tt := range tests {
+		got, err := Resolve(t.Context(), tt.value)
+		if err != nil {
+			t.Errorf("Resolve(%q) error = %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	for _, value := range []string{
+		"secret://",
+		"secret://env",
+		"secret://unknown/name",
+	} {
+		if got, err := Resolve(t.Context(), value); err == nil {
+			t.Errorf("Resolve(%q) = %q, want error", value, got)
+		}
+	}
+	for _, value := range []string{
+		"secret://env/ADK_TEST_MISSING_SECRET",
+		"secret://file/" + filepath.Join(t.TempDir(), "missing"),
+	} {
+		if _, err := Resolve(t.Context(), value); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Resolve(%q) error = %v, want %v", value, err, ErrNotFound)
+		}
+	}
+}
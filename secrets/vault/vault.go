@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vault provides a [secrets.Provider] of the secrets of the KV
+// version 2 secrets engine of HashiCorp Vault, registered as "vault" by the
+// launchers.
+package vault
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"google.golang.org/adk/secrets"
+)
+
+// Config configures the Vault provider.
+type Config struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200.
+	// Defaults to the VAULT_ADDR environment variable.
+	Address string
+	// Token authenticating to Vault. Defaults to the VAULT_TOKEN environment
+	// variable.
+	Token string
+	// Namespace of Vault Enterprise. Defaults to the VAULT_NAMESPACE
+	// environment variable.
+	Namespace string
+	// Mount is the path of the KV secrets engine. Defaults to "secret".
+	Mount string
+	// HTTPClient sends the requests to Vault. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type provider struct {
+	address   string
+	token     string
+	namespace string
+	mount     string
+	client    *http.Client
+}
+
+// defaultField is the field of the secrets read when the name has none.
+const defaultField = "value"
+
+// New creates a provider of the secrets of Vault. The names of the secrets
+// are "<path>#<field>", e.g. "adk/gemini#api_key" for the field api_key of
+// the secret adk/gemini. The field defaults to "value".
+func New(cfg Config) (secrets.Provider, error) {
+	p := &provider{
+		address:   strings.TrimSuffix(cmp.Or(cfg.Address, os.Getenv("VAULT_ADDR")), "/"),
+		token:     cmp.Or(cfg.Token, os.Getenv("VAULT_TOKEN")),
+		namespace: cmp.Or(cfg.Namespace, os.Getenv("VAULT_NAMESPACE")),
+		mount:     strings.Trim(cmp.Or(cfg.Mount, "secret"), "/"),
+		client:    cmp.Or(cfg.HTTPClient, http.DefaultClient),
+	}
+	if p.address == "" {
+		return nil, fmt.Errorf("vault address is required, set it or VAULT_ADDR")
+	}
+	if p.token == "" {
+		return nil, fmt.Errorf("vault token is required, set it or VAULT_TOKEN")
+	}
+	return p, nil
+}
+
+// Secret implements [secrets.Provider].
+func (p *provider) Secret(ctx context.Context, name string) (string, error) {
+	path, field, _ := strings.Cut(name, "#")
+	field = cmp.Or(field, defaultField)
+	u, err := url.JoinPath(p.address, "v1", p.mount, "data", path)
+	if err != nil {
+		return "", fmt.Errorf("invalid vault secret path %q: %w", path, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the vault secret %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("vault secret %s: %w", path, secrets.ErrNotFound)
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("failed to read the vault secret %s: %s", path, resp.Status)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode the vault secret %s: %w", path, err)
+	}
+	value, ok := body.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no field %s: %w", path, field, secrets.ErrNotFound)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("field %s of the vault secret %s is a %T, want a string", field, path, value)
+	}
+	return s, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/adk/secrets"
+)
+
+func TestSecret(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "my-token" || r.Header.Get("X-Vault-Namespace") != "team" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/kv/data/adk/gemini" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"data": map[string]any{"api_key": "my-key", "value": "default", "port": 8080},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	p, err := New(Config{Address: srv.URL + "/", Token: "my-token", Namespace: "team", Mount: "kv"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"adk/gemini#api_key": "my-key",
+		"adk/gemini":         "default",
+	} {
+		got, err := p.Secret(t.Context(), name)
+		if err != nil {
+			t.Errorf("Secret(%q) error = %v", name, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Secret(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	for _, name := range []string{"adk/missing#api_key", "adk/gemini#missing"} {
+		if _, err := p.Secret(t.Context(), name); !errors.Is(err, secrets.ErrNotFound) {
+			t.Errorf("Secret(%q) error = %v, want %v", name, err, secrets.ErrNotFound)
+		}
+	}
+	if _, err := p.Secret(t.Context(), "adk/gemini#port"); err == nil {
+		t.Errorf("Secret() of a number field succeeded, want error")
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+	if _, err := New(Config{Token: "token"}); err == nil {
+		t.Errorf("New() without address succeeded, want error")
+	}
+	if _, err := New(Config{Address: "http://localhost:8200"}); err == nil {
+		t.Errorf("New() without token succeeded, want error")
+	}
+}
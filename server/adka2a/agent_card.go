@@ -16,6 +16,7 @@ package adka2a
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
 	"strings"
@@ -28,6 +29,69 @@ import (
 	"google.golang.org/adk/internal/llminternal"
 )
 
+// AgentCardConfig configures the agent card built by [BuildAgentCard].
+type AgentCardConfig struct {
+	// URL is the endpoint the A2A requests are sent to.
+	URL string
+	// PreferredTransport is the transport served on URL. Defaults to
+	// JSON-RPC.
+	PreferredTransport a2a.TransportProtocol
+	// AdditionalInterfaces are the other transports the agent is served on.
+	// Optional.
+	AdditionalInterfaces []a2a.AgentInterface
+	// SecuritySchemes are the authentication schemes the clients must use
+	// for their requests, any of them is accepted. Optional.
+	SecuritySchemes a2a.NamedSecuritySchemes
+	// Version is the version of the agent. Defaults to 1.0.0.
+	Version string
+}
+
+// BuildAgentCard builds the agent card of the agent: its name, description,
+// skills from [BuildAgentSkills], the input and output modes of the agent and
+// the transports and the authentication requirements of cfg.
+//
+// JSON is advertised as an input mode if an LLM agent of the tree has an
+// input schema, and as an output mode if the agent has an output schema.
+func BuildAgentCard(agent agent.Agent, cfg AgentCardConfig) *a2a.AgentCard {
+	card := &a2a.AgentCard{
+		Name:                 agent.Name(),
+		Description:          agent.Description(),
+		URL:                  cfg.URL,
+		PreferredTransport:   cfg.PreferredTransport,
+		AdditionalInterfaces: cfg.AdditionalInterfaces,
+		ProtocolVersion:      string(a2a.Version),
+		Version:              cfg.Version,
+		DefaultInputModes:    []string{"text/plain"},
+		DefaultOutputModes:   []string{"text/plain"},
+		Skills:               BuildAgentSkills(agent),
+		Capabilities:         a2a.AgentCapabilities{Streaming: true, Extensions: []a2a.AgentExtension{ActionsExtension}},
+		SecuritySchemes:      cfg.SecuritySchemes,
+	}
+	if card.PreferredTransport == "" {
+		card.PreferredTransport = a2a.TransportProtocolJSONRPC
+	}
+	if card.Version == "" {
+		card.Version = "1.0.0"
+	}
+	if hasInputSchema(agent) {
+		card.DefaultInputModes = append(card.DefaultInputModes, "application/json")
+	}
+	if llmAgent, ok := agent.(llminternal.Agent); ok && llminternal.Reveal(llmAgent).OutputSchema != nil {
+		card.DefaultOutputModes = append(card.DefaultOutputModes, "application/json")
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.SecuritySchemes)) {
+		card.Security = append(card.Security, a2a.SecurityRequirements{name: {}})
+	}
+	return card
+}
+
+func hasInputSchema(agent agent.Agent) bool {
+	if llmAgent, ok := agent.(llminternal.Agent); ok && llminternal.Reveal(llmAgent).InputSchema != nil {
+		return true
+	}
+	return slices.ContainsFunc(agent.SubAgents(), hasInputSchema)
+}
+
 // BuildAgentSkills attempts to create a list of [a2a.AgentSkill]s based on agent descriptions and types.
 // This information can be used in [a2a.AgentCard] to help clients understand agent capabilities.
 func BuildAgentSkills(agent agent.Agent) []a2a.AgentSkill {
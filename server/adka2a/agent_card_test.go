@@ -19,6 +19,7 @@ import (
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
@@ -346,6 +347,74 @@ func TestGetAgentSkills_LLMAgent(t *testing.T) {
 	}
 }
 
+func TestBuildAgentCard(t *testing.T) {
+	schema := &genai.Schema{Type: genai.TypeObject}
+	tests := []struct {
+		name  string
+		agent agent.Agent
+		cfg   AgentCardConfig
+		want  *a2a.AgentCard
+	}{
+		{
+			name:  "defaults",
+			agent: must(llmagent.New(llmagent.Config{Name: "assistant", Description: "Helps."})),
+			cfg:   AgentCardConfig{URL: "http://localhost/invoke"},
+			want: &a2a.AgentCard{
+				Name:               "assistant",
+				Description:        "Helps.",
+				URL:                "http://localhost/invoke",
+				PreferredTransport: a2a.TransportProtocolJSONRPC,
+				ProtocolVersion:    string(a2a.Version),
+				Version:            "1.0.0",
+				DefaultInputModes:  []string{"text/plain"},
+				DefaultOutputModes: []string{"text/plain"},
+				Capabilities:       a2a.AgentCapabilities{Streaming: true, Extensions: []a2a.AgentExtension{ActionsExtension}},
+			},
+		},
+		{
+			name: "schemas and security",
+			agent: must(llmagent.New(llmagent.Config{
+				Name:         "extractor",
+				OutputSchema: schema,
+				SubAgents:    []agent.Agent{must(llmagent.New(llmagent.Config{Name: "parser", InputSchema: schema}))},
+			})),
+			cfg: AgentCardConfig{
+				URL:                "http://localhost/invoke",
+				PreferredTransport: a2a.TransportProtocolGRPC,
+				Version:            "2.0.0",
+				SecuritySchemes: a2a.NamedSecuritySchemes{
+					"oauth":  a2a.OpenIDConnectSecurityScheme{OpenIDConnectURL: "https://accounts.example.com"},
+					"apiKey": a2a.APIKeySecurityScheme{Name: "X-Api-Key", In: a2a.APIKeySecuritySchemeInHeader},
+				},
+			},
+			want: &a2a.AgentCard{
+				Name:               "extractor",
+				URL:                "http://localhost/invoke",
+				PreferredTransport: a2a.TransportProtocolGRPC,
+				ProtocolVersion:    string(a2a.Version),
+				Version:            "2.0.0",
+				DefaultInputModes:  []string{"text/plain", "application/json"},
+				DefaultOutputModes: []string{"text/plain", "application/json"},
+				Capabilities:       a2a.AgentCapabilities{Streaming: true, Extensions: []a2a.AgentExtension{ActionsExtension}},
+				SecuritySchemes: a2a.NamedSecuritySchemes{
+					"oauth":  a2a.OpenIDConnectSecurityScheme{OpenIDConnectURL: "https://accounts.example.com"},
+					"apiKey": a2a.APIKeySecurityScheme{Name: "X-Api-Key", In: a2a.APIKeySecuritySchemeInHeader},
+				},
+				Security: []a2a.SecurityRequirements{{"apiKey": {}}, {"oauth": {}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildAgentCard(tt.agent, tt.cfg)
+			tt.want.Skills = BuildAgentSkills(tt.agent)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("BuildAgentCard() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestReplacePronouns(t *testing.T) {
 	testCases := []struct {
 		input string
@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adka2a
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/session"
+)
+
+var (
+	metadataArtifactFileNameKey = ToA2AMetaKey("artifact_file_name")
+	metadataArtifactVersionKey  = ToA2AMetaKey("artifact_version")
+)
+
+// ArtifactRef references a version of an ADK artifact.
+type ArtifactRef struct {
+	AppName, UserID, SessionID, FileName string
+	Version                              int64
+}
+
+// ArtifactConfig controls how the files produced by an agent reach A2A clients.
+type ArtifactConfig struct {
+	// SendSavedArtifacts makes the executor send the artifacts saved during an
+	// invocation, e.g. by tools, as A2A artifacts named after their files. The
+	// artifacts are loaded from the artifact service of the runner.
+	SendSavedArtifacts bool
+
+	// MaxInlineSize is the size in bytes above which files are sent by URI
+	// instead of with their bytes, if FileURI is set. Inline binary parts of
+	// events over the size are saved as artifacts of the session to get one.
+	// Zero means no limit.
+	MaxInlineSize int
+
+	// FileURI returns the URI A2A clients download an artifact from, e.g. a
+	// signed URL of the storage of the artifact service.
+	FileURI func(ctx context.Context, ref ArtifactRef) (string, error)
+}
+
+// ToA2AArtifact converts a version of an ADK artifact to an A2A artifact
+// named after its file. The file name and version are also stored in the
+// artifact metadata, for [ToGenAIArtifact].
+func ToA2AArtifact(fileName string, version int64, part *genai.Part) (*a2a.Artifact, error) {
+	a2aPart, err := ToA2APart(part, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &a2a.Artifact{
+		ID:    a2a.NewArtifactID(),
+		Name:  fileName,
+		Parts: a2a.ContentParts{a2aPart},
+		Metadata: map[string]any{
+			metadataArtifactFileNameKey: fileName,
+			metadataArtifactVersionKey:  version,
+		},
+	}, nil
+}
+
+// ToGenAIArtifact converts an A2A artifact to the file name and the part of
+// an ADK artifact, e.g. to save the files produced by a remote agent with
+// [artifact.Service]. The artifact must have a single part. The file name is
+// the one of [ToA2AArtifact] if set, else the artifact or file name.
+func ToGenAIArtifact(a2aArtifact *a2a.Artifact) (string, *genai.Part, error) {
+	if len(a2aArtifact.Parts) != 1 {
+		return "", nil, fmt.Errorf("artifact %s has %d parts, want 1", a2aArtifact.ID, len(a2aArtifact.Parts))
+	}
+	part, err := ToGenAIPart(a2aArtifact.Parts[0])
+	if err != nil {
+		return "", nil, err
+	}
+	fileName, _ := a2aArtifact.Metadata[metadataArtifactFileNameKey].(string)
+	if fileName == "" {
+		fileName = a2aArtifact.Name
+	}
+	if filePart, ok := a2aArtifact.Parts[0].(a2a.FilePart); ok && fileName == "" {
+		switch file := filePart.File.(type) {
+		case a2a.FileBytes:
+			fileName = file.Name
+		case a2a.FileURI:
+			fileName = file.Name
+		}
+	}
+	if fileName == "" {
+		return "", nil, fmt.Errorf("artifact %s has no name", a2aArtifact.ID)
+	}
+	return fileName, part, nil
+}
+
+// sendByURI replaces the files of the parts larger than the configured
+// inline size with their URIs. save is called to get an artifact reference
+// for the file of the part at the given index.
+func (e *Executor) sendByURI(ctx context.Context, parts []a2a.Part, save func(i int, blob *genai.Blob) (ArtifactRef, error)) error {
+	cfg := e.config.ArtifactConfig
+	if cfg.MaxInlineSize <= 0 || cfg.FileURI == nil {
+		return nil
+	}
+	for i, part := range parts {
+		filePart, ok := part.(a2a.FilePart)
+		if !ok {
+			continue
+		}
+		file, ok := filePart.File.(a2a.FileBytes)
+		if !ok || base64.StdEncoding.DecodedLen(len(file.Bytes)) <= cfg.MaxInlineSize {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(file.Bytes)
+		if err != nil {
+			return err
+		}
+		if len(data) <= cfg.MaxInlineSize {
+			continue
+		}
+		ref, err := save(i, &genai.Blob{Data: data, MIMEType: file.MimeType, DisplayName: file.Name})
+		if err != nil {
+			return err
+		}
+		uri, err := cfg.FileURI(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to get the URI of artifact %s: %w", ref.FileName, err)
+		}
+		filePart.File = a2a.FileURI{FileMeta: file.FileMeta, URI: uri}
+		parts[i] = filePart
+	}
+	return nil
+}
+
+// sendInlineFilesByURI saves the inline files of an event larger than the
+// configured inline size as artifacts, and sends their URIs instead.
+func (e *Executor) sendInlineFilesByURI(ctx context.Context, meta invocationMeta, event *session.Event, update *a2a.TaskArtifactUpdateEvent) error {
+	service := e.config.RunnerConfig.ArtifactService
+	if service == nil || update == nil {
+		return nil
+	}
+	return e.sendByURI(ctx, update.Artifact.Parts, func(i int, blob *genai.Blob) (ArtifactRef, error) {
+		fileName := blob.DisplayName
+		if fileName == "" {
+			fileName = fmt.Sprintf("%s_%d", event.ID, i)
+		}
+		resp, err := service.Save(ctx, &artifact.SaveRequest{
+			AppName:   e.config.RunnerConfig.AppName,
+			UserID:    meta.userID,
+			SessionID: meta.sessionID,
+			FileName:  fileName,
+			Part:      &genai.Part{InlineData: blob},
+		})
+		if err != nil {
+			return ArtifactRef{}, fmt.Errorf("failed to save artifact %s: %w", fileName, err)
+		}
+		return e.artifactRef(meta, fileName, resp.Version), nil
+	})
+}
+
+// savedArtifactEvents loads the artifacts saved by an event and converts
+// them to A2A artifact updates.
+func (e *Executor) savedArtifactEvents(ctx context.Context, meta invocationMeta, event *session.Event) ([]*a2a.TaskArtifactUpdateEvent, error) {
+	service := e.config.RunnerConfig.ArtifactService
+	if !e.config.ArtifactConfig.SendSavedArtifacts || service == nil || event == nil || len(event.Actions.ArtifactDelta) == 0 {
+		return nil, nil
+	}
+	var result []*a2a.TaskArtifactUpdateEvent
+	for _, fileName := range slices.Sorted(maps.Keys(event.Actions.ArtifactDelta)) {
+		ref := e.artifactRef(meta, fileName, event.Actions.ArtifactDelta[fileName])
+		resp, err := service.Load(ctx, &artifact.LoadRequest{
+			AppName:   ref.AppName,
+			UserID:    ref.UserID,
+			SessionID: ref.SessionID,
+			FileName:  ref.FileName,
+			Version:   ref.Version,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load artifact %s: %w", fileName, err)
+		}
+		a2aArtifact, err := ToA2AArtifact(fileName, ref.Version, resp.Part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert artifact %s: %w", fileName, err)
+		}
+		err = e.sendByURI(ctx, a2aArtifact.Parts, func(int, *genai.Blob) (ArtifactRef, error) { return ref, nil })
+		if err != nil {
+			return nil, err
+		}
+		update := a2a.NewArtifactEvent(meta.reqCtx, a2aArtifact.Parts...)
+		update.Artifact.Name = a2aArtifact.Name
+		update.Artifact.Metadata = a2aArtifact.Metadata
+		update.LastChunk = true
+		result = append(result, update)
+	}
+	return result, nil
+}
+
+func (e *Executor) artifactRef(meta invocationMeta, fileName string, version int64) ArtifactRef {
+	return ArtifactRef{
+		AppName:   e.config.RunnerConfig.AppName,
+		UserID:    meta.userID,
+		SessionID: meta.sessionID,
+		FileName:  fileName,
+		Version:   version,
+	}
+}
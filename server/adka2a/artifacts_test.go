@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adka2a
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+func TestToA2AArtifact(t *testing.T) {
+	tests := []struct {
+		name     string
+		part     *genai.Part
+		wantPart a2a.Part
+	}{
+		{
+			name:     "bytes",
+			part:     &genai.Part{InlineData: &genai.Blob{Data: []byte("hello"), MIMEType: "text/plain"}},
+			wantPart: a2a.FilePart{File: a2a.FileBytes{FileMeta: a2a.FileMeta{MimeType: "text/plain"}, Bytes: "aGVsbG8="}},
+		},
+		{
+			name:     "uri",
+			part:     &genai.Part{FileData: &genai.FileData{FileURI: "gs://bucket/report.pdf", MIMEType: "application/pdf"}},
+			wantPart: a2a.FilePart{File: a2a.FileURI{FileMeta: a2a.FileMeta{MimeType: "application/pdf"}, URI: "gs://bucket/report.pdf"}},
+		},
+		{
+			name:     "text",
+			part:     genai.NewPartFromText("notes"),
+			wantPart: a2a.TextPart{Text: "notes"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToA2AArtifact("report", 2, tc.part)
+			if err != nil {
+				t.Fatalf("ToA2AArtifact() error = %v", err)
+			}
+			if got.Name != "report" {
+				t.Errorf("artifact name = %q, want %q", got.Name, "report")
+			}
+			if diff := cmp.Diff(a2a.ContentParts{tc.wantPart}, got.Parts); diff != "" {
+				t.Errorf("artifact parts mismatch (-want +got):\n%s", diff)
+			}
+
+			fileName, part, err := ToGenAIArtifact(got)
+			if err != nil {
+				t.Fatalf("ToGenAIArtifact() error = %v", err)
+			}
+			if fileName != "report" {
+				t.Errorf("ToGenAIArtifact() file name = %q, want %q", fileName, "report")
+			}
+			if diff := cmp.Diff(tc.part, part); diff != "" {
+				t.Errorf("ToGenAIArtifact() part mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestToGenAIArtifact_FileName(t *testing.T) {
+	file := a2a.FilePart{File: a2a.FileBytes{FileMeta: a2a.FileMeta{Name: "chart.png"}, Bytes: "aGVsbG8="}}
+	fileName, _, err := ToGenAIArtifact(&a2a.Artifact{ID: "a", Parts: a2a.ContentParts{file}})
+	if err != nil {
+		t.Fatalf("ToGenAIArtifact() error = %v", err)
+	}
+	if fileName != "chart.png" {
+		t.Errorf("ToGenAIArtifact() file name = %q, want %q", fileName, "chart.png")
+	}
+
+	if _, _, err := ToGenAIArtifact(&a2a.Artifact{ID: "a", Parts: a2a.ContentParts{a2a.TextPart{Text: "x"}}}); err == nil {
+		t.Error("ToGenAIArtifact() error = nil, want an error for an unnamed artifact")
+	}
+}
+
+func fileURI(_ context.Context, ref ArtifactRef) (string, error) {
+	return fmt.Sprintf("https://files.example.com/%s/%s?version=%d", ref.SessionID, ref.FileName, ref.Version), nil
+}
+
+func TestExecutor_Artifacts(t *testing.T) {
+	task := &a2a.Task{ID: a2a.NewTaskID(), ContextID: a2a.NewContextID()}
+	hiMsg := a2a.NewMessageForTask(a2a.MessageRoleUser, task, a2a.TextPart{Text: "hi"})
+	userID := "A2A_USER_" + task.ContextID
+	largeFile := make([]byte, 100)
+
+	tests := []struct {
+		name      string
+		config    ArtifactConfig
+		saved     map[string]*genai.Part
+		event     *session.Event
+		wantFiles []a2a.Part
+		wantSaved []string
+		wantNames []string
+	}{
+		{
+			name:   "saved artifacts sent with bytes",
+			config: ArtifactConfig{SendSavedArtifacts: true},
+			saved:  map[string]*genai.Part{"report.txt": {InlineData: &genai.Blob{Data: []byte("hello"), MIMEType: "text/plain"}}},
+			event: &session.Event{Actions: session.EventActions{
+				ArtifactDelta: map[string]int64{"report.txt": 1},
+			}},
+			wantFiles: []a2a.Part{a2a.FilePart{File: a2a.FileBytes{FileMeta: a2a.FileMeta{MimeType: "text/plain"}, Bytes: "aGVsbG8="}}},
+			wantNames: []string{"report.txt"},
+		},
+		{
+			name:   "large saved artifacts sent by uri",
+			config: ArtifactConfig{SendSavedArtifacts: true, MaxInlineSize: 10, FileURI: fileURI},
+			saved:  map[string]*genai.Part{"data.bin": {InlineData: &genai.Blob{Data: largeFile, MIMEType: "application/octet-stream"}}},
+			event: &session.Event{Actions: session.EventActions{
+				ArtifactDelta: map[string]int64{"data.bin": 1},
+			}},
+			wantFiles: []a2a.Part{a2a.FilePart{File: a2a.FileURI{
+				FileMeta: a2a.FileMeta{MimeType: "application/octet-stream"},
+				URI:      "https://files.example.com/" + task.ContextID + "/data.bin?version=1",
+			}}},
+			wantNames: []string{"data.bin"},
+		},
+		{
+			name:   "saved artifacts not sent",
+			saved:  map[string]*genai.Part{"report.txt": genai.NewPartFromText("hello")},
+			event:  &session.Event{Actions: session.EventActions{ArtifactDelta: map[string]int64{"report.txt": 1}}},
+			config: ArtifactConfig{},
+		},
+		{
+			name:   "large event parts sent by uri",
+			config: ArtifactConfig{MaxInlineSize: 10, FileURI: fileURI},
+			event: &session.Event{ID: "event-1", LLMResponse: model.LLMResponse{Content: genai.NewContentFromParts([]*genai.Part{
+				{InlineData: &genai.Blob{Data: largeFile, MIMEType: "image/png", DisplayName: "chart.png"}},
+				{InlineData: &genai.Blob{Data: []byte("small"), MIMEType: "image/png"}},
+			}, genai.RoleModel)}},
+			wantFiles: []a2a.Part{
+				a2a.FilePart{File: a2a.FileURI{
+					FileMeta: a2a.FileMeta{Name: "chart.png", MimeType: "image/png"},
+					URI:      "https://files.example.com/" + task.ContextID + "/chart.png?version=1",
+				}},
+				a2a.FilePart{File: a2a.FileBytes{FileMeta: a2a.FileMeta{MimeType: "image/png"}, Bytes: "c21hbGw="}},
+			},
+			wantSaved: []string{"chart.png"},
+			wantNames: []string{""},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			artifacts := artifact.InMemoryService()
+			for fileName, part := range tc.saved {
+				_, err := artifacts.Save(ctx, &artifact.SaveRequest{
+					AppName: "test", UserID: userID, SessionID: task.ContextID, FileName: fileName, Part: part,
+				})
+				if err != nil {
+					t.Fatalf("artifacts.Save() error = %v", err)
+				}
+			}
+			agent, err := newEventReplayAgent([]*session.Event{tc.event}, nil)
+			if err != nil {
+				t.Fatalf("newEventReplayAgent() error = %v", err)
+			}
+			executor := NewExecutor(ExecutorConfig{
+				RunnerConfig: runner.Config{
+					AppName:         "test",
+					Agent:           agent,
+					SessionService:  session.InMemoryService(),
+					ArtifactService: artifacts,
+				},
+				ArtifactConfig: tc.config,
+			})
+
+			queue := &testQueue{Queue: newInMemoryQueue(t)}
+			reqCtx := &a2asrv.RequestContext{TaskID: task.ID, ContextID: task.ContextID, Message: hiMsg, StoredTask: task}
+			if err := executor.Execute(ctx, reqCtx, queue); err != nil {
+				t.Fatalf("executor.Execute() error = %v", err)
+			}
+
+			var gotFiles []a2a.Part
+			var gotNames []string
+			for _, event := range queue.events {
+				if update, ok := event.(*a2a.TaskArtifactUpdateEvent); ok && len(update.Artifact.Parts) > 0 {
+					gotFiles = append(gotFiles, update.Artifact.Parts...)
+					gotNames = append(gotNames, update.Artifact.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantFiles, gotFiles); diff != "" {
+				t.Errorf("artifact parts mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("artifact names mismatch (-want +got):\n%s", diff)
+			}
+			for _, fileName := range tc.wantSaved {
+				if _, err := artifacts.Load(ctx, &artifact.LoadRequest{
+					AppName: "test", UserID: userID, SessionID: task.ContextID, FileName: fileName,
+				}); err != nil {
+					t.Errorf("artifacts.Load(%s) error = %v", fileName, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adka2a
+
+import (
+	"context"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+
+	"google.golang.org/adk/agent/identity"
+)
+
+var metadataSkillIDKey = ToA2AMetaKey("skill_id")
+
+// AuthorizationRequest describes an A2A request to authorize.
+type AuthorizationRequest struct {
+	// Caller is the identity of the caller, nil for anonymous callers.
+	Caller *identity.Identity
+	// Method is the A2A method called, e.g. message/send.
+	Method string
+	// Message is the message sent, nil for the methods not sending one.
+	Message *a2a.Message
+	// SkillID is the skill of the agent card the message is addressed to, set
+	// by the client in the adk_skill_id metadata of the message. Optional.
+	SkillID string
+}
+
+// AuthConfig configures the authentication and authorization of the callers
+// of an A2A server, see [NewAuthInterceptor].
+type AuthConfig struct {
+	// RequireIdentity rejects the requests of the callers without identity
+	// with a2a.ErrUnauthenticated.
+	RequireIdentity bool
+
+	// UserID returns the user ID of the sessions of a caller. Defaults to the
+	// subject of its identity.
+	UserID func(caller *identity.Identity) string
+
+	// Authorize is called for every request, e.g. to check the claims of the
+	// caller for the skill of the message. A returned error rejects the
+	// request, wrap a2a.ErrUnauthorized to report a permission error to the
+	// caller. Optional.
+	Authorize func(ctx context.Context, req *AuthorizationRequest) error
+}
+
+// NewAuthInterceptor returns an a2asrv.CallInterceptor authenticating and
+// authorizing the callers of an A2A server, to install with
+// a2asrv.WithCallInterceptor.
+//
+// The identity of a caller is the one of identity.FromContext, e.g. set by
+// identity.Middleware of the launcher from the credentials of the request.
+// It becomes the a2asrv user of the call, so the [Executor] runs the agent
+// in the sessions of the user ID of the caller, isolated from the sessions
+// of other callers, and with the identity in its context.
+func NewAuthInterceptor(cfg AuthConfig) a2asrv.CallInterceptor {
+	return &authInterceptor{cfg: cfg}
+}
+
+type authInterceptor struct {
+	a2asrv.PassthroughCallInterceptor
+	cfg AuthConfig
+}
+
+func (ai *authInterceptor) Before(ctx context.Context, callCtx *a2asrv.CallContext, req *a2asrv.Request) (context.Context, error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok && ai.cfg.RequireIdentity {
+		return ctx, a2a.ErrUnauthenticated
+	}
+	if ok {
+		userID := caller.Subject
+		if ai.cfg.UserID != nil {
+			userID = ai.cfg.UserID(caller)
+		}
+		if userID == "" {
+			return ctx, a2a.NewError(a2a.ErrUnauthenticated, "no user ID for the caller")
+		}
+		callCtx.User = &identityUser{userID: userID, identity: caller}
+	}
+	if ai.cfg.Authorize == nil {
+		return ctx, nil
+	}
+	authzReq := &AuthorizationRequest{Caller: caller, Method: callCtx.Method()}
+	if params, ok := req.Payload.(*a2a.MessageSendParams); ok && params.Message != nil {
+		authzReq.Message = params.Message
+		authzReq.SkillID, _ = params.Message.Metadata[metadataSkillIDKey].(string)
+	}
+	if err := ai.cfg.Authorize(ctx, authzReq); err != nil {
+		return ctx, err
+	}
+	return ctx, nil
+}
+
+// identityUser is the a2asrv user of an authenticated caller.
+type identityUser struct {
+	userID   string
+	identity *identity.Identity
+}
+
+var _ a2asrv.User = (*identityUser)(nil)
+
+func (u *identityUser) Name() string {
+	return u.userID
+}
+
+func (u *identityUser) Authenticated() bool {
+	return true
+}
+
+// withCallerIdentity returns a copy of ctx carrying the identity of the
+// caller authenticated by the auth interceptor, if any, as the execution
+// might not run in the context of the request.
+func withCallerIdentity(ctx context.Context) context.Context {
+	if _, ok := identity.FromContext(ctx); ok {
+		return ctx
+	}
+	if callCtx, ok := a2asrv.CallContextFrom(ctx); ok {
+		if user, ok := callCtx.User.(*identityUser); ok {
+			return identity.ToContext(ctx, user.identity)
+		}
+	}
+	return ctx
+}
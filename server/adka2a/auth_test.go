@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adka2a
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/a2aproject/a2a-go/a2asrv"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/identity"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/session"
+)
+
+func TestAuthInterceptor(t *testing.T) {
+	type run struct {
+		userID  string
+		subject string
+	}
+	runs := make(chan run, 1)
+	agnt, err := agent.New(agent.Config{
+		Name: "test",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				r := run{userID: ctx.Session().UserID()}
+				if caller, ok := identity.FromContext(ctx); ok {
+					r.subject = caller.Subject
+				}
+				runs <- r
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+
+	authorize := func(ctx context.Context, req *AuthorizationRequest) error {
+		if req.SkillID == "admin" && req.Caller.Claims["role"] != "admin" {
+			return a2a.NewError(a2a.ErrUnauthorized, "admin role required")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		cfg     AuthConfig
+		caller  *identity.Identity
+		skillID string
+		wantErr error
+		wantRun run
+	}{
+		{
+			name:    "caller sessions",
+			caller:  &identity.Identity{Subject: "alice"},
+			wantRun: run{userID: "alice", subject: "alice"},
+		},
+		{
+			name:    "custom user id",
+			cfg:     AuthConfig{UserID: func(caller *identity.Identity) string { return "user:" + caller.Email }},
+			caller:  &identity.Identity{Subject: "alice", Email: "alice@example.com"},
+			wantRun: run{userID: "user:alice@example.com", subject: "alice"},
+		},
+		{
+			name:    "identity required",
+			cfg:     AuthConfig{RequireIdentity: true},
+			wantErr: a2a.ErrUnauthenticated,
+		},
+		{
+			name:    "skill authorized",
+			cfg:     AuthConfig{Authorize: authorize},
+			caller:  &identity.Identity{Subject: "bob", Claims: map[string]any{"role": "admin"}},
+			skillID: "admin",
+			wantRun: run{userID: "bob", subject: "bob"},
+		},
+		{
+			name:    "skill unauthorized",
+			cfg:     AuthConfig{Authorize: authorize},
+			caller:  &identity.Identity{Subject: "alice"},
+			skillID: "admin",
+			wantErr: a2a.ErrUnauthorized,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			executor := NewExecutor(ExecutorConfig{
+				RunnerConfig: runner.Config{AppName: "test", Agent: agnt, SessionService: session.InMemoryService()},
+			})
+			handler := a2asrv.NewJSONRPCHandler(a2asrv.NewHandler(executor, a2asrv.WithCallInterceptor(NewAuthInterceptor(tc.cfg))))
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.caller != nil {
+					r = r.WithContext(identity.ToContext(r.Context(), tc.caller))
+				}
+				handler.ServeHTTP(w, r)
+			}))
+			defer server.Close()
+
+			client, err := a2aclient.NewFromCard(t.Context(), &a2a.AgentCard{
+				URL:                server.URL,
+				PreferredTransport: a2a.TransportProtocolJSONRPC,
+			})
+			if err != nil {
+				t.Fatalf("a2aclient.NewFromCard() error = %v", err)
+			}
+			msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hi"})
+			if tc.skillID != "" {
+				msg.Metadata = map[string]any{ToA2AMetaKey("skill_id"): tc.skillID}
+			}
+			_, err = client.SendMessage(t.Context(), &a2a.MessageSendParams{Message: msg})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("client.SendMessage() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("client.SendMessage() error = %v", err)
+			}
+			if got := <-runs; got != tc.wantRun {
+				t.Errorf("agent run = %+v, want %+v", got, tc.wantRun)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor_Anonymous(t *testing.T) {
+	interceptor := NewAuthInterceptor(AuthConfig{})
+	ctx, callCtx := a2asrv.WithCallContext(t.Context(), nil)
+	if _, err := interceptor.Before(ctx, callCtx, &a2asrv.Request{}); err != nil {
+		t.Fatalf("Before() error = %v", err)
+	}
+	if callCtx.User.Authenticated() {
+		t.Errorf("callCtx.User = %v, want an unauthenticated user", callCtx.User)
+	}
+}
@@ -13,4 +13,7 @@
 // limitations under the License.
 
 // Package adka2a allows to expose ADK agents via A2A.
+//
+// Agents served over A2A are consumed with remoteagent.NewA2A, which uses
+// the event conversions of this package.
 package adka2a
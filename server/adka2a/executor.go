@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2aclient"
@@ -105,6 +106,10 @@ type ExecutorConfig struct {
 	// Defaults to [OutputArtifactPerRun].
 	OutputMode OutputMode
 
+	// ArtifactConfig controls how the files produced by the agent reach A2A clients: the artifacts saved
+	// during an invocation and the transport of large files.
+	ArtifactConfig ArtifactConfig
+
 	// A2AExecutionCleanupCallback is a callback which will be called after an execution or cancellation has completed or failed.
 	// If not provided, the default behavior is to log the failure cause, if any.
 	A2AExecutionCleanupCallback A2AExecutionCleanupCallback
@@ -116,6 +121,8 @@ var _ a2asrv.AgentExecutor = (*Executor)(nil)
 //   - If the input doesn't reference any a2a.Task, produce a Task with TaskStateSubmitted state.
 //   - Right before runner.Runner invocation, produce TaskStatusUpdateEvent with TaskStateWorking.
 //   - For every session.Event produce a TaskArtifactUpdateEvent{Append=true} with transformed parts.
+//   - If ArtifactConfig.SendSavedArtifacts is set, for every artifact saved by a session.Event produce a
+//     TaskArtifactUpdateEvent{LastChunk=true} with an artifact named after the file.
 //   - After the last session.Event is processed produce an empty TaskArtifactUpdateEvent{Append=true} with LastChunk=true,
 //     if at least one artifact update was produced during the run.
 //   - If there was an LLMResponse with non-zero error code, produce a TaskStatusUpdateEvent with TaskStateFailed.
@@ -123,6 +130,10 @@ var _ a2asrv.AgentExecutor = (*Executor)(nil)
 //     Else produce a TaskStatusUpdateEvent with TaskStateCompleted.
 type Executor struct {
 	config ExecutorConfig
+
+	// running holds the plugins of the running executions, by task ID, to
+	// cancel their invocations.
+	running sync.Map
 }
 
 // NewExecutor creates an initialized [Executor] instance.
@@ -135,6 +146,7 @@ func (e *Executor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, q
 	if msg == nil {
 		return fmt.Errorf("message not provided")
 	}
+	ctx = withCallerIdentity(ctx)
 	content, err := toGenAIContent(ctx, msg, e.config.A2APartConverter)
 	if err != nil {
 		return fmt.Errorf("a2a message conversion failed: %w", err)
@@ -144,6 +156,8 @@ func (e *Executor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, q
 	if err != nil {
 		return fmt.Errorf("failed to install a2a-executor plugin: %w", err)
 	}
+	e.running.Store(reqCtx.TaskID, executorPlugin)
+	defer e.running.CompareAndDelete(reqCtx.TaskID, executorPlugin)
 
 	r, err := runner.New(runnerCfg)
 	if err != nil {
@@ -197,7 +211,12 @@ func (e *Executor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, q
 	return e.process(executorContext, r, processor, queue)
 }
 
+// Cancel cancels the invocation of the task, if it is running in this process: in-flight model and tool calls are
+// interrupted and the cancellation is recorded in the session. The task is then reported as canceled.
 func (e *Executor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	if running, ok := e.running.Load(reqCtx.TaskID); ok {
+		running.(*executorPlugin).cancel("task cancelled by the A2A client")
+	}
 	event := a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateCanceled, nil)
 	event.Final = true
 	return queue.Write(ctx, event)
@@ -295,6 +314,13 @@ func (e *Executor) process(ctx ExecutorContext, r *runner.Runner, processor *eve
 		}
 
 		a2aEvent, pErr := processor.process(ctx, adkEvent)
+		if pErr == nil {
+			pErr = e.sendInlineFilesByURI(ctx, meta, adkEvent, a2aEvent)
+		}
+		var artifactEvents []*a2a.TaskArtifactUpdateEvent
+		if pErr == nil {
+			artifactEvents, pErr = e.savedArtifactEvents(ctx, meta, adkEvent)
+		}
 		if pErr == nil && a2aEvent != nil && e.config.AfterEventCallback != nil {
 			pErr = e.config.AfterEventCallback(ctx, adkEvent, a2aEvent)
 		}
@@ -309,6 +335,11 @@ func (e *Executor) process(ctx ExecutorContext, r *runner.Runner, processor *eve
 				return fmt.Errorf("event write failed: %w", err)
 			}
 		}
+		for _, artifactEvent := range artifactEvents {
+			if err := q.Write(ctx, artifactEvent); err != nil {
+				return fmt.Errorf("event write failed: %w", err)
+			}
+		}
 	}
 
 	finalStatus := processor.makeFinalStatusUpdate()
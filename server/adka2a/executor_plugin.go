@@ -16,6 +16,7 @@ package adka2a
 
 import (
 	"slices"
+	"sync"
 
 	"google.golang.org/genai"
 
@@ -29,6 +30,13 @@ type executorPlugin struct {
 	plugin *plugin.Plugin
 
 	invocationSession session.Session
+
+	mu sync.Mutex
+	// invocation is the invocation of the execution, set once it started.
+	invocation agent.InvocationContext
+	// cancelReason is set if the execution was cancelled before its
+	// invocation started.
+	cancelReason string
 }
 
 func withExecutorPlugin(cfg runner.Config) (runner.Config, *executorPlugin, error) {
@@ -46,6 +54,12 @@ func newExecutorPlugin() (*executorPlugin, error) {
 		Name: "a2a-executor",
 		BeforeRunCallback: func(ic agent.InvocationContext) (*genai.Content, error) {
 			execPlugin.invocationSession = ic.Session()
+			execPlugin.mu.Lock()
+			defer execPlugin.mu.Unlock()
+			execPlugin.invocation = ic
+			if execPlugin.cancelReason != "" {
+				ic.Cancel(execPlugin.cancelReason)
+			}
 			return nil, nil
 		},
 	})
@@ -55,3 +69,15 @@ func newExecutorPlugin() (*executorPlugin, error) {
 	execPlugin.plugin = plugin
 	return execPlugin, nil
 }
+
+// cancel cancels the invocation of the execution, or the invocation about to
+// start, with the given reason.
+func (p *executorPlugin) cancel(reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.invocation != nil {
+		p.invocation.Cancel(reason)
+		return
+	}
+	p.cancelReason = reason
+}
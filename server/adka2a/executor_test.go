@@ -935,3 +935,84 @@ func TestExecutor_OutputArtifactPerEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_Cancel_Invocation(t *testing.T) {
+	sessionService := session.InMemoryService()
+	started := make(chan struct{})
+	cancelled := make(chan bool, 1)
+
+	agent, err := agent.New(agent.Config{
+		Name: "test",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				close(started)
+				<-ctx.Done()
+				cancelled <- agent.IsInvocationCancelled(ctx)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+	executor := NewExecutor(ExecutorConfig{
+		RunnerConfig: runner.Config{AppName: agent.Name(), Agent: agent, SessionService: sessionService},
+	})
+	server := startA2AServer(executor)
+	defer server.Close()
+
+	client, err := a2aclient.NewFromCard(t.Context(), &a2a.AgentCard{URL: server.URL, PreferredTransport: a2a.TransportProtocolJSONRPC})
+	if err != nil {
+		t.Fatalf("a2aclient.NewFromCard() error = %v", err)
+	}
+	blocking := false
+	result, err := client.SendMessage(t.Context(), &a2a.MessageSendParams{
+		Message: a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "TEST"}),
+		Config:  &a2a.MessageSendConfig{Blocking: &blocking},
+	})
+	if err != nil {
+		t.Fatalf("client.SendMessage() error = %v", err)
+	}
+	<-started
+
+	task, err := client.CancelTask(t.Context(), &a2a.TaskIDParams{ID: result.TaskInfo().TaskID})
+	if err != nil {
+		t.Fatalf("client.CancelTask() error = %v", err)
+	}
+	if task.Status.State != a2a.TaskStateCanceled {
+		t.Errorf("client.CancelTask() state = %v, want %v", task.Status.State, a2a.TaskStateCanceled)
+	}
+
+	select {
+	case got := <-cancelled:
+		if !got {
+			t.Error("agent.IsInvocationCancelled() = false, want the invocation cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("agent did not unblock")
+	}
+}
+
+func TestExecutor_CancelledInvocation(t *testing.T) {
+	task := &a2a.Task{ID: a2a.NewTaskID(), ContextID: a2a.NewContextID()}
+	hiMsg := a2a.NewMessageForTask(a2a.MessageRoleUser, task, a2a.TextPart{Text: "hi"})
+	agent, err := newEventReplayAgent([]*session.Event{
+		{LLMResponse: model.LLMResponse{ErrorCode: runner.ErrorCodeCancelled, ErrorMessage: "invocation cancelled: stop"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("newEventReplayAgent() error = %v", err)
+	}
+	executor := NewExecutor(ExecutorConfig{
+		RunnerConfig: runner.Config{AppName: agent.Name(), Agent: agent, SessionService: session.InMemoryService()},
+	})
+
+	queue := &testQueue{Queue: newInMemoryQueue(t)}
+	reqCtx := &a2asrv.RequestContext{TaskID: task.ID, ContextID: task.ContextID, Message: hiMsg, StoredTask: task}
+	if err := executor.Execute(t.Context(), reqCtx, queue); err != nil {
+		t.Fatalf("executor.Execute() error = %v", err)
+	}
+
+	last, ok := queue.events[len(queue.events)-1].(*a2a.TaskStatusUpdateEvent)
+	if !ok || !last.Final || last.Status.State != a2a.TaskStateCanceled {
+		t.Errorf("executor.Execute() last event = %v, want a final canceled status update", queue.events[len(queue.events)-1])
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adka2a
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+
+	"google.golang.org/adk/session"
+)
+
+// ActionsExtensionURI identifies the A2A extension carrying the ADK data of
+// a task which has no A2A equivalent, so ADK clients orchestrating ADK
+// servers preserve it instead of getting text only.
+const ActionsExtensionURI = "https://google.golang.org/adk/a2a/extensions/actions/v1"
+
+// ActionsExtension is the A2A extension of [ActionsExtensionURI], declared
+// in the agent cards of [BuildAgentCard].
+//
+// If a client requests the extension, the metadata of the final status
+// update of a task carries, in addition to escalation and agent transfer,
+// the state delta of the invocation, the tool confirmations it requested and
+// the IDs of its long-running function calls. Artifact deltas are not
+// carried, the artifacts are stored by the server.
+var ActionsExtension = a2a.AgentExtension{
+	URI:         ActionsExtensionURI,
+	Description: "State deltas, tool confirmation requests and long-running function call IDs of ADK invocations in task metadata.",
+}
+
+var (
+	metadataStateDeltaKey         = ToA2AMetaKey("state_delta")
+	metadataToolConfirmationsKey  = ToA2AMetaKey("requested_tool_confirmations")
+	metadataLongRunningToolIDsKey = ToA2AMetaKey("long_running_tool_ids")
+)
+
+// activateActionsExtension activates the actions extension in the call
+// context if the client requested it, and reports whether it did.
+func activateActionsExtension(ctx context.Context) bool {
+	extensions, ok := a2asrv.ExtensionsFrom(ctx)
+	if !ok || !extensions.Requested(&ActionsExtension) {
+		return false
+	}
+	extensions.Activate(&ActionsExtension)
+	return true
+}
+
+// extensionActions accumulates the data of the actions extension of the
+// events of an invocation. The values are kept in their JSON form, as A2A
+// metadata only holds JSON values.
+type extensionActions struct {
+	stateDelta         map[string]any
+	toolConfirmations  map[string]any
+	longRunningToolIDs []any
+}
+
+func (a *extensionActions) update(event *session.Event) error {
+	if len(event.Actions.StateDelta) > 0 {
+		var stateDelta map[string]any
+		if err := toJSONValue(event.Actions.StateDelta, &stateDelta); err != nil {
+			return fmt.Errorf("failed to encode state delta: %w", err)
+		}
+		if a.stateDelta == nil {
+			a.stateDelta = make(map[string]any)
+		}
+		maps.Copy(a.stateDelta, stateDelta)
+	}
+	if len(event.Actions.RequestedToolConfirmations) > 0 {
+		var toolConfirmations map[string]any
+		if err := toJSONValue(event.Actions.RequestedToolConfirmations, &toolConfirmations); err != nil {
+			return fmt.Errorf("failed to encode tool confirmations: %w", err)
+		}
+		if a.toolConfirmations == nil {
+			a.toolConfirmations = make(map[string]any)
+		}
+		maps.Copy(a.toolConfirmations, toolConfirmations)
+	}
+	for _, id := range event.LongRunningToolIDs {
+		if !slices.Contains(a.longRunningToolIDs, any(id)) {
+			a.longRunningToolIDs = append(a.longRunningToolIDs, id)
+		}
+	}
+	return nil
+}
+
+// setMeta adds the accumulated data to the metadata of a terminal event.
+// All the keys are set, even if empty, as the metadata of the terminal
+// events is merged into the task metadata, which outlives the invocation.
+func (a *extensionActions) setMeta(meta map[string]any) map[string]any {
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta[metadataStateDeltaKey] = orEmptyMap(a.stateDelta)
+	meta[metadataToolConfirmationsKey] = orEmptyMap(a.toolConfirmations)
+	if a.longRunningToolIDs == nil {
+		meta[metadataLongRunningToolIDsKey] = []any{}
+	} else {
+		meta[metadataLongRunningToolIDsKey] = a.longRunningToolIDs
+	}
+	return meta
+}
+
+func orEmptyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
+
+// processExtensionMeta sets the data of the actions extension found in the
+// metadata of an A2A event on the session event converted from it.
+func processExtensionMeta(meta map[string]any, event *session.Event) error {
+	if err := decodeMeta(meta, metadataStateDeltaKey, &event.Actions.StateDelta); err != nil {
+		return err
+	}
+	if err := decodeMeta(meta, metadataToolConfirmationsKey, &event.Actions.RequestedToolConfirmations); err != nil {
+		return err
+	}
+	var longRunningToolIDs []string
+	if err := decodeMeta(meta, metadataLongRunningToolIDsKey, &longRunningToolIDs); err != nil {
+		return err
+	}
+	for _, id := range longRunningToolIDs {
+		if !slices.Contains(event.LongRunningToolIDs, id) {
+			event.LongRunningToolIDs = append(event.LongRunningToolIDs, id)
+		}
+	}
+	return nil
+}
+
+// decodeMeta decodes the metadata value of key into target, if present. The
+// value is the Go value set by the server when both run in process, or its
+// JSON decoding when it was sent over the wire.
+func decodeMeta[T any](meta map[string]any, key string, target *T) error {
+	v, ok := meta[key]
+	if !ok {
+		return nil
+	}
+	return toJSONValue(v, target)
+}
+
+// toJSONValue converts v to target through its JSON encoding.
+func toJSONValue[T any](v any, target *T) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
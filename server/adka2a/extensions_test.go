@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adka2a
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool/toolconfirmation"
+)
+
+type testPreferences struct {
+	Language string `json:"language"`
+}
+
+func TestActivateActionsExtension(t *testing.T) {
+	testCases := []struct {
+		name string
+		ctx  func() context.Context
+		want bool
+	}{
+		{
+			name: "no call context",
+			ctx:  t.Context,
+			want: false,
+		},
+		{
+			name: "not requested",
+			ctx: func() context.Context {
+				ctx, _ := a2asrv.WithCallContext(t.Context(), a2asrv.NewRequestMeta(nil))
+				return ctx
+			},
+			want: false,
+		},
+		{
+			name: "requested",
+			ctx: func() context.Context {
+				meta := a2asrv.NewRequestMeta(map[string][]string{a2asrv.ExtensionsMetaKey: {ActionsExtensionURI}})
+				ctx, _ := a2asrv.WithCallContext(t.Context(), meta)
+				return ctx
+			},
+			want: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := tc.ctx()
+			if got := activateActionsExtension(ctx); got != tc.want {
+				t.Fatalf("activateActionsExtension() = %v, want %v", got, tc.want)
+			}
+			if extensions, ok := a2asrv.ExtensionsFrom(ctx); ok {
+				if got := extensions.Active(&ActionsExtension); got != tc.want {
+					t.Fatalf("extensions.Active() = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEventProcessor_ActionsExtension(t *testing.T) {
+	events := []*session.Event{
+		{
+			LLMResponse: modelResponseFromParts(genai.NewPartFromFunctionCall("book", map[string]any{"seat": "1A"})),
+			Actions: session.EventActions{
+				StateDelta: map[string]any{"prefs": testPreferences{Language: "en"}, "count": 1},
+				RequestedToolConfirmations: map[string]toolconfirmation.ToolConfirmation{
+					"call-1": {Hint: "Book seat 1A?", Payload: map[string]any{"price": 100}},
+				},
+			},
+			LongRunningToolIDs: []string{"call-2"},
+		},
+		{
+			LLMResponse: modelResponseFromParts(genai.NewPartFromText("Waiting for confirmation")),
+			Actions: session.EventActions{
+				StateDelta: map[string]any{"count": 2},
+			},
+			LongRunningToolIDs: []string{"call-2", "call-3"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		active   bool
+		wantMeta map[string]any
+	}{
+		{
+			name:   "inactive",
+			active: false,
+		},
+		{
+			name:   "active",
+			active: true,
+			wantMeta: map[string]any{
+				metadataStateDeltaKey: map[string]any{
+					"prefs": map[string]any{"language": "en"},
+					"count": float64(2),
+				},
+				metadataToolConfirmationsKey: map[string]any{
+					"call-1": map[string]any{
+						"hint":      "Book seat 1A?",
+						"confirmed": false,
+						"payload":   map[string]any{"price": float64(100)},
+					},
+				},
+				metadataLongRunningToolIDsKey: []any{"call-2", "call-3"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqCtx := &a2asrv.RequestContext{TaskID: a2a.NewTaskID(), ContextID: a2a.NewContextID()}
+			processor := newEventProcessor(reqCtx, invocationMeta{actionsExtension: tc.active}, nil, newLegacyArtifactMaker(reqCtx))
+			for _, event := range events {
+				if _, err := processor.process(t.Context(), event); err != nil {
+					t.Fatalf("processor.process() error = %v", err)
+				}
+			}
+
+			finalStatus := processor.makeFinalStatusUpdate()
+			gotMeta := map[string]any{}
+			for _, key := range []string{metadataStateDeltaKey, metadataToolConfirmationsKey, metadataLongRunningToolIDsKey} {
+				if v, ok := finalStatus.Metadata[key]; ok {
+					gotMeta[key] = v
+				}
+			}
+			if diff := cmp.Diff(tc.wantMeta, gotMeta, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("finalStatus.Metadata wrong result (+got,-want)\ndiff = %s", diff)
+			}
+		})
+	}
+}
+
+func TestEventProcessor_ActionsExtensionEmpty(t *testing.T) {
+	reqCtx := &a2asrv.RequestContext{TaskID: a2a.NewTaskID(), ContextID: a2a.NewContextID()}
+	processor := newEventProcessor(reqCtx, invocationMeta{actionsExtension: true}, nil, newLegacyArtifactMaker(reqCtx))
+	if _, err := processor.process(t.Context(), &session.Event{LLMResponse: modelResponseFromParts(genai.NewPartFromText("Hi"))}); err != nil {
+		t.Fatalf("processor.process() error = %v", err)
+	}
+
+	// The keys are set to clear the values of the previous invocations of the task in its metadata.
+	finalStatus := processor.makeFinalStatusUpdate()
+	want := map[string]any{
+		metadataStateDeltaKey:         map[string]any{},
+		metadataToolConfirmationsKey:  map[string]any{},
+		metadataLongRunningToolIDsKey: []any{},
+	}
+	for key, wantValue := range want {
+		if diff := cmp.Diff(wantValue, finalStatus.Metadata[key]); diff != "" {
+			t.Fatalf("finalStatus.Metadata[%q] wrong result (+got,-want)\ndiff = %s", key, diff)
+		}
+	}
+}
+
+func TestProcessExtensionMeta(t *testing.T) {
+	meta := map[string]any{
+		metadataStateDeltaKey: map[string]any{"count": float64(2)},
+		metadataToolConfirmationsKey: map[string]any{
+			"call-1": map[string]any{"hint": "Book seat 1A?", "confirmed": false},
+		},
+		metadataLongRunningToolIDsKey: []any{"call-2", "call-3"},
+	}
+	event := &session.Event{LongRunningToolIDs: []string{"call-2"}}
+
+	if err := processExtensionMeta(meta, event); err != nil {
+		t.Fatalf("processExtensionMeta() error = %v", err)
+	}
+
+	want := &session.Event{
+		Actions: session.EventActions{
+			StateDelta: map[string]any{"count": float64(2)},
+			RequestedToolConfirmations: map[string]toolconfirmation.ToolConfirmation{
+				"call-1": {Hint: "Book seat 1A?"},
+			},
+		},
+		LongRunningToolIDs: []string{"call-2", "call-3"},
+	}
+	if diff := cmp.Diff(want, event, cmpopts.IgnoreUnexported(session.Event{})); diff != "" {
+		t.Fatalf("processExtensionMeta() wrong result (+got,-want)\ndiff = %s", diff)
+	}
+}
@@ -55,6 +55,8 @@ type invocationMeta struct {
 	agentName string
 	reqCtx    *a2asrv.RequestContext
 	eventMeta map[string]any
+	// actionsExtension is set if the client requested the ActionsExtension.
+	actionsExtension bool
 }
 
 func toInvocationMeta(ctx context.Context, config ExecutorConfig, reqCtx *a2asrv.RequestContext) invocationMeta {
@@ -79,6 +81,8 @@ func toInvocationMeta(ctx context.Context, config ExecutorConfig, reqCtx *a2asrv
 		agentName: config.RunnerConfig.Agent.Name(),
 		eventMeta: meta,
 		reqCtx:    reqCtx,
+
+		actionsExtension: activateActionsExtension(ctx),
 	}
 }
 
@@ -164,7 +168,7 @@ func processA2AMeta(a2aEvent a2a.Event, event *session.Event) error {
 	}
 
 	event.Actions = toEventActions(a2aEvent.Meta())
-	return nil
+	return processExtensionMeta(a2aEvent.Meta(), event)
 }
 
 func addMeta(result map[string]any, key string, data any) error {
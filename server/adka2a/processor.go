@@ -23,6 +23,7 @@ import (
 	"github.com/a2aproject/a2a-go/a2asrv"
 
 	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
 	"google.golang.org/adk/session"
 )
 
@@ -41,6 +42,10 @@ type eventProcessor struct {
 	// This is done to make sure the caller processes it, since intermediate events without parts might be ignored.
 	terminalActions session.EventActions
 
+	// extensionActions accumulates the data sent with the terminal event if the client requested the
+	// ActionsExtension.
+	extensionActions extensionActions
+
 	// failedEvent is used to postpone sending a terminal event until the whole ADK response is saved as an A2A artifact.
 	// Will be sent as the final Task status update if not nil.
 	failedEvent *a2a.TaskStatusUpdateEvent
@@ -74,6 +79,11 @@ func (p *eventProcessor) process(ctx context.Context, event *session.Event) (*a2
 	}
 
 	p.updateTerminalActions(event)
+	if p.meta.actionsExtension {
+		if err := p.extensionActions.update(event); err != nil {
+			return nil, err
+		}
+	}
 
 	eventMeta, err := toEventMeta(p.meta, event)
 	if err != nil {
@@ -87,7 +97,11 @@ func (p *eventProcessor) process(ctx context.Context, event *session.Event) (*a2
 			// terminal event might add additional keys to its metadata when it's dispatched and these changes should
 			// not be reflected in this event's metadata
 			terminalEventMeta := maps.Clone(eventMeta)
-			p.failedEvent = toTaskFailedUpdateEvent(p.reqCtx, errorFromResponse(&resp), terminalEventMeta)
+			if resp.ErrorCode == runner.ErrorCodeCancelled {
+				p.failedEvent = toTaskCanceledUpdateEvent(p.reqCtx, resp.ErrorMessage, terminalEventMeta)
+			} else {
+				p.failedEvent = toTaskFailedUpdateEvent(p.reqCtx, errorFromResponse(&resp), terminalEventMeta)
+			}
 		}
 	}
 
@@ -119,7 +133,7 @@ func (p *eventProcessor) makeFinalArtifactUpdate() *a2a.TaskArtifactUpdateEvent
 func (p *eventProcessor) makeFinalStatusUpdate() *a2a.TaskStatusUpdateEvent {
 	for _, event := range []*a2a.TaskStatusUpdateEvent{p.failedEvent, p.inputRequiredProcessor.event} {
 		if event != nil {
-			event.Metadata = setActionsMeta(event.Metadata, p.terminalActions)
+			event.Metadata = p.setTerminalActionsMeta(event.Metadata)
 			return event
 		}
 	}
@@ -129,7 +143,7 @@ func (p *eventProcessor) makeFinalStatusUpdate() *a2a.TaskStatusUpdateEvent {
 	// we're modifying base processor metadata which might have been sent with one of the previous events.
 	// this update shouldn't be reflected in the sent events' metadata.
 	baseMetaCopy := maps.Clone(p.meta.eventMeta)
-	ev.Metadata = setActionsMeta(baseMetaCopy, p.terminalActions)
+	ev.Metadata = p.setTerminalActionsMeta(baseMetaCopy)
 	return ev
 }
 
@@ -152,6 +166,14 @@ func (p *eventProcessor) updateTerminalActions(event *session.Event) {
 	}
 }
 
+func (p *eventProcessor) setTerminalActionsMeta(meta map[string]any) map[string]any {
+	meta = setActionsMeta(meta, p.terminalActions)
+	if p.meta.actionsExtension {
+		meta = p.extensionActions.setMeta(meta)
+	}
+	return meta
+}
+
 func (p *eventProcessor) convertParts(ctx context.Context, event *session.Event) ([]a2a.Part, error) {
 	if event.Content == nil || len(event.Content.Parts) == 0 {
 		return nil, nil
@@ -182,6 +204,15 @@ func toTaskFailedUpdateEvent(task a2a.TaskInfoProvider, cause error, meta map[st
 	return ev
 }
 
+// toTaskCanceledUpdateEvent reports the cancellation of the invocation of a task, e.g. with A2A tasks/cancel.
+func toTaskCanceledUpdateEvent(task a2a.TaskInfoProvider, reason string, meta map[string]any) *a2a.TaskStatusUpdateEvent {
+	msg := a2a.NewMessageForTask(a2a.MessageRoleAgent, task, a2a.TextPart{Text: reason})
+	ev := a2a.NewStatusUpdateEvent(task, a2a.TaskStateCanceled, msg)
+	ev.Metadata = meta
+	ev.Final = true
+	return ev
+}
+
 func errorFromResponse(resp *model.LLMResponse) error {
 	return fmt.Errorf("llm error response: %q", resp.ErrorMessage)
 }
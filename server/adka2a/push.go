@@ -0,0 +1,296 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adka2a
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// JWKSPath is the path the JSON Web Key Set of a [PushSender] is
+// conventionally served on, for the clients to verify the notifications.
+const JWKSPath = "/.well-known/jwks.json"
+
+// PushSenderConfig configures a [PushSender].
+type PushSenderConfig struct {
+	// Signer signs the JWT sent with every notification, an RSA key (RS256)
+	// or an ECDSA P-256 key (ES256). Optional: if nil, the notifications are
+	// authenticated with the credentials of the push configs instead.
+	Signer crypto.Signer
+	// KeyID identifies the key of Signer in the JWKS. Optional.
+	KeyID string
+	// Client sends the notifications. Defaults to a client with a 30 second
+	// timeout.
+	Client *http.Client
+	// MaxRetries is the number of retries of a failed delivery. Defaults to
+	// 3, a negative value disables the retries.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled for every
+	// other retry. Defaults to 500ms.
+	RetryBackoff time.Duration
+}
+
+// PushSender delivers the status updates of A2A tasks to the webhooks the
+// clients registered with their push notification configs. Use it with
+// a2asrv.WithPushNotifications.
+//
+// A notification is the JSON of the task, POSTed when the state of the task
+// changes, e.g. when an invocation running in the background completes.
+// With a signer, the Authorization header is a bearer JWT whose
+// request_body_sha256 claim is the SHA-256 of the body, which the clients
+// verify with the keys of [PushSender.JWKSHandler]. The token of the push
+// config is sent in the X-A2A-Notification-Token header. Failed deliveries
+// are retried with an exponential backoff.
+type PushSender struct {
+	cfg PushSenderConfig
+	alg string
+
+	mu sync.Mutex
+	// states are the task states last delivered, by task and push config.
+	states map[string]a2a.TaskState
+}
+
+var _ a2asrv.PushSender = (*PushSender)(nil)
+
+// NewPushSender creates a PushSender. It fails if the key of the signer is
+// not supported.
+func NewPushSender(cfg PushSenderConfig) (*PushSender, error) {
+	s := &PushSender{cfg: cfg, states: make(map[string]a2a.TaskState)}
+	if cfg.Signer != nil {
+		switch key := cfg.Signer.Public().(type) {
+		case *rsa.PublicKey:
+			s.alg = "RS256"
+		case *ecdsa.PublicKey:
+			if key.Curve != elliptic.P256() {
+				return nil, fmt.Errorf("unsupported ECDSA curve %s, want P-256", key.Curve.Params().Name)
+			}
+			s.alg = "ES256"
+		default:
+			return nil, fmt.Errorf("unsupported signer key %T, want RSA or ECDSA", key)
+		}
+	}
+	if s.cfg.Client == nil {
+		s.cfg.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if s.cfg.MaxRetries == 0 {
+		s.cfg.MaxRetries = 3
+	}
+	if s.cfg.RetryBackoff <= 0 {
+		s.cfg.RetryBackoff = 500 * time.Millisecond
+	}
+	return s, nil
+}
+
+// SendPush implements a2asrv.PushSender. It delivers the task if its state
+// changed since the last notification sent with the config.
+func (s *PushSender) SendPush(ctx context.Context, config *a2a.PushConfig, task *a2a.Task) error {
+	key := string(task.ID) + "/" + config.ID + "/" + config.URL
+	s.mu.Lock()
+	if last, ok := s.states[key]; ok && last == task.Status.State {
+		s.mu.Unlock()
+		return nil
+	}
+	if task.Status.State.Terminal() {
+		delete(s.states, key)
+	} else {
+		s.states[key] = task.Status.State
+	}
+	s.mu.Unlock()
+
+	body, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to serialize task: %w", err)
+	}
+	backoff := s.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err = s.deliver(ctx, config, body)
+		if err == nil || attempt >= s.cfg.MaxRetries || !isRetryable(err) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	if err != nil {
+		return fmt.Errorf("failed to deliver push notification for task %s to %s: %w", task.ID, config.URL, err)
+	}
+	return nil
+}
+
+// statusError is the error of a delivery answered with a non-2xx status.
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("webhook returned status %d %s", e.code, http.StatusText(e.code))
+}
+
+// isRetryable reports whether a failed delivery is retried: network errors,
+// server errors and rate limits are.
+func isRetryable(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code >= 500 || statusErr.code == http.StatusTooManyRequests || statusErr.code == http.StatusRequestTimeout
+	}
+	return true
+}
+
+func (s *PushSender) deliver(ctx context.Context, config *a2a.PushConfig, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if config.Token != "" {
+		req.Header.Set("X-A2A-Notification-Token", config.Token)
+	}
+	switch {
+	case s.cfg.Signer != nil:
+		token, err := s.sign(body)
+		if err != nil {
+			return fmt.Errorf("failed to sign the notification: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case config.Auth != nil && config.Auth.Credentials != "":
+		for _, scheme := range config.Auth.Schemes {
+			if strings.EqualFold(scheme, "Bearer") || strings.EqualFold(scheme, "Basic") {
+				req.Header.Set("Authorization", scheme+" "+config.Auth.Credentials)
+				break
+			}
+		}
+	}
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &statusError{code: resp.StatusCode}
+	}
+	return nil
+}
+
+// sign returns the JWT of a notification with the given body.
+func (s *PushSender) sign(body []byte) (string, error) {
+	header := map[string]string{"alg": s.alg, "typ": "JWT"}
+	if s.cfg.KeyID != "" {
+		header["kid"] = s.cfg.KeyID
+	}
+	sum := sha256.Sum256(body)
+	claims := map[string]any{
+		"iat":                 time.Now().Unix(),
+		"request_body_sha256": hex.EncodeToString(sum[:]),
+	}
+	encodedHeader, err := encodeSegment(header)
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := encodedHeader + "." + encodedClaims
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := s.cfg.Signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	if s.alg == "ES256" {
+		// JWS uses the fixed size concatenation of r and s, not ASN.1.
+		var parsed struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(sig, &parsed); err != nil {
+			return "", err
+		}
+		sig = make([]byte, 64)
+		parsed.R.FillBytes(sig[:32])
+		parsed.S.FillBytes(sig[32:])
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func encodeSegment(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// JWKS returns the JSON Web Key Set with the public key of the signer, or
+// nil if the sender has no signer.
+func (s *PushSender) JWKS() map[string]any {
+	if s.cfg.Signer == nil {
+		return nil
+	}
+	key := map[string]any{"use": "sig", "alg": s.alg}
+	if s.cfg.KeyID != "" {
+		key["kid"] = s.cfg.KeyID
+	}
+	switch pub := s.cfg.Signer.Public().(type) {
+	case *rsa.PublicKey:
+		key["kty"] = "RSA"
+		key["n"] = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		key["e"] = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		ecdhKey, err := pub.ECDH()
+		if err != nil {
+			return nil
+		}
+		// The uncompressed point is 0x04 || x || y.
+		point := ecdhKey.Bytes()
+		key["kty"] = "EC"
+		key["crv"] = "P-256"
+		key["x"] = base64.RawURLEncoding.EncodeToString(point[1:33])
+		key["y"] = base64.RawURLEncoding.EncodeToString(point[33:])
+	}
+	return map[string]any{"keys": []any{key}}
+}
+
+// JWKSHandler serves [PushSender.JWKS], conventionally on [JWKSPath].
+func (s *PushSender) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jwks := s.JWKS()
+		if jwks == nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(jwks)
+	})
+}
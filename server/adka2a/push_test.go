@@ -0,0 +1,244 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adka2a
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// webhook records the notifications it receives, and fails the first
+// failures of them with a 500.
+type webhook struct {
+	mu       sync.Mutex
+	failures int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (w *webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failures > 0 {
+		w.failures--
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.requests = append(w.requests, r)
+	w.bodies = append(w.bodies, body)
+}
+
+func newTestTask(state a2a.TaskState) *a2a.Task {
+	return &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: state}}
+}
+
+// verifyJWT checks the signature of the token with the key of the JWKS, and
+// returns its claims.
+func verifyJWT(t *testing.T, token string, jwks map[string]any) map[string]any {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token has %d parts, want 3", len(parts))
+	}
+	decode := func(s string) []byte {
+		data, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			t.Fatalf("failed to decode %q: %v", s, err)
+		}
+		return data
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	sig := decode(parts[2])
+	key := jwks["keys"].([]any)[0].(map[string]any)
+	switch key["kty"] {
+	case "RSA":
+		pub := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(decode(key["n"].(string))),
+			E: int(new(big.Int).SetBytes(decode(key["e"].(string))).Int64()),
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			t.Fatalf("invalid RS256 signature: %v", err)
+		}
+	case "EC":
+		pub := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(decode(key["x"].(string))),
+			Y:     new(big.Int).SetBytes(decode(key["y"].(string))),
+		}
+		if !ecdsa.Verify(pub, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])) {
+			t.Fatal("invalid ES256 signature")
+		}
+	default:
+		t.Fatalf("unexpected key type %v", key["kty"])
+	}
+	var header map[string]any
+	if err := json.Unmarshal(decode(parts[0]), &header); err != nil {
+		t.Fatal(err)
+	}
+	if header["kid"] != key["kid"] || header["alg"] != key["alg"] {
+		t.Errorf("header = %v, want kid and alg of %v", header, key)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(decode(parts[1]), &claims); err != nil {
+		t.Fatal(err)
+	}
+	return claims
+}
+
+func TestPushSender_SignedNotification(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, signer := range map[string]crypto.Signer{"RS256": rsaKey, "ES256": ecKey} {
+		t.Run(name, func(t *testing.T) {
+			hook := &webhook{}
+			server := httptest.NewServer(hook)
+			defer server.Close()
+
+			sender, err := NewPushSender(PushSenderConfig{Signer: signer, KeyID: "key-1"})
+			if err != nil {
+				t.Fatalf("NewPushSender() error = %v", err)
+			}
+			config := &a2a.PushConfig{ID: "config-1", URL: server.URL, Token: "client-token"}
+			if err := sender.SendPush(t.Context(), config, newTestTask(a2a.TaskStateCompleted)); err != nil {
+				t.Fatalf("SendPush() error = %v", err)
+			}
+
+			if len(hook.requests) != 1 {
+				t.Fatalf("webhook got %d requests, want 1", len(hook.requests))
+			}
+			req := hook.requests[0]
+			if got := req.Header.Get("X-A2A-Notification-Token"); got != "client-token" {
+				t.Errorf("notification token = %q, want %q", got, "client-token")
+			}
+			var task a2a.Task
+			if err := json.Unmarshal(hook.bodies[0], &task); err != nil {
+				t.Fatalf("failed to parse notification: %v", err)
+			}
+			if task.ID != "task-1" || task.Status.State != a2a.TaskStateCompleted {
+				t.Errorf("notification = %+v, want the completed task", task)
+			}
+
+			recorder := httptest.NewRecorder()
+			sender.JWKSHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+			var jwks map[string]any
+			if err := json.Unmarshal(recorder.Body.Bytes(), &jwks); err != nil {
+				t.Fatalf("failed to parse JWKS: %v", err)
+			}
+			token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				t.Fatalf("Authorization = %q, want a bearer token", req.Header.Get("Authorization"))
+			}
+			claims := verifyJWT(t, token, jwks)
+			sum := sha256.Sum256(hook.bodies[0])
+			if got, want := claims["request_body_sha256"], hex.EncodeToString(sum[:]); got != want {
+				t.Errorf("request_body_sha256 = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPushSender_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		maxRetries   int
+		wantErr      bool
+		wantRequests int
+	}{
+		{name: "succeeds after retries", failures: 2, maxRetries: 3, wantRequests: 1},
+		{name: "gives up", failures: 5, maxRetries: 2, wantErr: true},
+		{name: "retries disabled", failures: 1, maxRetries: -1, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := &webhook{failures: tc.failures}
+			server := httptest.NewServer(hook)
+			defer server.Close()
+
+			sender, err := NewPushSender(PushSenderConfig{MaxRetries: tc.maxRetries, RetryBackoff: time.Millisecond})
+			if err != nil {
+				t.Fatal(err)
+			}
+			config := &a2a.PushConfig{URL: server.URL, Auth: &a2a.PushAuthInfo{Schemes: []string{"Bearer"}, Credentials: "secret"}}
+			err = sender.SendPush(context.Background(), config, newTestTask(a2a.TaskStateWorking))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("SendPush() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if len(hook.requests) != tc.wantRequests {
+				t.Fatalf("webhook got %d requests, want %d", len(hook.requests), tc.wantRequests)
+			}
+			if tc.wantRequests > 0 {
+				if got := hook.requests[0].Header.Get("Authorization"); got != "Bearer secret" {
+					t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+				}
+			}
+		})
+	}
+}
+
+func TestPushSender_OnlyStateChanges(t *testing.T) {
+	hook := &webhook{}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	sender, err := NewPushSender(PushSenderConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &a2a.PushConfig{ID: "config-1", URL: server.URL}
+	for _, state := range []a2a.TaskState{a2a.TaskStateSubmitted, a2a.TaskStateWorking, a2a.TaskStateWorking, a2a.TaskStateCompleted} {
+		if err := sender.SendPush(t.Context(), config, newTestTask(state)); err != nil {
+			t.Fatalf("SendPush(%s) error = %v", state, err)
+		}
+	}
+	if len(hook.requests) != 3 {
+		t.Errorf("webhook got %d requests, want 3", len(hook.requests))
+	}
+}
+
+func TestNewPushSender_UnsupportedKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewPushSender(PushSenderConfig{Signer: key}); err == nil {
+		t.Error("NewPushSender() error = nil, want an error for a P-384 key")
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkgrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"google.golang.org/genai"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"google.golang.org/adk/server/adkgrpc/runnerpb"
+	"google.golang.org/adk/session"
+)
+
+// toGenAIContent converts a content of a request.
+func toGenAIContent(c *runnerpb.Content) (*genai.Content, error) {
+	content := &genai.Content{Role: c.GetRole()}
+	for i, p := range c.GetParts() {
+		part := &genai.Part{Thought: p.GetThought()}
+		switch data := p.GetData().(type) {
+		case *runnerpb.Part_Text:
+			part.Text = data.Text
+		case *runnerpb.Part_FunctionCall:
+			part.FunctionCall = &genai.FunctionCall{
+				ID:   data.FunctionCall.GetId(),
+				Name: data.FunctionCall.GetName(),
+				Args: data.FunctionCall.GetArgs().AsMap(),
+			}
+		case *runnerpb.Part_FunctionResponse:
+			part.FunctionResponse = &genai.FunctionResponse{
+				ID:       data.FunctionResponse.GetId(),
+				Name:     data.FunctionResponse.GetName(),
+				Response: data.FunctionResponse.GetResponse().AsMap(),
+			}
+		case *runnerpb.Part_InlineData:
+			part.InlineData = &genai.Blob{
+				MIMEType: data.InlineData.GetMimeType(),
+				Data:     data.InlineData.GetData(),
+			}
+		default:
+			return nil, fmt.Errorf("part %d has no data", i)
+		}
+		content.Parts = append(content.Parts, part)
+	}
+	return content, nil
+}
+
+// fromGenAIContent converts a content of an event. The parts other than
+// text, function calls and responses and inline data are dropped.
+func fromGenAIContent(c *genai.Content) (*runnerpb.Content, error) {
+	if c == nil {
+		return nil, nil
+	}
+	content := &runnerpb.Content{Role: c.Role}
+	for _, p := range c.Parts {
+		if p == nil {
+			continue
+		}
+		part := &runnerpb.Part{Thought: p.Thought}
+		switch {
+		case p.FunctionCall != nil:
+			args, err := toStruct(p.FunctionCall.Args)
+			if err != nil {
+				return nil, fmt.Errorf("function call %q: %w", p.FunctionCall.Name, err)
+			}
+			part.Data = &runnerpb.Part_FunctionCall{FunctionCall: &runnerpb.FunctionCall{
+				Id:   p.FunctionCall.ID,
+				Name: p.FunctionCall.Name,
+				Args: args,
+			}}
+		case p.FunctionResponse != nil:
+			response, err := toStruct(p.FunctionResponse.Response)
+			if err != nil {
+				return nil, fmt.Errorf("function response %q: %w", p.FunctionResponse.Name, err)
+			}
+			part.Data = &runnerpb.Part_FunctionResponse{FunctionResponse: &runnerpb.FunctionResponse{
+				Id:       p.FunctionResponse.ID,
+				Name:     p.FunctionResponse.Name,
+				Response: response,
+			}}
+		case p.InlineData != nil:
+			part.Data = &runnerpb.Part_InlineData{InlineData: &runnerpb.Blob{
+				MimeType: p.InlineData.MIMEType,
+				Data:     p.InlineData.Data,
+			}}
+		case p.Text != "":
+			part.Data = &runnerpb.Part_Text{Text: p.Text}
+		default:
+			continue
+		}
+		content.Parts = append(content.Parts, part)
+	}
+	return content, nil
+}
+
+// fromSessionEvent converts an event of a run or a session.
+func fromSessionEvent(e *session.Event) (*runnerpb.Event, error) {
+	content, err := fromGenAIContent(e.Content)
+	if err != nil {
+		return nil, err
+	}
+	customMetadata, err := toStruct(e.CustomMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("custom metadata: %w", err)
+	}
+	stateDelta, err := toStruct(e.Actions.StateDelta)
+	if err != nil {
+		return nil, fmt.Errorf("state delta: %w", err)
+	}
+	event := &runnerpb.Event{
+		Id:             e.ID,
+		InvocationId:   e.InvocationID,
+		Author:         e.Author,
+		Branch:         e.Branch,
+		Content:        content,
+		Partial:        e.Partial,
+		TurnComplete:   e.TurnComplete,
+		Interrupted:    e.Interrupted,
+		ErrorCode:      e.ErrorCode,
+		ErrorMessage:   e.ErrorMessage,
+		CustomMetadata: customMetadata,
+		Actions: &runnerpb.EventActions{
+			StateDelta:        stateDelta,
+			ArtifactDelta:     maps.Clone(e.Actions.ArtifactDelta),
+			TransferToAgent:   e.Actions.TransferToAgent,
+			Escalate:          e.Actions.Escalate,
+			SkipSummarization: e.Actions.SkipSummarization,
+		},
+		LongRunningToolIds: e.LongRunningToolIDs,
+	}
+	if !e.Timestamp.IsZero() {
+		event.Timestamp = timestamppb.New(e.Timestamp)
+	}
+	if u := e.UsageMetadata; u != nil {
+		event.UsageMetadata = &runnerpb.UsageMetadata{
+			PromptTokenCount:     u.PromptTokenCount,
+			CandidatesTokenCount: u.CandidatesTokenCount,
+			TotalTokenCount:      u.TotalTokenCount,
+		}
+	}
+	return event, nil
+}
+
+// toPBSession converts a session, with its events if withEvents is set.
+func toPBSession(s session.Session, withEvents bool) (*runnerpb.Session, error) {
+	state, err := toStruct(maps.Collect(s.State().All()))
+	if err != nil {
+		return nil, fmt.Errorf("session %s state: %w", s.ID(), err)
+	}
+	pbSession := &runnerpb.Session{
+		AppName:        s.AppName(),
+		UserId:         s.UserID(),
+		Id:             s.ID(),
+		State:          state,
+		LastUpdateTime: timestamppb.New(s.LastUpdateTime()),
+	}
+	if !withEvents {
+		return pbSession, nil
+	}
+	for event := range s.Events().All() {
+		pbEvent, err := fromSessionEvent(event)
+		if err != nil {
+			return nil, fmt.Errorf("session %s event %s: %w", s.ID(), event.ID, err)
+		}
+		pbSession.Events = append(pbSession.Events, pbEvent)
+	}
+	return pbSession, nil
+}
+
+// toStruct converts a map to a Struct through its JSON encoding, so the
+// values that are not JSON primitives, e.g. Go structs, are converted as in
+// the HTTP API.
+func toStruct(m map[string]any) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err := protojson.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
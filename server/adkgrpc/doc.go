@@ -0,0 +1,21 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package adkgrpc serves ADK agents over gRPC with the Runner service of
+// runnerpb, for the services that invoke agents with typed requests and
+// streamed events rather than the HTTP API of adkrest.
+//
+// RegisterHealthServer adds the standard gRPC health service, reporting the
+// services as serving while their dependencies pass readiness checks.
+package adkgrpc
@@ -0,0 +1,228 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/genai"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+)
+
+// ReadinessCheck reports whether a dependency of the served agents, e.g. the
+// session backend or the model, is ready.
+type ReadinessCheck struct {
+	// Name identifies the check in the logs.
+	Name string
+	// Services are the gRPC services, e.g. "adk.runner.v1.Runner", that are
+	// not serving while the check fails. If empty, all the services.
+	Services []string // optional
+	// Check returns an error if the dependency is not ready.
+	Check func(ctx context.Context) error
+}
+
+// SessionServiceCheck checks that the session service is up by listing the
+// sessions of a reserved user.
+func SessionServiceCheck(service session.Service) ReadinessCheck {
+	return ReadinessCheck{
+		Name: "session_service",
+		Check: func(ctx context.Context) error {
+			_, err := service.List(ctx, &session.ListRequest{AppName: "adk_health_check", UserID: "adk_health_check"})
+			return err
+		},
+	}
+}
+
+// ModelCheck checks that the model is reachable by generating a single
+// token. Every check is a billed model call, see HealthConfig.Interval.
+func ModelCheck(llm model.LLM) ReadinessCheck {
+	return ReadinessCheck{
+		Name: "model:" + llm.Name(),
+		Check: func(ctx context.Context) error {
+			req := &model.LLMRequest{
+				Contents: []*genai.Content{genai.NewContentFromText("ping", genai.RoleUser)},
+				Config:   &genai.GenerateContentConfig{MaxOutputTokens: 1},
+			}
+			for _, err := range llm.GenerateContent(ctx, req, false) {
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// HealthConfig configures the health service registered by
+// RegisterHealthServer.
+type HealthConfig struct {
+	// Checks are run to report the status of the services.
+	Checks []ReadinessCheck // optional
+	// Interval is how long the results of the checks are reused, and how
+	// often the status is checked for the Watch calls. Defaults to 10s.
+	Interval time.Duration // optional
+	// Timeout limits the duration of every check. Defaults to 5s.
+	Timeout time.Duration // optional
+}
+
+// HealthServer implements the standard gRPC health service, see
+// grpc_health_v1. A service is serving while the readiness checks it depends
+// on pass; the empty service name reports the server as a whole, serving
+// while all the checks pass.
+type HealthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	server *grpc.Server
+	config HealthConfig
+
+	mu sync.Mutex
+	// checked is when the checks last ran, failed the checks that failed.
+	checked time.Time
+	failed  []ReadinessCheck
+}
+
+// RegisterHealthServer registers the health service on the server. The
+// status of all the services registered on the server is reported, also of
+// those registered after it.
+func RegisterHealthServer(server *grpc.Server, cfg HealthConfig) *HealthServer {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	h := &HealthServer{server: server, config: cfg}
+	healthpb.RegisterHealthServer(server, h)
+	return h
+}
+
+// Check implements grpc_health_v1.HealthServer.
+func (h *HealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !h.known(req.GetService()) {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: h.status(ctx, req.GetService())}, nil
+}
+
+// List implements grpc_health_v1.HealthServer.
+func (h *HealthServer) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	resp := &healthpb.HealthListResponse{Statuses: map[string]*healthpb.HealthCheckResponse{
+		"": {Status: h.status(ctx, "")},
+	}}
+	for service := range h.server.GetServiceInfo() {
+		resp.Statuses[service] = &healthpb.HealthCheckResponse{Status: h.status(ctx, service)}
+	}
+	return resp, nil
+}
+
+// Watch implements grpc_health_v1.HealthServer. The status of an unknown
+// service is reported as SERVICE_UNKNOWN, as it may be registered later.
+func (h *HealthServer) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(h.config.Interval)
+	defer ticker.Stop()
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		current := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if h.known(req.GetService()) {
+			current = h.status(ctx, req.GetService())
+		}
+		if current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *HealthServer) known(service string) bool {
+	if service == "" || service == healthpb.Health_ServiceDesc.ServiceName {
+		return true
+	}
+	_, ok := h.server.GetServiceInfo()[service]
+	return ok
+}
+
+// status returns the status of the service, running the checks if their
+// results are older than the interval.
+func (h *HealthServer) status(ctx context.Context, service string) healthpb.HealthCheckResponse_ServingStatus {
+	for _, check := range h.failedChecks(ctx) {
+		if service == "" || len(check.Services) == 0 || slices.Contains(check.Services, service) {
+			return healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+func (h *HealthServer) failedChecks(ctx context.Context) []ReadinessCheck {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.checked.IsZero() && time.Since(h.checked) < h.config.Interval {
+		return h.failed
+	}
+	// The checks outlive the request that triggered them, their results are
+	// shared.
+	ctx = context.WithoutCancel(ctx)
+	var failed []ReadinessCheck
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, check := range h.config.Checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := h.run(ctx, check); err != nil {
+				logging.FromContext(ctx).WarnContext(ctx, "Readiness check failed", "check", check.Name, "error", err)
+				mu.Lock()
+				failed = append(failed, check)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	h.checked, h.failed = time.Now(), failed
+	return failed
+}
+
+func (h *HealthServer) run(ctx context.Context, check ReadinessCheck) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, h.config.Timeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("readiness check %s panicked: %v", check.Name, r)
+		}
+	}()
+	if check.Check == nil {
+		return errors.New("no check function")
+	}
+	return check.Check(ctx)
+}
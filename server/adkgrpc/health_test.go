@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkgrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"google.golang.org/adk/server/adkgrpc/runnerpb"
+	"google.golang.org/adk/session"
+)
+
+func TestHealthServer(t *testing.T) {
+	ctx := t.Context()
+	var modelDown atomic.Bool
+	modelDown.Store(true)
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	RegisterHealthServer(grpcServer, HealthConfig{
+		Checks: []ReadinessCheck{
+			SessionServiceCheck(session.InMemoryService()),
+			{Name: "model", Services: []string{runnerpb.Runner_ServiceDesc.ServiceName}, Check: func(context.Context) error {
+				if modelDown.Load() {
+					return errors.New("model unreachable")
+				}
+				return nil
+			}},
+		},
+		Interval: 10 * time.Millisecond,
+	})
+	runnerpb.RegisterRunnerServer(grpcServer, NewServer(Config{SessionService: session.InMemoryService()}))
+	grpcServer.RegisterService(&grpc.ServiceDesc{ServiceName: "other.Service", HandlerType: (*any)(nil)}, struct{}{})
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	client := healthpb.NewHealthClient(conn)
+
+	check := func(t *testing.T, service string) healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) error = %v", service, err)
+		}
+		return resp.GetStatus()
+	}
+
+	for service, want := range map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":                                      healthpb.HealthCheckResponse_NOT_SERVING,
+		runnerpb.Runner_ServiceDesc.ServiceName: healthpb.HealthCheckResponse_NOT_SERVING,
+		"other.Service":                         healthpb.HealthCheckResponse_SERVING,
+	} {
+		if got := check(t, service); got != want {
+			t.Errorf("Check(%q) status = %v, want %v", service, got, want)
+		}
+	}
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown.Service"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Check(unknown) error = %v, want NotFound", err)
+	}
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: runnerpb.Runner_ServiceDesc.ServiceName})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if resp, err := stream.Recv(); err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("Watch() first status = %v, %v, want NOT_SERVING", resp.GetStatus(), err)
+	}
+	// The model becomes reachable, the checks run again after the interval.
+	modelDown.Store(false)
+	if resp, err := stream.Recv(); err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Watch() next status = %v, %v, want SERVING", resp.GetStatus(), err)
+	}
+	if got := check(t, ""); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Check(\"\") status = %v, want SERVING", got)
+	}
+}
@@ -0,0 +1,1720 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: runner.proto
+
+package runnerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Content is the content of a message, mirroring genai.Content.
+type Content struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Role of the producer of the content, either "user" or "model".
+	Role string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	// Ordered parts of the content.
+	Parts         []*Part `protobuf:"bytes,2,rep,name=parts,proto3" json:"parts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Content) Reset() {
+	*x = Content{}
+	mi := &file_runner_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Content) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Content) ProtoMessage() {}
+
+func (x *Content) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Content.ProtoReflect.Descriptor instead.
+func (*Content) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Content) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Content) GetParts() []*Part {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+// Part is a single part of a content.
+type Part struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*Part_Text
+	//	*Part_FunctionCall
+	//	*Part_FunctionResponse
+	//	*Part_InlineData
+	Data isPart_Data `protobuf_oneof:"data"`
+	// Whether the part is a thought of the model.
+	Thought       bool `protobuf:"varint,5,opt,name=thought,proto3" json:"thought,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Part) Reset() {
+	*x = Part{}
+	mi := &file_runner_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Part) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Part) ProtoMessage() {}
+
+func (x *Part) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Part.ProtoReflect.Descriptor instead.
+func (*Part) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Part) GetData() isPart_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Part) GetText() string {
+	if x != nil {
+		if x, ok := x.Data.(*Part_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *Part) GetFunctionCall() *FunctionCall {
+	if x != nil {
+		if x, ok := x.Data.(*Part_FunctionCall); ok {
+			return x.FunctionCall
+		}
+	}
+	return nil
+}
+
+func (x *Part) GetFunctionResponse() *FunctionResponse {
+	if x != nil {
+		if x, ok := x.Data.(*Part_FunctionResponse); ok {
+			return x.FunctionResponse
+		}
+	}
+	return nil
+}
+
+func (x *Part) GetInlineData() *Blob {
+	if x != nil {
+		if x, ok := x.Data.(*Part_InlineData); ok {
+			return x.InlineData
+		}
+	}
+	return nil
+}
+
+func (x *Part) GetThought() bool {
+	if x != nil {
+		return x.Thought
+	}
+	return false
+}
+
+type isPart_Data interface {
+	isPart_Data()
+}
+
+type Part_Text struct {
+	// Text of the part.
+	Text string `protobuf:"bytes,1,opt,name=text,proto3,oneof"`
+}
+
+type Part_FunctionCall struct {
+	// Function call requested by the model.
+	FunctionCall *FunctionCall `protobuf:"bytes,2,opt,name=function_call,json=functionCall,proto3,oneof"`
+}
+
+type Part_FunctionResponse struct {
+	// Result of a function call.
+	FunctionResponse *FunctionResponse `protobuf:"bytes,3,opt,name=function_response,json=functionResponse,proto3,oneof"`
+}
+
+type Part_InlineData struct {
+	// Inline binary data.
+	InlineData *Blob `protobuf:"bytes,4,opt,name=inline_data,json=inlineData,proto3,oneof"`
+}
+
+func (*Part_Text) isPart_Data() {}
+
+func (*Part_FunctionCall) isPart_Data() {}
+
+func (*Part_FunctionResponse) isPart_Data() {}
+
+func (*Part_InlineData) isPart_Data() {}
+
+// FunctionCall is a function call requested by the model.
+type FunctionCall struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique ID of the function call.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the function to call.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Arguments of the function call.
+	Args          *structpb.Struct `protobuf:"bytes,3,opt,name=args,proto3" json:"args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionCall) Reset() {
+	*x = FunctionCall{}
+	mi := &file_runner_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionCall) ProtoMessage() {}
+
+func (x *FunctionCall) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionCall.ProtoReflect.Descriptor instead.
+func (*FunctionCall) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FunctionCall) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FunctionCall) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionCall) GetArgs() *structpb.Struct {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+// FunctionResponse is the result of a function call.
+type FunctionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the function call this is the response of.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the called function.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Response of the function.
+	Response      *structpb.Struct `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionResponse) Reset() {
+	*x = FunctionResponse{}
+	mi := &file_runner_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionResponse) ProtoMessage() {}
+
+func (x *FunctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionResponse.ProtoReflect.Descriptor instead.
+func (*FunctionResponse) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FunctionResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FunctionResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionResponse) GetResponse() *structpb.Struct {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// Blob is inline binary data.
+type Blob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IANA MIME type of the data.
+	MimeType string `protobuf:"bytes,1,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// Raw bytes.
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Blob) Reset() {
+	*x = Blob{}
+	mi := &file_runner_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Blob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Blob) ProtoMessage() {}
+
+func (x *Blob) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Blob.ProtoReflect.Descriptor instead.
+func (*Blob) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Blob) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Blob) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// UsageMetadata is the token usage of a model response.
+type UsageMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of tokens in the prompt.
+	PromptTokenCount int32 `protobuf:"varint,1,opt,name=prompt_token_count,json=promptTokenCount,proto3" json:"prompt_token_count,omitempty"`
+	// Number of tokens in the response.
+	CandidatesTokenCount int32 `protobuf:"varint,2,opt,name=candidates_token_count,json=candidatesTokenCount,proto3" json:"candidates_token_count,omitempty"`
+	// Total number of tokens.
+	TotalTokenCount int32 `protobuf:"varint,3,opt,name=total_token_count,json=totalTokenCount,proto3" json:"total_token_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UsageMetadata) Reset() {
+	*x = UsageMetadata{}
+	mi := &file_runner_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageMetadata) ProtoMessage() {}
+
+func (x *UsageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageMetadata.ProtoReflect.Descriptor instead.
+func (*UsageMetadata) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UsageMetadata) GetPromptTokenCount() int32 {
+	if x != nil {
+		return x.PromptTokenCount
+	}
+	return 0
+}
+
+func (x *UsageMetadata) GetCandidatesTokenCount() int32 {
+	if x != nil {
+		return x.CandidatesTokenCount
+	}
+	return 0
+}
+
+func (x *UsageMetadata) GetTotalTokenCount() int32 {
+	if x != nil {
+		return x.TotalTokenCount
+	}
+	return 0
+}
+
+// EventActions are the actions attached to an event.
+type EventActions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Changes to the session state.
+	StateDelta *structpb.Struct `protobuf:"bytes,1,opt,name=state_delta,json=stateDelta,proto3" json:"state_delta,omitempty"`
+	// New versions of the artifacts saved by the event.
+	ArtifactDelta map[string]int64 `protobuf:"bytes,2,rep,name=artifact_delta,json=artifactDelta,proto3" json:"artifact_delta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// Agent the invocation is transferred to, if any.
+	TransferToAgent string `protobuf:"bytes,3,opt,name=transfer_to_agent,json=transferToAgent,proto3" json:"transfer_to_agent,omitempty"`
+	// Whether the agent escalates to its parent.
+	Escalate bool `protobuf:"varint,4,opt,name=escalate,proto3" json:"escalate,omitempty"`
+	// Whether the model skips summarizing the function response.
+	SkipSummarization bool `protobuf:"varint,5,opt,name=skip_summarization,json=skipSummarization,proto3" json:"skip_summarization,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EventActions) Reset() {
+	*x = EventActions{}
+	mi := &file_runner_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventActions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventActions) ProtoMessage() {}
+
+func (x *EventActions) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventActions.ProtoReflect.Descriptor instead.
+func (*EventActions) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EventActions) GetStateDelta() *structpb.Struct {
+	if x != nil {
+		return x.StateDelta
+	}
+	return nil
+}
+
+func (x *EventActions) GetArtifactDelta() map[string]int64 {
+	if x != nil {
+		return x.ArtifactDelta
+	}
+	return nil
+}
+
+func (x *EventActions) GetTransferToAgent() string {
+	if x != nil {
+		return x.TransferToAgent
+	}
+	return ""
+}
+
+func (x *EventActions) GetEscalate() bool {
+	if x != nil {
+		return x.Escalate
+	}
+	return false
+}
+
+func (x *EventActions) GetSkipSummarization() bool {
+	if x != nil {
+		return x.SkipSummarization
+	}
+	return false
+}
+
+// Event is an event of a session, mirroring session.Event.
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique ID of the event.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ID of the invocation that produced the event.
+	InvocationId string `protobuf:"bytes,2,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"`
+	// Author of the event, either "user" or the name of an agent.
+	Author string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	// Agent branch of the event.
+	Branch string `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	// Time of the event.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Content of the event.
+	Content *Content `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	// Whether the event is a partial streaming chunk.
+	Partial bool `protobuf:"varint,7,opt,name=partial,proto3" json:"partial,omitempty"`
+	// Whether the model turn is complete.
+	TurnComplete bool `protobuf:"varint,8,opt,name=turn_complete,json=turnComplete,proto3" json:"turn_complete,omitempty"`
+	// Whether the model generation was interrupted.
+	Interrupted bool `protobuf:"varint,9,opt,name=interrupted,proto3" json:"interrupted,omitempty"`
+	// Error code, if the event reports an error.
+	ErrorCode string `protobuf:"bytes,10,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	// Error message, if the event reports an error.
+	ErrorMessage string `protobuf:"bytes,11,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// Token usage of the model response.
+	UsageMetadata *UsageMetadata `protobuf:"bytes,12,opt,name=usage_metadata,json=usageMetadata,proto3" json:"usage_metadata,omitempty"`
+	// Custom metadata attached to the event.
+	CustomMetadata *structpb.Struct `protobuf:"bytes,13,opt,name=custom_metadata,json=customMetadata,proto3" json:"custom_metadata,omitempty"`
+	// Actions attached to the event.
+	Actions *EventActions `protobuf:"bytes,14,opt,name=actions,proto3" json:"actions,omitempty"`
+	// IDs of the long running function calls of the event.
+	LongRunningToolIds []string `protobuf:"bytes,15,rep,name=long_running_tool_ids,json=longRunningToolIds,proto3" json:"long_running_tool_ids,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_runner_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Event) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
+	}
+	return ""
+}
+
+func (x *Event) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Event) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *Event) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Event) GetContent() *Content {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *Event) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *Event) GetTurnComplete() bool {
+	if x != nil {
+		return x.TurnComplete
+	}
+	return false
+}
+
+func (x *Event) GetInterrupted() bool {
+	if x != nil {
+		return x.Interrupted
+	}
+	return false
+}
+
+func (x *Event) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *Event) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Event) GetUsageMetadata() *UsageMetadata {
+	if x != nil {
+		return x.UsageMetadata
+	}
+	return nil
+}
+
+func (x *Event) GetCustomMetadata() *structpb.Struct {
+	if x != nil {
+		return x.CustomMetadata
+	}
+	return nil
+}
+
+func (x *Event) GetActions() *EventActions {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *Event) GetLongRunningToolIds() []string {
+	if x != nil {
+		return x.LongRunningToolIds
+	}
+	return nil
+}
+
+// RunRequest runs an agent on a new user message.
+type RunRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the app to run.
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	// ID of the user.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ID of the session.
+	SessionId string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// New message of the user.
+	NewMessage *Content `protobuf:"bytes,4,opt,name=new_message,json=newMessage,proto3" json:"new_message,omitempty"`
+	// Changes applied to the session state before the run.
+	StateDelta *structpb.Struct `protobuf:"bytes,5,opt,name=state_delta,json=stateDelta,proto3" json:"state_delta,omitempty"`
+	// Whether the model streams partial responses.
+	Streaming     bool `protobuf:"varint,6,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunRequest) Reset() {
+	*x = RunRequest{}
+	mi := &file_runner_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunRequest) ProtoMessage() {}
+
+func (x *RunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunRequest.ProtoReflect.Descriptor instead.
+func (*RunRequest) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RunRequest) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *RunRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RunRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *RunRequest) GetNewMessage() *Content {
+	if x != nil {
+		return x.NewMessage
+	}
+	return nil
+}
+
+func (x *RunRequest) GetStateDelta() *structpb.Struct {
+	if x != nil {
+		return x.StateDelta
+	}
+	return nil
+}
+
+func (x *RunRequest) GetStreaming() bool {
+	if x != nil {
+		return x.Streaming
+	}
+	return false
+}
+
+// RunResponse holds all the events of an invocation.
+type RunResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Events of the invocation, in order.
+	Events        []*Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunResponse) Reset() {
+	*x = RunResponse{}
+	mi := &file_runner_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunResponse) ProtoMessage() {}
+
+func (x *RunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunResponse.ProtoReflect.Descriptor instead.
+func (*RunResponse) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RunResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// ResumeRequest resumes an invocation paused on a long running function call.
+type ResumeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the app to run.
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	// ID of the user.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ID of the session.
+	SessionId string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// ID of the function call to respond to.
+	FunctionCallId string `protobuf:"bytes,4,opt,name=function_call_id,json=functionCallId,proto3" json:"function_call_id,omitempty"`
+	// Response of the function call.
+	Response *structpb.Struct `protobuf:"bytes,5,opt,name=response,proto3" json:"response,omitempty"`
+	// Whether the model streams partial responses.
+	Streaming     bool `protobuf:"varint,6,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
+	mi := &file_runner_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRequest) ProtoMessage() {}
+
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ResumeRequest) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *ResumeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ResumeRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ResumeRequest) GetFunctionCallId() string {
+	if x != nil {
+		return x.FunctionCallId
+	}
+	return ""
+}
+
+func (x *ResumeRequest) GetResponse() *structpb.Struct {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ResumeRequest) GetStreaming() bool {
+	if x != nil {
+		return x.Streaming
+	}
+	return false
+}
+
+// CancelRequest cancels a running invocation.
+type CancelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the invocation to cancel.
+	InvocationId string `protobuf:"bytes,1,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"`
+	// Reason of the cancellation.
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRequest) Reset() {
+	*x = CancelRequest{}
+	mi := &file_runner_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRequest) ProtoMessage() {}
+
+func (x *CancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelRequest) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
+	}
+	return ""
+}
+
+func (x *CancelRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// CancelResponse reports whether an invocation was cancelled.
+type CancelResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the invocation was running and has been cancelled.
+	Cancelled     bool `protobuf:"varint,1,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelResponse) Reset() {
+	*x = CancelResponse{}
+	mi := &file_runner_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelResponse) ProtoMessage() {}
+
+func (x *CancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelResponse.ProtoReflect.Descriptor instead.
+func (*CancelResponse) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelResponse) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+// Session is a conversation between a user and the agents of an app.
+type Session struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the app.
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	// ID of the user.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ID of the session.
+	Id string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// State of the session.
+	State *structpb.Struct `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	// Events of the session, in order.
+	Events []*Event `protobuf:"bytes,5,rep,name=events,proto3" json:"events,omitempty"`
+	// Time of the last update of the session.
+	LastUpdateTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_update_time,json=lastUpdateTime,proto3" json:"last_update_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_runner_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Session) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *Session) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetState() *structpb.Struct {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *Session) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Session) GetLastUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdateTime
+	}
+	return nil
+}
+
+// CreateSessionRequest creates a session.
+type CreateSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the app.
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	// ID of the user.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ID of the session, generated when empty.
+	SessionId string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Initial state of the session.
+	State         *structpb.Struct `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSessionRequest) Reset() {
+	*x = CreateSessionRequest{}
+	mi := &file_runner_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionRequest) ProtoMessage() {}
+
+func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateSessionRequest) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetState() *structpb.Struct {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// GetSessionRequest gets a session.
+type GetSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the app.
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	// ID of the user.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ID of the session.
+	SessionId     string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSessionRequest) Reset() {
+	*x = GetSessionRequest{}
+	mi := &file_runner_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionRequest) ProtoMessage() {}
+
+func (x *GetSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetSessionRequest) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSessionRequest) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *GetSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// ListSessionsRequest lists the sessions of a user.
+type ListSessionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the app.
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	// ID of the user.
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_runner_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListSessionsRequest) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *ListSessionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// ListSessionsResponse holds the sessions of a user, without their events.
+type ListSessionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sessions of the user.
+	Sessions      []*Session `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_runner_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// DeleteSessionRequest deletes a session.
+type DeleteSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the app.
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	// ID of the user.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ID of the session.
+	SessionId     string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSessionRequest) Reset() {
+	*x = DeleteSessionRequest{}
+	mi := &file_runner_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSessionRequest) ProtoMessage() {}
+
+func (x *DeleteSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSessionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSessionRequest) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteSessionRequest) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *DeleteSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// DeleteSessionResponse is the empty response of DeleteSession.
+type DeleteSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSessionResponse) Reset() {
+	*x = DeleteSessionResponse{}
+	mi := &file_runner_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSessionResponse) ProtoMessage() {}
+
+func (x *DeleteSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSessionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSessionResponse) Descriptor() ([]byte, []int) {
+	return file_runner_proto_rawDescGZIP(), []int{19}
+}
+
+var File_runner_proto protoreflect.FileDescriptor
+
+const file_runner_proto_rawDesc = "" +
+	"\n" +
+	"\frunner.proto\x12\x14google.adk.runner.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"O\n" +
+	"\aContent\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x120\n" +
+	"\x05parts\x18\x02 \x03(\v2\x1a.google.adk.runner.v1.PartR\x05parts\"\x9f\x02\n" +
+	"\x04Part\x12\x14\n" +
+	"\x04text\x18\x01 \x01(\tH\x00R\x04text\x12I\n" +
+	"\rfunction_call\x18\x02 \x01(\v2\".google.adk.runner.v1.FunctionCallH\x00R\ffunctionCall\x12U\n" +
+	"\x11function_response\x18\x03 \x01(\v2&.google.adk.runner.v1.FunctionResponseH\x00R\x10functionResponse\x12=\n" +
+	"\vinline_data\x18\x04 \x01(\v2\x1a.google.adk.runner.v1.BlobH\x00R\n" +
+	"inlineData\x12\x18\n" +
+	"\athought\x18\x05 \x01(\bR\athoughtB\x06\n" +
+	"\x04data\"_\n" +
+	"\fFunctionCall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12+\n" +
+	"\x04args\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x04args\"k\n" +
+	"\x10FunctionResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x123\n" +
+	"\bresponse\x18\x03 \x01(\v2\x17.google.protobuf.StructR\bresponse\"7\n" +
+	"\x04Blob\x12\x1b\n" +
+	"\tmime_type\x18\x01 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x9f\x01\n" +
+	"\rUsageMetadata\x12,\n" +
+	"\x12prompt_token_count\x18\x01 \x01(\x05R\x10promptTokenCount\x124\n" +
+	"\x16candidates_token_count\x18\x02 \x01(\x05R\x14candidatesTokenCount\x12*\n" +
+	"\x11total_token_count\x18\x03 \x01(\x05R\x0ftotalTokenCount\"\xdf\x02\n" +
+	"\fEventActions\x128\n" +
+	"\vstate_delta\x18\x01 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"stateDelta\x12\\\n" +
+	"\x0eartifact_delta\x18\x02 \x03(\v25.google.adk.runner.v1.EventActions.ArtifactDeltaEntryR\rartifactDelta\x12*\n" +
+	"\x11transfer_to_agent\x18\x03 \x01(\tR\x0ftransferToAgent\x12\x1a\n" +
+	"\bescalate\x18\x04 \x01(\bR\bescalate\x12-\n" +
+	"\x12skip_summarization\x18\x05 \x01(\bR\x11skipSummarization\x1a@\n" +
+	"\x12ArtifactDeltaEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x83\x05\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rinvocation_id\x18\x02 \x01(\tR\finvocationId\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x16\n" +
+	"\x06branch\x18\x04 \x01(\tR\x06branch\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x127\n" +
+	"\acontent\x18\x06 \x01(\v2\x1d.google.adk.runner.v1.ContentR\acontent\x12\x18\n" +
+	"\apartial\x18\a \x01(\bR\apartial\x12#\n" +
+	"\rturn_complete\x18\b \x01(\bR\fturnComplete\x12 \n" +
+	"\vinterrupted\x18\t \x01(\bR\vinterrupted\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\n" +
+	" \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_message\x18\v \x01(\tR\ferrorMessage\x12J\n" +
+	"\x0eusage_metadata\x18\f \x01(\v2#.google.adk.runner.v1.UsageMetadataR\rusageMetadata\x12@\n" +
+	"\x0fcustom_metadata\x18\r \x01(\v2\x17.google.protobuf.StructR\x0ecustomMetadata\x12<\n" +
+	"\aactions\x18\x0e \x01(\v2\".google.adk.runner.v1.EventActionsR\aactions\x121\n" +
+	"\x15long_running_tool_ids\x18\x0f \x03(\tR\x12longRunningToolIds\"\xf7\x01\n" +
+	"\n" +
+	"RunRequest\x12\x19\n" +
+	"\bapp_name\x18\x01 \x01(\tR\aappName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12>\n" +
+	"\vnew_message\x18\x04 \x01(\v2\x1d.google.adk.runner.v1.ContentR\n" +
+	"newMessage\x128\n" +
+	"\vstate_delta\x18\x05 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"stateDelta\x12\x1c\n" +
+	"\tstreaming\x18\x06 \x01(\bR\tstreaming\"B\n" +
+	"\vRunResponse\x123\n" +
+	"\x06events\x18\x01 \x03(\v2\x1b.google.adk.runner.v1.EventR\x06events\"\xdf\x01\n" +
+	"\rResumeRequest\x12\x19\n" +
+	"\bapp_name\x18\x01 \x01(\tR\aappName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12(\n" +
+	"\x10function_call_id\x18\x04 \x01(\tR\x0efunctionCallId\x123\n" +
+	"\bresponse\x18\x05 \x01(\v2\x17.google.protobuf.StructR\bresponse\x12\x1c\n" +
+	"\tstreaming\x18\x06 \x01(\bR\tstreaming\"L\n" +
+	"\rCancelRequest\x12#\n" +
+	"\rinvocation_id\x18\x01 \x01(\tR\finvocationId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\".\n" +
+	"\x0eCancelResponse\x12\x1c\n" +
+	"\tcancelled\x18\x01 \x01(\bR\tcancelled\"\xf7\x01\n" +
+	"\aSession\x12\x19\n" +
+	"\bapp_name\x18\x01 \x01(\tR\aappName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12-\n" +
+	"\x05state\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x05state\x123\n" +
+	"\x06events\x18\x05 \x03(\v2\x1b.google.adk.runner.v1.EventR\x06events\x12D\n" +
+	"\x10last_update_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastUpdateTime\"\x98\x01\n" +
+	"\x14CreateSessionRequest\x12\x19\n" +
+	"\bapp_name\x18\x01 \x01(\tR\aappName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12-\n" +
+	"\x05state\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x05state\"f\n" +
+	"\x11GetSessionRequest\x12\x19\n" +
+	"\bapp_name\x18\x01 \x01(\tR\aappName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\"I\n" +
+	"\x13ListSessionsRequest\x12\x19\n" +
+	"\bapp_name\x18\x01 \x01(\tR\aappName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"Q\n" +
+	"\x14ListSessionsResponse\x129\n" +
+	"\bsessions\x18\x01 \x03(\v2\x1d.google.adk.runner.v1.SessionR\bsessions\"i\n" +
+	"\x14DeleteSessionRequest\x12\x19\n" +
+	"\bapp_name\x18\x01 \x01(\tR\aappName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\"\x17\n" +
+	"\x15DeleteSessionResponse2\xc8\x05\n" +
+	"\x06Runner\x12J\n" +
+	"\x03Run\x12 .google.adk.runner.v1.RunRequest\x1a!.google.adk.runner.v1.RunResponse\x12L\n" +
+	"\tRunStream\x12 .google.adk.runner.v1.RunRequest\x1a\x1b.google.adk.runner.v1.Event0\x01\x12L\n" +
+	"\x06Resume\x12#.google.adk.runner.v1.ResumeRequest\x1a\x1b.google.adk.runner.v1.Event0\x01\x12S\n" +
+	"\x06Cancel\x12#.google.adk.runner.v1.CancelRequest\x1a$.google.adk.runner.v1.CancelResponse\x12Z\n" +
+	"\rCreateSession\x12*.google.adk.runner.v1.CreateSessionRequest\x1a\x1d.google.adk.runner.v1.Session\x12T\n" +
+	"\n" +
+	"GetSession\x12'.google.adk.runner.v1.GetSessionRequest\x1a\x1d.google.adk.runner.v1.Session\x12e\n" +
+	"\fListSessions\x12).google.adk.runner.v1.ListSessionsRequest\x1a*.google.adk.runner.v1.ListSessionsResponse\x12h\n" +
+	"\rDeleteSession\x12*.google.adk.runner.v1.DeleteSessionRequest\x1a+.google.adk.runner.v1.DeleteSessionResponseB/Z-google.golang.org/adk/server/adkgrpc/runnerpbb\x06proto3"
+
+var (
+	file_runner_proto_rawDescOnce sync.Once
+	file_runner_proto_rawDescData []byte
+)
+
+func file_runner_proto_rawDescGZIP() []byte {
+	file_runner_proto_rawDescOnce.Do(func() {
+		file_runner_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_runner_proto_rawDesc), len(file_runner_proto_rawDesc)))
+	})
+	return file_runner_proto_rawDescData
+}
+
+var file_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_runner_proto_goTypes = []any{
+	(*Content)(nil),               // 0: google.adk.runner.v1.Content
+	(*Part)(nil),                  // 1: google.adk.runner.v1.Part
+	(*FunctionCall)(nil),          // 2: google.adk.runner.v1.FunctionCall
+	(*FunctionResponse)(nil),      // 3: google.adk.runner.v1.FunctionResponse
+	(*Blob)(nil),                  // 4: google.adk.runner.v1.Blob
+	(*UsageMetadata)(nil),         // 5: google.adk.runner.v1.UsageMetadata
+	(*EventActions)(nil),          // 6: google.adk.runner.v1.EventActions
+	(*Event)(nil),                 // 7: google.adk.runner.v1.Event
+	(*RunRequest)(nil),            // 8: google.adk.runner.v1.RunRequest
+	(*RunResponse)(nil),           // 9: google.adk.runner.v1.RunResponse
+	(*ResumeRequest)(nil),         // 10: google.adk.runner.v1.ResumeRequest
+	(*CancelRequest)(nil),         // 11: google.adk.runner.v1.CancelRequest
+	(*CancelResponse)(nil),        // 12: google.adk.runner.v1.CancelResponse
+	(*Session)(nil),               // 13: google.adk.runner.v1.Session
+	(*CreateSessionRequest)(nil),  // 14: google.adk.runner.v1.CreateSessionRequest
+	(*GetSessionRequest)(nil),     // 15: google.adk.runner.v1.GetSessionRequest
+	(*ListSessionsRequest)(nil),   // 16: google.adk.runner.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),  // 17: google.adk.runner.v1.ListSessionsResponse
+	(*DeleteSessionRequest)(nil),  // 18: google.adk.runner.v1.DeleteSessionRequest
+	(*DeleteSessionResponse)(nil), // 19: google.adk.runner.v1.DeleteSessionResponse
+	nil,                           // 20: google.adk.runner.v1.EventActions.ArtifactDeltaEntry
+	(*structpb.Struct)(nil),       // 21: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 22: google.protobuf.Timestamp
+}
+var file_runner_proto_depIdxs = []int32{
+	1,  // 0: google.adk.runner.v1.Content.parts:type_name -> google.adk.runner.v1.Part
+	2,  // 1: google.adk.runner.v1.Part.function_call:type_name -> google.adk.runner.v1.FunctionCall
+	3,  // 2: google.adk.runner.v1.Part.function_response:type_name -> google.adk.runner.v1.FunctionResponse
+	4,  // 3: google.adk.runner.v1.Part.inline_data:type_name -> google.adk.runner.v1.Blob
+	21, // 4: google.adk.runner.v1.FunctionCall.args:type_name -> google.protobuf.Struct
+	21, // 5: google.adk.runner.v1.FunctionResponse.response:type_name -> google.protobuf.Struct
+	21, // 6: google.adk.runner.v1.EventActions.state_delta:type_name -> google.protobuf.Struct
+	20, // 7: google.adk.runner.v1.EventActions.artifact_delta:type_name -> google.adk.runner.v1.EventActions.ArtifactDeltaEntry
+	22, // 8: google.adk.runner.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 9: google.adk.runner.v1.Event.content:type_name -> google.adk.runner.v1.Content
+	5,  // 10: google.adk.runner.v1.Event.usage_metadata:type_name -> google.adk.runner.v1.UsageMetadata
+	21, // 11: google.adk.runner.v1.Event.custom_metadata:type_name -> google.protobuf.Struct
+	6,  // 12: google.adk.runner.v1.Event.actions:type_name -> google.adk.runner.v1.EventActions
+	0,  // 13: google.adk.runner.v1.RunRequest.new_message:type_name -> google.adk.runner.v1.Content
+	21, // 14: google.adk.runner.v1.RunRequest.state_delta:type_name -> google.protobuf.Struct
+	7,  // 15: google.adk.runner.v1.RunResponse.events:type_name -> google.adk.runner.v1.Event
+	21, // 16: google.adk.runner.v1.ResumeRequest.response:type_name -> google.protobuf.Struct
+	21, // 17: google.adk.runner.v1.Session.state:type_name -> google.protobuf.Struct
+	7,  // 18: google.adk.runner.v1.Session.events:type_name -> google.adk.runner.v1.Event
+	22, // 19: google.adk.runner.v1.Session.last_update_time:type_name -> google.protobuf.Timestamp
+	21, // 20: google.adk.runner.v1.CreateSessionRequest.state:type_name -> google.protobuf.Struct
+	13, // 21: google.adk.runner.v1.ListSessionsResponse.sessions:type_name -> google.adk.runner.v1.Session
+	8,  // 22: google.adk.runner.v1.Runner.Run:input_type -> google.adk.runner.v1.RunRequest
+	8,  // 23: google.adk.runner.v1.Runner.RunStream:input_type -> google.adk.runner.v1.RunRequest
+	10, // 24: google.adk.runner.v1.Runner.Resume:input_type -> google.adk.runner.v1.ResumeRequest
+	11, // 25: google.adk.runner.v1.Runner.Cancel:input_type -> google.adk.runner.v1.CancelRequest
+	14, // 26: google.adk.runner.v1.Runner.CreateSession:input_type -> google.adk.runner.v1.CreateSessionRequest
+	15, // 27: google.adk.runner.v1.Runner.GetSession:input_type -> google.adk.runner.v1.GetSessionRequest
+	16, // 28: google.adk.runner.v1.Runner.ListSessions:input_type -> google.adk.runner.v1.ListSessionsRequest
+	18, // 29: google.adk.runner.v1.Runner.DeleteSession:input_type -> google.adk.runner.v1.DeleteSessionRequest
+	9,  // 30: google.adk.runner.v1.Runner.Run:output_type -> google.adk.runner.v1.RunResponse
+	7,  // 31: google.adk.runner.v1.Runner.RunStream:output_type -> google.adk.runner.v1.Event
+	7,  // 32: google.adk.runner.v1.Runner.Resume:output_type -> google.adk.runner.v1.Event
+	12, // 33: google.adk.runner.v1.Runner.Cancel:output_type -> google.adk.runner.v1.CancelResponse
+	13, // 34: google.adk.runner.v1.Runner.CreateSession:output_type -> google.adk.runner.v1.Session
+	13, // 35: google.adk.runner.v1.Runner.GetSession:output_type -> google.adk.runner.v1.Session
+	17, // 36: google.adk.runner.v1.Runner.ListSessions:output_type -> google.adk.runner.v1.ListSessionsResponse
+	19, // 37: google.adk.runner.v1.Runner.DeleteSession:output_type -> google.adk.runner.v1.DeleteSessionResponse
+	30, // [30:38] is the sub-list for method output_type
+	22, // [22:30] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_runner_proto_init() }
+func file_runner_proto_init() {
+	if File_runner_proto != nil {
+		return
+	}
+	file_runner_proto_msgTypes[1].OneofWrappers = []any{
+		(*Part_Text)(nil),
+		(*Part_FunctionCall)(nil),
+		(*Part_FunctionResponse)(nil),
+		(*Part_InlineData)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_runner_proto_rawDesc), len(file_runner_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_runner_proto_goTypes,
+		DependencyIndexes: file_runner_proto_depIdxs,
+		MessageInfos:      file_runner_proto_msgTypes,
+	}.Build()
+	File_runner_proto = out.File
+	file_runner_proto_goTypes = nil
+	file_runner_proto_depIdxs = nil
+}
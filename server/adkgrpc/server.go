@@ -0,0 +1,333 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkgrpc
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"google.golang.org/adk/admission"
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/quota"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/server/adkgrpc/runnerpb"
+	"google.golang.org/adk/session"
+)
+
+// Config contains the services used by the Server to run the agents.
+type Config struct {
+	// AgentLoader loads the agent of the app of the requests.
+	AgentLoader agent.Loader
+	// SessionService stores the sessions of the runs.
+	SessionService session.Service
+	// ArtifactService stores the artifacts saved by the agents.
+	ArtifactService artifact.Service // optional
+	// MemoryService is the memory searched by the agents.
+	MemoryService memory.Service // optional
+	// PluginConfig configures the plugins of the runners.
+	PluginConfig runner.PluginConfig // optional
+	// Quota limits the invocations and the tokens of the runs.
+	Quota *quota.Enforcer // optional
+	// Admission limits the concurrent runs, see runner.Config.Admission.
+	// The rejected runs fail with codes.ResourceExhausted.
+	Admission *admission.Controller // optional
+	// SessionLocker and InstanceID configure the runners of the server for
+	// multiple replicas, see runner.Config.
+	SessionLocker runner.SessionLocker // optional
+	InstanceID    string               // optional
+}
+
+// Server implements the Runner service of runnerpb. It is registered on a
+// gRPC server with runnerpb.RegisterRunnerServer.
+//
+// The errors are reported with the same codes whatever the session service:
+// InvalidArgument for invalid requests, NotFound for unknown sessions and
+// function calls, AlreadyExists when creating a session that already exists
+// and FailedPrecondition when resuming a function call that already has a
+// response.
+type Server struct {
+	runnerpb.UnimplementedRunnerServer
+
+	config Config
+}
+
+// NewServer creates a Server running the agents with the services of cfg.
+func NewServer(cfg Config) *Server {
+	return &Server{config: cfg}
+}
+
+// Run runs an agent on a user message and returns all the events of the
+// invocation once it is complete. The partial events of a streaming run are
+// skipped: their content is repeated by the following complete event.
+func (s *Server) Run(ctx context.Context, req *runnerpb.RunRequest) (*runnerpb.RunResponse, error) {
+	events, err := s.run(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &runnerpb.RunResponse{}
+	for event, err := range events {
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		if event.Partial {
+			continue
+		}
+		pbEvent, err := fromSessionEvent(event)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert event: %v", err)
+		}
+		resp.Events = append(resp.Events, pbEvent)
+	}
+	return resp, nil
+}
+
+// RunStream runs an agent on a user message and streams its events.
+func (s *Server) RunStream(req *runnerpb.RunRequest, stream runnerpb.Runner_RunStreamServer) error {
+	events, err := s.run(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return sendEvents(events, stream)
+}
+
+// Resume responds to a long running function call and streams the events of
+// the resumed invocation, see runner.Runner.ResumeWithFunctionResponse.
+func (s *Server) Resume(req *runnerpb.ResumeRequest, stream runnerpb.Runner_ResumeServer) error {
+	if err := requireSession(req.GetAppName(), req.GetUserId(), req.GetSessionId()); err != nil {
+		return err
+	}
+	if req.GetFunctionCallId() == "" {
+		return status.Error(codes.InvalidArgument, "function_call_id is required")
+	}
+	r, err := s.newRunner(req.GetAppName())
+	if err != nil {
+		return err
+	}
+	events := r.ResumeWithFunctionResponse(stream.Context(), req.GetUserId(), req.GetSessionId(), req.GetFunctionCallId(), req.GetResponse().AsMap(), runConfig(req.GetStreaming()))
+	return sendEvents(events, stream)
+}
+
+// Cancel cancels a running invocation of this process, see
+// runner.CancelRunningInvocation.
+func (s *Server) Cancel(ctx context.Context, req *runnerpb.CancelRequest) (*runnerpb.CancelResponse, error) {
+	if req.GetInvocationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "invocation_id is required")
+	}
+	return &runnerpb.CancelResponse{
+		Cancelled: runner.CancelRunningInvocation(req.GetInvocationId(), req.GetReason()),
+	}, nil
+}
+
+// CreateSession creates a session with the optional state of the request.
+func (s *Server) CreateSession(ctx context.Context, req *runnerpb.CreateSessionRequest) (*runnerpb.Session, error) {
+	if err := requireUser(req.GetAppName(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+	resp, err := s.config.SessionService.Create(ctx, &session.CreateRequest{
+		AppName:   req.GetAppName(),
+		UserID:    req.GetUserId(),
+		SessionID: req.GetSessionId(),
+		State:     req.GetState().AsMap(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBSession(resp.Session, true)
+}
+
+// GetSession gets a session with its events.
+func (s *Server) GetSession(ctx context.Context, req *runnerpb.GetSessionRequest) (*runnerpb.Session, error) {
+	if err := requireSession(req.GetAppName(), req.GetUserId(), req.GetSessionId()); err != nil {
+		return nil, err
+	}
+	resp, err := s.config.SessionService.Get(ctx, &session.GetRequest{
+		AppName:   req.GetAppName(),
+		UserID:    req.GetUserId(),
+		SessionID: req.GetSessionId(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBSession(resp.Session, true)
+}
+
+// ListSessions lists the sessions of a user, without their events.
+func (s *Server) ListSessions(ctx context.Context, req *runnerpb.ListSessionsRequest) (*runnerpb.ListSessionsResponse, error) {
+	if err := requireUser(req.GetAppName(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+	resp, err := s.config.SessionService.List(ctx, &session.ListRequest{
+		AppName: req.GetAppName(),
+		UserID:  req.GetUserId(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	sessions := &runnerpb.ListSessionsResponse{}
+	for _, sess := range resp.Sessions {
+		pbSession, err := toPBSession(sess, false)
+		if err != nil {
+			return nil, err
+		}
+		sessions.Sessions = append(sessions.Sessions, pbSession)
+	}
+	return sessions, nil
+}
+
+// DeleteSession deletes a session.
+func (s *Server) DeleteSession(ctx context.Context, req *runnerpb.DeleteSessionRequest) (*runnerpb.DeleteSessionResponse, error) {
+	if err := requireSession(req.GetAppName(), req.GetUserId(), req.GetSessionId()); err != nil {
+		return nil, err
+	}
+	// Not all the session services report the deletion of unknown sessions.
+	if _, err := s.config.SessionService.Get(ctx, &session.GetRequest{
+		AppName:         req.GetAppName(),
+		UserID:          req.GetUserId(),
+		SessionID:       req.GetSessionId(),
+		NumRecentEvents: 1,
+	}); err != nil {
+		return nil, toStatus(err)
+	}
+	err := s.config.SessionService.Delete(ctx, &session.DeleteRequest{
+		AppName:   req.GetAppName(),
+		UserID:    req.GetUserId(),
+		SessionID: req.GetSessionId(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &runnerpb.DeleteSessionResponse{}, nil
+}
+
+// run starts the run of the request.
+func (s *Server) run(ctx context.Context, req *runnerpb.RunRequest) (iter.Seq2[*session.Event, error], error) {
+	if err := requireSession(req.GetAppName(), req.GetUserId(), req.GetSessionId()); err != nil {
+		return nil, err
+	}
+	if req.GetNewMessage() == nil {
+		return nil, status.Error(codes.InvalidArgument, "new_message is required")
+	}
+	msg, err := toGenAIContent(req.GetNewMessage())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid new_message: %v", err)
+	}
+	r, err := s.newRunner(req.GetAppName())
+	if err != nil {
+		return nil, err
+	}
+	var opts []runner.RunOption
+	if req.GetStateDelta() != nil {
+		opts = append(opts, runner.WithStateDelta(req.GetStateDelta().AsMap()))
+	}
+	return r.Run(ctx, req.GetUserId(), req.GetSessionId(), msg, runConfig(req.GetStreaming()), opts...), nil
+}
+
+// newRunner creates the runner of the agent of the app.
+func (s *Server) newRunner(appName string) (*runner.Runner, error) {
+	a, err := s.config.AgentLoader.LoadAgent(appName)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to load agent: %v", err)
+	}
+	r, err := runner.New(runner.Config{
+		AppName:         appName,
+		Agent:           a,
+		SessionService:  s.config.SessionService,
+		ArtifactService: s.config.ArtifactService,
+		MemoryService:   s.config.MemoryService,
+		PluginConfig:    s.config.PluginConfig,
+		Quota:           s.config.Quota,
+		Admission:       s.config.Admission,
+		SessionLocker:   s.config.SessionLocker,
+		InstanceID:      s.config.InstanceID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create runner: %v", err)
+	}
+	return r, nil
+}
+
+// sendEvents sends the events to the stream as they are produced.
+func sendEvents(events iter.Seq2[*session.Event, error], stream runnerpb.Runner_RunStreamServer) error {
+	for event, err := range events {
+		if err != nil {
+			return toStatus(err)
+		}
+		pbEvent, err := fromSessionEvent(event)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to convert event: %v", err)
+		}
+		if err := stream.Send(pbEvent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runConfig(streaming bool) agent.RunConfig {
+	if streaming {
+		return agent.RunConfig{StreamingMode: agent.StreamingModeSSE}
+	}
+	return agent.RunConfig{StreamingMode: agent.StreamingModeNone}
+}
+
+func requireUser(appName, userID string) error {
+	if appName == "" {
+		return status.Error(codes.InvalidArgument, "app_name is required")
+	}
+	if userID == "" {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	return nil
+}
+
+func requireSession(appName, userID, sessionID string) error {
+	if err := requireUser(appName, userID); err != nil {
+		return err
+	}
+	if sessionID == "" {
+		return status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	return nil
+}
+
+// toStatus returns the gRPC status error with the code of the kind of err.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var rejected *admission.RejectedError
+	switch {
+	case errors.As(err, &rejected):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, session.ErrSessionNotFound), errors.Is(err, session.ErrEventNotFound), errors.Is(err, runner.ErrFunctionCallNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, session.ErrSessionAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, runner.ErrFunctionCallAnswered):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
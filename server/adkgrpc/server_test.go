@@ -0,0 +1,284 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adkgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/server/adkgrpc/runnerpb"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// newTestClient serves the agent with a Server and returns a client
+// connected to it.
+func newTestClient(t *testing.T, a agent.Agent, sessionService session.Service) runnerpb.RunnerClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	runnerpb.RegisterRunnerServer(grpcServer, NewServer(Config{
+		AgentLoader:    agent.NewSingleLoader(a),
+		SessionService: sessionService,
+	}))
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return runnerpb.NewRunnerClient(conn)
+}
+
+func newWeatherAgent(t *testing.T) agent.Agent {
+	t.Helper()
+	type args struct {
+		City string `json:"city"`
+	}
+	getWeather, err := functiontool.New(functiontool.Config{Name: "get_weather"}, func(ctx tool.Context, a args) (map[string]any, error) {
+		return map[string]any{"weather": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name: "weather_agent",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": "Paris"}, genai.RoleModel),
+			genai.NewContentFromText("It is sunny in Paris.", genai.RoleModel),
+		}},
+		Tools: []tool.Tool{getWeather},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func userMessage(text string) *runnerpb.Content {
+	return &runnerpb.Content{Role: genai.RoleUser, Parts: []*runnerpb.Part{{Data: &runnerpb.Part_Text{Text: text}}}}
+}
+
+func TestServer_Run(t *testing.T) {
+	ctx := t.Context()
+	sessionService := session.InMemoryService()
+	client := newTestClient(t, newWeatherAgent(t), sessionService)
+	if _, err := client.CreateSession(ctx, &runnerpb.CreateSessionRequest{AppName: "weather_agent", UserId: "user", SessionId: "s1"}); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	resp, err := client.Run(ctx, &runnerpb.RunRequest{
+		AppName:    "weather_agent",
+		UserId:     "user",
+		SessionId:  "s1",
+		NewMessage: userMessage("Weather in Paris?"),
+		StateDelta: must(structpb.NewStruct(map[string]any{"unit": "celsius"})),
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	events := resp.GetEvents()
+	if len(events) != 3 {
+		t.Fatalf("Run() returned %d events, want the tool call, the tool response and the answer", len(events))
+	}
+	call := events[0].GetContent().GetParts()[0].GetFunctionCall()
+	if call.GetName() != "get_weather" || call.GetArgs().GetFields()["city"].GetStringValue() != "Paris" {
+		t.Errorf("events[0] = %v, want the get_weather call", events[0].GetContent())
+	}
+	response := events[1].GetContent().GetParts()[0].GetFunctionResponse()
+	if response.GetId() != call.GetId() || response.GetResponse().GetFields()["weather"].GetStringValue() != "sunny" {
+		t.Errorf("events[1] = %v, want the get_weather response", events[1].GetContent())
+	}
+	if got := events[2].GetContent().GetParts()[0].GetText(); got != "It is sunny in Paris." {
+		t.Errorf("events[2] text = %q, want the answer", got)
+	}
+	for i, event := range events {
+		if event.GetTimestamp() == nil || event.GetAuthor() != "weather_agent" || event.GetInvocationId() == "" {
+			t.Errorf("events[%d] = %v, want a timestamp, an invocation and the agent as author", i, event)
+		}
+	}
+
+	got, err := client.GetSession(ctx, &runnerpb.GetSessionRequest{AppName: "weather_agent", UserId: "user", SessionId: "s1"})
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	if len(got.GetEvents()) != 4 {
+		t.Errorf("GetSession() has %d events, want the user message and the 3 events of the run", len(got.GetEvents()))
+	}
+	if unit := got.GetState().GetFields()["unit"].GetStringValue(); unit != "celsius" {
+		t.Errorf("session state unit = %q, want the state delta of the request", unit)
+	}
+}
+
+func TestServer_RunStream(t *testing.T) {
+	ctx := t.Context()
+	client := newTestClient(t, newWeatherAgent(t), session.InMemoryService())
+	if _, err := client.CreateSession(ctx, &runnerpb.CreateSessionRequest{AppName: "weather_agent", UserId: "user", SessionId: "s1"}); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	stream, err := client.RunStream(ctx, &runnerpb.RunRequest{AppName: "weather_agent", UserId: "user", SessionId: "s1", NewMessage: userMessage("Weather in Paris?")})
+	if err != nil {
+		t.Fatalf("RunStream() error = %v", err)
+	}
+	var authors []string
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		authors = append(authors, event.GetAuthor())
+	}
+	if diff := cmp.Diff([]string{"weather_agent", "weather_agent", "weather_agent"}, authors); diff != "" {
+		t.Errorf("RunStream() authors mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	ctx := t.Context()
+	client := newTestClient(t, newWeatherAgent(t), session.InMemoryService())
+	if _, err := client.CreateSession(ctx, &runnerpb.CreateSessionRequest{AppName: "weather_agent", UserId: "user", SessionId: "s1"}); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{
+			name: "run without message",
+			call: func() error {
+				_, err := client.Run(ctx, &runnerpb.RunRequest{AppName: "weather_agent", UserId: "user", SessionId: "s1"})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "run of unknown session",
+			call: func() error {
+				_, err := client.Run(ctx, &runnerpb.RunRequest{AppName: "weather_agent", UserId: "user", SessionId: "other", NewMessage: userMessage("hi")})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "resume of unknown function call",
+			call: func() error {
+				stream, err := client.Resume(ctx, &runnerpb.ResumeRequest{AppName: "weather_agent", UserId: "user", SessionId: "s1", FunctionCallId: "unknown"})
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "create existing session",
+			call: func() error {
+				_, err := client.CreateSession(ctx, &runnerpb.CreateSessionRequest{AppName: "weather_agent", UserId: "user", SessionId: "s1"})
+				return err
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "delete unknown session",
+			call: func() error {
+				_, err := client.DeleteSession(ctx, &runnerpb.DeleteSessionRequest{AppName: "weather_agent", UserId: "user", SessionId: "other"})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != tt.wantCode {
+				t.Errorf("error code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServer_Sessions(t *testing.T) {
+	ctx := t.Context()
+	client := newTestClient(t, newWeatherAgent(t), session.InMemoryService())
+
+	created, err := client.CreateSession(ctx, &runnerpb.CreateSessionRequest{
+		AppName: "weather_agent",
+		UserId:  "user",
+		State:   must(structpb.NewStruct(map[string]any{"unit": "celsius"})),
+	})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	if created.GetId() == "" {
+		t.Errorf("CreateSession() = %v, want a generated ID", created)
+	}
+
+	list, err := client.ListSessions(ctx, &runnerpb.ListSessionsRequest{AppName: "weather_agent", UserId: "user"})
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	want := &runnerpb.ListSessionsResponse{Sessions: []*runnerpb.Session{created}}
+	if diff := cmp.Diff(want, list, protocmp.Transform(), protocmp.IgnoreFields(&runnerpb.Session{}, "last_update_time")); diff != "" {
+		t.Errorf("ListSessions() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := client.DeleteSession(ctx, &runnerpb.DeleteSessionRequest{AppName: "weather_agent", UserId: "user", SessionId: created.GetId()}); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+	if _, err := client.GetSession(ctx, &runnerpb.GetSessionRequest{AppName: "weather_agent", UserId: "user", SessionId: created.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetSession() of deleted session error = %v, want NotFound", err)
+	}
+
+	cancelled, err := client.Cancel(ctx, &runnerpb.CancelRequest{InvocationId: "e-unknown"})
+	if err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if cancelled.GetCancelled() {
+		t.Errorf("Cancel() of unknown invocation = true, want false")
+	}
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
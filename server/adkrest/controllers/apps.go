@@ -15,9 +15,14 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
+
+	"github.com/gorilla/mux"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/server/adkrest/internal/models"
 )
 
 // AppsAPIController is the controller for the Apps API.
@@ -35,3 +40,17 @@ func (c *AppsAPIController) ListAppsHandler(rw http.ResponseWriter, req *http.Re
 	apps := c.agentLoader.ListAgents()
 	EncodeJSONResponse(apps, http.StatusOK, rw)
 }
+
+// GetAppHandler handles describing a loaded app and its root agent.
+func (c *AppsAPIController) GetAppHandler(rw http.ResponseWriter, req *http.Request) error {
+	appName := mux.Vars(req)["app_name"]
+	if !slices.Contains(c.agentLoader.ListAgents(), appName) {
+		return newStatusError(fmt.Errorf("app %s not found", appName), http.StatusNotFound)
+	}
+	a, err := c.agentLoader.LoadAgent(appName)
+	if err != nil {
+		return newStatusError(err, http.StatusInternalServerError)
+	}
+	EncodeJSONResponse(models.FromAgent(appName, a), http.StatusOK, rw)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/session"
+)
+
+func newNamedAgent(t *testing.T, name string, subAgents ...agent.Agent) agent.Agent {
+	t.Helper()
+	a, err := agent.New(agent.Config{
+		Name:        name,
+		Description: "The " + name + " agent.",
+		SubAgents:   subAgents,
+		Run: func(agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(func(*session.Event, error) bool) {}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestGetAppHandler(t *testing.T) {
+	loader, err := agent.NewAppLoader("weather", map[string]agent.AppConstructor{
+		"weather": func() (agent.Agent, error) {
+			return newNamedAgent(t, "weather_agent", newNamedAgent(t, "forecast_agent")), nil
+		},
+		"broken": func() (agent.Agent, error) { return nil, errors.New("bad config") },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	controller := controllers.NewAppsAPIController(loader)
+
+	tests := []struct {
+		name       string
+		appName    string
+		wantStatus int
+	}{
+		{name: "app", appName: "weather", wantStatus: http.StatusOK},
+		{name: "unknown app", appName: "billing", wantStatus: http.StatusNotFound},
+		{name: "app failing to load", appName: "broken", wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/apps/"+tt.appName, nil), map[string]string{"app_name": tt.appName})
+			rr := httptest.NewRecorder()
+			controllers.NewErrorHandler(controller.GetAppHandler)(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got struct {
+				Name          string   `json:"name"`
+				RootAgentName string   `json:"rootAgentName"`
+				Description   string   `json:"description"`
+				SubAgents     []string `json:"subAgents"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != "weather" || got.RootAgentName != "weather_agent" || got.Description != "The weather_agent agent." || !slices.Equal(got.SubAgents, []string{"forecast_agent"}) {
+				t.Errorf("app = %+v, want the weather app", got)
+			}
+		})
+	}
+}
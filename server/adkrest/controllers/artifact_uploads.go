@@ -0,0 +1,248 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/server/adkrest/internal/models"
+)
+
+// artifactUploadExpiry is the time after which the resumable uploads that
+// received no chunk are discarded.
+const artifactUploadExpiry = 24 * time.Hour
+
+// artifactUpload is a resumable upload of an artifact, whose received chunks
+// are stored in a temporary file until the upload is complete.
+type artifactUpload struct {
+	mu      sync.Mutex
+	id      string
+	req     artifact.SaveStreamRequest
+	size    int64
+	offset  int64
+	file    *os.File
+	updated time.Time
+	// removed is set once the upload is complete or discarded.
+	removed bool
+}
+
+// status returns the status of the upload.
+func (u *artifactUpload) status() models.ArtifactUpload {
+	return models.ArtifactUpload{UploadID: u.id, Offset: u.offset, Size: u.size}
+}
+
+// discard removes the temporary file of the upload.
+func (u *artifactUpload) discard() error {
+	return errors.Join(u.file.Close(), os.Remove(u.file.Name()))
+}
+
+// CreateArtifactUploadHandler starts a resumable upload of an artifact, whose
+// content is then sent in chunks with ArtifactUploadChunkHandler. Uploads
+// that receive no chunk for a day are discarded.
+func (c *ArtifactsAPIController) CreateArtifactUploadHandler(rw http.ResponseWriter, req *http.Request) {
+	sessionID, artifactName, err := artifactParameters(req)
+	if err != nil {
+		writeArtifactError(rw, err)
+		return
+	}
+	var createReq models.CreateArtifactUploadRequest
+	if err := json.NewDecoder(req.Body).Decode(&createReq); err != nil {
+		http.Error(rw, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if createReq.Size <= 0 {
+		http.Error(rw, "size must be positive", http.StatusBadRequest)
+		return
+	}
+	c.discardExpiredUploads(req)
+	file, err := os.CreateTemp(c.uploadDir, "adk-artifact-upload-*")
+	if err != nil {
+		writeArtifactError(rw, fmt.Errorf("failed to create upload file: %w", err))
+		return
+	}
+	upload := &artifactUpload{
+		id: uuid.NewString(),
+		req: artifact.SaveStreamRequest{
+			AppName:   sessionID.AppName,
+			UserID:    sessionID.UserID,
+			SessionID: sessionID.ID,
+			FileName:  artifactName,
+			MIMEType:  uploadMIMEType(createReq.MIMEType),
+		},
+		size:    createReq.Size,
+		file:    file,
+		updated: time.Now(),
+	}
+	c.mu.Lock()
+	c.uploads[upload.id] = upload
+	c.mu.Unlock()
+	EncodeJSONResponse(upload.status(), http.StatusCreated, rw)
+}
+
+// ArtifactUploadChunkHandler receives a chunk of a resumable upload, whose
+// position is given by the Content-Range header, e.g. "bytes 0-1048575/4194304".
+// The chunk must start at the offset of the upload, otherwise it is rejected
+// with 409 Conflict and the client resumes from the offset returned by
+// GetArtifactUploadHandler. With the last chunk, the artifact is saved and
+// its version returned.
+func (c *ArtifactsAPIController) ArtifactUploadChunkHandler(rw http.ResponseWriter, req *http.Request) {
+	upload, err := c.lockArtifactUpload(req)
+	if err != nil {
+		writeArtifactError(rw, err)
+		return
+	}
+	defer upload.mu.Unlock()
+
+	var start, end, size int64
+	if _, err := fmt.Sscanf(req.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &size); err != nil || start > end {
+		http.Error(rw, `Content-Range header must be "bytes <start>-<end>/<size>"`, http.StatusBadRequest)
+		return
+	}
+	if size != upload.size || end >= size {
+		http.Error(rw, fmt.Sprintf("Content-Range %d-%d/%d does not fit the upload of %d bytes", start, end, size, upload.size), http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	if start != upload.offset {
+		http.Error(rw, fmt.Sprintf("chunk starts at %d, want the offset %d of the upload", start, upload.offset), http.StatusConflict)
+		return
+	}
+	// The offset is only advanced once the whole chunk is written, so that an
+	// interrupted chunk is sent again.
+	length := end - start + 1
+	n, err := io.Copy(io.NewOffsetWriter(upload.file, start), io.LimitReader(req.Body, length))
+	if err != nil {
+		writeArtifactError(rw, fmt.Errorf("failed to write chunk: %w", err))
+		return
+	}
+	if n != length {
+		http.Error(rw, fmt.Sprintf("chunk has %d bytes, want %d", n, length), http.StatusBadRequest)
+		return
+	}
+	upload.updated = time.Now()
+	if end+1 < upload.size {
+		upload.offset = end + 1
+		EncodeJSONResponse(upload.status(), http.StatusOK, rw)
+		return
+	}
+
+	saveReq := upload.req
+	saveReq.Content = io.NewSectionReader(upload.file, 0, upload.size)
+	version, err := c.saveArtifact(req.Context(), &saveReq)
+	if err != nil {
+		writeArtifactError(rw, err)
+		return
+	}
+	upload.offset = upload.size
+	c.removeUpload(req, upload)
+	status := upload.status()
+	status.Version = version
+	EncodeJSONResponse(status, http.StatusCreated, rw)
+}
+
+// GetArtifactUploadHandler returns the status of a resumable upload, with
+// the offset from which to resume it.
+func (c *ArtifactsAPIController) GetArtifactUploadHandler(rw http.ResponseWriter, req *http.Request) {
+	upload, err := c.lockArtifactUpload(req)
+	if err != nil {
+		writeArtifactError(rw, err)
+		return
+	}
+	defer upload.mu.Unlock()
+	EncodeJSONResponse(upload.status(), http.StatusOK, rw)
+}
+
+// CancelArtifactUploadHandler discards a resumable upload.
+func (c *ArtifactsAPIController) CancelArtifactUploadHandler(rw http.ResponseWriter, req *http.Request) {
+	upload, err := c.lockArtifactUpload(req)
+	if err != nil {
+		writeArtifactError(rw, err)
+		return
+	}
+	defer upload.mu.Unlock()
+	c.removeUpload(req, upload)
+	EncodeJSONResponse(nil, http.StatusOK, rw)
+}
+
+// lockArtifactUpload locks and returns the upload of the URL, which must be
+// an upload of the artifact of the URL.
+func (c *ArtifactsAPIController) lockArtifactUpload(req *http.Request) (*artifactUpload, error) {
+	sessionID, artifactName, err := artifactParameters(req)
+	if err != nil {
+		return nil, err
+	}
+	uploadID := mux.Vars(req)["upload_id"]
+	c.mu.Lock()
+	upload, ok := c.uploads[uploadID]
+	c.mu.Unlock()
+	notFound := newStatusError(fmt.Errorf("upload %q not found", uploadID), http.StatusNotFound)
+	if !ok || upload.req.AppName != sessionID.AppName || upload.req.UserID != sessionID.UserID ||
+		upload.req.SessionID != sessionID.ID || upload.req.FileName != artifactName {
+		return nil, notFound
+	}
+	upload.mu.Lock()
+	// The upload may have been removed while waiting for the lock.
+	if upload.removed {
+		upload.mu.Unlock()
+		return nil, notFound
+	}
+	return upload, nil
+}
+
+// removeUpload removes the upload, locked by the caller, and its temporary
+// file.
+func (c *ArtifactsAPIController) removeUpload(req *http.Request, upload *artifactUpload) {
+	upload.removed = true
+	c.mu.Lock()
+	delete(c.uploads, upload.id)
+	c.mu.Unlock()
+	if err := upload.discard(); err != nil {
+		logging.FromContext(req.Context()).Warn("failed to remove artifact upload file", "upload_id", upload.id, "error", err)
+	}
+}
+
+// discardExpiredUploads removes the uploads that received no chunk for
+// artifactUploadExpiry.
+func (c *ArtifactsAPIController) discardExpiredUploads(req *http.Request) {
+	c.mu.Lock()
+	var expired []*artifactUpload
+	for _, upload := range c.uploads {
+		// Uploads receiving a chunk are not expired.
+		if !upload.mu.TryLock() {
+			continue
+		}
+		if time.Since(upload.updated) > artifactUploadExpiry {
+			expired = append(expired, upload)
+		} else {
+			upload.mu.Unlock()
+		}
+	}
+	c.mu.Unlock()
+	for _, upload := range expired {
+		c.removeUpload(req, upload)
+		upload.mu.Unlock()
+	}
+}
@@ -15,34 +15,61 @@
 package controllers
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
+	"google.golang.org/genai"
 
 	"google.golang.org/adk/artifact"
 	"google.golang.org/adk/server/adkrest/internal/models"
 )
 
+// DefaultArtifactUploadThreshold is the size in bytes of the largest artifact
+// uploaded in a single request, if the threshold is not set. Larger artifacts
+// are uploaded with resumable uploads.
+const DefaultArtifactUploadThreshold = 32 << 20
+
 // ArtifactsAPIController is the controller for the Artifacts API.
 type ArtifactsAPIController struct {
 	artifactService artifact.Service
+	uploadThreshold int64
+	uploadDir       string
+
+	mu      sync.Mutex
+	uploads map[string]*artifactUpload
 }
 
-func NewArtifactsAPIController(artifactService artifact.Service) *ArtifactsAPIController {
-	return &ArtifactsAPIController{artifactService: artifactService}
+// NewArtifactsAPIController creates the controller of the Artifacts API.
+// Artifacts larger than uploadThreshold bytes, DefaultArtifactUploadThreshold
+// if zero, are uploaded with resumable uploads, whose received chunks are
+// stored in temporary files in uploadDir, os.TempDir if empty.
+func NewArtifactsAPIController(artifactService artifact.Service, uploadThreshold int64, uploadDir string) *ArtifactsAPIController {
+	if uploadThreshold <= 0 {
+		uploadThreshold = DefaultArtifactUploadThreshold
+	}
+	return &ArtifactsAPIController{
+		artifactService: artifactService,
+		uploadThreshold: uploadThreshold,
+		uploadDir:       uploadDir,
+		uploads:         map[string]*artifactUpload{},
+	}
 }
 
 // ListArtifactsHandler lists all the artifact filenames within a session.
 func (c *ArtifactsAPIController) ListArtifactsHandler(rw http.ResponseWriter, req *http.Request) {
-	vars := mux.Vars(req)
-	sessionID, err := models.SessionIDFromHTTPParameters(vars)
+	sessionID, err := artifactSessionID(req)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
-		return
-	}
-	if sessionID.ID == "" {
-		http.Error(rw, "session_id parameter is required", http.StatusBadRequest)
+		writeArtifactError(rw, err)
 		return
 	}
 	resp, err := c.artifactService.List(req.Context(), &artifact.ListRequest{
@@ -51,7 +78,7 @@ func (c *ArtifactsAPIController) ListArtifactsHandler(rw http.ResponseWriter, re
 		SessionID: sessionID.ID,
 	})
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		writeArtifactError(rw, err)
 		return
 	}
 	files := resp.FileNames
@@ -61,44 +88,41 @@ func (c *ArtifactsAPIController) ListArtifactsHandler(rw http.ResponseWriter, re
 	EncodeJSONResponse(files, http.StatusOK, rw)
 }
 
-// LoadArtifactHandler gets an artifact from the artifact service storage.
-func (c *ArtifactsAPIController) LoadArtifactHandler(rw http.ResponseWriter, req *http.Request) {
-	vars := mux.Vars(req)
-	sessionID, err := models.SessionIDFromHTTPParameters(vars)
+// ListArtifactVersionsHandler lists the versions of an artifact.
+func (c *ArtifactsAPIController) ListArtifactVersionsHandler(rw http.ResponseWriter, req *http.Request) {
+	sessionID, artifactName, err := artifactParameters(req)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+		writeArtifactError(rw, err)
 		return
 	}
-	if sessionID.ID == "" {
-		http.Error(rw, "session_id parameter is required", http.StatusBadRequest)
-		return
-	}
-	artifactName := vars["artifact_name"]
-	if artifactName == "" {
-		http.Error(rw, "artifact_name parameter is required", http.StatusBadRequest)
-		return
-	}
-	loadReq := &artifact.LoadRequest{
+	resp, err := c.artifactService.Versions(req.Context(), &artifact.VersionsRequest{
 		AppName:   sessionID.AppName,
 		UserID:    sessionID.UserID,
 		SessionID: sessionID.ID,
 		FileName:  artifactName,
+	})
+	if err != nil {
+		writeArtifactError(rw, err)
+		return
 	}
-
-	queryParams := req.URL.Query()
-	version := queryParams.Get("version")
-	if version != "" {
-		versionInt, err := strconv.Atoi(version)
-		if err != nil {
-			http.Error(rw, "version parameter must be an integer", http.StatusBadRequest)
-			return
-		}
-		loadReq.Version = int64(versionInt)
+	versions := resp.Versions
+	if versions == nil {
+		versions = []int64{}
 	}
+	EncodeJSONResponse(versions, http.StatusOK, rw)
+}
 
+// LoadArtifactHandler gets an artifact from the artifact service storage.
+// The latest version is returned unless the version query parameter is set.
+func (c *ArtifactsAPIController) LoadArtifactHandler(rw http.ResponseWriter, req *http.Request) {
+	loadReq, err := artifactLoadRequest(req, req.URL.Query().Get("version"))
+	if err != nil {
+		writeArtifactError(rw, err)
+		return
+	}
 	resp, err := c.artifactService.Load(req.Context(), loadReq)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		writeArtifactError(rw, err)
 		return
 	}
 	EncodeJSONResponse(resp.Part, http.StatusOK, rw)
@@ -106,76 +130,232 @@ func (c *ArtifactsAPIController) LoadArtifactHandler(rw http.ResponseWriter, req
 
 // LoadArtifactVersionHandler gets an artifact from the artifact service storage with specified version.
 func (c *ArtifactsAPIController) LoadArtifactVersionHandler(rw http.ResponseWriter, req *http.Request) {
-	vars := mux.Vars(req)
-	sessionID, err := models.SessionIDFromHTTPParameters(vars)
-	if err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+	version := mux.Vars(req)["version"]
+	if version == "" {
+		http.Error(rw, "version parameter is required", http.StatusBadRequest)
 		return
 	}
-	if sessionID.ID == "" {
-		http.Error(rw, "session_id parameter is required", http.StatusBadRequest)
+	loadReq, err := artifactLoadRequest(req, version)
+	if err != nil {
+		writeArtifactError(rw, err)
 		return
 	}
-	artifactName := vars["artifact_name"]
-	if artifactName == "" {
-		http.Error(rw, "artifact_name parameter is required", http.StatusBadRequest)
+	resp, err := c.artifactService.Load(req.Context(), loadReq)
+	if err != nil {
+		writeArtifactError(rw, err)
 		return
 	}
-	version := vars["version"]
+	EncodeJSONResponse(resp.Part, http.StatusOK, rw)
+}
 
-	if version == "" {
-		http.Error(rw, "version parameter is required", http.StatusBadRequest)
+// DownloadArtifactHandler writes the content of an artifact, with its MIME
+// type as Content-Type. The latest version is returned unless the version
+// query parameter is set. The content is copied from the artifact service as
+// it is read, and the Range requests are answered with the requested ranges.
+func (c *ArtifactsAPIController) DownloadArtifactHandler(rw http.ResponseWriter, req *http.Request) {
+	loadReq, err := artifactLoadRequest(req, req.URL.Query().Get("version"))
+	if err != nil {
+		writeArtifactError(rw, err)
 		return
 	}
-
-	versionInt, err := strconv.Atoi(version)
+	resp, err := c.openArtifact(req.Context(), loadReq)
 	if err != nil {
-		http.Error(rw, "version parameter must be an integer", http.StatusBadRequest)
+		writeArtifactError(rw, err)
 		return
 	}
+	defer resp.Content.Close()
+	// Without Content-Type, http.ServeContent detects it from the content.
+	if resp.MIMEType != "" {
+		rw.Header().Set("Content-Type", resp.MIMEType)
+	}
+	rw.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": loadReq.FileName}))
+	http.ServeContent(rw, req, "", time.Time{}, resp.Content)
+}
 
-	loadReq := &artifact.LoadRequest{
+// UploadArtifactHandler saves the body of the request as a new version of an
+// artifact, with the Content-Type of the request as MIME type. The body is
+// copied to the artifact service as it is received. Bodies larger than the
+// upload threshold are rejected with 413 Request Entity Too Large, and must
+// be sent with a resumable upload, see CreateArtifactUploadHandler.
+func (c *ArtifactsAPIController) UploadArtifactHandler(rw http.ResponseWriter, req *http.Request) {
+	sessionID, artifactName, err := artifactParameters(req)
+	if err != nil {
+		writeArtifactError(rw, err)
+		return
+	}
+	if req.ContentLength > c.uploadThreshold {
+		http.Error(rw, fmt.Sprintf("artifact of %d bytes is larger than %d bytes, use a resumable upload", req.ContentLength, c.uploadThreshold), http.StatusRequestEntityTooLarge)
+		return
+	}
+	version, err := c.saveArtifact(req.Context(), &artifact.SaveStreamRequest{
 		AppName:   sessionID.AppName,
 		UserID:    sessionID.UserID,
 		SessionID: sessionID.ID,
 		FileName:  artifactName,
-		Version:   int64(versionInt),
-	}
-
-	resp, err := c.artifactService.Load(req.Context(), loadReq)
+		MIMEType:  uploadMIMEType(req.Header.Get("Content-Type")),
+		Content:   http.MaxBytesReader(rw, req.Body, c.uploadThreshold),
+	})
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = newStatusError(fmt.Errorf("artifact is larger than %d bytes, use a resumable upload", c.uploadThreshold), http.StatusRequestEntityTooLarge)
+		}
+		writeArtifactError(rw, err)
 		return
 	}
-	EncodeJSONResponse(resp.Part, http.StatusOK, rw)
+	EncodeJSONResponse(models.ArtifactVersion{Version: version}, http.StatusCreated, rw)
 }
 
 // DeleteArtifactHandler handles deleting an artifact.
 func (c *ArtifactsAPIController) DeleteArtifactHandler(rw http.ResponseWriter, req *http.Request) {
-	vars := mux.Vars(req)
-	sessionID, err := models.SessionIDFromHTTPParameters(vars)
+	sessionID, artifactName, err := artifactParameters(req)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+		writeArtifactError(rw, err)
 		return
 	}
-	if sessionID.ID == "" {
-		http.Error(rw, "session_id parameter is required", http.StatusBadRequest)
+	err = c.artifactService.Delete(req.Context(), &artifact.DeleteRequest{
+		AppName:   sessionID.AppName,
+		UserID:    sessionID.UserID,
+		SessionID: sessionID.ID,
+		FileName:  artifactName,
+	})
+	if err != nil {
+		writeArtifactError(rw, err)
 		return
 	}
-	artifactName := vars["artifact_name"]
+	EncodeJSONResponse(nil, http.StatusOK, rw)
+}
+
+// artifactSessionID returns the session ID of the URL, which must include the
+// ID of the session.
+func artifactSessionID(req *http.Request) (models.SessionID, error) {
+	sessionID, err := models.SessionIDFromHTTPParameters(mux.Vars(req))
+	if err != nil {
+		return sessionID, newStatusError(err, http.StatusBadRequest)
+	}
+	if sessionID.ID == "" {
+		return sessionID, newStatusError(errors.New("session_id parameter is required"), http.StatusBadRequest)
+	}
+	return sessionID, nil
+}
+
+// artifactParameters returns the session ID and the artifact name of the URL.
+func artifactParameters(req *http.Request) (models.SessionID, string, error) {
+	sessionID, err := artifactSessionID(req)
+	if err != nil {
+		return sessionID, "", err
+	}
+	artifactName := mux.Vars(req)["artifact_name"]
 	if artifactName == "" {
-		http.Error(rw, "artifact_name parameter is required", http.StatusBadRequest)
-		return
+		return sessionID, "", newStatusError(errors.New("artifact_name parameter is required"), http.StatusBadRequest)
 	}
-	err = c.artifactService.Delete(req.Context(), &artifact.DeleteRequest{
+	return sessionID, artifactName, nil
+}
+
+// artifactLoadRequest returns the request loading the artifact of the URL in
+// the given version, the latest if empty.
+func artifactLoadRequest(req *http.Request, version string) (*artifact.LoadRequest, error) {
+	sessionID, artifactName, err := artifactParameters(req)
+	if err != nil {
+		return nil, err
+	}
+	loadReq := &artifact.LoadRequest{
 		AppName:   sessionID.AppName,
 		UserID:    sessionID.UserID,
 		SessionID: sessionID.ID,
 		FileName:  artifactName,
-	})
+	}
+	if version != "" {
+		versionInt, err := strconv.ParseInt(version, 10, 64)
+		if err != nil {
+			return nil, newStatusError(errors.New("version parameter must be an integer"), http.StatusBadRequest)
+		}
+		loadReq.Version = versionInt
+	}
+	return loadReq, nil
+}
+
+// openArtifact opens the content of an artifact, loading it whole if the
+// artifact service does not implement artifact.StreamService.
+func (c *ArtifactsAPIController) openArtifact(ctx context.Context, req *artifact.LoadRequest) (*artifact.OpenResponse, error) {
+	if streamService, ok := c.artifactService.(artifact.StreamService); ok {
+		return streamService.Open(ctx, req)
+	}
+	resp, err := c.artifactService.Load(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var mimeType string
+	var data []byte
+	switch part := resp.Part; {
+	case part.InlineData != nil:
+		mimeType, data = part.InlineData.MIMEType, part.InlineData.Data
+	case part.Text != "":
+		mimeType, data = "text/plain; charset=utf-8", []byte(part.Text)
+	default:
+		return nil, newStatusError(fmt.Errorf("artifact %q has no content to download", req.FileName), http.StatusUnprocessableEntity)
+	}
+	return &artifact.OpenResponse{
+		Content:  readSeekNopCloser{bytes.NewReader(data)},
+		MIMEType: mimeType,
+		Size:     int64(len(data)),
+		Version:  req.Version,
+	}, nil
+}
+
+// saveArtifact saves the content of an artifact and returns its version,
+// reading the content whole if the artifact service does not implement
+// artifact.StreamService.
+func (c *ArtifactsAPIController) saveArtifact(ctx context.Context, req *artifact.SaveStreamRequest) (int64, error) {
+	var resp *artifact.SaveResponse
+	var err error
+	if streamService, ok := c.artifactService.(artifact.StreamService); ok {
+		resp, err = streamService.SaveStream(ctx, req)
+	} else {
+		var data []byte
+		data, err = io.ReadAll(req.Content)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read artifact content: %w", err)
+		}
+		resp, err = c.artifactService.Save(ctx, &artifact.SaveRequest{
+			AppName:   req.AppName,
+			UserID:    req.UserID,
+			SessionID: req.SessionID,
+			FileName:  req.FileName,
+			Part:      genai.NewPartFromBytes(data, req.MIMEType),
+		})
+	}
 	if err != nil {
+		return 0, err
+	}
+	return resp.Version, nil
+}
+
+// uploadMIMEType returns the MIME type of uploaded content, given as
+// Content-Type.
+func uploadMIMEType(contentType string) string {
+	if contentType == "" {
+		return "application/octet-stream"
+	}
+	return contentType
+}
+
+// readSeekNopCloser is a reader of content in memory, which needs no closing.
+type readSeekNopCloser struct {
+	io.ReadSeeker
+}
+
+func (readSeekNopCloser) Close() error { return nil }
+
+// writeArtifactError writes the error with the status code of its kind.
+func writeArtifactError(rw http.ResponseWriter, err error) {
+	var statusErr statusError
+	switch {
+	case errors.As(err, &statusErr):
+		http.Error(rw, err.Error(), statusErr.Status())
+	case errors.Is(err, fs.ErrNotExist):
+		http.Error(rw, err.Error(), http.StatusNotFound)
+	default:
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
-		return
 	}
-	EncodeJSONResponse(nil, http.StatusOK, rw)
 }
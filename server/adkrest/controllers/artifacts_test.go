@@ -0,0 +1,312 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/artifact"
+	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
+)
+
+func TestArtifactsAPIController(t *testing.T) {
+	ctx := t.Context()
+	service := artifact.InMemoryService()
+	for _, part := range []*genai.Part{
+		genai.NewPartFromBytes([]byte("first"), "image/png"),
+		genai.NewPartFromBytes([]byte("second"), "image/png"),
+	} {
+		if _, err := service.Save(ctx, &artifact.SaveRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession", FileName: "chart.png", Part: part}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := service.Save(ctx, &artifact.SaveRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession", FileName: "notes.txt", Part: genai.NewPartFromText("hello")}); err != nil {
+		t.Fatal(err)
+	}
+	apiController := controllers.NewArtifactsAPIController(service, 0, "")
+
+	serve := func(handler http.HandlerFunc, artifactName, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/artifacts"+query, nil)
+		req = mux.SetURLVars(req, map[string]string{"app_name": "testApp", "user_id": "testUser", "session_id": "testSession", "artifact_name": artifactName})
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		return rr
+	}
+
+	t.Run("list", func(t *testing.T) {
+		rr := serve(apiController.ListArtifactsHandler, "", "")
+		var got []string
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"chart.png", "notes.txt"}, got); diff != "" {
+			t.Errorf("ListArtifacts() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("versions", func(t *testing.T) {
+		rr := serve(apiController.ListArtifactVersionsHandler, "chart.png", "")
+		var got []int64
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Errorf("ListArtifactVersions() = %v, want 2 versions", got)
+		}
+		if rr := serve(apiController.ListArtifactVersionsHandler, "unknown.png", ""); rr.Code != http.StatusNotFound {
+			t.Errorf("ListArtifactVersions() of an unknown artifact status = %v, want %v", rr.Code, http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name            string
+		artifactName    string
+		query           string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "latest version", artifactName: "chart.png", wantStatus: http.StatusOK, wantContentType: "image/png", wantBody: "second"},
+		{name: "given version", artifactName: "chart.png", query: "?version=1", wantStatus: http.StatusOK, wantContentType: "image/png", wantBody: "first"},
+		{name: "text", artifactName: "notes.txt", wantStatus: http.StatusOK, wantContentType: "text/plain; charset=utf-8", wantBody: "hello"},
+		{name: "unknown artifact", artifactName: "unknown.png", wantStatus: http.StatusNotFound},
+		{name: "invalid version", artifactName: "chart.png", query: "?version=latest", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run("download "+tt.name, func(t *testing.T) {
+			rr := serve(apiController.DownloadArtifactHandler, tt.artifactName, tt.query)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("DownloadArtifact() status = %v, want %v: %s", rr.Code, tt.wantStatus, rr.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rr.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if got := rr.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/artifacts", nil)
+		req = mux.SetURLVars(req, map[string]string{"app_name": "testApp", "user_id": "testUser", "session_id": "testSession", "artifact_name": "notes.txt"})
+		rr := httptest.NewRecorder()
+		apiController.DeleteArtifactHandler(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("DeleteArtifact() status = %v, want %v", rr.Code, http.StatusOK)
+		}
+		if rr := serve(apiController.LoadArtifactHandler, "notes.txt", ""); rr.Code != http.StatusNotFound {
+			t.Errorf("LoadArtifact() of a deleted artifact status = %v, want %v", rr.Code, http.StatusNotFound)
+		}
+	})
+}
+
+// loadOnlyService hides the artifact.StreamService of an artifact service.
+type loadOnlyService struct {
+	artifact.Service
+}
+
+func TestArtifactsAPIController_DownloadRange(t *testing.T) {
+	ctx := t.Context()
+	for name, service := range map[string]artifact.Service{
+		"stream service": artifact.InMemoryService(),
+		"load service":   loadOnlyService{artifact.InMemoryService()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := service.Save(ctx, &artifact.SaveRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession", FileName: "video.mp4", Part: genai.NewPartFromBytes([]byte("0123456789"), "video/mp4")}); err != nil {
+				t.Fatal(err)
+			}
+			apiController := controllers.NewArtifactsAPIController(service, 0, "")
+			req := httptest.NewRequest(http.MethodGet, "/content", nil)
+			req.Header.Set("Range", "bytes=2-5")
+			req = mux.SetURLVars(req, map[string]string{"app_name": "testApp", "user_id": "testUser", "session_id": "testSession", "artifact_name": "video.mp4"})
+			rr := httptest.NewRecorder()
+			apiController.DownloadArtifactHandler(rr, req)
+			if rr.Code != http.StatusPartialContent {
+				t.Fatalf("DownloadArtifact() status = %v, want %v: %s", rr.Code, http.StatusPartialContent, rr.Body)
+			}
+			if got := rr.Body.String(); got != "2345" {
+				t.Errorf("body = %q, want %q", got, "2345")
+			}
+			for header, want := range map[string]string{"Content-Range": "bytes 2-5/10", "Content-Type": "video/mp4", "Accept-Ranges": "bytes"} {
+				if got := rr.Header().Get(header); got != want {
+					t.Errorf("%s = %q, want %q", header, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestArtifactsAPIController_Upload(t *testing.T) {
+	ctx := t.Context()
+	service := artifact.InMemoryService()
+	uploadDir := t.TempDir()
+	apiController := controllers.NewArtifactsAPIController(service, 8, uploadDir)
+	vars := map[string]string{"app_name": "testApp", "user_id": "testUser", "session_id": "testSession", "artifact_name": "video.mp4"}
+
+	serve := func(handler http.HandlerFunc, method string, body io.Reader, vars map[string]string, header ...string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, "/artifacts", body)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		req = mux.SetURLVars(req, vars)
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		return rr
+	}
+	load := func(t *testing.T) *genai.Part {
+		t.Helper()
+		resp, err := service.Load(ctx, &artifact.LoadRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession", FileName: "video.mp4"})
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		return resp.Part
+	}
+
+	t.Run("single request", func(t *testing.T) {
+		rr := serve(apiController.UploadArtifactHandler, http.MethodPost, strings.NewReader("small"), vars, "Content-Type", "video/mp4")
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("UploadArtifact() status = %v, want %v: %s", rr.Code, http.StatusCreated, rr.Body)
+		}
+		if got := strings.TrimSpace(rr.Body.String()); got != `{"version":1}` {
+			t.Errorf("UploadArtifact() = %s, want version 1", got)
+		}
+		if diff := cmp.Diff(genai.NewPartFromBytes([]byte("small"), "video/mp4"), load(t)); diff != "" {
+			t.Errorf("uploaded artifact mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("single request over the threshold", func(t *testing.T) {
+		if rr := serve(apiController.UploadArtifactHandler, http.MethodPost, strings.NewReader("larger than 8 bytes"), vars); rr.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("UploadArtifact() status = %v, want %v", rr.Code, http.StatusRequestEntityTooLarge)
+		}
+		// Without Content-Length, the body is cut at the threshold.
+		body := io.MultiReader(strings.NewReader("larger than "), strings.NewReader("8 bytes"))
+		if rr := serve(apiController.UploadArtifactHandler, http.MethodPost, body, vars); rr.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("UploadArtifact() without Content-Length status = %v, want %v", rr.Code, http.StatusRequestEntityTooLarge)
+		}
+	})
+
+	t.Run("resumable", func(t *testing.T) {
+		rr := serve(apiController.CreateArtifactUploadHandler, http.MethodPost, strings.NewReader(`{"size": 10, "mimeType": "video/mp4"}`), vars)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("CreateArtifactUpload() status = %v, want %v: %s", rr.Code, http.StatusCreated, rr.Body)
+		}
+		var upload models.ArtifactUpload
+		if err := json.NewDecoder(rr.Body).Decode(&upload); err != nil {
+			t.Fatal(err)
+		}
+		uploadVars := map[string]string{"upload_id": upload.UploadID}
+		for k, v := range vars {
+			uploadVars[k] = v
+		}
+		chunk := func(t *testing.T, start int, data string) *httptest.ResponseRecorder {
+			t.Helper()
+			contentRange := fmt.Sprintf("bytes %d-%d/10", start, start+len(data)-1)
+			return serve(apiController.ArtifactUploadChunkHandler, http.MethodPut, strings.NewReader(data), uploadVars, "Content-Range", contentRange)
+		}
+
+		if rr := chunk(t, 0, "0123"); rr.Code != http.StatusOK {
+			t.Fatalf("ArtifactUploadChunk() status = %v, want %v: %s", rr.Code, http.StatusOK, rr.Body)
+		}
+		if rr := chunk(t, 6, "6789"); rr.Code != http.StatusConflict {
+			t.Errorf("ArtifactUploadChunk() past the offset status = %v, want %v", rr.Code, http.StatusConflict)
+		}
+		// An interrupted chunk does not advance the offset.
+		req := httptest.NewRequest(http.MethodPut, "/artifacts", strings.NewReader("45"))
+		req.Header.Set("Content-Range", "bytes 4-7/10")
+		req = mux.SetURLVars(req, uploadVars)
+		rr = httptest.NewRecorder()
+		apiController.ArtifactUploadChunkHandler(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("ArtifactUploadChunk() of a short chunk status = %v, want %v", rr.Code, http.StatusBadRequest)
+		}
+		rr = serve(apiController.GetArtifactUploadHandler, http.MethodGet, nil, uploadVars)
+		if err := json.NewDecoder(rr.Body).Decode(&upload); err != nil {
+			t.Fatal(err)
+		}
+		if upload.Offset != 4 {
+			t.Fatalf("GetArtifactUpload() offset = %d, want 4", upload.Offset)
+		}
+
+		rr = chunk(t, 4, "456789")
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("ArtifactUploadChunk() of the last chunk status = %v, want %v: %s", rr.Code, http.StatusCreated, rr.Body)
+		}
+		if err := json.NewDecoder(rr.Body).Decode(&upload); err != nil {
+			t.Fatal(err)
+		}
+		if upload.Version != 2 || upload.Offset != 10 {
+			t.Errorf("ArtifactUploadChunk() = %+v, want version 2 at offset 10", upload)
+		}
+		if diff := cmp.Diff(genai.NewPartFromBytes([]byte("0123456789"), "video/mp4"), load(t)); diff != "" {
+			t.Errorf("uploaded artifact mismatch (-want +got):\n%s", diff)
+		}
+		if rr := serve(apiController.GetArtifactUploadHandler, http.MethodGet, nil, uploadVars); rr.Code != http.StatusNotFound {
+			t.Errorf("GetArtifactUpload() of a complete upload status = %v, want %v", rr.Code, http.StatusNotFound)
+		}
+		if files, _ := os.ReadDir(uploadDir); len(files) != 0 {
+			t.Errorf("upload directory has %d files, want the upload file removed", len(files))
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		rr := serve(apiController.CreateArtifactUploadHandler, http.MethodPost, bytes.NewBufferString(`{"size": 10}`), vars)
+		var upload models.ArtifactUpload
+		if err := json.NewDecoder(rr.Body).Decode(&upload); err != nil {
+			t.Fatal(err)
+		}
+		uploadVars := map[string]string{"upload_id": upload.UploadID}
+		for k, v := range vars {
+			uploadVars[k] = v
+		}
+		otherArtifact := map[string]string{"artifact_name": "other.mp4"}
+		for k, v := range uploadVars {
+			if k != "artifact_name" {
+				otherArtifact[k] = v
+			}
+		}
+		if rr := serve(apiController.CancelArtifactUploadHandler, http.MethodDelete, nil, otherArtifact); rr.Code != http.StatusNotFound {
+			t.Errorf("CancelArtifactUpload() of another artifact status = %v, want %v", rr.Code, http.StatusNotFound)
+		}
+		if rr := serve(apiController.CancelArtifactUploadHandler, http.MethodDelete, nil, uploadVars); rr.Code != http.StatusOK {
+			t.Fatalf("CancelArtifactUpload() status = %v, want %v", rr.Code, http.StatusOK)
+		}
+		if rr := serve(apiController.GetArtifactUploadHandler, http.MethodGet, nil, uploadVars); rr.Code != http.StatusNotFound {
+			t.Errorf("GetArtifactUpload() of a cancelled upload status = %v, want %v", rr.Code, http.StatusNotFound)
+		}
+		if files, _ := os.ReadDir(uploadDir); len(files) != 0 {
+			t.Errorf("upload directory has %d files, want the upload file removed", len(files))
+		}
+	})
+}
@@ -18,12 +18,14 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/gorilla/mux"
 	semconv "go.opentelemetry.io/otel/semconv/v1.36.0"
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/runner"
 	"google.golang.org/adk/server/adkrest/internal/models"
 	"google.golang.org/adk/server/adkrest/internal/services"
 	"google.golang.org/adk/session"
@@ -69,6 +71,23 @@ func (c *DebugAPIController) EventSpanHandler(rw http.ResponseWriter, req *http.
 	http.Error(rw, fmt.Sprintf("event not found: %s", eventID), http.StatusNotFound)
 }
 
+// EventModelCallHandler returns the model call an event was built from: the
+// request as sent to the model and its raw responses. Only the last model
+// calls of the server are retained.
+func (c *DebugAPIController) EventModelCallHandler(rw http.ResponseWriter, req *http.Request) {
+	eventID := mux.Vars(req)["event_id"]
+	if eventID == "" {
+		http.Error(rw, "event_id parameter is required", http.StatusBadRequest)
+		return
+	}
+	call, ok := c.debugTelemetry.GetModelCallByEventID(eventID)
+	if !ok {
+		http.Error(rw, fmt.Sprintf("no model call retained for event %s", eventID), http.StatusNotFound)
+		return
+	}
+	EncodeJSONResponse(call, http.StatusOK, rw)
+}
+
 // ADK web expects different format than in [SessionSpansHandler].
 // The main difference is that span attributes need to be flattened in the response.
 func convertEventSpan(span services.DebugSpan) map[string]any {
@@ -87,6 +106,32 @@ func convertEventSpan(span services.DebugSpan) map[string]any {
 	return flattened
 }
 
+// ListInvocationsHandler returns the invocations running in the server, by
+// start time, e.g. to find stuck agents.
+func (c *DebugAPIController) ListInvocationsHandler(rw http.ResponseWriter, req *http.Request) {
+	now := time.Now()
+	invocations := []models.Invocation{}
+	for _, info := range runner.RunningInvocations() {
+		invocations = append(invocations, models.FromInvocationInfo(info, now))
+	}
+	EncodeJSONResponse(invocations, http.StatusOK, rw)
+}
+
+// CancelInvocationHandler cancels a running invocation. The runner appends
+// the cancellation event to the session of the invocation.
+func (c *DebugAPIController) CancelInvocationHandler(rw http.ResponseWriter, req *http.Request) {
+	invocationID := mux.Vars(req)["invocation_id"]
+	if invocationID == "" {
+		http.Error(rw, "invocation_id parameter is required", http.StatusBadRequest)
+		return
+	}
+	if !runner.CancelRunningInvocation(invocationID, "cancelled from the debug API") {
+		http.Error(rw, fmt.Sprintf("no running invocation %s", invocationID), http.StatusNotFound)
+		return
+	}
+	rw.WriteHeader(http.StatusAccepted)
+}
+
 // SessionSpansHandler returns the debug spans for the session.
 func (c *DebugAPIController) SessionSpansHandler(rw http.ResponseWriter, req *http.Request) {
 	params := mux.Vars(req)
@@ -99,6 +144,81 @@ func (c *DebugAPIController) SessionSpansHandler(rw http.ResponseWriter, req *ht
 	EncodeJSONResponse(spans, http.StatusOK, rw)
 }
 
+// InvocationTraceHandler returns the trace tree of an invocation: the model
+// calls, tool calls and their timings. The tree is built from the recorded
+// spans, or reconstructed from the event log of the session if there are
+// none, e.g. after a restart of the server.
+func (c *DebugAPIController) InvocationTraceHandler(rw http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	invocationID := vars["invocation_id"]
+	if invocationID == "" {
+		http.Error(rw, "invocation_id parameter is required", http.StatusBadRequest)
+		return
+	}
+	events, ok := c.sessionEvents(rw, req)
+	if !ok {
+		return
+	}
+	trace := c.invocationTrace(invocationID, events)
+	if trace == nil {
+		http.Error(rw, fmt.Sprintf("invocation not found: %s", invocationID), http.StatusNotFound)
+		return
+	}
+	EncodeJSONResponse(trace, http.StatusOK, rw)
+}
+
+// SessionTracesHandler returns the trace trees of the invocations of the
+// session, in the order of the invocations.
+func (c *DebugAPIController) SessionTracesHandler(rw http.ResponseWriter, req *http.Request) {
+	events, ok := c.sessionEvents(rw, req)
+	if !ok {
+		return
+	}
+	traces := []*services.InvocationTrace{}
+	seen := make(map[string]bool)
+	for event := range events.All() {
+		if event.InvocationID == "" || seen[event.InvocationID] {
+			continue
+		}
+		seen[event.InvocationID] = true
+		traces = append(traces, c.invocationTrace(event.InvocationID, events))
+	}
+	EncodeJSONResponse(traces, http.StatusOK, rw)
+}
+
+func (c *DebugAPIController) invocationTrace(invocationID string, events session.Events) *services.InvocationTrace {
+	if c.debugTelemetry != nil {
+		if trace := services.InvocationTraceFromSpans(invocationID, c.debugTelemetry.GetSpansByInvocationID(invocationID)); trace != nil {
+			return trace
+		}
+	}
+	return services.InvocationTraceFromEvents(invocationID, events)
+}
+
+// sessionEvents returns the events of the session of the request. It writes
+// the error response and returns false if the session cannot be read.
+func (c *DebugAPIController) sessionEvents(rw http.ResponseWriter, req *http.Request) (session.Events, bool) {
+	sessionID, err := models.SessionIDFromHTTPParameters(mux.Vars(req))
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	if sessionID.ID == "" {
+		http.Error(rw, "session_id parameter is required", http.StatusBadRequest)
+		return nil, false
+	}
+	resp, err := c.sessionService.Get(req.Context(), &session.GetRequest{
+		AppName:   sessionID.AppName,
+		UserID:    sessionID.UserID,
+		SessionID: sessionID.ID,
+	})
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusNotFound)
+		return nil, false
+	}
+	return resp.Session.Events(), true
+}
+
 // EventGraphHandler returns the debug information for the session and session events in form of graph.
 func (c *DebugAPIController) EventGraphHandler(rw http.ResponseWriter, req *http.Request) {
 	vars := mux.Vars(req)
@@ -17,8 +17,11 @@ package controllers_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"iter"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,9 +34,18 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.36.0"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genai"
 
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/runner"
 	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
 	"google.golang.org/adk/server/adkrest/internal/services"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
 )
 
 func TestSessionSpansHandler(t *testing.T) {
@@ -305,3 +317,288 @@ func emitTestSignals(sessionID, eventID, opName string, tp *sdktrace.TracerProvi
 	_ = tp.ForceFlush(context.Background())
 	_ = lp.ForceFlush(context.Background())
 }
+
+func TestInvocationTraceHandler(t *testing.T) {
+	ctx := t.Context()
+	type args struct {
+		City string `json:"city"`
+	}
+	weatherTool, err := functiontool.New(functiontool.Config{Name: "weather", Description: "returns the weather"}, func(ctx tool.Context, _ args) (map[string]any, error) {
+		return map[string]any{"weather": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootAgent, err := llmagent.New(llmagent.Config{
+		Name: "testApp",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "weather", Args: map[string]any{"city": "Paris"}}}}},
+			genai.NewContentFromText("It is sunny.", genai.RoleModel),
+		}},
+		Tools: []tool.Tool{weatherTool},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	debugTelemetry := services.NewDebugTelemetry()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(debugTelemetry.SpanProcessor()))
+	sessionService := session.InMemoryService()
+	r, err := runner.New(runner.Config{
+		AppName:           "testApp",
+		Agent:             rootAgent,
+		SessionService:    sessionService,
+		AutoCreateSession: true,
+		TracerProvider:    tp,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invocationID string
+	for event, err := range r.Run(ctx, "testUser", "testSession", genai.NewContentFromText("weather in Paris?", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		invocationID = event.InvocationID
+	}
+	if err := tp.ForceFlush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		debugTelemetry *services.DebugTelemetry
+		wantSource     string
+		// wantTree lists the span names of the tree, depth first, indented
+		// by depth.
+		wantTree []string
+	}{
+		{
+			name:           "from telemetry",
+			debugTelemetry: debugTelemetry,
+			wantSource:     services.TraceSourceTelemetry,
+			wantTree: []string{
+				"invocation",
+				" invoke_agent testApp",
+				"  generate_content mock",
+				"  execute_tool weather",
+				"  generate_content mock",
+			},
+		},
+		{
+			name:           "from events",
+			debugTelemetry: services.NewDebugTelemetry(),
+			wantSource:     services.TraceSourceEvents,
+			wantTree: []string{
+				"invocation",
+				" generate_content testApp",
+				"  execute_tool weather",
+				" generate_content testApp",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := controllers.NewDebugAPIController(sessionService, nil, tt.debugTelemetry)
+			vars := map[string]string{
+				"app_name":   "testApp",
+				"user_id":    "testUser",
+				"session_id": "testSession",
+			}
+
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/traces", nil), vars)
+			rr := httptest.NewRecorder()
+			controller.SessionTracesHandler(rr, req)
+			if rr.Code != http.StatusOK {
+				t.Fatalf("SessionTracesHandler() status = %d, body = %s", rr.Code, rr.Body)
+			}
+			var traces []services.InvocationTrace
+			if err := json.NewDecoder(rr.Body).Decode(&traces); err != nil {
+				t.Fatal(err)
+			}
+			if len(traces) != 1 || traces[0].InvocationID != invocationID {
+				t.Fatalf("SessionTracesHandler() = %+v, want the trace of invocation %q", traces, invocationID)
+			}
+
+			vars["invocation_id"] = invocationID
+			req = mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/trace", nil), vars)
+			rr = httptest.NewRecorder()
+			controller.InvocationTraceHandler(rr, req)
+			if rr.Code != http.StatusOK {
+				t.Fatalf("InvocationTraceHandler() status = %d, body = %s", rr.Code, rr.Body)
+			}
+			var got services.InvocationTrace
+			if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Source != tt.wantSource {
+				t.Errorf("trace source = %q, want %q", got.Source, tt.wantSource)
+			}
+			var tree []string
+			var walk func(nodes []*services.TraceNode, depth int)
+			walk = func(nodes []*services.TraceNode, depth int) {
+				for _, node := range nodes {
+					tree = append(tree, fmt.Sprintf("%*s%s", depth, "", node.Name))
+					walk(node.Children, depth+1)
+				}
+			}
+			walk(got.Roots, 0)
+			if diff := cmp.Diff(tt.wantTree, tree); diff != "" {
+				t.Errorf("trace tree mismatch (-want +got):\n%s", diff)
+			}
+
+			vars["invocation_id"] = "unknown"
+			req = mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/trace", nil), vars)
+			rr = httptest.NewRecorder()
+			controller.InvocationTraceHandler(rr, req)
+			if rr.Code != http.StatusNotFound {
+				t.Errorf("InvocationTraceHandler() for an unknown invocation status = %d, want %d", rr.Code, http.StatusNotFound)
+			}
+		})
+	}
+}
+
+func TestEventModelCallHandler(t *testing.T) {
+	debugTelemetry := services.NewDebugTelemetry()
+	ctx := debugTelemetry.RecordModelCalls(t.Context())
+	rootAgent, err := llmagent.New(llmagent.Config{
+		Name:        "testApp",
+		Instruction: "Answer in French.",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromText("Il fait beau.", genai.RoleModel),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := runner.New(runner.Config{
+		AppName:           "testApp",
+		Agent:             rootAgent,
+		SessionService:    session.InMemoryService(),
+		AutoCreateSession: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var eventID string
+	for event, err := range r.Run(ctx, "testUser", "testSession", genai.NewContentFromText("weather?", genai.RoleUser), agent.RunConfig{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		eventID = event.ID
+	}
+
+	controller := controllers.NewDebugAPIController(nil, nil, debugTelemetry)
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/debug/model_call/"+eventID, nil), map[string]string{"event_id": eventID})
+	rr := httptest.NewRecorder()
+	controller.EventModelCallHandler(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("EventModelCallHandler() status = %d, body = %s", rr.Code, rr.Body)
+	}
+	var got struct {
+		Agent   string `json:"agent"`
+		Request struct {
+			Contents []*genai.Content
+			Config   *genai.GenerateContentConfig
+		} `json:"request"`
+		Responses []struct {
+			EventID  string `json:"event_id"`
+			Response struct {
+				Content *genai.Content
+			} `json:"response"`
+		} `json:"responses"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Agent != "testApp" {
+		t.Errorf("agent = %q, want %q", got.Agent, "testApp")
+	}
+	if len(got.Request.Contents) != 1 || got.Request.Contents[0].Parts[0].Text != "weather?" {
+		t.Errorf("request contents = %+v, want the user message", got.Request.Contents)
+	}
+	if got.Request.Config == nil || got.Request.Config.SystemInstruction == nil || !strings.Contains(got.Request.Config.SystemInstruction.Parts[0].Text, "Answer in French.") {
+		t.Errorf("request config = %+v, want the system instruction", got.Request.Config)
+	}
+	if len(got.Responses) != 1 || got.Responses[0].EventID != eventID || got.Responses[0].Response.Content.Parts[0].Text != "Il fait beau." {
+		t.Errorf("responses = %+v, want the raw model response of event %q", got.Responses, eventID)
+	}
+
+	req = mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/debug/model_call/unknown", nil), map[string]string{"event_id": "unknown"})
+	rr = httptest.NewRecorder()
+	controller.EventModelCallHandler(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("EventModelCallHandler() for an unknown event status = %d, want %d", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestInvocationsHandlers(t *testing.T) {
+	// The agent runs until the invocation is cancelled.
+	blockingAgent, err := agent.New(agent.Config{
+		Name: "blocking",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				<-ctx.Done()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := runner.New(runner.Config{AppName: "testApp", Agent: blockingAgent, SessionService: session.InMemoryService(), AutoCreateSession: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan []*session.Event)
+	go func() {
+		var events []*session.Event
+		for event, err := range r.Run(t.Context(), "testUser", "debugSession", genai.NewContentFromText("hi", genai.RoleUser), agent.RunConfig{}) {
+			if err == nil {
+				events = append(events, event)
+			}
+		}
+		done <- events
+	}()
+
+	controller := controllers.NewDebugAPIController(nil, nil, nil)
+	var invocation models.Invocation
+	deadline := time.Now().Add(5 * time.Second)
+	for invocation.InvocationID == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("the invocation is not listed")
+		}
+		rr := httptest.NewRecorder()
+		controller.ListInvocationsHandler(rr, httptest.NewRequest(http.MethodGet, "/debug/invocations", nil))
+		var got []models.Invocation
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		for _, inv := range got {
+			if inv.SessionID == "debugSession" {
+				invocation = inv
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if invocation.AppName != "testApp" || invocation.UserID != "testUser" || invocation.AgentPath != "blocking" || invocation.ElapsedSeconds < 0 {
+		t.Errorf("invocation = %+v, want the running invocation of the blocking agent", invocation)
+	}
+
+	cancel := func(invocationID string) int {
+		req := httptest.NewRequest(http.MethodPost, "/debug/invocations/"+invocationID+"/cancel", nil)
+		req = mux.SetURLVars(req, map[string]string{"invocation_id": invocationID})
+		rr := httptest.NewRecorder()
+		controller.CancelInvocationHandler(rr, req)
+		return rr.Code
+	}
+	if got := cancel("unknown"); got != http.StatusNotFound {
+		t.Errorf("cancel of an unknown invocation returned status %d, want %d", got, http.StatusNotFound)
+	}
+	if got := cancel(invocation.InvocationID); got != http.StatusAccepted {
+		t.Fatalf("cancel returned status %d, want %d", got, http.StatusAccepted)
+	}
+	events := <-done
+	if len(events) == 0 || events[len(events)-1].ErrorCode != runner.ErrorCodeCancelled {
+		t.Errorf("events = %+v, want the cancellation event last", events)
+	}
+}
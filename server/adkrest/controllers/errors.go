@@ -14,15 +14,42 @@
 
 package controllers
 
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/adk/admission"
+)
+
 type statusError struct {
 	Err  error
 	Code int
+	// Header is added to the headers of the error response.
+	Header http.Header
 }
 
 func newStatusError(err error, code int) statusError {
 	return statusError{Err: err, Code: code}
 }
 
+// newRunError returns the status error of a failed run: 429 with a
+// Retry-After header if the run was not admitted, 500 otherwise.
+func newRunError(err error) statusError {
+	var rejected *admission.RejectedError
+	if !errors.As(err, &rejected) {
+		return newStatusError(fmt.Errorf("failed to run agent: %w", err), http.StatusInternalServerError)
+	}
+	seconds := int(math.Ceil(rejected.RetryAfter.Seconds()))
+	return statusError{
+		Err:    err,
+		Code:   http.StatusTooManyRequests,
+		Header: http.Header{"Retry-After": []string{strconv.Itoa(seconds)}},
+	}
+}
+
 // Error returns an associated error
 func (se statusError) Error() string {
 	return se.Err.Error()
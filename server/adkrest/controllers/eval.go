@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"google.golang.org/adk/eval"
+	"google.golang.org/adk/server/adkrest/internal/models"
+	"google.golang.org/adk/session"
+)
+
+// EvalAPIController is the controller for the Eval API.
+type EvalAPIController struct {
+	sessionService session.Service
+}
+
+// NewEvalAPIController creates the controller for the Eval API.
+func NewEvalAPIController(sessionService session.Service) *EvalAPIController {
+	return &EvalAPIController{sessionService: sessionService}
+}
+
+// RecordEvalCaseHandler converts the session into an eval case, with the
+// tool calls and final responses of the agent as the expected behavior. The
+// eval ID is given by the eval_id query parameter and defaults to the
+// session ID.
+func (c *EvalAPIController) RecordEvalCaseHandler(rw http.ResponseWriter, req *http.Request) {
+	sessionID, err := models.SessionIDFromHTTPParameters(mux.Vars(req))
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if sessionID.ID == "" {
+		http.Error(rw, "session_id parameter is required", http.StatusBadRequest)
+		return
+	}
+	resp, err := c.sessionService.Get(req.Context(), &session.GetRequest{
+		AppName:   sessionID.AppName,
+		UserID:    sessionID.UserID,
+		SessionID: sessionID.ID,
+	})
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusNotFound)
+		return
+	}
+	evalID := req.URL.Query().Get("eval_id")
+	if evalID == "" {
+		evalID = sessionID.ID
+	}
+	evalCase, err := eval.CaseFromSession(evalID, resp.Session)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	EncodeJSONResponse(evalCase, http.StatusOK, rw)
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/eval"
+	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/session"
+)
+
+func TestRecordEvalCaseHandler(t *testing.T) {
+	ctx := t.Context()
+	sessionService := session.InMemoryService()
+	created, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	userEvent := session.NewEvent("inv-1")
+	userEvent.Author = "user"
+	userEvent.Content = genai.NewContentFromText("weather in Paris?", genai.RoleUser)
+	answer := session.NewEvent("inv-1")
+	answer.Author = "testApp"
+	answer.Content = genai.NewContentFromText("It is sunny.", genai.RoleModel)
+	for _, event := range []*session.Event{userEvent, answer} {
+		if err := sessionService.AppendEvent(ctx, created.Session, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	controller := controllers.NewEvalAPIController(sessionService)
+
+	tests := []struct {
+		name       string
+		sessionID  string
+		query      string
+		wantStatus int
+		wantEvalID string
+	}{
+		{name: "default eval ID", sessionID: "testSession", wantStatus: http.StatusOK, wantEvalID: "testSession"},
+		{name: "eval ID", sessionID: "testSession", query: "?eval_id=paris", wantStatus: http.StatusOK, wantEvalID: "paris"},
+		{name: "unknown session", sessionID: "unknown", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/eval_case"+tt.query, nil), map[string]string{
+				"app_name":   "testApp",
+				"user_id":    "testUser",
+				"session_id": tt.sessionID,
+			})
+			rr := httptest.NewRecorder()
+			controller.RecordEvalCaseHandler(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			set, err := eval.ParseEvalSet([]byte(`{"evalSetId": "s", "evalCases": [` + rr.Body.String() + `]}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := set.EvalCases[0]
+			if got.EvalID != tt.wantEvalID || len(got.Conversation) != 1 || got.Conversation[0].FinalResponse.Parts[0].Text != "It is sunny." {
+				t.Errorf("eval case = %s, want the conversation of the session", rr.Body)
+			}
+		})
+	}
+}
@@ -45,6 +45,9 @@ func NewErrorHandler(fn errorHandler) http.HandlerFunc {
 		err := fn(w, r)
 		if err != nil {
 			if statusErr, ok := err.(statusError); ok {
+				for name, values := range statusErr.Header {
+					w.Header()[name] = values
+				}
 				http.Error(w, statusErr.Error(), statusErr.Status())
 			} else {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/server/adkrest/internal/models"
+)
+
+// job is an agent run started with RunAsyncHandler.
+type job struct {
+	*runner.Job
+	sessionID models.SessionID
+}
+
+// RunAsyncHandler starts an agent run in the background and returns the job
+// that can be polled with GetJobHandler and cancelled with CancelJobHandler.
+func (c *RuntimeAPIController) RunAsyncHandler(rw http.ResponseWriter, req *http.Request) error {
+	runAgentRequest, err := decodeRequestBody(req)
+	if err != nil {
+		return err
+	}
+	err = c.validateSessionExists(req.Context(), runAgentRequest.AppName, runAgentRequest.UserId, runAgentRequest.SessionId)
+	if err != nil {
+		return err
+	}
+	r, rCfg, err := c.getRunner(runAgentRequest)
+	if err != nil {
+		return err
+	}
+
+	opts := []runner.RunOption{}
+	if runAgentRequest.StateDelta != nil {
+		opts = append(opts, runner.WithStateDelta(*runAgentRequest.StateDelta))
+	}
+	j := &job{
+		Job: r.RunAsync(req.Context(), runAgentRequest.UserId, runAgentRequest.SessionId, &runAgentRequest.NewMessage, *rCfg, opts...),
+		sessionID: models.SessionID{
+			ID:      runAgentRequest.SessionId,
+			AppName: runAgentRequest.AppName,
+			UserID:  runAgentRequest.UserId,
+		},
+	}
+	c.jobsMu.Lock()
+	c.jobs[j.ID()] = j
+	c.jobsMu.Unlock()
+	go func() {
+		<-j.Done()
+		c.jobsMu.Lock()
+		delete(c.jobs, j.ID())
+		c.jobsMu.Unlock()
+	}()
+
+	EncodeJSONResponse(toJobModel(j.Info()), http.StatusAccepted, rw)
+	return nil
+}
+
+// GetJobHandler returns the status of a job.
+func (c *RuntimeAPIController) GetJobHandler(rw http.ResponseWriter, req *http.Request) error {
+	sessionID, jobID, err := jobFromHTTPParameters(req)
+	if err != nil {
+		return err
+	}
+	// The status is read from the session, which is updated once the job
+	// has stored the user message.
+	info, err := runner.GetJobInfo(req.Context(), c.sessionService, sessionID.AppName, sessionID.UserID, sessionID.ID, jobID)
+	if errors.Is(err, runner.ErrJobNotFound) {
+		if j := c.runningJob(sessionID, jobID); j != nil {
+			EncodeJSONResponse(toJobModel(j.Info()), http.StatusOK, rw)
+			return nil
+		}
+		return newStatusError(err, http.StatusNotFound)
+	}
+	if err != nil {
+		return newStatusError(fmt.Errorf("failed to get job: %w", err), http.StatusInternalServerError)
+	}
+	EncodeJSONResponse(toJobModel(*info), http.StatusOK, rw)
+	return nil
+}
+
+// CancelJobHandler cancels a running job. Only jobs started by this server
+// can be cancelled: the jobs running on other instances sharing the session
+// service are answered with 409 Conflict.
+func (c *RuntimeAPIController) CancelJobHandler(rw http.ResponseWriter, req *http.Request) error {
+	sessionID, jobID, err := jobFromHTTPParameters(req)
+	if err != nil {
+		return err
+	}
+	j := c.runningJob(sessionID, jobID)
+	if j == nil {
+		info, err := runner.GetJobInfo(req.Context(), c.sessionService, sessionID.AppName, sessionID.UserID, sessionID.ID, jobID)
+		if err == nil && info.Status == runner.JobRunning && info.InstanceID != "" && info.InstanceID != c.instanceID {
+			return newStatusError(fmt.Errorf("job %q runs on instance %q", jobID, info.InstanceID), http.StatusConflict)
+		}
+		return newStatusError(fmt.Errorf("%w: no running job %q", runner.ErrJobNotFound, jobID), http.StatusNotFound)
+	}
+	j.Cancel("cancelled by the client")
+	EncodeJSONResponse(toJobModel(j.Info()), http.StatusAccepted, rw)
+	return nil
+}
+
+func (c *RuntimeAPIController) runningJob(sessionID models.SessionID, jobID string) *job {
+	c.jobsMu.Lock()
+	defer c.jobsMu.Unlock()
+	j, ok := c.jobs[jobID]
+	if !ok || j.sessionID != sessionID {
+		return nil
+	}
+	return j
+}
+
+func jobFromHTTPParameters(req *http.Request) (models.SessionID, string, error) {
+	params := mux.Vars(req)
+	sessionID, err := models.SessionIDFromHTTPParameters(params)
+	if err != nil {
+		return sessionID, "", newStatusError(err, http.StatusBadRequest)
+	}
+	if sessionID.ID == "" {
+		return sessionID, "", newStatusError(errors.New("session_id parameter is required"), http.StatusBadRequest)
+	}
+	jobID := params["job_id"]
+	if jobID == "" {
+		return sessionID, "", newStatusError(errors.New("job_id parameter is required"), http.StatusBadRequest)
+	}
+	return sessionID, jobID, nil
+}
+
+func toJobModel(info runner.JobInfo) models.Job {
+	return models.Job{ID: info.ID, Status: string(info.Status), Error: info.Error, InstanceID: info.InstanceID}
+}
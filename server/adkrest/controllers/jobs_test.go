@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
+	"google.golang.org/adk/session"
+)
+
+func TestJobs(t *testing.T) {
+	ctx := t.Context()
+	// The agent runs until the job is cancelled.
+	blockingAgent, err := agent.New(agent.Config{
+		Name: "testApp",
+		Run: func(ctx agent.InvocationContext) iter.Seq2[*session.Event, error] {
+			return func(yield func(*session.Event, error) bool) {
+				<-ctx.Done()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession"}); err != nil {
+		t.Fatal(err)
+	}
+	controller := controllers.NewRuntimeAPIController(sessionService, nil, agent.NewSingleLoader(blockingAgent), nil, time.Second, runner.PluginConfig{}, nil, nil, nil, "")
+
+	body, err := json.Marshal(models.RunAgentRequest{
+		AppName:    "testApp",
+		UserId:     "testUser",
+		SessionId:  "testSession",
+		NewMessage: *genai.NewContentFromText("hi", genai.RoleUser),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	if err := controller.RunAsyncHandler(rr, httptest.NewRequest(http.MethodPost, "/run_async", bytes.NewReader(body))); err != nil {
+		t.Fatalf("RunAsyncHandler() error = %v", err)
+	}
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("RunAsyncHandler() status = %d, want %d", rr.Code, http.StatusAccepted)
+	}
+	var started models.Job
+	if err := json.NewDecoder(rr.Body).Decode(&started); err != nil {
+		t.Fatal(err)
+	}
+	if started.Status != string(runner.JobRunning) {
+		t.Errorf("started job status = %q, want %q", started.Status, runner.JobRunning)
+	}
+
+	jobRequest := func(t *testing.T, handler func(http.ResponseWriter, *http.Request) error, method, sessionID, jobID string) (*httptest.ResponseRecorder, error) {
+		t.Helper()
+		req := httptest.NewRequest(method, "/jobs", nil)
+		req = mux.SetURLVars(req, map[string]string{
+			"app_name":   "testApp",
+			"user_id":    "testUser",
+			"session_id": sessionID,
+			"job_id":     jobID,
+		})
+		rr := httptest.NewRecorder()
+		return rr, handler(rr, req)
+	}
+	getStatus := func(t *testing.T) string {
+		t.Helper()
+		rr, err := jobRequest(t, controller.GetJobHandler, http.MethodGet, "testSession", started.ID)
+		if err != nil {
+			t.Fatalf("GetJobHandler() error = %v", err)
+		}
+		var got models.Job
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		return got.Status
+	}
+
+	if got := getStatus(t); got != string(runner.JobRunning) {
+		t.Errorf("job status = %q, want %q", got, runner.JobRunning)
+	}
+
+	if _, err := jobRequest(t, controller.CancelJobHandler, http.MethodPost, "otherSession", started.ID); err == nil {
+		t.Error("CancelJobHandler() for another session succeeded, want error")
+	}
+	if _, err := jobRequest(t, controller.CancelJobHandler, http.MethodPost, "testSession", started.ID); err != nil {
+		t.Fatalf("CancelJobHandler() error = %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for getStatus(t) != string(runner.JobCancelled) {
+		if time.Now().After(deadline) {
+			t.Fatal("job was not cancelled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, err := jobRequest(t, controller.GetJobHandler, http.MethodGet, "testSession", "job-unknown"); err == nil {
+		t.Error("GetJobHandler() for an unknown job succeeded, want error")
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/server/adkrest/internal/models"
+)
+
+// RunLiveHandler runs an agent live over a WebSocket connection, e.g. for
+// voice agents. The session is given by the app_name, user_id and
+// session_id query parameters, the response modalities of the model by the
+// modalities parameter, "AUDIO" by default as for the Web UI. The audio input and output are
+// transcribed when the model responds with audio.
+//
+// The client sends LiveRequest messages: text turns, chunks of realtime
+// input and activity signals, or close to end the run. The server sends
+// back the events of the run, including the partial model output, the
+// transcriptions and the turn complete signals.
+func (c *RuntimeAPIController) RunLiveHandler(rw http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	appName, userID, sessionID := query.Get("app_name"), query.Get("user_id"), query.Get("session_id")
+	if appName == "" || userID == "" || sessionID == "" {
+		return newStatusError(errors.New("app_name, user_id and session_id are required"), http.StatusBadRequest)
+	}
+	if err := c.validateSessionExists(req.Context(), appName, userID, sessionID); err != nil {
+		return err
+	}
+	r, rCfg, err := c.getRunner(models.RunAgentRequest{AppName: appName})
+	if err != nil {
+		return err
+	}
+	for _, modality := range strings.Split(strings.Join(query["modalities"], ","), ",") {
+		if modality = strings.ToUpper(strings.TrimSpace(modality)); modality != "" {
+			rCfg.ResponseModalities = append(rCfg.ResponseModalities, genai.Modality(modality))
+		}
+	}
+	if len(rCfg.ResponseModalities) == 0 {
+		rCfg.ResponseModalities = []genai.Modality{genai.ModalityAudio}
+	}
+	if slices.Contains(rCfg.ResponseModalities, genai.ModalityAudio) {
+		rCfg.InputAudioTranscription = &genai.AudioTranscriptionConfig{}
+		rCfg.OutputAudioTranscription = &genai.AudioTranscriptionConfig{}
+	}
+
+	// The connection is accepted from the origins allowed by the CORS
+	// headers of the server.
+	allowedOrigin := rw.Header().Get("Access-Control-Allow-Origin")
+	upgrader := websocket.Upgrader{CheckOrigin: func(req *http.Request) bool {
+		origin := req.Header.Get("Origin")
+		if origin == "" || (allowedOrigin != "" && (allowedOrigin == "*" || origin == allowedOrigin)) {
+			return true
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, req.Host)
+	}}
+	conn, err := upgrader.Upgrade(rw, req, nil)
+	if err != nil {
+		// The upgrader has replied with the error.
+		return nil
+	}
+	defer conn.Close()
+	// A live run lasts as long as the client stays connected.
+	_ = conn.SetReadDeadline(time.Time{})
+	_ = conn.SetWriteDeadline(time.Time{})
+
+	queue := agent.NewLiveRequestQueue()
+	defer queue.Close()
+	go readLiveRequests(conn, queue)
+
+	for event, err := range r.RunLive(req.Context(), userID, sessionID, queue, *rCfg) {
+		if err != nil {
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, truncateCloseReason(err.Error())))
+			return nil
+		}
+		if err := conn.WriteJSON(models.FromSessionEvent(*event)); err != nil {
+			return nil
+		}
+	}
+	_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	return nil
+}
+
+// readLiveRequests sends the requests of the client to the queue, until the
+// client closes the run or the connection.
+func readLiveRequests(conn *websocket.Conn, queue *agent.LiveRequestQueue) {
+	defer queue.Close()
+	for {
+		var liveReq models.LiveRequest
+		if err := conn.ReadJSON(&liveReq); err != nil {
+			return
+		}
+		if liveReq.Close {
+			return
+		}
+		err := queue.Send(&agent.LiveRequest{
+			Content:       liveReq.Content,
+			Blob:          liveReq.Blob,
+			ActivityStart: liveReq.ActivityStart,
+			ActivityEnd:   liveReq.ActivityEnd,
+		})
+		if err != nil {
+			return
+		}
+	}
+}
+
+// truncateCloseReason fits the reason in a WebSocket close frame.
+func truncateCloseReason(reason string) string {
+	const maxLen = 123
+	if len(reason) > maxLen {
+		reason = reason[:maxLen]
+	}
+	return reason
+}
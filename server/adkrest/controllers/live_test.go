@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"iter"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/runner"
+	"google.golang.org/adk/server/adkrest/internal/models"
+	"google.golang.org/adk/session"
+)
+
+// echoLiveModel echoes the text turns, and transcribes the realtime input
+// as its MIME type.
+type echoLiveModel struct {
+	config *genai.LiveConnectConfig
+}
+
+func (m *echoLiveModel) Name() string { return "echo-live" }
+
+func (m *echoLiveModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {}
+}
+
+func (m *echoLiveModel) ConnectLive(ctx context.Context, req *model.LLMRequest) (model.LiveConnection, error) {
+	m.config = req.LiveConnectConfig
+	return &echoLiveConnection{out: make(chan *model.LLMResponse, 16), closed: make(chan struct{})}, nil
+}
+
+type echoLiveConnection struct {
+	out       chan *model.LLMResponse
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *echoLiveConnection) SendContent(content *genai.Content) error {
+	text := "echo: " + content.Parts[0].Text
+	c.out <- &model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel), Partial: true}
+	c.out <- &model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel)}
+	c.out <- &model.LLMResponse{TurnComplete: true}
+	return nil
+}
+
+func (c *echoLiveConnection) SendRealtime(input genai.LiveRealtimeInput) error {
+	c.out <- &model.LLMResponse{InputTranscription: &genai.Transcription{Text: input.Audio.MIMEType, Finished: true}}
+	return nil
+}
+
+func (c *echoLiveConnection) Receive() iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		for {
+			select {
+			case resp := <-c.out:
+				if !yield(resp, nil) {
+					return
+				}
+			case <-c.closed:
+				return
+			}
+		}
+	}
+}
+
+func (c *echoLiveConnection) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+func TestRunLiveHandler(t *testing.T) {
+	ctx := t.Context()
+	llm := &echoLiveModel{}
+	echoAgent, err := llmagent.New(llmagent.Config{Name: "echo_agent", Model: llm})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "echo_agent", UserID: "testUser", SessionID: "testSession"}); err != nil {
+		t.Fatal(err)
+	}
+	controller := NewRuntimeAPIController(sessionService, nil, agent.NewSingleLoader(echoAgent), nil, time.Second, runner.PluginConfig{}, nil, nil, nil, "")
+	server := httptest.NewServer(NewErrorHandler(controller.RunLiveHandler))
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	if _, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL+"/run_live?app_name=echo_agent&user_id=testUser&session_id=unknown", nil); err == nil {
+		t.Error("Dial() for an unknown session succeeded, want error")
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL+"/run_live?app_name=echo_agent&user_id=testUser&session_id=testSession&modalities=TEXT", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	// The Web UI sends the MIME type of the blobs in snake case.
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"blob": {"mime_type": "audio/pcm", "data": "AAE="}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for {
+		var event models.Event
+		if err := conn.ReadJSON(&event); err != nil {
+			t.Fatalf("ReadJSON() error = %v", err)
+		}
+		switch {
+		case event.TurnComplete:
+			got = append(got, "turn complete")
+		case event.InputTranscription != nil:
+			got = append(got, event.Author+" transcription: "+event.InputTranscription.Text)
+			if err := conn.WriteJSON(models.LiveRequest{Content: genai.NewContentFromText("hello", genai.RoleUser)}); err != nil {
+				t.Fatal(err)
+			}
+		case event.Partial:
+			got = append(got, "partial "+event.Content.Parts[0].Text)
+		default:
+			got = append(got, event.Author+": "+event.Content.Parts[0].Text)
+		}
+		if event.TurnComplete {
+			break
+		}
+	}
+	want := []string{
+		"user transcription: audio/pcm",
+		"user: hello",
+		"partial echo: hello",
+		"echo_agent: echo: hello",
+		"turn complete",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+	if llm.config == nil || len(llm.config.ResponseModalities) != 1 || llm.config.ResponseModalities[0] != genai.ModalityText {
+		t.Errorf("LiveConnectConfig = %+v, want the TEXT response modality", llm.config)
+	}
+
+	if err := conn.WriteJSON(models.LiveRequest{Close: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("ReadMessage() after close error = %v, want a normal closure", err)
+	}
+}
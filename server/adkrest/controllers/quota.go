@@ -0,0 +1,57 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"google.golang.org/adk/quota"
+	"google.golang.org/adk/server/adkrest/internal/models"
+)
+
+// QuotaAPIController is the controller for the Quota API.
+type QuotaAPIController struct {
+	quota *quota.Enforcer
+}
+
+// NewQuotaAPIController creates the controller for the Quota API. The
+// enforcer may be nil if no quota is enforced.
+func NewQuotaAPIController(enforcer *quota.Enforcer) *QuotaAPIController {
+	return &QuotaAPIController{quota: enforcer}
+}
+
+// GetQuotaHandler returns the usage and the remaining quota of a user in an
+// app.
+func (c *QuotaAPIController) GetQuotaHandler(rw http.ResponseWriter, req *http.Request) error {
+	params := mux.Vars(req)
+	appName, userID := params["app_name"], params["user_id"]
+	if appName == "" || userID == "" {
+		return newStatusError(errors.New("app_name and user_id parameters are required"), http.StatusBadRequest)
+	}
+	var statuses []quota.Status
+	if c.quota != nil {
+		var err error
+		statuses, err = c.quota.Usage(req.Context(), appName, userID)
+		if err != nil {
+			return newStatusError(fmt.Errorf("failed to get the quota usage: %w", err), http.StatusInternalServerError)
+		}
+	}
+	EncodeJSONResponse(models.FromQuotaStatuses(appName, userID, statuses), http.StatusOK, rw)
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"google.golang.org/adk/quota"
+	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
+)
+
+func TestGetQuotaHandler(t *testing.T) {
+	enforcer := quota.New(quota.Config{Limits: quota.Limits{InvocationsPerUserPerDay: 3, TokensPerAppPerMonth: 100}})
+	if err := enforcer.Begin(t.Context(), "weather", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := enforcer.RecordTokens(t.Context(), "weather", 40); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		enforcer *quota.Enforcer
+		want     map[string]int64
+	}{
+		{name: "enforced", enforcer: enforcer, want: map[string]int64{"invocations": 2, "tokens": 60}},
+		{name: "not enforced", want: map[string]int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := controllers.NewQuotaAPIController(tt.enforcer)
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/apps/weather/users/alice/quota", nil), map[string]string{"app_name": "weather", "user_id": "alice"})
+			rr := httptest.NewRecorder()
+			controllers.NewErrorHandler(controller.GetQuotaHandler)(rr, req)
+			if rr.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, http.StatusOK, rr.Body)
+			}
+			var got models.Quota
+			if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode the response: %v", err)
+			}
+			remaining := map[string]int64{}
+			for _, q := range got.Quotas {
+				remaining[q.Metric] = q.Remaining
+			}
+			if len(remaining) != len(tt.want) {
+				t.Fatalf("quotas = %+v, want remaining %v", got.Quotas, tt.want)
+			}
+			for metric, want := range tt.want {
+				if remaining[metric] != want {
+					t.Errorf("remaining %s = %d, want %d", metric, remaining[metric], want)
+				}
+			}
+		})
+	}
+}
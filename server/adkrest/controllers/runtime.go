@@ -17,13 +17,17 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	"google.golang.org/adk/admission"
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/artifact"
 	"google.golang.org/adk/memory"
+	"google.golang.org/adk/quota"
 	"google.golang.org/adk/runner"
 	"google.golang.org/adk/server/adkrest/internal/models"
 	"google.golang.org/adk/session"
@@ -37,14 +41,29 @@ type RuntimeAPIController struct {
 	artifactService artifact.Service
 	agentLoader     agent.Loader
 	pluginConfig    runner.PluginConfig
+	quota           *quota.Enforcer
+	admission       *admission.Controller
+	sessionLocker   runner.SessionLocker
+	instanceID      string
+
+	// jobs holds the agent runs started with RunAsyncHandler that are not
+	// done yet.
+	jobsMu sync.Mutex
+	jobs   map[string]*job
 }
 
 // NewRuntimeAPIController creates the controller for the Runtime API.
-func NewRuntimeAPIController(sessionService session.Service, memoryService memory.Service, agentLoader agent.Loader, artifactService artifact.Service, sseTimeout time.Duration, pluginConfig runner.PluginConfig) *RuntimeAPIController {
-	return &RuntimeAPIController{sessionService: sessionService, memoryService: memoryService, agentLoader: agentLoader, artifactService: artifactService, sseTimeout: sseTimeout, pluginConfig: pluginConfig}
+func NewRuntimeAPIController(sessionService session.Service, memoryService memory.Service, agentLoader agent.Loader, artifactService artifact.Service, sseTimeout time.Duration, pluginConfig runner.PluginConfig, quota *quota.Enforcer, admission *admission.Controller, sessionLocker runner.SessionLocker, instanceID string) *RuntimeAPIController {
+	if sessionLocker == nil {
+		// The runners are created per request, they share the locks of the
+		// sessions.
+		sessionLocker = runner.NewInMemorySessionLocker()
+	}
+	return &RuntimeAPIController{sessionService: sessionService, memoryService: memoryService, agentLoader: agentLoader, artifactService: artifactService, sseTimeout: sseTimeout, pluginConfig: pluginConfig, quota: quota, admission: admission, sessionLocker: sessionLocker, instanceID: instanceID, jobs: make(map[string]*job)}
 }
 
-// RunAgent executes a non-streaming agent run for a given session and message.
+// RunHandler executes an agent run for a given session and message, and
+// returns all the events of the invocation once it is complete.
 func (c *RuntimeAPIController) RunHandler(rw http.ResponseWriter, req *http.Request) error {
 	runAgentRequest, err := decodeRequestBody(req)
 	if err != nil {
@@ -54,7 +73,7 @@ func (c *RuntimeAPIController) RunHandler(rw http.ResponseWriter, req *http.Requ
 	if err != nil {
 		return err
 	}
-	var events []models.Event
+	events := []models.Event{}
 	for _, event := range sessionEvents {
 		events = append(events, models.FromSessionEvent(*event))
 	}
@@ -62,7 +81,9 @@ func (c *RuntimeAPIController) RunHandler(rw http.ResponseWriter, req *http.Requ
 	return nil
 }
 
-// RunAgent executes a non-streaming agent run for a given session and message.
+// runAgent executes an agent run for a given session and message, and
+// returns its events. The partial events of a streaming run are skipped:
+// their content is repeated by the following complete event.
 func (c *RuntimeAPIController) runAgent(ctx context.Context, runAgentRequest models.RunAgentRequest) ([]*session.Event, error) {
 	err := c.validateSessionExists(ctx, runAgentRequest.AppName, runAgentRequest.UserId, runAgentRequest.SessionId)
 	if err != nil {
@@ -74,12 +95,19 @@ func (c *RuntimeAPIController) runAgent(ctx context.Context, runAgentRequest mod
 		return nil, err
 	}
 
-	resp := r.Run(ctx, runAgentRequest.UserId, runAgentRequest.SessionId, &runAgentRequest.NewMessage, *rCfg)
+	opts := []runner.RunOption{}
+	if runAgentRequest.StateDelta != nil {
+		opts = append(opts, runner.WithStateDelta(*runAgentRequest.StateDelta))
+	}
+	resp := r.Run(ctx, runAgentRequest.UserId, runAgentRequest.SessionId, &runAgentRequest.NewMessage, *rCfg, opts...)
 
 	var events []*session.Event
 	for event, err := range resp {
 		if err != nil {
-			return nil, newStatusError(fmt.Errorf("failed to run agent: %w", err), http.StatusInternalServerError)
+			return nil, newRunError(err)
+		}
+		if event.Partial {
+			continue
 		}
 		events = append(events, event)
 	}
@@ -121,7 +149,14 @@ func (c *RuntimeAPIController) RunSSEHandler(rw http.ResponseWriter, req *http.R
 	}
 	resp := r.Run(req.Context(), runAgentRequest.UserId, runAgentRequest.SessionId, &runAgentRequest.NewMessage, *rCfg, opts...)
 
+	written := false
 	for event, err := range resp {
+		var rejected *admission.RejectedError
+		if !written && errors.As(err, &rejected) {
+			// Nothing was streamed yet, so the client can be told to retry.
+			return newRunError(err)
+		}
+		written = true
 		if err != nil {
 			_, err := fmt.Fprintf(rw, "Error while running agent: %v\n", err)
 			if err != nil {
@@ -187,6 +222,10 @@ func (c *RuntimeAPIController) getRunner(req models.RunAgentRequest) (*runner.Ru
 		MemoryService:   c.memoryService,
 		ArtifactService: c.artifactService,
 		PluginConfig:    c.pluginConfig,
+		Quota:           c.quota,
+		Admission:       c.admission,
+		SessionLocker:   c.sessionLocker,
+		InstanceID:      c.instanceID,
 	},
 	)
 	if err != nil {
@@ -198,19 +237,22 @@ func (c *RuntimeAPIController) getRunner(req models.RunAgentRequest) (*runner.Ru
 		streamingMode = agent.StreamingModeSSE
 	}
 	return r, &agent.RunConfig{
-		StreamingMode: streamingMode,
+		StreamingMode:     streamingMode,
+		StreamGranularity: agent.StreamGranularity(req.StreamGranularity),
+		GenerationProfile: req.GenerationProfile,
 	}, nil
 }
 
-func decodeRequestBody(req *http.Request) (decodedReq models.RunAgentRequest, err error) {
+func decodeRequestBody(req *http.Request) (models.RunAgentRequest, error) {
 	var runAgentRequest models.RunAgentRequest
-	defer func() {
-		err = req.Body.Close()
-	}()
+	defer req.Body.Close()
 	d := json.NewDecoder(req.Body)
 	d.DisallowUnknownFields()
 	if err := d.Decode(&runAgentRequest); err != nil {
 		return runAgentRequest, newStatusError(fmt.Errorf("failed to decode request: %w", err), http.StatusBadRequest)
 	}
+	if err := runAgentRequest.AssertRunAgentRequestRequired(); err != nil {
+		return runAgentRequest, newStatusError(err, http.StatusBadRequest)
+	}
 	return runAgentRequest, nil
 }
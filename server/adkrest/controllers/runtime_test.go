@@ -15,11 +15,26 @@
 package controllers
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/admission"
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/plugin"
 	"google.golang.org/adk/runner"
+	"google.golang.org/adk/server/adkrest/internal/models"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
 )
 
 func TestNewRuntimeAPIController_PluginsAssignment(t *testing.T) {
@@ -64,7 +79,7 @@ func TestNewRuntimeAPIController_PluginsAssignment(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			controller := NewRuntimeAPIController(nil, nil, nil, nil, 10*time.Second, runner.PluginConfig{
 				Plugins: tt.plugins,
-			})
+			}, nil, nil, nil, "")
 
 			if controller == nil {
 				t.Fatal("NewRuntimeAPIController returned nil")
@@ -76,3 +91,138 @@ func TestNewRuntimeAPIController_PluginsAssignment(t *testing.T) {
 		})
 	}
 }
+
+func TestRunHandler(t *testing.T) {
+	ctx := t.Context()
+	type args struct {
+		City string `json:"city"`
+	}
+	getWeather, err := functiontool.New(functiontool.Config{Name: "get_weather"}, func(ctx tool.Context, a args) (map[string]any, error) {
+		if err := ctx.State().Set("last_city", a.City); err != nil {
+			return nil, err
+		}
+		return map[string]any{"weather": "sunny"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	weatherAgent, err := llmagent.New(llmagent.Config{
+		Name: "weather_agent",
+		Model: &testutil.MockModel{Responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("get_weather", map[string]any{"city": "Paris"}, genai.RoleModel),
+			genai.NewContentFromText("It is sunny in Paris.", genai.RoleModel),
+		}},
+		Tools: []tool.Tool{getWeather},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "weather_agent", UserID: "testUser", SessionID: "testSession"}); err != nil {
+		t.Fatal(err)
+	}
+	controller := NewRuntimeAPIController(sessionService, nil, agent.NewSingleLoader(weatherAgent), nil, time.Second, runner.PluginConfig{}, nil, nil, nil, "")
+
+	run := func(t *testing.T, body string) (*httptest.ResponseRecorder, error) {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		return rr, controller.RunHandler(rr, httptest.NewRequest(http.MethodPost, "/run", bytes.NewBufferString(body)))
+	}
+
+	rr, err := run(t, `{"appName": "weather_agent", "userId": "testUser", "sessionId": "testSession", "newMessage": {"role": "user", "parts": [{"text": "Weather in Paris?"}]}, "stateDelta": {"unit": "celsius"}}`)
+	if err != nil {
+		t.Fatalf("RunHandler() error = %v", err)
+	}
+	var events []models.Event
+	if err := json.NewDecoder(rr.Body).Decode(&events); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("RunHandler() returned %d events, want the tool call, the tool response and the answer", len(events))
+	}
+	if call := events[0].Content.Parts[0].FunctionCall; call == nil || call.Name != "get_weather" || call.Args["city"] != "Paris" {
+		t.Errorf("events[0] = %+v, want the get_weather call", events[0].Content)
+	}
+	if got := events[1].Actions.StateDelta["last_city"]; got != "Paris" {
+		t.Errorf("events[1] state delta = %v, want last_city set by the tool", events[1].Actions.StateDelta)
+	}
+	if got := events[2].Content.Parts[0].Text; got != "It is sunny in Paris." {
+		t.Errorf("events[2] text = %q, want the answer", got)
+	}
+	for i, event := range events {
+		if event.Timestamp == 0 || event.Author != "weather_agent" {
+			t.Errorf("events[%d] = %+v, want a timestamp and the agent as author", i, event)
+		}
+	}
+	resp, err := sessionService.Get(ctx, &session.GetRequest{AppName: "weather_agent", UserID: "testUser", SessionID: "testSession"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := resp.Session.State().Get("unit"); got != "celsius" {
+		t.Errorf("session state unit = %v, want the state delta of the request", got)
+	}
+
+	for _, tt := range []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "invalid JSON", body: `{"appName":`, wantStatus: http.StatusBadRequest},
+		{name: "missing message", body: `{"appName": "weather_agent", "userId": "testUser", "sessionId": "testSession"}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown session", body: `{"appName": "weather_agent", "userId": "testUser", "sessionId": "other", "newMessage": {"role": "user", "parts": [{"text": "hi"}]}}`, wantStatus: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := run(t, tt.body)
+			var statusErr statusError
+			if !errors.As(err, &statusErr) || statusErr.Status() != tt.wantStatus {
+				t.Errorf("RunHandler() error = %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRunHandler_Rejected(t *testing.T) {
+	ctx := t.Context()
+	echoAgent, err := llmagent.New(llmagent.Config{
+		Name:  "echo_agent",
+		Model: &testutil.MockModel{Responses: []*genai.Content{genai.NewContentFromText("hi", genai.RoleModel)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionService := session.InMemoryService()
+	if _, err := sessionService.Create(ctx, &session.CreateRequest{AppName: "echo_agent", UserID: "testUser", SessionID: "testSession"}); err != nil {
+		t.Fatal(err)
+	}
+	controller := admission.New(admission.Config{MaxConcurrent: 1, RetryAfter: 1500 * time.Millisecond})
+	// The only slot is taken.
+	release, err := controller.Acquire(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	runtime := NewRuntimeAPIController(sessionService, nil, agent.NewSingleLoader(echoAgent), nil, time.Second, runner.PluginConfig{}, nil, controller, nil, "")
+
+	body := `{"appName": "echo_agent", "userId": "testUser", "sessionId": "testSession", "newMessage": {"role": "user", "parts": [{"text": "hi"}]}}`
+	for name, handler := range map[string]func(http.ResponseWriter, *http.Request) error{
+		"run":     runtime.RunHandler,
+		"run_sse": runtime.RunSSEHandler,
+	} {
+		t.Run(name, func(t *testing.T) {
+			// A server, as the SSE handler sets a write deadline.
+			server := httptest.NewServer(NewErrorHandler(handler))
+			defer server.Close()
+			resp, err := http.Post(server.URL, "application/json", bytes.NewBufferString(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusTooManyRequests {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+			}
+			if got := resp.Header.Get("Retry-After"); got != "2" {
+				t.Errorf("Retry-After = %q, want 2", got)
+			}
+		})
+	}
+}
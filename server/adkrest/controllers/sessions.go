@@ -17,7 +17,10 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
@@ -25,9 +28,11 @@ import (
 	"google.golang.org/adk/session"
 )
 
-// TODO: Confirm error handling and target semantic for REST API.
-
 // SessionsAPIController is the controller for the Sessions API.
+//
+// The errors are reported with the same status codes whatever the session
+// service: 400 for invalid requests, 404 for unknown sessions and 409 when
+// creating a session that already exists.
 type SessionsAPIController struct {
 	service session.Service
 }
@@ -37,7 +42,8 @@ func NewSessionsAPIController(service session.Service) *SessionsAPIController {
 	return &SessionsAPIController{service: service}
 }
 
-// CreateSesssionHTTP is a HTTP handler for the create session API.
+// CreateSessionHandler is a HTTP handler for the create session API. The
+// session is created with the optional state and events of the request body.
 func (c *SessionsAPIController) CreateSessionHandler(rw http.ResponseWriter, req *http.Request) {
 	params := mux.Vars(req)
 	sessionID, err := models.SessionIDFromHTTPParameters(params)
@@ -56,7 +62,7 @@ func (c *SessionsAPIController) CreateSessionHandler(rw http.ResponseWriter, req
 	}
 	respSession, err := c.createSession(req.Context(), sessionID, createSessionRequest)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		writeSessionError(rw, err)
 		return
 	}
 	EncodeJSONResponse(respSession, http.StatusOK, rw)
@@ -81,61 +87,59 @@ func (c *SessionsAPIController) createSession(ctx context.Context, sessionID mod
 	return models.FromSession(session.Session)
 }
 
-// DeleteSession handles deleting a specific session.
+// DeleteSessionHandler handles deleting a specific session.
 func (c *SessionsAPIController) DeleteSessionHandler(rw http.ResponseWriter, req *http.Request) {
-	params := mux.Vars(req)
-	sessionID, err := models.SessionIDFromHTTPParameters(params)
+	sessionID, err := requiredSessionID(req)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+		writeSessionError(rw, err)
 		return
 	}
-	if sessionID.ID == "" {
-		http.Error(rw, "session_id parameter is required", http.StatusBadRequest)
+	// Not all the session services report the deletion of unknown sessions.
+	if _, err := c.getSession(req.Context(), sessionID, &session.GetRequest{NumRecentEvents: 1}); err != nil {
+		writeSessionError(rw, err)
 		return
 	}
-
 	err = c.service.Delete(req.Context(), &session.DeleteRequest{
 		AppName:   sessionID.AppName,
 		UserID:    sessionID.UserID,
 		SessionID: sessionID.ID,
 	})
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		writeSessionError(rw, err)
 		return
 	}
 	EncodeJSONResponse(nil, http.StatusOK, rw)
 }
 
-// GetSession retrieves a specific session by its ID.
+// GetSessionHandler retrieves a specific session by its ID. The events can be
+// limited with the num_recent_events query parameter, the most recent events
+// are returned, and the after query parameter, the events since the given
+// time in seconds since the Unix epoch are returned.
 func (c *SessionsAPIController) GetSessionHandler(rw http.ResponseWriter, req *http.Request) {
-	params := mux.Vars(req)
-	sessionID, err := models.SessionIDFromHTTPParameters(params)
+	sessionID, err := requiredSessionID(req)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+		writeSessionError(rw, err)
 		return
 	}
-	if sessionID.ID == "" {
-		http.Error(rw, "session_id parameter is required", http.StatusBadRequest)
+	getRequest, err := eventFilters(req)
+	if err != nil {
+		writeSessionError(rw, err)
 		return
 	}
-	storedSession, err := c.service.Get(req.Context(), &session.GetRequest{
-		AppName:   sessionID.AppName,
-		UserID:    sessionID.UserID,
-		SessionID: sessionID.ID,
-	})
+	storedSession, err := c.getSession(req.Context(), sessionID, getRequest)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		writeSessionError(rw, err)
 		return
 	}
-	session, err := models.FromSession(storedSession.Session)
+	session, err := models.FromSession(storedSession)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		writeSessionError(rw, err)
 		return
 	}
 	EncodeJSONResponse(session, http.StatusOK, rw)
 }
 
-// ListSessions handles listing all sessions for a given app and user.
+// ListSessionsHandler handles listing all sessions for a given app and user.
 func (c *SessionsAPIController) ListSessionsHandler(rw http.ResponseWriter, req *http.Request) {
 	params := mux.Vars(req)
 	sessionID, err := models.SessionIDFromHTTPParameters(params)
@@ -143,22 +147,170 @@ func (c *SessionsAPIController) ListSessionsHandler(rw http.ResponseWriter, req
 		http.Error(rw, err.Error(), http.StatusBadRequest)
 		return
 	}
-	var sessions []models.Session
+	sessions := []models.Session{}
 	resp, err := c.service.List(req.Context(), &session.ListRequest{
 		AppName: sessionID.AppName,
 		UserID:  sessionID.UserID,
 	})
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		writeSessionError(rw, err)
 		return
 	}
-	for _, session := range resp.Sessions {
-		respSession, err := models.FromSession(session)
+	for _, sess := range resp.Sessions {
+		if sessionID.UserID != "" {
+			if err := session.CheckScope(sess, sessionID.AppName, sessionID.UserID, ""); err != nil {
+				writeSessionError(rw, err)
+				return
+			}
+		}
+		respSession, err := models.FromSession(sess)
 		if err != nil {
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			writeSessionError(rw, err)
 			return
 		}
 		sessions = append(sessions, respSession)
 	}
 	EncodeJSONResponse(sessions, http.StatusOK, rw)
 }
+
+// UpdateSessionHandler applies the state delta of the request body to a
+// session, and returns the updated session. The delta is recorded as an
+// event of the session, as the state changes made by the agents.
+func (c *SessionsAPIController) UpdateSessionHandler(rw http.ResponseWriter, req *http.Request) {
+	sessionID, err := requiredSessionID(req)
+	if err != nil {
+		writeSessionError(rw, err)
+		return
+	}
+	var updateRequest models.UpdateSessionRequest
+	if err := json.NewDecoder(req.Body).Decode(&updateRequest); err != nil {
+		http.Error(rw, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(updateRequest.StateDelta) == 0 {
+		http.Error(rw, "stateDelta is required", http.StatusBadRequest)
+		return
+	}
+	storedSession, err := c.getSession(req.Context(), sessionID, &session.GetRequest{})
+	if err != nil {
+		writeSessionError(rw, err)
+		return
+	}
+	event := session.NewEventWithContext(req.Context(), "p-"+session.NewID(req.Context()))
+	event.Author = "user"
+	event.Actions.StateDelta = updateRequest.StateDelta
+	if err := c.service.AppendEvent(req.Context(), storedSession, event); err != nil {
+		writeSessionError(rw, err)
+		return
+	}
+	storedSession, err = c.getSession(req.Context(), sessionID, &session.GetRequest{})
+	if err != nil {
+		writeSessionError(rw, err)
+		return
+	}
+	session, err := models.FromSession(storedSession)
+	if err != nil {
+		writeSessionError(rw, err)
+		return
+	}
+	EncodeJSONResponse(session, http.StatusOK, rw)
+}
+
+// ForkSessionHandler creates a new session with the state and the events of
+// a session before the event of the request body, and returns it.
+func (c *SessionsAPIController) ForkSessionHandler(rw http.ResponseWriter, req *http.Request) {
+	sessionID, err := requiredSessionID(req)
+	if err != nil {
+		writeSessionError(rw, err)
+		return
+	}
+	var forkRequest models.ForkSessionRequest
+	if req.ContentLength > 0 {
+		if err := json.NewDecoder(req.Body).Decode(&forkRequest); err != nil {
+			http.Error(rw, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	resp, err := session.Fork(req.Context(), c.service, &session.ForkRequest{
+		AppName:      sessionID.AppName,
+		UserID:       sessionID.UserID,
+		SessionID:    sessionID.ID,
+		EventID:      forkRequest.EventID,
+		NewSessionID: forkRequest.SessionID,
+	})
+	if err != nil {
+		writeSessionError(rw, err)
+		return
+	}
+	fork, err := models.FromSession(resp.Session)
+	if err != nil {
+		writeSessionError(rw, err)
+		return
+	}
+	EncodeJSONResponse(fork, http.StatusOK, rw)
+}
+
+// getSession gets the session with the filters of getRequest.
+func (c *SessionsAPIController) getSession(ctx context.Context, sessionID models.SessionID, getRequest *session.GetRequest) (session.Session, error) {
+	getRequest.AppName = sessionID.AppName
+	getRequest.UserID = sessionID.UserID
+	getRequest.SessionID = sessionID.ID
+	resp, err := c.service.Get(ctx, getRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.CheckScope(resp.Session, sessionID.AppName, sessionID.UserID, ""); err != nil {
+		return nil, err
+	}
+	return resp.Session, nil
+}
+
+// requiredSessionID returns the session ID of the URL, which must include
+// the ID of the session.
+func requiredSessionID(req *http.Request) (models.SessionID, error) {
+	sessionID, err := models.SessionIDFromHTTPParameters(mux.Vars(req))
+	if err != nil {
+		return sessionID, newStatusError(err, http.StatusBadRequest)
+	}
+	if sessionID.ID == "" {
+		return sessionID, newStatusError(errors.New("session_id parameter is required"), http.StatusBadRequest)
+	}
+	return sessionID, nil
+}
+
+// eventFilters returns the GetRequest with the event filters of the query
+// parameters.
+func eventFilters(req *http.Request) (*session.GetRequest, error) {
+	getRequest := &session.GetRequest{}
+	query := req.URL.Query()
+	if v := query.Get("num_recent_events"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, newStatusError(fmt.Errorf("invalid num_recent_events %q: want a non-negative integer", v), http.StatusBadRequest)
+		}
+		getRequest.NumRecentEvents = n
+	}
+	if v := query.Get("after"); v != "" {
+		seconds, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, newStatusError(fmt.Errorf("invalid after %q: want seconds since the Unix epoch", v), http.StatusBadRequest)
+		}
+		getRequest.After = models.FromUnixSeconds(seconds)
+	}
+	return getRequest, nil
+}
+
+// writeSessionError writes the error with the status code of its kind.
+func writeSessionError(rw http.ResponseWriter, err error) {
+	var statusErr statusError
+	switch {
+	case errors.As(err, &statusErr):
+		http.Error(rw, err.Error(), statusErr.Status())
+	case errors.Is(err, session.ErrSessionNotFound), errors.Is(err, session.ErrEventNotFound):
+		http.Error(rw, err.Error(), http.StatusNotFound)
+	case errors.Is(err, session.ErrSessionAlreadyExists):
+		http.Error(rw, err.Error(), http.StatusConflict)
+	default:
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -27,10 +28,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/gorilla/mux"
+	"google.golang.org/genai"
 
 	"google.golang.org/adk/server/adkrest/controllers"
 	"google.golang.org/adk/server/adkrest/internal/fakes"
 	"google.golang.org/adk/server/adkrest/internal/models"
+	"google.golang.org/adk/session"
 )
 
 func TestGetSession(t *testing.T) {
@@ -75,8 +78,8 @@ func TestGetSession(t *testing.T) {
 			name:           "session does not exist",
 			storedSessions: map[fakes.SessionKey]fakes.TestSession{},
 			sessionID:      id,
-			wantErr:        fmt.Errorf("not found"),
-			wantStatus:     http.StatusInternalServerError,
+			wantErr:        fmt.Errorf("session not found"),
+			wantStatus:     http.StatusNotFound,
 		},
 		{
 			name: "user ID is missing in input",
@@ -178,7 +181,7 @@ func TestCreateSession(t *testing.T) {
 			},
 			sessionID:  id,
 			wantErr:    fmt.Errorf("session already exists"),
-			wantStatus: http.StatusInternalServerError,
+			wantStatus: http.StatusConflict,
 		},
 		{
 			name:           "successful create operation",
@@ -295,7 +298,7 @@ func TestDeleteSession(t *testing.T) {
 			name:           "session does not exist",
 			storedSessions: map[fakes.SessionKey]fakes.TestSession{},
 			sessionID:      id,
-			wantStatus:     http.StatusInternalServerError,
+			wantStatus:     http.StatusNotFound,
 		},
 	}
 
@@ -434,6 +437,158 @@ func TestListSessions(t *testing.T) {
 	}
 }
 
+func TestGetSession_EventFilters(t *testing.T) {
+	ctx := t.Context()
+	service := session.InMemoryService()
+	created, err := service.Create(ctx, &session.CreateRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for i := range 3 {
+		event := session.NewEvent("invocation")
+		event.Author = "user"
+		event.Timestamp = start.Add(time.Duration(i) * time.Second)
+		event.Content = genai.NewContentFromText(fmt.Sprintf("message %d", i), genai.RoleUser)
+		if err := service.AppendEvent(ctx, created.Session, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	apiController := controllers.NewSessionsAPIController(service)
+	after := strconv.FormatFloat(float64(start.Add(time.Second).UnixMicro())/1e6, 'f', -1, 64)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantEvents int
+		wantStatus int
+	}{
+		{name: "all events", query: "", wantEvents: 3, wantStatus: http.StatusOK},
+		{name: "recent events", query: "?num_recent_events=1", wantEvents: 1, wantStatus: http.StatusOK},
+		{name: "events after", query: "?after=" + after, wantEvents: 2, wantStatus: http.StatusOK},
+		{name: "invalid num_recent_events", query: "?num_recent_events=-1", wantStatus: http.StatusBadRequest},
+		{name: "invalid after", query: "?after=yesterday", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/apps/testApp/users/testUser/sessions/testSession"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"app_name": "testApp", "user_id": "testUser", "session_id": "testSession"})
+			rr := httptest.NewRecorder()
+			apiController.GetSessionHandler(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("handler returned wrong status code: got %v want %v: %s", rr.Code, tt.wantStatus, rr.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got models.Session
+			if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Events) != tt.wantEvents {
+				t.Errorf("GetSession() returned %d events, want %d", len(got.Events), tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestUpdateSession(t *testing.T) {
+	ctx := t.Context()
+	service := session.InMemoryService()
+	if _, err := service.Create(ctx, &session.CreateRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession", State: map[string]any{"foo": "bar"}}); err != nil {
+		t.Fatal(err)
+	}
+	apiController := controllers.NewSessionsAPIController(service)
+	update := func(sessionID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/apps/testApp/users/testUser/sessions/"+sessionID, strings.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"app_name": "testApp", "user_id": "testUser", "session_id": sessionID})
+		rr := httptest.NewRecorder()
+		apiController.UpdateSessionHandler(rr, req)
+		return rr
+	}
+
+	rr := update("testSession", `{"stateDelta": {"unit": "celsius"}}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s", rr.Code, http.StatusOK, rr.Body)
+	}
+	var got models.Session
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]any{"foo": "bar", "unit": "celsius"}, got.State); diff != "" {
+		t.Errorf("UpdateSession() state mismatch (-want +got):\n%s", diff)
+	}
+	if len(got.Events) != 1 || got.Events[0].Author != "user" {
+		t.Errorf("UpdateSession() events = %+v, want the state delta event", got.Events)
+	}
+
+	if rr := update("unknown", `{"stateDelta": {"unit": "celsius"}}`); rr.Code != http.StatusNotFound {
+		t.Errorf("update of an unknown session returned status %v, want %v", rr.Code, http.StatusNotFound)
+	}
+	if rr := update("testSession", `{}`); rr.Code != http.StatusBadRequest {
+		t.Errorf("update without state delta returned status %v, want %v", rr.Code, http.StatusBadRequest)
+	}
+}
+
+func TestForkSession(t *testing.T) {
+	ctx := t.Context()
+	service := session.InMemoryService()
+	created, err := service.Create(ctx, &session.CreateRequest{AppName: "testApp", UserID: "testUser", SessionID: "testSession", State: map[string]any{"foo": "bar"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"first", "second"} {
+		event := session.NewEvent("inv")
+		event.ID = id
+		event.Author = "user"
+		if err := service.AppendEvent(ctx, created.Session, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	apiController := controllers.NewSessionsAPIController(service)
+	fork := func(sessionID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/apps/testApp/users/testUser/sessions/"+sessionID+"/fork", strings.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"app_name": "testApp", "user_id": "testUser", "session_id": sessionID})
+		rr := httptest.NewRecorder()
+		apiController.ForkSessionHandler(rr, req)
+		return rr
+	}
+
+	rr := fork("testSession", `{"eventId": "second", "sessionId": "forked"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s", rr.Code, http.StatusOK, rr.Body)
+	}
+	var got models.Session
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "forked" || len(got.Events) != 1 || got.Events[0].ID != "first" {
+		t.Errorf("ForkSession() = %+v, want the session forked with the first event", got)
+	}
+	if diff := cmp.Diff(map[string]any{"foo": "bar"}, got.State); diff != "" {
+		t.Errorf("ForkSession() state mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name      string
+		sessionID string
+		body      string
+		want      int
+	}{
+		{name: "unknown session", sessionID: "unknown", body: `{}`, want: http.StatusNotFound},
+		{name: "unknown event", sessionID: "testSession", body: `{"eventId": "unknown"}`, want: http.StatusNotFound},
+		{name: "existing session", sessionID: "testSession", body: `{"sessionId": "forked"}`, want: http.StatusConflict},
+		{name: "invalid body", sessionID: "testSession", body: `{`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rr := fork(tt.sessionID, tt.body); rr.Code != tt.want {
+				t.Errorf("handler returned wrong status code: got %v want %v: %s", rr.Code, tt.want, rr.Body)
+			}
+		})
+	}
+}
+
 func sessionVars(sessionID fakes.SessionKey) map[string]string {
 	return map[string]string{
 		"app_name":   sessionID.AppName,
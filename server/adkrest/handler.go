@@ -34,16 +34,25 @@ func NewHandler(config *launcher.Config, sseWriteTimeout time.Duration) http.Han
 	config.TelemetryOptions = append(config.TelemetryOptions, telemetry.WithLogRecordProcessors(debugTelemetry.LogProcessor()))
 
 	router := mux.NewRouter().StrictSlash(true)
+	// The model calls of the invocations run by the API are retained for
+	// the debug API.
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(rw, req.WithContext(debugTelemetry.RecordModelCalls(req.Context())))
+		})
+	})
 	// TODO: Allow taking a prefix to allow customizing the path
 	// where the ADK REST API will be served.
-	setupRouter(router,
+	subrouters := []routers.Router{
 		routers.NewSessionsAPIRouter(controllers.NewSessionsAPIController(config.SessionService)),
-		routers.NewRuntimeAPIRouter(controllers.NewRuntimeAPIController(config.SessionService, config.MemoryService, config.AgentLoader, config.ArtifactService, sseWriteTimeout, config.PluginConfig)),
+		routers.NewRuntimeAPIRouter(controllers.NewRuntimeAPIController(config.SessionService, config.MemoryService, config.AgentLoader, config.ArtifactService, sseWriteTimeout, config.PluginConfig, config.Quota, config.Admission, config.SessionLocker, config.InstanceID)),
 		routers.NewAppsAPIRouter(controllers.NewAppsAPIController(config.AgentLoader)),
 		routers.NewDebugAPIRouter(controllers.NewDebugAPIController(config.SessionService, config.AgentLoader, debugTelemetry)),
-		routers.NewArtifactsAPIRouter(controllers.NewArtifactsAPIController(config.ArtifactService)),
-		&routers.EvalAPIRouter{},
-	)
+		routers.NewArtifactsAPIRouter(controllers.NewArtifactsAPIController(config.ArtifactService, config.ArtifactUploadThreshold, config.ArtifactUploadDir)),
+		routers.NewEvalAPIRouter(controllers.NewEvalAPIController(config.SessionService)),
+		routers.NewQuotaAPIRouter(controllers.NewQuotaAPIController(config.Quota)),
+	}
+	setupRouter(router, append(subrouters, routers.NewOpenAPIRouter(subrouters...))...)
 	return router
 }
 
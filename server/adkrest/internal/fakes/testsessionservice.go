@@ -109,7 +109,7 @@ type SessionKey struct {
 
 func (s *FakeSessionService) Create(ctx context.Context, req *session.CreateRequest) (*session.CreateResponse, error) {
 	if _, ok := s.Sessions[SessionKey{AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID}]; ok {
-		return nil, fmt.Errorf("session already exists")
+		return nil, session.ErrSessionAlreadyExists
 	}
 
 	if req.SessionID == "" {
@@ -145,7 +145,7 @@ func (s *FakeSessionService) Get(ctx context.Context, req *session.GetRequest) (
 			Session: &sess,
 		}, nil
 	}
-	return nil, fmt.Errorf("not found")
+	return nil, session.ErrSessionNotFound
 }
 
 func (s *FakeSessionService) List(ctx context.Context, req *session.ListRequest) (*session.ListResponse, error) {
@@ -168,7 +168,7 @@ func (s *FakeSessionService) Delete(ctx context.Context, req *session.DeleteRequ
 		SessionID: req.SessionID,
 	}
 	if _, ok := s.Sessions[id]; !ok {
-		return fmt.Errorf("not found")
+		return session.ErrSessionNotFound
 	}
 	delete(s.Sessions, id)
 	return nil
@@ -185,4 +185,13 @@ func (s *FakeSessionService) AppendEvent(ctx context.Context, curSession session
 	return nil
 }
 
+func (s *FakeSessionService) AppendEvents(ctx context.Context, curSession session.Session, events []*session.Event) error {
+	for _, event := range events {
+		if err := s.AppendEvent(ctx, curSession, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 var _ session.Service = (*FakeSessionService)(nil)
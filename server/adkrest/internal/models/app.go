@@ -0,0 +1,39 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import "google.golang.org/adk/agent"
+
+// App describes an app served by the API and its root agent.
+type App struct {
+	Name        string   `json:"name"`
+	RootAgent   string   `json:"rootAgentName"`
+	Description string   `json:"description"`
+	SubAgents   []string `json:"subAgents"`
+}
+
+// FromAgent creates an App for the root agent of the app.
+func FromAgent(appName string, a agent.Agent) App {
+	subAgents := make([]string, 0, len(a.SubAgents()))
+	for _, sub := range a.SubAgents() {
+		subAgents = append(subAgents, sub.Name())
+	}
+	return App{
+		Name:        appName,
+		RootAgent:   a.Name(),
+		Description: a.Description(),
+		SubAgents:   subAgents,
+	}
+}
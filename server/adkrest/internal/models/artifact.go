@@ -0,0 +1,39 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// ArtifactVersion is the version of an uploaded artifact.
+type ArtifactVersion struct {
+	Version int64 `json:"version"`
+}
+
+// CreateArtifactUploadRequest starts a resumable upload of an artifact.
+type CreateArtifactUploadRequest struct {
+	// Size is the size of the artifact in bytes.
+	Size int64 `json:"size"`
+	// MIMEType defaults to application/octet-stream.
+	MIMEType string `json:"mimeType,omitempty"`
+}
+
+// ArtifactUpload is the status of a resumable upload of an artifact.
+type ArtifactUpload struct {
+	UploadID string `json:"uploadId"`
+	// Offset is the number of bytes received, where the next chunk starts.
+	Offset int64 `json:"offset"`
+
+	Size int64 `json:"size"`
+	// Version is set once the upload is complete.
+	Version int64 `json:"version,omitempty"`
+}
@@ -16,6 +16,8 @@ package models
 
 import (
 	"encoding/json"
+	"math"
+	"time"
 
 	"google.golang.org/genai"
 
@@ -32,47 +34,65 @@ type EventActions struct {
 	TransferToAgent   string           `json:"transferToAgent,omitempty"`
 }
 
+// Candidate represents one of the candidate responses retained on an event,
+// see model.WithCandidates.
+type Candidate struct {
+	Content      *genai.Content     `json:"content"`
+	ErrorCode    string             `json:"errorCode,omitempty"`
+	ErrorMessage string             `json:"errorMessage,omitempty"`
+	AvgLogprobs  float64            `json:"avgLogprobs,omitempty"`
+	FinishReason genai.FinishReason `json:"finishReason,omitempty"`
+}
+
 // Event represents a single event in a session.
 type Event struct {
-	ID                 string                                      `json:"id"`
-	InvocationID       string                                      `json:"invocationId"`
-	Branch             string                                      `json:"branch,omitempty"`
-	Author             string                                      `json:"author"`
-	Partial            bool                                        `json:"partial,omitempty"`
-	LongRunningToolIDs []string                                    `json:"longRunningToolIds,omitempty"`
-	Content            *genai.Content                              `json:"content"`
-	GroundingMetadata  *genai.GroundingMetadata                    `json:"groundingMetadata"`
-	UsageMetadata      *genai.GenerateContentResponseUsageMetadata `json:"usageMetadata"`
-	TurnComplete       bool                                        `json:"turnComplete,omitempty"`
-	Interrupted        bool                                        `json:"interrupted,omitempty"`
-	ErrorCode          string                                      `json:"errorCode,omitempty"`
-	ErrorMessage       string                                      `json:"errorMessage,omitempty"`
-	AvgLogprobs        float64                                     `json:"avgLogprobs,omitempty"`
-	FinishReason       genai.FinishReason                          `json:"finishReason,omitempty"`
-	ModelVersion       string                                      `json:"modelVersion,omitempty"`
-	Actions            EventActions                                `json:"actions"`
+	ID                  string                                      `json:"id"`
+	Timestamp           float64                                     `json:"timestamp,omitempty"` // seconds since the Unix epoch
+	InvocationID        string                                      `json:"invocationId"`
+	Branch              string                                      `json:"branch,omitempty"`
+	Author              string                                      `json:"author"`
+	Partial             bool                                        `json:"partial,omitempty"`
+	LongRunningToolIDs  []string                                    `json:"longRunningToolIds,omitempty"`
+	Content             *genai.Content                              `json:"content"`
+	GroundingMetadata   *genai.GroundingMetadata                    `json:"groundingMetadata"`
+	UsageMetadata       *genai.GenerateContentResponseUsageMetadata `json:"usageMetadata"`
+	TurnComplete        bool                                        `json:"turnComplete,omitempty"`
+	Interrupted         bool                                        `json:"interrupted,omitempty"`
+	InputTranscription  *genai.Transcription                        `json:"inputTranscription,omitempty"`
+	OutputTranscription *genai.Transcription                        `json:"outputTranscription,omitempty"`
+	ErrorCode           string                                      `json:"errorCode,omitempty"`
+	ErrorMessage        string                                      `json:"errorMessage,omitempty"`
+	AvgLogprobs         float64                                     `json:"avgLogprobs,omitempty"`
+	FinishReason        genai.FinishReason                          `json:"finishReason,omitempty"`
+	ModelVersion        string                                      `json:"modelVersion,omitempty"`
+	Candidates          []Candidate                                 `json:"candidates,omitempty"`
+	Actions             EventActions                                `json:"actions"`
 }
 
 // ToSessionEvent maps Event data struct to session.Event
 func ToSessionEvent(event Event) *session.Event {
 	return &session.Event{
 		ID:                 event.ID,
+		Timestamp:          FromUnixSeconds(event.Timestamp),
 		InvocationID:       event.InvocationID,
 		Branch:             event.Branch,
 		Author:             event.Author,
 		LongRunningToolIDs: event.LongRunningToolIDs,
 		LLMResponse: model.LLMResponse{
-			AvgLogprobs:       event.AvgLogprobs,
-			Content:           event.Content,
-			GroundingMetadata: event.GroundingMetadata,
-			UsageMetadata:     event.UsageMetadata,
-			Partial:           event.Partial,
-			TurnComplete:      event.TurnComplete,
-			Interrupted:       event.Interrupted,
-			ErrorCode:         event.ErrorCode,
-			ErrorMessage:      event.ErrorMessage,
-			FinishReason:      event.FinishReason,
-			ModelVersion:      event.ModelVersion,
+			AvgLogprobs:         event.AvgLogprobs,
+			Content:             event.Content,
+			GroundingMetadata:   event.GroundingMetadata,
+			UsageMetadata:       event.UsageMetadata,
+			Partial:             event.Partial,
+			TurnComplete:        event.TurnComplete,
+			Interrupted:         event.Interrupted,
+			InputTranscription:  event.InputTranscription,
+			OutputTranscription: event.OutputTranscription,
+			ErrorCode:           event.ErrorCode,
+			ErrorMessage:        event.ErrorMessage,
+			FinishReason:        event.FinishReason,
+			ModelVersion:        event.ModelVersion,
+			Candidates:          toLLMResponses(event.Candidates),
 		},
 		Actions: session.EventActions{
 			StateDelta:        event.Actions.StateDelta,
@@ -87,22 +107,26 @@ func ToSessionEvent(event Event) *session.Event {
 // FromSessionEvent maps session.Event to Event data struct
 func FromSessionEvent(event session.Event) Event {
 	return Event{
-		ID:                 event.ID,
-		InvocationID:       event.InvocationID,
-		Branch:             event.Branch,
-		Author:             event.Author,
-		Partial:            event.Partial,
-		LongRunningToolIDs: event.LongRunningToolIDs,
-		AvgLogprobs:        event.LLMResponse.AvgLogprobs,
-		Content:            event.LLMResponse.Content,
-		GroundingMetadata:  event.LLMResponse.GroundingMetadata,
-		UsageMetadata:      event.LLMResponse.UsageMetadata,
-		TurnComplete:       event.LLMResponse.TurnComplete,
-		Interrupted:        event.LLMResponse.Interrupted,
-		ErrorCode:          event.LLMResponse.ErrorCode,
-		ErrorMessage:       event.LLMResponse.ErrorMessage,
-		FinishReason:       event.LLMResponse.FinishReason,
-		ModelVersion:       event.LLMResponse.ModelVersion,
+		ID:                  event.ID,
+		Timestamp:           toUnixSeconds(event.Timestamp),
+		InvocationID:        event.InvocationID,
+		Branch:              event.Branch,
+		Author:              event.Author,
+		Partial:             event.Partial,
+		LongRunningToolIDs:  event.LongRunningToolIDs,
+		AvgLogprobs:         event.LLMResponse.AvgLogprobs,
+		Content:             event.LLMResponse.Content,
+		GroundingMetadata:   event.LLMResponse.GroundingMetadata,
+		UsageMetadata:       event.LLMResponse.UsageMetadata,
+		TurnComplete:        event.LLMResponse.TurnComplete,
+		Interrupted:         event.LLMResponse.Interrupted,
+		InputTranscription:  event.LLMResponse.InputTranscription,
+		OutputTranscription: event.LLMResponse.OutputTranscription,
+		ErrorCode:           event.LLMResponse.ErrorCode,
+		ErrorMessage:        event.LLMResponse.ErrorMessage,
+		FinishReason:        event.LLMResponse.FinishReason,
+		ModelVersion:        event.LLMResponse.ModelVersion,
+		Candidates:          fromLLMResponses(event.LLMResponse.Candidates),
 		Actions: EventActions{
 			StateDelta:        event.Actions.StateDelta,
 			ArtifactDelta:     event.Actions.ArtifactDelta,
@@ -113,6 +137,56 @@ func FromSessionEvent(event session.Event) Event {
 	}
 }
 
+func fromLLMResponses(resps []*model.LLMResponse) []Candidate {
+	if len(resps) == 0 {
+		return nil
+	}
+	candidates := make([]Candidate, len(resps))
+	for i, r := range resps {
+		candidates[i] = Candidate{
+			Content:      r.Content,
+			ErrorCode:    r.ErrorCode,
+			ErrorMessage: r.ErrorMessage,
+			AvgLogprobs:  r.AvgLogprobs,
+			FinishReason: r.FinishReason,
+		}
+	}
+	return candidates
+}
+
+func toLLMResponses(candidates []Candidate) []*model.LLMResponse {
+	if len(candidates) == 0 {
+		return nil
+	}
+	resps := make([]*model.LLMResponse, len(candidates))
+	for i, c := range candidates {
+		resps[i] = &model.LLMResponse{
+			Content:      c.Content,
+			ErrorCode:    c.ErrorCode,
+			ErrorMessage: c.ErrorMessage,
+			AvgLogprobs:  c.AvgLogprobs,
+			FinishReason: c.FinishReason,
+		}
+	}
+	return resps
+}
+
+func toUnixSeconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixMicro()) / 1e6
+}
+
+// FromUnixSeconds converts seconds since the Unix epoch, as in the timestamps
+// of the events, to a time. Zero is the zero time.
+func FromUnixSeconds(seconds float64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(math.Round(seconds * 1e6)))
+}
+
 func (e Event) MarshalJSON() ([]byte, error) {
 	// Define Proxy structs to override specific JSON tags.
 	// These embed the original types to inherit all other fields automatically.
@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+
+	"google.golang.org/adk/runner"
+)
+
+// Invocation describes an invocation running in the server.
+type Invocation struct {
+	InvocationID string `json:"invocationId"`
+	AppName      string `json:"appName"`
+	UserID       string `json:"userId"`
+	SessionID    string `json:"sessionId"`
+	AgentPath    string `json:"agentPath"`
+	CurrentStep  string `json:"currentStep"`
+	// StartTime is in seconds since the Unix epoch.
+	StartTime      float64 `json:"startTime"`
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+	LLMCalls       int     `json:"llmCalls"`
+	ToolCalls      int     `json:"toolCalls"`
+}
+
+// FromInvocationInfo converts a running invocation, with its elapsed time
+// as of now.
+func FromInvocationInfo(info runner.InvocationInfo, now time.Time) Invocation {
+	return Invocation{
+		InvocationID:   info.InvocationID,
+		AppName:        info.AppName,
+		UserID:         info.UserID,
+		SessionID:      info.SessionID,
+		AgentPath:      info.AgentPath,
+		CurrentStep:    info.Step,
+		StartTime:      toUnixSeconds(info.StartTime),
+		ElapsedSeconds: now.Sub(info.StartTime).Seconds(),
+		LLMCalls:       info.LLMCalls,
+		ToolCalls:      info.ToolCalls,
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+
+	"google.golang.org/adk/quota"
+)
+
+// Quota is the usage of the quotas of a user in an app.
+type Quota struct {
+	AppName string `json:"appName"`
+
+	UserID string `json:"userId"`
+	// Quotas without limit are omitted.
+	Quotas []QuotaStatus `json:"quotas"`
+}
+
+// QuotaStatus is the usage of a quota.
+type QuotaStatus struct {
+	Metric string `json:"metric"`
+	// Scope is "user" or "app".
+	Scope string `json:"scope"`
+
+	Limit int64 `json:"limit"`
+
+	Used int64 `json:"used"`
+
+	Remaining int64 `json:"remaining"`
+
+	ResetTime time.Time `json:"resetTime"`
+}
+
+// FromQuotaStatuses converts the quota statuses of a user in an app.
+func FromQuotaStatuses(appName, userID string, statuses []quota.Status) Quota {
+	q := Quota{AppName: appName, UserID: userID, Quotas: []QuotaStatus{}}
+	for _, s := range statuses {
+		scope := "app"
+		if s.UserID != "" {
+			scope = "user"
+		}
+		q.Quotas = append(q.Quotas, QuotaStatus{
+			Metric:    string(s.Metric),
+			Scope:     scope,
+			Limit:     s.Limit,
+			Used:      s.Used,
+			Remaining: s.Remaining,
+			ResetTime: s.ResetTime,
+		})
+	}
+	return q
+}
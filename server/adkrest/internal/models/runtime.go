@@ -15,6 +15,7 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"google.golang.org/genai"
@@ -30,6 +31,12 @@ type RunAgentRequest struct {
 	NewMessage genai.Content `json:"newMessage"`
 
 	Streaming bool `json:"streaming,omitempty"`
+	// StreamGranularity is the granularity of the partial events when
+	// streaming: "chunk", the default, "sentence" or "paragraph".
+	StreamGranularity string `json:"streamGranularity,omitempty"`
+	// GenerationProfile is the name of the generation profile applied to
+	// the model requests of the run, see agent.RunConfig.GenerationProfile.
+	GenerationProfile string `json:"generationProfile,omitempty"`
 
 	StateDelta *map[string]any `json:"stateDelta,omitempty"`
 }
@@ -47,6 +54,59 @@ func (req RunAgentRequest) AssertRunAgentRequestRequired() error {
 			return fmt.Errorf("%s is required", name)
 		}
 	}
+	switch req.StreamGranularity {
+	case "", "chunk", "sentence", "paragraph":
+	default:
+		return fmt.Errorf("unknown streamGranularity %q", req.StreamGranularity)
+	}
 
 	return nil
 }
+
+// LiveRequest is a message sent by the client of a live run.
+type LiveRequest struct {
+	// Content is a turn of the user.
+	Content *genai.Content `json:"content,omitempty"`
+	// Blob is a chunk of realtime input, e.g. audio.
+	Blob *genai.Blob `json:"blob,omitempty"`
+
+	ActivityStart bool `json:"activityStart,omitempty"`
+
+	ActivityEnd bool `json:"activityEnd,omitempty"`
+	// Close ends the live run.
+	Close bool `json:"close,omitempty"`
+}
+
+// UnmarshalJSON decodes the request, accepting the mime_type of the blob as
+// sent by the Web UI.
+func (r *LiveRequest) UnmarshalJSON(data []byte) error {
+	type alias LiveRequest
+	aux := struct {
+		*alias
+		Blob *struct {
+			genai.Blob
+			SnakeMIMEType string `json:"mime_type"`
+		} `json:"blob,omitempty"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Blob != nil {
+		r.Blob = &aux.Blob.Blob
+		if r.Blob.MIMEType == "" {
+			r.Blob.MIMEType = aux.Blob.SnakeMIMEType
+		}
+	}
+	return nil
+}
+
+// Job is the status of an agent run started in the background.
+type Job struct {
+	ID string `json:"id"`
+
+	Status string `json:"status"`
+
+	Error string `json:"error,omitempty"`
+	// InstanceID is the instance of the server running the job.
+	InstanceID string `json:"instanceId,omitempty"`
+}
@@ -38,6 +38,20 @@ type CreateSessionRequest struct {
 	Events []Event        `json:"events"`
 }
 
+// UpdateSessionRequest is the request body of the update session API.
+type UpdateSessionRequest struct {
+	StateDelta map[string]any `json:"stateDelta"`
+}
+
+// ForkSessionRequest is the request body of the fork session API.
+type ForkSessionRequest struct {
+	// EventID is the ID of the event the fork starts at. If empty, all the
+	// events are copied.
+	EventID string `json:"eventId,omitempty"`
+	// SessionID is the ID of the new session. If empty, it is generated.
+	SessionID string `json:"sessionId,omitempty"`
+}
+
 type SessionID struct {
 	ID      string `mapstructure:"session_id,optional"`
 	AppName string `mapstructure:"app_name,required"`
@@ -18,6 +18,7 @@ import (
 	"net/http"
 
 	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
 )
 
 // AppsAPIRouter defines the routes for the Apps API.
@@ -38,6 +39,16 @@ func (r *AppsAPIRouter) Routes() Routes {
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/list-apps",
 			HandlerFunc: r.appsController.ListAppsHandler,
+			Summary:     "Lists the names of the apps.",
+			Response:    []string{},
+		},
+		Route{
+			Name:        "GetApp",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/apps/{app_name}",
+			HandlerFunc: controllers.NewErrorHandler(r.appsController.GetAppHandler),
+			Summary:     "Describes an app and its root agent.",
+			Response:    models.App{},
 		},
 	}
 }
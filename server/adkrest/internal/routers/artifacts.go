@@ -17,7 +17,10 @@ package routers
 import (
 	"net/http"
 
+	"google.golang.org/genai"
+
 	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
 )
 
 // ArtifactsAPIRouter defines the routes for the Artifacts API.
@@ -38,24 +41,90 @@ func (r *ArtifactsAPIRouter) Routes() Routes {
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts",
 			HandlerFunc: r.artifactsController.ListArtifactsHandler,
+			Summary:     "Lists the artifact names of a session.",
+			Response:    []string{},
 		},
 		Route{
 			Name:        "LoadArtifact",
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}",
 			HandlerFunc: r.artifactsController.LoadArtifactHandler,
+			Summary:     "Returns the latest or the given version of an artifact.",
+			Query:       []string{"version"},
+			Response:    genai.Part{},
+		},
+		Route{
+			Name:        "ListArtifactVersions",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}/versions",
+			HandlerFunc: r.artifactsController.ListArtifactVersionsHandler,
+			Summary:     "Lists the versions of an artifact.",
+			Response:    []int64{},
+		},
+		Route{
+			Name:        "DownloadArtifact",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}/content",
+			HandlerFunc: r.artifactsController.DownloadArtifactHandler,
+			Summary:     "Downloads the content of an artifact, or the ranges of the Range header.",
+			Query:       []string{"version"},
+		},
+		Route{
+			Name:        "UploadArtifact",
+			Methods:     []string{http.MethodPost},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}/content",
+			HandlerFunc: r.artifactsController.UploadArtifactHandler,
+			Summary:     "Saves the body as a new version of an artifact, with the Content-Type as MIME type.",
+			Response:    models.ArtifactVersion{},
+			Status:      http.StatusCreated,
+		},
+		Route{
+			Name:        "CreateArtifactUpload",
+			Methods:     []string{http.MethodPost},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}/uploads",
+			HandlerFunc: r.artifactsController.CreateArtifactUploadHandler,
+			Summary:     "Starts a resumable upload of an artifact.",
+			Request:     models.CreateArtifactUploadRequest{},
+			Response:    models.ArtifactUpload{},
+			Status:      http.StatusCreated,
+		},
+		Route{
+			Name:        "ArtifactUploadChunk",
+			Methods:     []string{http.MethodPut},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}/uploads/{upload_id}",
+			HandlerFunc: r.artifactsController.ArtifactUploadChunkHandler,
+			Summary:     "Receives the chunk of a resumable upload given by the Content-Range header.",
+			Response:    models.ArtifactUpload{},
+		},
+		Route{
+			Name:        "GetArtifactUpload",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}/uploads/{upload_id}",
+			HandlerFunc: r.artifactsController.GetArtifactUploadHandler,
+			Summary:     "Returns the status of a resumable upload.",
+			Response:    models.ArtifactUpload{},
+		},
+		Route{
+			Name:        "CancelArtifactUpload",
+			Methods:     []string{http.MethodDelete},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}/uploads/{upload_id}",
+			HandlerFunc: r.artifactsController.CancelArtifactUploadHandler,
+			Summary:     "Discards a resumable upload.",
 		},
 		Route{
 			Name:        "LoadArtifactVersion",
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}/versions/{version}",
 			HandlerFunc: r.artifactsController.LoadArtifactVersionHandler,
+			Summary:     "Returns a version of an artifact.",
+			Response:    genai.Part{},
 		},
 		Route{
 			Name:        "DeleteArtifact",
 			Methods:     []string{http.MethodDelete, http.MethodOptions},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/artifacts/{artifact_name}",
 			HandlerFunc: r.artifactsController.DeleteArtifactHandler,
+			Summary:     "Deletes all the versions of an artifact.",
 		},
 	}
 }
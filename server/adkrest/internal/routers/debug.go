@@ -18,6 +18,7 @@ import (
 	"net/http"
 
 	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
 )
 
 // DebugAPIRouter defines the routes for the Debug API.
@@ -38,19 +39,61 @@ func (r *DebugAPIRouter) Routes() Routes {
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/debug/trace/{event_id}",
 			HandlerFunc: r.runtimeController.EventSpanHandler,
+			Summary:     "Returns the debug span of an event.",
+			Response:    map[string]any{},
+		},
+		Route{
+			Name:        "GetEventModelCall",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/debug/model_call/{event_id}",
+			HandlerFunc: r.runtimeController.EventModelCallHandler,
+			Summary:     "Returns the model request and raw responses of an event.",
 		},
 		Route{
 			Name:        "GetEventGraph",
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/events/{event_id}/graph",
 			HandlerFunc: r.runtimeController.EventGraphHandler,
+			Summary:     "Returns the agent graph of an event in DOT format.",
+			Response:    map[string]string{},
 		},
 
+		Route{
+			Name:        "GetInvocationTraceTree",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/invocations/{invocation_id}/trace",
+			HandlerFunc: r.runtimeController.InvocationTraceHandler,
+			Summary:     "Returns the trace tree of an invocation.",
+		},
+		Route{
+			Name:        "GetSessionTraceTrees",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/traces",
+			HandlerFunc: r.runtimeController.SessionTracesHandler,
+			Summary:     "Returns the trace trees of the invocations of a session.",
+		},
+		Route{
+			Name:        "ListInvocations",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/debug/invocations",
+			HandlerFunc: r.runtimeController.ListInvocationsHandler,
+			Summary:     "Lists the running invocations.",
+			Response:    []models.Invocation{},
+		},
+		Route{
+			Name:        "CancelInvocation",
+			Methods:     []string{http.MethodPost, http.MethodOptions},
+			Pattern:     "/debug/invocations/{invocation_id}/cancel",
+			HandlerFunc: r.runtimeController.CancelInvocationHandler,
+			Summary:     "Cancels a running invocation.",
+			Status:      http.StatusAccepted,
+		},
 		Route{
 			Name:        "GetSessionTrace",
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/debug/trace/session/{session_id}",
 			HandlerFunc: r.runtimeController.SessionSpansHandler,
+			Summary:     "Returns the debug spans of a session.",
 		},
 	}
 }
@@ -17,13 +17,21 @@ package routers
 import (
 	"net/http"
 
+	"google.golang.org/adk/eval"
 	"google.golang.org/adk/server/adkrest/controllers"
 )
 
 // EvalAPIRouter defines the routes for the Eval API.
-type EvalAPIRouter struct{}
+type EvalAPIRouter struct {
+	evalController *controllers.EvalAPIController
+}
+
+// NewEvalAPIRouter creates a new EvalAPIRouter.
+func NewEvalAPIRouter(controller *controllers.EvalAPIController) *EvalAPIRouter {
+	return &EvalAPIRouter{evalController: controller}
+}
 
-// Routes returns the routes for the Apps API.
+// Routes returns the routes for the Eval API.
 func (r *EvalAPIRouter) Routes() Routes {
 	return Routes{
 		Route{
@@ -31,18 +39,30 @@ func (r *EvalAPIRouter) Routes() Routes {
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/apps/{app_name}/eval_sets",
 			HandlerFunc: controllers.Unimplemented,
+			Summary:     "Lists the eval sets of an app.",
 		},
 		Route{
-			Name:        "ListEvalSets",
+			Name:        "CreateEvalSet",
 			Methods:     []string{http.MethodPost, http.MethodOptions},
 			Pattern:     "/apps/{app_name}/eval_sets/{eval_set_name}",
 			HandlerFunc: controllers.Unimplemented,
+			Summary:     "Creates an eval set.",
 		},
 		Route{
 			Name:        "ListEvalResults",
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/apps/{app_name}/eval_results",
 			HandlerFunc: controllers.Unimplemented,
+			Summary:     "Lists the eval results of an app.",
+		},
+		Route{
+			Name:        "RecordEvalCase",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/eval_case",
+			HandlerFunc: r.evalController.RecordEvalCaseHandler,
+			Summary:     "Returns an eval case recorded from a session.",
+			Query:       []string{"eval_id"},
+			Response:    eval.EvalCase{},
 		},
 	}
 }
@@ -0,0 +1,206 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// OpenAPIRouter serves the OpenAPI 3.1 document describing the routes of
+// the other routers.
+type OpenAPIRouter struct {
+	document func() ([]byte, error)
+}
+
+// NewOpenAPIRouter creates a new OpenAPIRouter documenting the subrouters.
+// The document is generated on the first request.
+func NewOpenAPIRouter(subrouters ...Router) *OpenAPIRouter {
+	return &OpenAPIRouter{document: sync.OnceValues(func() ([]byte, error) {
+		return OpenAPI(subrouters...)
+	})}
+}
+
+// Routes returns the routes for the OpenAPI document.
+func (r *OpenAPIRouter) Routes() Routes {
+	return Routes{
+		Route{
+			Name:        "GetOpenAPI",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/openapi.json",
+			HandlerFunc: r.openAPIHandler,
+		},
+	}
+}
+
+func (r *OpenAPIRouter) openAPIHandler(rw http.ResponseWriter, req *http.Request) {
+	doc, err := r.document()
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	_, _ = rw.Write(doc)
+}
+
+// pathParameterRE matches the variables of mux patterns, e.g. {name} or
+// {name:[0-9]+}.
+var pathParameterRE = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// OpenAPI returns the OpenAPI 3.1 document of the routes of the subrouters.
+// The request and response schemas are inferred from the Request and
+// Response values of the routes.
+func OpenAPI(subrouters ...Router) ([]byte, error) {
+	b := &openAPIBuilder{
+		schemas: make(map[string]any),
+		types:   make(map[reflect.Type]string),
+	}
+	paths := make(map[string]map[string]any)
+	for _, api := range subrouters {
+		for _, route := range api.Routes() {
+			p := pathParameterRE.ReplaceAllString(route.Pattern, "{$1}")
+			if paths[p] == nil {
+				paths[p] = make(map[string]any)
+			}
+			for _, method := range route.Methods {
+				if method == http.MethodOptions {
+					continue
+				}
+				op, err := b.operation(route, method)
+				if err != nil {
+					return nil, fmt.Errorf("route %s: %w", route.Name, err)
+				}
+				paths[p][strings.ToLower(method)] = op
+			}
+		}
+	}
+	return json.MarshalIndent(map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   "ADK REST API",
+			"version": "1.0.0",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": b.schemas},
+	}, "", "  ")
+}
+
+type openAPIBuilder struct {
+	// schemas are the component schemas by name, types the component names
+	// by type.
+	schemas map[string]any
+	types   map[reflect.Type]string
+}
+
+func (b *openAPIBuilder) operation(route Route, method string) (map[string]any, error) {
+	op := map[string]any{"operationId": route.Name}
+	if slices.ContainsFunc(route.Methods, func(m string) bool { return m != method && m != http.MethodOptions }) {
+		// Operation IDs must be unique.
+		op["operationId"] = route.Name + strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+	}
+	if route.Summary != "" {
+		op["summary"] = route.Summary
+	}
+	var params []any
+	for _, m := range pathParameterRE.FindAllStringSubmatch(route.Pattern, -1) {
+		params = append(params, map[string]any{
+			"name":     m[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]any{"type": "string"},
+		})
+	}
+	for _, name := range route.Query {
+		params = append(params, map[string]any{
+			"name":   name,
+			"in":     "query",
+			"schema": map[string]any{"type": "string"},
+		})
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if route.Request != nil {
+		schema, err := b.schema(reflect.TypeOf(route.Request))
+		if err != nil {
+			return nil, err
+		}
+		op["requestBody"] = map[string]any{
+			"content": map[string]any{"application/json": map[string]any{"schema": schema}},
+		}
+	}
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := map[string]any{"description": http.StatusText(status)}
+	if route.Response != nil {
+		schema, err := b.schema(reflect.TypeOf(route.Response))
+		if err != nil {
+			return nil, err
+		}
+		response["content"] = map[string]any{"application/json": map[string]any{"schema": schema}}
+	}
+	op["responses"] = map[string]any{
+		strconv.Itoa(status): response,
+		"default": map[string]any{
+			"description": "The error message.",
+			"content":     map[string]any{"text/plain": map[string]any{"schema": map[string]any{"type": "string"}}},
+		},
+	}
+	return op, nil
+}
+
+// schema returns the schema of t. Named struct types are added to the
+// component schemas and referenced.
+func (b *openAPIBuilder) schema(t reflect.Type) (any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		items, err := b.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case t.Kind() != reflect.Struct || t.Name() == "":
+		return jsonschema.ForType(t, &jsonschema.ForOptions{IgnoreInvalidTypes: true})
+	}
+	name, ok := b.types[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := b.schemas[name]; taken {
+			name = path.Base(t.PkgPath()) + t.Name()
+		}
+		schema, err := jsonschema.ForType(t, &jsonschema.ForOptions{IgnoreInvalidTypes: true})
+		if err != nil {
+			return nil, err
+		}
+		b.types[t] = name
+		b.schemas[name] = schema
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + name}, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func allRouters() []Router {
+	// The routes are only documented, the handlers are not called.
+	return []Router{
+		NewSessionsAPIRouter(nil),
+		NewRuntimeAPIRouter(nil),
+		NewAppsAPIRouter(nil),
+		NewDebugAPIRouter(nil),
+		NewArtifactsAPIRouter(nil),
+		NewEvalAPIRouter(nil),
+	}
+}
+
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Paths   map[string]map[string]struct {
+		OperationID string `json:"operationId"`
+		Parameters  []struct {
+			Name string `json:"name"`
+			In   string `json:"in"`
+		} `json:"parameters"`
+		RequestBody map[string]any            `json:"requestBody"`
+		Responses   map[string]map[string]any `json:"responses"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]any `json:"schemas"`
+	} `json:"components"`
+}
+
+func TestOpenAPI(t *testing.T) {
+	data, err := OpenAPI(allRouters()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc openAPIDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("openapi = %q, want 3.1.0", doc.OpenAPI)
+	}
+
+	operationIDs := make(map[string]bool)
+	for _, api := range allRouters() {
+		for _, route := range api.Routes() {
+			item, ok := doc.Paths[route.Pattern]
+			if !ok {
+				t.Errorf("route %s: path %s is not documented", route.Name, route.Pattern)
+				continue
+			}
+			for _, method := range route.Methods {
+				if method == http.MethodOptions {
+					continue
+				}
+				op, ok := item[strings.ToLower(method)]
+				if !ok {
+					t.Errorf("route %s: operation %s %s is not documented", route.Name, method, route.Pattern)
+					continue
+				}
+				if operationIDs[op.OperationID] {
+					t.Errorf("duplicate operationId %q", op.OperationID)
+				}
+				operationIDs[op.OperationID] = true
+			}
+		}
+	}
+
+	session := doc.Paths["/apps/{app_name}/users/{user_id}/sessions/{session_id}"]["get"]
+	var params []string
+	for _, p := range session.Parameters {
+		params = append(params, p.In+":"+p.Name)
+	}
+	if got, want := strings.Join(params, ","), "path:app_name,path:user_id,path:session_id,query:num_recent_events,query:after"; got != want {
+		t.Errorf("GetSession parameters = %s, want %s", got, want)
+	}
+	if got := session.Responses["200"]["content"]; !strings.Contains(toJSON(t, got), `"$ref":"#/components/schemas/Session"`) {
+		t.Errorf("GetSession response = %s, want a reference to the Session schema", toJSON(t, got))
+	}
+	if got := doc.Paths["/run_async"]["post"].Responses; got["202"] == nil {
+		t.Errorf("RunAgentAsync responses = %v, want 202", got)
+	}
+	for _, name := range []string{"Session", "Event", "RunAgentRequest", "Job", "Part"} {
+		if doc.Components.Schemas[name] == nil {
+			t.Errorf("schema %s is missing", name)
+		}
+	}
+	// All the references resolve.
+	for _, ref := range strings.Split(string(data), `"$ref": "#/components/schemas/`)[1:] {
+		name := ref[:strings.IndexByte(ref, '"')]
+		if doc.Components.Schemas[name] == nil {
+			t.Errorf("reference to unknown schema %s", name)
+		}
+	}
+}
+
+func TestOpenAPIRouter(t *testing.T) {
+	router := mux.NewRouter()
+	SetupSubRouters(router, NewOpenAPIRouter(allRouters()...))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rr.Code, http.StatusOK, rr.Body)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	var doc openAPIDocument
+	if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc.Paths["/run"]["post"]; !ok {
+		t.Errorf("paths = %v, want the /run operation", doc.Paths)
+	}
+}
+
+func toJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
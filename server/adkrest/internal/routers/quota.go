@@ -0,0 +1,46 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routers
+
+import (
+	"net/http"
+
+	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
+)
+
+// QuotaAPIRouter defines the routes for the Quota API.
+type QuotaAPIRouter struct {
+	quotaController *controllers.QuotaAPIController
+}
+
+// NewQuotaAPIRouter creates a new QuotaAPIRouter.
+func NewQuotaAPIRouter(controller *controllers.QuotaAPIController) *QuotaAPIRouter {
+	return &QuotaAPIRouter{quotaController: controller}
+}
+
+// Routes returns the routes for the Quota API.
+func (r *QuotaAPIRouter) Routes() Routes {
+	return Routes{
+		Route{
+			Name:        "GetQuota",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/apps/{app_name}/users/{user_id}/quota",
+			HandlerFunc: controllers.NewErrorHandler(r.quotaController.GetQuotaHandler),
+			Summary:     "Returns the usage and the remaining quota of a user in an app.",
+			Response:    models.Quota{},
+		},
+	}
+}
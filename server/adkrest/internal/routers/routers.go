@@ -27,6 +27,16 @@ type Route struct {
 	Methods     []string
 	Pattern     string
 	HandlerFunc http.HandlerFunc
+
+	// Summary, Query, Request, Response and Status document the endpoint in
+	// the OpenAPI document. Request and Response are values of the types of
+	// the JSON request and response bodies, Query the names of the query
+	// parameters. Status defaults to http.StatusOK.
+	Summary  string
+	Query    []string
+	Request  any
+	Response any
+	Status   int
 }
 
 // Routes is a list of defined api endpoints
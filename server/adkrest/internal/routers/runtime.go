@@ -18,6 +18,7 @@ import (
 	"net/http"
 
 	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
 )
 
 // RuntimeAPIRouter defines the routes for the Runtime API.
@@ -38,12 +39,52 @@ func (r *RuntimeAPIRouter) Routes() Routes {
 			Methods:     []string{http.MethodPost, http.MethodOptions},
 			Pattern:     "/run",
 			HandlerFunc: controllers.NewErrorHandler(r.runtimeController.RunHandler),
+			Summary:     "Runs an agent and returns all the events of the run.",
+			Request:     models.RunAgentRequest{},
+			Response:    []models.Event{},
 		},
 		Route{
 			Name:        "RunAgentSse",
 			Methods:     []string{http.MethodPost, http.MethodOptions},
 			Pattern:     "/run_sse",
 			HandlerFunc: controllers.NewErrorHandler(r.runtimeController.RunSSEHandler),
+			Summary:     "Runs an agent and streams the events as server-sent events.",
+			Request:     models.RunAgentRequest{},
+		},
+		Route{
+			Name:        "RunAgentLive",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/run_live",
+			HandlerFunc: controllers.NewErrorHandler(r.runtimeController.RunLiveHandler),
+			Summary:     "Runs an agent live over a WebSocket connection.",
+			Query:       []string{"app_name", "user_id", "session_id", "modalities"},
+		},
+		Route{
+			Name:        "RunAgentAsync",
+			Methods:     []string{http.MethodPost, http.MethodOptions},
+			Pattern:     "/run_async",
+			HandlerFunc: controllers.NewErrorHandler(r.runtimeController.RunAsyncHandler),
+			Summary:     "Starts a run of an agent in the background.",
+			Request:     models.RunAgentRequest{},
+			Response:    models.Job{},
+			Status:      http.StatusAccepted,
+		},
+		Route{
+			Name:        "GetJob",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/jobs/{job_id}",
+			HandlerFunc: controllers.NewErrorHandler(r.runtimeController.GetJobHandler),
+			Summary:     "Returns the status of a background run.",
+			Response:    models.Job{},
+		},
+		Route{
+			Name:        "CancelJob",
+			Methods:     []string{http.MethodPost, http.MethodOptions},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/jobs/{job_id}/cancel",
+			HandlerFunc: controllers.NewErrorHandler(r.runtimeController.CancelJobHandler),
+			Summary:     "Cancels a background run.",
+			Response:    models.Job{},
+			Status:      http.StatusAccepted,
 		},
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"net/http"
 
 	"google.golang.org/adk/server/adkrest/controllers"
+	"google.golang.org/adk/server/adkrest/internal/models"
 )
 
 // SessionsAPIRouter defines the routes for the Sessions API.
@@ -38,30 +39,60 @@ func (r *SessionsAPIRouter) Routes() Routes {
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}",
 			HandlerFunc: r.sessionController.GetSessionHandler,
+			Summary:     "Returns a session with its events.",
+			Query:       []string{"num_recent_events", "after"},
+			Response:    models.Session{},
 		},
 		Route{
 			Name:        "CreateSession",
 			Methods:     []string{http.MethodPost},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions",
 			HandlerFunc: r.sessionController.CreateSessionHandler,
+			Summary:     "Creates a session with a generated ID.",
+			Request:     models.CreateSessionRequest{},
+			Response:    models.Session{},
 		},
 		Route{
 			Name:        "CreateSessionWithId",
 			Methods:     []string{http.MethodPost},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}",
 			HandlerFunc: r.sessionController.CreateSessionHandler,
+			Summary:     "Creates a session with the given ID.",
+			Request:     models.CreateSessionRequest{},
+			Response:    models.Session{},
+		},
+		Route{
+			Name:        "UpdateSession",
+			Methods:     []string{http.MethodPatch, http.MethodOptions},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}",
+			HandlerFunc: r.sessionController.UpdateSessionHandler,
+			Summary:     "Applies a state delta to a session.",
+			Request:     models.UpdateSessionRequest{},
+			Response:    models.Session{},
+		},
+		Route{
+			Name:        "ForkSession",
+			Methods:     []string{http.MethodPost, http.MethodOptions},
+			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}/fork",
+			HandlerFunc: r.sessionController.ForkSessionHandler,
+			Summary:     "Creates a session with the events of a session before an event.",
+			Request:     models.ForkSessionRequest{},
+			Response:    models.Session{},
 		},
 		Route{
 			Name:        "DeleteSession",
 			Methods:     []string{http.MethodDelete, http.MethodOptions},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions/{session_id}",
 			HandlerFunc: r.sessionController.DeleteSessionHandler,
+			Summary:     "Deletes a session.",
 		},
 		Route{
 			Name:        "ListSessions",
 			Methods:     []string{http.MethodGet},
 			Pattern:     "/apps/{app_name}/users/{user_id}/sessions",
 			HandlerFunc: r.sessionController.ListSessionsHandler,
+			Summary:     "Lists the sessions of a user.",
+			Response:    []models.Session{},
 		},
 	}
 }
@@ -26,23 +26,39 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.36.0"
 	"go.opentelemetry.io/otel/trace"
 
+	"google.golang.org/adk/internal/modelcalls"
 	"google.golang.org/adk/internal/telemetry"
 )
 
 const eventIDKey = "gcp.vertex.agent.event_id"
 
-// DebugTelemetry stores the in memory spans and logs, grouped by session and event.
+// DebugTelemetry stores the in memory spans and logs, grouped by session and
+// event, and the last model calls.
 type DebugTelemetry struct {
-	store *spanStore
+	store      *spanStore
+	modelCalls *modelcalls.Store
 }
 
 // NewDebugTelemetry returns a new DebugTelemetry instance.
 func NewDebugTelemetry() *DebugTelemetry {
 	return &DebugTelemetry{
-		store: newSpanStore(),
+		store:      newSpanStore(),
+		modelCalls: modelcalls.NewStore(modelcalls.DefaultCapacity),
 	}
 }
 
+// RecordModelCalls returns the context recording the model calls of the
+// invocations run with it, see GetModelCallByEventID.
+func (d *DebugTelemetry) RecordModelCalls(ctx context.Context) context.Context {
+	return modelcalls.ToContext(ctx, d.modelCalls)
+}
+
+// GetModelCallByEventID returns the model call whose response the event was
+// built from, if it is among the last recorded calls.
+func (d *DebugTelemetry) GetModelCallByEventID(eventID string) (*modelcalls.Call, bool) {
+	return d.modelCalls.ByEventID(eventID)
+}
+
 func (d *DebugTelemetry) SpanProcessor() sdktrace.SpanProcessor {
 	return sdktrace.NewBatchSpanProcessor(d.store)
 }
@@ -113,6 +129,8 @@ type spanStore struct {
 	recordsBySpanID map[string]*spanRecord
 	// traceIDsBySessionID stores trace ids indexed by session id for easy lookup.
 	traceIDsBySessionID map[string]map[string]struct{}
+	// traceIDsByInvocationID stores trace ids indexed by invocation id for easy lookup.
+	traceIDsByInvocationID map[string]map[string]struct{}
 	// recordsByEventID stores spans indexed by event id for easy lookup.
 	recordsByEventID map[string][]*spanRecord
 	// recordsByTraceID stores spans indexed by trace id for easy lookup.
@@ -121,10 +139,11 @@ type spanStore struct {
 
 func newSpanStore() *spanStore {
 	return &spanStore{
-		recordsBySpanID:     make(map[string]*spanRecord),
-		traceIDsBySessionID: make(map[string]map[string]struct{}),
-		recordsByEventID:    make(map[string][]*spanRecord),
-		recordsByTraceID:    make(map[string][]*spanRecord),
+		recordsBySpanID:        make(map[string]*spanRecord),
+		traceIDsBySessionID:    make(map[string]map[string]struct{}),
+		traceIDsByInvocationID: make(map[string]map[string]struct{}),
+		recordsByEventID:       make(map[string][]*spanRecord),
+		recordsByTraceID:       make(map[string][]*spanRecord),
 	}
 }
 
@@ -246,6 +265,15 @@ func (s *spanStore) updateSpanIndexes(span *inMemorySpan, record *spanRecord) {
 		traceID := span.Context.TraceID().String()
 		traces[traceID] = struct{}{}
 	}
+	// Update invocation id -> trace id mapping.
+	if invocationID, ok := span.Attributes[invocationIDKey]; ok {
+		traces, ok := s.traceIDsByInvocationID[invocationID]
+		if !ok {
+			traces = make(map[string]struct{})
+			s.traceIDsByInvocationID[invocationID] = traces
+		}
+		traces[span.Context.TraceID().String()] = struct{}{}
+	}
 	// Update event id -> span id mapping.
 	if eventID, ok := span.Attributes[eventIDKey]; ok {
 		s.recordsByEventID[eventID] = append(s.recordsByEventID[eventID], record)
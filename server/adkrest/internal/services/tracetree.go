@@ -0,0 +1,218 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.36.0"
+
+	"google.golang.org/adk/session"
+)
+
+const (
+	invocationIDKey = "gcp.vertex.agent.invocation_id"
+	toolCallArgsKey = "gcp.vertex.agent.tool_call_args"
+	toolResponseKey = "gcp.vertex.agent.tool_response"
+	llmResponseKey  = "gcp.vertex.agent.llm_response"
+	authorKey       = "gcp.vertex.agent.author"
+	errorKey        = "gcp.vertex.agent.error"
+)
+
+// Sources of the trace trees.
+const (
+	TraceSourceTelemetry = "telemetry"
+	TraceSourceEvents    = "events"
+)
+
+// TraceNode is a span of a trace tree, with its child spans.
+type TraceNode struct {
+	DebugSpan
+	Children []*TraceNode `json:"children"`
+}
+
+// InvocationTrace is the trace tree of an invocation.
+type InvocationTrace struct {
+	InvocationID string `json:"invocation_id"`
+	// Source is TraceSourceTelemetry if the tree was built from the recorded
+	// spans, or TraceSourceEvents if it was reconstructed from the event log.
+	Source string       `json:"source"`
+	Roots  []*TraceNode `json:"roots"`
+}
+
+// GetSpansByInvocationID returns the spans of the traces the invocation was
+// recorded in.
+func (d *DebugTelemetry) GetSpansByInvocationID(invocationID string) []DebugSpan {
+	return d.store.getSpansByInvocationID(invocationID)
+}
+
+func (s *spanStore) getSpansByInvocationID(invocationID string) []DebugSpan {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var records []*spanRecord
+	for traceID := range s.traceIDsByInvocationID[invocationID] {
+		records = append(records, s.recordsByTraceID[traceID]...)
+	}
+	return convertRecords(records)
+}
+
+// InvocationTraceFromSpans builds the trace tree of the invocation from its
+// spans. The roots are the outermost spans of the invocation, e.g. the
+// invocation span. It returns nil if there is no span of the invocation.
+func InvocationTraceFromSpans(invocationID string, spans []DebugSpan) *InvocationTrace {
+	nodes := make(map[string]*TraceNode, len(spans))
+	for _, span := range spans {
+		nodes[span.SpanID] = &TraceNode{DebugSpan: span, Children: []*TraceNode{}}
+	}
+	// The spans are sorted by start time, so are the children.
+	var roots []*TraceNode
+	for _, span := range spans {
+		node := nodes[span.SpanID]
+		parent, ok := nodes[span.ParentSpanID]
+		if ok {
+			parent.Children = append(parent.Children, node)
+		}
+		if node.Attributes[invocationIDKey] == invocationID && (!ok || parent.Attributes[invocationIDKey] != invocationID) {
+			roots = append(roots, node)
+		}
+	}
+	if len(roots) == 0 {
+		return nil
+	}
+	return &InvocationTrace{InvocationID: invocationID, Source: TraceSourceTelemetry, Roots: roots}
+}
+
+// InvocationTraceFromEvents reconstructs the trace tree of the invocation from
+// the event log, for invocations without recorded spans. The tree has a root
+// span for the invocation, with a generate_content span per model response
+// and an execute_tool span per tool call. The spans start and end at the
+// timestamps of the events, so the model latency is not known.
+// It returns nil if there is no event of the invocation.
+func InvocationTraceFromEvents(invocationID string, events session.Events) *InvocationTrace {
+	root := &TraceNode{
+		DebugSpan: DebugSpan{
+			Name:       "invocation",
+			SpanID:     invocationID,
+			Attributes: map[string]string{invocationIDKey: invocationID},
+			Logs:       []DebugLog{},
+		},
+		Children: []*TraceNode{},
+	}
+	toolCalls := make(map[string]*TraceNode)
+	found := false
+	for event := range events.All() {
+		if event.InvocationID != invocationID {
+			continue
+		}
+		ts := event.Timestamp.UnixNano()
+		if !found {
+			root.StartTime = ts
+			found = true
+		}
+		root.EndTime = ts
+		if event.Author == "user" || event.Content == nil {
+			if event.ErrorCode != "" {
+				root.Attributes[errorKey] = event.ErrorCode + ": " + event.ErrorMessage
+			}
+			continue
+		}
+
+		var responses []*TraceNode
+		for _, part := range event.Content.Parts {
+			if fr := part.FunctionResponse; fr != nil {
+				if node, ok := toolCalls[fr.ID]; ok {
+					node.EndTime = ts
+					node.Attributes[toolResponseKey] = serialize(fr.Response)
+					continue
+				}
+				responses = append(responses, newEventNode(event, "execute_tool "+fr.Name, map[string]string{
+					string(semconv.GenAIToolNameKey): fr.Name,
+					toolResponseKey:                  serialize(fr.Response),
+				}))
+			}
+		}
+		root.Children = append(root.Children, responses...)
+		if hasOnlyFunctionResponses(event) {
+			continue
+		}
+
+		attrs := map[string]string{
+			authorKey:      event.Author,
+			llmResponseKey: serialize(event.Content),
+		}
+		if event.UsageMetadata != nil {
+			attrs[string(semconv.GenAIUsageInputTokensKey)] = strconv.Itoa(int(event.UsageMetadata.PromptTokenCount))
+			attrs[string(semconv.GenAIUsageOutputTokensKey)] = strconv.Itoa(int(event.UsageMetadata.CandidatesTokenCount))
+		}
+		if event.ErrorCode != "" {
+			attrs[errorKey] = event.ErrorCode + ": " + event.ErrorMessage
+		}
+		llmNode := newEventNode(event, "generate_content "+event.Author, attrs)
+		for _, part := range event.Content.Parts {
+			if fc := part.FunctionCall; fc != nil {
+				toolNode := newEventNode(event, "execute_tool "+fc.Name, map[string]string{
+					string(semconv.GenAIToolNameKey): fc.Name,
+					toolCallArgsKey:                  serialize(fc.Args),
+				})
+				toolNode.SpanID = fc.ID
+				toolNode.ParentSpanID = llmNode.SpanID
+				toolCalls[fc.ID] = toolNode
+				llmNode.Children = append(llmNode.Children, toolNode)
+			}
+		}
+		root.Children = append(root.Children, llmNode)
+	}
+	if !found {
+		return nil
+	}
+	return &InvocationTrace{InvocationID: invocationID, Source: TraceSourceEvents, Roots: []*TraceNode{root}}
+}
+
+func newEventNode(event *session.Event, name string, attrs map[string]string) *TraceNode {
+	attrs[invocationIDKey] = event.InvocationID
+	attrs[eventIDKey] = event.ID
+	ts := event.Timestamp.UnixNano()
+	return &TraceNode{
+		DebugSpan: DebugSpan{
+			Name:         name,
+			StartTime:    ts,
+			EndTime:      ts,
+			SpanID:       event.ID,
+			ParentSpanID: event.InvocationID,
+			Attributes:   attrs,
+			Logs:         []DebugLog{},
+		},
+		Children: []*TraceNode{},
+	}
+}
+
+func hasOnlyFunctionResponses(event *session.Event) bool {
+	for _, part := range event.Content.Parts {
+		if part.FunctionResponse == nil {
+			return false
+		}
+	}
+	return len(event.Content.Parts) > 0
+}
+
+func serialize(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("<not serializable: %v>", err)
+	}
+	return string(b)
+}
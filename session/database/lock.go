@@ -0,0 +1,182 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/session"
+)
+
+// storageSessionLock corresponds to the 'session_locks' table: a lease on a
+// session held by a runner, see NewSessionLocker.
+type storageSessionLock struct {
+	AppName    string `gorm:"primaryKey;"`
+	UserID     string `gorm:"primaryKey;"`
+	SessionID  string `gorm:"primaryKey;"`
+	Owner      string
+	ExpireTime time.Time `gorm:"precision:6"`
+}
+
+// TableName explicitly sets the table name for the storageSessionLock struct.
+func (storageSessionLock) TableName() string {
+	return "session_locks"
+}
+
+// LockConfig configures the session locker of NewSessionLocker.
+type LockConfig struct {
+	// Owner identifies the holder of the locks in the database, e.g. the
+	// instance ID of the replica, see runner.Config.InstanceID. Each lock
+	// is held under the owner and a random suffix. Defaults to "runner".
+	Owner string // optional
+	// TTL is the duration of the leases. The holder renews its leases at a
+	// third of the TTL, so that a lock held by a replica that stopped is
+	// released once its lease expires. Defaults to 30 seconds.
+	TTL time.Duration // optional
+	// PollInterval is how often Lock retries a lock held by another
+	// invocation. Defaults to 200 milliseconds.
+	PollInterval time.Duration // optional
+}
+
+// NewSessionLocker returns a [session.Locker] serializing the
+// invocations on the same session across the runners sharing the database
+// of the session service, e.g. the replicas of a launcher behind a load
+// balancer. The locks are leases in the session_locks table, created by
+// [AutoMigrate]. The expiry of the leases is checked against the clocks of
+// the replicas, which should be synchronized well within the TTL.
+func NewSessionLocker(service session.Service, cfg LockConfig) (session.Locker, error) {
+	dbservice, ok := service.(*databaseService)
+	if !ok {
+		return nil, fmt.Errorf("invalid session service type")
+	}
+	if cfg.Owner == "" {
+		cfg.Owner = "runner"
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * time.Second
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 200 * time.Millisecond
+	}
+	return &sessionLocker{db: dbservice.db, cfg: cfg}, nil
+}
+
+type sessionLocker struct {
+	db  *gorm.DB
+	cfg LockConfig
+}
+
+func (l *sessionLocker) Lock(ctx context.Context, key session.Key) (func(), error) {
+	for {
+		unlock, err := l.TryLock(ctx, key)
+		var busy *session.BusyError
+		if !errors.As(err, &busy) {
+			return unlock, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(l.cfg.PollInterval):
+		}
+	}
+}
+
+func (l *sessionLocker) TryLock(ctx context.Context, key session.Key) (func(), error) {
+	owner := l.cfg.Owner + "/" + session.NewID(ctx)
+	now := time.Now()
+	lock := &storageSessionLock{
+		AppName:    key.AppName,
+		UserID:     key.UserID,
+		SessionID:  key.SessionID,
+		Owner:      owner,
+		ExpireTime: now.Add(l.cfg.TTL),
+	}
+	db := l.db.WithContext(ctx)
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(lock)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to lock session: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// The lock exists: take it over if its lease expired.
+		result = whereKey(db.Model(&storageSessionLock{}), key).
+			Where("expire_time < ?", now).
+			Updates(map[string]any{"owner": owner, "expire_time": lock.ExpireTime})
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to lock session: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil, &session.BusyError{Key: key}
+		}
+	}
+
+	renewCtx, stop := context.WithCancel(context.WithoutCancel(ctx))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		l.renew(renewCtx, key, owner)
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			stop()
+			wg.Wait()
+			err := whereKey(l.db.WithContext(context.WithoutCancel(ctx)), key).Where("owner = ?", owner).Delete(&storageSessionLock{}).Error
+			if err != nil {
+				logging.FromContext(ctx).WarnContext(ctx, "Failed to unlock the session, it is released when its lease expires", "error", err)
+			}
+		})
+	}, nil
+}
+
+// renew extends the lease of the lock until ctx is done.
+func (l *sessionLocker) renew(ctx context.Context, key session.Key, owner string) {
+	ticker := time.NewTicker(l.cfg.TTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		result := whereKey(l.db.WithContext(ctx).Model(&storageSessionLock{}), key).
+			Where("owner = ?", owner).
+			Update("expire_time", time.Now().Add(l.cfg.TTL))
+		switch {
+		case ctx.Err() != nil:
+			return
+		case result.Error != nil:
+			logging.FromContext(ctx).WarnContext(ctx, "Failed to renew the session lock", "error", result.Error)
+		case result.RowsAffected == 0:
+			logging.FromContext(ctx).WarnContext(ctx, "Session lock lost: its lease expired and another runner took it")
+			return
+		}
+	}
+}
+
+// whereKey restricts the query to the lock of the session. The condition is
+// explicit: a struct condition would drop the empty fields of the key and
+// match the locks of other sessions.
+func whereKey(db *gorm.DB, key session.Key) *gorm.DB {
+	return db.Where("app_name = ? AND user_id = ? AND session_id = ?", key.AppName, key.UserID, key.SessionID)
+}
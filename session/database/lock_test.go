@@ -0,0 +1,136 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/adk/session"
+)
+
+func TestSessionLocker(t *testing.T) {
+	ctx := t.Context()
+	service := emptyService(t)
+	// Two replicas sharing the database.
+	first, err := NewSessionLocker(service, LockConfig{Owner: "first", PollInterval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewSessionLocker() error = %v", err)
+	}
+	second, err := NewSessionLocker(service, LockConfig{Owner: "second", PollInterval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewSessionLocker() error = %v", err)
+	}
+	key := session.Key{AppName: "app", UserID: "user", SessionID: "session"}
+
+	unlock, err := first.TryLock(ctx, key)
+	if err != nil {
+		t.Fatalf("TryLock() error = %v", err)
+	}
+	var busy *session.BusyError
+	if _, err := second.TryLock(ctx, key); !errors.As(err, &busy) {
+		t.Errorf("TryLock() of a locked session error = %v, want a SessionBusyError", err)
+	}
+	other, err := second.TryLock(ctx, session.Key{AppName: "app", UserID: "user", SessionID: "other"})
+	if err != nil {
+		t.Fatalf("TryLock() of another session error = %v", err)
+	}
+	other()
+
+	locked := make(chan func())
+	go func() {
+		unlock, err := second.Lock(ctx, key)
+		if err != nil {
+			t.Errorf("Lock() error = %v", err)
+		}
+		locked <- unlock
+	}()
+	select {
+	case <-locked:
+		t.Fatal("Lock() acquired a locked session")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	unlock = <-locked
+	unlock()
+}
+
+func TestSessionLocker_ExpiredLease(t *testing.T) {
+	ctx := t.Context()
+	service := emptyService(t)
+	key := session.Key{AppName: "app", UserID: "user", SessionID: "session"}
+	// A replica that stopped without unlocking: its lease is not renewed.
+	if err := service.db.Create(&storageSessionLock{
+		AppName:    key.AppName,
+		UserID:     key.UserID,
+		SessionID:  key.SessionID,
+		Owner:      "stopped/1",
+		ExpireTime: time.Now().Add(-time.Second),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+	locker, err := NewSessionLocker(service, LockConfig{})
+	if err != nil {
+		t.Fatalf("NewSessionLocker() error = %v", err)
+	}
+	unlock, err := locker.TryLock(ctx, key)
+	if err != nil {
+		t.Fatalf("TryLock() of an expired lock error = %v", err)
+	}
+	unlock()
+	var count int64
+	if err := service.db.Model(&storageSessionLock{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("%d locks left after unlock, want 0", count)
+	}
+}
+
+func TestSessionLocker_EmptyKeyComponent(t *testing.T) {
+	ctx := t.Context()
+	service := emptyService(t)
+	// Expired locks of two sessions, one with an empty user ID.
+	for _, userID := range []string{"", "user"} {
+		if err := service.db.Create(&storageSessionLock{
+			AppName:    "app",
+			UserID:     userID,
+			SessionID:  "session",
+			Owner:      "stopped/" + userID,
+			ExpireTime: time.Now().Add(-time.Second),
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	locker, err := NewSessionLocker(service, LockConfig{})
+	if err != nil {
+		t.Fatalf("NewSessionLocker() error = %v", err)
+	}
+	unlock, err := locker.TryLock(ctx, session.Key{AppName: "app", SessionID: "session"})
+	if err != nil {
+		t.Fatalf("TryLock() of an expired lock error = %v", err)
+	}
+	unlock()
+
+	// The lock of the other user is neither taken over nor deleted.
+	var locks []storageSessionLock
+	if err := service.db.Find(&locks).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 1 || locks[0].UserID != "user" || locks[0].Owner != "stopped/user" {
+		t.Errorf("locks left after unlock = %+v, want the lock of user owned by stopped/user", locks)
+	}
+}
@@ -22,31 +22,109 @@ import (
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"google.golang.org/adk/codec"
 	"google.golang.org/adk/session"
 )
 
 // databaseService is an database implementation of sessionService.Service.
 type databaseService struct {
 	db *gorm.DB
+	// codec encodes the events, see WithCodec.
+	codec codec.Codec
+	// compression compresses the large events, see WithCompression.
+	compression codec.Compression
 }
 
 // NewSessionService creates a new [session.Service] implementation that uses a
 // relational database (e.g., PostgreSQL, Spanner, SQLite) via the GORM library.
 //
 // It requires a [gorm.Dialector] to specify the database connection and
-// accepts optional [gorm.Option] values for further GORM configuration.
+// accepts optional [gorm.Option] values for further GORM configuration, and
+// the options of the service, e.g. [WithCodec].
 //
 // It returns the new [session.Service] or an error if the database connection
 // [gorm.Open] fails.
 func NewSessionService(dialector gorm.Dialector, opts ...gorm.Option) (session.Service, error) {
-	db, err := gorm.Open(dialector, opts...)
+	s := &databaseService{codec: codec.JSON}
+	gormOpts := make([]gorm.Option, 0, len(opts))
+	for _, opt := range opts {
+		if opt, ok := opt.(serviceOption); ok {
+			opt.apply(s)
+			continue
+		}
+		gormOpts = append(gormOpts, opt)
+	}
+	db, err := gorm.Open(dialector, gormOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("error creating database session service: %w", err)
 	}
-	return &databaseService{db: db}, nil
+	s.db = db
+	return s, nil
+}
+
+// serviceOption is an option of the service passed along the GORM options
+// of NewSessionService. It is not passed to GORM.
+type serviceOption struct {
+	apply func(s *databaseService)
+}
+
+// Apply implements gorm.Option. It is never called.
+func (serviceOption) Apply(*gorm.Config) error { return nil }
+
+// AfterInitialize implements gorm.Option. It is never called.
+func (serviceOption) AfterInitialize(*gorm.DB) error { return nil }
+
+// WithCodec returns the option of NewSessionService encoding the events
+// with the codec, e.g. [codec.MessagePack] for a compact binary encoding.
+//
+// With the default JSON codec, the content, metadata and actions of the
+// events are stored in their own JSON columns. With another codec, the
+// whole event is encoded in its payload column instead. The codec of each
+// event is detected when it is read, so the codec can be changed without
+// migrating the events already stored. AutoMigrate must have added the
+// payload column before a non-JSON codec is used.
+func WithCodec(c codec.Codec) gorm.Option {
+	return serviceOption{apply: func(s *databaseService) {
+		if c != nil {
+			s.codec = c
+		}
+	}}
+}
+
+// WithCompression returns the option of NewSessionService compressing the
+// events whose encoding reaches the threshold of the compression, e.g. the
+// events holding large tool outputs.
+//
+// The compressed events are stored in the payload column, whatever the
+// codec. The compression of each event is detected when it is read, so the
+// compression can be changed or disabled without migrating the events
+// already stored.
+func WithCompression(c codec.Compression) gorm.Option {
+	return serviceOption{apply: func(s *databaseService) {
+		s.compression = c
+	}}
+}
+
+// encodeEvent returns the payload of the event, nil if the event is stored
+// in the JSON columns: with the JSON codec, when it is not compressed.
+func (s *databaseService) encodeEvent(event *session.Event) ([]byte, error) {
+	isJSON := s.codec == nil || s.codec.Name() == codec.JSON.Name()
+	if isJSON && !s.compression.Enabled() {
+		return nil, nil
+	}
+	payload, err := codec.Encode(s.codec, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	if payload, err = s.compression.Compress(payload); err != nil {
+		return nil, fmt.Errorf("failed to compress event: %w", err)
+	}
+	if isJSON && !codec.IsCompressed(payload) {
+		return nil, nil
+	}
+	return payload, nil
 }
 
 // AutoMigrate runs the GORM auto-migration tool to ensure the database schema
@@ -60,7 +138,7 @@ func AutoMigrate(service session.Service) error {
 	if !ok {
 		return fmt.Errorf("invalid session service type")
 	}
-	err := dbservice.db.AutoMigrate(&storageSession{}, &storageEvent{}, &storageAppState{}, &storageUserState{})
+	err := dbservice.db.AutoMigrate(&storageSession{}, &storageEvent{}, &storageAppState{}, &storageUserState{}, &storageSessionLock{})
 	if err != nil {
 		return fmt.Errorf("auto migrate failed: %w", err)
 	}
@@ -75,7 +153,7 @@ func (s *databaseService) Create(ctx context.Context, req *session.CreateRequest
 
 	sessionID := req.SessionID
 	if sessionID == "" {
-		sessionID = uuid.NewString()
+		sessionID = session.NewID(ctx)
 	}
 
 	stateMap := req.State
@@ -87,7 +165,7 @@ func (s *databaseService) Create(ctx context.Context, req *session.CreateRequest
 		userID:    req.UserID,
 		sessionID: sessionID,
 		state:     stateMap,
-		updatedAt: time.Now(),
+		updatedAt: session.Now(ctx),
 	}
 	createdSession, err := createStorageSession(val)
 	if err != nil {
@@ -121,6 +199,13 @@ func (s *databaseService) Create(ctx context.Context, req *session.CreateRequest
 		}
 		createdSession.State = sessionState
 
+		var existing int64
+		if err := tx.Model(&storageSession{}).Where(&storageSession{AppName: req.AppName, UserID: req.UserID, ID: sessionID}).Count(&existing).Error; err != nil {
+			return fmt.Errorf("error on create session: %w", err)
+		}
+		if existing > 0 {
+			return fmt.Errorf("session %s: %w", sessionID, session.ErrSessionAlreadyExists)
+		}
 		if err := tx.Create(createdSession).Error; err != nil {
 			return fmt.Errorf("error creating session on database: %w", err)
 		}
@@ -154,8 +239,10 @@ func (s *databaseService) Get(ctx context.Context, req *session.GetRequest) (*se
 			ID:      sessionID,
 		}).
 		First(&foundSession).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("session %+v: %w", sessionID, session.ErrSessionNotFound)
+	}
 	if err != nil {
-		// For any error including ErrRecordNotFound, return it as a system error.
 		return nil, fmt.Errorf("database error while fetching session: %w", err)
 	}
 
@@ -316,46 +403,87 @@ func (s *databaseService) Delete(ctx context.Context, req *session.DeleteRequest
 	})
 }
 
-func (s *databaseService) AppendEvent(ctx context.Context, curSession session.Session, event *session.Event) error {
-	if curSession == nil {
-		return fmt.Errorf("session is nil")
-	}
-	if event == nil {
-		return fmt.Errorf("event is nil")
+// DeleteEvents implements session.EventDeleter.
+func (s *databaseService) DeleteEvents(ctx context.Context, req *session.DeleteEventsRequest) error {
+	appName, userID, sessionID := req.AppName, req.UserID, req.SessionID
+	if appName == "" || userID == "" || sessionID == "" {
+		return fmt.Errorf("app_name, user_id, session_id are required, got app_name: %q, user_id: %q, session_id: %q", appName, userID, sessionID)
 	}
-	// ignore partial events
-	if event.Partial {
+	if len(req.EventIDs) == 0 {
 		return nil
 	}
 
-	// Truncate timestamp to microsecond precision to match database precision and prevent rounding errors.
-	event.Timestamp = event.Timestamp.Truncate(time.Microsecond)
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where(&storageEvent{
+			AppName:   appName,
+			UserID:    userID,
+			SessionID: sessionID,
+		}).Where("id IN ?", req.EventIDs).Delete(&storageEvent{})
+
+		if result.Error != nil {
+			return fmt.Errorf("database error during event deletion: %w", result.Error)
+		}
+
+		return nil
+	})
+}
+
+func (s *databaseService) AppendEvent(ctx context.Context, curSession session.Session, event *session.Event) error {
+	if event == nil {
+		return fmt.Errorf("event is nil")
+	}
+	return s.AppendEvents(ctx, curSession, []*session.Event{event})
+}
 
+// AppendEvents appends the events and applies their state changes in a
+// single transaction.
+func (s *databaseService) AppendEvents(ctx context.Context, curSession session.Session, events []*session.Event) error {
+	if curSession == nil {
+		return fmt.Errorf("session is nil")
+	}
 	sess, ok := curSession.(*localSession)
 	if !ok {
 		return fmt.Errorf("unexpected session type %T", sess)
 	}
-	// append it to session
-	if err := sess.appendEvent(event); err != nil {
-		return err
+
+	var persisted []*session.Event
+	for _, event := range events {
+		if event == nil {
+			return fmt.Errorf("event is nil")
+		}
+		// ignore partial events
+		if event.Partial {
+			continue
+		}
+
+		// Truncate timestamp to microsecond precision to match database precision and prevent rounding errors.
+		event.Timestamp = event.Timestamp.Truncate(time.Microsecond)
+
+		// append it to session
+		if err := sess.appendEvent(event); err != nil {
+			return err
+		}
+		// Trim temp state before persisting
+		persisted = append(persisted, trimTempDeltaState(event))
+	}
+	if len(persisted) == 0 {
+		return nil
 	}
 
-	// Trim temp state before persisting
-	event = trimTempDeltaState(event)
 	// applyChanges and persist them
-	err := s.applyEvent(ctx, sess, event)
+	err := s.applyEvents(ctx, sess, persisted)
 	if err != nil {
 		return err
 	}
 
 	// update local session last update time
-	sess.updatedAt = event.Timestamp
+	sess.updatedAt = persisted[len(persisted)-1].Timestamp
 	return nil
 }
 
-// applyEvent fetches the session, validates it, applies state changes from an
-// event, and saves the event atomically.
-func (s *databaseService) applyEvent(ctx context.Context, session *localSession, event *session.Event) error {
+// applyEvents fetches the session, validates it, applies state changes from
+// the events, and saves the events atomically.
+func (s *databaseService) applyEvents(ctx context.Context, session *localSession, events []*session.Event) error {
 	// Wrap database operations in a single transaction.
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Fetch the session object from storage.
@@ -391,37 +519,53 @@ func (s *databaseService) applyEvent(ctx context.Context, session *localSession,
 			return err
 		}
 
-		appDelta, userDelta, sessionDelta := extractStateDeltas(event.Actions.StateDelta)
+		// Merge the state deltas of all the events, in order.
+		appChanged, userChanged := false, false
+		storageEvs := make([]*storageEvent, 0, len(events))
+		for _, event := range events {
+			appDelta, userDelta, sessionDelta := extractStateDeltas(event.Actions.StateDelta)
+			if len(appDelta) > 0 {
+				maps.Copy(storageApp.State, appDelta)
+				appChanged = true
+			}
+			if len(userDelta) > 0 {
+				maps.Copy(storageUser.State, userDelta)
+				userChanged = true
+			}
+			if len(sessionDelta) > 0 {
+				maps.Copy(storageSess.State, sessionDelta)
+				// The session state update will be saved along with the event timestamp update.
+			}
+
+			payload, err := s.encodeEvent(event)
+			if err != nil {
+				return err
+			}
+			storageEv, err := createStorageEvent(session, event, payload)
+			if err != nil {
+				return fmt.Errorf("failed to map event to storage model: %w", err)
+			}
+			storageEvs = append(storageEvs, storageEv)
+		}
 
-		// Merge state deltas and update the storage objects.
 		// GORM's .Save() method will correctly perform an INSERT or UPDATE.
-		if len(appDelta) > 0 {
-			maps.Copy(storageApp.State, appDelta)
+		if appChanged {
 			if err := tx.Save(&storageApp).Error; err != nil {
 				return fmt.Errorf("failed to save app state: %w", err)
 			}
 		}
-		if len(userDelta) > 0 {
-			maps.Copy(storageUser.State, userDelta)
+		if userChanged {
 			if err := tx.Save(&storageUser).Error; err != nil {
 				return fmt.Errorf("failed to save user state: %w", err)
 			}
 		}
-		if len(sessionDelta) > 0 {
-			maps.Copy(storageSess.State, sessionDelta)
-			// The session state update will be saved along with the event timestamp update.
-		}
 
-		// Create the new event record in the database.
-		storageEv, err := createStorageEvent(session, event)
-		if err != nil {
-			return fmt.Errorf("failed to map event to storage model: %w", err)
-		}
-		if err := tx.Create(storageEv).Error; err != nil {
+		// Create the new event records in the database.
+		if err := tx.Create(storageEvs).Error; err != nil {
 			return fmt.Errorf("failed to save event: %w", err)
 		}
 
-		storageSess.UpdateTime = event.Timestamp
+		storageSess.UpdateTime = events[len(events)-1].Timestamp
 		// Save the session to update its state and UpdateTime.
 		if err := tx.Save(&storageSess).Error; err != nil {
 			return fmt.Errorf("failed to save session state: %w", err)
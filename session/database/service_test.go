@@ -15,8 +15,11 @@
 package database
 
 import (
+	"errors"
 	"maps"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,8 +29,10 @@ import (
 	"google.golang.org/genai"
 	"gorm.io/gorm"
 
+	"google.golang.org/adk/codec"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
+	"google.golang.org/adk/session/servicetest"
 )
 
 func Test_databaseService_Create(t *testing.T) {
@@ -86,6 +91,9 @@ func Test_databaseService_Create(t *testing.T) {
 			}
 
 			if err != nil {
+				if !errors.Is(err, session.ErrSessionAlreadyExists) {
+					t.Errorf("Create() error = %v, want %v", err, session.ErrSessionAlreadyExists)
+				}
 				return
 			}
 
@@ -153,6 +161,43 @@ func Test_databaseService_Delete(t *testing.T) {
 	}
 }
 
+func Test_databaseService_DeleteEvents(t *testing.T) {
+	s := serviceDbWithData(t)
+	ctx := t.Context()
+	getResp, err := s.Get(ctx, &session.GetRequest{AppName: "app2", UserID: "user2", SessionID: "session2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := session.NewEvent("inv")
+	event.ID = "new_event"
+	if err := s.AppendEvent(ctx, getResp.Session, event); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DeleteEvents(ctx, &session.DeleteEventsRequest{AppName: "app2", UserID: "user2", SessionID: "session2", EventIDs: []string{"existing_event1", "unknown"}}); err != nil {
+		t.Fatalf("databaseService.DeleteEvents() error = %v", err)
+	}
+
+	getResp, err = s.Get(ctx, &session.GetRequest{AppName: "app2", UserID: "user2", SessionID: "session2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for event := range getResp.Session.Events().All() {
+		ids = append(ids, event.ID)
+	}
+	if diff := cmp.Diff([]string{"new_event"}, ids); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+	if v, _ := getResp.Session.State().Get("k2"); v != "v2" {
+		t.Errorf("state k2 = %v, want v2", v)
+	}
+
+	if err := s.DeleteEvents(ctx, &session.DeleteEventsRequest{AppName: "app2", SessionID: "session2", EventIDs: []string{"new_event"}}); err == nil {
+		t.Error("databaseService.DeleteEvents() without user succeeded, want an error")
+	}
+}
+
 func Test_databaseService_Get(t *testing.T) {
 	// This setup function is required for a test case.
 	// It creates the specific scenario from 'test_get_session_respects_user_id'.
@@ -766,6 +811,150 @@ func Test_databaseService_AppendEvent(t *testing.T) {
 	}
 }
 
+func Test_databaseService_AppendEvents(t *testing.T) {
+	ctx := t.Context()
+	s := emptyService(t)
+	resp, err := s.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user", SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	now := time.Now()
+	events := []*session.Event{
+		{ID: "e1", Timestamp: now, Actions: session.EventActions{StateDelta: map[string]any{"k": "v1", "app:k": "a", "temp:k": "t"}}},
+		{ID: "partial", Timestamp: now.Add(time.Millisecond), LLMResponse: model.LLMResponse{Partial: true}},
+		{ID: "e2", Timestamp: now.Add(2 * time.Millisecond), Actions: session.EventActions{StateDelta: map[string]any{"k": "v2", "user:k": "u"}}},
+	}
+	if err := s.AppendEvents(ctx, resp.Session, events); err != nil {
+		t.Fatalf("AppendEvents() error = %v", err)
+	}
+	if got := resp.Session.LastUpdateTime(); !got.Equal(events[2].Timestamp) {
+		t.Errorf("LastUpdateTime() = %v, want the timestamp of the last event %v", got, events[2].Timestamp)
+	}
+
+	got, err := s.Get(ctx, &session.GetRequest{AppName: "app", UserID: "user", SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	var gotIDs []string
+	for event := range got.Session.Events().All() {
+		gotIDs = append(gotIDs, event.ID)
+	}
+	if diff := cmp.Diff([]string{"e1", "e2"}, gotIDs); diff != "" {
+		t.Errorf("Get() events mismatch (-want +got):\n%s", diff)
+	}
+	wantState := map[string]any{"k": "v2", "app:k": "a", "user:k": "u"}
+	if diff := cmp.Diff(wantState, maps.Collect(got.Session.State().All())); diff != "" {
+		t.Errorf("Get() state mismatch (-want +got):\n%s", diff)
+	}
+
+	// None of the events are written when the batch is rejected.
+	stale := &localSession{appName: "app", userID: "user", sessionID: "s1"}
+	err = s.AppendEvents(ctx, stale, []*session.Event{{ID: "e3", Timestamp: time.Now()}, nil})
+	if err == nil {
+		t.Fatal("AppendEvents() with a nil event succeeded, want an error")
+	}
+	err = s.AppendEvents(ctx, stale, []*session.Event{{ID: "e3", Timestamp: time.Now()}, {ID: "e4", Timestamp: time.Now()}})
+	if err == nil {
+		t.Fatal("AppendEvents() on a stale session succeeded, want an error")
+	}
+	got, err = s.Get(ctx, &session.GetRequest{AppName: "app", UserID: "user", SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if n := got.Session.Events().Len(); n != 2 {
+		t.Errorf("Get() returned %d events after the failed appends, want 2", n)
+	}
+}
+
+func Test_databaseService_Codecs(t *testing.T) {
+	ctx := t.Context()
+	s := emptyService(t)
+	resp, err := s.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user", SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Each event is written with another codec, as after changes of the
+	// codec of the service: all are read back.
+	now := time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)
+	var want []*session.Event
+	for i, c := range []codec.Codec{codec.JSON, codec.MessagePack, codec.Protobuf} {
+		s.codec = c
+		event := &session.Event{
+			ID:           c.Name(),
+			InvocationID: "inv",
+			Author:       "model",
+			Timestamp:    now.Add(time.Duration(i) * time.Second),
+			Actions:      session.EventActions{StateDelta: map[string]any{"k": c.Name()}},
+			LLMResponse: model.LLMResponse{
+				Content:       genai.NewContentFromText("hello "+c.Name(), genai.RoleModel),
+				UsageMetadata: &genai.GenerateContentResponseUsageMetadata{TotalTokenCount: 42},
+				TurnComplete:  true,
+			},
+		}
+		if err := s.AppendEvent(ctx, resp.Session, event); err != nil {
+			t.Fatalf("AppendEvent() with codec %s error = %v", c.Name(), err)
+		}
+		want = append(want, event)
+	}
+
+	got, err := s.Get(ctx, &session.GetRequest{AppName: "app", UserID: "user", SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if diff := cmp.Diff(want, slices.Collect(got.Session.Events().All())); diff != "" {
+		t.Errorf("Get() events mismatch (-want +got):\n%s", diff)
+	}
+	if k := maps.Collect(got.Session.State().All())["k"]; k != "protobuf" {
+		t.Errorf("Get() state k = %v, want the value of the last event %q", k, "protobuf")
+	}
+}
+
+func Test_databaseService_Compression(t *testing.T) {
+	ctx := t.Context()
+	s := emptyService(t)
+	s.compression = codec.Compression{Compressor: codec.Zstd, Threshold: 512}
+	resp, err := s.Create(ctx, &session.CreateRequest{AppName: "app", UserID: "user", SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)
+	want := []*session.Event{
+		{ID: "small", Author: "user", Timestamp: now, LLMResponse: model.LLMResponse{Content: genai.NewContentFromText("hi", genai.RoleUser)}},
+		{ID: "large", Author: "tool", Timestamp: now.Add(time.Second), LLMResponse: model.LLMResponse{Content: genai.NewContentFromText(strings.Repeat("tool output ", 1000), genai.RoleUser)}},
+	}
+	for _, event := range want {
+		if err := s.AppendEvent(ctx, resp.Session, event); err != nil {
+			t.Fatalf("AppendEvent() error = %v", err)
+		}
+	}
+
+	var stored []storageEvent
+	if err := s.db.Order("timestamp").Find(&stored).Error; err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(stored) != 2 {
+		t.Fatalf("stored %d events, want 2", len(stored))
+	}
+	if stored[0].Payload != nil || stored[0].Content == nil {
+		t.Errorf("small event stored with payload %q, want it in the JSON columns", stored[0].Payload)
+	}
+	if !codec.IsCompressed(stored[1].Payload) || len(stored[1].Payload) > 1000 {
+		t.Errorf("large event stored with a payload of %d bytes, want it compressed", len(stored[1].Payload))
+	}
+
+	// The compressed events are read back once the compression is disabled.
+	s.compression = codec.Compression{}
+	got, err := s.Get(ctx, &session.GetRequest{AppName: "app", UserID: "user", SessionID: "s1"})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if diff := cmp.Diff(want, slices.Collect(got.Session.Events().All())); diff != "" {
+		t.Errorf("Get() events mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func Test_databaseService_StateManagement(t *testing.T) {
 	ctx := t.Context()
 	appName := "my_app"
@@ -1018,3 +1207,9 @@ func emptyService(t *testing.T) *databaseService {
 
 	return dbservice
 }
+
+func Test_databaseService_StateIsolation(t *testing.T) {
+	servicetest.TestStateIsolation(t, func(t *testing.T) session.Service {
+		return emptyService(t)
+	})
+}
@@ -21,6 +21,7 @@ import (
 
 	"google.golang.org/genai"
 
+	"google.golang.org/adk/codec"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
 )
@@ -50,8 +51,8 @@ func createStorageSession(s *localSession) (*storageSession, error) {
 		AppName:    s.appName,
 		ID:         s.sessionID,
 		State:      s.state,
-		CreateTime: time.Now(),
-		UpdateTime: time.Now(),
+		CreateTime: s.updatedAt,
+		UpdateTime: s.updatedAt,
 	}, nil
 }
 
@@ -95,6 +96,10 @@ type storageEvent struct {
 	ErrorMessage *string
 	Interrupted  *bool
 
+	// Payload is the whole event encoded by a non-JSON codec or compressed,
+	// in which case the actions and the JSON columns above are empty.
+	Payload []byte
+
 	// Belongs-To relationship: An event belongs to a session.
 	Session storageSession `gorm:"foreignKey:AppName,UserID,SessionID;references:AppName,UserID,ID"`
 }
@@ -106,7 +111,8 @@ func (storageEvent) TableName() string {
 
 // createStorageEvent translates the application-level Session and Event models
 // into a GORM-compatible storageEvent struct, ready for database insertion.
-func createStorageEvent(session session.Session, event *session.Event) (*storageEvent, error) {
+// The event is encoded in the JSON columns unless its payload is given.
+func createStorageEvent(session session.Session, event *session.Event, payload []byte) (*storageEvent, error) {
 	// Initialize the base storageEvent with direct field mappings.
 	storageEv := &storageEvent{
 		ID:           event.ID,
@@ -118,23 +124,6 @@ func createStorageEvent(session session.Session, event *session.Event) (*storage
 		Timestamp:    event.Timestamp,
 	}
 
-	// --- Handle complex or nullable fields ---
-	// Serialize the entire Actions struct into a JSON byte slice.
-	actionsJSON, err := json.Marshal(event.Actions)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal event actions: %w", err)
-	}
-	storageEv.Actions = actionsJSON
-
-	// Serialize the list of tool IDs into a JSON string
-	if len(event.LongRunningToolIDs) > 0 {
-		toolIDsJSON, err := json.Marshal(event.LongRunningToolIDs)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal long running tool IDs: %w", err)
-		}
-		storageEv.LongRunningToolIDsJSON = toolIDsJSON
-	}
-
 	// Handle optional fields by taking the address of the value.
 	// An empty string from the event becomes a nil pointer in storage.
 	if event.Branch != "" {
@@ -152,6 +141,28 @@ func createStorageEvent(session session.Session, event *session.Event) (*storage
 	storageEv.TurnComplete = &event.TurnComplete
 	storageEv.Interrupted = &event.Interrupted
 
+	if payload != nil {
+		storageEv.Payload = payload
+		return storageEv, nil
+	}
+
+	// --- Handle complex or nullable fields ---
+	// Serialize the entire Actions struct into a JSON byte slice.
+	actionsJSON, err := json.Marshal(event.Actions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event actions: %w", err)
+	}
+	storageEv.Actions = actionsJSON
+
+	// Serialize the list of tool IDs into a JSON string
+	if len(event.LongRunningToolIDs) > 0 {
+		toolIDsJSON, err := json.Marshal(event.LongRunningToolIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal long running tool IDs: %w", err)
+		}
+		storageEv.LongRunningToolIDsJSON = toolIDsJSON
+	}
+
 	// --- Handle JSON content fields ---
 	if event.Content != nil {
 		storageEv.Content, err = json.Marshal(event.Content)
@@ -200,6 +211,15 @@ func derefOrZero[T any](p *T) T {
 // createEventFromStorageEvent translates a GORM storageEvent back into an
 // application-level Event model.
 func createEventFromStorageEvent(se *storageEvent) (*session.Event, error) {
+	if len(se.Payload) > 0 {
+		// The codec and compression of the payload are read from its header.
+		var event session.Event
+		if err := codec.Decode(se.Payload, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+		return &event, nil
+	}
+
 	var actions session.EventActions
 	if len(se.Actions) > 0 {
 		if err := json.Unmarshal(se.Actions, &actions); err != nil {
@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/auth"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool/toolconfirmation"
+)
+
+// EventSchemaVersion is the version of the canonical JSON encoding of the
+// events, written in their "schemaVersion" field.
+//
+// The version is incremented when the meaning of a field changes. New fields
+// are added without a new version: decoders ignore the unknown fields.
+const EventSchemaVersion = 1
+
+// eventJSON is the canonical JSON encoding of the fields of an Event, besides
+// its LLMResponse, whose fields are inlined.
+type eventJSON struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	ID            string    `json:"id"`
+	Timestamp     time.Time `json:"timestamp"`
+	InvocationID  string    `json:"invocationId"`
+	Branch        string    `json:"branch,omitempty"`
+	Author        string    `json:"author"`
+	// Actions is encoded without the indirection of EventActions.MarshalJSON.
+	Actions            eventActionsJSON `json:"actions"`
+	LongRunningToolIDs []string         `json:"longRunningToolIds,omitempty"`
+}
+
+// MarshalJSON encodes the event with its schema version and camelCase field
+// names. The fields of the LLMResponse are inlined in the event object.
+func (e Event) MarshalJSON() ([]byte, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		bufferPool.Put(buf)
+	}()
+	enc := json.NewEncoder(buf)
+	if err := enc.Encode(eventJSON{
+		SchemaVersion:      EventSchemaVersion,
+		ID:                 e.ID,
+		Timestamp:          e.Timestamp,
+		InvocationID:       e.InvocationID,
+		Branch:             e.Branch,
+		Author:             e.Author,
+		Actions:            eventActionsJSON(e.Actions),
+		LongRunningToolIDs: e.LongRunningToolIDs,
+	}); err != nil {
+		return nil, err
+	}
+	headLen := buf.Len()
+	if err := enc.Encode(e.LLMResponse); err != nil {
+		return nil, err
+	}
+	head := bytes.TrimSpace(buf.Bytes()[:headLen])
+	resp := bytes.TrimSpace(buf.Bytes()[headLen:])
+	if len(resp) <= 2 {
+		return bytes.Clone(head), nil
+	}
+	// Merge the two objects: {head...,resp...}.
+	merged := make([]byte, 0, len(head)+len(resp))
+	merged = append(merged, head[:len(head)-1]...)
+	merged = append(merged, ',')
+	return append(merged, resp[1:]...), nil
+}
+
+// bufferPool holds the buffers the events are encoded in, before the
+// encoding is copied out.
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// UnmarshalJSON decodes an event of any schema version up to
+// EventSchemaVersion. The events encoded with the Go field names, before
+// the canonical encoding, are accepted too.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var v eventJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.SchemaVersion > EventSchemaVersion {
+		return fmt.Errorf("unsupported event schema version %d, want at most %d", v.SchemaVersion, EventSchemaVersion)
+	}
+	var resp model.LLMResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	*e = Event{
+		LLMResponse:        resp,
+		ID:                 v.ID,
+		Timestamp:          v.Timestamp,
+		InvocationID:       v.InvocationID,
+		Branch:             v.Branch,
+		Author:             v.Author,
+		Actions:            EventActions(v.Actions),
+		LongRunningToolIDs: v.LongRunningToolIDs,
+	}
+	return nil
+}
+
+// eventActionsJSON is the canonical JSON encoding of EventActions.
+type eventActionsJSON struct {
+	StateDelta                 map[string]any                               `json:"stateDelta,omitempty"`
+	ArtifactDelta              map[string]int64                             `json:"artifactDelta,omitempty"`
+	RequestedToolConfirmations map[string]toolconfirmation.ToolConfirmation `json:"requestedToolConfirmations,omitempty"`
+	RequestedAuthConfigs       map[string]auth.Config                       `json:"requestedAuthConfigs,omitempty"`
+	SkipSummarization          bool                                         `json:"skipSummarization,omitempty"`
+	TransferToAgent            string                                       `json:"transferToAgent,omitempty"`
+	Escalate                   bool                                         `json:"escalate,omitempty"`
+}
+
+// MarshalJSON encodes the actions with camelCase field names, omitting the
+// unset fields.
+func (a EventActions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventActionsJSON(a))
+}
+
+// UnmarshalJSON decodes the actions. The field names are matched
+// case-insensitively, so the Go field names written before the canonical
+// encoding are accepted too.
+func (a *EventActions) UnmarshalJSON(data []byte) error {
+	var v eventActionsJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*a = EventActions(v)
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/internal/race"
+	"google.golang.org/adk/model"
+)
+
+// eventMarshalAllocsBudget is the allocation budget of the JSON encoding of
+// benchmarkEvent, enforced by TestEventJSON_AllocationBudget. The encoding
+// is compared across changes with benchstat, see BenchmarkEventMarshalJSON.
+const eventMarshalAllocsBudget = 16
+
+func benchmarkEvent() *Event {
+	return &Event{
+		LLMResponse: model.LLMResponse{
+			Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{
+				{Text: "It is sunny in Paris, with a high of 24 degrees."},
+				{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "get_weather", Args: map[string]any{"city": "Paris"}}},
+			}},
+			UsageMetadata: &genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 120, CandidatesTokenCount: 20, TotalTokenCount: 140},
+			FinishReason:  genai.FinishReasonStop,
+		},
+		ID:           "event-1",
+		Timestamp:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		InvocationID: "invocation-1",
+		Author:       "weather_agent",
+		Actions:      EventActions{StateDelta: map[string]any{"last_city": "Paris"}},
+	}
+}
+
+func BenchmarkEventMarshalJSON(b *testing.B) {
+	event := benchmarkEvent()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := json.Marshal(event); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEventUnmarshalJSON(b *testing.B) {
+	data, err := json.Marshal(benchmarkEvent())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		var event Event
+		if err := json.Unmarshal(data, &event); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEventJSON_AllocationBudget(t *testing.T) {
+	if race.Enabled || testing.Short() {
+		t.Skip("the allocation budgets are not checked with the race detector or in short mode")
+	}
+	event := benchmarkEvent()
+	if allocs := testing.AllocsPerRun(100, func() {
+		if _, err := json.Marshal(event); err != nil {
+			t.Fatal(err)
+		}
+	}); allocs > eventMarshalAllocsBudget {
+		t.Errorf("json.Marshal(event) allocations = %v, want at most %d", allocs, eventMarshalAllocsBudget)
+	}
+}
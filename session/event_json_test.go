@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/auth"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool/toolconfirmation"
+)
+
+func TestEventJSON_RoundTrip(t *testing.T) {
+	event := &Event{
+		LLMResponse: model.LLMResponse{
+			Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{
+				{Text: "hello"},
+				{FunctionCall: &genai.FunctionCall{ID: "call", Name: "lookup", Args: map[string]any{"q": "x"}}},
+			}},
+			UsageMetadata:  &genai.GenerateContentResponseUsageMetadata{TotalTokenCount: 12},
+			CustomMetadata: map[string]any{"key": "value"},
+			ModelVersion:   "model-1",
+			TurnComplete:   true,
+			ErrorCode:      "CODE",
+			FinishReason:   genai.FinishReasonStop,
+			AvgLogprobs:    -0.5,
+		},
+		ID:           "event",
+		Timestamp:    time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC),
+		InvocationID: "invocation",
+		Branch:       "root.child",
+		Author:       "child",
+		Actions: EventActions{
+			StateDelta:                 map[string]any{"count": float64(2)},
+			ArtifactDelta:              map[string]int64{"file.txt": 1},
+			RequestedToolConfirmations: map[string]toolconfirmation.ToolConfirmation{"call": {Hint: "sure?"}},
+			RequestedAuthConfigs:       map[string]auth.Config{"call": {Scheme: auth.Scheme{Type: auth.TypeAPIKey, In: "header", Name: "X-Key"}}},
+			TransferToAgent:            "other",
+		},
+		LongRunningToolIDs: []string{"call"},
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for _, want := range []string{`"schemaVersion":1`, `"invocationId":"invocation"`, `"stateDelta":{"count":2}`, `"turnComplete":true`, `"customMetadata":{"key":"value"}`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("json.Marshal() = %s, want it to contain %s", data, want)
+		}
+	}
+
+	var got Event
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if diff := cmp.Diff(event, &got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEventJSON_Legacy(t *testing.T) {
+	// Encoded with the Go field names, before the canonical encoding.
+	data := `{"Content":{"parts":[{"text":"hi"}],"role":"user"},"Partial":false,"TurnComplete":true,` +
+		`"ID":"event","Timestamp":"2026-01-02T03:04:05Z","InvocationID":"invocation","Author":"user",` +
+		`"Actions":{"StateDelta":{"k":"v"},"ArtifactDelta":{},"SkipSummarization":true},"LongRunningToolIDs":null}`
+	var got Event
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := Event{
+		LLMResponse:  model.LLMResponse{Content: genai.NewContentFromText("hi", genai.RoleUser), TurnComplete: true},
+		ID:           "event",
+		Timestamp:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		InvocationID: "invocation",
+		Author:       "user",
+		Actions:      EventActions{StateDelta: map[string]any{"k": "v"}, ArtifactDelta: map[string]int64{}, SkipSummarization: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("json.Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEventJSON_Versions(t *testing.T) {
+	var event Event
+	if err := json.Unmarshal([]byte(`{"schemaVersion":1,"id":"event","futureField":true}`), &event); err != nil || event.ID != "event" {
+		t.Errorf("json.Unmarshal() of unknown fields = %+v, %v, want the event", event, err)
+	}
+	if err := json.Unmarshal([]byte(`{"schemaVersion":2,"id":"event"}`), &event); err == nil {
+		t.Errorf("json.Unmarshal() of a newer schema version succeeded, want error")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// ForkRequest represents a request to fork a session.
+type ForkRequest struct {
+	AppName   string
+	UserID    string
+	SessionID string
+	// EventID is the ID of the event the fork starts at: the new session
+	// has the events of the session before it.
+	// Optional: if empty, all the events are copied.
+	EventID string
+	// NewSessionID is the client-provided ID of the new session.
+	// Optional: if not set, it will be autogenerated.
+	NewSessionID string
+}
+
+// ForkResponse represents a response from [Fork].
+type ForkResponse struct {
+	Session Session
+}
+
+// Fork creates a new session with the events of a session before the given
+// event, e.g. to regenerate the conversation from an edited user message.
+// It works with any [Service]: the session is created with the state that
+// no event changed, and the events are appended to it, replaying their
+// state deltas. App and user state are shared, so they are not copied.
+//
+// It returns ErrSessionNotFound if the session does not exist and
+// ErrEventNotFound if the session has no event with the given ID.
+func Fork(ctx context.Context, s Service, req *ForkRequest) (*ForkResponse, error) {
+	resp, err := s.Get(ctx, &GetRequest{AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID})
+	if err != nil {
+		return nil, err
+	}
+	source := resp.Session
+
+	var events []*Event
+	found := req.EventID == ""
+	for event := range source.Events().All() {
+		if event.ID == req.EventID && req.EventID != "" {
+			found = true
+			break
+		}
+		events = append(events, event)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s in session %s", ErrEventNotFound, req.EventID, req.SessionID)
+	}
+
+	changed := make(map[string]bool)
+	for event := range source.Events().All() {
+		for key := range event.Actions.StateDelta {
+			changed[key] = true
+		}
+	}
+	state := make(map[string]any)
+	for key, value := range source.State().All() {
+		if changed[key] || strings.HasPrefix(key, KeyPrefixApp) || strings.HasPrefix(key, KeyPrefixUser) || strings.HasPrefix(key, KeyPrefixTemp) {
+			continue
+		}
+		state[key] = value
+	}
+
+	created, err := s.Create(ctx, &CreateRequest{
+		AppName:   req.AppName,
+		UserID:    req.UserID,
+		SessionID: req.NewSessionID,
+		State:     state,
+	})
+	if err != nil {
+		return nil, err
+	}
+	fork := created.Session
+	for _, event := range events {
+		event := *event
+		event.Actions.StateDelta = maps.Clone(event.Actions.StateDelta)
+		event.Actions.ArtifactDelta = maps.Clone(event.Actions.ArtifactDelta)
+		if err := s.AppendEvent(ctx, fork, &event); err != nil {
+			return nil, fmt.Errorf("failed to copy event %s: %w", event.ID, err)
+		}
+	}
+
+	resp, err = s.Get(ctx, &GetRequest{AppName: req.AppName, UserID: req.UserID, SessionID: fork.ID()})
+	if err != nil {
+		return nil, err
+	}
+	return &ForkResponse{Session: resp.Session}, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+func TestFork(t *testing.T) {
+	ctx := t.Context()
+	s := InMemoryService()
+	created, err := s.Create(ctx, &CreateRequest{
+		AppName:   "app",
+		UserID:    "user",
+		SessionID: "source",
+		State:     map[string]any{"initial": "kept", "counter": 0, "app:shared": "app"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := created.Session
+	for i, text := range []string{"first", "second", "third"} {
+		event := NewEvent("inv")
+		event.ID = text
+		event.Author = "user"
+		event.LLMResponse = model.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleUser)}
+		event.Actions.StateDelta["counter"] = i + 1
+		if err := s.AppendEvent(ctx, source, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := Fork(ctx, s, &ForkRequest{AppName: "app", UserID: "user", SessionID: "source", EventID: "third", NewSessionID: "fork"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fork := resp.Session
+	if fork.ID() != "fork" {
+		t.Errorf("ID() = %q, want fork", fork.ID())
+	}
+	var ids []string
+	for event := range fork.Events().All() {
+		ids = append(ids, event.ID)
+	}
+	if diff := cmp.Diff([]string{"first", "second"}, ids); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+	for key, want := range map[string]any{"initial": "kept", "counter": 2, "app:shared": "app"} {
+		if got, err := fork.State().Get(key); err != nil || got != want {
+			t.Errorf("State().Get(%q) = %v, %v, want %v", key, got, err, want)
+		}
+	}
+
+	// The source session is unchanged.
+	got, err := s.Get(ctx, &GetRequest{AppName: "app", UserID: "user", SessionID: "source"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := got.Session.Events().Len(); n != 3 {
+		t.Errorf("source session has %d events, want 3", n)
+	}
+	if counter, _ := got.Session.State().Get("counter"); counter != 3 {
+		t.Errorf("source counter = %v, want 3", counter)
+	}
+
+	// Without an event ID, all the events are copied.
+	resp, err = Fork(ctx, s, &ForkRequest{AppName: "app", UserID: "user", SessionID: "source"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := resp.Session.Events().Len(); n != 3 || resp.Session.ID() == "source" {
+		t.Errorf("fork %q has %d events, want a new session with 3 events", resp.Session.ID(), n)
+	}
+}
+
+func TestFork_Errors(t *testing.T) {
+	ctx := t.Context()
+	s := InMemoryService()
+	if _, err := s.Create(ctx, &CreateRequest{AppName: "app", UserID: "user", SessionID: "source"}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		req  *ForkRequest
+		want error
+	}{
+		{
+			name: "unknown session",
+			req:  &ForkRequest{AppName: "app", UserID: "user", SessionID: "unknown"},
+			want: ErrSessionNotFound,
+		},
+		{
+			name: "unknown event",
+			req:  &ForkRequest{AppName: "app", UserID: "user", SessionID: "source", EventID: "unknown"},
+			want: ErrEventNotFound,
+		},
+		{
+			name: "existing new session",
+			req:  &ForkRequest{AppName: "app", UserID: "user", SessionID: "source", NewSessionID: "source"},
+			want: ErrSessionAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Fork(ctx, s, tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("Fork() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -25,7 +25,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	"rsc.io/omap"
 	"rsc.io/ordered"
 
@@ -50,7 +49,7 @@ func (s *inMemoryService) Create(ctx context.Context, req *CreateRequest) (*Crea
 
 	sessionID := req.SessionID
 	if sessionID == "" {
-		sessionID = uuid.NewString()
+		sessionID = NewID(ctx)
 	}
 
 	key := id{
@@ -64,7 +63,7 @@ func (s *inMemoryService) Create(ctx context.Context, req *CreateRequest) (*Crea
 	defer s.mu.Unlock()
 
 	if _, ok := s.sessions.Get(encodedKey); ok {
-		return nil, fmt.Errorf("session %s already exists", req.SessionID)
+		return nil, fmt.Errorf("session %s: %w", req.SessionID, ErrSessionAlreadyExists)
 	}
 
 	state := req.State
@@ -74,7 +73,7 @@ func (s *inMemoryService) Create(ctx context.Context, req *CreateRequest) (*Crea
 	val := &session{
 		id:        key,
 		state:     state,
-		updatedAt: time.Now(),
+		updatedAt: Now(ctx),
 	}
 
 	s.sessions.Set(encodedKey, val)
@@ -109,7 +108,7 @@ func (s *inMemoryService) Get(ctx context.Context, req *GetRequest) (*GetRespons
 
 	res, ok := s.sessions.Get(id.Encode())
 	if !ok {
-		return nil, fmt.Errorf("session %+v not found", req.SessionID)
+		return nil, fmt.Errorf("session %+v: %w", req.SessionID, ErrSessionNotFound)
 	}
 
 	copiedSession := copySessionWithoutStateAndEvents(res)
@@ -194,6 +193,40 @@ func (s *inMemoryService) Delete(ctx context.Context, req *DeleteRequest) error
 	return nil
 }
 
+// DeleteEvents implements EventDeleter.
+func (s *inMemoryService) DeleteEvents(ctx context.Context, req *DeleteEventsRequest) error {
+	appName, userID, sessionID := req.AppName, req.UserID, req.SessionID
+	if appName == "" || userID == "" || sessionID == "" {
+		return fmt.Errorf("app_name, user_id, session_id are required, got app_name: %q, user_id: %q, session_id: %q", appName, userID, sessionID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := id{
+		appName:   appName,
+		userID:    userID,
+		sessionID: sessionID,
+	}
+	stored, ok := s.sessions.Get(id.Encode())
+	if !ok {
+		return fmt.Errorf("session %+v: %w", sessionID, ErrSessionNotFound)
+	}
+	stored.events = slices.DeleteFunc(slices.Clone(stored.events), func(event *Event) bool {
+		return slices.Contains(req.EventIDs, event.ID)
+	})
+	return nil
+}
+
+func (s *inMemoryService) AppendEvents(ctx context.Context, curSession Session, events []*Event) error {
+	for _, event := range events {
+		if err := s.AppendEvent(ctx, curSession, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *inMemoryService) AppendEvent(ctx context.Context, curSession Session, event *Event) error {
 	if curSession == nil {
 		return fmt.Errorf("session is nil")
@@ -233,6 +266,7 @@ func (s *inMemoryService) AppendEvent(ctx context.Context, curSession Session, e
 			StateDelta:                 maps.Clone(event.Actions.StateDelta),
 			ArtifactDelta:              maps.Clone(event.Actions.ArtifactDelta),
 			RequestedToolConfirmations: maps.Clone(event.Actions.RequestedToolConfirmations),
+			RequestedAuthConfigs:       maps.Clone(event.Actions.RequestedAuthConfigs),
 			TransferToAgent:            event.Actions.TransferToAgent,
 			Escalate:                   event.Actions.Escalate,
 			SkipSummarization:          event.Actions.SkipSummarization,
@@ -344,6 +378,18 @@ func (s *session) LastUpdateTime() time.Time {
 	return s.updatedAt
 }
 
+// Clone implements Cloner.
+func (s *session) Clone() Session {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &session{
+		id:        s.id,
+		events:    slices.Clone(s.events),
+		state:     maps.Clone(s.state),
+		updatedAt: s.updatedAt,
+	}
+}
+
 func (s *session) appendEvent(event *Event) error {
 	if event.Partial {
 		return nil
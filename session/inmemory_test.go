@@ -15,6 +15,7 @@
 package session
 
 import (
+	"errors"
 	"maps"
 	"strconv"
 	"strings"
@@ -86,6 +87,9 @@ func Test_databaseService_Create(t *testing.T) {
 			}
 
 			if err != nil {
+				if !errors.Is(err, ErrSessionAlreadyExists) {
+					t.Errorf("Create() error = %v, want %v", err, ErrSessionAlreadyExists)
+				}
 				return
 			}
 
@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"fmt"
+)
+
+// Key identifies a session across apps and users.
+type Key struct {
+	AppName, UserID, SessionID string
+}
+
+// Locker serializes the invocations on the same session, see
+// runner.Config.SessionLocker.
+//
+// The runner locks sessions within the process by default. Runners in
+// multiple processes sharing the same session service need an implementation
+// backed by a distributed lock, e.g. database.NewSessionLocker.
+type Locker interface {
+	// Lock blocks until the lock on the session is acquired or ctx is done.
+	// The returned function releases the lock.
+	Lock(ctx context.Context, key Key) (unlock func(), err error)
+	// TryLock acquires the lock on the session without waiting. It returns a
+	// *BusyError if the lock is held.
+	TryLock(ctx context.Context, key Key) (unlock func(), err error)
+}
+
+// BusyError is returned by [Locker.TryLock] when another invocation holds
+// the lock on the session.
+type BusyError struct {
+	Key Key
+}
+
+func (e *BusyError) Error() string {
+	return fmt.Sprintf("session %q of user %q in app %q is busy", e.Key.SessionID, e.Key.UserID, e.Key.AppName)
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Providers generate the IDs and the timestamps of the sessions, events and
+// invocations. Tests and replays set deterministic providers, e.g.
+// [SequentialIDs] and [SteppingClock], to get stable output.
+//
+// The providers are carried by the context, see [WithProviders]. The runner
+// sets them with runner.Config.Providers, the session services use the
+// providers of the context of their calls.
+type Providers struct {
+	// NewID returns a new unique ID. Defaults to uuid.NewString.
+	NewID func() string
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+type providersKey struct{}
+
+// WithProviders returns a context carrying the providers. The unset
+// providers are inherited from ctx.
+func WithProviders(ctx context.Context, p Providers) context.Context {
+	parent, _ := ctx.Value(providersKey{}).(Providers)
+	if p.NewID == nil {
+		p.NewID = parent.NewID
+	}
+	if p.Now == nil {
+		p.Now = parent.Now
+	}
+	return context.WithValue(ctx, providersKey{}, p)
+}
+
+// NewID returns a new unique ID generated by the providers of ctx.
+func NewID(ctx context.Context) string {
+	if p, ok := ctx.Value(providersKey{}).(Providers); ok && p.NewID != nil {
+		return p.NewID()
+	}
+	return uuid.NewString()
+}
+
+// Now returns the current time according to the providers of ctx.
+func Now(ctx context.Context) time.Time {
+	if p, ok := ctx.Value(providersKey{}).(Providers); ok && p.Now != nil {
+		return p.Now()
+	}
+	return time.Now()
+}
+
+// SequentialIDs returns an ID provider generating prefix-1, prefix-2, etc.
+// It is safe for concurrent use.
+func SequentialIDs(prefix string) func() string {
+	var n atomic.Int64
+	return func() string {
+		return fmt.Sprintf("%s-%d", prefix, n.Add(1))
+	}
+}
+
+// SteppingClock returns a time provider returning start, then advancing by
+// step on every call. It is safe for concurrent use.
+func SteppingClock(start time.Time, step time.Duration) func() time.Time {
+	var mu sync.Mutex
+	next := start
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		now := next
+		next = next.Add(step)
+		return now
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProviders(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctx := WithProviders(t.Context(), Providers{
+		NewID: SequentialIDs("id"),
+		Now:   SteppingClock(start, time.Second),
+	})
+
+	event := NewEventWithContext(ctx, "invocation")
+	if event.ID != "id-1" || !event.Timestamp.Equal(start) {
+		t.Errorf("NewEventWithContext() = ID %q at %v, want id-1 at %v", event.ID, event.Timestamp, start)
+	}
+
+	// Unset providers are inherited.
+	ctx = WithProviders(ctx, Providers{NewID: SequentialIDs("other")})
+	event = NewEventWithContext(ctx, "invocation")
+	if want := start.Add(time.Second); event.ID != "other-1" || !event.Timestamp.Equal(want) {
+		t.Errorf("NewEventWithContext() = ID %q at %v, want other-1 at %v", event.ID, event.Timestamp, want)
+	}
+
+	resp, err := InMemoryService().Create(ctx, &CreateRequest{AppName: "app", UserID: "user"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got := resp.Session.ID(); got != "other-2" {
+		t.Errorf("Create() session ID = %q, want other-2", got)
+	}
+	if got, want := resp.Session.LastUpdateTime(), start.Add(2*time.Second); !got.Equal(want) {
+		t.Errorf("Create() LastUpdateTime = %v, want %v", got, want)
+	}
+}
+
+func TestNewEvent_DefaultProviders(t *testing.T) {
+	a, b := NewEvent("invocation"), NewEvent("invocation")
+	if a.ID == "" || a.ID == b.ID {
+		t.Errorf("NewEvent() IDs = %q, %q, want unique IDs", a.ID, b.ID)
+	}
+	if time.Since(a.Timestamp) > time.Minute {
+		t.Errorf("NewEvent() Timestamp = %v, want now", a.Timestamp)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrPruneNotSupported is returned by [Prune] when the service cannot
+// delete events, i.e. does not implement [EventDeleter].
+var ErrPruneNotSupported = errors.New("the session service cannot delete events")
+
+// EventDeleter is implemented by the services able to delete events of a
+// session, see [Prune].
+type EventDeleter interface {
+	// DeleteEvents deletes the events of the session. The state of the
+	// session is not changed. The events that do not exist are ignored.
+	DeleteEvents(context.Context, *DeleteEventsRequest) error
+}
+
+// DeleteEventsRequest represents a request to delete events of a session.
+type DeleteEventsRequest struct {
+	AppName   string
+	UserID    string
+	SessionID string
+	EventIDs  []string
+}
+
+// RetentionPolicy tells which events of a session are pruned. An event is
+// pruned if it is older than MaxAge or not among the MaxEvents most recent
+// events. The zero policy prunes nothing.
+type RetentionPolicy struct {
+	// MaxAge is the age of the oldest events kept. Optional: if zero, the
+	// events are kept whatever their age.
+	MaxAge time.Duration
+	// MaxEvents is the number of the most recent events kept. Optional: if
+	// zero, the events are kept whatever their number.
+	MaxEvents int
+}
+
+// Archive stores the events pruned from the sessions, e.g. in cold storage.
+type Archive interface {
+	// ArchiveEvents stores the events pruned from the session, in
+	// chronological order.
+	ArchiveEvents(ctx context.Context, sess Session, events []*Event) error
+}
+
+// ArchiveFunc is a function implementing [Archive].
+type ArchiveFunc func(ctx context.Context, sess Session, events []*Event) error
+
+// ArchiveEvents implements Archive.
+func (f ArchiveFunc) ArchiveEvents(ctx context.Context, sess Session, events []*Event) error {
+	return f(ctx, sess, events)
+}
+
+// PruneRequest represents a request to prune the events of a session.
+type PruneRequest struct {
+	AppName   string
+	UserID    string
+	SessionID string
+	Policy    RetentionPolicy
+	// Archive stores the pruned events before they are deleted.
+	// Optional: if nil, the events are deleted.
+	Archive Archive
+}
+
+// PruneResponse represents a response from [Prune].
+type PruneResponse struct {
+	// Pruned is the number of events pruned.
+	Pruned int
+}
+
+// Prune deletes the events of a session beyond its retention policy, after
+// storing them in the archive, if any. The conversation is cut between two
+// turns, a turn starting with a user message, so that the events kept never
+// start with the function responses or the answers to a pruned message: the
+// events of the turn of the oldest event to keep are kept.
+//
+// The state of the session is not changed: the state deltas of the pruned
+// events stay applied, which preserves the summaries kept in the state,
+// e.g. by the sessionsummaryplugin package.
+//
+// The service must implement [EventDeleter]; Prune returns
+// ErrPruneNotSupported otherwise.
+func Prune(ctx context.Context, s Service, req *PruneRequest) (*PruneResponse, error) {
+	deleter, ok := s.(EventDeleter)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrPruneNotSupported, s)
+	}
+	resp, err := s.Get(ctx, &GetRequest{AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID})
+	if err != nil {
+		return nil, err
+	}
+	sess := resp.Session
+
+	var events []*Event
+	for event := range sess.Events().All() {
+		events = append(events, event)
+	}
+	cut := pruneCut(events, req.Policy, Now(ctx))
+	if cut == 0 {
+		return &PruneResponse{}, nil
+	}
+	pruned := events[:cut]
+
+	if req.Archive != nil {
+		if err := req.Archive.ArchiveEvents(ctx, sess, pruned); err != nil {
+			return nil, fmt.Errorf("failed to archive the events of session %s: %w", req.SessionID, err)
+		}
+	}
+	ids := make([]string, len(pruned))
+	for i, event := range pruned {
+		ids[i] = event.ID
+	}
+	if err := deleter.DeleteEvents(ctx, &DeleteEventsRequest{AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID, EventIDs: ids}); err != nil {
+		return nil, fmt.Errorf("failed to delete the events of session %s: %w", req.SessionID, err)
+	}
+	return &PruneResponse{Pruned: len(pruned)}, nil
+}
+
+// pruneCut returns the number of the oldest events to prune.
+func pruneCut(events []*Event, policy RetentionPolicy, now time.Time) int {
+	cut := 0
+	if policy.MaxEvents > 0 && len(events) > policy.MaxEvents {
+		cut = len(events) - policy.MaxEvents
+	}
+	if policy.MaxAge > 0 {
+		oldest := now.Add(-policy.MaxAge)
+		for cut < len(events) && events[cut].Timestamp.Before(oldest) {
+			cut++
+		}
+	}
+	for cut > 0 && cut < len(events) && !startsTurn(events[cut]) {
+		cut--
+	}
+	return cut
+}
+
+// startsTurn reports whether the event is a message of the user, not a
+// function response.
+func startsTurn(event *Event) bool {
+	if event.Author != "user" || event.Content == nil {
+		return false
+	}
+	for _, part := range event.Content.Parts {
+		if part != nil && part.FunctionResponse != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// PruneAppRequest represents a request to prune the sessions of an app.
+type PruneAppRequest struct {
+	AppName string
+	Policy  RetentionPolicy
+	// Archive stores the pruned events before they are deleted.
+	// Optional: if nil, the events are deleted.
+	Archive Archive
+}
+
+// PruneAppResponse represents a response from [PruneApp].
+type PruneAppResponse struct {
+	// Sessions is the number of sessions pruned and Pruned the number of
+	// events pruned from them.
+	Sessions int
+	Pruned   int
+}
+
+// PruneApp prunes the events of all the sessions of an app, see [Prune].
+// It goes on after the failures to prune a session, and returns them
+// joined.
+func PruneApp(ctx context.Context, s Service, req *PruneAppRequest) (*PruneAppResponse, error) {
+	if _, ok := s.(EventDeleter); !ok {
+		return nil, fmt.Errorf("%w: %T", ErrPruneNotSupported, s)
+	}
+	list, err := s.List(ctx, &ListRequest{AppName: req.AppName})
+	if err != nil {
+		return nil, err
+	}
+	resp := &PruneAppResponse{}
+	var errs []error
+	for _, sess := range list.Sessions {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		pruned, err := Prune(ctx, s, &PruneRequest{
+			AppName:   req.AppName,
+			UserID:    sess.UserID(),
+			SessionID: sess.ID(),
+			Policy:    req.Policy,
+			Archive:   req.Archive,
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if pruned.Pruned > 0 {
+			resp.Sessions++
+			resp.Pruned += pruned.Pruned
+		}
+	}
+	return resp, errors.Join(errs...)
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// newPruneSession creates a session with two turns: a question answered with
+// a function call an hour ago, and a question answered a minute ago.
+func newPruneSession(t *testing.T, s Service, sessionID string, now time.Time) {
+	t.Helper()
+	ctx := t.Context()
+	created, err := s.Create(ctx, &CreateRequest{AppName: "app", UserID: "user", SessionID: sessionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, recent := now.Add(-time.Hour), now.Add(-time.Minute)
+	for _, e := range []struct {
+		id        string
+		author    string
+		content   *genai.Content
+		timestamp time.Time
+	}{
+		{"q1", "user", genai.NewContentFromText("weather?", genai.RoleUser), old},
+		{"call", "agent", genai.NewContentFromFunctionCall("weather", nil, genai.RoleModel), old},
+		{"response", "user", genai.NewContentFromFunctionResponse("weather", map[string]any{"sky": "clear"}, genai.RoleUser), old},
+		{"a1", "agent", genai.NewContentFromText("clear", genai.RoleModel), old},
+		{"q2", "user", genai.NewContentFromText("thanks", genai.RoleUser), recent},
+		{"a2", "agent", genai.NewContentFromText("welcome", genai.RoleModel), recent},
+	} {
+		event := NewEvent("inv")
+		event.ID = e.id
+		event.Author = e.author
+		event.Timestamp = e.timestamp
+		event.LLMResponse = model.LLMResponse{Content: e.content}
+		if e.id == "a1" {
+			event.Actions.StateDelta["summary"] = "the sky is clear"
+		}
+		if err := s.AppendEvent(ctx, created.Session, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func eventIDs(t *testing.T, s Service, sessionID string) []string {
+	t.Helper()
+	resp, err := s.Get(t.Context(), &GetRequest{AppName: "app", UserID: "user", SessionID: sessionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for event := range resp.Session.Events().All() {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+		want   []string
+	}{
+		{name: "zero policy", policy: RetentionPolicy{}, want: []string{"q1", "call", "response", "a1", "q2", "a2"}},
+		{name: "max age", policy: RetentionPolicy{MaxAge: 10 * time.Minute}, want: []string{"q2", "a2"}},
+		{name: "max events", policy: RetentionPolicy{MaxEvents: 2}, want: []string{"q2", "a2"}},
+		{
+			// The oldest event to keep answers a function response, its
+			// turn is kept.
+			name:   "turn kept",
+			policy: RetentionPolicy{MaxEvents: 3},
+			want:   []string{"q1", "call", "response", "a1", "q2", "a2"},
+		},
+		{name: "all events too old", policy: RetentionPolicy{MaxAge: time.Second}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithProviders(t.Context(), Providers{Now: func() time.Time { return now }})
+			s := InMemoryService()
+			newPruneSession(t, s, "session", now)
+			var archived []string
+			archive := ArchiveFunc(func(ctx context.Context, sess Session, events []*Event) error {
+				if sess.ID() != "session" {
+					t.Errorf("archived session = %q, want session", sess.ID())
+				}
+				for _, event := range events {
+					archived = append(archived, event.ID)
+				}
+				return nil
+			})
+
+			resp, err := Prune(ctx, s, &PruneRequest{AppName: "app", UserID: "user", SessionID: "session", Policy: tt.policy, Archive: archive})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := eventIDs(t, s, "session")
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("events kept mismatch (-want +got):\n%s", diff)
+			}
+			if resp.Pruned != 6-len(got) || len(archived) != resp.Pruned {
+				t.Errorf("Pruned = %d, archived %v, want the %d events pruned", resp.Pruned, archived, 6-len(got))
+			}
+			// The state deltas of the pruned events stay applied.
+			sess, err := s.Get(ctx, &GetRequest{AppName: "app", UserID: "user", SessionID: "session"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary, _ := sess.Session.State().Get("summary"); summary != "the sky is clear" {
+				t.Errorf("summary = %v, want the summary kept", summary)
+			}
+		})
+	}
+}
+
+func TestPrune_ArchiveFails(t *testing.T) {
+	s := InMemoryService()
+	newPruneSession(t, s, "session", time.Now())
+	archiveErr := errors.New("archive unavailable")
+	archive := ArchiveFunc(func(ctx context.Context, sess Session, events []*Event) error {
+		return archiveErr
+	})
+
+	_, err := Prune(t.Context(), s, &PruneRequest{AppName: "app", UserID: "user", SessionID: "session", Policy: RetentionPolicy{MaxEvents: 2}, Archive: archive})
+	if !errors.Is(err, archiveErr) {
+		t.Errorf("Prune() error = %v, want %v", err, archiveErr)
+	}
+	if n := len(eventIDs(t, s, "session")); n != 6 {
+		t.Errorf("session has %d events, want none deleted", n)
+	}
+}
+
+func TestPrune_NotSupported(t *testing.T) {
+	s := struct{ Service }{InMemoryService()}
+	_, err := Prune(t.Context(), s, &PruneRequest{AppName: "app", UserID: "user", SessionID: "session", Policy: RetentionPolicy{MaxEvents: 2}})
+	if !errors.Is(err, ErrPruneNotSupported) {
+		t.Errorf("Prune() error = %v, want %v", err, ErrPruneNotSupported)
+	}
+}
+
+func TestPruneApp(t *testing.T) {
+	s := InMemoryService()
+	now := time.Now()
+	newPruneSession(t, s, "s1", now)
+	newPruneSession(t, s, "s2", now)
+	if _, err := s.Create(t.Context(), &CreateRequest{AppName: "app", UserID: "other", SessionID: "empty"}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := PruneApp(t.Context(), s, &PruneAppRequest{AppName: "app", Policy: RetentionPolicy{MaxEvents: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Sessions != 2 || resp.Pruned != 8 {
+		t.Errorf("PruneApp() = %+v, want 8 events pruned from 2 sessions", resp)
+	}
+	for _, id := range []string{"s1", "s2"} {
+		if diff := cmp.Diff([]string{"q2", "a2"}, eventIDs(t, s, id)); diff != "" {
+			t.Errorf("events of %s mismatch (-want +got):\n%s", id, diff)
+		}
+	}
+}
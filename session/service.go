@@ -29,6 +29,10 @@ type Service interface {
 	Delete(context.Context, *DeleteRequest) error
 	// AppendEvent is used to append an event to a session, and remove temporary state keys from the event.
 	AppendEvent(context.Context, Session, *Event) error
+	// AppendEvents appends the events to a session in order, as AppendEvent
+	// does for each of them, in as few writes as the backend allows, e.g. a
+	// single transaction of the database service.
+	AppendEvents(context.Context, Session, []*Event) error
 }
 
 // InMemoryService returns an in-memory implementation of the session service.
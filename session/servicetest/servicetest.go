@@ -0,0 +1,234 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package servicetest provides conformance tests for the implementations of
+// session.Service.
+package servicetest
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"google.golang.org/adk/session"
+)
+
+// TestStateIsolation checks that the state of the sessions is scoped as
+// documented by the key prefixes: the app: keys are shared by the sessions
+// of an app, the user: keys by the sessions of a user in an app, the temp:
+// keys are not stored, and the other keys belong to their session. It runs
+// random sequences of creations and state changes across several apps,
+// users and sessions, and checks after every step that no session, read
+// with Get or List, observes the state of another app, user or session.
+//
+// newService must return an empty service.
+func TestStateIsolation(t *testing.T, newService func(t *testing.T) session.Service) {
+	for seed := range uint64(5) {
+		t.Run(fmt.Sprintf("seed=%d", seed), func(t *testing.T) {
+			c := &checker{
+				t:       t,
+				service: newService(t),
+				rnd:     rand.New(rand.NewPCG(seed, seed)),
+				model:   newModel(),
+			}
+			for step := range 40 {
+				c.step(step)
+				c.verify()
+			}
+		})
+	}
+}
+
+var (
+	apps  = []string{"app_a", "app_b"}
+	users = []string{"alice", "bob", "carol"}
+)
+
+type sessionKey struct {
+	app, user, id string
+}
+
+// model is the expected state of the service.
+type model struct {
+	app      map[string]map[string]any
+	user     map[string]map[string]map[string]any
+	sessions map[sessionKey]map[string]any
+}
+
+func newModel() *model {
+	return &model{
+		app:      make(map[string]map[string]any),
+		user:     make(map[string]map[string]map[string]any),
+		sessions: make(map[sessionKey]map[string]any),
+	}
+}
+
+func (m *model) apply(key sessionKey, delta map[string]any) {
+	for k, v := range delta {
+		switch {
+		case strings.HasPrefix(k, session.KeyPrefixApp):
+			if m.app[key.app] == nil {
+				m.app[key.app] = make(map[string]any)
+			}
+			m.app[key.app][k] = v
+		case strings.HasPrefix(k, session.KeyPrefixUser):
+			if m.user[key.app] == nil {
+				m.user[key.app] = make(map[string]map[string]any)
+			}
+			if m.user[key.app][key.user] == nil {
+				m.user[key.app][key.user] = make(map[string]any)
+			}
+			m.user[key.app][key.user][k] = v
+		case strings.HasPrefix(k, session.KeyPrefixTemp):
+		default:
+			m.sessions[key][k] = v
+		}
+	}
+}
+
+// state returns the state expected in the session.
+func (m *model) state(key sessionKey) map[string]any {
+	state := maps.Clone(m.sessions[key])
+	maps.Copy(state, m.app[key.app])
+	maps.Copy(state, m.user[key.app][key.user])
+	return state
+}
+
+type checker struct {
+	t       *testing.T
+	service session.Service
+	rnd     *rand.Rand
+	model   *model
+}
+
+// step creates a session or appends an event with a random state delta to
+// a random session.
+func (c *checker) step(step int) {
+	t := c.t
+	t.Helper()
+	if len(c.model.sessions) == 0 || c.rnd.IntN(4) == 0 {
+		key := sessionKey{app: pick(c.rnd, apps), user: pick(c.rnd, users), id: fmt.Sprintf("s%d", step)}
+		delta := c.delta(step, false)
+		c.model.sessions[key] = make(map[string]any)
+		if _, err := c.service.Create(t.Context(), &session.CreateRequest{AppName: key.app, UserID: key.user, SessionID: key.id, State: maps.Clone(delta)}); err != nil {
+			t.Fatalf("step %d: Create(%v) error = %v", step, key, err)
+		}
+		c.model.apply(key, delta)
+		return
+	}
+
+	keys := slices.SortedFunc(maps.Keys(c.model.sessions), func(a, b sessionKey) int {
+		return strings.Compare(a.app+a.user+a.id, b.app+b.user+b.id)
+	})
+	key := pick(c.rnd, keys)
+	resp, err := c.service.Get(t.Context(), &session.GetRequest{AppName: key.app, UserID: key.user, SessionID: key.id})
+	if err != nil {
+		t.Fatalf("step %d: Get(%v) error = %v", step, key, err)
+	}
+	delta := c.delta(step, true)
+	event := session.NewEvent(fmt.Sprintf("invocation-%d", step))
+	event.Author = "agent"
+	event.Timestamp = time.Now()
+	event.Actions.StateDelta = maps.Clone(delta)
+	if err := c.service.AppendEvent(t.Context(), resp.Session, event); err != nil {
+		t.Fatalf("step %d: AppendEvent(%v) error = %v", step, key, err)
+	}
+	c.model.apply(key, delta)
+}
+
+// delta returns a random state delta. The values identify the step, so that
+// a value leaked to another session is told apart from its own.
+func (c *checker) delta(step int, withTemp bool) map[string]any {
+	prefixes := []string{"", session.KeyPrefixApp, session.KeyPrefixUser}
+	if withTemp {
+		prefixes = append(prefixes, session.KeyPrefixTemp)
+	}
+	delta := make(map[string]any)
+	for range 1 + c.rnd.IntN(3) {
+		key := pick(c.rnd, prefixes) + pick(c.rnd, []string{"x", "y", "z"})
+		delta[key] = fmt.Sprintf("step-%d", step)
+	}
+	return delta
+}
+
+// verify checks the state of every session, read with Get and with List.
+func (c *checker) verify() {
+	t := c.t
+	t.Helper()
+	for key := range c.model.sessions {
+		resp, err := c.service.Get(t.Context(), &session.GetRequest{AppName: key.app, UserID: key.user, SessionID: key.id})
+		if err != nil {
+			t.Fatalf("Get(%v) error = %v", key, err)
+		}
+		if err := session.CheckScope(resp.Session, key.app, key.user, key.id); err != nil {
+			t.Fatalf("Get(%v): %v", key, err)
+		}
+		if diff := cmp.Diff(c.model.state(key), stateMap(resp.Session)); diff != "" {
+			t.Fatalf("Get(%v) state mismatch (-want +got):\n%s", key, diff)
+		}
+	}
+
+	for _, app := range apps {
+		for _, user := range append(slices.Clone(users), "") {
+			resp, err := c.service.List(t.Context(), &session.ListRequest{AppName: app, UserID: user})
+			if err != nil {
+				t.Fatalf("List(%q, %q) error = %v", app, user, err)
+			}
+			var got []string
+			for _, s := range resp.Sessions {
+				key := sessionKey{app: s.AppName(), user: s.UserID(), id: s.ID()}
+				if user != "" {
+					if err := session.CheckScope(s, app, user, ""); err != nil {
+						t.Fatalf("List(%q, %q): %v", app, user, err)
+					}
+				} else if key.app != app {
+					t.Fatalf("List(%q, all users) returned session %v of another app", app, key)
+				}
+				got = append(got, key.user+"/"+key.id)
+				if diff := cmp.Diff(c.model.state(key), stateMap(s)); diff != "" {
+					t.Fatalf("List(%q, %q) state of session %v mismatch (-want +got):\n%s", app, user, key, diff)
+				}
+			}
+			var want []string
+			for key := range c.model.sessions {
+				if key.app == app && (user == "" || key.user == user) {
+					want = append(want, key.user+"/"+key.id)
+				}
+			}
+			slices.Sort(got)
+			slices.Sort(want)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("List(%q, %q) sessions mismatch (-want +got):\n%s", app, user, diff)
+			}
+		}
+	}
+}
+
+func stateMap(s session.Session) map[string]any {
+	state := make(map[string]any)
+	for k, v := range s.State().All() {
+		state[k] = v
+	}
+	return state
+}
+
+func pick[T any](rnd *rand.Rand, values []T) T {
+	return values[rnd.IntN(len(values))]
+}
@@ -15,12 +15,13 @@
 package session
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"iter"
 	"time"
 
-	"github.com/google/uuid"
-
+	"google.golang.org/adk/auth"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/tool/toolconfirmation"
 )
@@ -45,6 +46,15 @@ type Session interface {
 	LastUpdateTime() time.Time
 }
 
+// Cloner is implemented by the sessions which can be copied, e.g. to be
+// served by a cache to several callers.
+type Cloner interface {
+	// Clone returns a copy of the session, which the service of the session
+	// accepts as the session itself. The state and the events of the copy
+	// are not shared with the session: they can be changed independently.
+	Clone() Session
+}
+
 // State defines a standard interface for a key-value store.
 // It provides basic methods for accessing, modifying, and iterating over
 // key-value pairs.
@@ -104,7 +114,9 @@ type Event struct {
 	// the parent of agent_2, and agent_2 is the parent of agent_3.
 	//
 	// Branch is used when multiple sub-agent shouldn't see their peer agents'
-	// conversation history.
+	// conversation history: the history sent to the model by an agent only
+	// includes the events of its branch, of the ancestors of its branch and
+	// the events without branch, e.g. the user messages.
 	Branch string
 	// Author is the name of the event's author
 	Author string
@@ -131,10 +143,16 @@ func (e *Event) IsFinalResponse() bool {
 
 // NewEvent creates a new event defining now as the timestamp.
 func NewEvent(invocationID string) *Event {
+	return NewEventWithContext(context.Background(), invocationID)
+}
+
+// NewEventWithContext creates a new event with the ID and the timestamp
+// generated by the providers of ctx, see [WithProviders].
+func NewEventWithContext(ctx context.Context, invocationID string) *Event {
 	return &Event{
-		ID:           uuid.NewString(),
+		ID:           NewID(ctx),
 		InvocationID: invocationID,
-		Timestamp:    time.Now(),
+		Timestamp:    Now(ctx),
 		Actions:      EventActions{StateDelta: make(map[string]any), ArtifactDelta: make(map[string]int64)},
 	}
 }
@@ -150,6 +168,10 @@ type EventActions struct {
 
 	RequestedToolConfirmations map[string]toolconfirmation.ToolConfirmation
 
+	// RequestedAuthConfigs holds the credentials requested by tools, by the
+	// ID of their function call. See tool.Context.RequestCredential.
+	RequestedAuthConfigs map[string]auth.Config
+
 	// If true, it won't call model to summarize function response.
 	// Only valid for function response event.
 	SkipSummarization bool
@@ -178,6 +200,36 @@ const (
 // ErrStateKeyNotExist is the error thrown when key does not exist.
 var ErrStateKeyNotExist = errors.New("state key does not exist")
 
+// ErrSessionNotFound is the error returned by [Service.Get] when the
+// requested session does not exist.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrSessionAlreadyExists is the error returned by [Service.Create] when a
+// session with the requested ID already exists.
+var ErrSessionAlreadyExists = errors.New("session already exists")
+
+// ErrEventNotFound is the error returned by [Fork] when the session has no
+// event with the requested ID.
+var ErrEventNotFound = errors.New("event not found")
+
+// ErrScopeViolation is the error returned by [CheckScope] when a service
+// returns the session of another app or user than requested, which would let
+// the user observe the state of another.
+var ErrScopeViolation = errors.New("session scope violation")
+
+// CheckScope returns an error wrapping [ErrScopeViolation] if s is not a
+// session of the app and user, and of the session ID if not empty. It guards
+// the callers of the services against the leaks of the sessions, and of the
+// user-scoped state they carry, across users.
+func CheckScope(s Session, appName, userID, sessionID string) error {
+	if s.AppName() != appName || s.UserID() != userID || sessionID != "" && s.ID() != sessionID {
+		// The identifiers of the other user are left out, the error may be
+		// returned to the user.
+		return fmt.Errorf("%w: the service returned a session other than the requested ones of user %q in app %q", ErrScopeViolation, userID, appName)
+	}
+	return nil
+}
+
 func hasFunctionCalls(resp *model.LLMResponse) bool {
 	if resp == nil || resp.Content == nil {
 		return false
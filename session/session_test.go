@@ -0,0 +1,48 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckScope(t *testing.T) {
+	s := &session{id: id{appName: "app", userID: "alice", sessionID: "s1"}}
+	tests := []struct {
+		name                     string
+		appName, userID, session string
+		wantErr                  bool
+	}{
+		{name: "same session", appName: "app", userID: "alice", session: "s1"},
+		{name: "any session of the user", appName: "app", userID: "alice"},
+		{name: "other user", appName: "app", userID: "bob", wantErr: true},
+		{name: "other app", appName: "other", userID: "alice", wantErr: true},
+		{name: "other session", appName: "app", userID: "alice", session: "s2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckScope(s, tt.appName, tt.userID, tt.session)
+			if gotErr := errors.Is(err, ErrScopeViolation); gotErr != tt.wantErr {
+				t.Fatalf("CheckScope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// The error must not reveal the session of the other user.
+			if err != nil && strings.Contains(err.Error(), "s1") {
+				t.Errorf("CheckScope() error = %q, reveals the returned session", err)
+			}
+		})
+	}
+}
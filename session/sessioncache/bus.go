@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessioncache
+
+import (
+	"context"
+	"sync"
+)
+
+// Key identifies the cached sessions to invalidate. A Key without SessionID
+// matches all the sessions of the user, e.g. when the user state changes,
+// and a Key without UserID all the sessions of the app.
+type Key struct {
+	AppName   string `json:"appName"`
+	UserID    string `json:"userId,omitempty"`
+	SessionID string `json:"sessionId,omitempty"`
+}
+
+// matches reports whether the session with the given key is invalidated by k.
+func (k Key) matches(session Key) bool {
+	return k.AppName == session.AppName &&
+		(k.UserID == "" || k.UserID == session.UserID) &&
+		(k.SessionID == "" || k.SessionID == session.SessionID)
+}
+
+// Bus broadcasts the invalidations of the caches of the replicas sharing a
+// session service, e.g. over Redis or Cloud Pub/Sub, so that a replica does
+// not serve a session modified by another one.
+type Bus interface {
+	// Publish sends the key of modified sessions to all the subscribers,
+	// the publisher included.
+	Publish(ctx context.Context, key Key) error
+	// Subscribe calls fn with the published keys until unsubscribe is
+	// called.
+	Subscribe(fn func(Key)) (unsubscribe func())
+}
+
+// LocalBus is a Bus delivering the keys to the subscribers of the process,
+// e.g. the caches of several session services over a shared database.
+type LocalBus struct {
+	mu          sync.RWMutex
+	next        int
+	subscribers map[int]func(Key)
+}
+
+// NewLocalBus returns a new LocalBus.
+func NewLocalBus() *LocalBus {
+	return &LocalBus{subscribers: map[int]func(Key){}}
+}
+
+// Publish implements Bus, calling the subscribers synchronously.
+func (b *LocalBus) Publish(ctx context.Context, key Key) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, fn := range b.subscribers {
+		fn(key)
+	}
+	return nil
+}
+
+// Subscribe implements Bus.
+func (b *LocalBus) Subscribe(fn func(Key)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.next
+	b.next++
+	b.subscribers[id] = fn
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, id)
+	}
+}
+
+var _ Bus = (*LocalBus)(nil)
@@ -0,0 +1,272 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sessioncache provides a read-through cache of the sessions of a
+// [session.Service], cutting the reads of the backend, e.g. a database, when
+// a session is read several times, as by the REST API checking a session
+// before running an agent in it.
+//
+// The cached sessions are invalidated when events are appended to them or
+// when they are deleted through the cache. In deployments with several
+// replicas sharing the backend, the invalidations are broadcast to the
+// caches of the other replicas with a [Bus].
+package sessioncache
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/session"
+)
+
+// Config is the configuration of a cache.
+type Config struct {
+	// Size is the maximum number of sessions cached, the least recently
+	// used are evicted. Defaults to 1000.
+	Size int // optional
+	// TTL is the time a session is served from the cache, bounding the
+	// staleness of the sessions modified by other replicas without Bus.
+	// Defaults to 1 minute.
+	TTL time.Duration // optional
+	// Bus broadcasts the invalidations to the caches of the other replicas.
+	Bus Bus // optional
+}
+
+// Service is a session.Service caching the sessions returned by Get.
+//
+// Each Get returns a copy of the cached session, so the callers do not
+// share sessions. The sessions of the backends which cannot be copied, not
+// implementing session.Cloner, are not cached. The Get requests filtering
+// the events, with NumRecentEvents or After, are not cached either.
+type Service struct {
+	session.Service
+	size        int
+	ttl         time.Duration
+	bus         Bus
+	unsubscribe func()
+
+	mu      sync.Mutex
+	entries map[Key]*list.Element
+	lru     *list.List
+	// epoch is incremented by each invalidation, so that a session read
+	// from the backend before an invalidation is not cached.
+	epoch uint64
+}
+
+type entry struct {
+	key     Key
+	session session.Cloner
+	expires time.Time
+}
+
+// New returns a Service caching the sessions of s.
+func New(s session.Service, cfg Config) *Service {
+	c := &Service{
+		Service: s,
+		size:    cmp.Or(cfg.Size, 1000),
+		ttl:     cmp.Or(cfg.TTL, time.Minute),
+		bus:     cfg.Bus,
+		entries: map[Key]*list.Element{},
+		lru:     list.New(),
+	}
+	if c.bus != nil {
+		c.unsubscribe = c.bus.Subscribe(c.invalidate)
+	}
+	return c
+}
+
+// Close stops receiving the invalidations of the Bus.
+func (c *Service) Close() error {
+	if c.unsubscribe != nil {
+		c.unsubscribe()
+	}
+	return nil
+}
+
+// Get returns the cached session, or reads it from the backend and caches
+// it.
+func (c *Service) Get(ctx context.Context, req *session.GetRequest) (*session.GetResponse, error) {
+	if req.NumRecentEvents > 0 || !req.After.IsZero() {
+		return c.Service.Get(ctx, req)
+	}
+	key := Key{AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID}
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry)
+		if time.Now().Before(e.expires) {
+			c.lru.MoveToFront(elem)
+			c.mu.Unlock()
+			return &session.GetResponse{Session: e.session.Clone()}, nil
+		}
+		c.remove(elem)
+	}
+	epoch := c.epoch
+	c.mu.Unlock()
+
+	resp, err := c.Service.Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	sess, ok := resp.Session.(session.Cloner)
+	if !ok {
+		return resp, nil
+	}
+	// The cache keeps its own copy, which is never handed out.
+	cached, ok := sess.Clone().(session.Cloner)
+	if !ok {
+		return resp, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.epoch == epoch {
+		c.add(key, cached)
+	}
+	return resp, nil
+}
+
+// Create creates the session in the backend, dropping a cached session with
+// the same ID.
+func (c *Service) Create(ctx context.Context, req *session.CreateRequest) (*session.CreateResponse, error) {
+	resp, err := c.Service.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	c.invalidate(Key{AppName: resp.Session.AppName(), UserID: resp.Session.UserID(), SessionID: resp.Session.ID()})
+	return resp, nil
+}
+
+// Delete deletes the session in the backend and invalidates it.
+func (c *Service) Delete(ctx context.Context, req *session.DeleteRequest) error {
+	key := Key{AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID}
+	c.invalidate(key)
+	err := c.Service.Delete(ctx, req)
+	c.invalidateAll(ctx, key)
+	return err
+}
+
+// DeleteEvents deletes the events in the backend and invalidates the
+// session. It implements session.EventDeleter, and fails if the backend
+// does not.
+func (c *Service) DeleteEvents(ctx context.Context, req *session.DeleteEventsRequest) error {
+	deleter, ok := c.Service.(session.EventDeleter)
+	if !ok {
+		return fmt.Errorf("%w: %T", session.ErrPruneNotSupported, c.Service)
+	}
+	key := Key{AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID}
+	c.invalidate(key)
+	err := deleter.DeleteEvents(ctx, req)
+	c.invalidateAll(ctx, key)
+	return err
+}
+
+// AppendEvent appends the event in the backend and invalidates the session,
+// or all the sessions of the user or of the app whose state the event
+// changes.
+func (c *Service) AppendEvent(ctx context.Context, sess session.Session, event *session.Event) error {
+	if event.Partial {
+		return c.Service.AppendEvent(ctx, sess, event)
+	}
+	key := invalidated(Key{AppName: sess.AppName(), UserID: sess.UserID(), SessionID: sess.ID()}, event)
+	// The session is invalidated before it is modified, so that it is not
+	// served while the event is appended, and after, so that it is not
+	// cached by a concurrent Get before the event is stored.
+	c.invalidate(key)
+	err := c.Service.AppendEvent(ctx, sess, event)
+	c.invalidateAll(ctx, key)
+	return err
+}
+
+// AppendEvents appends the events in the backend and invalidates the
+// sessions, as AppendEvent does.
+func (c *Service) AppendEvents(ctx context.Context, sess session.Session, events []*session.Event) error {
+	key := Key{AppName: sess.AppName(), UserID: sess.UserID(), SessionID: sess.ID()}
+	for _, event := range events {
+		key = invalidated(key, event)
+	}
+	c.invalidate(key)
+	err := c.Service.AppendEvents(ctx, sess, events)
+	c.invalidateAll(ctx, key)
+	return err
+}
+
+// invalidated returns the key of the sessions invalidated by appending the
+// event to the sessions of key: the sessions of the app or of the user whose
+// state the event changes.
+func invalidated(key Key, event *session.Event) Key {
+	if event == nil || event.Partial {
+		return key
+	}
+	for k := range event.Actions.StateDelta {
+		switch {
+		case strings.HasPrefix(k, session.KeyPrefixApp):
+			key.UserID, key.SessionID = "", ""
+		case strings.HasPrefix(k, session.KeyPrefixUser) && key.UserID != "":
+			key.SessionID = ""
+		}
+	}
+	return key
+}
+
+// invalidateAll invalidates the sessions of key in this cache and in the
+// caches of the other replicas.
+func (c *Service) invalidateAll(ctx context.Context, key Key) {
+	c.invalidate(key)
+	if c.bus == nil {
+		return
+	}
+	if err := c.bus.Publish(ctx, key); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to publish session cache invalidation", "app_name", key.AppName, "user_id", key.UserID, "session_id", key.SessionID, "error", err)
+	}
+}
+
+// invalidate drops the cached sessions matching key.
+func (c *Service) invalidate(key Key) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	if key.SessionID != "" && key.UserID != "" {
+		if elem, ok := c.entries[key]; ok {
+			c.remove(elem)
+		}
+		return
+	}
+	for k, elem := range c.entries {
+		if key.matches(k) {
+			c.remove(elem)
+		}
+	}
+}
+
+func (c *Service) add(key Key, sess session.Cloner) {
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(&entry{key: key, session: sess, expires: time.Now().Add(c.ttl)})
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Service) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*entry).key)
+}
+
+var _ session.Service = (*Service)(nil)
@@ -0,0 +1,213 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessioncache_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/session/sessioncache"
+)
+
+// countingService counts the reads of the backend.
+type countingService struct {
+	session.Service
+	gets atomic.Int64
+}
+
+func (s *countingService) Get(ctx context.Context, req *session.GetRequest) (*session.GetResponse, error) {
+	s.gets.Add(1)
+	return s.Service.Get(ctx, req)
+}
+
+func newBackend(t *testing.T, sessionIDs ...string) *countingService {
+	t.Helper()
+	backend := &countingService{Service: session.InMemoryService()}
+	for _, id := range sessionIDs {
+		if _, err := backend.Create(t.Context(), &session.CreateRequest{AppName: "app", UserID: "user", SessionID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return backend
+}
+
+func get(t *testing.T, s session.Service, sessionID string) session.Session {
+	t.Helper()
+	resp, err := s.Get(t.Context(), &session.GetRequest{AppName: "app", UserID: "user", SessionID: sessionID})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	return resp.Session
+}
+
+func appendEvent(t *testing.T, s session.Service, sess session.Session, stateDelta map[string]any) {
+	t.Helper()
+	event := session.NewEvent("invocation")
+	event.Author = "agent"
+	event.Actions.StateDelta = stateDelta
+	if err := s.AppendEvent(t.Context(), sess, event); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+}
+
+func TestService(t *testing.T) {
+	backend := newBackend(t, "s1", "s2")
+	cache := sessioncache.New(backend, sessioncache.Config{})
+	defer cache.Close()
+
+	get(t, cache, "s1")
+	get(t, cache, "s1")
+	if got := backend.gets.Load(); got != 1 {
+		t.Errorf("backend reads = %d, want 1", got)
+	}
+
+	// Filtered reads are not cached.
+	if _, err := cache.Get(t.Context(), &session.GetRequest{AppName: "app", UserID: "user", SessionID: "s1", NumRecentEvents: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if got := backend.gets.Load(); got != 2 {
+		t.Errorf("backend reads = %d, want the filtered read", got)
+	}
+
+	appendEvent(t, cache, get(t, cache, "s1"), map[string]any{"color": "blue"})
+	sess := get(t, cache, "s1")
+	if got := backend.gets.Load(); got != 3 {
+		t.Errorf("backend reads = %d, want the session read again after AppendEvent", got)
+	}
+	if sess.Events().Len() != 1 {
+		t.Errorf("session has %d events, want the appended event", sess.Events().Len())
+	}
+
+	// A user state change invalidates the other sessions of the user.
+	get(t, cache, "s2")
+	appendEvent(t, cache, sess, map[string]any{session.KeyPrefixUser + "lang": "fr"})
+	if got, err := get(t, cache, "s2").State().Get(session.KeyPrefixUser + "lang"); err != nil || got != "fr" {
+		t.Errorf("user state of another session = %v, %v, want the new value", got, err)
+	}
+
+	if err := cache.Delete(t.Context(), &session.DeleteRequest{AppName: "app", UserID: "user", SessionID: "s1"}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := cache.Get(t.Context(), &session.GetRequest{AppName: "app", UserID: "user", SessionID: "s1"}); !errors.Is(err, session.ErrSessionNotFound) {
+		t.Errorf("Get() of a deleted session error = %v, want session.ErrSessionNotFound", err)
+	}
+}
+
+func TestService_Copies(t *testing.T) {
+	backend := newBackend(t, "s1")
+	cache := sessioncache.New(backend, sessioncache.Config{})
+	defer cache.Close()
+
+	first := get(t, cache, "s1")
+	second := get(t, cache, "s1")
+	if first == second {
+		t.Fatal("Get() returned the same session twice, want a copy per Get")
+	}
+	if err := first.State().Set("color", "blue"); err != nil {
+		t.Fatal(err)
+	}
+	appendEvent(t, backend, second, map[string]any{"size": 1})
+	for name, sess := range map[string]session.Session{"second": second, "cached": get(t, cache, "s1")} {
+		if got, err := sess.State().Get("color"); !errors.Is(err, session.ErrStateKeyNotExist) {
+			t.Errorf("%s session state = %v, %v, want the change of another copy not to be seen", name, got, err)
+		}
+	}
+	if got := get(t, cache, "s1").Events().Len(); got != 0 {
+		t.Errorf("cached session has %d events, want none appended to another copy", got)
+	}
+	if got := backend.gets.Load(); got != 1 {
+		t.Errorf("backend reads = %d, want 1", got)
+	}
+}
+
+func TestService_Prune(t *testing.T) {
+	backend := session.InMemoryService()
+	if _, err := backend.Create(t.Context(), &session.CreateRequest{AppName: "app", UserID: "user", SessionID: "s1"}); err != nil {
+		t.Fatal(err)
+	}
+	cache := sessioncache.New(backend, sessioncache.Config{})
+	defer cache.Close()
+	for range 2 {
+		appendEvent(t, cache, get(t, cache, "s1"), nil)
+	}
+	get(t, cache, "s1")
+
+	resp, err := session.Prune(t.Context(), cache, &session.PruneRequest{AppName: "app", UserID: "user", SessionID: "s1", Policy: session.RetentionPolicy{MaxAge: time.Nanosecond}})
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if resp.Pruned != 2 {
+		t.Errorf("Pruned = %d, want 2", resp.Pruned)
+	}
+	if n := get(t, cache, "s1").Events().Len(); n != 0 {
+		t.Errorf("session has %d events, want the cached session invalidated", n)
+	}
+
+	// The backends that cannot delete events are reported.
+	cache = sessioncache.New(newBackend(t, "s1"), sessioncache.Config{})
+	defer cache.Close()
+	if err := cache.DeleteEvents(t.Context(), &session.DeleteEventsRequest{AppName: "app", UserID: "user", SessionID: "s1"}); !errors.Is(err, session.ErrPruneNotSupported) {
+		t.Errorf("DeleteEvents() error = %v, want session.ErrPruneNotSupported", err)
+	}
+}
+
+func TestService_Eviction(t *testing.T) {
+	backend := newBackend(t, "s1", "s2")
+	cache := sessioncache.New(backend, sessioncache.Config{Size: 1})
+	get(t, cache, "s1")
+	get(t, cache, "s2")
+	get(t, cache, "s1")
+	if got := backend.gets.Load(); got != 3 {
+		t.Errorf("backend reads = %d, want the least recently used session evicted", got)
+	}
+
+	backend = newBackend(t, "s1")
+	cache = sessioncache.New(backend, sessioncache.Config{TTL: time.Millisecond})
+	get(t, cache, "s1")
+	time.Sleep(2 * time.Millisecond)
+	get(t, cache, "s1")
+	if got := backend.gets.Load(); got != 2 {
+		t.Errorf("backend reads = %d, want the expired session read again", got)
+	}
+}
+
+func TestService_Bus(t *testing.T) {
+	// Two replicas sharing a backend.
+	backend := newBackend(t, "s1")
+	bus := sessioncache.NewLocalBus()
+	replica1 := sessioncache.New(backend, sessioncache.Config{Bus: bus})
+	defer replica1.Close()
+	replica2 := sessioncache.New(backend, sessioncache.Config{Bus: bus})
+	defer replica2.Close()
+
+	if got := get(t, replica2, "s1").Events().Len(); got != 0 {
+		t.Fatalf("session has %d events, want none", got)
+	}
+	appendEvent(t, replica1, get(t, replica1, "s1"), nil)
+	if got := get(t, replica2, "s1").Events().Len(); got != 1 {
+		t.Errorf("session read by the other replica has %d events, want the appended event", got)
+	}
+
+	replica2.Close()
+	get(t, replica2, "s1")
+	appendEvent(t, replica1, get(t, replica1, "s1"), nil)
+	if got := get(t, replica2, "s1").Events().Len(); got != 1 {
+		t.Errorf("session read by a closed replica has %d events, want the cached session", got)
+	}
+}
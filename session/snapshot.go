@@ -0,0 +1,184 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Snapshot is the session state at an event boundary, see [NewSnapshot].
+// It only holds the keys of the session scope: app and user state are
+// shared with other sessions and temporary state is discarded after each
+// invocation, so they are neither captured nor rolled back.
+type Snapshot struct {
+	SessionID string `json:"sessionId"`
+	// EventID is the ID of the last event applied to the state, empty if the
+	// snapshot precedes all the events.
+	EventID string         `json:"eventId,omitempty"`
+	State   map[string]any `json:"state"`
+	// Unknown are the keys of a snapshot of a past event whose value at the
+	// event cannot be rebuilt: first changed after the event, they either
+	// did not exist or had the value set at the creation of the session. A
+	// rollback leaves them unchanged; snapshots of the current state have
+	// none.
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// NewSnapshot returns the state of the session after the event with the
+// given ID, or its current state if the ID is empty, e.g. before a
+// multi-step tool workflow. The state at a past event is rebuilt from the
+// state deltas of the events, see [Snapshot.Unknown].
+//
+// It returns ErrEventNotFound if the session has no event with the given ID.
+func NewSnapshot(sess Session, eventID string) (*Snapshot, error) {
+	snapshot := &Snapshot{SessionID: sess.ID(), State: make(map[string]any)}
+	if eventID == "" {
+		for key, value := range sess.State().All() {
+			if isSessionKey(key) {
+				snapshot.State[key] = value
+			}
+		}
+		if n := sess.Events().Len(); n > 0 {
+			snapshot.EventID = sess.Events().At(n - 1).ID
+		}
+		return snapshot, nil
+	}
+
+	index := -1
+	changed := make(map[string]bool)
+	for i := range sess.Events().Len() {
+		event := sess.Events().At(i)
+		if event.ID == eventID && index < 0 {
+			index = i
+		}
+		for key := range event.Actions.StateDelta {
+			changed[key] = true
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("%w: %s in session %s", ErrEventNotFound, eventID, sess.ID())
+	}
+	snapshot.EventID = eventID
+	for key, value := range sess.State().All() {
+		if !changed[key] && isSessionKey(key) {
+			snapshot.State[key] = value
+		}
+	}
+	for i := range index + 1 {
+		for key, value := range sess.Events().At(i).Actions.StateDelta {
+			if isSessionKey(key) {
+				snapshot.State[key] = value
+			}
+		}
+	}
+	for key := range changed {
+		if _, ok := snapshot.State[key]; !ok && isSessionKey(key) {
+			snapshot.Unknown = append(snapshot.Unknown, key)
+		}
+	}
+	slices.Sort(snapshot.Unknown)
+	return snapshot, nil
+}
+
+// RollbackDelta returns the state delta reverting the state to the
+// snapshot: the keys whose value changed are reset and the keys added since
+// the snapshot are set to nil, as state keys cannot be deleted. Tools can
+// add it to the state delta of their actions to revert the changes of a
+// failed workflow within an invocation.
+func (s *Snapshot) RollbackDelta(state ReadonlyState) map[string]any {
+	delta := make(map[string]any)
+	current := make(map[string]any)
+	for key, value := range state.All() {
+		if !isSessionKey(key) || slices.Contains(s.Unknown, key) {
+			continue
+		}
+		current[key] = value
+		if want, ok := s.State[key]; !ok {
+			if value != nil {
+				delta[key] = nil
+			}
+		} else if !reflect.DeepEqual(value, want) {
+			delta[key] = want
+		}
+	}
+	for key, want := range s.State {
+		if _, ok := current[key]; !ok {
+			delta[key] = want
+		}
+	}
+	return delta
+}
+
+// RollbackRequest represents a request to roll back the state of a session
+// to a snapshot.
+type RollbackRequest struct {
+	AppName   string
+	UserID    string
+	SessionID string
+	Snapshot  *Snapshot
+	// InvocationID and Author are set on the compensating event.
+	// Optional: the author defaults to "user".
+	InvocationID string
+	Author       string
+}
+
+// RollbackResponse represents a response from [Rollback].
+type RollbackResponse struct {
+	// Event is the compensating event appended to the session, nil if the
+	// state already matched the snapshot.
+	Event   *Event
+	Session Session
+}
+
+// Rollback reverts the state of a session to a snapshot by appending a
+// compensating event whose state delta is [Snapshot.RollbackDelta]. The
+// events are kept, so the history records the rollback. It works with any
+// [Service].
+func Rollback(ctx context.Context, s Service, req *RollbackRequest) (*RollbackResponse, error) {
+	if req.Snapshot == nil {
+		return nil, fmt.Errorf("snapshot is nil")
+	}
+	if req.Snapshot.SessionID != req.SessionID {
+		return nil, fmt.Errorf("snapshot of session %s cannot roll back session %s", req.Snapshot.SessionID, req.SessionID)
+	}
+	resp, err := s.Get(ctx, &GetRequest{AppName: req.AppName, UserID: req.UserID, SessionID: req.SessionID})
+	if err != nil {
+		return nil, err
+	}
+	sess := resp.Session
+
+	delta := req.Snapshot.RollbackDelta(sess.State())
+	if len(delta) == 0 {
+		return &RollbackResponse{Session: sess}, nil
+	}
+	event := NewEventWithContext(ctx, req.InvocationID)
+	event.Author = req.Author
+	if event.Author == "" {
+		event.Author = "user"
+	}
+	event.Actions.StateDelta = delta
+	if err := s.AppendEvent(ctx, sess, event); err != nil {
+		return nil, fmt.Errorf("failed to append the rollback event: %w", err)
+	}
+	return &RollbackResponse{Event: event, Session: sess}, nil
+}
+
+func isSessionKey(key string) bool {
+	return !strings.HasPrefix(key, KeyPrefixApp) && !strings.HasPrefix(key, KeyPrefixUser) && !strings.HasPrefix(key, KeyPrefixTemp)
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSnapshotRollback(t *testing.T) {
+	ctx := t.Context()
+	s := InMemoryService()
+	created, err := s.Create(ctx, &CreateRequest{
+		AppName:   "app",
+		UserID:    "user",
+		SessionID: "session",
+		State:     map[string]any{"initial": "kept", "step": 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess := created.Session
+	appendDelta := func(id string, delta map[string]any) {
+		t.Helper()
+		event := NewEvent("inv")
+		event.ID = id
+		event.Author = "agent"
+		event.Actions.StateDelta = delta
+		if err := s.AppendEvent(ctx, sess, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	appendDelta("first", map[string]any{"step": 1})
+
+	snapshot, err := NewSnapshot(sess, "")
+	if err != nil {
+		t.Fatalf("NewSnapshot() error = %v", err)
+	}
+	want := &Snapshot{SessionID: "session", EventID: "first", State: map[string]any{"initial": "kept", "step": 1}}
+	if diff := cmp.Diff(want, snapshot); diff != "" {
+		t.Errorf("NewSnapshot() mismatch (-want +got):\n%s", diff)
+	}
+
+	appendDelta("second", map[string]any{"step": 2, "booking": "pending", "user:name": "Ada"})
+	appendDelta("third", map[string]any{"step": 3, "initial": "changed"})
+
+	// The snapshot of a past event cannot tell the value of the keys first
+	// changed after it.
+	past, err := NewSnapshot(sess, "first")
+	if err != nil {
+		t.Fatalf("NewSnapshot() error = %v", err)
+	}
+	want = &Snapshot{SessionID: "session", EventID: "first", State: map[string]any{"step": 1}, Unknown: []string{"booking", "initial"}}
+	if diff := cmp.Diff(want, past); diff != "" {
+		t.Errorf("NewSnapshot() of a past event mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"step": 1}, past.RollbackDelta(sess.State())); diff != "" {
+		t.Errorf("RollbackDelta() of a past snapshot mismatch (-want +got):\n%s", diff)
+	}
+
+	resp, err := Rollback(ctx, s, &RollbackRequest{AppName: "app", UserID: "user", SessionID: "session", Snapshot: snapshot})
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	wantDelta := map[string]any{"step": 1, "initial": "kept", "booking": nil}
+	if diff := cmp.Diff(wantDelta, resp.Event.Actions.StateDelta); diff != "" {
+		t.Errorf("rollback event state delta mismatch (-want +got):\n%s", diff)
+	}
+	if resp.Event.Author != "user" {
+		t.Errorf("rollback event author = %q, want user", resp.Event.Author)
+	}
+
+	got, err := s.Get(ctx, &GetRequest{AppName: "app", UserID: "user", SessionID: "session"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]any{"initial": "kept", "step": 1, "booking": nil, "user:name": "Ada"} {
+		if value, err := got.Session.State().Get(key); err != nil || value != want {
+			t.Errorf("State().Get(%q) = %v, %v, want %v", key, value, err, want)
+		}
+	}
+	if n := got.Session.Events().Len(); n != 4 {
+		t.Errorf("session has %d events, want 4", n)
+	}
+
+	// The state matches the snapshot, so nothing is appended.
+	resp, err = Rollback(ctx, s, &RollbackRequest{AppName: "app", UserID: "user", SessionID: "session", Snapshot: snapshot})
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if resp.Event != nil {
+		t.Errorf("Rollback() appended %+v, want no event", resp.Event)
+	}
+
+	if _, err := NewSnapshot(sess, "unknown"); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("NewSnapshot() error = %v, want %v", err, ErrEventNotFound)
+	}
+	if _, err := Rollback(ctx, s, &RollbackRequest{AppName: "app", UserID: "user", SessionID: "other", Snapshot: snapshot}); err == nil {
+		t.Error("Rollback() of another session succeeded, want error")
+	}
+}
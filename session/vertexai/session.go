@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"iter"
 	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -68,6 +69,20 @@ func (s *localSession) LastUpdateTime() time.Time {
 	return s.updatedAt
 }
 
+// Clone implements session.Cloner.
+func (s *localSession) Clone() session.Session {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &localSession{
+		appName:   s.appName,
+		userID:    s.userID,
+		sessionID: s.sessionID,
+		events:    slices.Clone(s.events),
+		state:     maps.Clone(s.state),
+		updatedAt: s.updatedAt,
+	}
+}
+
 func (s *localSession) appendEvent(event *session.Event) error {
 	if event.Partial {
 		return nil
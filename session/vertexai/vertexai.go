@@ -126,6 +126,17 @@ func (s *vertexAiService) Delete(ctx context.Context, req *session.DeleteRequest
 	return nil
 }
 
+// AppendEvents appends the events one by one, as the Vertex AI API has no
+// batch append.
+func (s *vertexAiService) AppendEvents(ctx context.Context, sess session.Session, events []*session.Event) error {
+	for _, event := range events {
+		if err := s.AppendEvent(ctx, sess, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *vertexAiService) AppendEvent(ctx context.Context, sess session.Session, event *session.Event) error {
 	if sess.ID() == "" || event == nil {
 		return fmt.Errorf("session_id and event are required, got session_id: %q, event_id: %t", sess.ID(), event == nil)
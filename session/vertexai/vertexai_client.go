@@ -142,11 +142,14 @@ func (c *vertexAiClient) getSession(ctx context.Context, req *session.GetRequest
 	}
 	sessRpcResp, err := c.rpcClient.GetSession(ctx, sessRpcReq)
 	if err != nil {
+		if isNotFoundError(err) {
+			return nil, fmt.Errorf("error fetching session: %w: %w", session.ErrSessionNotFound, err)
+		}
 		return nil, fmt.Errorf("error fetching session: %w", err)
 	}
 
 	if sessRpcResp == nil {
-		return nil, fmt.Errorf("session %+v not found", req.SessionID)
+		return nil, fmt.Errorf("session %+v: %w", req.SessionID, session.ErrSessionNotFound)
 	}
 	if sessRpcResp.UserId != req.UserID {
 		return nil, fmt.Errorf("session %s does not belong to user %s", req.SessionID, req.UserID)
@@ -0,0 +1,172 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package googlespeech implements the speech components with the Google Cloud
+// Speech-to-Text and Text-to-Speech APIs.
+package googlespeech
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/option"
+	speechapi "google.golang.org/api/speech/v1"
+	ttsapi "google.golang.org/api/texttospeech/v1"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/speech"
+)
+
+// SpeechToTextConfig configures the Speech-to-Text API requests.
+type SpeechToTextConfig struct {
+	// LanguageCode is the BCP-47 language of the audio, e.g. "en-US".
+	LanguageCode string
+	// Model is the transcription model, e.g. "latest_short".
+	Model string // optional
+}
+
+// NewSpeechToText returns a speech.SpeechToText transcribing the audio with
+// the Google Cloud Speech-to-Text API. The audio is sent in a single
+// recognize request, which is limited to one minute of audio.
+func NewSpeechToText(ctx context.Context, cfg SpeechToTextConfig, opts ...option.ClientOption) (speech.SpeechToText, error) {
+	if cfg.LanguageCode == "" {
+		return nil, fmt.Errorf("LanguageCode is required")
+	}
+	svc, err := speechapi.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Speech-to-Text client: %w", err)
+	}
+	return &speechToText{svc: svc, cfg: cfg}, nil
+}
+
+type speechToText struct {
+	svc *speechapi.Service
+	cfg SpeechToTextConfig
+}
+
+func (s *speechToText) Transcribe(ctx context.Context, audio *genai.Blob) (string, error) {
+	encoding, sampleRate := recognitionEncoding(audio.MIMEType)
+	resp, err := s.svc.Speech.Recognize(&speechapi.RecognizeRequest{
+		Config: &speechapi.RecognitionConfig{
+			Encoding:        encoding,
+			SampleRateHertz: sampleRate,
+			LanguageCode:    s.cfg.LanguageCode,
+			Model:           s.cfg.Model,
+		},
+		Audio: &speechapi.RecognitionAudio{Content: base64.StdEncoding.EncodeToString(audio.Data)},
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to recognize the speech: %w", err)
+	}
+	var texts []string
+	for _, result := range resp.Results {
+		if len(result.Alternatives) > 0 {
+			texts = append(texts, strings.TrimSpace(result.Alternatives[0].Transcript))
+		}
+	}
+	return strings.Join(texts, " "), nil
+}
+
+// recognitionEncoding returns the encoding and the sample rate of the audio
+// of the MIME type, e.g. "audio/pcm;rate=16000". The sample rate is zero
+// when the API detects it.
+func recognitionEncoding(mimeType string) (string, int64) {
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return "ENCODING_UNSPECIFIED", 0
+	}
+	rate, _ := strconv.ParseInt(params["rate"], 10, 64)
+	switch mediaType {
+	case "audio/pcm", "audio/l16":
+		if rate == 0 {
+			rate = 16000
+		}
+		return "LINEAR16", rate
+	case "audio/flac":
+		return "FLAC", rate
+	case "audio/ogg":
+		return "OGG_OPUS", rate
+	case "audio/webm":
+		return "WEBM_OPUS", rate
+	case "audio/mpeg", "audio/mp3":
+		return "MP3", rate
+	case "audio/basic", "audio/mulaw":
+		return "MULAW", rate
+	}
+	// The encoding of the WAV files is read from their header.
+	return "ENCODING_UNSPECIFIED", rate
+}
+
+// TextToSpeechConfig configures the Text-to-Speech API requests.
+type TextToSpeechConfig struct {
+	// LanguageCode is the BCP-47 language of the voice, e.g. "en-US". It is
+	// overridden by the LanguageCode of the genai.SpeechConfig of the
+	// request.
+	LanguageCode string
+	// VoiceName is the name of the voice, e.g. "en-US-Chirp3-HD-Kore".
+	// Defaults to a voice of the language chosen by the API.
+	VoiceName string // optional
+	// SampleRate is the sample rate of the synthesized audio in Hz.
+	// Defaults to 24000.
+	SampleRate int // optional
+}
+
+// NewTextToSpeech returns a speech.TextToSpeech synthesizing the text with
+// the Google Cloud Text-to-Speech API. The audio is 16-bit PCM, e.g. of MIME
+// type "audio/pcm;rate=24000", as streamed by the live models.
+func NewTextToSpeech(ctx context.Context, cfg TextToSpeechConfig, opts ...option.ClientOption) (speech.TextToSpeech, error) {
+	if cfg.LanguageCode == "" {
+		return nil, fmt.Errorf("LanguageCode is required")
+	}
+	if cfg.SampleRate == 0 {
+		cfg.SampleRate = 24000
+	}
+	svc, err := ttsapi.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Text-to-Speech client: %w", err)
+	}
+	return &textToSpeech{svc: svc, cfg: cfg}, nil
+}
+
+type textToSpeech struct {
+	svc *ttsapi.Service
+	cfg TextToSpeechConfig
+}
+
+func (s *textToSpeech) Synthesize(ctx context.Context, text string, cfg *genai.SpeechConfig) (*genai.Blob, error) {
+	voice := &ttsapi.VoiceSelectionParams{LanguageCode: s.cfg.LanguageCode, Name: s.cfg.VoiceName}
+	if cfg != nil && cfg.LanguageCode != "" {
+		voice.LanguageCode = cfg.LanguageCode
+	}
+	resp, err := s.svc.Text.Synthesize(&ttsapi.SynthesizeSpeechRequest{
+		Input: &ttsapi.SynthesisInput{Text: text},
+		Voice: voice,
+		AudioConfig: &ttsapi.AudioConfig{
+			AudioEncoding:   "PCM",
+			SampleRateHertz: int64(s.cfg.SampleRate),
+		},
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to synthesize the speech: %w", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.AudioContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the audio: %w", err)
+	}
+	return &genai.Blob{MIMEType: fmt.Sprintf("audio/pcm;rate=%d", s.cfg.SampleRate), Data: data}, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlespeech_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/speech/googlespeech"
+)
+
+// newServer returns a server answering the requests to path with resp, and
+// storing their body in req.
+func newServer(t *testing.T, path string, req *map[string]any, resp any) []option.ClientOption {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("request path = %q, want %q", r.URL.Path, path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Errorf("failed to decode the request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return []option.ClientOption{option.WithEndpoint(srv.URL), option.WithoutAuthentication()}
+}
+
+func TestSpeechToText(t *testing.T) {
+	var req map[string]any
+	opts := newServer(t, "/v1/speech:recognize", &req, map[string]any{
+		"results": []any{
+			map[string]any{"alternatives": []any{map[string]any{"transcript": "What is"}}},
+			map[string]any{"alternatives": []any{map[string]any{"transcript": " the weather?"}}},
+		},
+	})
+	stt, err := googlespeech.NewSpeechToText(t.Context(), googlespeech.SpeechToTextConfig{LanguageCode: "en-US"}, opts...)
+	if err != nil {
+		t.Fatalf("NewSpeechToText() error = %v", err)
+	}
+
+	got, err := stt.Transcribe(t.Context(), &genai.Blob{MIMEType: "audio/pcm;rate=24000", Data: []byte{1, 2}})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if want := "What is the weather?"; got != want {
+		t.Errorf("Transcribe() = %q, want %q", got, want)
+	}
+	wantReq := map[string]any{
+		"config": map[string]any{"encoding": "LINEAR16", "sampleRateHertz": float64(24000), "languageCode": "en-US"},
+		"audio":  map[string]any{"content": base64.StdEncoding.EncodeToString([]byte{1, 2})},
+	}
+	if diff := cmp.Diff(wantReq, req); diff != "" {
+		t.Errorf("request mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTextToSpeech(t *testing.T) {
+	var req map[string]any
+	opts := newServer(t, "/v1/text:synthesize", &req, map[string]any{
+		"audioContent": base64.StdEncoding.EncodeToString([]byte{3, 4}),
+	})
+	tts, err := googlespeech.NewTextToSpeech(t.Context(), googlespeech.TextToSpeechConfig{LanguageCode: "en-US", VoiceName: "en-US-Chirp3-HD-Kore"}, opts...)
+	if err != nil {
+		t.Fatalf("NewTextToSpeech() error = %v", err)
+	}
+
+	got, err := tts.Synthesize(t.Context(), "It is sunny.", &genai.SpeechConfig{LanguageCode: "en-GB"})
+	if err != nil {
+		t.Fatalf("Synthesize() error = %v", err)
+	}
+	if diff := cmp.Diff(&genai.Blob{MIMEType: "audio/pcm;rate=24000", Data: []byte{3, 4}}, got); diff != "" {
+		t.Errorf("Synthesize() mismatch (-want +got):\n%s", diff)
+	}
+	wantReq := map[string]any{
+		"input":       map[string]any{"text": "It is sunny."},
+		"voice":       map[string]any{"languageCode": "en-GB", "name": "en-US-Chirp3-HD-Kore"},
+		"audioConfig": map[string]any{"audioEncoding": "PCM", "sampleRateHertz": float64(24000)},
+	}
+	if diff := cmp.Diff(wantReq, req); diff != "" {
+		t.Errorf("request mismatch (-want +got):\n%s", diff)
+	}
+}
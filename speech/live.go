@@ -0,0 +1,327 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speech
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+)
+
+// ErrConnectionClosed is returned when sending to a closed live connection of
+// a model created by [NewLiveModel].
+var ErrConnectionClosed = errors.New("live connection closed")
+
+// Config configures the speech components of [NewLiveModel].
+type Config struct {
+	// SpeechToText transcribes the audio of the user. Without it, the user
+	// can only send text.
+	SpeechToText SpeechToText // optional
+	// TextToSpeech synthesizes the responses of the model when the audio
+	// response modality is requested, or no modality at all. Without it,
+	// the model responds with text.
+	TextToSpeech TextToSpeech // optional
+}
+
+// NewLiveModel returns a live model on top of a model without live support:
+// the audio of the user is transcribed and sent to the model as a text turn,
+// and the text responses of the model are synthesized to audio.
+//
+// The turns of the user are processed one after the other, each with a
+// GenerateContent call to the model. Automatic activity detection is not
+// supported: the audio of the user is buffered until the end of its activity
+// is signaled, e.g. with agent.LiveRequestQueue.SendActivityEnd. Video input
+// is not supported.
+func NewLiveModel(llm model.LLM, cfg Config) model.LiveLLM {
+	return &liveModel{LLM: llm, cfg: cfg}
+}
+
+type liveModel struct {
+	model.LLM
+	cfg Config
+}
+
+func (m *liveModel) ConnectLive(ctx context.Context, req *model.LLMRequest) (model.LiveConnection, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	c := &liveConnection{
+		llm:       m.LLM,
+		cfg:       m.cfg,
+		req:       req,
+		history:   slices.Clone(req.Contents),
+		cancel:    cancel,
+		wake:      make(chan struct{}, 1),
+		responses: make(chan liveResult),
+	}
+	if lc := req.LiveConnectConfig; lc != nil {
+		c.audioOut = m.cfg.TextToSpeech != nil && (len(lc.ResponseModalities) == 0 || slices.Contains(lc.ResponseModalities, genai.ModalityAudio))
+		c.speechConfig = lc.SpeechConfig
+		c.inputTranscription = lc.InputAudioTranscription != nil
+		c.outputTranscription = lc.OutputAudioTranscription != nil
+	} else {
+		c.audioOut = m.cfg.TextToSpeech != nil
+	}
+	go c.run(ctx)
+	return c, nil
+}
+
+// liveInput is a turn of the user, or the responses to the function calls of
+// the model.
+type liveInput struct {
+	content *genai.Content
+	audio   *genai.Blob
+}
+
+type liveResult struct {
+	resp *model.LLMResponse
+	err  error
+}
+
+type liveConnection struct {
+	llm          model.LLM
+	cfg          Config
+	req          *model.LLMRequest
+	audioOut     bool
+	speechConfig *genai.SpeechConfig
+
+	inputTranscription, outputTranscription bool
+
+	// history is only used by run.
+	history []*genai.Content
+
+	mu sync.Mutex
+	// audio is the audio of the user since the start of its activity.
+	audio   *genai.Blob
+	pending []liveInput
+	closed  bool
+
+	cancel    context.CancelFunc
+	wake      chan struct{}
+	responses chan liveResult
+}
+
+func (c *liveConnection) SendContent(content *genai.Content) error {
+	return c.push(liveInput{content: content})
+}
+
+func (c *liveConnection) SendRealtime(input genai.LiveRealtimeInput) error {
+	switch {
+	case input.Text != "":
+		return c.push(liveInput{content: genai.NewContentFromText(input.Text, genai.RoleUser)})
+	case input.Audio != nil || input.Media != nil && strings.HasPrefix(input.Media.MIMEType, "audio/"):
+		audio := input.Audio
+		if audio == nil {
+			audio = input.Media
+		}
+		if c.cfg.SpeechToText == nil {
+			return errors.New("audio input requires a SpeechToText")
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.audio == nil {
+			c.audio = &genai.Blob{MIMEType: audio.MIMEType}
+		}
+		c.audio.Data = append(c.audio.Data, audio.Data...)
+		return nil
+	case input.ActivityStart != nil:
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.audio = nil
+		return nil
+	case input.ActivityEnd != nil || input.AudioStreamEnd:
+		c.mu.Lock()
+		audio := c.audio
+		c.audio = nil
+		c.mu.Unlock()
+		if audio == nil {
+			return nil
+		}
+		return c.push(liveInput{audio: audio})
+	case input.Video != nil || input.Media != nil:
+		return errors.New("video input is not supported")
+	}
+	return nil
+}
+
+func (c *liveConnection) Receive() iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		for r := range c.responses {
+			if !yield(r.resp, r.err) {
+				return
+			}
+		}
+	}
+}
+
+func (c *liveConnection) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.cancel()
+	return nil
+}
+
+// push queues the input for run.
+func (c *liveConnection) push(input liveInput) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrConnectionClosed
+	}
+	c.pending = append(c.pending, input)
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// run processes the inputs in order until the connection is closed.
+func (c *liveConnection) run(ctx context.Context) {
+	defer close(c.responses)
+	for {
+		select {
+		case <-c.wake:
+		case <-ctx.Done():
+			return
+		}
+		c.mu.Lock()
+		inputs := c.pending
+		c.pending = nil
+		c.mu.Unlock()
+		for _, input := range inputs {
+			if err := c.process(ctx, input); err != nil {
+				if ctx.Err() == nil {
+					c.send(ctx, liveResult{err: err})
+				}
+				return
+			}
+		}
+	}
+}
+
+// process sends the input to the model and its responses to the receiver.
+func (c *liveConnection) process(ctx context.Context, input liveInput) error {
+	content := input.content
+	if input.audio != nil {
+		text, err := c.cfg.SpeechToText.Transcribe(ctx, input.audio)
+		if err != nil {
+			return fmt.Errorf("failed to transcribe the audio: %w", err)
+		}
+		if c.inputTranscription && !c.send(ctx, liveResult{resp: &model.LLMResponse{
+			InputTranscription: &genai.Transcription{Text: text, Finished: true},
+		}}) {
+			return ctx.Err()
+		}
+		if strings.TrimSpace(text) == "" {
+			return nil
+		}
+		content = genai.NewContentFromText(text, genai.RoleUser)
+	}
+	c.history = append(c.history, content)
+
+	req := &model.LLMRequest{
+		Model:    c.req.Model,
+		Contents: slices.Clone(c.history),
+		Config:   c.req.Config,
+		Tools:    c.req.Tools,
+	}
+	var resp *model.LLMResponse
+	for r, err := range c.llm.GenerateContent(ctx, req, false) {
+		if err != nil {
+			return err
+		}
+		resp = r
+	}
+	if resp == nil {
+		return nil
+	}
+	if resp.Content != nil {
+		c.history = append(c.history, resp.Content)
+	}
+	responses, err := c.liveResponses(ctx, resp)
+	if err != nil {
+		return err
+	}
+	for _, r := range responses {
+		if !c.send(ctx, liveResult{resp: r}) {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// liveResponses converts the response of the model to the responses of a
+// live model: the text is synthesized in the audio mode and the turn
+// completes unless the model calls functions.
+func (c *liveConnection) liveResponses(ctx context.Context, resp *model.LLMResponse) ([]*model.LLMResponse, error) {
+	var text strings.Builder
+	var calls []*genai.Part
+	if resp.Content != nil {
+		for _, part := range resp.Content.Parts {
+			switch {
+			case part.FunctionCall != nil:
+				calls = append(calls, part)
+			case !part.Thought:
+				text.WriteString(part.Text)
+			}
+		}
+	}
+	if !c.audioOut || text.Len() == 0 {
+		if len(calls) > 0 {
+			return []*model.LLMResponse{resp}, nil
+		}
+		return []*model.LLMResponse{resp, {TurnComplete: true}}, nil
+	}
+
+	audio, err := c.cfg.TextToSpeech.Synthesize(ctx, text.String(), c.speechConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to synthesize the response: %w", err)
+	}
+	responses := []*model.LLMResponse{{
+		Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{{InlineData: audio}}},
+		Partial: true,
+	}}
+	if c.outputTranscription {
+		responses = append(responses, &model.LLMResponse{
+			OutputTranscription: &genai.Transcription{Text: text.String(), Finished: true},
+		})
+	}
+	if len(calls) > 0 {
+		return append(responses, &model.LLMResponse{
+			Content:       &genai.Content{Role: genai.RoleModel, Parts: calls},
+			UsageMetadata: resp.UsageMetadata,
+		}), nil
+	}
+	return append(responses, &model.LLMResponse{TurnComplete: true, UsageMetadata: resp.UsageMetadata}), nil
+}
+
+// send sends the result to the receiver. It reports false if the connection
+// is closed.
+func (c *liveConnection) send(ctx context.Context, r liveResult) bool {
+	select {
+	case c.responses <- r:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
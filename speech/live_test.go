@@ -0,0 +1,184 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package speech_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/speech"
+)
+
+// fakeLLM responds with the given contents in order.
+type fakeLLM struct {
+	responses []*genai.Content
+	requests  []*model.LLMRequest
+}
+
+func (m *fakeLLM) Name() string { return "fake" }
+
+func (m *fakeLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		m.requests = append(m.requests, req)
+		if len(m.responses) == 0 {
+			yield(nil, errors.New("no more responses"))
+			return
+		}
+		resp := m.responses[0]
+		m.responses = m.responses[1:]
+		yield(&model.LLMResponse{Content: resp}, nil)
+	}
+}
+
+// receive returns the responses of the connection until the turn completes
+// or the model calls functions.
+func receive(t *testing.T, conn model.LiveConnection) []*model.LLMResponse {
+	t.Helper()
+	var got []*model.LLMResponse
+	for resp, err := range conn.Receive() {
+		if err != nil {
+			t.Fatalf("Receive() error = %v", err)
+		}
+		got = append(got, resp)
+		if resp.TurnComplete || resp.Content != nil && resp.Content.Parts[0].FunctionCall != nil {
+			break
+		}
+	}
+	return got
+}
+
+func TestNewLiveModel_Audio(t *testing.T) {
+	llm := &fakeLLM{responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("get_weather", nil, genai.RoleModel),
+		genai.NewContentFromText("It is sunny.", genai.RoleModel),
+	}}
+	stt := speech.TranscribeFunc(func(ctx context.Context, audio *genai.Blob) (string, error) {
+		if string(audio.Data) != "weather?" || audio.MIMEType != "audio/pcm;rate=16000" {
+			return "", errors.New("unexpected audio")
+		}
+		return "What is the weather?", nil
+	})
+	speechConfig := &genai.SpeechConfig{LanguageCode: "en-US"}
+	tts := speech.SynthesizeFunc(func(ctx context.Context, text string, cfg *genai.SpeechConfig) (*genai.Blob, error) {
+		if cfg != speechConfig {
+			return nil, errors.New("unexpected speech config")
+		}
+		return &genai.Blob{MIMEType: "audio/pcm;rate=24000", Data: []byte(text)}, nil
+	})
+	live := speech.NewLiveModel(llm, speech.Config{SpeechToText: stt, TextToSpeech: tts})
+
+	history := []*genai.Content{genai.NewContentFromText("hello", genai.RoleUser)}
+	conn, err := live.ConnectLive(t.Context(), &model.LLMRequest{
+		Model:    "fake",
+		Contents: history,
+		LiveConnectConfig: &genai.LiveConnectConfig{
+			ResponseModalities:       []genai.Modality{genai.ModalityAudio},
+			SpeechConfig:             speechConfig,
+			InputAudioTranscription:  &genai.AudioTranscriptionConfig{},
+			OutputAudioTranscription: &genai.AudioTranscriptionConfig{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ConnectLive() error = %v", err)
+	}
+	defer conn.Close()
+
+	for _, input := range []genai.LiveRealtimeInput{
+		{ActivityStart: &genai.ActivityStart{}},
+		{Audio: &genai.Blob{MIMEType: "audio/pcm;rate=16000", Data: []byte("weather")}},
+		{Audio: &genai.Blob{MIMEType: "audio/pcm;rate=16000", Data: []byte("?")}},
+		{ActivityEnd: &genai.ActivityEnd{}},
+	} {
+		if err := conn.SendRealtime(input); err != nil {
+			t.Fatalf("SendRealtime() error = %v", err)
+		}
+	}
+	want := []*model.LLMResponse{
+		{InputTranscription: &genai.Transcription{Text: "What is the weather?", Finished: true}},
+		{Content: genai.NewContentFromFunctionCall("get_weather", nil, genai.RoleModel)},
+	}
+	if diff := cmp.Diff(want, receive(t, conn)); diff != "" {
+		t.Errorf("responses to the audio mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := conn.SendContent(genai.NewContentFromFunctionResponse("get_weather", map[string]any{"weather": "sunny"}, genai.RoleUser)); err != nil {
+		t.Fatalf("SendContent() error = %v", err)
+	}
+	want = []*model.LLMResponse{
+		{Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{{InlineData: &genai.Blob{MIMEType: "audio/pcm;rate=24000", Data: []byte("It is sunny.")}}}}, Partial: true},
+		{OutputTranscription: &genai.Transcription{Text: "It is sunny.", Finished: true}},
+		{TurnComplete: true},
+	}
+	if diff := cmp.Diff(want, receive(t, conn)); diff != "" {
+		t.Errorf("responses to the function response mismatch (-want +got):\n%s", diff)
+	}
+
+	if got, want := len(llm.requests), 2; got != want {
+		t.Fatalf("got %d model requests, want %d", got, want)
+	}
+	wantContents := []*genai.Content{
+		genai.NewContentFromText("hello", genai.RoleUser),
+		genai.NewContentFromText("What is the weather?", genai.RoleUser),
+		genai.NewContentFromFunctionCall("get_weather", nil, genai.RoleModel),
+		genai.NewContentFromFunctionResponse("get_weather", map[string]any{"weather": "sunny"}, genai.RoleUser),
+	}
+	if diff := cmp.Diff(wantContents, llm.requests[1].Contents); diff != "" {
+		t.Errorf("contents of the second request mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewLiveModel_Text(t *testing.T) {
+	llm := &fakeLLM{responses: []*genai.Content{genai.NewContentFromText("Hi!", genai.RoleModel)}}
+	tts := speech.SynthesizeFunc(func(ctx context.Context, text string, cfg *genai.SpeechConfig) (*genai.Blob, error) {
+		return nil, errors.New("unexpected synthesis")
+	})
+	live := speech.NewLiveModel(llm, speech.Config{TextToSpeech: tts})
+	conn, err := live.ConnectLive(t.Context(), &model.LLMRequest{
+		LiveConnectConfig: &genai.LiveConnectConfig{ResponseModalities: []genai.Modality{genai.ModalityText}},
+	})
+	if err != nil {
+		t.Fatalf("ConnectLive() error = %v", err)
+	}
+
+	if err := conn.SendRealtime(genai.LiveRealtimeInput{Audio: &genai.Blob{MIMEType: "audio/pcm"}}); err == nil {
+		t.Error("SendRealtime() of audio without SpeechToText succeeded, want error")
+	}
+	if err := conn.SendRealtime(genai.LiveRealtimeInput{Text: "hello"}); err != nil {
+		t.Fatalf("SendRealtime() error = %v", err)
+	}
+	want := []*model.LLMResponse{
+		{Content: genai.NewContentFromText("Hi!", genai.RoleModel)},
+		{TurnComplete: true},
+	}
+	if diff := cmp.Diff(want, receive(t, conn)); diff != "" {
+		t.Errorf("responses mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := conn.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := conn.SendContent(genai.NewContentFromText("bye", genai.RoleUser)); !errors.Is(err, speech.ErrConnectionClosed) {
+		t.Errorf("SendContent() after Close() error = %v, want %v", err, speech.ErrConnectionClosed)
+	}
+	for _, err := range conn.Receive() {
+		t.Errorf("Receive() after Close() yielded %v, want nothing", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package speech provides the speech-to-text and text-to-speech components
+// letting the models without native audio support converse by voice in the
+// live invocations run by runner.Runner.RunLive, see [NewLiveModel].
+//
+// The package googlespeech implements the components with the Google Cloud
+// Speech-to-Text and Text-to-Speech APIs.
+package speech
+
+import (
+	"context"
+
+	"google.golang.org/genai"
+)
+
+// SpeechToText transcribes the audio of the user.
+type SpeechToText interface {
+	// Transcribe returns the text spoken in the audio, empty if nothing
+	// was said.
+	Transcribe(ctx context.Context, audio *genai.Blob) (string, error)
+}
+
+// TextToSpeech synthesizes the responses of the model.
+type TextToSpeech interface {
+	// Synthesize returns the audio of the text spoken with the voice
+	// configured by cfg, which may be nil.
+	Synthesize(ctx context.Context, text string, cfg *genai.SpeechConfig) (*genai.Blob, error)
+}
+
+// TranscribeFunc is an adapter to use a function as a [SpeechToText].
+type TranscribeFunc func(ctx context.Context, audio *genai.Blob) (string, error)
+
+// Transcribe calls f(ctx, audio).
+func (f TranscribeFunc) Transcribe(ctx context.Context, audio *genai.Blob) (string, error) {
+	return f(ctx, audio)
+}
+
+// SynthesizeFunc is an adapter to use a function as a [TextToSpeech].
+type SynthesizeFunc func(ctx context.Context, text string, cfg *genai.SpeechConfig) (*genai.Blob, error)
+
+// Synthesize calls f(ctx, text, cfg).
+func (f SynthesizeFunc) Synthesize(ctx context.Context, text string, cfg *genai.SpeechConfig) (*genai.Blob, error) {
+	return f(ctx, text, cfg)
+}
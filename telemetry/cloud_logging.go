@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewCloudLoggingHandler returns a slog handler writing the records as JSON
+// lines in the Cloud Logging structured logging format. The logging agents of
+// Cloud Run, GKE and Agent Engine ingest them from stdout or stderr.
+//
+// Records logged with a context carrying a span, e.g. within an agent
+// invocation, are correlated with the trace exported to Cloud Trace of the
+// given project.
+func NewCloudLoggingHandler(w io.Writer, projectID string, opts *slog.HandlerOptions) slog.Handler {
+	var jsonOpts slog.HandlerOptions
+	if opts != nil {
+		jsonOpts = *opts
+	}
+	replaceAttr := jsonOpts.ReplaceAttr
+	jsonOpts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 {
+			switch a.Key {
+			case slog.LevelKey:
+				return slog.String("severity", cloudLoggingSeverity(a.Value.Any().(slog.Level)))
+			case slog.MessageKey:
+				a.Key = "message"
+			case slog.TimeKey:
+				a.Key = "timestamp"
+			}
+		}
+		if replaceAttr != nil {
+			return replaceAttr(groups, a)
+		}
+		return a
+	}
+	return &cloudLoggingHandler{Handler: slog.NewJSONHandler(w, &jsonOpts), projectID: projectID}
+}
+
+type cloudLoggingHandler struct {
+	slog.Handler
+	projectID string
+}
+
+func (h *cloudLoggingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r = r.Clone()
+		if h.projectID != "" {
+			r.AddAttrs(slog.String("logging.googleapis.com/trace", fmt.Sprintf("projects/%s/traces/%s", h.projectID, sc.TraceID())))
+		}
+		r.AddAttrs(
+			slog.String("logging.googleapis.com/spanId", sc.SpanID().String()),
+			slog.Bool("logging.googleapis.com/trace_sampled", sc.IsSampled()),
+		)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *cloudLoggingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &cloudLoggingHandler{Handler: h.Handler.WithAttrs(attrs), projectID: h.projectID}
+}
+
+func (h *cloudLoggingHandler) WithGroup(name string) slog.Handler {
+	return &cloudLoggingHandler{Handler: h.Handler.WithGroup(name), projectID: h.projectID}
+}
+
+// cloudLoggingSeverity maps the slog levels to the Cloud Logging severities.
+func cloudLoggingSeverity(level slog.Level) string {
+	switch {
+	case level < slog.LevelInfo:
+		return "DEBUG"
+	case level < slog.LevelWarn:
+		return "INFO"
+	case level < slog.LevelError:
+		return "WARNING"
+	default:
+		return "ERROR"
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestCloudLoggingHandler(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { _ = tp.Shutdown(t.Context()) })
+	spanCtx, span := tp.Tracer("test").Start(t.Context(), "test")
+	defer span.End()
+	sc := span.SpanContext()
+
+	tests := []struct {
+		name string
+		log  func(*slog.Logger)
+		want map[string]any
+	}{
+		{
+			name: "without span",
+			log: func(logger *slog.Logger) {
+				logger.WarnContext(t.Context(), "hello", "key", "value")
+			},
+			want: map[string]any{"severity": "WARNING", "message": "hello", "key": "value"},
+		},
+		{
+			name: "with span",
+			log: func(logger *slog.Logger) {
+				logger.With("invocation_id", "inv-1").ErrorContext(spanCtx, "failed")
+			},
+			want: map[string]any{
+				"severity":                             "ERROR",
+				"message":                              "failed",
+				"invocation_id":                        "inv-1",
+				"logging.googleapis.com/trace":         "projects/my-project/traces/" + sc.TraceID().String(),
+				"logging.googleapis.com/spanId":        sc.SpanID().String(),
+				"logging.googleapis.com/trace_sampled": true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(slog.New(NewCloudLoggingHandler(&buf, "my-project", nil)))
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := got["timestamp"]; !ok {
+				t.Errorf("log line %v has no timestamp", got)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreMapEntries(func(k string, _ any) bool { return k == "timestamp" })); diff != "" {
+				t.Errorf("log line mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
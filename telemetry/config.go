@@ -17,6 +17,7 @@ package telemetry
 
 import (
 	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"golang.org/x/oauth2/google"
@@ -50,11 +51,17 @@ type config struct {
 	// logProcessors registers additional log processors, e.g. for custom log exporters.
 	logProcessors []sdklog.Processor
 
+	// metricReaders registers additional metric readers, e.g. a Prometheus exporter.
+	metricReaders []sdkmetric.Reader
+
 	// tracerProvider overrides the default TracerProvider.
 	tracerProvider *sdktrace.TracerProvider
 
 	// loggerProvider overrides the default LoggerProvider.
 	loggerProvider *sdklog.LoggerProvider
+
+	// meterProvider overrides the default MeterProvider.
+	meterProvider *sdkmetric.MeterProvider
 }
 
 // Option configures adk telemetry.
@@ -124,6 +131,14 @@ func WithLogRecordProcessors(p ...sdklog.Processor) Option {
 	})
 }
 
+// WithMetricReaders registers additional metric readers.
+func WithMetricReaders(r ...sdkmetric.Reader) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.metricReaders = append(cfg.metricReaders, r...)
+		return nil
+	})
+}
+
 // WithTracerProvider overrides the default TracerProvider with preconfigured instance.
 func WithTracerProvider(tp *sdktrace.TracerProvider) Option {
 	return optionFunc(func(cfg *config) error {
@@ -140,6 +155,14 @@ func WithLoggerProvider(lp *sdklog.LoggerProvider) Option {
 	})
 }
 
+// WithMeterProvider overrides the default MeterProvider with preconfigured instance.
+func WithMeterProvider(mp *sdkmetric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.meterProvider = mp
+		return nil
+	})
+}
+
 // WithGenAICaptureMessageContent overrides the default [config.genAICaptureMessageContent].
 func WithGenAICaptureMessageContent(capture bool) Option {
 	return optionFunc(func(cfg *config) error {
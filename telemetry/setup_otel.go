@@ -23,8 +23,10 @@ import (
 	"go.opentelemetry.io/contrib/detectors/gcp"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"golang.org/x/oauth2"
@@ -62,12 +64,13 @@ func configure(ctx context.Context, opts ...Option) (*config, error) {
 		return nil, fmt.Errorf("failed to resolve resource: %w", err)
 	}
 
-	spanProcessors, logProcessors, err := configureExporters(ctx, cfg)
+	spanProcessors, logProcessors, metricReaders, err := configureExporters(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure exporters: %w", err)
 	}
 	cfg.spanProcessors = append(cfg.spanProcessors, spanProcessors...)
 	cfg.logProcessors = append(cfg.logProcessors, logProcessors...)
+	cfg.metricReaders = append(cfg.metricReaders, metricReaders...)
 	return cfg, nil
 }
 
@@ -87,13 +90,14 @@ func configFromOpts(opts ...Option) (*config, error) {
 func newInternal(cfg *config) (*Providers, error) {
 	tp := initTracerProvider(cfg)
 	lp := initLoggerProvider(cfg)
-
-	// TODO(#479) init meter provider
+	mp := initMeterProvider(cfg)
 
 	return &Providers{
 		TracerProvider:             tp,
 		genAICaptureMessageContent: cfg.genAICaptureMessageContent,
 		LoggerProvider:             lp,
+		MeterProvider:              mp,
+		GCPProject:                 cfg.gcpResourceProject,
 	}, nil
 }
 
@@ -170,9 +174,10 @@ func resolveResource(ctx context.Context, cfg *config) (*resource.Resource, erro
 }
 
 // configureExporters initializes OTel exporters from environment variables and otelToCloud.
-func configureExporters(ctx context.Context, cfg *config) ([]sdktrace.SpanProcessor, []sdklog.Processor, error) {
+func configureExporters(ctx context.Context, cfg *config) ([]sdktrace.SpanProcessor, []sdklog.Processor, []sdkmetric.Reader, error) {
 	var spanProcessors []sdktrace.SpanProcessor
 	var logProcessors []sdklog.Processor
+	var metricReaders []sdkmetric.Reader
 
 	otelEndpointEnv := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"))
 	// Tracing section.
@@ -180,7 +185,7 @@ func configureExporters(ctx context.Context, cfg *config) ([]sdktrace.SpanProces
 	if otelEndpointEnv != "" || otelTracesEndpointEnv != "" {
 		exporter, err := otlptracehttp.New(ctx)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create OTLP HTTP exporter: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to create OTLP HTTP exporter: %w", err)
 		}
 		spanProcessors = append(spanProcessors, sdktrace.NewBatchSpanProcessor(
 			exporter,
@@ -189,7 +194,7 @@ func configureExporters(ctx context.Context, cfg *config) ([]sdktrace.SpanProces
 	if cfg.oTelToCloud {
 		spanExporter, err := newGcpSpanExporter(ctx, cfg)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create GCP span exporter: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to create GCP span exporter: %w", err)
 		}
 		spanProcessors = append(spanProcessors, sdktrace.NewBatchSpanProcessor(spanExporter))
 	}
@@ -198,14 +203,23 @@ func configureExporters(ctx context.Context, cfg *config) ([]sdktrace.SpanProces
 	if otelEndpointEnv != "" || otelLogsEndpointEnv != "" {
 		exporter, err := otlploghttp.New(ctx)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create OTLP HTTP log exporter: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to create OTLP HTTP log exporter: %w", err)
 		}
 		logProcessors = append(logProcessors, sdklog.NewBatchProcessor(
 			exporter,
 		))
 	}
 	// Golang OTel exporter to CloudLogging is not yet available.
-	return spanProcessors, logProcessors, nil
+	// Metrics section.
+	otelMetricsEndpointEnv := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"))
+	if otelEndpointEnv != "" || otelMetricsEndpointEnv != "" {
+		exporter, err := otlpmetrichttp.New(ctx)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to create OTLP HTTP metric exporter: %w", err)
+		}
+		metricReaders = append(metricReaders, sdkmetric.NewPeriodicReader(exporter))
+	}
+	return spanProcessors, logProcessors, metricReaders, nil
 }
 
 func initTracerProvider(cfg *config) *sdktrace.TracerProvider {
@@ -244,6 +258,24 @@ func initLoggerProvider(cfg *config) *sdklog.LoggerProvider {
 	return lp
 }
 
+func initMeterProvider(cfg *config) *sdkmetric.MeterProvider {
+	if cfg.meterProvider != nil {
+		return cfg.meterProvider
+	}
+	if len(cfg.metricReaders) == 0 {
+		return nil
+	}
+	opts := []sdkmetric.Option{
+		sdkmetric.WithResource(cfg.resource),
+	}
+	for _, r := range cfg.metricReaders {
+		opts = append(opts, sdkmetric.WithReader(r))
+	}
+	mp := sdkmetric.NewMeterProvider(opts...)
+
+	return mp
+}
+
 func newGcpSpanExporter(ctx context.Context, cfg *config) (sdktrace.SpanExporter, error) {
 	client := oauth2.NewClient(ctx, cfg.googleCredentials.TokenSource)
 	return otlptracehttp.New(ctx,
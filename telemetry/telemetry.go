@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/otel"
 	logglobal "go.opentelemetry.io/otel/log/global"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -34,6 +35,10 @@ type Providers struct {
 	TracerProvider *sdktrace.TracerProvider
 	// LoggerProvider is the configured LoggerProvider or nil.
 	LoggerProvider *sdklog.LoggerProvider
+	// MeterProvider is the configured MeterProvider or nil.
+	MeterProvider *sdkmetric.MeterProvider
+	// GCPProject is the GCP project the telemetry is exported to, or empty.
+	GCPProject string
 }
 
 // Shutdown shuts down underlying OTel providers.
@@ -49,6 +54,11 @@ func (t *Providers) Shutdown(ctx context.Context) error {
 			err = errors.Join(err, lpErr)
 		}
 	}
+	if t.MeterProvider != nil {
+		if mpErr := t.MeterProvider.Shutdown(ctx); mpErr != nil {
+			err = errors.Join(err, mpErr)
+		}
+	}
 	return err
 }
 
@@ -61,6 +71,9 @@ func (t *Providers) SetGlobalOtelProviders() {
 	if t.LoggerProvider != nil {
 		logglobal.SetLoggerProvider(t.LoggerProvider)
 	}
+	if t.MeterProvider != nil {
+		otel.SetMeterProvider(t.MeterProvider)
+	}
 }
 
 // New initializes telemetry providers: TraceProvider, LogProvider, and MeterProvider.
@@ -445,9 +445,10 @@ func (e *inMemoryLogExporter) Shutdown(context.Context) error   { return nil }
 func (e *inMemoryLogExporter) ForceFlush(context.Context) error { return nil }
 
 type envVars struct {
-	OTEL_EXPORTER_OTLP_ENDPOINT        string
-	OTEL_EXPORTER_OTLP_TRACES_ENDPOINT string
-	OTEL_EXPORTER_OTLP_LOGS_ENDPOINT   string
+	OTEL_EXPORTER_OTLP_ENDPOINT         string
+	OTEL_EXPORTER_OTLP_TRACES_ENDPOINT  string
+	OTEL_EXPORTER_OTLP_LOGS_ENDPOINT    string
+	OTEL_EXPORTER_OTLP_METRICS_ENDPOINT string
 }
 
 func TestConfigureExporters(t *testing.T) {
@@ -459,6 +460,7 @@ func TestConfigureExporters(t *testing.T) {
 		// Accessing it via reflection is too brittle. The best thing we can do is a smoke test, which checks the number of created processors.
 		wantSpanProcessors int
 		wantLogProcessors  int
+		wantMetricReaders  int
 	}{
 		{
 			name:               "no processors",
@@ -473,6 +475,7 @@ func TestConfigureExporters(t *testing.T) {
 			},
 			wantSpanProcessors: 1,
 			wantLogProcessors:  1,
+			wantMetricReaders:  1,
 		},
 		{
 			name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
@@ -490,6 +493,13 @@ func TestConfigureExporters(t *testing.T) {
 			wantSpanProcessors: 0,
 			wantLogProcessors:  1,
 		},
+		{
+			name: "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+			envVars: envVars{
+				OTEL_EXPORTER_OTLP_METRICS_ENDPOINT: "http://localhost:4318/v1/metrics",
+			},
+			wantMetricReaders: 1,
+		},
 		{
 			name: "OTEL_EXPORTER_OTLP_ENDPOINT and otel_to_cloud",
 			envVars: envVars{
@@ -501,6 +511,7 @@ func TestConfigureExporters(t *testing.T) {
 			},
 			wantSpanProcessors: 2,
 			wantLogProcessors:  1,
+			wantMetricReaders:  1,
 		},
 		{
 			name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT and otel_to_cloud",
@@ -533,6 +544,7 @@ func TestConfigureExporters(t *testing.T) {
 			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", tc.envVars.OTEL_EXPORTER_OTLP_ENDPOINT)
 			t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", tc.envVars.OTEL_EXPORTER_OTLP_TRACES_ENDPOINT)
 			t.Setenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", tc.envVars.OTEL_EXPORTER_OTLP_LOGS_ENDPOINT)
+			t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", tc.envVars.OTEL_EXPORTER_OTLP_METRICS_ENDPOINT)
 			// Set the quota project needed to configure GCP exporters.
 			t.Setenv("GOOGLE_CLOUD_PROJECT", "test-project")
 			ctx := t.Context()
@@ -540,7 +552,7 @@ func TestConfigureExporters(t *testing.T) {
 			if err != nil {
 				t.Fatalf("configure() unexpected error: %v", err)
 			}
-			spanProcessors, logProcessors, err := configureExporters(ctx, cfg)
+			spanProcessors, logProcessors, metricReaders, err := configureExporters(ctx, cfg)
 			if err != nil {
 				t.Fatalf("configureExporters() unexpected error: %v", err)
 			}
@@ -550,6 +562,9 @@ func TestConfigureExporters(t *testing.T) {
 			if len(logProcessors) != tc.wantLogProcessors {
 				t.Errorf("got %d log processors, want %d", len(logProcessors), tc.wantLogProcessors)
 			}
+			if len(metricReaders) != tc.wantMetricReaders {
+				t.Errorf("got %d metric readers, want %d", len(metricReaders), tc.wantMetricReaders)
+			}
 		})
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/auth"
 	"google.golang.org/adk/memory"
 	"google.golang.org/adk/model"
 	"google.golang.org/adk/session"
@@ -52,6 +53,8 @@ func (m *mockToolContext) SearchMemory(ctx context.Context, query string) (*memo
 }
 func (m *mockToolContext) ToolConfirmation() *toolconfirmation.ToolConfirmation { return nil }
 func (m *mockToolContext) RequestConfirmation(hint string, payload any) error   { return nil }
+func (m *mockToolContext) Credential(cfg auth.Config) (*auth.Credential, error) { return nil, nil }
+func (m *mockToolContext) RequestCredential(cfg auth.Config) error              { return nil }
 func (m *mockToolContext) AgentName() string                                    { return "mock_agent" }
 func (m *mockToolContext) ReadonlyState() session.ReadonlyState                 { return nil }
 func (m *mockToolContext) State() session.State                                 { return nil }
@@ -24,6 +24,7 @@ import (
 	"github.com/google/jsonschema-go/jsonschema"
 	"google.golang.org/genai"
 
+	"google.golang.org/adk/agent"
 	"google.golang.org/adk/internal/toolinternal/toolutils"
 	"google.golang.org/adk/internal/typeutil"
 	"google.golang.org/adk/model"
@@ -185,7 +186,7 @@ func (f *functionTool[TArgs, TResults]) Run(ctx tool.Context, args any) (result
 	// TODO: Handle function call request from tc.InvocationContext.
 	defer func() {
 		if r := recover(); r != nil {
-			err = fmt.Errorf("panic in tool %q: %v\nstack: %s", f.Name(), r, debug.Stack())
+			err = &agent.PanicError{Source: fmt.Sprintf("tool %q", f.Name()), Value: r, Stack: debug.Stack()}
 		}
 	}()
 
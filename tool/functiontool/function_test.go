@@ -28,7 +28,9 @@ import (
 	"github.com/google/jsonschema-go/jsonschema"
 	"google.golang.org/genai"
 
+	"google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/auth"
 	icontext "google.golang.org/adk/internal/context"
 	"google.golang.org/adk/internal/testutil"
 	"google.golang.org/adk/internal/toolinternal"
@@ -776,7 +778,7 @@ func TestToolConfirmation(t *testing.T) {
 
 			var confirmFunctionCall *genai.FunctionCall
 			for got, err := range ev {
-				if err != nil && err.Error() == "no data" {
+				if errors.Is(err, testutil.ErrNoModelData) {
 					break
 				}
 				if err != nil {
@@ -819,7 +821,7 @@ func TestToolConfirmation(t *testing.T) {
 					Parts: []*genai.Part{{FunctionResponse: tc.confirmFunctionResponse}},
 				})
 				for got, err := range ev {
-					if err != nil && err.Error() == "no data" {
+					if errors.Is(err, testutil.ErrNoModelData) {
 						break
 					}
 					if err != nil {
@@ -865,6 +867,121 @@ func TestToolConfirmation(t *testing.T) {
 	}
 }
 
+func TestRequestCredential(t *testing.T) {
+	authConfig := auth.Config{
+		Scheme: auth.Scheme{Type: auth.TypeAPIKey, In: "header", Name: "X-API-Key"},
+	}
+	toolCalls := 0
+	apiTool, err := functiontool.New(functiontool.Config{Name: "api_tool"}, func(ctx tool.Context, args map[string]any) (map[string]any, error) {
+		toolCalls++
+		cred, err := ctx.Credential(authConfig)
+		if err != nil {
+			return nil, err
+		}
+		if cred == nil {
+			if err := ctx.RequestCredential(authConfig); err != nil {
+				return nil, err
+			}
+			return map[string]any{"status": "pending authorization"}, nil
+		}
+		return map[string]any{"key": cred.APIKey}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to create tool: %v", err)
+	}
+	mockModel := &testutil.MockModel{
+		Responses: []*genai.Content{
+			genai.NewContentFromFunctionCall("api_tool", map[string]any{}, genai.RoleModel),
+			genai.NewContentFromText("done", genai.RoleModel),
+			genai.NewContentFromFunctionCall("api_tool", map[string]any{}, genai.RoleModel),
+			genai.NewContentFromText("done again", genai.RoleModel),
+		},
+	}
+	a, err := llmagent.New(llmagent.Config{
+		Name:  "simple agent",
+		Model: mockModel,
+		Tools: []tool.Tool{apiTool},
+	})
+	if err != nil {
+		t.Fatalf("failed to create llm agent: %v", err)
+	}
+	runner := testutil.NewTestAgentRunner(t, a)
+
+	collect := func(events iter.Seq2[*session.Event, error]) []*genai.Content {
+		t.Helper()
+		var contents []*genai.Content
+		for ev, err := range events {
+			if errors.Is(err, testutil.ErrNoModelData) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("runner returned unexpected error: %v", err)
+			}
+			contents = append(contents, ev.Content)
+		}
+		return contents
+	}
+
+	// The tool requests the credential, and the invocation stops.
+	got := collect(runner.Run(t, "id", "message"))
+	if len(got) != 3 {
+		t.Fatalf("got %d events, want 3: %+v", len(got), got)
+	}
+	toolCall := got[0].Parts[0].FunctionCall
+	requestCall := got[1].Parts[0].FunctionCall
+	if requestCall == nil || requestCall.Name != auth.FunctionCallName {
+		t.Fatalf("second event = %+v, want a %s function call", got[1], auth.FunctionCallName)
+	}
+	wantArgs := map[string]any{
+		"functionCallId": toolCall.ID,
+		"authConfig": map[string]any{
+			"authScheme":    map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			"credentialKey": authConfig.Key(),
+		},
+	}
+	if diff := cmp.Diff(wantArgs, requestCall.Args); diff != "" {
+		t.Errorf("auth request args mismatch (-want +got):\n%s", diff)
+	}
+
+	// The client responds with the credential: the tool runs again and gets
+	// it.
+	response := map[string]any{
+		"authScheme":              map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+		"exchangedAuthCredential": map[string]any{"authType": "apiKey", "apiKey": "secret-key"},
+	}
+	got = collect(runner.RunContent(t, "id", &genai.Content{
+		Role: genai.RoleUser,
+		Parts: []*genai.Part{{FunctionResponse: &genai.FunctionResponse{
+			ID:       requestCall.ID,
+			Name:     auth.FunctionCallName,
+			Response: response,
+		}}},
+	}))
+	want := []*genai.Content{
+		{Role: genai.RoleUser, Parts: []*genai.Part{{FunctionResponse: &genai.FunctionResponse{
+			ID:       toolCall.ID,
+			Name:     "api_tool",
+			Response: map[string]any{"key": "secret-key"},
+		}}}},
+		genai.NewContentFromText("done", genai.RoleModel),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("resumed invocation mismatch (-want +got):\n%s", diff)
+	}
+
+	// The credential is stored for the user.
+	got = collect(runner.Run(t, "id", "again"))
+	if len(got) != 3 {
+		t.Fatalf("got %d events, want 3: %+v", len(got), got)
+	}
+	if resp := got[1].Parts[0].FunctionResponse; resp == nil || resp.Response["key"] != "secret-key" {
+		t.Errorf("second event = %+v, want the tool response with the stored key", got[1])
+	}
+	if toolCalls != 3 {
+		t.Errorf("tool called %d times, want 3", toolCalls)
+	}
+}
+
 // Mock types for TArgs and TResults
 type TestArgs struct {
 	Name string
@@ -1066,11 +1183,15 @@ func TestFunctionTool_PanicRecovery(t *testing.T) {
 		"panic in tool",
 		"panic_tool",
 		"intentional panic for testing",
-		"stack:",
 	}
 	for _, part := range expectedErrParts {
 		if !strings.Contains(err.Error(), part) {
 			t.Errorf("expected error to contain %q, but it did not. Error: %v", part, err)
 		}
 	}
+	// The stack trace is kept out of the message sent to the model.
+	var panicErr *agent.PanicError
+	if !errors.As(err, &panicErr) || len(panicErr.Stack) == 0 || strings.Contains(err.Error(), "goroutine") {
+		t.Errorf("expected an *agent.PanicError with the stack trace out of its message, got %v", err)
+	}
 }
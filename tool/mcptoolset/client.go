@@ -19,11 +19,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"sync"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/internal/stagetimeout"
+	"google.golang.org/adk/internal/telemetry"
 	"google.golang.org/adk/internal/version"
 )
 
@@ -157,6 +159,7 @@ func (c *connectionRefresher) getSession(ctx context.Context) (*mcp.ClientSessio
 	if err != nil {
 		return nil, fmt.Errorf("failed to init MCP session: %w", err)
 	}
+	telemetry.RecordMCPSession(ctx, false)
 
 	c.session = session
 	return c.session, nil
@@ -169,11 +172,14 @@ func (c *connectionRefresher) refreshConnection(ctx context.Context) (*mcp.Clien
 	// Ping to verify the connection is actually dead before reconnecting.
 	// This handles the case where another goroutine already reconnected.
 	if c.session != nil {
-		if err := c.session.Ping(ctx, &mcp.PingParams{}); err == nil {
+		pingCtx, cancel := stagetimeout.With(ctx, stagetimeout.MCPPing, stagetimeout.FromContext(ctx).MCPPing)
+		err := c.session.Ping(pingCtx, &mcp.PingParams{})
+		cancel()
+		if err == nil {
 			return c.session, nil
 		}
 		if err := c.session.Close(); err != nil {
-			log.Printf("failed to close MCP session: %v", err)
+			logging.FromContext(ctx).WarnContext(ctx, "Failed to close MCP session", "error", err)
 		}
 		c.session = nil
 	}
@@ -182,6 +188,7 @@ func (c *connectionRefresher) refreshConnection(ctx context.Context) (*mcp.Clien
 	if err != nil {
 		return nil, fmt.Errorf("failed to refresh MCP session: %w", err)
 	}
+	telemetry.RecordMCPSession(ctx, true)
 
 	c.session = session
 	return c.session, nil
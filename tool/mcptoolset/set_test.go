@@ -675,7 +675,7 @@ func TestMCPToolSetConfirmation(t *testing.T) {
 			eventCount := 0
 			var confirmFunctionCall *genai.FunctionCall
 			for got, err := range ev {
-				if err != nil && err.Error() == "no data" {
+				if errors.Is(err, testutil.ErrNoModelData) {
 					break
 				}
 				if err != nil {
@@ -706,7 +706,7 @@ func TestMCPToolSetConfirmation(t *testing.T) {
 					Parts: []*genai.Part{{FunctionResponse: tc.confirmFunctionResponse}},
 				})
 				for got, err := range ev {
-					if err != nil && err.Error() == "no data" {
+					if errors.Is(err, testutil.ErrNoModelData) {
 						break
 					}
 					if err != nil {
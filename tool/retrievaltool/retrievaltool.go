@@ -0,0 +1,284 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retrievaltool provides the retry and quota handling shared by the
+// retrieval tools, e.g. search or memory tools, so that grounded agents
+// under load do not turn every rate limit error of the search backend into a
+// failed tool call for the model to deal with.
+//
+// The wrapped tools are called at most at the rate of a limiter, their
+// transient failures are retried with an exponential backoff, and their
+// results are cached per query within an invocation:
+//
+//	search, err := retrievaltool.Wrap(searchTool, retrievaltool.Config{
+//		Limiter: rate.NewLimiter(rate.Limit(10), 1),
+//	})
+//
+// Only the tools run by the ADK can be wrapped. The built-in retrieval of the
+// models, e.g. geminitool.New with a genai.Retrieval, runs as part of the
+// model call and is retried with it.
+package retrievaltool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+	"google.golang.org/genai"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/logging"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// Config configures the retry and quota handling of the wrapped tools.
+type Config struct {
+	// Limiter limits the rate of the calls of the wrapped tools. The calls
+	// wait for the limiter, so the tools and invocations sharing a limiter
+	// share the quota of the backend. No limit if nil.
+	Limiter *rate.Limiter // optional
+	// MaxAttempts is the number of calls of a tool before its error is
+	// returned. Defaults to 3; 1 disables the retries.
+	MaxAttempts int // optional
+	// Backoff is the delay before the first retry, doubled at every retry up
+	// to MaxBackoff. Defaults to 1 second.
+	Backoff time.Duration // optional
+	// MaxBackoff is the maximum delay between two attempts. Defaults to 30
+	// seconds.
+	MaxBackoff time.Duration // optional
+	// Retryable reports whether a failed call may succeed if retried.
+	// Defaults to IsTransientError.
+	Retryable func(error) bool // optional
+	// DisableCache disables the caching of the results. By default, the
+	// calls of a tool with the same arguments within an invocation share
+	// the result of the first successful one, which suits the tools without
+	// side effects only.
+	DisableCache bool // optional
+}
+
+// maxCachedInvocations is the number of invocations whose results are
+// cached, the oldest invocations being evicted first.
+const maxCachedInvocations = 100
+
+// Wrap returns t with the retry and quota handling of cfg. t must be a
+// function tool, e.g. created with functiontool.New.
+func Wrap(t tool.Tool, cfg Config) (tool.Tool, error) {
+	ft, ok := t.(toolinternal.FunctionTool)
+	if !ok {
+		return nil, fmt.Errorf("tool %q is not run by the ADK and cannot be wrapped", t.Name())
+	}
+	return &retrievalTool{FunctionTool: ft, handler: newHandler(cfg)}, nil
+}
+
+// WrapToolset returns ts with the retry and quota handling of cfg applied to
+// its function tools. Its other tools are returned unchanged.
+func WrapToolset(ts tool.Toolset, cfg Config) tool.Toolset {
+	return &retrievalToolset{Toolset: ts, handler: newHandler(cfg)}
+}
+
+// IsTransientError reports whether the error is a rate limit, quota or
+// availability error, which may succeed if retried later: an API error of
+// the genai SDK or a gRPC error with such a code.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if model.IsRateLimitError(err) {
+		return true
+	}
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		return transientHTTPStatus(apiErr.Code)
+	}
+	var apiErrPtr *genai.APIError
+	if errors.As(err, &apiErrPtr) {
+		return transientHTTPStatus(apiErrPtr.Code)
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.ResourceExhausted, codes.Unavailable:
+			return true
+		}
+	}
+	return false
+}
+
+func transientHTTPStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+type retrievalToolset struct {
+	tool.Toolset
+	handler *handler
+}
+
+func (ts *retrievalToolset) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	tools, err := ts.Toolset.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wrapped := make([]tool.Tool, 0, len(tools))
+	for _, t := range tools {
+		if ft, ok := t.(toolinternal.FunctionTool); ok {
+			t = &retrievalTool{FunctionTool: ft, handler: ts.handler}
+		}
+		wrapped = append(wrapped, t)
+	}
+	return wrapped, nil
+}
+
+// retrievalTool is a function tool called through a handler.
+type retrievalTool struct {
+	toolinternal.FunctionTool
+	handler *handler
+}
+
+// ProcessRequest lets the wrapped tool process the request, e.g. to add its
+// instructions, and registers the wrapper to be called in its place.
+func (t *retrievalTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	if rp, ok := t.FunctionTool.(toolinternal.RequestProcessor); ok {
+		if err := rp.ProcessRequest(ctx, req); err != nil {
+			return err
+		}
+		if req.Tools == nil {
+			req.Tools = make(map[string]any)
+		}
+		req.Tools[t.Name()] = t
+		return nil
+	}
+	return toolutils.PackTool(req, t)
+}
+
+func (t *retrievalTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	return t.handler.run(ctx, t.FunctionTool, args)
+}
+
+// handler holds the state shared by the tools wrapped with a config.
+type handler struct {
+	cfg   Config
+	calls singleflight.Group
+
+	mu          sync.Mutex
+	results     map[string]map[string]map[string]any // by invocation and call key
+	invocations []string                             // cached, oldest first
+}
+
+func newHandler(cfg Config) *handler {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 30 * time.Second
+	}
+	if cfg.Retryable == nil {
+		cfg.Retryable = IsTransientError
+	}
+	return &handler{cfg: cfg, results: make(map[string]map[string]map[string]any)}
+}
+
+func (h *handler) run(ctx tool.Context, t toolinternal.FunctionTool, args any) (map[string]any, error) {
+	if h.cfg.DisableCache {
+		return h.call(ctx, t, args)
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return h.call(ctx, t, args)
+	}
+	key := t.Name() + "\x00" + string(data)
+	if result, ok := h.cached(ctx.InvocationID(), key); ok {
+		return maps.Clone(result), nil
+	}
+	// Concurrent calls with the same arguments, e.g. parallel function
+	// calls of the model, share a single call of the tool.
+	v, err, _ := h.calls.Do(ctx.InvocationID()+"\x00"+key, func() (any, error) {
+		result, err := h.call(ctx, t, args)
+		if err == nil {
+			h.store(ctx.InvocationID(), key, result)
+		}
+		return result, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return maps.Clone(v.(map[string]any)), nil
+}
+
+// call calls the tool, waiting for the limiter and retrying the transient
+// failures.
+func (h *handler) call(ctx tool.Context, t toolinternal.FunctionTool, args any) (map[string]any, error) {
+	backoff := h.cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		if h.cfg.Limiter != nil {
+			if err := h.cfg.Limiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("waiting for the rate limit of tool %q: %w", t.Name(), err)
+			}
+		}
+		result, err := t.Run(ctx, args)
+		if err == nil || !h.cfg.Retryable(err) {
+			return result, err
+		}
+		if attempt == h.cfg.MaxAttempts {
+			return nil, fmt.Errorf("tool %q failed after %d attempts: %w", t.Name(), attempt, err)
+		}
+		logging.FromContext(ctx).WarnContext(ctx, "Retrying the tool call", "tool", t.Name(), "attempt", attempt, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("tool %q: %w", t.Name(), context.Cause(ctx))
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, h.cfg.MaxBackoff)
+	}
+}
+
+func (h *handler) cached(invocationID, key string) (map[string]any, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result, ok := h.results[invocationID][key]
+	return result, ok
+}
+
+func (h *handler) store(invocationID, key string, result map[string]any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	results, ok := h.results[invocationID]
+	if !ok {
+		if len(h.invocations) == maxCachedInvocations {
+			delete(h.results, h.invocations[0])
+			h.invocations = h.invocations[1:]
+		}
+		results = make(map[string]map[string]any)
+		h.results[invocationID] = results
+		h.invocations = append(h.invocations, invocationID)
+	}
+	results[key] = maps.Clone(result)
+}
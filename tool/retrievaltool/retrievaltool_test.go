@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrievaltool_test
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	icontext "google.golang.org/adk/internal/context"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/geminitool"
+	"google.golang.org/adk/tool/retrievaltool"
+)
+
+type searchArgs struct {
+	Query string `json:"query"`
+}
+
+type searchResult struct {
+	Documents []string `json:"documents"`
+}
+
+// newSearchTool returns a search tool failing with err the first failures
+// calls, and the number of its calls.
+func newSearchTool(t *testing.T, failures int, err error) (tool.Tool, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	search, e := functiontool.New(functiontool.Config{Name: "search", Description: "searches the documents"},
+		func(ctx tool.Context, args searchArgs) (searchResult, error) {
+			if int(calls.Add(1)) <= failures {
+				return searchResult{}, err
+			}
+			return searchResult{Documents: []string{"doc about " + args.Query}}, nil
+		})
+	if e != nil {
+		t.Fatal(e)
+	}
+	return search, &calls
+}
+
+func wrap(t *testing.T, search tool.Tool, cfg retrievaltool.Config) toolinternal.FunctionTool {
+	t.Helper()
+	wrapped, err := retrievaltool.Wrap(search, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wrapped.(toolinternal.FunctionTool)
+}
+
+func toolContext(t *testing.T, invocationID string) tool.Context {
+	invCtx := icontext.NewInvocationContext(t.Context(), icontext.InvocationContextParams{InvocationID: invocationID})
+	return toolinternal.NewToolContext(invCtx, "", &session.EventActions{}, nil)
+}
+
+func TestWrap_RetriesTransientErrors(t *testing.T) {
+	search, calls := newSearchTool(t, 2, genai.APIError{Code: 429, Message: "quota exceeded"})
+	wrapped := wrap(t, search, retrievaltool.Config{Backoff: time.Millisecond})
+
+	got, err := wrapped.Run(toolContext(t, "inv"), map[string]any{"query": "go"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if fmt.Sprint(got["documents"]) != "[doc about go]" {
+		t.Errorf("Run() = %v, want the documents", got)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("tool was called %d times, want 3", calls.Load())
+	}
+}
+
+func TestWrap_GivesUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCalls int32
+	}{
+		{name: "transient", err: status.Error(codes.Unavailable, "unavailable"), wantCalls: 2},
+		{name: "permanent", err: errors.New("bad query"), wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			search, calls := newSearchTool(t, 10, tt.err)
+			wrapped := wrap(t, search, retrievaltool.Config{MaxAttempts: 2, Backoff: time.Millisecond})
+
+			if _, err := wrapped.Run(toolContext(t, "inv"), map[string]any{"query": "go"}); err == nil {
+				t.Fatal("Run() succeeded, want an error")
+			}
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("tool was called %d times, want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWrap_CachesResultsPerInvocation(t *testing.T) {
+	search, calls := newSearchTool(t, 0, nil)
+	wrapped := wrap(t, search, retrievaltool.Config{})
+
+	for _, call := range []struct {
+		invocationID string
+		query        string
+	}{
+		{"inv1", "go"},
+		{"inv1", "go"},
+		{"inv1", "rust"},
+		{"inv2", "go"},
+	} {
+		if _, err := wrapped.Run(toolContext(t, call.invocationID), map[string]any{"query": call.query}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	if calls.Load() != 3 {
+		t.Errorf("tool was called %d times, want once per query and invocation", calls.Load())
+	}
+
+	wrapped = wrap(t, search, retrievaltool.Config{DisableCache: true})
+	calls.Store(0)
+	for range 2 {
+		if _, err := wrapped.Run(toolContext(t, "inv1"), map[string]any{"query": "go"}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("tool without cache was called %d times, want 2", calls.Load())
+	}
+}
+
+func TestWrap_ProcessRequest(t *testing.T) {
+	search, _ := newSearchTool(t, 0, nil)
+	wrapped := wrap(t, search, retrievaltool.Config{})
+
+	req := &model.LLMRequest{}
+	if err := wrapped.(toolinternal.RequestProcessor).ProcessRequest(toolContext(t, "inv"), req); err != nil {
+		t.Fatal(err)
+	}
+	if req.Tools["search"] != wrapped {
+		t.Errorf("req.Tools[%q] = %v, want the wrapped tool", "search", req.Tools["search"])
+	}
+	if len(req.Config.Tools) != 1 || req.Config.Tools[0].FunctionDeclarations[0].Name != "search" {
+		t.Errorf("req.Config.Tools = %v, want the declaration of the tool", req.Config.Tools)
+	}
+}
+
+func TestWrap_NotFunctionTool(t *testing.T) {
+	if _, err := retrievaltool.Wrap(geminitool.GoogleSearch{}, retrievaltool.Config{}); err == nil {
+		t.Error("Wrap() of a Gemini tool succeeded, want an error")
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: genai.APIError{Code: 429}, want: true},
+		{err: fmt.Errorf("search: %w", &genai.APIError{Code: 503}), want: true},
+		{err: genai.APIError{Code: 400}, want: false},
+		{err: status.Error(codes.ResourceExhausted, "quota"), want: true},
+		{err: status.Error(codes.InvalidArgument, "bad"), want: false},
+		{err: errors.New("bad query"), want: false},
+	}
+	for _, tt := range tests {
+		if got := retrievaltool.IsTransientError(tt.err); got != tt.want {
+			t.Errorf("IsTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
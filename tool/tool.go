@@ -25,6 +25,7 @@ import (
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/auth"
 	"google.golang.org/adk/internal/toolinternal/toolutils"
 	"google.golang.org/adk/memory"
 	"google.golang.org/adk/model"
@@ -99,6 +100,29 @@ type Context interface {
 	//   - error: If there was a failure in initiating the confirmation process itself (e.g., invalid
 	//     arguments, issue with the event system). The request to ask the user has not been sent.
 	RequestConfirmation(hint string, payload any) error
+
+	// Credential returns the credential of cfg for the user: the raw
+	// credential of cfg if it can be used as is, the credential stored for
+	// the user, refreshed if expired, or the credential the client returned
+	// for a previous RequestCredential of this call, exchanged and stored.
+	// It returns nil if the user has to provide the credential, see
+	// RequestCredential.
+	//
+	// Example Usage:
+	// cred, err := ctx.Credential(cfg)
+	// if err != nil {
+	//     return nil, err
+	// }
+	// if cred == nil {
+	//     return nil, ctx.RequestCredential(cfg)
+	// }
+	Credential(cfg auth.Config) (*auth.Credential, error)
+
+	// RequestCredential asks the client for the credential of cfg, e.g. to
+	// let the user authorize the tool with OAuth2. The ADK emits an
+	// auth.FunctionCallName function call and the tool is called again
+	// once the client responds, when Credential returns the credential.
+	RequestCredential(cfg auth.Config) error
 }
 
 // Toolset is an interface for a collection of tools. It allows grouping
@@ -22,6 +22,7 @@ import (
 	"google.golang.org/genai"
 
 	"google.golang.org/adk/agent"
+	"google.golang.org/adk/auth"
 	"google.golang.org/adk/internal/toolinternal"
 	"google.golang.org/adk/memory"
 	"google.golang.org/adk/session"
@@ -128,6 +129,14 @@ func (c *testContext) RequestConfirmation(string, any) error {
 	return nil
 }
 
+func (c *testContext) Credential(auth.Config) (*auth.Credential, error) {
+	return nil, nil
+}
+
+func (c *testContext) RequestCredential(auth.Config) error {
+	return nil
+}
+
 func (c *testContext) Actions() *session.EventActions {
 	if c.eventActions == nil {
 		c.eventActions = &session.EventActions{}
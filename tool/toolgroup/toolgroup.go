@@ -0,0 +1,276 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toolgroup provides a tool presenting groups of tools to the model
+// on demand, to keep the number of function declarations low for the agents
+// with many tools.
+//
+// The model is first given a single "describe group" meta-tool, whose
+// description lists the groups. When the model calls it with a group, the
+// tools of the group are described in the response and their declarations
+// are sent with the following requests of the session, their names
+// prefixed with the name of the group:
+//
+//	groups, err := toolgroup.New(toolgroup.Config{
+//		Groups: []toolgroup.Group{
+//			{Name: "github", Description: "Issues and pull requests.", Toolsets: []tool.Toolset{githubTools}},
+//			{Name: "calendar", Description: "Events and availability.", Tools: calendarTools},
+//		},
+//	})
+//	agent, err := llmagent.New(llmagent.Config{..., Tools: []tool.Tool{groups}})
+package toolgroup
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/internal/toolinternal"
+	"google.golang.org/adk/internal/toolinternal/toolutils"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// ExpandedKeyPrefix prefixes the session state keys marking the groups
+// described to the model, whose tools are declared in the requests, e.g.
+// "adk_tool_group_expanded:github".
+const ExpandedKeyPrefix = "adk_tool_group_expanded:"
+
+// Config configures the tool groups.
+type Config struct {
+	// Name of the meta-tool describing a group. Defaults to
+	// "describe_tool_group".
+	Name string // optional
+	// Groups of tools presented to the model.
+	Groups []Group
+	// Separator joins the name of a group and the names of its tools.
+	// Defaults to "__", the names of the tools being restricted to letters,
+	// digits, underscores and dashes by some model providers.
+	Separator string // optional
+}
+
+// Group is a named group of tools.
+type Group struct {
+	// Name of the group, the prefix of the names of its tools.
+	Name string
+	// Description tells the model what the tools of the group are for.
+	Description string
+	// Tools of the group. They must be function tools, e.g. created with
+	// functiontool.New.
+	Tools []tool.Tool
+	// Toolsets whose function tools are added to the group.
+	Toolsets []tool.Toolset // optional
+}
+
+// New creates the meta-tool describing the groups of cfg. The meta-tool is
+// the only tool of the groups to be added to the agent.
+//
+// The request processing of the tools of the groups is not run, e.g. the
+// instructions added by a tool are not sent: the tools are declared with
+// their function declarations only.
+func New(cfg Config) (tool.Tool, error) {
+	if len(cfg.Groups) == 0 {
+		return nil, errors.New("at least one group is required")
+	}
+	if cfg.Name == "" {
+		cfg.Name = "describe_tool_group"
+	}
+	if cfg.Separator == "" {
+		cfg.Separator = "__"
+	}
+	seen := make(map[string]bool)
+	for _, g := range cfg.Groups {
+		if g.Name == "" {
+			return nil, errors.New("group name is required")
+		}
+		if seen[g.Name] {
+			return nil, fmt.Errorf("duplicate group: %q", g.Name)
+		}
+		seen[g.Name] = true
+		for _, t := range g.Tools {
+			if _, ok := t.(toolinternal.FunctionTool); !ok {
+				return nil, fmt.Errorf("tool %q of group %q is not a function tool", t.Name(), g.Name)
+			}
+		}
+	}
+	return &describeGroupTool{cfg: cfg}, nil
+}
+
+// describeGroupTool is the meta-tool describing a group. It declares the
+// tools of the described groups and dispatches the calls of all the tools.
+type describeGroupTool struct {
+	cfg Config
+}
+
+var _ toolinternal.FunctionTool = (*describeGroupTool)(nil)
+
+// Name implements tool.Tool.
+func (t *describeGroupTool) Name() string {
+	return t.cfg.Name
+}
+
+// Description implements tool.Tool.
+func (t *describeGroupTool) Description() string {
+	var b strings.Builder
+	b.WriteString("Describes the tools of a group and makes them available. Call it before using the tools of a group. The groups are:\n")
+	for _, g := range t.cfg.Groups {
+		fmt.Fprintf(&b, "- %s: %s\n", g.Name, g.Description)
+	}
+	return b.String()
+}
+
+// IsLongRunning implements tool.Tool.
+func (t *describeGroupTool) IsLongRunning() bool {
+	return false
+}
+
+// Declaration implements toolinternal.FunctionTool.
+func (t *describeGroupTool) Declaration() *genai.FunctionDeclaration {
+	names := make([]string, 0, len(t.cfg.Groups))
+	for _, g := range t.cfg.Groups {
+		names = append(names, g.Name)
+	}
+	return &genai.FunctionDeclaration{
+		Name:        t.cfg.Name,
+		Description: t.Description(),
+		Parameters: &genai.Schema{
+			Type: genai.TypeObject,
+			Properties: map[string]*genai.Schema{
+				"group": {
+					Type:        genai.TypeString,
+					Description: "The name of the group.",
+					Enum:        names,
+				},
+			},
+			Required: []string{"group"},
+		},
+	}
+}
+
+// Run implements toolinternal.FunctionTool. It returns the tools of the
+// group and records the group as expanded.
+func (t *describeGroupTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	m, ok := args.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected args type, got: %T", args)
+	}
+	name, _ := m["group"].(string)
+	i := slices.IndexFunc(t.cfg.Groups, func(g Group) bool { return g.Name == name })
+	if i < 0 {
+		names := make([]string, 0, len(t.cfg.Groups))
+		for _, g := range t.cfg.Groups {
+			names = append(names, g.Name)
+		}
+		return nil, fmt.Errorf("unknown group %q, the groups are: %s", name, strings.Join(names, ", "))
+	}
+	members, err := t.members(ctx, t.cfg.Groups[i])
+	if err != nil {
+		return nil, err
+	}
+	tools := make([]map[string]any, 0, len(members))
+	for _, m := range members {
+		tools = append(tools, map[string]any{"name": m.Name(), "description": m.Description()})
+	}
+	// A key per group, for the groups described by parallel calls not to
+	// overwrite each other.
+	ctx.Actions().StateDelta[ExpandedKeyPrefix+name] = true
+	return map[string]any{"group": name, "tools": tools}, nil
+}
+
+// ProcessRequest declares the meta-tool and the tools of the expanded
+// groups. The tools of all the groups are registered, so that their calls
+// are dispatched even if their group was not described in this session.
+func (t *describeGroupTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	if err := toolutils.PackTool(req, t); err != nil {
+		return err
+	}
+	for _, g := range t.cfg.Groups {
+		members, err := t.members(ctx, g)
+		if err != nil {
+			return err
+		}
+		declare := expanded(ctx, g.Name)
+		for _, m := range members {
+			if !declare {
+				if _, ok := req.Tools[m.Name()]; ok {
+					return fmt.Errorf("duplicate tool: %q", m.Name())
+				}
+				req.Tools[m.Name()] = m
+				continue
+			}
+			if err := toolutils.PackTool(req, m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// members returns the function tools of the group, named with the prefix of
+// the group.
+func (t *describeGroupTool) members(ctx agent.ReadonlyContext, g Group) ([]*groupedTool, error) {
+	tools := slices.Clone(g.Tools)
+	for _, ts := range g.Toolsets {
+		tsTools, err := ts.Tools(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract tools from the tool set %q of group %q: %w", ts.Name(), g.Name, err)
+		}
+		tools = append(tools, tsTools...)
+	}
+	members := make([]*groupedTool, 0, len(tools))
+	for _, tl := range tools {
+		ft, ok := tl.(toolinternal.FunctionTool)
+		if !ok {
+			continue
+		}
+		members = append(members, &groupedTool{FunctionTool: ft, name: g.Name + t.cfg.Separator + ft.Name()})
+	}
+	return members, nil
+}
+
+// expanded reports whether the group was described in the session.
+func expanded(ctx agent.ReadonlyContext, group string) bool {
+	v, err := ctx.ReadonlyState().Get(ExpandedKeyPrefix + group)
+	if err != nil {
+		return false
+	}
+	b, _ := v.(bool)
+	return b
+}
+
+// groupedTool is a tool of a group, named with the prefix of the group.
+type groupedTool struct {
+	toolinternal.FunctionTool
+	name string
+}
+
+// Name implements tool.Tool.
+func (t *groupedTool) Name() string {
+	return t.name
+}
+
+// Declaration implements toolinternal.FunctionTool.
+func (t *groupedTool) Declaration() *genai.FunctionDeclaration {
+	decl := t.FunctionTool.Declaration()
+	if decl == nil {
+		return nil
+	}
+	renamed := *decl
+	renamed.Name = t.name
+	return &renamed
+}
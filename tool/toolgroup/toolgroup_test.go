@@ -0,0 +1,153 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolgroup_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/internal/testutil"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+	"google.golang.org/adk/tool/geminitool"
+	"google.golang.org/adk/tool/toolgroup"
+)
+
+type numbers struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+type result struct {
+	Result int `json:"result"`
+}
+
+func newTool(t *testing.T, name string, fn func(a, b int) int) tool.Tool {
+	t.Helper()
+	tl, err := functiontool.New(functiontool.Config{Name: name, Description: name + "s two numbers"},
+		func(ctx tool.Context, args numbers) (result, error) {
+			return result{Result: fn(args.A, args.B)}, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tl
+}
+
+func declaredFunctions(req *model.LLMRequest) []string {
+	var names []string
+	for _, tl := range req.Config.Tools {
+		for _, decl := range tl.FunctionDeclarations {
+			names = append(names, decl.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestGroups(t *testing.T) {
+	groups, err := toolgroup.New(toolgroup.Config{
+		Groups: []toolgroup.Group{
+			{
+				Name:        "math",
+				Description: "Arithmetic.",
+				Tools: []tool.Tool{
+					newTool(t, "add", func(a, b int) int { return a + b }),
+					newTool(t, "multiply", func(a, b int) int { return a * b }),
+				},
+			},
+			{
+				Name:        "text",
+				Description: "Text processing.",
+				Tools:       []tool.Tool{newTool(t, "concat", func(a, b int) int { return a*10 + b })},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockModel := &testutil.MockModel{Responses: []*genai.Content{
+		genai.NewContentFromFunctionCall("describe_tool_group", map[string]any{"group": "math"}, genai.RoleModel),
+		genai.NewContentFromFunctionCall("math__add", map[string]any{"a": 1, "b": 2}, genai.RoleModel),
+		genai.NewContentFromText("3", genai.RoleModel),
+	}}
+	a, err := llmagent.New(llmagent.Config{Name: "agent", Model: mockModel, Tools: []tool.Tool{groups}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := testutil.CollectEvents(testutil.NewTestAgentRunner(t, a).Run(t, "session", "1 + 2?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mockModel.Requests) != 3 {
+		t.Fatalf("model was called %d times, want 3", len(mockModel.Requests))
+	}
+	wantDeclared := [][]string{
+		{"describe_tool_group"},
+		{"describe_tool_group", "math__add", "math__multiply"},
+		{"describe_tool_group", "math__add", "math__multiply"},
+	}
+	for i, req := range mockModel.Requests {
+		if diff := cmp.Diff(wantDeclared[i], declaredFunctions(req)); diff != "" {
+			t.Errorf("request %d declared functions mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	var responses []map[string]any
+	for _, ev := range events {
+		for _, part := range ev.Content.Parts {
+			if part.FunctionResponse != nil {
+				responses = append(responses, part.FunctionResponse.Response)
+			}
+		}
+	}
+	want := []map[string]any{
+		{"group": "math", "tools": []map[string]any{
+			{"name": "math__add", "description": "adds two numbers"},
+			{"name": "math__multiply", "description": "multiplys two numbers"},
+		}},
+		{"result": 3.0},
+	}
+	if diff := cmp.Diff(want, responses); diff != "" {
+		t.Errorf("function responses mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	add := newTool(t, "add", func(a, b int) int { return a + b })
+	tests := []struct {
+		name string
+		cfg  toolgroup.Config
+	}{
+		{name: "no groups", cfg: toolgroup.Config{}},
+		{name: "unnamed group", cfg: toolgroup.Config{Groups: []toolgroup.Group{{Tools: []tool.Tool{add}}}}},
+		{name: "duplicate group", cfg: toolgroup.Config{Groups: []toolgroup.Group{{Name: "math"}, {Name: "math"}}}},
+		{name: "not a function tool", cfg: toolgroup.Config{Groups: []toolgroup.Group{{Name: "search", Tools: []tool.Tool{geminitool.GoogleSearch{}}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := toolgroup.New(tt.cfg); err == nil {
+				t.Error("New() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolselect
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/history"
+)
+
+// SimilarityConfig configures the Similarity selector.
+type SimilarityConfig struct {
+	// Embedder computes the embeddings of the query and of the tools, e.g.
+	// history.GenAIEmbedder.
+	Embedder history.Embedder
+	// TopK is the number of tools selected, the most similar to the query,
+	// besides the AlwaysInclude ones.
+	TopK int
+	// AlwaysInclude are the names of the tools selected whatever their
+	// relevance, e.g. the tools the instruction of the agent refers to.
+	AlwaysInclude []string // optional
+}
+
+// Similarity returns the selector keeping the tools most relevant to the
+// query: the cosine similarity of the embeddings of the query and of the
+// names and descriptions of the tools ranks the tools, of which the TopK
+// best are selected with the AlwaysInclude ones, in the order of the
+// declarations. All the tools are selected if there are no more than TopK
+// of them to rank, or if the query has no text.
+//
+// The embeddings of the tools are computed once and cached by the selector.
+func Similarity(cfg SimilarityConfig) Selector {
+	s := &similarity{cfg: cfg, embeddings: make(map[string][]float32)}
+	return SelectorFunc(s.selectTools)
+}
+
+type similarity struct {
+	cfg SimilarityConfig
+
+	mu         sync.Mutex
+	embeddings map[string][]float32 // by tool text
+}
+
+func (s *similarity) selectTools(ctx context.Context, query string, tools []*genai.FunctionDeclaration) ([]*genai.FunctionDeclaration, error) {
+	var ranked []int
+	for i, tool := range tools {
+		if !slices.Contains(s.cfg.AlwaysInclude, tool.Name) {
+			ranked = append(ranked, i)
+		}
+	}
+	if len(ranked) <= s.cfg.TopK || query == "" {
+		return tools, nil
+	}
+
+	texts := make([]string, len(ranked))
+	for j, i := range ranked {
+		texts[j] = toolText(tools[i])
+	}
+	embeddings, err := s.embed(ctx, query, texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed the tools: %w", err)
+	}
+	scores := make(map[int]float64, len(ranked))
+	for j, i := range ranked {
+		scores[i] = cosine(embeddings[0], embeddings[j+1])
+	}
+
+	// The first declared tools win the ties.
+	slices.SortStableFunc(ranked, func(a, b int) int { return cmp.Compare(scores[b], scores[a]) })
+	selected := make(map[int]bool)
+	for _, i := range ranked[:max(s.cfg.TopK, 0)] {
+		selected[i] = true
+	}
+	var result []*genai.FunctionDeclaration
+	for i, tool := range tools {
+		if selected[i] || slices.Contains(s.cfg.AlwaysInclude, tool.Name) {
+			result = append(result, tool)
+		}
+	}
+	return result, nil
+}
+
+// embed returns the embeddings of the query and of the texts of the tools,
+// embedding the texts not cached yet with the query.
+func (s *similarity) embed(ctx context.Context, query string, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts)+1)
+	missing := []string{query}
+	var indices []int
+	s.mu.Lock()
+	for i, text := range texts {
+		if e, ok := s.embeddings[text]; ok {
+			embeddings[i+1] = e
+		} else {
+			missing = append(missing, text)
+			indices = append(indices, i+1)
+		}
+	}
+	s.mu.Unlock()
+
+	computed, err := s.cfg.Embedder.Embed(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	if len(computed) != len(missing) {
+		return nil, fmt.Errorf("embedder returned %d embeddings for %d texts", len(computed), len(missing))
+	}
+	embeddings[0] = computed[0]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for j, i := range indices {
+		embeddings[i] = computed[j+1]
+		s.embeddings[texts[i-1]] = computed[j+1]
+	}
+	return embeddings, nil
+}
+
+// toolText returns the text embedded for the tool.
+func toolText(tool *genai.FunctionDeclaration) string {
+	if tool.Description == "" {
+		return tool.Name
+	}
+	return tool.Name + ": " + tool.Description
+}
+
+// cosine returns the cosine similarity of the vectors, 0 if one of them is
+// null.
+func cosine(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range min(len(a), len(b)) {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolselect
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"google.golang.org/adk/history"
+)
+
+// keywordEmbedder embeds the texts on the axes of the keywords they contain,
+// and counts the embedded texts.
+func keywordEmbedder(embedded *int, keywords ...string) history.EmbedderFunc {
+	return func(ctx context.Context, texts []string) ([][]float32, error) {
+		*embedded += len(texts)
+		embeddings := make([][]float32, len(texts))
+		for i, text := range texts {
+			embeddings[i] = make([]float32, len(keywords))
+			for j, keyword := range keywords {
+				if strings.Contains(strings.ToLower(text), keyword) {
+					embeddings[i][j] = 1
+				}
+			}
+		}
+		return embeddings, nil
+	}
+}
+
+var tools = []*genai.FunctionDeclaration{
+	{Name: "book_hotel", Description: "Books a hotel room."},
+	{Name: "get_weather", Description: "Returns the weather forecast of a city."},
+	{Name: "cancel_hotel", Description: "Cancels a hotel booking."},
+	{Name: "send_email", Description: "Sends an email."},
+}
+
+func names(decls []*genai.FunctionDeclaration) []string {
+	var names []string
+	for _, decl := range decls {
+		names = append(names, decl.Name)
+	}
+	return names
+}
+
+func TestSimilarity(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		cfg   SimilarityConfig
+		query string
+		want  []string
+	}{
+		{
+			name:  "top k",
+			cfg:   SimilarityConfig{TopK: 2},
+			query: "Find me a hotel in Paris.",
+			want:  []string{"book_hotel", "cancel_hotel"},
+		},
+		{
+			name:  "always include",
+			cfg:   SimilarityConfig{TopK: 1, AlwaysInclude: []string{"send_email"}},
+			query: "Will it rain? What's the weather?",
+			want:  []string{"get_weather", "send_email"},
+		},
+		{
+			name:  "few tools",
+			cfg:   SimilarityConfig{TopK: 3, AlwaysInclude: []string{"send_email"}},
+			query: "Find me a hotel in Paris.",
+			want:  []string{"book_hotel", "get_weather", "cancel_hotel", "send_email"},
+		},
+		{
+			name:  "no query",
+			cfg:   SimilarityConfig{TopK: 1},
+			query: "",
+			want:  []string{"book_hotel", "get_weather", "cancel_hotel", "send_email"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var embedded int
+			tt.cfg.Embedder = keywordEmbedder(&embedded, "hotel", "weather", "email")
+			got, err := Similarity(tt.cfg).Select(t.Context(), tt.query, tools)
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, names(got)); diff != "" {
+				t.Errorf("Select() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSimilarity_CachesToolEmbeddings(t *testing.T) {
+	var embedded int
+	s := Similarity(SimilarityConfig{Embedder: keywordEmbedder(&embedded, "hotel", "weather", "email"), TopK: 1})
+	for _, query := range []string{"Book a hotel.", "What's the weather?"} {
+		if _, err := s.Select(t.Context(), query, tools); err != nil {
+			t.Fatalf("Select() error = %v", err)
+		}
+	}
+	if want := len(tools) + 2; embedded != want {
+		t.Errorf("embedded %d texts, want %d: the tools once and each query", embedded, want)
+	}
+}
+
+func TestSimilarity_EmbedderError(t *testing.T) {
+	s := Similarity(SimilarityConfig{
+		Embedder: history.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+			return nil, errors.New("quota exceeded")
+		}),
+		TopK: 1,
+	})
+	if _, err := s.Select(t.Context(), "Book a hotel.", tools); err == nil {
+		t.Error("Select() succeeded, want the error of the embedder")
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toolselect provides selectors of the tools declared to the model,
+// to reduce the cost of the requests and improve the accuracy of the calls of
+// the agents with many tools, see llmagent.Config.ToolSelector.
+//
+// The selectors choose among the function declarations of the tools of the
+// agent. The tools not selected are not declared to the model, but remain
+// callable, e.g. if the model calls a tool it used earlier in the
+// conversation.
+package toolselect
+
+import (
+	"context"
+
+	"google.golang.org/genai"
+)
+
+// Selector selects the tools declared to the model.
+type Selector interface {
+	// Select returns the declarations of the tools relevant to the query,
+	// the text of the user message of the invocation. The declarations must
+	// not be modified.
+	Select(ctx context.Context, query string, tools []*genai.FunctionDeclaration) ([]*genai.FunctionDeclaration, error)
+}
+
+// SelectorFunc is a function implementing Selector.
+type SelectorFunc func(ctx context.Context, query string, tools []*genai.FunctionDeclaration) ([]*genai.FunctionDeclaration, error)
+
+// Select implements Selector.
+func (f SelectorFunc) Select(ctx context.Context, query string, tools []*genai.FunctionDeclaration) ([]*genai.FunctionDeclaration, error) {
+	return f(ctx, query, tools)
+}